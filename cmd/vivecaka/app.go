@@ -4,13 +4,22 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/muesli/termenv"
 
+	"github.com/indrasvat/vivecaka/internal/adapter/cassette"
+	"github.com/indrasvat/vivecaka/internal/adapter/ghapi"
 	"github.com/indrasvat/vivecaka/internal/adapter/ghcli"
+	"github.com/indrasvat/vivecaka/internal/adapter/gitea"
+	"github.com/indrasvat/vivecaka/internal/adapter/gitlab"
 	"github.com/indrasvat/vivecaka/internal/config"
+	"github.com/indrasvat/vivecaka/internal/domain"
 	"github.com/indrasvat/vivecaka/internal/logging"
+	"github.com/indrasvat/vivecaka/internal/plugin"
+	"github.com/indrasvat/vivecaka/internal/plugin/external"
+	"github.com/indrasvat/vivecaka/internal/plugin/shellhook"
 	"github.com/indrasvat/vivecaka/internal/tui"
 )
 
@@ -43,20 +52,45 @@ func runApp(opts cliOptions) error {
 		"repo_override", repoOverride,
 	)
 
-	adapter := ghcli.New()
+	var adapter backend
+	var replayer *cassette.Replayer
+	if opts.replayDir != "" {
+		replayer = cassette.NewReplayer(opts.replayDir)
+		adapter = replayer
+	} else {
+		adapter = newBackend(cfg.General.Backend, cfg)
+	}
 	if err := adapter.Check(); err != nil {
 		return err
 	}
 
-	appOptions := []tui.Option{
-		tui.WithVersion(version),
-		tui.WithReader(adapter),
-		tui.WithReviewer(adapter),
-		tui.WithWriter(adapter),
-		tui.WithRepoManager(adapter),
+	// Capabilities are discovered by the registry, so a backend only has to
+	// implement the domain interfaces it supports.
+	registry := plugin.NewRegistry()
+	// External plugins register ahead of the backend, so a plugin that
+	// provides a capability is the one the TUI gets.
+	host := registerExternalPlugins(registry)
+	defer host.Close()
+	if len(cfg.Hooks.Commands()) > 0 {
+		if err := registry.Register(shellhook.New(cfg.Hooks)); err != nil {
+			return fmt.Errorf("registering shell hooks: %w", err)
+		}
 	}
-	if opts.repo.Owner != "" {
-		appOptions = append(appOptions, tui.WithRepo(opts.repo))
+	if err := registerBackend(registry, opts.recordDir, adapter); err != nil {
+		return err
+	}
+	var routes []plugin.Route
+	if replayer == nil {
+		routes = registerRouteBackends(registry, cfg, adapter)
+	}
+
+	appOptions := append([]tui.Option{tui.WithVersion(version), tui.WithPlugins(registry)}, capabilityOptions(registry, routes)...)
+	repo := opts.repo
+	if repo.Owner == "" && replayer != nil {
+		repo = replayer.Repo()
+	}
+	if repo.Owner != "" {
+		appOptions = append(appOptions, tui.WithRepo(repo))
 	}
 
 	app := tui.New(cfg, appOptions...)
@@ -68,6 +102,7 @@ func runApp(opts cliOptions) error {
 	defer resetTerminalBackground(os.Stdout)
 
 	p := tea.NewProgram(app, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	app.SetSender(p.Send)
 	_, runErr := p.Run()
 
 	logging.Log.Info("shutting down", "error", runErr)
@@ -79,6 +114,150 @@ func runApp(opts cliOptions) error {
 	return nil
 }
 
+// backend is a PR host adapter that can verify its credentials at startup.
+type backend interface {
+	plugin.Plugin
+	Check() error
+}
+
+// newBackend returns the adapter for a general.backend value.
+func newBackend(kind string, cfg *config.Config) backend {
+	switch kind {
+	case "api":
+		return ghapi.New()
+	case "gitlab":
+		return gitlab.New(gitlab.WithBaseURL(cfg.GitLab.URL))
+	case "gitea":
+		return gitea.New(gitea.WithBaseURL(cfg.Gitea.URL))
+	default:
+		return ghcli.New()
+	}
+}
+
+// registerBackend registers the backend. With a record directory, a
+// cassette recorder wrapping the backend's reader is registered as its
+// reader, so every read that reaches the backend is recorded.
+func registerBackend(registry *plugin.Registry, recordDir string, adapter backend) error {
+	reader, ok := adapter.(domain.PRReader)
+	if recordDir != "" {
+		if !ok {
+			return fmt.Errorf("%s backend cannot read PRs to record", adapter.Info().Name)
+		}
+		rec, err := cassette.NewRecorder(recordDir, reader)
+		if err != nil {
+			return fmt.Errorf("starting recording: %w", err)
+		}
+		reader = rec
+	}
+	if err := registry.RegisterWithReader(adapter, reader); err != nil {
+		return fmt.Errorf("registering %s backend: %w", adapter.Info().Name, err)
+	}
+	return nil
+}
+
+// registerExternalPlugins starts the executables in the plugins directory
+// and registers them. Plugins that fail to start or register are logged and
+// skipped: they never keep vivecaka from starting.
+func registerExternalPlugins(registry *plugin.Registry) *external.Host {
+	host, err := external.Discover(external.Dir(), version)
+	if err != nil {
+		logging.Log.Warn("loading external plugins", "dir", external.Dir(), "err", err)
+	}
+	for _, p := range host.Plugins() {
+		if err := registry.Register(p); err != nil {
+			logging.Log.Warn("registering external plugin", "plugin", p.Info().Name, "err", err)
+		}
+	}
+	return host
+}
+
+// registerRouteBackends registers the built-in backends that [[routes]]
+// name besides the selected one, and resolves every route to the plugin
+// name of its backend. A backend that fails its check is logged and
+// skipped, so its repos fall back to the other readers.
+func registerRouteBackends(registry *plugin.Registry, cfg *config.Config, adapter backend) []plugin.Route {
+	// Routes name built-in backends by their general.backend value and
+	// external plugins by plugin name.
+	names := map[string]string{cfg.General.Backend: adapter.Info().Name}
+	for _, p := range registry.Plugins() {
+		names[p.Info().Name] = p.Info().Name
+	}
+	routes := make([]plugin.Route, 0, len(cfg.Routes))
+	for _, rc := range cfg.Routes {
+		name, ok := names[rc.Backend]
+		switch {
+		case ok:
+		case slices.Contains([]string{"gh", "api", "gitlab", "gitea"}, rc.Backend):
+			b := newBackend(rc.Backend, cfg)
+			name = b.Info().Name
+			if err := b.Check(); err != nil {
+				logging.Log.Warn("skipping routed backend", "backend", rc.Backend, "err", err)
+			} else if err := registry.Register(b); err != nil {
+				logging.Log.Warn("registering routed backend", "backend", rc.Backend, "err", err)
+			}
+		default:
+			name = rc.Backend // an external plugin that did not load
+		}
+		names[rc.Backend] = name
+		routes = append(routes, plugin.Route{Pattern: rc.Repos, Backend: name})
+	}
+	return routes
+}
+
+// capabilityOptions injects the registered implementation of each domain
+// capability into the TUI. With several backends, a router sends each
+// repo's reads and writes to the backend its route names, failing reads
+// over between backends for the repo's host. The router offers every
+// optional capability; when the repo's backend lacks one, the call fails
+// with errors.ErrUnsupported and the TUI reports it as not supported.
+func capabilityOptions(registry *plugin.Registry, routes []plugin.Route) []tui.Option {
+	backends := registry.Backends()
+	var (
+		reader   domain.PRReader
+		reviewer domain.PRReviewer
+		writer   domain.PRWriter
+		manager  domain.RepoManager
+	)
+	switch len(backends) {
+	case 0:
+		return nil
+	case 1:
+		b := backends[0]
+		reader, reviewer, writer, manager = b.Reader, b.Reviewer, b.Writer, b.RepoManager
+	default:
+		router := plugin.NewRouter(backends, routes)
+		for _, b := range backends {
+			if b.Reader != nil {
+				reader = router
+			}
+			if b.Reviewer != nil {
+				reviewer = router
+			}
+			if b.Writer != nil {
+				writer = router
+			}
+			if b.RepoManager != nil {
+				manager = router
+			}
+		}
+	}
+
+	var opts []tui.Option
+	if reader != nil {
+		opts = append(opts, tui.WithReader(reader))
+	}
+	if reviewer != nil {
+		opts = append(opts, tui.WithReviewer(reviewer))
+	}
+	if writer != nil {
+		opts = append(opts, tui.WithWriter(writer))
+	}
+	if manager != nil {
+		opts = append(opts, tui.WithRepoManager(manager))
+	}
+	return opts
+}
+
 func setTerminalBackground(w io.Writer) {
 	output := termenv.NewOutput(w)
 	output.SetBackgroundColor(termenv.RGBColor(terminalBackgroundHex))
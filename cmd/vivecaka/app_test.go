@@ -6,6 +6,13 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/indrasvat/vivecaka/internal/adapter/cassette"
+	"github.com/indrasvat/vivecaka/internal/adapter/ghcli"
+	"github.com/indrasvat/vivecaka/internal/adapter/gitea"
+	"github.com/indrasvat/vivecaka/internal/config"
+	"github.com/indrasvat/vivecaka/internal/plugin"
 )
 
 func TestSetTerminalBackgroundEmitsOSC11(t *testing.T) {
@@ -28,3 +35,52 @@ func TestResetTerminalBackgroundEmitsOSC111(t *testing.T) {
 
 	assert.Equal(t, resetBackgroundOSC, buf.String())
 }
+
+func TestNewBackendSelectsAdapter(t *testing.T) {
+	cfg := config.Default()
+	assert.Equal(t, "ghcli", newBackend(cfg.General.Backend, cfg).Info().Name)
+
+	for backend, name := range map[string]string{"api": "github-api", "gitlab": "gitlab", "gitea": "gitea"} {
+		assert.Equal(t, name, newBackend(backend, cfg).Info().Name, "backend %q", backend)
+	}
+}
+
+func TestCapabilityOptionsFromRegistry(t *testing.T) {
+	registry := plugin.NewRegistry()
+	assert.Empty(t, capabilityOptions(registry, nil))
+
+	require.NoError(t, registry.Register(gitea.New()))
+	assert.Len(t, capabilityOptions(registry, nil), 4)
+}
+
+func TestRegisterRouteBackends(t *testing.T) {
+	cfg := config.Default()
+	cfg.Gitea.URL = "https://forge.example.com"
+	cfg.Routes = []config.RouteConfig{
+		{Repos: "forge.example.com", Backend: "gitea"},
+		{Repos: "github.com/acme/*", Backend: "gh"},
+		{Repos: "ghe.example.com", Backend: "my-plugin"},
+	}
+	registry := plugin.NewRegistry()
+	primary := ghcli.New()
+	require.NoError(t, registry.Register(primary))
+
+	routes := registerRouteBackends(registry, cfg, primary)
+	assert.Equal(t, []plugin.Route{
+		{Pattern: "forge.example.com", Backend: "gitea"},
+		{Pattern: "github.com/acme/*", Backend: "ghcli"},
+		{Pattern: "ghe.example.com", Backend: "my-plugin"},
+	}, routes)
+}
+
+func TestRegisterBackendRecordsInPlaceOfItsReader(t *testing.T) {
+	registry := plugin.NewRegistry()
+	backend := gitea.New()
+	require.NoError(t, registerBackend(registry, t.TempDir(), backend))
+
+	backends := registry.Backends()
+	require.Len(t, backends, 1, "the recorder is not a second reader to fail over from")
+	assert.Equal(t, "gitea", backends[0].Name)
+	assert.IsType(t, &cassette.Recorder{}, backends[0].Reader)
+	assert.Same(t, backend, registry.GetReviewers()[0])
+}
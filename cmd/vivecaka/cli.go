@@ -23,6 +23,8 @@ type cliOptions struct {
 	repo        domain.RepoRef
 	repoSource  string
 	showVersion bool
+	recordDir   string
+	replayDir   string
 }
 
 type cliEnvDefaults struct {
@@ -85,7 +87,10 @@ func newRootCommand(envDefaults cliEnvDefaults, stdout, stderr io.Writer, run fu
 	if opts.repo.Owner != "" {
 		repoValue.set = true
 	}
-	cmd.Flags().Var(&repoValue, "repo", "Start in a specific repository (owner/name)")
+	cmd.Flags().Var(&repoValue, "repo", "Start in a specific repository ([host/]owner/name)")
+	cmd.Flags().StringVar(&opts.recordDir, "record", "", "Record PR responses to a cassette directory")
+	cmd.Flags().StringVar(&opts.replayDir, "replay", "", "Replay PR responses from a cassette directory (offline)")
+	cmd.MarkFlagsMutuallyExclusive("record", "replay")
 
 	return cmd
 }
@@ -111,12 +116,7 @@ func parseBoolEnv(value string) bool {
 }
 
 func parseRepoRef(value string) (domain.RepoRef, error) {
-	repo := strings.TrimSpace(value)
-	owner, name, found := strings.Cut(repo, "/")
-	if !found || owner == "" || name == "" || strings.Contains(name, "/") {
-		return domain.RepoRef{}, fmt.Errorf("expected owner/name, got %q", value)
-	}
-	return domain.RepoRef{Owner: owner, Name: name}, nil
+	return domain.ParseRepoRef(value)
 }
 
 type repoFlagValue struct {
@@ -151,7 +151,7 @@ func (v *repoFlagValue) Set(value string) error {
 	return nil
 }
 
-func (v *repoFlagValue) Type() string { return "owner/name" }
+func (v *repoFlagValue) Type() string { return "[host/]owner/name" }
 
 func helpTheme() core.Theme {
 	cfg, err := config.Load()
@@ -213,6 +213,8 @@ func (r cliHelpRenderer) Render(cmd *cobra.Command) string {
 		sectionStyle.Render("Examples"),
 		textStyle.Render("  vivecaka"),
 		textStyle.Render("  vivecaka --repo indrasvat/vivecaka"),
+		textStyle.Render("  vivecaka --record ./session"),
+		textStyle.Render("  vivecaka --replay ./session"),
 		textStyle.Render("  vivecaka --debug"),
 		textStyle.Render("  vivecaka --help"),
 		"",
@@ -221,10 +223,12 @@ func (r cliHelpRenderer) Render(cmd *cobra.Command) string {
 		renderRow("-v, --version", "Show version information"),
 		renderRow("-d, --debug", "Enable debug logging"),
 		renderRow("--repo owner/name", "Start in a specific repository"),
+		renderRow("--record dir", "Record PR responses to a cassette"),
+		renderRow("--replay dir", "Replay a cassette offline (no gh needed)"),
 		"",
 		sectionStyle.Render("Environment"),
 		renderRow(debugEnvVar, "Enable debug logging when set to 1/true"),
-		renderRow(repoEnvVar, "Default repository override (owner/name)"),
+		renderRow(repoEnvVar, "Default repository override ([host/]owner/name)"),
 	}
 
 	if r.opts.repo.Owner != "" {
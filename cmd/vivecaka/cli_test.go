@@ -7,6 +7,8 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
 )
 
 func TestParseRepoRef(t *testing.T) {
@@ -18,6 +20,27 @@ func TestParseRepoRef(t *testing.T) {
 	assert.Equal(t, "vivecaka", repo.Name)
 }
 
+func TestParseRepoRefNestedNamespace(t *testing.T) {
+	t.Parallel()
+
+	repo, err := parseRepoRef("group/subgroup/project")
+	require.NoError(t, err)
+	assert.Equal(t, "group/subgroup", repo.Owner)
+	assert.Equal(t, "project", repo.Name)
+
+	_, err = parseRepoRef("group//project")
+	require.Error(t, err)
+}
+
+func TestParseRepoRefEnterpriseHost(t *testing.T) {
+	t.Parallel()
+
+	repo, err := parseRepoRef("ghe.example.com/org/repo")
+	require.NoError(t, err)
+	assert.Equal(t, domain.RepoRef{Host: "ghe.example.com", Owner: "org", Name: "repo"}, repo)
+	assert.Equal(t, "ghe.example.com/org/repo", repo.String())
+}
+
 func TestParseRepoRefRejectsInvalidValue(t *testing.T) {
 	t.Parallel()
 
@@ -94,6 +117,28 @@ func TestRootCommandFlagOverridesEnvRepo(t *testing.T) {
 	assert.Equal(t, "flag", received.repoSource)
 }
 
+func TestRootCommandPassesCassetteDirs(t *testing.T) {
+	t.Parallel()
+
+	cmd, _, _, called, received := newTestRootCommand(cliEnvDefaults{})
+	cmd.SetArgs([]string{"--replay", "./session"})
+
+	require.NoError(t, cmd.Execute())
+	assert.True(t, *called)
+	assert.Equal(t, "./session", received.replayDir)
+	assert.Empty(t, received.recordDir)
+}
+
+func TestRootCommandRejectsRecordWithReplay(t *testing.T) {
+	t.Parallel()
+
+	cmd, _, _, called, _ := newTestRootCommand(cliEnvDefaults{})
+	cmd.SetArgs([]string{"--record", "a", "--replay", "b"})
+
+	require.Error(t, cmd.Execute())
+	assert.False(t, *called)
+}
+
 func TestRootCommandRejectsUnexpectedArgs(t *testing.T) {
 	t.Parallel()
 
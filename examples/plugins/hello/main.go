@@ -0,0 +1,136 @@
+// Command hello is a reference vivecaka plugin. It speaks the external plugin
+// protocol (docs/PLUGINS.md) with the standard library only: it counts the
+// PRs of every list fetch, and pressing H on the PR list shows a toast
+// with the last count.
+//
+// Install it with
+//
+//	go build -o ~/.local/share/vivecaka/plugins/hello ./examples/plugins/hello
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+)
+
+const protocolVersion = 1
+
+// message is a JSON-RPC 2.0 request, response or notification.
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      *int64          `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type repo struct {
+	Owner string `json:"owner"`
+	Name  string `json:"name"`
+}
+
+type hookParams struct {
+	Point string `json:"point"`
+	Event struct {
+		Repo  repo              `json:"repo"`
+		PRs   []json.RawMessage `json:"prs"`
+		Error string            `json:"error"`
+	} `json:"event"`
+}
+
+type keyParams struct {
+	Action string `json:"action"`
+	Repo   repo   `json:"repo"`
+}
+
+func main() {
+	// stderr ends up in vivecaka's debug log.
+	log.SetFlags(0)
+	if err := serve(os.Stdin, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// serve answers requests until the host sends shutdown or closes stdin.
+func serve(r io.Reader, w io.Writer) error {
+	in := bufio.NewScanner(r)
+	in.Buffer(make([]byte, 64<<10), 16<<20)
+	out := json.NewEncoder(w) // Encode writes one message per line
+	lastCount := -1
+
+	for in.Scan() {
+		var req message
+		if err := json.Unmarshal(in.Bytes(), &req); err != nil {
+			return fmt.Errorf("reading request: %w", err)
+		}
+		if req.ID == nil {
+			continue // hello expects no notifications from the host
+		}
+		resp := message{JSONRPC: "2.0", ID: req.ID}
+
+		switch req.Method {
+		case "initialize":
+			resp.Result = map[string]any{
+				"protocol_version": protocolVersion,
+				"name":             "hello",
+				"version":          "1.0.0",
+				"description":      "Counts listed PRs",
+				"hooks":            []string{"after_fetch"},
+				"keys": []map[string]string{
+					{"key": "H", "view": "pr_list", "action": "count", "help": "hello"},
+				},
+			}
+		case "hook":
+			var p hookParams
+			if err := json.Unmarshal(req.Params, &p); err != nil {
+				resp.Error = &rpcError{Code: -32602, Message: err.Error()}
+				break
+			}
+			if p.Point == "after_fetch" && p.Event.Error == "" {
+				lastCount = len(p.Event.PRs)
+				log.Printf("fetched %d PRs from %s/%s", lastCount, p.Event.Repo.Owner, p.Event.Repo.Name)
+			}
+			resp.Result = struct{}{}
+		case "key":
+			var p keyParams
+			if err := json.Unmarshal(req.Params, &p); err != nil {
+				resp.Error = &rpcError{Code: -32602, Message: err.Error()}
+				break
+			}
+			if lastCount < 0 {
+				// Notifications may be sent at any time, not only as answers.
+				if err := out.Encode(message{
+					JSONRPC: "2.0",
+					Method:  "notify",
+					Params:  json.RawMessage(`{"message":"hello: nothing fetched yet","level":"warning"}`),
+				}); err != nil {
+					return err
+				}
+				resp.Result = struct{}{}
+				break
+			}
+			resp.Result = map[string]string{
+				"message": fmt.Sprintf("Hello! %s/%s lists %d PRs", p.Repo.Owner, p.Repo.Name, lastCount),
+			}
+		case "shutdown":
+			resp.Result = struct{}{}
+			return out.Encode(resp)
+		default:
+			resp.Error = &rpcError{Code: -32601, Message: "method not found: " + req.Method}
+		}
+		if err := out.Encode(resp); err != nil {
+			return err
+		}
+	}
+	return in.Err()
+}
@@ -0,0 +1,133 @@
+// Package cassette records PR host responses to a directory and replays
+// them later without network access or a gh binary.
+//
+// A cassette directory holds a cassette.json manifest plus one JSON file per
+// recorded call, grouped by repo:
+//
+//	cassette.json
+//	owner_name/list-3f2a9c1b7d4e.json
+//	owner_name/pr-42.json
+//	owner_name/diff-42.json
+//
+// Each file keeps the request next to the response so a cassette attached
+// to a bug report can be read without tooling.
+package cassette
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+const (
+	manifestName  = "cassette.json"
+	formatVersion = 1
+)
+
+// manifest describes a cassette as a whole.
+type manifest struct {
+	Version    int            `json:"version"`
+	Repo       domain.RepoRef `json:"repo"`
+	RecordedAt time.Time      `json:"recorded_at"`
+}
+
+// entry is one recorded call.
+type entry struct {
+	Method     string           `json:"method"`
+	Repo       domain.RepoRef   `json:"repo"`
+	Number     int              `json:"number,omitempty"`
+	Opts       *domain.ListOpts `json:"opts,omitempty"`
+	State      domain.PRState   `json:"state,omitempty"`
+	RecordedAt time.Time        `json:"recorded_at"`
+	Response   json.RawMessage  `json:"response"`
+}
+
+// listName keys a ListPRs recording by a hash of its options, so every
+// filter and page combination gets its own file.
+func listName(opts domain.ListOpts) string {
+	raw, _ := json.Marshal(opts)
+	sum := sha256.Sum256(raw)
+	return "list-" + hex.EncodeToString(sum[:6])
+}
+
+func countName(state domain.PRState) string {
+	if state == "" {
+		return "count-all"
+	}
+	return "count-" + string(state)
+}
+
+func prName(kind string, number int) string {
+	return fmt.Sprintf("%s-%d", kind, number)
+}
+
+func entryPath(dir string, repo domain.RepoRef, name string) string {
+	return filepath.Join(dir, repo.SafeFilename(), name+".json")
+}
+
+// writeJSON writes v to path atomically. Recordings for different PRs are
+// written concurrently, so each write gets its own temp file.
+func writeJSON(path string, v any) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create cassette dir: %w", err)
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal recording: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".rec-*")
+	if err != nil {
+		return fmt.Errorf("write recording: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write recording: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write recording: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func readManifest(dir string) (manifest, error) {
+	var m manifest
+	raw, err := os.ReadFile(filepath.Join(dir, manifestName))
+	if err != nil {
+		return m, fmt.Errorf("read cassette manifest: %w", err)
+	}
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return m, fmt.Errorf("unmarshal cassette manifest: %w", err)
+	}
+	if m.Version != formatVersion {
+		return m, fmt.Errorf("unsupported cassette version %d", m.Version)
+	}
+	return m, nil
+}
+
+// readEntry decodes the recorded response for name into out. A missing
+// recording is reported as domain.ErrNotFound.
+func readEntry(dir string, repo domain.RepoRef, name string, out any) error {
+	raw, err := os.ReadFile(entryPath(dir, repo, name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%w: no recording of %s for %s", domain.ErrNotFound, name, repo)
+		}
+		return fmt.Errorf("read recording: %w", err)
+	}
+	var e entry
+	if err := json.Unmarshal(raw, &e); err != nil {
+		return fmt.Errorf("unmarshal recording %s: %w", name, err)
+	}
+	if err := json.Unmarshal(e.Response, out); err != nil {
+		return fmt.Errorf("unmarshal recording %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package cassette
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/plugin"
+)
+
+var testRepo = domain.RepoRef{Owner: "owner", Name: "repo"}
+
+// fakeReader serves canned data and counts calls.
+type fakeReader struct {
+	prs    []domain.PR
+	detail *domain.PRDetail
+	diff   *domain.Diff
+	checks []domain.Check
+	thread []domain.CommentThread
+	items  []domain.DiscussionItem
+	count  int
+	err    error
+	calls  int
+}
+
+func (f *fakeReader) ListPRs(context.Context, domain.RepoRef, domain.ListOpts) ([]domain.PR, error) {
+	f.calls++
+	return f.prs, f.err
+}
+
+func (f *fakeReader) GetPR(context.Context, domain.RepoRef, int) (*domain.PRDetail, error) {
+	f.calls++
+	return f.detail, f.err
+}
+
+func (f *fakeReader) GetDiff(context.Context, domain.RepoRef, int) (*domain.Diff, error) {
+	f.calls++
+	return f.diff, f.err
+}
+
+func (f *fakeReader) GetChecks(context.Context, domain.RepoRef, int) ([]domain.Check, error) {
+	f.calls++
+	return f.checks, f.err
+}
+
+func (f *fakeReader) GetComments(context.Context, domain.RepoRef, int) ([]domain.CommentThread, error) {
+	f.calls++
+	return f.thread, f.err
+}
+
+func (f *fakeReader) GetDiscussion(context.Context, domain.RepoRef, int) ([]domain.DiscussionItem, error) {
+	f.calls++
+	return f.items, f.err
+}
+
+func (f *fakeReader) GetPRCount(context.Context, domain.RepoRef, domain.PRState) (int, error) {
+	f.calls++
+	return f.count, f.err
+}
+
+func sampleReader() *fakeReader {
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	pr := domain.PR{Number: 42, Title: "Add cassettes", Author: "alice", State: domain.PRStateOpen, CreatedAt: created, UpdatedAt: created}
+	return &fakeReader{
+		prs:    []domain.PR{pr},
+		detail: &domain.PRDetail{PR: pr, Body: "body", Checks: []domain.Check{{Name: "ci", Status: domain.CIPass, Duration: 90 * time.Second}}},
+		diff: &domain.Diff{Files: []domain.FileDiff{{Path: "main.go", Hunks: []domain.Hunk{{
+			Header: "@@ -1 +1 @@",
+			Lines:  []domain.DiffLine{{Type: domain.DiffAdd, Content: "+x", NewNum: 1}},
+		}}}}},
+		checks: []domain.Check{{Name: "ci", Status: domain.CIPass}},
+		thread: []domain.CommentThread{{ID: "1", Path: "main.go", Line: 1, Comments: []domain.Comment{{ID: "1", Author: "bob", Body: "nit", CreatedAt: created}}}},
+		items:  []domain.DiscussionItem{{ID: "r1", Kind: domain.DiscussionReview, CreatedAt: created}},
+		count:  7,
+	}
+}
+
+func TestRecordThenReplay(t *testing.T) {
+	ctx := t.Context()
+	dir := t.TempDir()
+	inner := sampleReader()
+	opts := domain.ListOpts{State: domain.PRStateOpen, PerPage: 50}
+
+	rec, err := NewRecorder(dir, inner)
+	require.NoError(t, err)
+	_, err = rec.ListPRs(ctx, testRepo, opts)
+	require.NoError(t, err)
+	_, err = rec.GetPR(ctx, testRepo, 42)
+	require.NoError(t, err)
+	_, err = rec.GetDiff(ctx, testRepo, 42)
+	require.NoError(t, err)
+	_, err = rec.GetChecks(ctx, testRepo, 42)
+	require.NoError(t, err)
+	_, err = rec.GetComments(ctx, testRepo, 42)
+	require.NoError(t, err)
+	_, err = rec.GetDiscussion(ctx, testRepo, 42)
+	require.NoError(t, err)
+	_, err = rec.GetPRCount(ctx, testRepo, domain.PRStateOpen)
+	require.NoError(t, err)
+
+	rep := NewReplayer(dir)
+	require.NoError(t, rep.Check())
+	assert.Equal(t, testRepo, rep.Repo())
+
+	prs, err := rep.ListPRs(ctx, testRepo, opts)
+	require.NoError(t, err)
+	assert.Equal(t, inner.prs, prs)
+
+	detail, err := rep.GetPR(ctx, testRepo, 42)
+	require.NoError(t, err)
+	assert.Equal(t, inner.detail, detail)
+
+	diff, err := rep.GetDiff(ctx, testRepo, 42)
+	require.NoError(t, err)
+	assert.Equal(t, inner.diff, diff)
+
+	checks, err := rep.GetChecks(ctx, testRepo, 42)
+	require.NoError(t, err)
+	assert.Equal(t, inner.checks, checks)
+
+	threads, err := rep.GetComments(ctx, testRepo, 42)
+	require.NoError(t, err)
+	assert.Equal(t, inner.thread, threads)
+
+	items, err := rep.GetDiscussion(ctx, testRepo, 42)
+	require.NoError(t, err)
+	assert.Equal(t, inner.items, items)
+
+	count, err := rep.GetPRCount(ctx, testRepo, domain.PRStateOpen)
+	require.NoError(t, err)
+	assert.Equal(t, 7, count)
+}
+
+func TestReplayMissingRecordingIsNotFound(t *testing.T) {
+	dir := t.TempDir()
+	_, err := NewRecorder(dir, sampleReader())
+	require.NoError(t, err)
+
+	rep := NewReplayer(dir)
+	require.NoError(t, rep.Check())
+
+	_, err = rep.GetPR(t.Context(), testRepo, 99)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	// Different list options are a different recording.
+	_, err = rep.ListPRs(t.Context(), testRepo, domain.ListOpts{Page: 2})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestRecorderSkipsFailedCalls(t *testing.T) {
+	dir := t.TempDir()
+	inner := sampleReader()
+	inner.err = errors.New("boom")
+
+	rec, err := NewRecorder(dir, inner)
+	require.NoError(t, err)
+	_, err = rec.GetPR(t.Context(), testRepo, 42)
+	require.Error(t, err)
+
+	_, err = os.Stat(entryPath(dir, testRepo, prName("pr", 42)))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestRecordingsAreKeyedByHost(t *testing.T) {
+	dir := t.TempDir()
+	rec, err := NewRecorder(dir, sampleReader())
+	require.NoError(t, err)
+
+	ghes := domain.RepoRef{Host: "ghe.example.com", Owner: "owner", Name: "repo"}
+	_, err = rec.GetPR(t.Context(), ghes, 42)
+	require.NoError(t, err)
+
+	rep := NewReplayer(dir)
+	require.NoError(t, rep.Check())
+	_, err = rep.GetPR(t.Context(), ghes, 42)
+	require.NoError(t, err)
+	_, err = rep.GetPR(t.Context(), testRepo, 42)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestReplayerCheckRequiresManifest(t *testing.T) {
+	err := NewReplayer(filepath.Join(t.TempDir(), "missing")).Check()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cassette")
+}
+
+func TestReplayerAcceptsReviews(t *testing.T) {
+	rep := NewReplayer(t.TempDir())
+	assert.NoError(t, rep.SubmitReview(t.Context(), testRepo, 1, domain.Review{Action: domain.ReviewActionApprove}))
+	assert.NoError(t, rep.AddComment(t.Context(), testRepo, 1, domain.InlineCommentInput{Body: "x"}))
+	assert.NoError(t, rep.ResolveThread(t.Context(), testRepo, "t1"))
+}
+
+func TestRegistryDiscoversCassetteCapabilities(t *testing.T) {
+	rec, err := NewRecorder(t.TempDir(), sampleReader())
+	require.NoError(t, err)
+
+	reg := plugin.NewRegistry()
+	require.NoError(t, reg.Register(rec))
+	require.NoError(t, reg.Register(NewReplayer(t.TempDir())))
+
+	assert.Len(t, reg.GetReaders(), 2)
+	assert.Len(t, reg.GetReviewers(), 1, "the recorder wraps reads only")
+}
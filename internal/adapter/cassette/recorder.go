@@ -0,0 +1,166 @@
+package cassette
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/logging"
+	"github.com/indrasvat/vivecaka/internal/plugin"
+)
+
+// Compile-time checks that Recorder satisfies its capabilities.
+var (
+	_ plugin.Plugin   = (*Recorder)(nil)
+	_ domain.PRReader = (*Recorder)(nil)
+)
+
+// Recorder wraps a PRReader and writes every successful response to a
+// cassette directory. Failed calls pass through unrecorded.
+type Recorder struct {
+	dir   string
+	inner domain.PRReader
+
+	mu       sync.Mutex
+	manifest manifest
+}
+
+// NewRecorder creates dir if needed and starts a cassette that records
+// inner's responses. Recording into an existing cassette adds to it.
+func NewRecorder(dir string, inner domain.PRReader) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create cassette dir: %w", err)
+	}
+	r := &Recorder{dir: dir, inner: inner}
+	if m, err := readManifest(dir); err == nil {
+		r.manifest = m
+		return r, nil
+	}
+	r.manifest = manifest{Version: formatVersion, RecordedAt: time.Now()}
+	if err := writeJSON(filepath.Join(dir, manifestName), r.manifest); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Info returns plugin metadata.
+func (r *Recorder) Info() plugin.PluginInfo {
+	return plugin.PluginInfo{
+		Name:        "cassette-recorder",
+		Version:     "1.0.0",
+		Description: "Records PR responses to a cassette directory",
+		Provides:    []string{"pr-reader"},
+	}
+}
+
+// Init satisfies the plugin.Plugin interface.
+func (r *Recorder) Init(_ plugin.AppContext) tea.Cmd {
+	return nil
+}
+
+// ListPRs records the PR list for repo and opts.
+func (r *Recorder) ListPRs(ctx context.Context, repo domain.RepoRef, opts domain.ListOpts) ([]domain.PR, error) {
+	prs, err := r.inner.ListPRs(ctx, repo, opts)
+	if err != nil {
+		return nil, err
+	}
+	r.record(listName(opts), entry{Method: "ListPRs", Repo: repo, Opts: &opts}, prs)
+	return prs, nil
+}
+
+// GetPR records a PR's detail.
+func (r *Recorder) GetPR(ctx context.Context, repo domain.RepoRef, number int) (*domain.PRDetail, error) {
+	detail, err := r.inner.GetPR(ctx, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	r.record(prName("pr", number), entry{Method: "GetPR", Repo: repo, Number: number}, detail)
+	return detail, nil
+}
+
+// GetDiff records a PR's diff.
+func (r *Recorder) GetDiff(ctx context.Context, repo domain.RepoRef, number int) (*domain.Diff, error) {
+	diff, err := r.inner.GetDiff(ctx, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	r.record(prName("diff", number), entry{Method: "GetDiff", Repo: repo, Number: number}, diff)
+	return diff, nil
+}
+
+// GetChecks records a PR's CI checks.
+func (r *Recorder) GetChecks(ctx context.Context, repo domain.RepoRef, number int) ([]domain.Check, error) {
+	checks, err := r.inner.GetChecks(ctx, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	r.record(prName("checks", number), entry{Method: "GetChecks", Repo: repo, Number: number}, checks)
+	return checks, nil
+}
+
+// GetComments records a PR's inline comment threads.
+func (r *Recorder) GetComments(ctx context.Context, repo domain.RepoRef, number int) ([]domain.CommentThread, error) {
+	threads, err := r.inner.GetComments(ctx, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	r.record(prName("comments", number), entry{Method: "GetComments", Repo: repo, Number: number}, threads)
+	return threads, nil
+}
+
+// GetDiscussion records a PR's discussion items.
+func (r *Recorder) GetDiscussion(ctx context.Context, repo domain.RepoRef, number int) ([]domain.DiscussionItem, error) {
+	items, err := r.inner.GetDiscussion(ctx, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	r.record(prName("discussion", number), entry{Method: "GetDiscussion", Repo: repo, Number: number}, items)
+	return items, nil
+}
+
+// GetPRCount records the PR count for repo and state.
+func (r *Recorder) GetPRCount(ctx context.Context, repo domain.RepoRef, state domain.PRState) (int, error) {
+	count, err := r.inner.GetPRCount(ctx, repo, state)
+	if err != nil {
+		return 0, err
+	}
+	r.record(countName(state), entry{Method: "GetPRCount", Repo: repo, State: state}, count)
+	return count, nil
+}
+
+// record writes one entry. A failed write only costs that recording, so
+// it is logged rather than failing a read that succeeded.
+func (r *Recorder) record(name string, e entry, response any) {
+	r.noteRepo(e.Repo)
+
+	raw, err := json.Marshal(response)
+	if err == nil {
+		e.Response = raw
+		e.RecordedAt = time.Now()
+		err = writeJSON(entryPath(r.dir, e.Repo, name), e)
+	}
+	if err != nil {
+		logging.Log.Warn("cassette: recording failed", "method", e.Method, "repo", e.Repo.String(), "err", err)
+	}
+}
+
+// noteRepo stores the first recorded repo in the manifest so replay can
+// start there without --repo.
+func (r *Recorder) noteRepo(repo domain.RepoRef) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.manifest.Repo.Owner != "" {
+		return
+	}
+	r.manifest.Repo = repo
+	if err := writeJSON(filepath.Join(r.dir, manifestName), r.manifest); err != nil {
+		logging.Log.Warn("cassette: updating manifest failed", "err", err)
+	}
+}
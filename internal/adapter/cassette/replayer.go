@@ -0,0 +1,141 @@
+package cassette
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/plugin"
+)
+
+// Compile-time checks that Replayer satisfies its capabilities.
+var (
+	_ plugin.Plugin     = (*Replayer)(nil)
+	_ domain.PRReader   = (*Replayer)(nil)
+	_ domain.PRReviewer = (*Replayer)(nil)
+)
+
+// Replayer serves recorded responses from a cassette directory. It never
+// touches the network or gh. Calls that were not recorded return
+// domain.ErrNotFound; review submissions are accepted and discarded so
+// review flows can be demoed.
+type Replayer struct {
+	dir      string
+	manifest manifest
+}
+
+// NewReplayer creates a Replayer for dir. Call Check before use.
+func NewReplayer(dir string) *Replayer {
+	return &Replayer{dir: dir}
+}
+
+// Info returns plugin metadata.
+func (r *Replayer) Info() plugin.PluginInfo {
+	return plugin.PluginInfo{
+		Name:        "cassette-replay",
+		Version:     "1.0.0",
+		Description: "Replays PR responses from a cassette directory",
+		Provides:    []string{"pr-reader", "pr-reviewer"},
+	}
+}
+
+// Check loads the cassette manifest.
+func (r *Replayer) Check() error {
+	m, err := readManifest(r.dir)
+	if err != nil {
+		return fmt.Errorf("cassette %s: %w", r.dir, err)
+	}
+	r.manifest = m
+	return nil
+}
+
+// Init satisfies the plugin.Plugin interface.
+func (r *Replayer) Init(_ plugin.AppContext) tea.Cmd {
+	return nil
+}
+
+// Repo returns the first repo recorded in the cassette, or the zero
+// RepoRef if nothing was recorded.
+func (r *Replayer) Repo() domain.RepoRef {
+	return r.manifest.Repo
+}
+
+// ListPRs replays the PR list recorded for repo and opts.
+func (r *Replayer) ListPRs(_ context.Context, repo domain.RepoRef, opts domain.ListOpts) ([]domain.PR, error) {
+	var prs []domain.PR
+	if err := readEntry(r.dir, repo, listName(opts), &prs); err != nil {
+		return nil, err
+	}
+	return prs, nil
+}
+
+// GetPR replays a PR's detail.
+func (r *Replayer) GetPR(_ context.Context, repo domain.RepoRef, number int) (*domain.PRDetail, error) {
+	var detail domain.PRDetail
+	if err := readEntry(r.dir, repo, prName("pr", number), &detail); err != nil {
+		return nil, err
+	}
+	return &detail, nil
+}
+
+// GetDiff replays a PR's diff.
+func (r *Replayer) GetDiff(_ context.Context, repo domain.RepoRef, number int) (*domain.Diff, error) {
+	var diff domain.Diff
+	if err := readEntry(r.dir, repo, prName("diff", number), &diff); err != nil {
+		return nil, err
+	}
+	return &diff, nil
+}
+
+// GetChecks replays a PR's CI checks.
+func (r *Replayer) GetChecks(_ context.Context, repo domain.RepoRef, number int) ([]domain.Check, error) {
+	var checks []domain.Check
+	if err := readEntry(r.dir, repo, prName("checks", number), &checks); err != nil {
+		return nil, err
+	}
+	return checks, nil
+}
+
+// GetComments replays a PR's inline comment threads.
+func (r *Replayer) GetComments(_ context.Context, repo domain.RepoRef, number int) ([]domain.CommentThread, error) {
+	var threads []domain.CommentThread
+	if err := readEntry(r.dir, repo, prName("comments", number), &threads); err != nil {
+		return nil, err
+	}
+	return threads, nil
+}
+
+// GetDiscussion replays a PR's discussion items.
+func (r *Replayer) GetDiscussion(_ context.Context, repo domain.RepoRef, number int) ([]domain.DiscussionItem, error) {
+	var items []domain.DiscussionItem
+	if err := readEntry(r.dir, repo, prName("discussion", number), &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetPRCount replays the PR count for repo and state.
+func (r *Replayer) GetPRCount(_ context.Context, repo domain.RepoRef, state domain.PRState) (int, error) {
+	var count int
+	if err := readEntry(r.dir, repo, countName(state), &count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// SubmitReview accepts and discards the review.
+func (r *Replayer) SubmitReview(context.Context, domain.RepoRef, int, domain.Review) error {
+	return nil
+}
+
+// AddComment accepts and discards the comment.
+func (r *Replayer) AddComment(context.Context, domain.RepoRef, int, domain.InlineCommentInput) error {
+	return nil
+}
+
+// ResolveThread accepts and discards the resolution.
+func (r *Replayer) ResolveThread(context.Context, domain.RepoRef, string) error {
+	return nil
+}
@@ -0,0 +1,78 @@
+package ghapi
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/indrasvat/vivecaka/internal/adapter/githubql"
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// ghHostEntry is the per-host section of gh's hosts.yml.
+// Newer gh versions nest per-account tokens under "users".
+type ghHostEntry struct {
+	User       string `yaml:"user"`
+	OAuthToken string `yaml:"oauth_token"`
+	Users      map[string]struct {
+		OAuthToken string `yaml:"oauth_token"`
+	} `yaml:"users"`
+}
+
+// ResolveToken finds an API token for host using the same precedence as gh:
+// GH_TOKEN / GITHUB_TOKEN (or the GH_ENTERPRISE_TOKEN pair for non-github.com
+// hosts), then the oauth_token stored in gh's hosts.yml.
+func ResolveToken(host string) (string, error) {
+	envVars := []string{"GH_TOKEN", "GITHUB_TOKEN"}
+	if host != defaultHost {
+		envVars = []string{"GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"}
+	}
+	for _, name := range envVars {
+		if v := strings.TrimSpace(os.Getenv(name)); v != "" {
+			return v, nil
+		}
+	}
+
+	token, err := tokenFromHostsFile(filepath.Join(githubql.ConfigDir(), "hosts.yml"), host)
+	if err != nil {
+		return "", err
+	}
+	if token == "" {
+		return "", fmt.Errorf("%w: no token for %s (set GH_TOKEN, or export GH_TOKEN=$(gh auth token) if gh stores it in the keyring)",
+			domain.ErrNotAuthenticated, host)
+	}
+	return token, nil
+}
+
+// tokenFromHostsFile reads the oauth token for host from a gh hosts.yml file.
+// A missing file is not an error; it yields an empty token.
+func tokenFromHostsFile(path, host string) (string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", fmt.Errorf("reading gh hosts file: %w", err)
+	}
+
+	var hosts map[string]ghHostEntry
+	if err := yaml.Unmarshal(raw, &hosts); err != nil {
+		return "", fmt.Errorf("parsing gh hosts file: %w", err)
+	}
+
+	entry, ok := hosts[host]
+	if !ok {
+		return "", nil
+	}
+	if entry.OAuthToken != "" {
+		return entry.OAuthToken, nil
+	}
+	if u, ok := entry.Users[entry.User]; ok {
+		return u.OAuthToken, nil
+	}
+	return "", nil
+}
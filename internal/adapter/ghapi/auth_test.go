@@ -0,0 +1,72 @@
+package ghapi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+func clearTokenEnv(t *testing.T) {
+	t.Helper()
+	for _, name := range []string{"GH_TOKEN", "GITHUB_TOKEN", "GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"} {
+		t.Setenv(name, "")
+	}
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+}
+
+func TestResolveTokenEnvPrecedence(t *testing.T) {
+	clearTokenEnv(t)
+	t.Setenv("GITHUB_TOKEN", "from-github-token")
+	t.Setenv("GH_TOKEN", "from-gh-token")
+
+	token, err := ResolveToken("github.com")
+	require.NoError(t, err)
+	assert.Equal(t, "from-gh-token", token)
+}
+
+func TestResolveTokenEnterpriseEnv(t *testing.T) {
+	clearTokenEnv(t)
+	t.Setenv("GH_TOKEN", "public")
+	t.Setenv("GH_ENTERPRISE_TOKEN", "enterprise")
+
+	token, err := ResolveToken("ghe.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "enterprise", token)
+}
+
+func TestResolveTokenFromHostsFile(t *testing.T) {
+	clearTokenEnv(t)
+	dir := os.Getenv("GH_CONFIG_DIR")
+	hosts := `github.com:
+    user: alice
+    git_protocol: https
+    users:
+        alice:
+            oauth_token: gho_nested
+ghe.example.com:
+    oauth_token: gho_flat
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "hosts.yml"), []byte(hosts), 0o600))
+
+	token, err := ResolveToken("github.com")
+	require.NoError(t, err)
+	assert.Equal(t, "gho_nested", token)
+
+	token, err = ResolveToken("ghe.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "gho_flat", token)
+}
+
+func TestResolveTokenMissing(t *testing.T) {
+	clearTokenEnv(t)
+
+	_, err := ResolveToken("github.com")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrNotAuthenticated)
+	assert.Contains(t, err.Error(), "GH_TOKEN")
+}
@@ -0,0 +1,339 @@
+package ghapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/indrasvat/vivecaka/internal/adapter/githubql"
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+const (
+	acceptJSON = "application/vnd.github+json"
+	acceptDiff = "application/vnd.github.v3.diff"
+	apiVersion = "2022-11-28"
+)
+
+// apiError is a non-2xx REST response.
+type apiError struct {
+	StatusCode int
+	Message    string
+	err        error
+}
+
+func (e *apiError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("github api: HTTP %d", e.StatusCode)
+	}
+	return fmt.Sprintf("github api: HTTP %d: %s", e.StatusCode, e.Message)
+}
+
+func (e *apiError) Unwrap() error { return e.err }
+
+// graphqlError is one entry of a GraphQL "errors" array.
+type graphqlError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// sleep waits for d or until ctx is done. Tests replace it to skip waits.
+var sleep = func(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// retryRateLimited runs fn, retrying with backoff while it fails with a
+// rate limit short enough to wait out. Only use it for calls that are safe
+// to repeat.
+func retryRateLimited(ctx context.Context, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		var rle *domain.RateLimitError
+		if err == nil || !errors.As(err, &rle) {
+			return err
+		}
+		delay, ok := rle.RetryDelay(time.Now(), attempt)
+		if !ok || sleep(ctx, delay) != nil {
+			return err
+		}
+	}
+}
+
+// doRequest performs an authenticated API request and returns the response
+// body. GET requests are retried on short rate limits. path is relative to
+// the REST root unless it is an absolute URL.
+func (a *Adapter) doRequest(ctx context.Context, method, path string, body any, accept string) ([]byte, error) {
+	if method != http.MethodGet {
+		return a.doRequestOnce(ctx, method, path, body, accept)
+	}
+	var out []byte
+	err := retryRateLimited(ctx, func() error {
+		var err error
+		out, err = a.doRequestOnce(ctx, method, path, body, accept)
+		return err
+	})
+	return out, err
+}
+
+func (a *Adapter) doRequestOnce(ctx context.Context, method, path string, body any, accept string) ([]byte, error) {
+	token, err := a.authToken()
+	if err != nil {
+		return nil, err
+	}
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("encoding request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	url := path
+	if !strings.HasPrefix(path, "http://") && !strings.HasPrefix(path, "https://") {
+		url = a.restURL + "/" + strings.TrimPrefix(path, "/")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return nil, fmt.Errorf("building request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", accept)
+	req.Header.Set("X-GitHub-Api-Version", apiVersion)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("github api: %w", err)
+		}
+		return nil, fmt.Errorf("github api: %w", &domain.HostError{
+			Kind: domain.ErrNetwork, Message: err.Error(),
+			Remedy: "check your connection or https://www.githubstatus.com",
+		})
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	out, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, newAPIError(resp, out)
+	}
+	return out, nil
+}
+
+// newAPIError maps an HTTP error response to a domain-aware error.
+func newAPIError(resp *http.Response, body []byte) error {
+	var payload struct {
+		Message string `json:"message"`
+	}
+	_ = json.Unmarshal(body, &payload)
+
+	e := &apiError{StatusCode: resp.StatusCode, Message: payload.Message}
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		e.err = domain.ErrNotAuthenticated
+	case isRateLimited(resp, payload.Message):
+		e.err = newRateLimitError(resp, payload.Message)
+	default:
+		e.err = classifyResponse(resp, payload.Message)
+	}
+	return e
+}
+
+// classifyResponse maps a non-rate-limit error response to a domain error,
+// reading GitHub's SSO and OAuth scope headers for the remedy.
+func classifyResponse(resp *http.Response, message string) error {
+	if sso, ok := strings.CutPrefix(resp.Header.Get("X-GitHub-SSO"), "required; url="); ok {
+		return &domain.HostError{Kind: domain.ErrSSORequired, URL: sso, Remedy: "authorize the token at " + sso}
+	}
+	if scopes := missingScopes(resp.Header); len(scopes) > 0 {
+		return &domain.HostError{Kind: domain.ErrInsufficientScope, Scopes: scopes, Remedy: scopeRemedy(scopes[0])}
+	}
+	lower := strings.ToLower(message)
+	switch {
+	case strings.Contains(lower, "archived"):
+		return &domain.HostError{Kind: domain.ErrArchived, Remedy: "the repository is read-only until it is unarchived"}
+	case strings.Contains(lower, "is locked"):
+		return &domain.HostError{Kind: domain.ErrLocked, Remedy: "only collaborators can comment while the conversation is locked"}
+	case resp.StatusCode == http.StatusForbidden:
+		return domain.ErrUnauthorized
+	case resp.StatusCode == http.StatusNotFound:
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// scopeRemedy tells the user how to grant a token scope. Tokens usually come
+// from gh, so its refresh command is the quickest route.
+func scopeRemedy(scope string) string {
+	return "grant the token the " + scope + " scope (gh auth refresh -s " + scope + ")"
+}
+
+// missingScopes compares the scopes an endpoint accepts with those a classic
+// token carries. Fine-grained tokens report no scopes, so nothing is missing.
+func missingScopes(h http.Header) []string {
+	granted, accepted := h.Get("X-OAuth-Scopes"), h.Get("X-Accepted-OAuth-Scopes")
+	if granted == "" || accepted == "" {
+		return nil
+	}
+	have := make(map[string]bool)
+	for s := range strings.SplitSeq(granted, ",") {
+		have[strings.TrimSpace(s)] = true
+	}
+	var want []string
+	for s := range strings.SplitSeq(accepted, ",") {
+		s = strings.TrimSpace(s)
+		if have[s] {
+			return nil
+		}
+		if s != "" {
+			want = append(want, s)
+		}
+	}
+	return want
+}
+
+// isRateLimited reports whether an error response is a primary or
+// secondary rate limit. GitHub uses 403 for both, 429 for some secondary
+// limits.
+func isRateLimited(resp *http.Response, message string) bool {
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusForbidden:
+		return resp.Header.Get("X-RateLimit-Remaining") == "0" ||
+			resp.Header.Get("Retry-After") != "" ||
+			strings.Contains(strings.ToLower(message), "rate limit")
+	}
+	return false
+}
+
+// newRateLimitError reads the reset time and retry hint from GitHub's
+// rate-limit headers.
+func newRateLimitError(resp *http.Response, message string) *domain.RateLimitError {
+	e := &domain.RateLimitError{Message: message}
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		e.RetryAfter = time.Duration(secs) * time.Second
+	}
+	// An exhausted budget is a primary limit; anything else is secondary.
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			e.Reset = time.Unix(reset, 0)
+		}
+	} else {
+		e.Secondary = true
+	}
+	return e
+}
+
+// restJSON performs a REST request with an optional JSON body and decodes the
+// JSON response into dst (which may be nil).
+func (a *Adapter) restJSON(ctx context.Context, method, path string, body, dst any) error {
+	out, err := a.doRequest(ctx, method, path, body, acceptJSON)
+	if err != nil {
+		return err
+	}
+	if dst == nil || len(out) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(out, dst); err != nil {
+		return fmt.Errorf("parsing api response: %w", err)
+	}
+	return nil
+}
+
+// graphql runs a GraphQL query with variables and decodes the "data" field
+// into dst. Queries, unlike mutations, are retried on short rate limits.
+func (a *Adapter) graphql(ctx context.Context, query string, vars map[string]any, dst any) error {
+	if strings.HasPrefix(strings.TrimSpace(query), "mutation") {
+		return a.graphqlOnce(ctx, query, vars, dst)
+	}
+	return retryRateLimited(ctx, func() error {
+		return a.graphqlOnce(ctx, query, vars, dst)
+	})
+}
+
+func (a *Adapter) graphqlOnce(ctx context.Context, query string, vars map[string]any, dst any) error {
+	out, err := a.doRequestOnce(ctx, http.MethodPost, a.graphqlURL, map[string]any{
+		"query":     query,
+		"variables": vars,
+	}, acceptJSON)
+	if err != nil {
+		return err
+	}
+
+	var envelope struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []graphqlError  `json:"errors"`
+	}
+	if err := json.Unmarshal(out, &envelope); err != nil {
+		return fmt.Errorf("parsing graphql response: %w", err)
+	}
+	if len(envelope.Errors) > 0 {
+		return newGraphQLError(envelope.Errors)
+	}
+	if dst == nil || len(envelope.Data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(envelope.Data, dst); err != nil {
+		return fmt.Errorf("parsing graphql data: %w", err)
+	}
+	return nil
+}
+
+// newGraphQLError folds GraphQL errors into one error, wrapping the domain
+// sentinel for the first recognized error type.
+func newGraphQLError(errs []graphqlError) error {
+	msgs := make([]string, 0, len(errs))
+	var sentinel error
+	for _, e := range errs {
+		if e.Type == "RATE_LIMITED" {
+			// GraphQL rate limits arrive in a 200 response, so there are
+			// no headers to read the reset from.
+			return fmt.Errorf("github graphql: %w", &domain.RateLimitError{Message: e.Message})
+		}
+		msgs = append(msgs, e.Message)
+		if sentinel != nil {
+			continue
+		}
+		switch e.Type {
+		case "NOT_FOUND":
+			sentinel = domain.ErrNotFound
+		case "FORBIDDEN":
+			sentinel = domain.ErrUnauthorized
+		case "INSUFFICIENT_SCOPES":
+			he := &domain.HostError{Kind: domain.ErrInsufficientScope, Scopes: githubql.MissingScopes(e.Message)}
+			if len(he.Scopes) > 0 {
+				he.Remedy = scopeRemedy(he.Scopes[0])
+			}
+			sentinel = he
+		}
+	}
+	msg := strings.Join(msgs, "; ")
+	if sentinel != nil {
+		return fmt.Errorf("github graphql: %w: %s", sentinel, msg)
+	}
+	return fmt.Errorf("github graphql: %s", msg)
+}
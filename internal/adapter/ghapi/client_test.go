@@ -0,0 +1,346 @@
+package ghapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// newTestAdapter starts an httptest server with handler and returns an
+// adapter pointed at it with a fixed token.
+func newTestAdapter(t *testing.T, handler http.Handler) *Adapter {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return New(WithBaseURL(srv.URL), WithToken("test-token"))
+}
+
+// gqlRequest is the decoded body of a GraphQL POST.
+type gqlRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+func decodeGQL(t *testing.T, r *http.Request) gqlRequest {
+	t.Helper()
+	var req gqlRequest
+	body, err := io.ReadAll(r.Body)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(body, &req))
+	return req
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestCurrentUserSendsAuthHeaders(t *testing.T) {
+	a := newTestAdapter(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/user", r.URL.Path)
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		assert.Equal(t, apiVersion, r.Header.Get("X-GitHub-Api-Version"))
+		writeJSON(w, map[string]string{"login": "octocat"})
+	}))
+
+	login, err := a.CurrentUser(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "octocat", login)
+}
+
+// stubSleep replaces the retry sleep for the test and records each wait.
+func stubSleep(t *testing.T) *[]time.Duration {
+	t.Helper()
+	var waits []time.Duration
+	orig := sleep
+	sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	t.Cleanup(func() { sleep = orig })
+	return &waits
+}
+
+func TestRESTErrorMapping(t *testing.T) {
+	stubSleep(t)
+	tests := []struct {
+		name      string
+		status    int
+		remaining string
+		want      error
+	}{
+		{"unauthorized", http.StatusUnauthorized, "", domain.ErrNotAuthenticated},
+		{"forbidden", http.StatusForbidden, "10", domain.ErrUnauthorized},
+		{"rate limited 403", http.StatusForbidden, "0", domain.ErrRateLimited},
+		{"rate limited 429", http.StatusTooManyRequests, "", domain.ErrRateLimited},
+		{"not found", http.StatusNotFound, "", domain.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestAdapter(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if tt.remaining != "" {
+					w.Header().Set("X-RateLimit-Remaining", tt.remaining)
+				}
+				w.WriteHeader(tt.status)
+				writeJSON(w, map[string]string{"message": "nope"})
+			}))
+
+			_, err := a.CurrentUser(t.Context())
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tt.want)
+			assert.Contains(t, err.Error(), "nope")
+		})
+	}
+}
+
+func TestGraphQLErrorMapping(t *testing.T) {
+	a := newTestAdapter(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/graphql", r.URL.Path)
+		writeJSON(w, map[string]any{
+			"data": nil,
+			"errors": []map[string]string{
+				{"type": "NOT_FOUND", "message": "Could not resolve to a Repository"},
+			},
+		})
+	}))
+
+	_, err := a.GetPRCount(t.Context(), domain.RepoRef{Owner: "o", Name: "r"}, domain.PRStateOpen)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Contains(t, err.Error(), "Could not resolve")
+}
+
+func TestRESTErrorRemedies(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		status  int
+		message string
+		want    error
+		remedy  string
+	}{
+		{
+			name:    "sso",
+			headers: map[string]string{"X-GitHub-SSO": "required; url=https://github.com/orgs/acme/sso?authorization_request=abc"},
+			status:  http.StatusForbidden,
+			message: "Resource protected by organization SAML enforcement.",
+			want:    domain.ErrSSORequired,
+			remedy:  "authorize the token at https://github.com/orgs/acme/sso?authorization_request=abc",
+		},
+		{
+			name:    "missing scope",
+			headers: map[string]string{"X-OAuth-Scopes": "repo", "X-Accepted-OAuth-Scopes": "admin:org"},
+			status:  http.StatusNotFound,
+			message: "Not Found",
+			want:    domain.ErrInsufficientScope,
+			remedy:  "grant the token the admin:org scope (gh auth refresh -s admin:org)",
+		},
+		{
+			name:    "scope already granted",
+			headers: map[string]string{"X-OAuth-Scopes": "repo, read:org", "X-Accepted-OAuth-Scopes": "repo"},
+			status:  http.StatusNotFound,
+			message: "Not Found",
+			want:    domain.ErrNotFound,
+		},
+		{
+			name:    "archived",
+			status:  http.StatusForbidden,
+			message: "Repository was archived so is read-only.",
+			want:    domain.ErrArchived,
+		},
+		{
+			name:    "locked",
+			status:  http.StatusForbidden,
+			message: "Unable to create comment because issue is locked.",
+			want:    domain.ErrLocked,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestAdapter(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				for k, v := range tt.headers {
+					w.Header().Set(k, v)
+				}
+				w.WriteHeader(tt.status)
+				writeJSON(w, map[string]string{"message": tt.message})
+			}))
+
+			_, err := a.CurrentUser(t.Context())
+			assert.ErrorIs(t, err, tt.want)
+			assert.Contains(t, err.Error(), tt.message)
+			if tt.remedy != "" {
+				var he *domain.HostError
+				require.True(t, errors.As(err, &he))
+				assert.Equal(t, tt.remedy, he.Remedy)
+			}
+		})
+	}
+}
+
+func TestNetworkFailureIsClassified(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+	a := New(WithBaseURL(srv.URL), WithToken("test-token"))
+
+	_, err := a.CurrentUser(t.Context())
+	assert.ErrorIs(t, err, domain.ErrNetwork)
+}
+
+func TestGraphQLInsufficientScopes(t *testing.T) {
+	a := newTestAdapter(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{
+			"data": nil,
+			"errors": []map[string]string{{
+				"type":    "INSUFFICIENT_SCOPES",
+				"message": "The 'login' field requires one of the following scopes: ['read:org'], but your token has only been granted the: ['repo'] scopes.",
+			}},
+		})
+	}))
+
+	_, err := a.GetPRCount(t.Context(), domain.RepoRef{Owner: "o", Name: "r"}, domain.PRStateOpen)
+	assert.ErrorIs(t, err, domain.ErrInsufficientScope)
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+	var he *domain.HostError
+	require.True(t, errors.As(err, &he))
+	assert.Equal(t, []string{"read:org"}, he.Scopes)
+}
+
+func TestRateLimitErrorCarriesResetAndRetryAfter(t *testing.T) {
+	stubSleep(t)
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	a := newTestAdapter(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		w.WriteHeader(http.StatusForbidden)
+		writeJSON(w, map[string]string{"message": "API rate limit exceeded"})
+	}))
+	_, err := a.CurrentUser(t.Context())
+	var rle *domain.RateLimitError
+	require.True(t, errors.As(err, &rle))
+	assert.False(t, rle.Secondary)
+	assert.Equal(t, reset, rle.Reset)
+
+	a = newTestAdapter(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusForbidden)
+		writeJSON(w, map[string]string{"message": "You have exceeded a secondary rate limit"})
+	}))
+	_, err = a.CurrentUser(t.Context())
+	require.True(t, errors.As(err, &rle))
+	assert.True(t, rle.Secondary)
+	assert.Equal(t, 2*time.Minute, rle.RetryAfter)
+}
+
+func TestSecondaryRateLimitRetriesReads(t *testing.T) {
+	waits := stubSleep(t)
+	var calls atomic.Int32
+	a := newTestAdapter(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) <= 2 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			writeJSON(w, map[string]string{"message": "slow down"})
+			return
+		}
+		writeJSON(w, map[string]string{"login": "octocat"})
+	}))
+
+	login, err := a.CurrentUser(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "octocat", login)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, *waits)
+}
+
+func TestRateLimitedWritesAreNotRetried(t *testing.T) {
+	waits := stubSleep(t)
+	var calls atomic.Int32
+	a := newTestAdapter(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+		writeJSON(w, map[string]string{"message": "slow down"})
+	}))
+
+	err := a.EditMetadata(t.Context(), testRepo, 1, domain.MetadataEdit{AddLabels: []string{"bug"}})
+	assert.ErrorIs(t, err, domain.ErrRateLimited)
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Empty(t, *waits)
+}
+
+func TestGraphQLRateLimitIsTyped(t *testing.T) {
+	stubSleep(t)
+	a := newTestAdapter(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{
+			"errors": []map[string]string{{"type": "RATE_LIMITED", "message": "API rate limit exceeded"}},
+		})
+	}))
+
+	_, err := a.GetPRCount(t.Context(), testRepo, domain.PRStateOpen)
+	var rle *domain.RateLimitError
+	require.True(t, errors.As(err, &rle))
+	assert.Equal(t, "API rate limit exceeded", rle.Message)
+}
+
+func TestRateLimitReportsBudget(t *testing.T) {
+	raw, err := os.ReadFile("../githubql/testdata/rate_limit.json")
+	require.NoError(t, err)
+	a := newTestAdapter(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rate_limit", r.URL.Path)
+		_, _ = w.Write(raw)
+	}))
+
+	rl, err := a.RateLimit(t.Context(), testRepo)
+	require.NoError(t, err)
+	assert.Equal(t, "graphql", rl.Resource)
+	assert.Equal(t, 300, rl.Remaining)
+}
+
+func TestGraphqlEndpoint(t *testing.T) {
+	assert.Equal(t, "https://api.github.com/graphql", graphqlEndpoint("https://api.github.com"))
+	assert.Equal(t, "https://ghe.example.com/api/graphql", graphqlEndpoint("https://ghe.example.com/api/v3"))
+}
+
+// rewriteTransport sends every request to target while keeping the
+// original host visible to the handler via X-Original-Host.
+type rewriteTransport struct {
+	target string
+}
+
+func (rt rewriteTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.Header.Set("X-Original-Host", r.URL.Host)
+	r.URL.Scheme = "http"
+	r.URL.Host = strings.TrimPrefix(rt.target, "http://")
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestEnterpriseRepoRoutesToHostAPI(t *testing.T) {
+	t.Setenv("GH_ENTERPRISE_TOKEN", "ghe-token")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "ghe.example.com", r.Header.Get("X-Original-Host"))
+		assert.Equal(t, "/api/v3/repos/org/repo/issues/7/labels", r.URL.Path)
+		assert.Equal(t, "Bearer ghe-token", r.Header.Get("Authorization"))
+		writeJSON(w, []any{})
+	}))
+	t.Cleanup(srv.Close)
+
+	a := New(WithToken("dotcom-token"), WithHTTPClient(&http.Client{Transport: rewriteTransport{target: srv.URL}}))
+	repo := domain.RepoRef{Host: "ghe.example.com", Owner: "org", Name: "repo"}
+	require.NoError(t, a.EditMetadata(t.Context(), repo, 7, domain.MetadataEdit{AddLabels: []string{"bug"}}))
+
+	assert.Same(t, a.forHost(repo), a.forHost(repo), "host adapters are cached")
+	assert.Same(t, a, a.forHost(domain.RepoRef{Owner: "org", Name: "repo"}))
+}
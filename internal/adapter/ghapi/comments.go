@@ -0,0 +1,110 @@
+package ghapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+var _ domain.CommentManager = (*Adapter)(nil)
+
+// editMutations and deleteMutations hold the GraphQL mutation for each kind
+// of comment, taking the comment's node ID as $id.
+var (
+	editMutations = map[domain.DiscussionKind]string{
+		domain.DiscussionComment:      `mutation($id: ID!, $body: String!) { updateIssueComment(input: {id: $id, body: $body}) { issueComment { id } } }`,
+		domain.DiscussionReview:       `mutation($id: ID!, $body: String!) { updatePullRequestReview(input: {pullRequestReviewId: $id, body: $body}) { pullRequestReview { id } } }`,
+		domain.DiscussionInlineThread: `mutation($id: ID!, $body: String!) { updatePullRequestReviewComment(input: {pullRequestReviewCommentId: $id, body: $body}) { pullRequestReviewComment { id } } }`,
+	}
+	deleteMutations = map[domain.DiscussionKind]string{
+		domain.DiscussionComment:      `mutation($id: ID!) { deleteIssueComment(input: {id: $id}) { clientMutationId } }`,
+		domain.DiscussionReview:       `mutation($id: ID!) { deletePullRequestReview(input: {pullRequestReviewId: $id}) { clientMutationId } }`,
+		domain.DiscussionInlineThread: `mutation($id: ID!) { deletePullRequestReviewComment(input: {id: $id}) { clientMutationId } }`,
+	}
+)
+
+const (
+	unresolveThreadMutation = `mutation($id: ID!) { unresolveReviewThread(input: {threadId: $id}) { thread { isResolved } } }`
+	addReactionMutation     = `mutation($id: ID!, $content: ReactionContent!) { addReaction(input: {subjectId: $id, content: $content}) { clientMutationId } }`
+	removeReactionMutation  = `mutation($id: ID!, $content: ReactionContent!) { removeReaction(input: {subjectId: $id, content: $content}) { clientMutationId } }`
+)
+
+// AddPRComment posts a comment on the PR's conversation via the REST API.
+func (a *Adapter) AddPRComment(ctx context.Context, repo domain.RepoRef, number int, body string) error {
+	if c := a.forHost(repo); c != a {
+		return c.AddPRComment(ctx, repo, number, body)
+	}
+	path := fmt.Sprintf("repos/%s/issues/%d/comments", repo.FullName(), number)
+	if err := a.restJSON(ctx, http.MethodPost, path, map[string]any{"body": body}, nil); err != nil {
+		return fmt.Errorf("commenting on PR #%d: %w", number, err)
+	}
+	return nil
+}
+
+// EditComment replaces a comment's body via the GraphQL API.
+func (a *Adapter) EditComment(ctx context.Context, repo domain.RepoRef, ref domain.CommentRef, body string) error {
+	if c := a.forHost(repo); c != a {
+		return c.EditComment(ctx, repo, ref, body)
+	}
+	query, ok := editMutations[ref.Kind]
+	if !ok {
+		return fmt.Errorf("editing comment %s: unknown comment kind %q", ref.NodeID, ref.Kind)
+	}
+	if err := a.graphql(ctx, query, map[string]any{"id": ref.NodeID, "body": body}, nil); err != nil {
+		return fmt.Errorf("editing comment %s: %w", ref.NodeID, err)
+	}
+	return nil
+}
+
+// DeleteComment deletes a comment via the GraphQL API. GitHub only deletes
+// reviews that are still pending.
+func (a *Adapter) DeleteComment(ctx context.Context, repo domain.RepoRef, ref domain.CommentRef) error {
+	if c := a.forHost(repo); c != a {
+		return c.DeleteComment(ctx, repo, ref)
+	}
+	query, ok := deleteMutations[ref.Kind]
+	if !ok {
+		return fmt.Errorf("deleting comment %s: unknown comment kind %q", ref.NodeID, ref.Kind)
+	}
+	if err := a.graphql(ctx, query, map[string]any{"id": ref.NodeID}, nil); err != nil {
+		return fmt.Errorf("deleting comment %s: %w", ref.NodeID, err)
+	}
+	return nil
+}
+
+// UnresolveThread reopens a resolved review thread via the GraphQL API.
+func (a *Adapter) UnresolveThread(ctx context.Context, repo domain.RepoRef, threadID string) error {
+	if c := a.forHost(repo); c != a {
+		return c.UnresolveThread(ctx, repo, threadID)
+	}
+	if err := a.graphql(ctx, unresolveThreadMutation, map[string]any{"id": threadID}, nil); err != nil {
+		return fmt.Errorf("unresolving thread %s: %w", threadID, err)
+	}
+	return nil
+}
+
+// AddReaction reacts to a comment via the GraphQL API.
+func (a *Adapter) AddReaction(ctx context.Context, repo domain.RepoRef, ref domain.CommentRef, content domain.ReactionContent) error {
+	if c := a.forHost(repo); c != a {
+		return c.AddReaction(ctx, repo, ref, content)
+	}
+	vars := map[string]any{"id": ref.NodeID, "content": string(content)}
+	if err := a.graphql(ctx, addReactionMutation, vars, nil); err != nil {
+		return fmt.Errorf("reacting to comment %s: %w", ref.NodeID, err)
+	}
+	return nil
+}
+
+// RemoveReaction withdraws the viewer's reaction via the GraphQL API.
+func (a *Adapter) RemoveReaction(ctx context.Context, repo domain.RepoRef, ref domain.CommentRef, content domain.ReactionContent) error {
+	if c := a.forHost(repo); c != a {
+		return c.RemoveReaction(ctx, repo, ref, content)
+	}
+	vars := map[string]any{"id": ref.NodeID, "content": string(content)}
+	if err := a.graphql(ctx, removeReactionMutation, vars, nil); err != nil {
+		return fmt.Errorf("removing reaction from comment %s: %w", ref.NodeID, err)
+	}
+	return nil
+}
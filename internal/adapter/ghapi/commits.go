@@ -0,0 +1,47 @@
+package ghapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/indrasvat/vivecaka/internal/adapter/githubql"
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// GetCommits lists a PR's commits, oldest first, with the check rollup of
+// each, a page of 100 per GraphQL query.
+func (a *Adapter) GetCommits(ctx context.Context, repo domain.RepoRef, number int) ([]domain.Commit, error) {
+	if c := a.forHost(repo); c != a {
+		return c.GetCommits(ctx, repo, number)
+	}
+	var (
+		cursor  string
+		commits []domain.Commit
+	)
+	for {
+		var result githubql.CommitsResult
+		if err := a.graphql(ctx, githubql.CommitsQuery(repo, number, cursor), nil, &result); err != nil {
+			return nil, fmt.Errorf("getting commits for PR #%d: %w", number, err)
+		}
+		page, next := result.Page()
+		commits = append(commits, page...)
+		if next == "" {
+			return commits, nil
+		}
+		cursor = next
+	}
+}
+
+// CompareCommits fetches the diff from base to head via the compare API.
+func (a *Adapter) CompareCommits(ctx context.Context, repo domain.RepoRef, base, head string) (*domain.Diff, error) {
+	if c := a.forHost(repo); c != a {
+		return c.CompareCommits(ctx, repo, base, head)
+	}
+	out, err := a.doRequest(ctx, http.MethodGet, githubql.CompareCommitsPath(repo, base, head), nil, acceptDiff)
+	if err != nil {
+		return nil, fmt.Errorf("comparing %.7s with %.7s: %w", head, base, err)
+	}
+	diff := domain.ParseDiff(string(out))
+	return &diff, nil
+}
@@ -0,0 +1,494 @@
+package ghapi
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+type pageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+type gqlActor struct {
+	Login string `json:"login"`
+}
+
+// gqlCheckContext is a statusCheckRollup context: either a CheckRun
+// (name/status/conclusion) or a legacy commit StatusContext (context/state).
+type gqlCheckContext struct {
+	Typename    string     `json:"__typename"`
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	Conclusion  string     `json:"conclusion"`
+	StartedAt   *time.Time `json:"startedAt"`
+	CompletedAt *time.Time `json:"completedAt"`
+	DetailsURL  string     `json:"detailsUrl"`
+	Context     string     `json:"context"`
+	State       string     `json:"state"`
+	TargetURL   string     `json:"targetUrl"`
+}
+
+type gqlCommits struct {
+	Nodes []struct {
+		Commit struct {
+			StatusCheckRollup *struct {
+				Contexts struct {
+					Nodes []gqlCheckContext `json:"nodes"`
+				} `json:"contexts"`
+			} `json:"statusCheckRollup"`
+		} `json:"commit"`
+	} `json:"nodes"`
+}
+
+// gqlPR is the GraphQL shape selected by prFieldsFragment.
+type gqlPR struct {
+	Number         int        `json:"number"`
+	Title          string     `json:"title"`
+	State          string     `json:"state"`
+	IsDraft        bool       `json:"isDraft"`
+	URL            string     `json:"url"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+	Author         gqlActor   `json:"author"`
+	HeadRefName    string     `json:"headRefName"`
+	BaseRefName    string     `json:"baseRefName"`
+	HeadRefOID     string     `json:"headRefOid"`
+	BaseRefOID     string     `json:"baseRefOid"`
+	ReviewDecision string     `json:"reviewDecision"`
+	Labels         gqlLabels  `json:"labels"`
+	Commits        gqlCommits `json:"commits"`
+}
+
+type gqlLabels struct {
+	Nodes []struct {
+		Name string `json:"name"`
+	} `json:"nodes"`
+}
+
+// checkContexts returns the status check contexts of the head commit.
+func (g gqlPR) checkContexts() []gqlCheckContext {
+	if len(g.Commits.Nodes) == 0 {
+		return nil
+	}
+	rollup := g.Commits.Nodes[len(g.Commits.Nodes)-1].Commit.StatusCheckRollup
+	if rollup == nil {
+		return nil
+	}
+	return rollup.Contexts.Nodes
+}
+
+type gqlFile struct {
+	Path       string `json:"path"`
+	Additions  int    `json:"additions"`
+	Deletions  int    `json:"deletions"`
+	ChangeType string `json:"changeType"`
+}
+
+type gqlFilesPage struct {
+	Nodes    []gqlFile `json:"nodes"`
+	PageInfo pageInfo  `json:"pageInfo"`
+}
+
+type gqlPRDetail struct {
+	gqlPR
+	Body      string `json:"body"`
+	Assignees struct {
+		Nodes []gqlActor `json:"nodes"`
+	} `json:"assignees"`
+	ReviewRequests struct {
+		Nodes []struct {
+			RequestedReviewer struct {
+				Login        string   `json:"login"`
+				Name         string   `json:"name"` // teams
+				Slug         string   `json:"slug"`
+				Organization gqlActor `json:"organization"`
+			} `json:"requestedReviewer"`
+		} `json:"nodes"`
+	} `json:"reviewRequests"`
+	LatestReviews struct {
+		Nodes []struct {
+			Author gqlActor `json:"author"`
+			State  string   `json:"state"`
+		} `json:"nodes"`
+	} `json:"latestReviews"`
+	Files gqlFilesPage `json:"files"`
+}
+
+type gqlComment struct {
+	ID         string    `json:"id"`
+	DatabaseID int       `json:"databaseId"`
+	Body       string    `json:"body"`
+	CreatedAt  time.Time `json:"createdAt"`
+	URL        string    `json:"url"`
+	Author     gqlActor  `json:"author"`
+	gqlViewerFields
+}
+
+// gqlViewerFields is the selection of viewerCommentFields.
+type gqlViewerFields struct {
+	ViewerCanUpdate bool               `json:"viewerCanUpdate"`
+	ViewerCanDelete bool               `json:"viewerCanDelete"`
+	ReactionGroups  []gqlReactionGroup `json:"reactionGroups"`
+}
+
+type gqlReactionGroup struct {
+	Content          string `json:"content"`
+	ViewerHasReacted bool   `json:"viewerHasReacted"`
+	Reactors         struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"reactors"`
+}
+
+type gqlCommentConnection struct {
+	Nodes    []gqlComment `json:"nodes"`
+	PageInfo pageInfo     `json:"pageInfo"`
+}
+
+type gqlReviewThread struct {
+	ID         string               `json:"id"`
+	IsResolved bool                 `json:"isResolved"`
+	Path       string               `json:"path"`
+	Line       *int                 `json:"line"`
+	StartLine  *int                 `json:"startLine"`
+	Comments   gqlCommentConnection `json:"comments"`
+}
+
+type gqlReview struct {
+	ID          string    `json:"id"`
+	Body        string    `json:"body"`
+	State       string    `json:"state"`
+	SubmittedAt time.Time `json:"submittedAt"`
+	URL         string    `json:"url"`
+	Author      gqlActor  `json:"author"`
+	gqlViewerFields
+}
+
+type gqlIssueComment struct {
+	ID        string    `json:"id"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"createdAt"`
+	URL       string    `json:"url"`
+	Author    gqlActor  `json:"author"`
+	gqlViewerFields
+}
+
+// toDomainPR converts a GraphQL pull request to a domain.PR.
+func toDomainPR(g gqlPR) domain.PR {
+	labels := make([]string, len(g.Labels.Nodes))
+	for i, l := range g.Labels.Nodes {
+		labels[i] = l.Name
+	}
+
+	return domain.PR{
+		Number: g.Number,
+		Title:  g.Title,
+		Author: g.Author.Login,
+		State:  mapState(g.State),
+		Draft:  g.IsDraft,
+		Branch: domain.BranchInfo{
+			Head:    g.HeadRefName,
+			Base:    g.BaseRefName,
+			HeadSHA: g.HeadRefOID,
+			BaseSHA: g.BaseRefOID,
+		},
+		Labels:         labels,
+		CI:             aggregateCI(g.checkContexts()),
+		Review:         mapReviewDecision(g.ReviewDecision),
+		UpdatedAt:      g.UpdatedAt,
+		CreatedAt:      g.CreatedAt,
+		URL:            g.URL,
+		LastActivityAt: g.UpdatedAt,
+	}
+}
+
+// toDomainPRDetail converts a GraphQL pull request detail to a domain.PRDetail.
+func toDomainPRDetail(g gqlPRDetail) domain.PRDetail {
+	assignees := make([]string, len(g.Assignees.Nodes))
+	for i, a := range g.Assignees.Nodes {
+		assignees[i] = a.Login
+	}
+
+	reviewers := make([]domain.ReviewerInfo, 0, len(g.ReviewRequests.Nodes)+len(g.LatestReviews.Nodes))
+	for _, rr := range g.ReviewRequests.Nodes {
+		r := rr.RequestedReviewer
+		login := r.Login
+		if login == "" && r.Slug != "" {
+			login = r.Organization.Login + "/" + r.Slug // teams are requested as org/slug
+		}
+		if login == "" {
+			login = r.Name
+		}
+		reviewers = append(reviewers, domain.ReviewerInfo{Login: login, State: domain.ReviewPending})
+	}
+	for _, r := range g.LatestReviews.Nodes {
+		reviewers = append(reviewers, domain.ReviewerInfo{Login: r.Author.Login, State: mapReviewState(r.State)})
+	}
+
+	files := make([]domain.FileChange, len(g.Files.Nodes))
+	for i, f := range g.Files.Nodes {
+		files[i] = domain.FileChange{
+			Path:      f.Path,
+			Additions: f.Additions,
+			Deletions: f.Deletions,
+			Status:    mapFileStatus(f.ChangeType),
+		}
+	}
+
+	contexts := g.checkContexts()
+	checks := make([]domain.Check, len(contexts))
+	for i, c := range contexts {
+		checks[i] = toDomainCheck(c)
+	}
+
+	return domain.PRDetail{
+		PR:        toDomainPR(g.gqlPR),
+		Body:      g.Body,
+		Assignees: assignees,
+		Reviewers: reviewers,
+		Checks:    checks,
+		Files:     files,
+	}
+}
+
+func toDomainCheck(c gqlCheckContext) domain.Check {
+	if c.Typename == "StatusContext" {
+		return domain.Check{
+			Name:   c.Context,
+			Status: mapStatusContextState(c.State),
+			URL:    c.TargetURL,
+		}
+	}
+	var duration time.Duration
+	if c.StartedAt != nil && c.CompletedAt != nil {
+		duration = c.CompletedAt.Sub(*c.StartedAt)
+	}
+	return domain.Check{
+		Name:     c.Name,
+		Status:   mapCheckStatus(c.Status, c.Conclusion),
+		Duration: duration,
+		URL:      c.DetailsURL,
+	}
+}
+
+func toDomainCommentThreads(threads []gqlReviewThread) []domain.CommentThread {
+	out := make([]domain.CommentThread, 0, len(threads))
+	for _, thread := range threads {
+		if len(thread.Comments.Nodes) == 0 {
+			continue
+		}
+		line := 0
+		if thread.Line != nil {
+			line = *thread.Line
+		}
+		startLine := 0
+		if thread.StartLine != nil && *thread.StartLine != line {
+			startLine = *thread.StartLine
+		}
+
+		comments := make([]domain.Comment, 0, len(thread.Comments.Nodes))
+		for _, c := range thread.Comments.Nodes {
+			comments = append(comments, domain.Comment{
+				ID:        strconv.Itoa(c.DatabaseID),
+				NodeID:    c.ID,
+				Author:    c.Author.Login,
+				Body:      c.Body,
+				CreatedAt: c.CreatedAt,
+				URL:       c.URL,
+				CanEdit:   c.ViewerCanUpdate,
+				CanDelete: c.ViewerCanDelete,
+				Reactions: c.reactions(),
+			})
+		}
+
+		rootID := comments[0].ID
+		out = append(out, domain.CommentThread{
+			ID:        rootID,
+			ThreadID:  thread.ID,
+			ReplyToID: rootID,
+			Path:      thread.Path,
+			Line:      line,
+			StartLine: startLine,
+			Resolved:  thread.IsResolved,
+			Comments:  comments,
+		})
+	}
+	return out
+}
+
+func toDomainReviewItems(reviews []gqlReview) []domain.DiscussionItem {
+	items := make([]domain.DiscussionItem, 0, len(reviews))
+	for _, review := range reviews {
+		if strings.TrimSpace(review.Body) == "" {
+			continue
+		}
+		items = append(items, domain.DiscussionItem{
+			ID:          review.ID,
+			Kind:        domain.DiscussionReview,
+			ReviewState: mapReviewState(review.State),
+			StateLabel:  strings.ToLower(strings.ReplaceAll(review.State, "_", " ")),
+			CreatedAt:   review.SubmittedAt,
+			URL:         review.URL,
+			Comments: []domain.Comment{{
+				ID:        review.ID,
+				NodeID:    review.ID,
+				Author:    review.Author.Login,
+				Body:      review.Body,
+				CreatedAt: review.SubmittedAt,
+				URL:       review.URL,
+				CanEdit:   review.ViewerCanUpdate,
+				CanDelete: review.ViewerCanDelete,
+				Reactions: review.reactions(),
+			}},
+		})
+	}
+	return items
+}
+
+func toDomainIssueComments(comments []gqlIssueComment) []domain.DiscussionItem {
+	items := make([]domain.DiscussionItem, 0, len(comments))
+	for _, comment := range comments {
+		if strings.TrimSpace(comment.Body) == "" {
+			continue
+		}
+		items = append(items, domain.DiscussionItem{
+			ID:        comment.ID,
+			Kind:      domain.DiscussionComment,
+			CreatedAt: comment.CreatedAt,
+			URL:       comment.URL,
+			Comments: []domain.Comment{{
+				ID:        comment.ID,
+				NodeID:    comment.ID,
+				Author:    comment.Author.Login,
+				Body:      comment.Body,
+				CreatedAt: comment.CreatedAt,
+				URL:       comment.URL,
+				CanEdit:   comment.ViewerCanUpdate,
+				CanDelete: comment.ViewerCanDelete,
+				Reactions: comment.reactions(),
+			}},
+		})
+	}
+	return items
+}
+
+// reactions converts the reaction groups, leaving out the emoji nobody
+// reacted with.
+func (v gqlViewerFields) reactions() []domain.Reaction {
+	var out []domain.Reaction
+	for _, g := range v.ReactionGroups {
+		if g.Reactors.TotalCount == 0 {
+			continue
+		}
+		out = append(out, domain.Reaction{
+			Content:       domain.ReactionContent(g.Content),
+			Count:         g.Reactors.TotalCount,
+			ViewerReacted: g.ViewerHasReacted,
+		})
+	}
+	return out
+}
+
+func mapState(s string) domain.PRState {
+	switch s {
+	case "CLOSED":
+		return domain.PRStateClosed
+	case "MERGED":
+		return domain.PRStateMerged
+	default:
+		return domain.PRStateOpen
+	}
+}
+
+func mapFileStatus(changeType string) string {
+	switch changeType {
+	case "ADDED":
+		return "added"
+	case "DELETED", "REMOVED":
+		return "removed"
+	case "RENAMED":
+		return "renamed"
+	default:
+		return "modified"
+	}
+}
+
+func aggregateCI(contexts []gqlCheckContext) domain.CIStatus {
+	if len(contexts) == 0 {
+		return domain.CINone
+	}
+	hasPending := false
+	for _, c := range contexts {
+		switch toDomainCheck(c).Status {
+		case domain.CIFail:
+			return domain.CIFail
+		case domain.CIPending:
+			hasPending = true
+		}
+	}
+	if hasPending {
+		return domain.CIPending
+	}
+	return domain.CIPass
+}
+
+func mapCheckStatus(status, conclusion string) domain.CIStatus {
+	switch status {
+	case "COMPLETED":
+		switch conclusion {
+		case "SUCCESS":
+			return domain.CIPass
+		case "SKIPPED", "NEUTRAL":
+			return domain.CISkipped
+		default:
+			return domain.CIFail
+		}
+	case "IN_PROGRESS", "QUEUED", "PENDING", "WAITING", "REQUESTED":
+		return domain.CIPending
+	default:
+		return domain.CINone
+	}
+}
+
+// mapStatusContextState maps a legacy commit status state to a CI status.
+func mapStatusContextState(state string) domain.CIStatus {
+	switch state {
+	case "SUCCESS":
+		return domain.CIPass
+	case "FAILURE", "ERROR":
+		return domain.CIFail
+	case "PENDING", "EXPECTED":
+		return domain.CIPending
+	default:
+		return domain.CINone
+	}
+}
+
+func mapReviewDecision(decision string) domain.ReviewStatus {
+	switch decision {
+	case "APPROVED":
+		return domain.ReviewStatus{State: domain.ReviewApproved}
+	case "CHANGES_REQUESTED":
+		return domain.ReviewStatus{State: domain.ReviewChangesRequested}
+	case "REVIEW_REQUIRED":
+		return domain.ReviewStatus{State: domain.ReviewPending}
+	default:
+		return domain.ReviewStatus{State: domain.ReviewNone}
+	}
+}
+
+func mapReviewState(state string) domain.ReviewState {
+	switch state {
+	case "APPROVED":
+		return domain.ReviewApproved
+	case "CHANGES_REQUESTED":
+		return domain.ReviewChangesRequested
+	case "PENDING", "COMMENTED":
+		return domain.ReviewPending
+	default:
+		return domain.ReviewNone
+	}
+}
@@ -0,0 +1,68 @@
+package ghapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/indrasvat/vivecaka/internal/adapter/githubql"
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/gitutil"
+)
+
+var _ domain.PRCreator = (*Adapter)(nil)
+
+// ListBranches fetches the repo's default branch and its 100 most recently
+// updated branches in one GraphQL query.
+func (a *Adapter) ListBranches(ctx context.Context, repo domain.RepoRef) (*domain.RepoBranches, error) {
+	if c := a.forHost(repo); c != a {
+		return c.ListBranches(ctx, repo)
+	}
+	var result githubql.BranchesResult
+	if err := a.graphql(ctx, githubql.BranchesQuery(repo), nil, &result); err != nil {
+		return nil, fmt.Errorf("listing branches of %s: %w", repo, err)
+	}
+	return result.Branches(), nil
+}
+
+// CompareBranch compares head with base in the local clone at dir.
+func (a *Adapter) CompareBranch(ctx context.Context, dir, base, head string) (*domain.BranchComparison, error) {
+	return gitutil.CompareBranch(ctx, dir, base, head)
+}
+
+// PushBranch pushes branch to origin from the clone at dir.
+func (a *Adapter) PushBranch(ctx context.Context, dir, branch string) error {
+	return gitutil.PushBranch(ctx, dir, branch)
+}
+
+// CreatePR opens a PR via the REST pulls endpoint, then adds its labels and
+// requests its reviewers. If either of those fails, the PR still exists:
+// its number is returned along with the error.
+func (a *Adapter) CreatePR(ctx context.Context, repo domain.RepoRef, pr domain.NewPR) (int, error) {
+	if c := a.forHost(repo); c != a {
+		return c.CreatePR(ctx, repo, pr)
+	}
+	var created struct {
+		Number int `json:"number"`
+	}
+	body := map[string]any{"title": pr.Title, "body": pr.Body, "head": pr.Head, "base": pr.Base, "draft": pr.Draft}
+	if err := a.restJSON(ctx, http.MethodPost, fmt.Sprintf("repos/%s/pulls", repo.FullName()), body, &created); err != nil {
+		return 0, fmt.Errorf("creating PR from %s: %w", pr.Head, err)
+	}
+
+	var reqs []restRequest
+	if len(pr.Labels) > 0 {
+		path := fmt.Sprintf("repos/%s/issues/%d/labels", repo.FullName(), created.Number)
+		reqs = append(reqs, restRequest{http.MethodPost, path, map[string]any{"labels": pr.Labels}})
+	}
+	if len(pr.Reviewers) > 0 {
+		path := fmt.Sprintf("repos/%s/pulls/%d/requested_reviewers", repo.FullName(), created.Number)
+		reqs = append(reqs, restRequest{http.MethodPost, path, reviewersBody(pr.Reviewers)})
+	}
+	for _, r := range reqs {
+		if err := a.restJSON(ctx, r.method, r.path, r.body, nil); err != nil {
+			return created.Number, fmt.Errorf("PR #%d was created, but: %w", created.Number, err)
+		}
+	}
+	return created.Number, nil
+}
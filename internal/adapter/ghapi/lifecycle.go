@@ -0,0 +1,104 @@
+package ghapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+var _ domain.PRLifecycleManager = (*Adapter)(nil)
+
+const (
+	// convertToDraftMutation turns the PR $id back into a draft.
+	convertToDraftMutation = `mutation($id: ID!) {
+  convertPullRequestToDraft(input: {pullRequestId: $id}) { clientMutationId }
+}`
+	// markReadyMutation marks the draft PR $id ready for review.
+	markReadyMutation = `mutation($id: ID!) {
+  markPullRequestReadyForReview(input: {pullRequestId: $id}) { clientMutationId }
+}`
+	// updateBranchMutation brings the head of PR $id up to date with its
+	// base. The REST update-branch endpoint can only merge, hence GraphQL.
+	updateBranchMutation = `mutation($id: ID!, $method: PullRequestBranchUpdateMethod!) {
+  updatePullRequestBranch(input: {pullRequestId: $id, updateMethod: $method}) { clientMutationId }
+}`
+)
+
+// ClosePR closes a PR via the REST pulls endpoint. The comment is posted
+// first, so it lands above the close event like gh pr close does.
+func (a *Adapter) ClosePR(ctx context.Context, repo domain.RepoRef, number int, comment string) error {
+	if c := a.forHost(repo); c != a {
+		return c.ClosePR(ctx, repo, number, comment)
+	}
+	if comment != "" {
+		if err := a.AddPRComment(ctx, repo, number, comment); err != nil {
+			return err
+		}
+	}
+	if err := a.setPullState(ctx, repo, number, "closed"); err != nil {
+		return fmt.Errorf("closing PR #%d: %w", number, err)
+	}
+	return nil
+}
+
+// ReopenPR reopens a closed PR via the REST pulls endpoint, then posts the
+// comment if set.
+func (a *Adapter) ReopenPR(ctx context.Context, repo domain.RepoRef, number int, comment string) error {
+	if c := a.forHost(repo); c != a {
+		return c.ReopenPR(ctx, repo, number, comment)
+	}
+	if err := a.setPullState(ctx, repo, number, "open"); err != nil {
+		return fmt.Errorf("reopening PR #%d: %w", number, err)
+	}
+	if comment != "" {
+		return a.AddPRComment(ctx, repo, number, comment)
+	}
+	return nil
+}
+
+func (a *Adapter) setPullState(ctx context.Context, repo domain.RepoRef, number int, state string) error {
+	path := fmt.Sprintf("repos/%s/pulls/%d", repo.FullName(), number)
+	return a.restJSON(ctx, http.MethodPatch, path, map[string]any{"state": state}, nil)
+}
+
+// SetDraft converts a PR to a draft, or marks it ready for review, via
+// GraphQL; REST has no endpoint for either.
+func (a *Adapter) SetDraft(ctx context.Context, repo domain.RepoRef, number int, draft bool) error {
+	if c := a.forHost(repo); c != a {
+		return c.SetDraft(ctx, repo, number, draft)
+	}
+	query, action := markReadyMutation, "marking PR #%d ready for review"
+	if draft {
+		query, action = convertToDraftMutation, "converting PR #%d to draft"
+	}
+	pr, err := a.getRestPull(ctx, repo, number)
+	if err != nil {
+		return fmt.Errorf(action+": %w", number, err)
+	}
+	if err := a.graphql(ctx, query, map[string]any{"id": pr.NodeID}, nil); err != nil {
+		return fmt.Errorf(action+": %w", number, err)
+	}
+	return nil
+}
+
+// UpdateBranch merges or rebases the base branch into the PR's head.
+func (a *Adapter) UpdateBranch(ctx context.Context, repo domain.RepoRef, number int, method string) error {
+	if c := a.forHost(repo); c != a {
+		return c.UpdateBranch(ctx, repo, number, method)
+	}
+	if method != domain.MergeMethodRebase {
+		method = domain.MergeMethodMerge
+	}
+	pr, err := a.getRestPull(ctx, repo, number)
+	if err != nil {
+		return fmt.Errorf("updating the branch of PR #%d: %w", number, err)
+	}
+	vars := map[string]any{"id": pr.NodeID, "method": strings.ToUpper(method)}
+	if err := a.graphql(ctx, updateBranchMutation, vars, nil); err != nil {
+		return fmt.Errorf("updating the branch of PR #%d: %w", number, err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package ghapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/indrasvat/vivecaka/internal/adapter/githubql"
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+var _ domain.MetadataEditor = (*Adapter)(nil)
+
+// GetMetadataOptions fetches the repo's labels and assignable users via
+// GraphQL, and the teams with access to the repo via REST. Listing teams
+// needs the read:org scope and only works for org repos, so a failure there
+// leaves the teams empty instead of failing.
+func (a *Adapter) GetMetadataOptions(ctx context.Context, repo domain.RepoRef) (*domain.MetadataOptions, error) {
+	if c := a.forHost(repo); c != a {
+		return c.GetMetadataOptions(ctx, repo)
+	}
+	var result githubql.MetadataOptionsResult
+	if err := a.graphql(ctx, githubql.MetadataOptionsQuery(repo), nil, &result); err != nil {
+		return nil, fmt.Errorf("fetching labels and users of %s: %w", repo, err)
+	}
+	opts := result.Options()
+
+	var teams []githubql.RepoTeam
+	path := fmt.Sprintf("repos/%s/teams?per_page=100", repo.FullName())
+	if err := a.restJSON(ctx, http.MethodGet, path, nil, &teams); err == nil {
+		opts.Teams = githubql.TeamSlugs(repo, teams)
+	}
+	return opts, nil
+}
+
+// EditMetadata changes a PR's labels, assignees and requested reviewers via
+// the REST issues and pulls endpoints, one request per kind of change.
+func (a *Adapter) EditMetadata(ctx context.Context, repo domain.RepoRef, number int, edit domain.MetadataEdit) error {
+	if c := a.forHost(repo); c != a {
+		return c.EditMetadata(ctx, repo, number, edit)
+	}
+	issue := fmt.Sprintf("repos/%s/issues/%d", repo.FullName(), number)
+	pull := fmt.Sprintf("repos/%s/pulls/%d", repo.FullName(), number)
+
+	var reqs []restRequest
+	if len(edit.AddLabels) > 0 {
+		reqs = append(reqs, restRequest{http.MethodPost, issue + "/labels", map[string]any{"labels": edit.AddLabels}})
+	}
+	for _, l := range edit.RemoveLabels {
+		reqs = append(reqs, restRequest{http.MethodDelete, issue + "/labels/" + url.PathEscape(l), nil})
+	}
+	if len(edit.AddAssignees) > 0 {
+		reqs = append(reqs, restRequest{http.MethodPost, issue + "/assignees", map[string]any{"assignees": edit.AddAssignees}})
+	}
+	if len(edit.RemoveAssignees) > 0 {
+		reqs = append(reqs, restRequest{http.MethodDelete, issue + "/assignees", map[string]any{"assignees": edit.RemoveAssignees}})
+	}
+	if len(edit.AddReviewers) > 0 {
+		reqs = append(reqs, restRequest{http.MethodPost, pull + "/requested_reviewers", reviewersBody(edit.AddReviewers)})
+	}
+	if len(edit.RemoveReviewers) > 0 {
+		reqs = append(reqs, restRequest{http.MethodDelete, pull + "/requested_reviewers", reviewersBody(edit.RemoveReviewers)})
+	}
+
+	for _, r := range reqs {
+		if err := a.restJSON(ctx, r.method, r.path, r.body, nil); err != nil {
+			return fmt.Errorf("editing PR #%d: %w", number, err)
+		}
+	}
+	return nil
+}
+
+// restRequest is one REST call of a multi-request write.
+type restRequest struct {
+	method, path string
+	body         any
+}
+
+// reviewersBody splits reviewers into the users and team slugs the
+// requested_reviewers endpoint takes. Teams belong to the repo's org, so
+// only the slug is sent.
+func reviewersBody(reviewers []string) map[string]any {
+	users, teams := []string{}, []string{}
+	for _, r := range reviewers {
+		if domain.IsTeam(r) {
+			_, slug, _ := strings.Cut(r, "/")
+			teams = append(teams, slug)
+		} else {
+			users = append(users, r)
+		}
+	}
+	return map[string]any{"reviewers": users, "team_reviewers": teams}
+}
@@ -0,0 +1,209 @@
+package ghapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/indrasvat/vivecaka/internal/adapter/githubql"
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/plugin"
+)
+
+const (
+	defaultHost   = domain.DefaultHost
+	defaultAPIURL = "https://api.github.com"
+
+	// checkTimeout bounds the startup auth probe so a dead network fails fast.
+	checkTimeout = 10 * time.Second
+)
+
+// Compile-time checks that Adapter satisfies every domain capability.
+var (
+	_ plugin.Plugin            = (*Adapter)(nil)
+	_ domain.PRReader          = (*Adapter)(nil)
+	_ domain.PRReviewer        = (*Adapter)(nil)
+	_ domain.PRWriter          = (*Adapter)(nil)
+	_ domain.RepoManager       = (*Adapter)(nil)
+	_ domain.RateLimitReporter = (*Adapter)(nil)
+	_ domain.PRPager           = (*Adapter)(nil)
+	_ domain.CIStatusReader    = (*Adapter)(nil)
+	_ domain.CommitReader      = (*Adapter)(nil)
+	_ domain.HostSupporter     = (*Adapter)(nil)
+)
+
+// Adapter talks to the GitHub REST and GraphQL APIs directly over net/http.
+// It implements plugin.Plugin, domain.PRReader, domain.PRReviewer,
+// domain.CommentManager, domain.PRWriter, domain.MergeStatusReader,
+// domain.MetadataEditor, domain.PRLifecycleManager, domain.PRCreator, and
+// domain.RepoManager without spawning gh.
+type Adapter struct {
+	host       string
+	restURL    string
+	graphqlURL string
+	token      string
+	httpClient *http.Client
+
+	tokenOnce sync.Once
+	tokenErr  error
+
+	// hosts caches adapters for GitHub Enterprise Server hosts reached
+	// through repos whose Host differs from this adapter's.
+	hostsMu sync.Mutex
+	hosts   map[string]*Adapter
+}
+
+// Option configures an Adapter.
+type Option func(*Adapter)
+
+// WithBaseURL points the adapter at a different REST API root, e.g. an
+// httptest server. The GraphQL endpoint is derived from it.
+func WithBaseURL(url string) Option {
+	return func(a *Adapter) {
+		a.restURL = strings.TrimSuffix(url, "/")
+		a.graphqlURL = graphqlEndpoint(a.restURL)
+	}
+}
+
+// WithToken sets the API token explicitly, skipping environment and
+// hosts.yml resolution.
+func WithToken(token string) Option {
+	return func(a *Adapter) { a.token = token }
+}
+
+// WithHTTPClient overrides the HTTP client used for all requests.
+func WithHTTPClient(c *http.Client) Option {
+	return func(a *Adapter) { a.httpClient = c }
+}
+
+// New creates a new GitHub API adapter.
+func New(opts ...Option) *Adapter {
+	a := &Adapter{
+		host:       defaultHost,
+		restURL:    defaultAPIURL,
+		graphqlURL: graphqlEndpoint(defaultAPIURL),
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// Info returns plugin metadata.
+func (a *Adapter) Info() plugin.PluginInfo {
+	return plugin.PluginInfo{
+		Name:        "github-api",
+		Version:     "1.0.0",
+		Description: "GitHub adapter using the REST and GraphQL APIs directly",
+		Provides:    []string{"pr-reader", "pr-reviewer", "pr-writer"},
+	}
+}
+
+// Check resolves an API token and verifies it against the /user endpoint.
+// Call this before starting the TUI to fail fast with a clear message.
+func (a *Adapter) Check() error {
+	if _, err := a.authToken(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), checkTimeout)
+	defer cancel()
+
+	if _, err := a.CurrentUser(ctx); err != nil {
+		return fmt.Errorf("github api: %w", err)
+	}
+	return nil
+}
+
+// Init satisfies the plugin.Plugin interface.
+func (a *Adapter) Init(_ plugin.AppContext) tea.Cmd {
+	return nil
+}
+
+// CurrentUser returns the login of the authenticated user.
+func (a *Adapter) CurrentUser(ctx context.Context) (string, error) {
+	var user struct {
+		Login string `json:"login"`
+	}
+	if err := a.restJSON(ctx, http.MethodGet, "user", nil, &user); err != nil {
+		return "", err
+	}
+	if user.Login == "" {
+		return "", domain.ErrNotFound
+	}
+	return user.Login, nil
+}
+
+// authToken returns the API token, resolving it once on first use.
+func (a *Adapter) authToken() (string, error) {
+	a.tokenOnce.Do(func() {
+		if a.token != "" {
+			return
+		}
+		a.token, a.tokenErr = ResolveToken(a.host)
+	})
+	return a.token, a.tokenErr
+}
+
+// forHost returns the adapter that serves repo's host: a itself for its
+// own host, otherwise a cached adapter pointed at that Enterprise Server
+// instance's API, sharing a's HTTP client.
+func (a *Adapter) forHost(repo domain.RepoRef) *Adapter {
+	host := repo.HostName()
+	if host == a.host {
+		return a
+	}
+
+	a.hostsMu.Lock()
+	defer a.hostsMu.Unlock()
+	if c, ok := a.hosts[host]; ok {
+		return c
+	}
+	restURL := "https://" + host + "/api/v3"
+	c := &Adapter{
+		host:       host,
+		restURL:    restURL,
+		graphqlURL: graphqlEndpoint(restURL),
+		httpClient: a.httpClient,
+	}
+	if a.hosts == nil {
+		a.hosts = make(map[string]*Adapter)
+	}
+	a.hosts[host] = c
+	return c
+}
+
+// SupportsHost reports whether host is a GitHub host the adapter can serve:
+// its own host, or one gh knows about.
+func (a *Adapter) SupportsHost(host string) bool {
+	return host == a.host || slices.Contains(githubql.KnownHosts(), host)
+}
+
+// graphqlEndpoint derives the GraphQL URL from a REST API root.
+// GitHub Enterprise Server serves REST under /api/v3 and GraphQL under /api/graphql.
+func graphqlEndpoint(restURL string) string {
+	if base, ok := strings.CutSuffix(restURL, "/api/v3"); ok {
+		return base + "/api/graphql"
+	}
+	return restURL + "/graphql"
+}
+
+// RateLimit reports the API budget left on repo's host. The /rate_limit
+// endpoint does not count against the budget.
+func (a *Adapter) RateLimit(ctx context.Context, repo domain.RepoRef) (domain.RateLimit, error) {
+	if c := a.forHost(repo); c != a {
+		return c.RateLimit(ctx, repo)
+	}
+	out, err := a.doRequest(ctx, http.MethodGet, "rate_limit", nil, acceptJSON)
+	if err != nil {
+		return domain.RateLimit{}, fmt.Errorf("getting rate limit: %w", err)
+	}
+	return githubql.ParseRateLimit(out)
+}
@@ -0,0 +1,599 @@
+package ghapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/indrasvat/vivecaka/internal/adapter/githubql"
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// prFieldsFragment selects the list-level fields for a pull request,
+// including the head commit's status check rollup for CI status.
+const prFieldsFragment = `
+fragment prFields on PullRequest {
+  number
+  title
+  state
+  isDraft
+  url
+  createdAt
+  updatedAt
+  author { login }
+  headRefName
+  baseRefName
+  headRefOid
+  baseRefOid
+  reviewDecision
+  labels(first: 50) { nodes { name } }
+  commits(last: 1) {
+    nodes {
+      commit {
+        statusCheckRollup {
+          contexts(first: 100) {
+            nodes {
+              __typename
+              ... on CheckRun { name status conclusion startedAt completedAt detailsUrl }
+              ... on StatusContext { context state targetUrl }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+const listPRsQuery = `query($owner: String!, $name: String!, $states: [PullRequestState!], $labels: [String!], $first: Int!, $after: String) {
+  repository(owner: $owner, name: $name) {
+    pullRequests(states: $states, labels: $labels, first: $first, after: $after, orderBy: {field: CREATED_AT, direction: DESC}) {
+      nodes { ...prFields }
+      pageInfo { hasNextPage endCursor }
+    }
+  }
+}` + prFieldsFragment
+
+const searchPRsQuery = `query($q: String!, $first: Int!, $after: String) {
+  search(query: $q, type: ISSUE, first: $first, after: $after) {
+    nodes { ... on PullRequest { ...prFields } }
+    pageInfo { hasNextPage endCursor }
+  }
+}` + prFieldsFragment
+
+const prDetailQuery = `query($owner: String!, $name: String!, $number: Int!) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      ...prFields
+      body
+      assignees(first: 100) { nodes { login } }
+      reviewRequests(first: 100) {
+        nodes {
+          requestedReviewer {
+            __typename
+            ... on User { login }
+            ... on Bot { login }
+            ... on Mannequin { login }
+            ... on Team { name slug organization { login } }
+          }
+        }
+      }
+      latestReviews(first: 100) { nodes { author { login } state } }
+      files(first: 100) {
+        nodes { path additions deletions changeType }
+        pageInfo { hasNextPage endCursor }
+      }
+    }
+  }
+}` + prFieldsFragment
+
+const prFilesQuery = `query($owner: String!, $name: String!, $number: Int!, $after: String) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      files(first: 100, after: $after) {
+        nodes { path additions deletions changeType }
+        pageInfo { hasNextPage endCursor }
+      }
+    }
+  }
+}`
+
+const prCountQuery = `query($owner: String!, $name: String!, $states: [PullRequestState!]) {
+  repository(owner: $owner, name: $name) {
+    pullRequests(states: $states) { totalCount }
+  }
+}`
+
+// viewerCommentFields selects what the viewer may do with a comment and
+// its reactions.
+const viewerCommentFields = `viewerCanUpdate viewerCanDelete reactionGroups { content viewerHasReacted reactors { totalCount } }`
+
+const reviewThreadsQuery = `query($owner: String!, $name: String!, $number: Int!, $after: String) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      reviewThreads(first: 100, after: $after) {
+        nodes {
+          id
+          isResolved
+          path
+          line
+          startLine
+          comments(first: 100) {
+            nodes { id databaseId body createdAt url author { login } ` + viewerCommentFields + ` }
+            pageInfo { hasNextPage endCursor }
+          }
+        }
+        pageInfo { hasNextPage endCursor }
+      }
+    }
+  }
+}`
+
+const threadCommentsQuery = `query($id: ID!, $after: String) {
+  node(id: $id) {
+    ... on PullRequestReviewThread {
+      comments(first: 100, after: $after) {
+        nodes { id databaseId body createdAt url author { login } ` + viewerCommentFields + ` }
+        pageInfo { hasNextPage endCursor }
+      }
+    }
+  }
+}`
+
+const issueCommentsQuery = `query($owner: String!, $name: String!, $number: Int!, $after: String) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      comments(first: 100, after: $after) {
+        nodes { id body createdAt url author { login } ` + viewerCommentFields + ` }
+        pageInfo { hasNextPage endCursor }
+      }
+    }
+  }
+}`
+
+const reviewsQuery = `query($owner: String!, $name: String!, $number: Int!, $after: String) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      reviews(first: 100, after: $after) {
+        nodes { id body state submittedAt url author { login } ` + viewerCommentFields + ` }
+        pageInfo { hasNextPage endCursor }
+      }
+    }
+  }
+}`
+
+const defaultPerPage = 50
+
+// repoVars returns the owner/name GraphQL variables for a repo.
+func repoVars(repo domain.RepoRef) map[string]any {
+	return map[string]any{"owner": repo.Owner, "name": repo.Name}
+}
+
+// cursorVar returns nil for an empty cursor so GraphQL receives null.
+func cursorVar(cursor string) any {
+	if cursor == "" {
+		return nil
+	}
+	return cursor
+}
+
+// gqlStates maps a domain state filter to GraphQL PullRequestState values.
+// An empty state means open (matching gh); "all" means no state filter.
+func gqlStates(state domain.PRState) []string {
+	switch state {
+	case "":
+		return []string{"OPEN"}
+	case "all":
+		return nil
+	case domain.PRStateClosed:
+		return []string{"CLOSED"}
+	case domain.PRStateMerged:
+		return []string{"MERGED"}
+	default:
+		return []string{"OPEN"}
+	}
+}
+
+// GetPRCount fetches the total number of PRs in the given state via GraphQL.
+func (a *Adapter) GetPRCount(ctx context.Context, repo domain.RepoRef, state domain.PRState) (int, error) {
+	if c := a.forHost(repo); c != a {
+		return c.GetPRCount(ctx, repo, state)
+	}
+	vars := repoVars(repo)
+	vars["states"] = gqlStates(state)
+
+	var result struct {
+		Repository struct {
+			PullRequests struct {
+				TotalCount int `json:"totalCount"`
+			} `json:"pullRequests"`
+		} `json:"repository"`
+	}
+	if err := a.graphql(ctx, prCountQuery, vars, &result); err != nil {
+		return 0, fmt.Errorf("getting PR count: %w", err)
+	}
+	return result.Repository.PullRequests.TotalCount, nil
+}
+
+// GetCIStatuses fetches the head commit rollup of several PRs in one GraphQL
+// query.
+func (a *Adapter) GetCIStatuses(ctx context.Context, repo domain.RepoRef, numbers []int) (map[int]domain.CIStatus, error) {
+	if c := a.forHost(repo); c != a {
+		return c.GetCIStatuses(ctx, repo, numbers)
+	}
+	if len(numbers) == 0 {
+		return map[int]domain.CIStatus{}, nil
+	}
+	var result githubql.CIStatusResult
+	if err := a.graphql(ctx, githubql.CIStatusQuery(repo, numbers), nil, &result); err != nil {
+		return nil, fmt.Errorf("fetching CI statuses: %w", err)
+	}
+	return result.Statuses(), nil
+}
+
+// ListPRs fetches PRs via GraphQL. Author and free-text filters go through
+// the search API; everything else uses the repository pullRequests connection.
+// Page is 1-based; cursors are walked until the requested page is filled.
+func (a *Adapter) ListPRs(ctx context.Context, repo domain.RepoRef, opts domain.ListOpts) ([]domain.PR, error) {
+	if c := a.forHost(repo); c != a {
+		return c.ListPRs(ctx, repo, opts)
+	}
+	page := max(opts.Page, 1)
+	perPage := opts.PerPage
+	if perPage <= 0 {
+		perPage = defaultPerPage
+	}
+	want := page * perPage
+
+	fetch := a.pullRequestsPage(repo, opts)
+	if opts.Author != "" || opts.Search != "" {
+		fetch = a.searchPage(repo, opts)
+	}
+
+	// Apply the client-side draft filter while walking pages so that
+	// excluded drafts don't reduce the effective page size.
+	var (
+		filtered []gqlPR
+		cursor   string
+	)
+	for len(filtered) < want {
+		nodes, info, err := fetch(ctx, perPage, cursor)
+		if err != nil {
+			return nil, fmt.Errorf("listing PRs: %w", err)
+		}
+		for _, n := range nodes {
+			if n.Number == 0 || !matchesDraft(n.IsDraft, opts.Draft) {
+				continue
+			}
+			filtered = append(filtered, n)
+		}
+		if !info.HasNextPage {
+			break
+		}
+		cursor = info.EndCursor
+	}
+
+	startIdx := (page - 1) * perPage
+	if startIdx >= len(filtered) {
+		return []domain.PR{}, nil
+	}
+	end := min(startIdx+perPage, len(filtered))
+
+	prs := make([]domain.PR, 0, end-startIdx)
+	for _, g := range filtered[startIdx:end] {
+		prs = append(prs, toDomainPR(g))
+	}
+	return prs, nil
+}
+
+// ListPRPage fetches one page of PRs, resuming at opts.Cursor. Without a
+// cursor it falls back to ListPRs' page-number walk. Drafts excluded by
+// opts.Draft are skipped while filling the page, so a page may run over
+// PerPage by up to one GraphQL page.
+func (a *Adapter) ListPRPage(ctx context.Context, repo domain.RepoRef, opts domain.ListOpts) (domain.PRPage, error) {
+	if c := a.forHost(repo); c != a {
+		return c.ListPRPage(ctx, repo, opts)
+	}
+	perPage := opts.PerPage
+	if perPage <= 0 {
+		perPage = defaultPerPage
+	}
+	if opts.Cursor == "" && opts.Page > 1 {
+		prs, err := a.ListPRs(ctx, repo, opts)
+		if err != nil {
+			return domain.PRPage{}, err
+		}
+		return domain.PRPage{PRs: prs, HasMore: len(prs) >= perPage}, nil
+	}
+
+	fetch := a.pullRequestsPage(repo, opts)
+	if opts.Author != "" || opts.Search != "" {
+		fetch = a.searchPage(repo, opts)
+	}
+
+	var page domain.PRPage
+	cursor := opts.Cursor
+	for len(page.PRs) < perPage {
+		nodes, info, err := fetch(ctx, perPage, cursor)
+		if err != nil {
+			return domain.PRPage{}, fmt.Errorf("listing PRs: %w", err)
+		}
+		for _, n := range nodes {
+			if n.Number == 0 || !matchesDraft(n.IsDraft, opts.Draft) {
+				continue
+			}
+			page.PRs = append(page.PRs, toDomainPR(n))
+		}
+		if !info.HasNextPage {
+			return page, nil
+		}
+		cursor = info.EndCursor
+	}
+	page.NextCursor = cursor
+	page.HasMore = true
+	return page, nil
+}
+
+type prPageFunc func(ctx context.Context, first int, cursor string) ([]gqlPR, pageInfo, error)
+
+func (a *Adapter) pullRequestsPage(repo domain.RepoRef, opts domain.ListOpts) prPageFunc {
+	return func(ctx context.Context, first int, cursor string) ([]gqlPR, pageInfo, error) {
+		vars := repoVars(repo)
+		vars["states"] = gqlStates(opts.State)
+		vars["first"] = first
+		vars["after"] = cursorVar(cursor)
+		if len(opts.Labels) > 0 {
+			vars["labels"] = opts.Labels
+		}
+
+		var result struct {
+			Repository struct {
+				PullRequests struct {
+					Nodes    []gqlPR  `json:"nodes"`
+					PageInfo pageInfo `json:"pageInfo"`
+				} `json:"pullRequests"`
+			} `json:"repository"`
+		}
+		if err := a.graphql(ctx, listPRsQuery, vars, &result); err != nil {
+			return nil, pageInfo{}, err
+		}
+		conn := result.Repository.PullRequests
+		return conn.Nodes, conn.PageInfo, nil
+	}
+}
+
+func (a *Adapter) searchPage(repo domain.RepoRef, opts domain.ListOpts) prPageFunc {
+	query := githubql.SearchQuery(repo, opts)
+	return func(ctx context.Context, first int, cursor string) ([]gqlPR, pageInfo, error) {
+		vars := map[string]any{
+			"q":     query,
+			"first": first,
+			"after": cursorVar(cursor),
+		}
+		var result struct {
+			Search struct {
+				Nodes    []gqlPR  `json:"nodes"`
+				PageInfo pageInfo `json:"pageInfo"`
+			} `json:"search"`
+		}
+		if err := a.graphql(ctx, searchPRsQuery, vars, &result); err != nil {
+			return nil, pageInfo{}, err
+		}
+		return result.Search.Nodes, result.Search.PageInfo, nil
+	}
+}
+
+func matchesDraft(isDraft bool, filter domain.DraftFilter) bool {
+	switch filter {
+	case domain.DraftExclude:
+		return !isDraft
+	case domain.DraftOnly:
+		return isDraft
+	default:
+		return true
+	}
+}
+
+// GetPR fetches a single PR with full details via GraphQL.
+func (a *Adapter) GetPR(ctx context.Context, repo domain.RepoRef, number int) (*domain.PRDetail, error) {
+	if c := a.forHost(repo); c != a {
+		return c.GetPR(ctx, repo, number)
+	}
+	vars := repoVars(repo)
+	vars["number"] = number
+
+	var result struct {
+		Repository struct {
+			PullRequest *gqlPRDetail `json:"pullRequest"`
+		} `json:"repository"`
+	}
+	if err := a.graphql(ctx, prDetailQuery, vars, &result); err != nil {
+		return nil, fmt.Errorf("getting PR #%d: %w", number, err)
+	}
+	g := result.Repository.PullRequest
+	if g == nil {
+		return nil, fmt.Errorf("getting PR #%d: %w", number, domain.ErrNotFound)
+	}
+
+	cursor := g.Files.PageInfo.EndCursor
+	for more := g.Files.PageInfo.HasNextPage; more; {
+		page, err := a.fetchFilesPage(ctx, repo, number, cursor)
+		if err != nil {
+			return nil, fmt.Errorf("getting files for PR #%d: %w", number, err)
+		}
+		g.Files.Nodes = append(g.Files.Nodes, page.Nodes...)
+		more, cursor = page.PageInfo.HasNextPage, page.PageInfo.EndCursor
+	}
+
+	detail := toDomainPRDetail(*g)
+	return &detail, nil
+}
+
+func (a *Adapter) fetchFilesPage(ctx context.Context, repo domain.RepoRef, number int, cursor string) (*gqlFilesPage, error) {
+	vars := repoVars(repo)
+	vars["number"] = number
+	vars["after"] = cursorVar(cursor)
+
+	var result struct {
+		Repository struct {
+			PullRequest struct {
+				Files gqlFilesPage `json:"files"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	}
+	if err := a.graphql(ctx, prFilesQuery, vars, &result); err != nil {
+		return nil, err
+	}
+	return &result.Repository.PullRequest.Files, nil
+}
+
+// GetDiff fetches the raw unified diff for a PR via REST and parses it.
+func (a *Adapter) GetDiff(ctx context.Context, repo domain.RepoRef, number int) (*domain.Diff, error) {
+	if c := a.forHost(repo); c != a {
+		return c.GetDiff(ctx, repo, number)
+	}
+	path := fmt.Sprintf("repos/%s/pulls/%d", repo.FullName(), number)
+	out, err := a.doRequest(ctx, http.MethodGet, path, nil, acceptDiff)
+	if err != nil {
+		return nil, fmt.Errorf("getting diff for PR #%d: %w", number, err)
+	}
+	diff := domain.ParseDiff(string(out))
+	return &diff, nil
+}
+
+// GetChecks fetches CI check results for a PR's head commit.
+func (a *Adapter) GetChecks(ctx context.Context, repo domain.RepoRef, number int) ([]domain.Check, error) {
+	if c := a.forHost(repo); c != a {
+		return c.GetChecks(ctx, repo, number)
+	}
+	detail, err := a.GetPR(ctx, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("getting checks for PR #%d: %w", number, err)
+	}
+	return detail.Checks, nil
+}
+
+// GetComments fetches inline review threads for a PR via GraphQL.
+func (a *Adapter) GetComments(ctx context.Context, repo domain.RepoRef, number int) ([]domain.CommentThread, error) {
+	if c := a.forHost(repo); c != a {
+		return c.GetComments(ctx, repo, number)
+	}
+	var (
+		cursor  string
+		threads []domain.CommentThread
+	)
+	for {
+		vars := repoVars(repo)
+		vars["number"] = number
+		vars["after"] = cursorVar(cursor)
+
+		var result struct {
+			Repository struct {
+				PullRequest struct {
+					ReviewThreads struct {
+						Nodes    []gqlReviewThread `json:"nodes"`
+						PageInfo pageInfo          `json:"pageInfo"`
+					} `json:"reviewThreads"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		}
+		if err := a.graphql(ctx, reviewThreadsQuery, vars, &result); err != nil {
+			return nil, fmt.Errorf("getting comments for PR #%d: %w", number, err)
+		}
+		page := result.Repository.PullRequest.ReviewThreads
+		for i := range page.Nodes {
+			if err := a.expandThreadComments(ctx, &page.Nodes[i]); err != nil {
+				return nil, fmt.Errorf("getting comments for PR #%d: %w", number, err)
+			}
+		}
+		threads = append(threads, toDomainCommentThreads(page.Nodes)...)
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+		cursor = page.PageInfo.EndCursor
+	}
+	return threads, nil
+}
+
+// expandThreadComments fetches the remaining comment pages for long threads.
+func (a *Adapter) expandThreadComments(ctx context.Context, thread *gqlReviewThread) error {
+	conn := thread.Comments
+	for conn.PageInfo.HasNextPage {
+		var result struct {
+			Node struct {
+				Comments gqlCommentConnection `json:"comments"`
+			} `json:"node"`
+		}
+		vars := map[string]any{"id": thread.ID, "after": cursorVar(conn.PageInfo.EndCursor)}
+		if err := a.graphql(ctx, threadCommentsQuery, vars, &result); err != nil {
+			return err
+		}
+		thread.Comments.Nodes = append(thread.Comments.Nodes, result.Node.Comments.Nodes...)
+		conn = result.Node.Comments
+	}
+	thread.Comments.PageInfo = pageInfo{}
+	return nil
+}
+
+// GetDiscussion fetches non-inline PR discussion items (review bodies + top-level PR comments).
+func (a *Adapter) GetDiscussion(ctx context.Context, repo domain.RepoRef, number int) ([]domain.DiscussionItem, error) {
+	if c := a.forHost(repo); c != a {
+		return c.GetDiscussion(ctx, repo, number)
+	}
+	var discussion []domain.DiscussionItem
+
+	cursor := ""
+	for {
+		vars := repoVars(repo)
+		vars["number"] = number
+		vars["after"] = cursorVar(cursor)
+
+		var result struct {
+			Repository struct {
+				PullRequest struct {
+					Comments struct {
+						Nodes    []gqlIssueComment `json:"nodes"`
+						PageInfo pageInfo          `json:"pageInfo"`
+					} `json:"comments"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		}
+		if err := a.graphql(ctx, issueCommentsQuery, vars, &result); err != nil {
+			return nil, fmt.Errorf("getting PR conversation comments for PR #%d: %w", number, err)
+		}
+		page := result.Repository.PullRequest.Comments
+		discussion = append(discussion, toDomainIssueComments(page.Nodes)...)
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+		cursor = page.PageInfo.EndCursor
+	}
+
+	cursor = ""
+	for {
+		vars := repoVars(repo)
+		vars["number"] = number
+		vars["after"] = cursorVar(cursor)
+
+		var result struct {
+			Repository struct {
+				PullRequest struct {
+					Reviews struct {
+						Nodes    []gqlReview `json:"nodes"`
+						PageInfo pageInfo    `json:"pageInfo"`
+					} `json:"reviews"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		}
+		if err := a.graphql(ctx, reviewsQuery, vars, &result); err != nil {
+			return nil, fmt.Errorf("getting reviews for PR #%d: %w", number, err)
+		}
+		page := result.Repository.PullRequest.Reviews
+		discussion = append(discussion, toDomainReviewItems(page.Nodes)...)
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+		cursor = page.PageInfo.EndCursor
+	}
+
+	return discussion, nil
+}
@@ -0,0 +1,339 @@
+package ghapi
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+var testRepo = domain.RepoRef{Owner: "owner", Name: "repo"}
+
+func prNode(number int, draft bool) map[string]any {
+	return map[string]any{
+		"number":         number,
+		"title":          fmt.Sprintf("PR %d", number),
+		"state":          "OPEN",
+		"isDraft":        draft,
+		"url":            fmt.Sprintf("https://github.com/owner/repo/pull/%d", number),
+		"createdAt":      "2026-01-02T03:04:05Z",
+		"updatedAt":      "2026-01-03T03:04:05Z",
+		"author":         map[string]any{"login": "alice"},
+		"headRefName":    "feat",
+		"baseRefName":    "main",
+		"headRefOid":     "1111",
+		"baseRefOid":     "aaaa",
+		"reviewDecision": "APPROVED",
+		"labels":         map[string]any{"nodes": []map[string]any{{"name": "bug"}}},
+		"commits": map[string]any{"nodes": []map[string]any{{
+			"commit": map[string]any{"statusCheckRollup": map[string]any{
+				"contexts": map[string]any{"nodes": []map[string]any{
+					{"__typename": "CheckRun", "name": "build", "status": "COMPLETED", "conclusion": "SUCCESS"},
+					{"__typename": "StatusContext", "context": "ci/legacy", "state": "PENDING"},
+				}},
+			}},
+		}}},
+	}
+}
+
+func TestListPRsWalksCursorsAndFiltersDrafts(t *testing.T) {
+	pages := map[string]map[string]any{
+		"": {
+			"nodes":    []map[string]any{prNode(10, false), prNode(9, true)},
+			"pageInfo": map[string]any{"hasNextPage": true, "endCursor": "c1"},
+		},
+		"c1": {
+			"nodes":    []map[string]any{prNode(8, false), prNode(7, false)},
+			"pageInfo": map[string]any{"hasNextPage": false, "endCursor": "c2"},
+		},
+	}
+	var calls int
+	a := newTestAdapter(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		req := decodeGQL(t, r)
+		assert.Contains(t, req.Query, "pullRequests(")
+		assert.Equal(t, []any{"OPEN"}, req.Variables["states"])
+		cursor, _ := req.Variables["after"].(string)
+		writeJSON(w, map[string]any{"data": map[string]any{
+			"repository": map[string]any{"pullRequests": pages[cursor]},
+		}})
+	}))
+
+	prs, err := a.ListPRs(t.Context(), testRepo, domain.ListOpts{PerPage: 2, Draft: domain.DraftExclude})
+	require.NoError(t, err)
+	require.Len(t, prs, 2)
+	assert.Equal(t, 10, prs[0].Number)
+	assert.Equal(t, 8, prs[1].Number)
+	assert.Equal(t, 2, calls)
+
+	pr := prs[0]
+	assert.Equal(t, "alice", pr.Author)
+	assert.Equal(t, []string{"bug"}, pr.Labels)
+	assert.Equal(t, domain.CIPending, pr.CI)
+	assert.Equal(t, domain.ReviewApproved, pr.Review.State)
+	assert.Equal(t, "1111", pr.Branch.HeadSHA)
+}
+
+func TestListPRPageResumesAtCursor(t *testing.T) {
+	var cursors []any
+	a := newTestAdapter(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := decodeGQL(t, r)
+		cursors = append(cursors, req.Variables["after"])
+		writeJSON(w, map[string]any{"data": map[string]any{
+			"repository": map[string]any{"pullRequests": map[string]any{
+				"nodes":    []map[string]any{prNode(8, false), prNode(7, false)},
+				"pageInfo": map[string]any{"hasNextPage": true, "endCursor": "c2"},
+			}},
+		}})
+	}))
+
+	page, err := a.ListPRPage(t.Context(), testRepo, domain.ListOpts{PerPage: 2, Cursor: "c1"})
+	require.NoError(t, err)
+	assert.Equal(t, []any{"c1"}, cursors, "only the requested page is fetched")
+	require.Len(t, page.PRs, 2)
+	assert.Equal(t, 8, page.PRs[0].Number)
+	assert.Equal(t, domain.CIPending, page.PRs[0].CI)
+	assert.True(t, page.HasMore)
+	assert.Equal(t, "c2", page.NextCursor)
+}
+
+func TestListPRsUsesSearchForAuthor(t *testing.T) {
+	a := newTestAdapter(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := decodeGQL(t, r)
+		assert.Equal(t, "repo:owner/repo is:pr is:merged author:bob fix typo sort:created-desc", req.Variables["q"])
+		writeJSON(w, map[string]any{"data": map[string]any{
+			"search": map[string]any{
+				"nodes":    []map[string]any{prNode(3, false)},
+				"pageInfo": map[string]any{"hasNextPage": false},
+			},
+		}})
+	}))
+
+	prs, err := a.ListPRs(t.Context(), testRepo, domain.ListOpts{
+		State:  domain.PRStateMerged,
+		Author: "bob",
+		Search: "fix typo",
+	})
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	assert.Equal(t, 3, prs[0].Number)
+}
+
+func TestGetPRFetchesAllFilePages(t *testing.T) {
+	a := newTestAdapter(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := decodeGQL(t, r)
+		if strings.Contains(req.Query, "body") {
+			detail := prNode(42, false)
+			detail["body"] = "Description"
+			detail["assignees"] = map[string]any{"nodes": []map[string]any{{"login": "carol"}}}
+			detail["reviewRequests"] = map[string]any{"nodes": []map[string]any{
+				{"requestedReviewer": map[string]any{"login": "dave"}},
+				{"requestedReviewer": map[string]any{"name": "Core Team", "slug": "core-team", "organization": map[string]any{"login": "acme"}}},
+			}}
+			detail["latestReviews"] = map[string]any{"nodes": []map[string]any{
+				{"author": map[string]any{"login": "erin"}, "state": "CHANGES_REQUESTED"},
+			}}
+			detail["files"] = map[string]any{
+				"nodes":    []map[string]any{{"path": "a.go", "additions": 1, "deletions": 2, "changeType": "MODIFIED"}},
+				"pageInfo": map[string]any{"hasNextPage": true, "endCursor": "f1"},
+			}
+			writeJSON(w, map[string]any{"data": map[string]any{"repository": map[string]any{"pullRequest": detail}}})
+			return
+		}
+		assert.Equal(t, "f1", req.Variables["after"])
+		writeJSON(w, map[string]any{"data": map[string]any{"repository": map[string]any{"pullRequest": map[string]any{
+			"files": map[string]any{
+				"nodes":    []map[string]any{{"path": "b.go", "additions": 5, "changeType": "ADDED"}},
+				"pageInfo": map[string]any{"hasNextPage": false},
+			},
+		}}}})
+	}))
+
+	pr, err := a.GetPR(t.Context(), testRepo, 42)
+	require.NoError(t, err)
+	assert.Equal(t, "Description", pr.Body)
+	assert.Equal(t, []string{"carol"}, pr.Assignees)
+	require.Len(t, pr.Reviewers, 3)
+	assert.Equal(t, "acme/core-team", pr.Reviewers[1].Login)
+	assert.Equal(t, domain.ReviewChangesRequested, pr.Reviewers[2].State)
+	require.Len(t, pr.Files, 2)
+	assert.Equal(t, "removed", mapFileStatus("DELETED"))
+	assert.Equal(t, "added", pr.Files[1].Status)
+	require.Len(t, pr.Checks, 2)
+	assert.Equal(t, domain.CIPass, pr.Checks[0].Status)
+	assert.Equal(t, "ci/legacy", pr.Checks[1].Name)
+}
+
+func TestGetPRMissingReturnsNotFound(t *testing.T) {
+	a := newTestAdapter(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{"data": map[string]any{"repository": map[string]any{"pullRequest": nil}}})
+	}))
+
+	_, err := a.GetPR(t.Context(), testRepo, 1)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestGetDiffRequestsDiffMediaType(t *testing.T) {
+	raw, err := os.ReadFile("../ghcli/testdata/pr_diff.txt")
+	require.NoError(t, err)
+
+	a := newTestAdapter(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/owner/repo/pulls/42", r.URL.Path)
+		assert.Equal(t, acceptDiff, r.Header.Get("Accept"))
+		_, _ = w.Write(raw)
+	}))
+
+	diff, err := a.GetDiff(t.Context(), testRepo, 42)
+	require.NoError(t, err)
+	assert.NotEmpty(t, diff.Files)
+}
+
+func TestGetCommitsWalksPages(t *testing.T) {
+	commit := func(oid string) map[string]any {
+		return map[string]any{"commit": map[string]any{
+			"oid": oid, "messageHeadline": "Commit " + oid, "authoredDate": "2026-01-02T03:04:05Z",
+			"author": map[string]any{"name": "Alice", "user": map[string]any{"login": "alice"}},
+		}}
+	}
+	var cursors []string
+	a := newTestAdapter(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := decodeGQL(t, r)
+		page := map[string]any{"nodes": []any{commit("aaa")}, "pageInfo": map[string]any{"hasNextPage": true, "endCursor": "c1"}}
+		cursors = append(cursors, "first")
+		if strings.Contains(req.Query, `after: "c1"`) {
+			page = map[string]any{"nodes": []any{commit("bbb")}, "pageInfo": map[string]any{"hasNextPage": false}}
+			cursors[len(cursors)-1] = "c1"
+		}
+		writeJSON(w, map[string]any{"data": map[string]any{
+			"repository": map[string]any{"pullRequest": map[string]any{"commits": page}},
+		}})
+	}))
+
+	commits, err := a.GetCommits(t.Context(), testRepo, 42)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "c1"}, cursors)
+	require.Len(t, commits, 2)
+	assert.Equal(t, "aaa", commits[0].SHA)
+	assert.Equal(t, "alice", commits[1].Author)
+}
+
+func TestCompareCommitsRequestsDiffMediaType(t *testing.T) {
+	raw, err := os.ReadFile("../ghcli/testdata/pr_diff.txt")
+	require.NoError(t, err)
+
+	a := newTestAdapter(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/owner/repo/compare/aaa...bbb", r.URL.Path)
+		assert.Equal(t, acceptDiff, r.Header.Get("Accept"))
+		_, _ = w.Write(raw)
+	}))
+
+	diff, err := a.CompareCommits(t.Context(), testRepo, "aaa", "bbb")
+	require.NoError(t, err)
+	assert.NotEmpty(t, diff.Files)
+}
+
+func TestGetCommentsExpandsLongThreads(t *testing.T) {
+	comment := func(id int, body string) map[string]any {
+		return map[string]any{
+			"id": fmt.Sprintf("node-%d", id), "databaseId": id, "body": body,
+			"createdAt": "2026-01-02T03:04:05Z", "author": map[string]any{"login": "alice"},
+		}
+	}
+	a := newTestAdapter(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := decodeGQL(t, r)
+		if strings.Contains(req.Query, "node(id:") {
+			assert.Equal(t, "T1", req.Variables["id"])
+			writeJSON(w, map[string]any{"data": map[string]any{"node": map[string]any{"comments": map[string]any{
+				"nodes":    []map[string]any{comment(2, "reply")},
+				"pageInfo": map[string]any{"hasNextPage": false},
+			}}}})
+			return
+		}
+		writeJSON(w, map[string]any{"data": map[string]any{"repository": map[string]any{"pullRequest": map[string]any{
+			"reviewThreads": map[string]any{
+				"nodes": []map[string]any{{
+					"id": "T1", "isResolved": true, "path": "main.go", "line": 12,
+					"comments": map[string]any{
+						"nodes":    []map[string]any{comment(1, "root")},
+						"pageInfo": map[string]any{"hasNextPage": true, "endCursor": "x"},
+					},
+				}},
+				"pageInfo": map[string]any{"hasNextPage": false},
+			},
+		}}}})
+	}))
+
+	threads, err := a.GetComments(t.Context(), testRepo, 42)
+	require.NoError(t, err)
+	require.Len(t, threads, 1)
+	th := threads[0]
+	assert.Equal(t, "T1", th.ThreadID)
+	assert.Equal(t, "1", th.ReplyToID)
+	assert.Equal(t, 12, th.Line)
+	assert.True(t, th.Resolved)
+	require.Len(t, th.Comments, 2)
+	assert.Equal(t, "reply", th.Comments[1].Body)
+	assert.Equal(t, "node-2", th.Comments[1].NodeID)
+}
+
+func TestGetDiscussionReadsViewerFields(t *testing.T) {
+	a := newTestAdapter(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := decodeGQL(t, r)
+		assert.Contains(t, req.Query, "viewerCanUpdate")
+		conn := map[string]any{"pageInfo": map[string]any{"hasNextPage": false}}
+		if strings.Contains(req.Query, "reviews(") {
+			conn["nodes"] = []map[string]any{}
+			writeJSON(w, map[string]any{"data": map[string]any{"repository": map[string]any{"pullRequest": map[string]any{"reviews": conn}}}})
+			return
+		}
+		conn["nodes"] = []map[string]any{{
+			"id": "IC_1", "body": "hi", "createdAt": "2026-01-02T03:04:05Z", "author": map[string]any{"login": "me"},
+			"viewerCanUpdate": true, "viewerCanDelete": false,
+			"reactionGroups": []map[string]any{
+				{"content": "HEART", "viewerHasReacted": false, "reactors": map[string]any{"totalCount": 2}},
+				{"content": "EYES", "viewerHasReacted": false, "reactors": map[string]any{"totalCount": 0}},
+			},
+		}}
+		writeJSON(w, map[string]any{"data": map[string]any{"repository": map[string]any{"pullRequest": map[string]any{"comments": conn}}}})
+	}))
+
+	items, err := a.GetDiscussion(t.Context(), testRepo, 42)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	c := items[0].Comments[0]
+	assert.Equal(t, "IC_1", c.NodeID)
+	assert.True(t, c.CanEdit)
+	assert.False(t, c.CanDelete)
+	assert.Equal(t, []domain.Reaction{{Content: domain.ReactionHeart, Count: 2}}, c.Reactions)
+}
+
+func TestGetCIStatusesBatchesOneQuery(t *testing.T) {
+	calls := 0
+	a := newTestAdapter(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		req := decodeGQL(t, r)
+		assert.Contains(t, req.Query, "pr4: pullRequest(number: 4)")
+		assert.Contains(t, req.Query, "pr5: pullRequest(number: 5)")
+		writeJSON(w, map[string]any{"data": map[string]any{
+			"repository": map[string]any{
+				"pr4": map[string]any{"number": 4, "commits": map[string]any{"nodes": []map[string]any{
+					{"commit": map[string]any{"statusCheckRollup": map[string]any{"state": "SUCCESS"}}},
+				}}},
+				"pr5": map[string]any{"number": 5, "commits": map[string]any{"nodes": []map[string]any{
+					{"commit": map[string]any{"statusCheckRollup": map[string]any{"state": "PENDING"}}},
+				}}},
+			},
+		}})
+	}))
+
+	statuses, err := a.GetCIStatuses(t.Context(), testRepo, []int{4, 5})
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, map[int]domain.CIStatus{4: domain.CIPass, 5: domain.CIPending}, statuses)
+}
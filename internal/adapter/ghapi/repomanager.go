@@ -0,0 +1,56 @@
+package ghapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/gitutil"
+)
+
+// CheckoutAt fetches a PR's head ref and checks it out as a local branch named
+// after the PR's head branch. If workDir is "", uses the process CWD.
+// An existing local branch is fast-forwarded rather than reset.
+func (a *Adapter) CheckoutAt(ctx context.Context, repo domain.RepoRef, number int, workDir string) (string, error) {
+	if c := a.forHost(repo); c != a {
+		return c.CheckoutAt(ctx, repo, number, workDir)
+	}
+	pr, err := a.getRestPull(ctx, repo, number)
+	if err != nil {
+		return "", fmt.Errorf("checking out PR #%d: %w", number, err)
+	}
+	branch := pr.Head.Ref
+	if branch == "" {
+		branch = fmt.Sprintf("pr-%d", number)
+	}
+	if err := gitutil.CheckoutRef(ctx, workDir, fmt.Sprintf("pull/%d/head", number), branch); err != nil {
+		return "", fmt.Errorf("checking out PR #%d: %w", number, err)
+	}
+	return branch, nil
+}
+
+// CloneRepo clones a repository to the specified local path over HTTPS.
+// If the target path exists and is a valid clone, it skips cloning and fetches instead.
+func (a *Adapter) CloneRepo(ctx context.Context, repo domain.RepoRef, targetPath string) error {
+	if c := a.forHost(repo); c != a {
+		return c.CloneRepo(ctx, repo, targetPath)
+	}
+	if err := gitutil.Clone(ctx, a.cloneURL(repo), targetPath); err != nil {
+		return fmt.Errorf("cloning %s: %w", repo, err)
+	}
+	return nil
+}
+
+// CreateWorktree creates a git worktree for a PR branch at the given path.
+// It fetches the PR ref into a unique local branch (pr-<number>) first.
+func (a *Adapter) CreateWorktree(ctx context.Context, _ domain.RepoRef, repoPath string, number int, _ string, worktreePath string) error {
+	if err := gitutil.AddWorktree(ctx, repoPath, fmt.Sprintf("pull/%d/head", number), fmt.Sprintf("pr-%d", number), worktreePath); err != nil {
+		return fmt.Errorf("creating worktree for PR #%d: %w", number, err)
+	}
+	return nil
+}
+
+// cloneURL returns the HTTPS clone URL for a repo on the adapter's host.
+func (a *Adapter) cloneURL(repo domain.RepoRef) string {
+	return fmt.Sprintf("https://%s/%s.git", a.host, repo.FullName())
+}
@@ -0,0 +1,98 @@
+package ghapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+const resolveThreadMutation = `mutation($id: ID!) { resolveReviewThread(input: {threadId: $id}) { thread { isResolved } } }`
+
+// SubmitReview submits a review, with its inline comments, via the pull
+// request reviews REST endpoint.
+func (a *Adapter) SubmitReview(ctx context.Context, repo domain.RepoRef, number int, review domain.Review) error {
+	if c := a.forHost(repo); c != a {
+		return c.SubmitReview(ctx, repo, number, review)
+	}
+	var event string
+	switch review.Action {
+	case domain.ReviewActionApprove:
+		event = "APPROVE"
+	case domain.ReviewActionRequestChanges:
+		event = "REQUEST_CHANGES"
+	case domain.ReviewActionComment:
+		event = "COMMENT"
+	default:
+		return fmt.Errorf("unknown review action: %q", review.Action)
+	}
+
+	body := map[string]any{"event": event}
+	if review.Body != "" {
+		body["body"] = review.Body
+	}
+	if len(review.Comments) > 0 {
+		comments := make([]map[string]any, 0, len(review.Comments))
+		for _, c := range review.Comments {
+			comments = append(comments, commentFields(c))
+		}
+		body["comments"] = comments
+	}
+
+	path := fmt.Sprintf("repos/%s/pulls/%d/reviews", repo.FullName(), number)
+	if err := a.restJSON(ctx, http.MethodPost, path, body, nil); err != nil {
+		return fmt.Errorf("submitting review for PR #%d: %w", number, err)
+	}
+	return nil
+}
+
+// AddComment adds an inline review comment via the REST API.
+func (a *Adapter) AddComment(ctx context.Context, repo domain.RepoRef, number int, input domain.InlineCommentInput) error {
+	if c := a.forHost(repo); c != a {
+		return c.AddComment(ctx, repo, number, input)
+	}
+	body := commentFields(input)
+	body["commit_id"] = input.CommitID
+	if input.InReplyTo != "" {
+		id, err := strconv.ParseInt(input.InReplyTo, 10, 64)
+		if err != nil {
+			return fmt.Errorf("adding comment to PR #%d: invalid reply target %q", number, input.InReplyTo)
+		}
+		body["in_reply_to"] = id
+	}
+
+	path := fmt.Sprintf("repos/%s/pulls/%d/comments", repo.FullName(), number)
+	if err := a.restJSON(ctx, http.MethodPost, path, body, nil); err != nil {
+		return fmt.Errorf("adding comment to PR #%d: %w", number, err)
+	}
+	return nil
+}
+
+// commentFields returns the REST fields that place and word an inline
+// comment, including the first line of a range.
+func commentFields(c domain.InlineCommentInput) map[string]any {
+	fields := map[string]any{
+		"body": c.Body,
+		"path": c.Path,
+		"line": c.Line,
+		"side": c.Side,
+	}
+	if c.IsRange() {
+		fields["start_line"] = c.StartLine
+		fields["start_side"] = c.StartSide
+	}
+	return fields
+}
+
+// ResolveThread resolves a review comment thread via the GraphQL API.
+func (a *Adapter) ResolveThread(ctx context.Context, repo domain.RepoRef, threadID string) error {
+	if c := a.forHost(repo); c != a {
+		return c.ResolveThread(ctx, repo, threadID)
+	}
+	if err := a.graphql(ctx, resolveThreadMutation, map[string]any{"id": threadID}, nil); err != nil {
+		return fmt.Errorf("resolving thread %s: %w", threadID, err)
+	}
+	return nil
+}
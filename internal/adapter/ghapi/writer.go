@@ -0,0 +1,139 @@
+package ghapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/indrasvat/vivecaka/internal/adapter/githubql"
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+var _ domain.MergeStatusReader = (*Adapter)(nil)
+
+// restPull is the subset of the REST pull request object used by write operations.
+type restPull struct {
+	NodeID string `json:"node_id"`
+	Head   struct {
+		Ref  string `json:"ref"`
+		Repo *struct {
+			FullName string `json:"full_name"`
+		} `json:"repo"`
+	} `json:"head"`
+}
+
+func (a *Adapter) getRestPull(ctx context.Context, repo domain.RepoRef, number int) (*restPull, error) {
+	var pr restPull
+	path := fmt.Sprintf("repos/%s/pulls/%d", repo.FullName(), number)
+	if err := a.restJSON(ctx, http.MethodGet, path, nil, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// Checkout checks out a PR branch in the process CWD.
+func (a *Adapter) Checkout(ctx context.Context, repo domain.RepoRef, number int) (string, error) {
+	if c := a.forHost(repo); c != a {
+		return c.Checkout(ctx, repo, number)
+	}
+	return a.CheckoutAt(ctx, repo, number, "")
+}
+
+// enableAutoMergeMutation turns on auto-merge for the PR $id.
+const enableAutoMergeMutation = `mutation($id: ID!, $method: PullRequestMergeMethod!, $title: String, $body: String) {
+  enablePullRequestAutoMerge(input: {pullRequestId: $id, mergeMethod: $method, commitHeadline: $title, commitBody: $body}) { clientMutationId }
+}`
+
+// Merge merges a PR via the REST merge endpoint, or enables auto-merge via
+// GraphQL with opts.Auto.
+func (a *Adapter) Merge(ctx context.Context, repo domain.RepoRef, number int, opts domain.MergeOpts) error {
+	if c := a.forHost(repo); c != a {
+		return c.Merge(ctx, repo, number, opts)
+	}
+	method := opts.Method
+	switch method {
+	case domain.MergeMethodSquash, domain.MergeMethodRebase:
+	default:
+		method = domain.MergeMethodMerge
+	}
+	if opts.Auto {
+		return a.enableAutoMerge(ctx, repo, number, method, opts)
+	}
+
+	body := map[string]any{"merge_method": method}
+	if opts.CommitTitle != "" {
+		body["commit_title"] = opts.CommitTitle
+	}
+	if opts.CommitMessage != "" {
+		body["commit_message"] = opts.CommitMessage
+	}
+
+	// Look up the head branch before merging; it is needed for deletion.
+	var head *restPull
+	if opts.DeleteBranch {
+		pr, err := a.getRestPull(ctx, repo, number)
+		if err != nil {
+			return fmt.Errorf("merging PR #%d: %w", number, err)
+		}
+		head = pr
+	}
+
+	path := fmt.Sprintf("repos/%s/pulls/%d/merge", repo.FullName(), number)
+	if err := a.restJSON(ctx, http.MethodPut, path, body, nil); err != nil {
+		return fmt.Errorf("merging PR #%d: %w", number, err)
+	}
+
+	// Only delete branches that live in the base repo; fork branches are not ours.
+	if head != nil && head.Head.Repo != nil && strings.EqualFold(head.Head.Repo.FullName, repo.FullName()) {
+		refPath := fmt.Sprintf("repos/%s/git/refs/heads/%s", repo.FullName(), escapeRef(head.Head.Ref))
+		if err := a.restJSON(ctx, http.MethodDelete, refPath, nil, nil); err != nil {
+			return fmt.Errorf("deleting branch %s: %w", head.Head.Ref, err)
+		}
+	}
+	return nil
+}
+
+// enableAutoMerge turns on auto-merge with method. The head branch is left
+// to the repo's delete-branch-on-merge setting, since it outlives this call.
+func (a *Adapter) enableAutoMerge(ctx context.Context, repo domain.RepoRef, number int, method string, opts domain.MergeOpts) error {
+	pr, err := a.getRestPull(ctx, repo, number)
+	if err != nil {
+		return fmt.Errorf("enabling auto-merge on PR #%d: %w", number, err)
+	}
+	vars := map[string]any{"id": pr.NodeID, "method": strings.ToUpper(method)}
+	if opts.CommitTitle != "" {
+		vars["title"] = opts.CommitTitle
+	}
+	if opts.CommitMessage != "" {
+		vars["body"] = opts.CommitMessage
+	}
+	if err := a.graphql(ctx, enableAutoMergeMutation, vars, nil); err != nil {
+		return fmt.Errorf("enabling auto-merge on PR #%d: %w", number, err)
+	}
+	return nil
+}
+
+// GetMergeStatus fetches the PR's mergeability, review decision, head
+// commit checks and the repo's merge settings in one GraphQL query.
+func (a *Adapter) GetMergeStatus(ctx context.Context, repo domain.RepoRef, number int) (*domain.MergeStatus, error) {
+	if c := a.forHost(repo); c != a {
+		return c.GetMergeStatus(ctx, repo, number)
+	}
+	var result githubql.MergeStatusResult
+	if err := a.graphql(ctx, githubql.MergeStatusQuery(repo, number), nil, &result); err != nil {
+		return nil, fmt.Errorf("fetching merge status of PR #%d: %w", number, err)
+	}
+	return result.Status(), nil
+}
+
+// escapeRef path-escapes each segment of a branch name, keeping the slashes
+// that the git refs endpoint expects.
+func escapeRef(ref string) string {
+	parts := strings.Split(ref, "/")
+	for i, p := range parts {
+		parts[i] = url.PathEscape(p)
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,363 @@
+package ghapi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// recordedRequest captures the method, path, and JSON body of a request.
+type recordedRequest struct {
+	Method string
+	Path   string
+	Body   map[string]any
+}
+
+func recordingAdapter(t *testing.T, respond func(w http.ResponseWriter, r *http.Request)) (*Adapter, *[]recordedRequest) {
+	t.Helper()
+	var reqs []recordedRequest
+	a := newTestAdapter(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := recordedRequest{Method: r.Method, Path: r.URL.Path}
+		raw, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		if len(raw) > 0 {
+			require.NoError(t, json.Unmarshal(raw, &rec.Body))
+		}
+		reqs = append(reqs, rec)
+		if respond != nil {
+			respond(w, r)
+			return
+		}
+		writeJSON(w, map[string]any{})
+	}))
+	return a, &reqs
+}
+
+func TestSubmitReviewEvents(t *testing.T) {
+	tests := []struct {
+		action domain.ReviewAction
+		event  string
+	}{
+		{domain.ReviewActionApprove, "APPROVE"},
+		{domain.ReviewActionRequestChanges, "REQUEST_CHANGES"},
+		{domain.ReviewActionComment, "COMMENT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.event, func(t *testing.T) {
+			a, reqs := recordingAdapter(t, nil)
+			err := a.SubmitReview(t.Context(), testRepo, 7, domain.Review{Action: tt.action, Body: "LGTM"})
+			require.NoError(t, err)
+			require.Len(t, *reqs, 1)
+			got := (*reqs)[0]
+			assert.Equal(t, http.MethodPost, got.Method)
+			assert.Equal(t, "/repos/owner/repo/pulls/7/reviews", got.Path)
+			assert.Equal(t, tt.event, got.Body["event"])
+			assert.Equal(t, "LGTM", got.Body["body"])
+		})
+	}
+}
+
+func TestSubmitReviewSendsComments(t *testing.T) {
+	a, reqs := recordingAdapter(t, nil)
+	err := a.SubmitReview(t.Context(), testRepo, 7, domain.Review{
+		Action: domain.ReviewActionComment,
+		Comments: []domain.InlineCommentInput{
+			{Path: "main.go", Line: 3, Side: "RIGHT", Body: "nit"},
+			{Path: "old.go", Line: 9, Side: "LEFT", Body: "why?", StartLine: 6, StartSide: "LEFT"},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, *reqs, 1)
+	body := (*reqs)[0].Body
+	assert.NotContains(t, body, "body")
+	comments, ok := body["comments"].([]any)
+	require.True(t, ok)
+	require.Len(t, comments, 2)
+	second, ok := comments[1].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "old.go", second["path"])
+	assert.InDelta(t, 9, second["line"], 0)
+	assert.Equal(t, "LEFT", second["side"])
+	assert.Equal(t, "why?", second["body"])
+	assert.InDelta(t, 6, second["start_line"], 0)
+	assert.Equal(t, "LEFT", second["start_side"])
+	first, ok := comments[0].(map[string]any)
+	require.True(t, ok)
+	assert.NotContains(t, first, "start_line", "single-line comments send no range")
+}
+
+func TestSubmitReviewUnknownAction(t *testing.T) {
+	a, reqs := recordingAdapter(t, nil)
+	err := a.SubmitReview(t.Context(), testRepo, 7, domain.Review{Action: "bogus"})
+	require.Error(t, err)
+	assert.Empty(t, *reqs)
+}
+
+func TestAddCommentReply(t *testing.T) {
+	a, reqs := recordingAdapter(t, nil)
+	err := a.AddComment(t.Context(), testRepo, 7, domain.InlineCommentInput{
+		Path: "main.go", Line: 3, Side: "RIGHT", Body: "nit", CommitID: "abc", InReplyTo: "99",
+	})
+	require.NoError(t, err)
+	require.Len(t, *reqs, 1)
+	body := (*reqs)[0].Body
+	assert.Equal(t, "/repos/owner/repo/pulls/7/comments", (*reqs)[0].Path)
+	assert.Equal(t, "main.go", body["path"])
+	assert.InDelta(t, 3, body["line"], 0)
+	assert.InDelta(t, 99, body["in_reply_to"], 0)
+}
+
+func TestResolveThreadMutation(t *testing.T) {
+	a, reqs := recordingAdapter(t, nil)
+	require.NoError(t, a.ResolveThread(t.Context(), testRepo, "PRRT_1"))
+	require.Len(t, *reqs, 1)
+	assert.Equal(t, "/graphql", (*reqs)[0].Path)
+	assert.Equal(t, map[string]any{"id": "PRRT_1"}, (*reqs)[0].Body["variables"])
+}
+
+func TestAddPRComment(t *testing.T) {
+	a, reqs := recordingAdapter(t, nil)
+	require.NoError(t, a.AddPRComment(t.Context(), testRepo, 7, "> quoted\n\nreply"))
+	require.Len(t, *reqs, 1)
+	assert.Equal(t, http.MethodPost, (*reqs)[0].Method)
+	assert.Equal(t, "/repos/owner/repo/issues/7/comments", (*reqs)[0].Path)
+	assert.Equal(t, map[string]any{"body": "> quoted\n\nreply"}, (*reqs)[0].Body)
+}
+
+func TestEditAndDeleteCommentPickMutationByKind(t *testing.T) {
+	a, reqs := recordingAdapter(t, nil)
+	require.NoError(t, a.EditComment(t.Context(), testRepo, domain.CommentRef{NodeID: "PRR_1", Kind: domain.DiscussionReview}, "new"))
+	require.NoError(t, a.DeleteComment(t.Context(), testRepo, domain.CommentRef{NodeID: "PRRC_2", Kind: domain.DiscussionInlineThread}))
+	require.Error(t, a.EditComment(t.Context(), testRepo, domain.CommentRef{NodeID: "X", Kind: "bogus"}, "new"))
+
+	require.Len(t, *reqs, 2)
+	assert.Contains(t, (*reqs)[0].Body["query"], "updatePullRequestReview(")
+	assert.Equal(t, map[string]any{"id": "PRR_1", "body": "new"}, (*reqs)[0].Body["variables"])
+	assert.Contains(t, (*reqs)[1].Body["query"], "deletePullRequestReviewComment")
+	assert.Equal(t, map[string]any{"id": "PRRC_2"}, (*reqs)[1].Body["variables"])
+}
+
+func TestUnresolveThreadAndReactions(t *testing.T) {
+	a, reqs := recordingAdapter(t, nil)
+	ref := domain.CommentRef{NodeID: "IC_1", Kind: domain.DiscussionComment}
+	require.NoError(t, a.UnresolveThread(t.Context(), testRepo, "PRRT_1"))
+	require.NoError(t, a.AddReaction(t.Context(), testRepo, ref, domain.ReactionRocket))
+	require.NoError(t, a.RemoveReaction(t.Context(), testRepo, ref, domain.ReactionRocket))
+
+	require.Len(t, *reqs, 3)
+	assert.Contains(t, (*reqs)[0].Body["query"], "unresolveReviewThread")
+	assert.Contains(t, (*reqs)[1].Body["query"], "addReaction")
+	assert.Contains(t, (*reqs)[2].Body["query"], "removeReaction")
+	assert.Equal(t, map[string]any{"id": "IC_1", "content": "ROCKET"}, (*reqs)[2].Body["variables"])
+}
+
+func TestMergeDeletesSameRepoBranch(t *testing.T) {
+	a, reqs := recordingAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			writeJSON(w, map[string]any{"head": map[string]any{
+				"ref":  "feat/x",
+				"repo": map[string]any{"full_name": "owner/repo"},
+			}})
+			return
+		}
+		writeJSON(w, map[string]any{})
+	})
+
+	err := a.Merge(t.Context(), testRepo, 7, domain.MergeOpts{Method: "squash", DeleteBranch: true})
+	require.NoError(t, err)
+	require.Len(t, *reqs, 3)
+	assert.Equal(t, http.MethodPut, (*reqs)[1].Method)
+	assert.Equal(t, "squash", (*reqs)[1].Body["merge_method"])
+	assert.Equal(t, http.MethodDelete, (*reqs)[2].Method)
+	assert.Equal(t, "/repos/owner/repo/git/refs/heads/feat/x", (*reqs)[2].Path)
+}
+
+func TestMergeSendsCommitTitle(t *testing.T) {
+	a, reqs := recordingAdapter(t, nil)
+	err := a.Merge(t.Context(), testRepo, 7, domain.MergeOpts{Method: "squash", CommitTitle: "Add x (#7)", CommitMessage: "details"})
+	require.NoError(t, err)
+	require.Len(t, *reqs, 1)
+	assert.Equal(t, "/repos/owner/repo/pulls/7/merge", (*reqs)[0].Path)
+	assert.Equal(t, "Add x (#7)", (*reqs)[0].Body["commit_title"])
+	assert.Equal(t, "details", (*reqs)[0].Body["commit_message"])
+}
+
+func TestMergeEnablesAutoMerge(t *testing.T) {
+	a, reqs := recordingAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			writeJSON(w, map[string]any{"node_id": "PR_7"})
+			return
+		}
+		writeJSON(w, map[string]any{"data": map[string]any{}})
+	})
+
+	err := a.Merge(t.Context(), testRepo, 7, domain.MergeOpts{Method: "rebase", Auto: true, DeleteBranch: true})
+	require.NoError(t, err)
+	require.Len(t, *reqs, 2)
+	assert.Equal(t, "/repos/owner/repo/pulls/7", (*reqs)[0].Path)
+	assert.Contains(t, (*reqs)[1].Body["query"], "enablePullRequestAutoMerge")
+	assert.Equal(t, map[string]any{"id": "PR_7", "method": "REBASE"}, (*reqs)[1].Body["variables"])
+}
+
+func TestGetMergeStatus(t *testing.T) {
+	a, reqs := recordingAdapter(t, func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{"data": map[string]any{"repository": map[string]any{
+			"mergeCommitAllowed": true,
+			"pullRequest": map[string]any{
+				"state": "OPEN", "mergeable": "CONFLICTING", "mergeStateStatus": "DIRTY",
+			},
+		}}})
+	})
+
+	status, err := a.GetMergeStatus(t.Context(), testRepo, 7)
+	require.NoError(t, err)
+	require.Len(t, *reqs, 1)
+	assert.Contains(t, (*reqs)[0].Body["query"], "pullRequest(number: 7)")
+	assert.Equal(t, domain.MergeableConflicting, status.Mergeable)
+	assert.Equal(t, []string{domain.MergeMethodMerge}, status.Methods)
+	assert.Equal(t, "the branch has conflicts that must be resolved", status.Blocker())
+}
+
+func TestEditMetadata(t *testing.T) {
+	a, reqs := recordingAdapter(t, nil)
+	err := a.EditMetadata(t.Context(), testRepo, 7, domain.MetadataEdit{
+		AddLabels:       []string{"ready"},
+		RemoveLabels:    []string{"needs review"},
+		AddAssignees:    []string{"alice"},
+		RemoveAssignees: []string{"bob"},
+		AddReviewers:    []string{"carol", "owner/core"},
+		RemoveReviewers: []string{"dave"},
+	})
+	require.NoError(t, err)
+	require.Len(t, *reqs, 6)
+	assert.Equal(t, recordedRequest{Method: "POST", Path: "/repos/owner/repo/issues/7/labels", Body: map[string]any{"labels": []any{"ready"}}}, (*reqs)[0])
+	assert.Equal(t, "DELETE", (*reqs)[1].Method)
+	assert.Equal(t, "/repos/owner/repo/issues/7/labels/needs review", (*reqs)[1].Path)
+	assert.Equal(t, recordedRequest{Method: "DELETE", Path: "/repos/owner/repo/issues/7/assignees", Body: map[string]any{"assignees": []any{"bob"}}}, (*reqs)[3])
+	assert.Equal(t, map[string]any{"reviewers": []any{"carol"}, "team_reviewers": []any{"core"}}, (*reqs)[4].Body)
+	assert.Equal(t, "DELETE", (*reqs)[5].Method)
+	assert.Equal(t, "/repos/owner/repo/pulls/7/requested_reviewers", (*reqs)[5].Path)
+}
+
+func TestGetMetadataOptions(t *testing.T) {
+	a, reqs := recordingAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repos/owner/repo/teams" {
+			writeJSON(w, []map[string]any{{"slug": "core", "name": "Core"}})
+			return
+		}
+		writeJSON(w, map[string]any{"data": map[string]any{"repository": map[string]any{
+			"labels":          map[string]any{"nodes": []map[string]any{{"name": "bug", "color": "d73a4a"}}},
+			"assignableUsers": map[string]any{"nodes": []map[string]any{{"login": "alice"}}},
+		}}})
+	})
+
+	opts, err := a.GetMetadataOptions(t.Context(), testRepo)
+	require.NoError(t, err)
+	require.Len(t, *reqs, 2)
+	assert.Equal(t, []domain.Label{{Name: "bug", Color: "d73a4a"}}, opts.Labels)
+	assert.Equal(t, []string{"alice"}, opts.Users)
+	assert.Equal(t, []string{"owner/core"}, opts.Teams)
+}
+
+func TestGetMetadataOptionsWithoutTeams(t *testing.T) {
+	a, _ := recordingAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repos/owner/repo/teams" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeJSON(w, map[string]any{"data": map[string]any{"repository": map[string]any{}}})
+	})
+
+	opts, err := a.GetMetadataOptions(t.Context(), testRepo)
+	require.NoError(t, err, "a user-owned repo has no teams")
+	assert.Empty(t, opts.Teams)
+}
+
+func TestClosePRCommentsFirst(t *testing.T) {
+	a, reqs := recordingAdapter(t, nil)
+	require.NoError(t, a.ClosePR(t.Context(), testRepo, 7, "superseded by #8"))
+	require.Len(t, *reqs, 2)
+	assert.Equal(t, recordedRequest{Method: "POST", Path: "/repos/owner/repo/issues/7/comments", Body: map[string]any{"body": "superseded by #8"}}, (*reqs)[0])
+	assert.Equal(t, recordedRequest{Method: "PATCH", Path: "/repos/owner/repo/pulls/7", Body: map[string]any{"state": "closed"}}, (*reqs)[1])
+}
+
+func TestReopenPR(t *testing.T) {
+	a, reqs := recordingAdapter(t, nil)
+	require.NoError(t, a.ReopenPR(t.Context(), testRepo, 7, ""))
+	require.Len(t, *reqs, 1, "no comment, no comment request")
+	assert.Equal(t, map[string]any{"state": "open"}, (*reqs)[0].Body)
+}
+
+func TestSetDraftAndUpdateBranch(t *testing.T) {
+	a, reqs := recordingAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			writeJSON(w, map[string]any{"node_id": "PR_7"})
+			return
+		}
+		writeJSON(w, map[string]any{"data": map[string]any{}})
+	})
+
+	require.NoError(t, a.SetDraft(t.Context(), testRepo, 7, true))
+	require.NoError(t, a.SetDraft(t.Context(), testRepo, 7, false))
+	require.NoError(t, a.UpdateBranch(t.Context(), testRepo, 7, domain.MergeMethodRebase))
+	require.Len(t, *reqs, 6)
+	assert.Contains(t, (*reqs)[1].Body["query"], "convertPullRequestToDraft")
+	assert.Equal(t, map[string]any{"id": "PR_7"}, (*reqs)[1].Body["variables"])
+	assert.Contains(t, (*reqs)[3].Body["query"], "markPullRequestReadyForReview")
+	assert.Contains(t, (*reqs)[5].Body["query"], "updatePullRequestBranch")
+	assert.Equal(t, map[string]any{"id": "PR_7", "method": "REBASE"}, (*reqs)[5].Body["variables"])
+}
+
+func TestCreatePR(t *testing.T) {
+	a, reqs := recordingAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repos/owner/repo/pulls" {
+			writeJSON(w, map[string]any{"number": 12})
+			return
+		}
+		writeJSON(w, map[string]any{})
+	})
+
+	number, err := a.CreatePR(t.Context(), testRepo, domain.NewPR{
+		Base: "main", Head: "feat/x", Title: "Add x", Body: "Body", Draft: true,
+		Reviewers: []string{"alice", "owner/core"}, Labels: []string{"ready"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 12, number)
+	require.Len(t, *reqs, 3)
+	assert.Equal(t, map[string]any{"title": "Add x", "body": "Body", "head": "feat/x", "base": "main", "draft": true}, (*reqs)[0].Body)
+	assert.Equal(t, "/repos/owner/repo/issues/12/labels", (*reqs)[1].Path)
+	assert.Equal(t, map[string]any{"reviewers": []any{"alice"}, "team_reviewers": []any{"core"}}, (*reqs)[2].Body)
+}
+
+func TestCreatePRKeepsNumberWhenReviewersFail(t *testing.T) {
+	a, _ := recordingAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repos/owner/repo/pulls" {
+			writeJSON(w, map[string]any{"number": 12})
+			return
+		}
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	})
+
+	number, err := a.CreatePR(t.Context(), testRepo, domain.NewPR{Base: "main", Head: "feat/x", Title: "x", Reviewers: []string{"ghost"}})
+	require.Error(t, err)
+	assert.Equal(t, 12, number)
+}
+
+func TestListBranches(t *testing.T) {
+	a, _ := recordingAdapter(t, func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{"data": map[string]any{"repository": map[string]any{
+			"defaultBranchRef": map[string]any{"name": "main"},
+			"refs":             map[string]any{"nodes": []map[string]any{{"name": "main"}, {"name": "dev"}}},
+		}}})
+	})
+
+	branches, err := a.ListBranches(t.Context(), testRepo)
+	require.NoError(t, err)
+	assert.Equal(t, &domain.RepoBranches{Default: "main", Names: []string{"main", "dev"}}, branches)
+}
@@ -0,0 +1,26 @@
+package ghcli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/indrasvat/vivecaka/internal/adapter/githubql"
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+var _ domain.CIStatusReader = (*Adapter)(nil)
+
+// GetCIStatuses fetches the head commit rollup of several PRs in one GraphQL
+// query.
+func (a *Adapter) GetCIStatuses(ctx context.Context, repo domain.RepoRef, numbers []int) (map[int]domain.CIStatus, error) {
+	if len(numbers) == 0 {
+		return map[int]domain.CIStatus{}, nil
+	}
+	var result struct {
+		Data githubql.CIStatusResult `json:"data"`
+	}
+	if err := ghJSON(ctx, &result, graphqlArgs(repo, githubql.CIStatusQuery(repo, numbers))...); err != nil {
+		return nil, fmt.Errorf("fetching CI statuses: %w", err)
+	}
+	return result.Data.Statuses(), nil
+}
@@ -0,0 +1,104 @@
+package ghcli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+var _ domain.CommentManager = (*Adapter)(nil)
+
+// editMutations and deleteMutations hold the GraphQL mutation for each kind
+// of comment, taking the comment's node ID as $id.
+var (
+	editMutations = map[domain.DiscussionKind]string{
+		domain.DiscussionComment:      `mutation($id: ID!, $body: String!) { updateIssueComment(input: {id: $id, body: $body}) { issueComment { id } } }`,
+		domain.DiscussionReview:       `mutation($id: ID!, $body: String!) { updatePullRequestReview(input: {pullRequestReviewId: $id, body: $body}) { pullRequestReview { id } } }`,
+		domain.DiscussionInlineThread: `mutation($id: ID!, $body: String!) { updatePullRequestReviewComment(input: {pullRequestReviewCommentId: $id, body: $body}) { pullRequestReviewComment { id } } }`,
+	}
+	deleteMutations = map[domain.DiscussionKind]string{
+		domain.DiscussionComment:      `mutation($id: ID!) { deleteIssueComment(input: {id: $id}) { clientMutationId } }`,
+		domain.DiscussionReview:       `mutation($id: ID!) { deletePullRequestReview(input: {pullRequestReviewId: $id}) { clientMutationId } }`,
+		domain.DiscussionInlineThread: `mutation($id: ID!) { deletePullRequestReviewComment(input: {id: $id}) { clientMutationId } }`,
+	}
+)
+
+const (
+	unresolveThreadMutation = `mutation($id: ID!) { unresolveReviewThread(input: {threadId: $id}) { thread { isResolved } } }`
+	addReactionMutation     = `mutation($id: ID!, $content: ReactionContent!) { addReaction(input: {subjectId: $id, content: $content}) { clientMutationId } }`
+	removeReactionMutation  = `mutation($id: ID!, $content: ReactionContent!) { removeReaction(input: {subjectId: $id, content: $content}) { clientMutationId } }`
+)
+
+// mutationArgs builds a gh api graphql invocation of query with string
+// variables given as name=value pairs.
+func mutationArgs(repo domain.RepoRef, query string, vars ...string) []string {
+	args := []string{"api", "graphql", "-f", "query=" + query}
+	for _, v := range vars {
+		args = append(args, "-f", v)
+	}
+	return append(args, hostArgs(repo)...)
+}
+
+// AddPRComment posts a comment on the PR's conversation via the REST API.
+func (a *Adapter) AddPRComment(ctx context.Context, repo domain.RepoRef, number int, body string) error {
+	endpoint := fmt.Sprintf("repos/%s/issues/%d/comments", repo.FullName(), number)
+	args := []string{"api", endpoint, "--method", "POST", "--raw-field", "body=" + body}
+	args = append(args, hostArgs(repo)...)
+
+	if _, err := ghExec(ctx, args...); err != nil {
+		return fmt.Errorf("commenting on PR #%d: %w", number, err)
+	}
+	return nil
+}
+
+// EditComment replaces a comment's body via the GraphQL API.
+func (a *Adapter) EditComment(ctx context.Context, repo domain.RepoRef, ref domain.CommentRef, body string) error {
+	query, ok := editMutations[ref.Kind]
+	if !ok {
+		return fmt.Errorf("editing comment %s: unknown comment kind %q", ref.NodeID, ref.Kind)
+	}
+	if _, err := ghExec(ctx, mutationArgs(repo, query, "id="+ref.NodeID, "body="+body)...); err != nil {
+		return fmt.Errorf("editing comment %s: %w", ref.NodeID, err)
+	}
+	return nil
+}
+
+// DeleteComment deletes a comment via the GraphQL API. GitHub only deletes
+// reviews that are still pending.
+func (a *Adapter) DeleteComment(ctx context.Context, repo domain.RepoRef, ref domain.CommentRef) error {
+	query, ok := deleteMutations[ref.Kind]
+	if !ok {
+		return fmt.Errorf("deleting comment %s: unknown comment kind %q", ref.NodeID, ref.Kind)
+	}
+	if _, err := ghExec(ctx, mutationArgs(repo, query, "id="+ref.NodeID)...); err != nil {
+		return fmt.Errorf("deleting comment %s: %w", ref.NodeID, err)
+	}
+	return nil
+}
+
+// UnresolveThread reopens a resolved review thread via the GraphQL API.
+func (a *Adapter) UnresolveThread(ctx context.Context, repo domain.RepoRef, threadID string) error {
+	if _, err := ghExec(ctx, mutationArgs(repo, unresolveThreadMutation, "id="+threadID)...); err != nil {
+		return fmt.Errorf("unresolving thread %s: %w", threadID, err)
+	}
+	return nil
+}
+
+// AddReaction reacts to a comment via the GraphQL API.
+func (a *Adapter) AddReaction(ctx context.Context, repo domain.RepoRef, ref domain.CommentRef, content domain.ReactionContent) error {
+	args := mutationArgs(repo, addReactionMutation, "id="+ref.NodeID, "content="+string(content))
+	if _, err := ghExec(ctx, args...); err != nil {
+		return fmt.Errorf("reacting to comment %s: %w", ref.NodeID, err)
+	}
+	return nil
+}
+
+// RemoveReaction withdraws the viewer's reaction via the GraphQL API.
+func (a *Adapter) RemoveReaction(ctx context.Context, repo domain.RepoRef, ref domain.CommentRef, content domain.ReactionContent) error {
+	args := mutationArgs(repo, removeReactionMutation, "id="+ref.NodeID, "content="+string(content))
+	if _, err := ghExec(ctx, args...); err != nil {
+		return fmt.Errorf("removing reaction from comment %s: %w", ref.NodeID, err)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package ghcli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/indrasvat/vivecaka/internal/adapter/githubql"
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+var _ domain.CommitReader = (*Adapter)(nil)
+
+// GetCommits lists a PR's commits, oldest first, with the check rollup of
+// each, a page of 100 per GraphQL query.
+func (a *Adapter) GetCommits(ctx context.Context, repo domain.RepoRef, number int) ([]domain.Commit, error) {
+	var (
+		cursor  string
+		commits []domain.Commit
+	)
+	for {
+		var result struct {
+			Data githubql.CommitsResult `json:"data"`
+		}
+		if err := ghJSON(ctx, &result, graphqlArgs(repo, githubql.CommitsQuery(repo, number, cursor))...); err != nil {
+			return nil, fmt.Errorf("getting commits for PR #%d: %w", number, err)
+		}
+		page, next := result.Data.Page()
+		commits = append(commits, page...)
+		if next == "" {
+			return commits, nil
+		}
+		cursor = next
+	}
+}
+
+// CompareCommits fetches the diff from base to head via the compare API.
+func (a *Adapter) CompareCommits(ctx context.Context, repo domain.RepoRef, base, head string) (*domain.Diff, error) {
+	args := []string{"api", githubql.CompareCommitsPath(repo, base, head), "-H", "Accept: application/vnd.github.v3.diff"}
+	args = append(args, hostArgs(repo)...)
+	out, err := ghExec(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("comparing %s with %s: %w", shortSHA(head), shortSHA(base), err)
+	}
+	diff := domain.ParseDiff(string(out))
+	return &diff, nil
+}
+
+func shortSHA(sha string) string { return domain.Commit{SHA: sha}.ShortSHA() }
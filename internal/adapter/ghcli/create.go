@@ -0,0 +1,67 @@
+package ghcli
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/indrasvat/vivecaka/internal/adapter/githubql"
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/gitutil"
+)
+
+var _ domain.PRCreator = (*Adapter)(nil)
+
+// ListBranches fetches the repo's default branch and its 100 most recently
+// updated branches in one GraphQL query.
+func (a *Adapter) ListBranches(ctx context.Context, repo domain.RepoRef) (*domain.RepoBranches, error) {
+	var result struct {
+		Data githubql.BranchesResult `json:"data"`
+	}
+	if err := ghJSON(ctx, &result, graphqlArgs(repo, githubql.BranchesQuery(repo))...); err != nil {
+		return nil, fmt.Errorf("listing branches of %s: %w", repo, err)
+	}
+	return result.Data.Branches(), nil
+}
+
+// CompareBranch compares head with base in the local clone at dir.
+func (a *Adapter) CompareBranch(ctx context.Context, dir, base, head string) (*domain.BranchComparison, error) {
+	return gitutil.CompareBranch(ctx, dir, base, head)
+}
+
+// PushBranch pushes branch to origin from the clone at dir.
+func (a *Adapter) PushBranch(ctx context.Context, dir, branch string) error {
+	return gitutil.PushBranch(ctx, dir, branch)
+}
+
+// CreatePR opens a PR via gh pr create and returns its number, read from
+// the URL gh prints.
+func (a *Adapter) CreatePR(ctx context.Context, repo domain.RepoRef, pr domain.NewPR) (int, error) {
+	out, err := ghExec(ctx, createPRArgs(repo, pr)...)
+	if err != nil {
+		return 0, fmt.Errorf("creating PR from %s: %w", pr.Head, err)
+	}
+	number, err := strconv.Atoi(path.Base(strings.TrimSpace(string(out))))
+	if err != nil {
+		return 0, fmt.Errorf("reading the number of the new PR from %q", strings.TrimSpace(string(out)))
+	}
+	return number, nil
+}
+
+func createPRArgs(repo domain.RepoRef, pr domain.NewPR) []string {
+	args := []string{"pr", "create"}
+	args = append(args, repoArgs(repo)...)
+	args = append(args, "--base", pr.Base, "--head", pr.Head, "--title", pr.Title, "--body", pr.Body)
+	if pr.Draft {
+		args = append(args, "--draft")
+	}
+	for _, r := range pr.Reviewers {
+		args = append(args, "--reviewer", r)
+	}
+	for _, l := range pr.Labels {
+		args = append(args, "--label", l)
+	}
+	return args
+}
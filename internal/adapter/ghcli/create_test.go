@@ -0,0 +1,23 @@
+package ghcli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+func TestCreatePRArgs(t *testing.T) {
+	args := createPRArgs(domain.RepoRef{Owner: "o", Name: "r"}, domain.NewPR{
+		Base: "main", Head: "feat/x", Title: "Add x", Body: "Body",
+		Draft: true, Reviewers: []string{"alice", "o/core"}, Labels: []string{"ready"},
+	})
+	assert.Equal(t, []string{
+		"pr", "create", "--repo", "o/r",
+		"--base", "main", "--head", "feat/x", "--title", "Add x", "--body", "Body",
+		"--draft",
+		"--reviewer", "alice", "--reviewer", "o/core",
+		"--label", "ready",
+	}, args)
+}
@@ -0,0 +1,104 @@
+package ghcli
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/indrasvat/vivecaka/internal/adapter/githubql"
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// gh relays GitHub's messages verbatim, e.g. "GraphQL: API rate limit
+// exceeded for user ID 1." or "HTTP 403: You have exceeded a secondary rate
+// limit.", and appends its own hints such as the gh auth refresh command
+// that grants a missing scope.
+var (
+	primaryRateLimitRe   = regexp.MustCompile(`(?i)API rate limit (?:already )?exceeded`)
+	secondaryRateLimitRe = regexp.MustCompile(`(?i)secondary rate limit|abuse detection|submitted too quickly|HTTP 429`)
+
+	ssoRe    = regexp.MustCompile(`(?i)SAML enforcement|\bSSO\b`)
+	ssoURLRe = regexp.MustCompile(`https?://\S+/sso\S*`)
+
+	scopeRe        = regexp.MustCompile(`(?i)required scopes|needs the "[^"]+" scope|missing required scope`)
+	authRefreshRe  = regexp.MustCompile(`gh auth refresh[^\n]*`)
+	networkRe      = regexp.MustCompile(`(?i)error connecting to|no such host|connection refused|network is unreachable|i/o timeout|TLS handshake timeout`)
+	archivedRe     = regexp.MustCompile(`(?i)\barchived\b`)
+	lockedRe       = regexp.MustCompile(`(?i)\bis locked\b|locked conversation`)
+	notFoundRe     = regexp.MustCompile(`(?i)could not resolve to an? |HTTP 404|no pull requests? found|could not find pull request`)
+	unauthorizedRe = regexp.MustCompile(`(?i)HTTP 401|bad credentials`)
+	forbiddenRe    = regexp.MustCompile(`(?i)HTTP 403|resource not accessible|must have (?:admin|push) (?:rights|access)`)
+)
+
+// classifyGhError maps a failed gh invocation to domain errors, attaching
+// the remedy for failures the user can fix.
+func classifyGhError(exitCode int, msg string) error {
+	msg = strings.TrimSpace(msg)
+	switch {
+	case exitCode == 4:
+		return fmt.Errorf("%w: %s", domain.ErrNotAuthenticated, msg)
+	case secondaryRateLimitRe.MatchString(msg):
+		return &domain.RateLimitError{Secondary: true, Message: msg}
+	case primaryRateLimitRe.MatchString(msg):
+		// gh does not relay the reset header; callers can ask RateLimit.
+		return &domain.RateLimitError{Message: msg}
+	case ssoRe.MatchString(msg):
+		e := &domain.HostError{Kind: domain.ErrSSORequired, Message: firstLine(msg)}
+		e.URL = ssoURLRe.FindString(msg)
+		if e.URL != "" {
+			e.Remedy = "authorize the token at " + e.URL
+		} else {
+			e.Remedy = "authorize your token for the organization, then run gh auth refresh"
+		}
+		return e
+	case scopeRe.MatchString(msg):
+		e := &domain.HostError{Kind: domain.ErrInsufficientScope, Message: firstLine(msg), Scopes: githubql.MissingScopes(msg)}
+		switch {
+		case authRefreshRe.MatchString(msg):
+			e.Remedy = "run " + strings.Join(strings.Fields(authRefreshRe.FindString(msg)), " ")
+		case len(e.Scopes) > 0:
+			e.Remedy = "run gh auth refresh -s " + e.Scopes[0]
+		default:
+			e.Remedy = "run gh auth refresh -s repo"
+		}
+		return e
+	case networkRe.MatchString(msg):
+		return &domain.HostError{
+			Kind: domain.ErrNetwork, Message: firstLine(msg),
+			Remedy: "check your connection or https://www.githubstatus.com",
+		}
+	case archivedRe.MatchString(msg):
+		return &domain.HostError{
+			Kind: domain.ErrArchived, Message: firstLine(msg),
+			Remedy: "the repository is read-only until it is unarchived",
+		}
+	case lockedRe.MatchString(msg):
+		return &domain.HostError{
+			Kind: domain.ErrLocked, Message: firstLine(msg),
+			Remedy: "only collaborators can comment while the conversation is locked",
+		}
+	case notFoundRe.MatchString(msg):
+		return &domain.HostError{
+			Kind: domain.ErrNotFound, Message: firstLine(msg),
+			Remedy: "check the repo name, or that your account can see it",
+		}
+	case unauthorizedRe.MatchString(msg):
+		return &domain.HostError{
+			Kind: domain.ErrUnauthorized, Message: firstLine(msg),
+			Remedy: "run gh auth login to renew your credentials",
+		}
+	case forbiddenRe.MatchString(msg):
+		return &domain.HostError{
+			Kind: domain.ErrUnauthorized, Message: firstLine(msg),
+			Remedy: "your account lacks permission for this action",
+		}
+	default:
+		return fmt.Errorf("gh: %s", msg)
+	}
+}
+
+// firstLine returns the host's message without gh's trailing hints.
+func firstLine(msg string) string {
+	line, _, _ := strings.Cut(msg, "\n")
+	return strings.TrimSpace(line)
+}
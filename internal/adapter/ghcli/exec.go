@@ -4,14 +4,49 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os/exec"
+	"strings"
+	"time"
 
 	"github.com/indrasvat/vivecaka/internal/domain"
 )
 
-// ghExec runs a gh CLI command and returns stdout. It respects context cancellation.
+// sleep waits for d or until ctx is done. Tests replace it to skip waits.
+var sleep = func(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// ghExec runs a gh CLI command and returns stdout. It respects context
+// cancellation. Read-only commands that hit a short rate limit are retried
+// with backoff.
 func ghExec(ctx context.Context, args ...string) ([]byte, error) {
+	retry := isReadOnlyCall(args)
+	for attempt := 0; ; attempt++ {
+		out, err := ghExecOnce(ctx, args...)
+		if err == nil || !retry {
+			return out, err
+		}
+		var rle *domain.RateLimitError
+		if !errors.As(err, &rle) {
+			return nil, err
+		}
+		delay, ok := rle.RetryDelay(time.Now(), attempt)
+		if !ok || sleep(ctx, delay) != nil {
+			return nil, err
+		}
+	}
+}
+
+func ghExecOnce(ctx context.Context, args ...string) ([]byte, error) {
 	cmd := exec.CommandContext(ctx, "gh", args...)
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
@@ -22,17 +57,53 @@ func ghExec(ctx context.Context, args ...string) ([]byte, error) {
 		if msg == "" {
 			msg = err.Error()
 		}
-		// Map common gh errors to domain errors.
-		switch {
-		case cmd.ProcessState != nil && cmd.ProcessState.ExitCode() == 4:
-			return nil, fmt.Errorf("%w: %s", domain.ErrNotAuthenticated, msg)
-		default:
-			return nil, fmt.Errorf("gh: %s", msg)
+		exitCode := -1
+		if cmd.ProcessState != nil {
+			exitCode = cmd.ProcessState.ExitCode()
 		}
+		return nil, classifyGhError(exitCode, msg)
 	}
 	return stdout.Bytes(), nil
 }
 
+// isReadOnlyCall reports whether a gh invocation only reads, so it is safe
+// to repeat. gh api defaults to POST once fields are added, and GraphQL
+// reads and writes share an endpoint, so both are inspected.
+func isReadOnlyCall(args []string) bool {
+	if len(args) < 2 {
+		return false
+	}
+	switch args[0] {
+	case "pr":
+		switch args[1] {
+		case "list", "view", "diff", "checks":
+			return true
+		}
+		return false
+	case "api":
+		if args[1] == "graphql" {
+			for _, arg := range args {
+				if q, ok := strings.CutPrefix(arg, "query="); ok {
+					return !strings.HasPrefix(strings.TrimSpace(q), "mutation")
+				}
+			}
+			return false
+		}
+		hasFields := false
+		for i, arg := range args {
+			switch arg {
+			case "--method", "-X":
+				return i+1 < len(args) && strings.EqualFold(args[i+1], "GET")
+			case "-f", "-F", "--field", "--raw-field", "--input":
+				hasFields = true
+			}
+		}
+		return !hasFields
+	default:
+		return false
+	}
+}
+
 // ghJSON runs a gh command and unmarshals the JSON output into dst.
 func ghJSON(ctx context.Context, dst any, args ...string) error {
 	out, err := ghExec(ctx, args...)
@@ -0,0 +1,141 @@
+package ghcli
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+func TestClassifyGhError(t *testing.T) {
+	err := classifyGhError(4, "To get started with GitHub CLI, please run: gh auth login")
+	assert.ErrorIs(t, err, domain.ErrNotAuthenticated)
+
+	err = classifyGhError(1, "GraphQL: API rate limit exceeded for user ID 1234.\n")
+	var rle *domain.RateLimitError
+	require.True(t, errors.As(err, &rle))
+	assert.False(t, rle.Secondary)
+	assert.Equal(t, "GraphQL: API rate limit exceeded for user ID 1234.", rle.Message)
+
+	err = classifyGhError(1, "HTTP 403: You have exceeded a secondary rate limit. Please wait a few minutes before you try again.")
+	require.True(t, errors.As(err, &rle))
+	assert.True(t, rle.Secondary)
+
+	err = classifyGhError(1, "unknown flag: --bogus")
+	assert.NotErrorIs(t, err, domain.ErrRateLimited)
+	assert.Equal(t, "gh: unknown flag: --bogus", err.Error())
+}
+
+func TestClassifyGhErrorTaxonomy(t *testing.T) {
+	tests := []struct {
+		name   string
+		stderr string
+		kind   error
+		remedy string
+	}{
+		{
+			name:   "repo not found",
+			stderr: "GraphQL: Could not resolve to a Repository with the name 'o/missing'. (repository)",
+			kind:   domain.ErrNotFound,
+			remedy: "check the repo name, or that your account can see it",
+		},
+		{
+			name:   "rest not found",
+			stderr: "HTTP 404: Not Found (https://api.github.com/repos/o/r/pulls/9)",
+			kind:   domain.ErrNotFound,
+		},
+		{
+			name: "sso",
+			stderr: "GraphQL: Resource protected by organization SAML enforcement. You must grant your OAuth token access to this organization.\n" +
+				"Authorize in your web browser: https://github.com/orgs/acme/sso?authorization_request=abc123",
+			kind:   domain.ErrSSORequired,
+			remedy: "authorize the token at https://github.com/orgs/acme/sso?authorization_request=abc123",
+		},
+		{
+			name: "scope hint from gh",
+			stderr: "HTTP 403: Must have admin rights to Repository.\n" +
+				"This API operation needs the \"admin:org\" scope. To request it, run:  gh auth refresh -h github.com -s admin:org",
+			kind:   domain.ErrInsufficientScope,
+			remedy: "run gh auth refresh -h github.com -s admin:org",
+		},
+		{
+			name: "graphql scopes",
+			stderr: "GraphQL: Your token has not been granted the required scopes to execute this query. " +
+				"The 'login' field requires one of the following scopes: ['read:org'], but your token has only been granted the: ['repo'] scopes.",
+			kind:   domain.ErrInsufficientScope,
+			remedy: "run gh auth refresh -s read:org",
+		},
+		{
+			name:   "network",
+			stderr: "error connecting to api.github.com\ncheck your internet connection or https://githubstatus.com",
+			kind:   domain.ErrNetwork,
+		},
+		{
+			name:   "archived",
+			stderr: "GraphQL: Repository was archived so is read-only. (addPullRequestReview)",
+			kind:   domain.ErrArchived,
+		},
+		{
+			name:   "locked",
+			stderr: "HTTP 403: Unable to create comment because issue is locked.",
+			kind:   domain.ErrLocked,
+		},
+		{
+			name:   "bad credentials",
+			stderr: "HTTP 401: Bad credentials (https://api.github.com/graphql)\nTry authenticating with:  gh auth login",
+			kind:   domain.ErrUnauthorized,
+			remedy: "run gh auth login to renew your credentials",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := classifyGhError(1, tt.stderr)
+			var he *domain.HostError
+			require.True(t, errors.As(err, &he), "got %v", err)
+			assert.ErrorIs(t, err, tt.kind)
+			assert.NotContains(t, he.Message, "\n")
+			if tt.remedy != "" {
+				assert.Equal(t, tt.remedy, he.Remedy)
+			}
+		})
+	}
+}
+
+func TestClassifyGhErrorScopes(t *testing.T) {
+	err := classifyGhError(1, "GraphQL: Your token has not been granted the required scopes to execute this query. "+
+		"The 'x' field requires one of the following scopes: ['read:org', 'repo'], but your token has only been granted the: [] scopes.")
+	var he *domain.HostError
+	require.True(t, errors.As(err, &he))
+	assert.Equal(t, []string{"read:org", "repo"}, he.Scopes)
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+
+	err = classifyGhError(1, "GraphQL: Resource protected by organization SAML enforcement.")
+	require.True(t, errors.As(err, &he))
+	assert.Empty(t, he.URL)
+	assert.Contains(t, he.Remedy, "gh auth refresh")
+}
+
+func TestIsReadOnlyCall(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"pr", "list", "--repo", "o/r"}, true},
+		{[]string{"pr", "view", "1"}, true},
+		{[]string{"pr", "merge", "1"}, false},
+		{[]string{"pr", "review", "1", "--approve"}, false},
+		{[]string{"api", "graphql", "-f", "query=query { viewer { login } }"}, true},
+		{[]string{"api", "graphql", "-f", "query=mutation($id: ID!) { x }", "-f", "id=1"}, false},
+		{[]string{"api", "rate_limit"}, true},
+		{[]string{"api", "repos/o/r/pulls/1/comments", "--method", "POST", "--raw-field", "body=x"}, false},
+		{[]string{"api", "repos/o/r/issues", "-f", "title=x"}, false},
+		{[]string{"api", "search/issues", "-X", "GET", "-f", "q=x"}, true},
+		{[]string{"repo", "clone", "o/r"}, false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, isReadOnlyCall(tt.args), "%v", tt.args)
+	}
+}
@@ -0,0 +1,31 @@
+package ghcli
+
+import (
+	"slices"
+
+	"github.com/indrasvat/vivecaka/internal/adapter/githubql"
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+var _ domain.HostSupporter = (*Adapter)(nil)
+
+// SupportsHost reports whether gh can talk to host: github.com, GH_HOST or
+// a host gh is authenticated against.
+func (a *Adapter) SupportsHost(host string) bool {
+	return slices.Contains(githubql.KnownHosts(), host)
+}
+
+// hostArgs returns the --hostname flag gh api needs to reach repo's host.
+// Default-host repos get no flag, leaving gh's own host resolution
+// (including GH_HOST) in charge as before.
+func hostArgs(repo domain.RepoRef) []string {
+	if repo.IsDefaultHost() {
+		return nil
+	}
+	return []string{"--hostname", repo.HostName()}
+}
+
+// graphqlArgs builds a gh api graphql invocation routed to repo's host.
+func graphqlArgs(repo domain.RepoRef, query string) []string {
+	return append([]string{"api", "graphql", "-f", "query=" + query}, hostArgs(repo)...)
+}
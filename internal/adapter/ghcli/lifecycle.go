@@ -0,0 +1,79 @@
+package ghcli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+var _ domain.PRLifecycleManager = (*Adapter)(nil)
+
+// ClosePR closes a PR via gh pr close, leaving comment first if set.
+func (a *Adapter) ClosePR(ctx context.Context, repo domain.RepoRef, number int, comment string) error {
+	if _, err := ghExec(ctx, closeArgs(repo, number, comment)...); err != nil {
+		return fmt.Errorf("closing PR #%d: %w", number, err)
+	}
+	return nil
+}
+
+// ReopenPR reopens a closed PR via gh pr reopen, leaving comment if set.
+func (a *Adapter) ReopenPR(ctx context.Context, repo domain.RepoRef, number int, comment string) error {
+	if _, err := ghExec(ctx, reopenArgs(repo, number, comment)...); err != nil {
+		return fmt.Errorf("reopening PR #%d: %w", number, err)
+	}
+	return nil
+}
+
+// SetDraft converts a PR to a draft, or marks it ready for review, via
+// gh pr ready.
+func (a *Adapter) SetDraft(ctx context.Context, repo domain.RepoRef, number int, draft bool) error {
+	if _, err := ghExec(ctx, setDraftArgs(repo, number, draft)...); err != nil {
+		if draft {
+			return fmt.Errorf("converting PR #%d to draft: %w", number, err)
+		}
+		return fmt.Errorf("marking PR #%d ready for review: %w", number, err)
+	}
+	return nil
+}
+
+// UpdateBranch merges or rebases the base branch into the PR's head via
+// gh pr update-branch.
+func (a *Adapter) UpdateBranch(ctx context.Context, repo domain.RepoRef, number int, method string) error {
+	if _, err := ghExec(ctx, updateBranchArgs(repo, number, method)...); err != nil {
+		return fmt.Errorf("updating the branch of PR #%d: %w", number, err)
+	}
+	return nil
+}
+
+func closeArgs(repo domain.RepoRef, number int, comment string) []string {
+	args := append([]string{"pr", "close", fmt.Sprintf("%d", number)}, repoArgs(repo)...)
+	if comment != "" {
+		args = append(args, "--comment", comment)
+	}
+	return args
+}
+
+func reopenArgs(repo domain.RepoRef, number int, comment string) []string {
+	args := append([]string{"pr", "reopen", fmt.Sprintf("%d", number)}, repoArgs(repo)...)
+	if comment != "" {
+		args = append(args, "--comment", comment)
+	}
+	return args
+}
+
+func setDraftArgs(repo domain.RepoRef, number int, draft bool) []string {
+	args := append([]string{"pr", "ready", fmt.Sprintf("%d", number)}, repoArgs(repo)...)
+	if draft {
+		args = append(args, "--undo")
+	}
+	return args
+}
+
+func updateBranchArgs(repo domain.RepoRef, number int, method string) []string {
+	args := append([]string{"pr", "update-branch", fmt.Sprintf("%d", number)}, repoArgs(repo)...)
+	if method == domain.MergeMethodRebase {
+		args = append(args, "--rebase")
+	}
+	return args
+}
@@ -0,0 +1,21 @@
+package ghcli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+func TestLifecycleArgs(t *testing.T) {
+	repo := domain.RepoRef{Owner: "o", Name: "r"}
+
+	assert.Equal(t, []string{"pr", "close", "7", "--repo", "o/r"}, closeArgs(repo, 7, ""))
+	assert.Equal(t, []string{"pr", "close", "7", "--repo", "o/r", "--comment", "superseded by #8"}, closeArgs(repo, 7, "superseded by #8"))
+	assert.Equal(t, []string{"pr", "reopen", "7", "--repo", "o/r", "--comment", "back"}, reopenArgs(repo, 7, "back"))
+	assert.Equal(t, []string{"pr", "ready", "7", "--repo", "o/r"}, setDraftArgs(repo, 7, false))
+	assert.Equal(t, []string{"pr", "ready", "7", "--repo", "o/r", "--undo"}, setDraftArgs(repo, 7, true))
+	assert.Equal(t, []string{"pr", "update-branch", "7", "--repo", "o/r"}, updateBranchArgs(repo, 7, domain.MergeMethodMerge))
+	assert.Equal(t, []string{"pr", "update-branch", "7", "--repo", "o/r", "--rebase"}, updateBranchArgs(repo, 7, domain.MergeMethodRebase))
+}
@@ -0,0 +1,23 @@
+package ghcli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/indrasvat/vivecaka/internal/adapter/githubql"
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+var _ domain.MergeStatusReader = (*Adapter)(nil)
+
+// GetMergeStatus fetches the PR's mergeability, review decision, head
+// commit checks and the repo's merge settings in one GraphQL query.
+func (a *Adapter) GetMergeStatus(ctx context.Context, repo domain.RepoRef, number int) (*domain.MergeStatus, error) {
+	var result struct {
+		Data githubql.MergeStatusResult `json:"data"`
+	}
+	if err := ghJSON(ctx, &result, graphqlArgs(repo, githubql.MergeStatusQuery(repo, number))...); err != nil {
+		return nil, fmt.Errorf("fetching merge status of PR #%d: %w", number, err)
+	}
+	return result.Data.Status(), nil
+}
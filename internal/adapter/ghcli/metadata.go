@@ -0,0 +1,75 @@
+package ghcli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/indrasvat/vivecaka/internal/adapter/githubql"
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+var _ domain.MetadataEditor = (*Adapter)(nil)
+
+// GetMetadataOptions fetches the repo's labels and assignable users in one
+// GraphQL query, and the teams with access to the repo via the REST API.
+// Listing teams needs the read:org scope and only works for org repos, so a
+// failure there leaves the teams empty instead of failing.
+func (a *Adapter) GetMetadataOptions(ctx context.Context, repo domain.RepoRef) (*domain.MetadataOptions, error) {
+	var result struct {
+		Data githubql.MetadataOptionsResult `json:"data"`
+	}
+	if err := ghJSON(ctx, &result, graphqlArgs(repo, githubql.MetadataOptionsQuery(repo))...); err != nil {
+		return nil, fmt.Errorf("fetching labels and users of %s: %w", repo, err)
+	}
+	opts := result.Data.Options()
+
+	if out, err := ghExec(ctx, teamsArgs(repo)...); err == nil {
+		var teams []githubql.RepoTeam
+		for slug := range strings.FieldsSeq(string(out)) {
+			teams = append(teams, githubql.RepoTeam{Slug: slug})
+		}
+		opts.Teams = githubql.TeamSlugs(repo, teams)
+	}
+	return opts, nil
+}
+
+// teamsArgs builds the gh api invocation that lists the slugs of every team
+// with access to repo, one per line. gh concatenates the pages' arrays, so
+// the slugs are extracted per page with --jq rather than decoded as JSON.
+func teamsArgs(repo domain.RepoRef) []string {
+	args := []string{"api", "--paginate", "--jq", ".[].slug", fmt.Sprintf("repos/%s/teams", repo.FullName())}
+	return append(args, hostArgs(repo)...)
+}
+
+// EditMetadata changes a PR's labels, assignees and requested reviewers
+// with a single gh pr edit.
+func (a *Adapter) EditMetadata(ctx context.Context, repo domain.RepoRef, number int, edit domain.MetadataEdit) error {
+	if _, err := ghExec(ctx, editMetadataArgs(repo, number, edit)...); err != nil {
+		return fmt.Errorf("editing PR #%d: %w", number, err)
+	}
+	return nil
+}
+
+// editMetadataArgs builds the gh pr edit invocation for edit. gh takes team
+// reviewers as "org/slug", like the domain does.
+func editMetadataArgs(repo domain.RepoRef, number int, edit domain.MetadataEdit) []string {
+	args := []string{"pr", "edit", fmt.Sprintf("%d", number)}
+	args = append(args, repoArgs(repo)...)
+	for _, flag := range []struct {
+		name   string
+		values []string
+	}{
+		{"--add-label", edit.AddLabels},
+		{"--remove-label", edit.RemoveLabels},
+		{"--add-assignee", edit.AddAssignees},
+		{"--remove-assignee", edit.RemoveAssignees},
+		{"--add-reviewer", edit.AddReviewers},
+		{"--remove-reviewer", edit.RemoveReviewers},
+	} {
+		for _, v := range flag.values {
+			args = append(args, flag.name, v)
+		}
+	}
+	return args
+}
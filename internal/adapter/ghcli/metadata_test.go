@@ -0,0 +1,32 @@
+package ghcli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+func TestEditMetadataArgs(t *testing.T) {
+	args := editMetadataArgs(domain.RepoRef{Owner: "o", Name: "r"}, 7, domain.MetadataEdit{
+		AddLabels:       []string{"ready"},
+		RemoveLabels:    []string{"wip"},
+		RemoveAssignees: []string{"bob"},
+		AddReviewers:    []string{"alice", "o/core"},
+	})
+	assert.Equal(t, []string{
+		"pr", "edit", "7", "--repo", "o/r",
+		"--add-label", "ready",
+		"--remove-label", "wip",
+		"--remove-assignee", "bob",
+		"--add-reviewer", "alice",
+		"--add-reviewer", "o/core",
+	}, args)
+}
+
+func TestTeamsArgsPaginate(t *testing.T) {
+	assert.Equal(t, []string{
+		"api", "--paginate", "--jq", ".[].slug", "repos/o/r/teams",
+	}, teamsArgs(domain.RepoRef{Owner: "o", Name: "r"}))
+}
@@ -11,7 +11,9 @@ import (
 )
 
 // Adapter implements the ghcli plugin providing PR data via the gh CLI.
-// It implements plugin.Plugin, domain.PRReader, domain.PRReviewer, and domain.PRWriter.
+// It implements plugin.Plugin, domain.PRReader, domain.PRReviewer,
+// domain.CommentManager, domain.PRWriter, domain.MergeStatusReader,
+// domain.MetadataEditor, domain.PRLifecycleManager, and domain.PRCreator.
 type Adapter struct {
 	// ghPath is the resolved path to the gh binary.
 	ghPath string
@@ -0,0 +1,249 @@
+package ghcli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/indrasvat/vivecaka/internal/adapter/githubql"
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+var _ domain.PRPager = (*Adapter)(nil)
+
+// defaultPerPage matches gh pr list's default page size.
+const defaultPerPage = 50
+
+// prListFragment selects the list-level fields for a pull request. CI comes
+// from the head commit's rollup state rather than every check context, which
+// keeps large pages from timing out.
+const prListFragment = `
+fragment prFields on PullRequest {
+  number
+  title
+  state
+  isDraft
+  url
+  createdAt
+  updatedAt
+  author { login }
+  headRefName
+  baseRefName
+  headRefOid
+  baseRefOid
+  reviewDecision
+  labels(first: 50) { nodes { name } }
+  commits(last: 1) { nodes { commit { statusCheckRollup { state } } } }
+}`
+
+// ghListPR is the GraphQL shape of a pull request in a list page.
+type ghListPR struct {
+	Number         int            `json:"number"`
+	Title          string         `json:"title"`
+	State          string         `json:"state"`
+	IsDraft        bool           `json:"isDraft"`
+	URL            string         `json:"url"`
+	CreatedAt      time.Time      `json:"createdAt"`
+	UpdatedAt      time.Time      `json:"updatedAt"`
+	Author         githubql.Actor `json:"author"`
+	HeadRefName    string         `json:"headRefName"`
+	BaseRefName    string         `json:"baseRefName"`
+	HeadRefOID     string         `json:"headRefOid"`
+	BaseRefOID     string         `json:"baseRefOid"`
+	ReviewDecision string         `json:"reviewDecision"`
+	Labels         struct {
+		Nodes []ghLabel `json:"nodes"`
+	} `json:"labels"`
+	Commits githubql.HeadCommit `json:"commits"`
+}
+
+// ghPRConnection is one page of a pullRequests or search connection.
+type ghPRConnection struct {
+	Nodes    []ghListPR        `json:"nodes"`
+	PageInfo githubql.PageInfo `json:"pageInfo"`
+}
+
+// ListPRs fetches one page of PRs via GraphQL; see ListPRPage.
+func (a *Adapter) ListPRs(ctx context.Context, repo domain.RepoRef, opts domain.ListOpts) ([]domain.PR, error) {
+	page, err := a.ListPRPage(ctx, repo, opts)
+	if err != nil {
+		return nil, err
+	}
+	return page.PRs, nil
+}
+
+// ListPRPage fetches one page of PRs via GraphQL, resuming at opts.Cursor.
+// Without a cursor, the pages before opts.Page are walked to find where it
+// starts. Author and free-text filters go through the search API, like gh
+// pr list. Drafts excluded by opts.Draft are skipped while filling the page,
+// so a page may run over PerPage by up to one GraphQL page.
+func (a *Adapter) ListPRPage(ctx context.Context, repo domain.RepoRef, opts domain.ListOpts) (domain.PRPage, error) {
+	perPage := opts.PerPage
+	if perPage <= 0 {
+		perPage = defaultPerPage
+	}
+	fetch := func(ctx context.Context, cursor string) (*ghPRConnection, error) {
+		return fetchPRListPage(ctx, repo, opts, perPage, cursor)
+	}
+
+	if opts.Cursor == "" {
+		for range max(opts.Page, 1) - 1 {
+			skipped, err := fillPRPage(ctx, opts, perPage, fetch)
+			if err != nil {
+				return domain.PRPage{}, fmt.Errorf("listing PRs: %w", err)
+			}
+			if !skipped.HasMore {
+				return domain.PRPage{PRs: []domain.PR{}}, nil
+			}
+			opts.Cursor = skipped.NextCursor
+		}
+	}
+
+	page, err := fillPRPage(ctx, opts, perPage, fetch)
+	if err != nil {
+		return domain.PRPage{}, fmt.Errorf("listing PRs: %w", err)
+	}
+	return page, nil
+}
+
+// fillPRPage walks GraphQL pages from opts.Cursor until perPage PRs pass
+// the draft filter or the listing ends.
+func fillPRPage(
+	ctx context.Context,
+	opts domain.ListOpts,
+	perPage int,
+	fetchPage func(context.Context, string) (*ghPRConnection, error),
+) (domain.PRPage, error) {
+	var page domain.PRPage
+	cursor := opts.Cursor
+	for len(page.PRs) < perPage {
+		conn, err := fetchPage(ctx, cursor)
+		if err != nil {
+			return domain.PRPage{}, err
+		}
+		for _, g := range conn.Nodes {
+			// Search can return non-PR nodes as empty objects.
+			if g.Number == 0 || !matchesDraft(g.IsDraft, opts.Draft) {
+				continue
+			}
+			page.PRs = append(page.PRs, toDomainListPR(g))
+		}
+		if !conn.PageInfo.HasNextPage {
+			return page, nil
+		}
+		cursor = conn.PageInfo.EndCursor
+	}
+	page.NextCursor = cursor
+	page.HasMore = true
+	return page, nil
+}
+
+func fetchPRListPage(ctx context.Context, repo domain.RepoRef, opts domain.ListOpts, first int, cursor string) (*ghPRConnection, error) {
+	after := "null"
+	if cursor != "" {
+		after = fmt.Sprintf("%q", cursor)
+	}
+
+	if opts.Author != "" || opts.Search != "" {
+		query := fmt.Sprintf(`query {
+  search(query: %q, type: ISSUE, first: %d, after: %s) {
+    nodes { ... on PullRequest { ...prFields } }
+    pageInfo { hasNextPage endCursor }
+  }
+}`, githubql.SearchQuery(repo, opts), first, after) + prListFragment
+
+		var result struct {
+			Data struct {
+				Search ghPRConnection `json:"search"`
+			} `json:"data"`
+		}
+		if err := ghJSON(ctx, &result, graphqlArgs(repo, query)...); err != nil {
+			return nil, err
+		}
+		return &result.Data.Search, nil
+	}
+
+	query := fmt.Sprintf(`query {
+  repository(owner: %q, name: %q) {
+    pullRequests(states: %s, labels: %s, first: %d, after: %s, orderBy: {field: CREATED_AT, direction: DESC}) {
+      nodes { ...prFields }
+      pageInfo { hasNextPage endCursor }
+    }
+  }
+}`, repo.Owner, repo.Name, gqlStates(opts.State), gqlStrings(opts.Labels), first, after) + prListFragment
+
+	var result struct {
+		Data struct {
+			Repository struct {
+				PullRequests ghPRConnection `json:"pullRequests"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+	if err := ghJSON(ctx, &result, graphqlArgs(repo, query)...); err != nil {
+		return nil, err
+	}
+	return &result.Data.Repository.PullRequests, nil
+}
+
+// gqlStates renders a state filter as a GraphQL PullRequestState list.
+// An empty state means open (matching gh); "all" means no state filter.
+func gqlStates(state domain.PRState) string {
+	switch state {
+	case "all":
+		return "null"
+	case domain.PRStateClosed:
+		return "[CLOSED]"
+	case domain.PRStateMerged:
+		return "[MERGED]"
+	default:
+		return "[OPEN]"
+	}
+}
+
+// gqlStrings renders values as a GraphQL string list, or null when empty.
+func gqlStrings(values []string) string {
+	if len(values) == 0 {
+		return "null"
+	}
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+func matchesDraft(isDraft bool, filter domain.DraftFilter) bool {
+	switch filter {
+	case domain.DraftExclude:
+		return !isDraft
+	case domain.DraftOnly:
+		return isDraft
+	default:
+		return true
+	}
+}
+
+// toDomainListPR converts a list-page pull request to a domain.PR.
+func toDomainListPR(g ghListPR) domain.PR {
+	pr := toDomainPR(ghPR{
+		Number:         g.Number,
+		Title:          g.Title,
+		Author:         g.Author,
+		State:          g.State,
+		IsDraft:        g.IsDraft,
+		HeadRefName:    g.HeadRefName,
+		BaseRefName:    g.BaseRefName,
+		HeadRefOID:     g.HeadRefOID,
+		BaseRefOID:     g.BaseRefOID,
+		Labels:         g.Labels.Nodes,
+		ReviewDecision: g.ReviewDecision,
+		UpdatedAt:      g.UpdatedAt,
+		CreatedAt:      g.CreatedAt,
+		URL:            g.URL,
+	})
+	if ci, ok := g.Commits.CIStatus(); ok {
+		pr.CI = ci
+	}
+	return pr
+}
@@ -0,0 +1,100 @@
+package ghcli
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/indrasvat/vivecaka/internal/adapter/githubql"
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+func loadPRListPage(t *testing.T) ghPRConnection {
+	t.Helper()
+	var result struct {
+		Data struct {
+			Repository struct {
+				PullRequests ghPRConnection `json:"pullRequests"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(loadFixture(t, "pr_list_page.json"), &result))
+	return result.Data.Repository.PullRequests
+}
+
+func TestToDomainListPR_FromFixture(t *testing.T) {
+	conn := loadPRListPage(t)
+	require.Len(t, conn.Nodes, 3)
+	assert.Equal(t, "Y3Vyc29yOjM=", conn.PageInfo.EndCursor)
+
+	pr := toDomainListPR(conn.Nodes[0])
+	assert.Equal(t, 142, pr.Number)
+	assert.Equal(t, "alice", pr.Author)
+	assert.Equal(t, "feat/auth", pr.Branch.Head)
+	assert.Equal(t, "1111111111111111111111111111111111111111", pr.Branch.HeadSHA)
+	assert.Equal(t, []string{"enhancement"}, pr.Labels)
+	assert.Equal(t, domain.CIPass, pr.CI)
+	assert.Equal(t, domain.ReviewApproved, pr.Review.State)
+
+	assert.Equal(t, domain.CIPending, toDomainListPR(conn.Nodes[1]).CI)
+	assert.Equal(t, domain.CINone, toDomainListPR(conn.Nodes[2]).CI, "no rollup means no checks")
+}
+
+// fakePRPages serves numbered PRs in pages of size, keyed by cursor.
+func fakePRPages(total, size int, drafts map[int]bool) (func(context.Context, string) (*ghPRConnection, error), *[]string) {
+	var cursors []string
+	return func(_ context.Context, cursor string) (*ghPRConnection, error) {
+		cursors = append(cursors, cursor)
+		start := 0
+		if cursor != "" {
+			start, _ = strconv.Atoi(cursor)
+		}
+		end := min(start+size, total)
+		conn := &ghPRConnection{PageInfo: githubql.PageInfo{HasNextPage: end < total, EndCursor: strconv.Itoa(end)}}
+		for n := start + 1; n <= end; n++ {
+			conn.Nodes = append(conn.Nodes, ghListPR{Number: n, IsDraft: drafts[n]})
+		}
+		return conn, nil
+	}, &cursors
+}
+
+func TestFillPRPageResumesAtCursor(t *testing.T) {
+	fetch, cursors := fakePRPages(5, 2, nil)
+
+	page, err := fillPRPage(t.Context(), domain.ListOpts{Cursor: "2"}, 2, fetch)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2"}, *cursors, "only the requested page is fetched")
+	require.Len(t, page.PRs, 2)
+	assert.Equal(t, 3, page.PRs[0].Number)
+	assert.True(t, page.HasMore)
+	assert.Equal(t, "4", page.NextCursor)
+
+	page, err = fillPRPage(t.Context(), domain.ListOpts{Cursor: page.NextCursor}, 2, fetch)
+	require.NoError(t, err)
+	require.Len(t, page.PRs, 1)
+	assert.False(t, page.HasMore)
+	assert.Empty(t, page.NextCursor)
+}
+
+func TestFillPRPageSkipsExcludedDrafts(t *testing.T) {
+	fetch, cursors := fakePRPages(6, 2, map[int]bool{1: true, 2: true})
+
+	page, err := fillPRPage(t.Context(), domain.ListOpts{Draft: domain.DraftExclude}, 2, fetch)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", "2"}, *cursors, "walks past a page of drafts")
+	require.Len(t, page.PRs, 2)
+	assert.Equal(t, 3, page.PRs[0].Number)
+	assert.Equal(t, "4", page.NextCursor)
+}
+
+func TestGraphQLListArgs(t *testing.T) {
+	assert.Equal(t, "[OPEN]", gqlStates(""))
+	assert.Equal(t, "[MERGED]", gqlStates(domain.PRStateMerged))
+	assert.Equal(t, "null", gqlStates("all"))
+	assert.Equal(t, "null", gqlStrings(nil))
+	assert.Equal(t, `["bug", "needs review"]`, gqlStrings([]string{"bug", "needs review"}))
+}
@@ -0,0 +1,22 @@
+package ghcli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/indrasvat/vivecaka/internal/adapter/githubql"
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+var _ domain.RateLimitReporter = (*Adapter)(nil)
+
+// RateLimit reports the API budget left on repo's host via gh api
+// rate_limit, which does not itself count against the budget.
+func (a *Adapter) RateLimit(ctx context.Context, repo domain.RepoRef) (domain.RateLimit, error) {
+	args := append([]string{"api", "rate_limit"}, hostArgs(repo)...)
+	out, err := ghExec(ctx, args...)
+	if err != nil {
+		return domain.RateLimit{}, fmt.Errorf("getting rate limit: %w", err)
+	}
+	return githubql.ParseRateLimit(out)
+}
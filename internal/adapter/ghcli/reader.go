@@ -6,46 +6,38 @@ import (
 	"strings"
 	"time"
 
+	"github.com/indrasvat/vivecaka/internal/adapter/githubql"
 	"github.com/indrasvat/vivecaka/internal/domain"
 )
 
-// JSON field lists for gh pr list/view --json.
+// JSON field lists for gh pr view/checks --json.
 const (
-	// prListFields is for the initial load (single page). Includes statusCheckRollup for CI status.
-	prListFields = "number,title,author,state,isDraft,headRefName,baseRefName,headRefOid,baseRefOid,labels,statusCheckRollup,reviewDecision,updatedAt,createdAt,url"
-	// prListFieldsLight is for pagination (loading more PRs). Excludes statusCheckRollup to avoid API timeouts.
-	// CI status will show as "none" for paginated items until detail view is opened.
-	prListFieldsLight = "number,title,author,state,isDraft,headRefName,baseRefName,headRefOid,baseRefOid,labels,reviewDecision,updatedAt,createdAt,url"
-	prViewFields      = "number,title,author,state,isDraft,headRefName,baseRefName,headRefOid,baseRefOid,labels,statusCheckRollup,reviewDecision,updatedAt,createdAt,url,body,assignees,reviewRequests,latestReviews,files"
-	checkFields       = "name,status,conclusion,startedAt,completedAt,detailsUrl"
+	prViewFields = "number,title,author,state,isDraft,headRefName,baseRefName,headRefOid,baseRefOid,labels,statusCheckRollup,reviewDecision,updatedAt,createdAt,url,body,assignees,reviewRequests,latestReviews,files"
+	checkFields  = "name,status,conclusion,startedAt,completedAt,detailsUrl"
 )
 
 // ghPR is the JSON shape returned by gh pr list/view.
 type ghPR struct {
-	Number            int           `json:"number"`
-	Title             string        `json:"title"`
-	Author            ghActor       `json:"author"`
-	State             string        `json:"state"`
-	IsDraft           bool          `json:"isDraft"`
-	HeadRefName       string        `json:"headRefName"`
-	BaseRefName       string        `json:"baseRefName"`
-	HeadRefOID        string        `json:"headRefOid"`
-	BaseRefOID        string        `json:"baseRefOid"`
-	Labels            []ghLabel     `json:"labels"`
-	StatusCheckRollup []ghCheck     `json:"statusCheckRollup"`
-	ReviewDecision    string        `json:"reviewDecision"`
-	UpdatedAt         time.Time     `json:"updatedAt"`
-	CreatedAt         time.Time     `json:"createdAt"`
-	URL               string        `json:"url"`
-	Body              string        `json:"body"`
-	Assignees         []ghActor     `json:"assignees"`
-	ReviewRequests    []ghReviewReq `json:"reviewRequests"`
-	LatestReviews     []ghReview    `json:"latestReviews"`
-	Files             []ghFile      `json:"files"`
-}
-
-type ghActor struct {
-	Login string `json:"login"`
+	Number            int              `json:"number"`
+	Title             string           `json:"title"`
+	Author            githubql.Actor   `json:"author"`
+	State             string           `json:"state"`
+	IsDraft           bool             `json:"isDraft"`
+	HeadRefName       string           `json:"headRefName"`
+	BaseRefName       string           `json:"baseRefName"`
+	HeadRefOID        string           `json:"headRefOid"`
+	BaseRefOID        string           `json:"baseRefOid"`
+	Labels            []ghLabel        `json:"labels"`
+	StatusCheckRollup []ghCheck        `json:"statusCheckRollup"`
+	ReviewDecision    string           `json:"reviewDecision"`
+	UpdatedAt         time.Time        `json:"updatedAt"`
+	CreatedAt         time.Time        `json:"createdAt"`
+	URL               string           `json:"url"`
+	Body              string           `json:"body"`
+	Assignees         []githubql.Actor `json:"assignees"`
+	ReviewRequests    []ghReviewReq    `json:"reviewRequests"`
+	LatestReviews     []ghReview       `json:"latestReviews"`
+	Files             []ghFile         `json:"files"`
 }
 
 type ghLabel struct {
@@ -63,13 +55,14 @@ type ghCheck struct {
 
 type ghReviewReq struct {
 	Login string `json:"login"`
-	// For team reviews this is under "name" key.
+	// For team reviews this is under "name" key, and slug is "org/slug".
 	Name string `json:"name"`
+	Slug string `json:"slug"`
 }
 
 type ghReview struct {
-	Author ghActor `json:"author"`
-	State  string  `json:"state"`
+	Author githubql.Actor `json:"author"`
+	State  string         `json:"state"`
 }
 
 type ghFile struct {
@@ -93,7 +86,7 @@ func (a *Adapter) GetPRCount(ctx context.Context, repo domain.RepoRef, state dom
 	query := fmt.Sprintf(`query { repository(owner: %q, name: %q) { pullRequests(states: %s) { totalCount } } }`,
 		repo.Owner, repo.Name, gqlState)
 
-	args := []string{"api", "graphql", "-f", "query=" + query}
+	args := graphqlArgs(repo, query)
 
 	var result struct {
 		Data struct {
@@ -112,81 +105,6 @@ func (a *Adapter) GetPRCount(ctx context.Context, repo domain.RepoRef, state dom
 	return result.Data.Repository.PullRequests.TotalCount, nil
 }
 
-// ListPRs fetches PRs via gh pr list --json.
-// Supports pagination via opts.Page and opts.PerPage.
-// Page is 1-based. For page > 1, we fetch page*perPage items and return only items for that page.
-// Note: For pagination (page > 1), we use a lighter field list that excludes statusCheckRollup
-// to avoid GitHub API timeouts on large result sets.
-func (a *Adapter) ListPRs(ctx context.Context, repo domain.RepoRef, opts domain.ListOpts) ([]domain.PR, error) {
-	// Use light fields for pagination to avoid API timeouts
-	fields := prListFields
-	if opts.Page > 1 {
-		fields = prListFieldsLight
-	}
-	args := []string{"pr", "list", "--json", fields}
-	args = append(args, repoArgs(repo)...)
-
-	if opts.State != "" && opts.State != "all" {
-		args = append(args, "--state", string(opts.State))
-	}
-	if opts.Author != "" {
-		args = append(args, "--author", opts.Author)
-	}
-	for _, l := range opts.Labels {
-		args = append(args, "--label", l)
-	}
-	if opts.Search != "" {
-		args = append(args, "--search", opts.Search)
-	}
-
-	// Calculate limit for pagination
-	// For page N, we need to fetch N*PerPage items total and skip the first (N-1)*PerPage
-	page := opts.Page
-	page = max(page, 1)
-	perPage := opts.PerPage
-	if perPage <= 0 {
-		perPage = 50 // default
-	}
-	limit := page * perPage
-	args = append(args, "--limit", fmt.Sprintf("%d", limit))
-
-	var ghPRs []ghPR
-	if err := ghJSON(ctx, &ghPRs, args...); err != nil {
-		return nil, fmt.Errorf("listing PRs: %w", err)
-	}
-
-	// Apply client-side draft filter BEFORE pagination so that excluded
-	// drafts don't reduce the effective page size.
-	filtered := ghPRs[:0:0]
-	for _, g := range ghPRs {
-		switch opts.Draft {
-		case domain.DraftExclude:
-			if g.IsDraft {
-				continue
-			}
-		case domain.DraftOnly:
-			if !g.IsDraft {
-				continue
-			}
-		}
-		filtered = append(filtered, g)
-	}
-
-	// Paginate over the filtered set.
-	startIdx := (page - 1) * perPage
-	if startIdx >= len(filtered) {
-		return []domain.PR{}, nil
-	}
-	pageItems := filtered[startIdx:]
-
-	prs := make([]domain.PR, 0, len(pageItems))
-	for _, g := range pageItems {
-		prs = append(prs, toDomainPR(g))
-	}
-
-	return prs, nil
-}
-
 // GetPR fetches a single PR with full details via gh pr view --json.
 func (a *Adapter) GetPR(ctx context.Context, repo domain.RepoRef, number int) (*domain.PRDetail, error) {
 	args := []string{"pr", "view", fmt.Sprintf("%d", number), "--json", prViewFields}
@@ -211,7 +129,7 @@ func (a *Adapter) GetDiff(ctx context.Context, repo domain.RepoRef, number int)
 		return nil, fmt.Errorf("getting diff for PR #%d: %w", number, err)
 	}
 
-	diff := ParseDiff(string(out))
+	diff := domain.ParseDiff(string(out))
 	return &diff, nil
 }
 
@@ -244,7 +162,9 @@ func (a *Adapter) GetComments(ctx context.Context, repo domain.RepoRef, number i
 		if err != nil {
 			return nil, fmt.Errorf("getting comments for PR #%d: %w", number, err)
 		}
-		pageThreads, err := expandReviewThreadComments(ctx, page.Nodes, fetchReviewThreadCommentsPage)
+		pageThreads, err := expandReviewThreadComments(ctx, page.Nodes, func(ctx context.Context, threadID, cursor string) (*ghGraphQLCommentConnection, error) {
+			return fetchReviewThreadCommentsPage(ctx, repo, threadID, cursor)
+		})
 		if err != nil {
 			return nil, fmt.Errorf("getting comments for PR #%d: %w", number, err)
 		}
@@ -291,23 +211,29 @@ func (a *Adapter) GetDiscussion(ctx context.Context, repo domain.RepoRef, number
 	return discussion, nil
 }
 
-type ghPageInfo struct {
-	HasNextPage bool   `json:"hasNextPage"`
-	EndCursor   string `json:"endCursor"`
+type ghGraphQLComment struct {
+	ID              string            `json:"id"`
+	DatabaseID      int               `json:"databaseId"`
+	Body            string            `json:"body"`
+	CreatedAt       time.Time         `json:"createdAt"`
+	URL             string            `json:"url"`
+	Author          githubql.Actor    `json:"author"`
+	ViewerCanUpdate bool              `json:"viewerCanUpdate"`
+	ViewerCanDelete bool              `json:"viewerCanDelete"`
+	ReactionGroups  []ghReactionGroup `json:"reactionGroups"`
 }
 
-type ghGraphQLComment struct {
-	ID         string    `json:"id"`
-	DatabaseID int       `json:"databaseId"`
-	Body       string    `json:"body"`
-	CreatedAt  time.Time `json:"createdAt"`
-	URL        string    `json:"url"`
-	Author     ghActor   `json:"author"`
+type ghReactionGroup struct {
+	Content          string `json:"content"`
+	ViewerHasReacted bool   `json:"viewerHasReacted"`
+	Reactors         struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"reactors"`
 }
 
 type ghGraphQLCommentConnection struct {
 	Nodes    []ghGraphQLComment `json:"nodes"`
-	PageInfo ghPageInfo         `json:"pageInfo"`
+	PageInfo githubql.PageInfo  `json:"pageInfo"`
 }
 
 type ghReviewThread struct {
@@ -315,39 +241,46 @@ type ghReviewThread struct {
 	IsResolved bool                       `json:"isResolved"`
 	Path       string                     `json:"path"`
 	Line       *int                       `json:"line"`
+	StartLine  *int                       `json:"startLine"`
 	Comments   ghGraphQLCommentConnection `json:"comments"`
 }
 
 type ghReviewThreadsPage struct {
-	Nodes    []ghReviewThread `json:"nodes"`
-	PageInfo ghPageInfo       `json:"pageInfo"`
+	Nodes    []ghReviewThread  `json:"nodes"`
+	PageInfo githubql.PageInfo `json:"pageInfo"`
 }
 
 type ghReviewSummary struct {
-	ID          string    `json:"id"`
-	Body        string    `json:"body"`
-	State       string    `json:"state"`
-	SubmittedAt time.Time `json:"submittedAt"`
-	URL         string    `json:"url"`
-	Author      ghActor   `json:"author"`
+	ID              string            `json:"id"`
+	Body            string            `json:"body"`
+	State           string            `json:"state"`
+	SubmittedAt     time.Time         `json:"submittedAt"`
+	URL             string            `json:"url"`
+	Author          githubql.Actor    `json:"author"`
+	ViewerCanUpdate bool              `json:"viewerCanUpdate"`
+	ViewerCanDelete bool              `json:"viewerCanDelete"`
+	ReactionGroups  []ghReactionGroup `json:"reactionGroups"`
 }
 
 type ghReviewsPage struct {
 	Nodes    []ghReviewSummary `json:"nodes"`
-	PageInfo ghPageInfo        `json:"pageInfo"`
+	PageInfo githubql.PageInfo `json:"pageInfo"`
 }
 
 type ghIssueComment struct {
-	ID        string    `json:"id"`
-	Body      string    `json:"body"`
-	CreatedAt time.Time `json:"createdAt"`
-	URL       string    `json:"url"`
-	Author    ghActor   `json:"author"`
+	ID              string            `json:"id"`
+	Body            string            `json:"body"`
+	CreatedAt       time.Time         `json:"createdAt"`
+	URL             string            `json:"url"`
+	Author          githubql.Actor    `json:"author"`
+	ViewerCanUpdate bool              `json:"viewerCanUpdate"`
+	ViewerCanDelete bool              `json:"viewerCanDelete"`
+	ReactionGroups  []ghReactionGroup `json:"reactionGroups"`
 }
 
 type ghIssueCommentsPage struct {
-	Nodes    []ghIssueComment `json:"nodes"`
-	PageInfo ghPageInfo       `json:"pageInfo"`
+	Nodes    []ghIssueComment  `json:"nodes"`
+	PageInfo githubql.PageInfo `json:"pageInfo"`
 }
 
 func fetchReviewThreadsPage(ctx context.Context, repo domain.RepoRef, number int, cursor string) (*ghReviewThreadsPage, error) {
@@ -365,6 +298,7 @@ func fetchReviewThreadsPage(ctx context.Context, repo domain.RepoRef, number int
           isResolved
           path
           line
+          startLine
 	          comments(first: 100) {
 	            nodes {
 	              id
@@ -373,6 +307,9 @@ func fetchReviewThreadsPage(ctx context.Context, repo domain.RepoRef, number int
 	              createdAt
 	              url
 	              author { login }
+	              viewerCanUpdate
+	              viewerCanDelete
+	              reactionGroups { content viewerHasReacted reactors { totalCount } }
 	            }
 	            pageInfo { hasNextPage endCursor }
 	          }
@@ -392,13 +329,13 @@ func fetchReviewThreadsPage(ctx context.Context, repo domain.RepoRef, number int
 			} `json:"repository"`
 		} `json:"data"`
 	}
-	if err := ghJSON(ctx, &result, "api", "graphql", "-f", "query="+query); err != nil {
+	if err := ghJSON(ctx, &result, graphqlArgs(repo, query)...); err != nil {
 		return nil, err
 	}
 	return &result.Data.Repository.PullRequest.ReviewThreads, nil
 }
 
-func fetchReviewThreadCommentsPage(ctx context.Context, threadID, cursor string) (*ghGraphQLCommentConnection, error) {
+func fetchReviewThreadCommentsPage(ctx context.Context, repo domain.RepoRef, threadID, cursor string) (*ghGraphQLCommentConnection, error) {
 	after := "null"
 	if cursor != "" {
 		after = fmt.Sprintf("%q", cursor)
@@ -415,6 +352,9 @@ func fetchReviewThreadCommentsPage(ctx context.Context, threadID, cursor string)
           createdAt
           url
           author { login }
+          viewerCanUpdate
+          viewerCanDelete
+          reactionGroups { content viewerHasReacted reactors { totalCount } }
         }
         pageInfo { hasNextPage endCursor }
       }
@@ -429,7 +369,7 @@ func fetchReviewThreadCommentsPage(ctx context.Context, threadID, cursor string)
 			} `json:"node"`
 		} `json:"data"`
 	}
-	if err := ghJSON(ctx, &result, "api", "graphql", "-f", "query="+query); err != nil {
+	if err := ghJSON(ctx, &result, graphqlArgs(repo, query)...); err != nil {
 		return nil, err
 	}
 	return &result.Data.Node.Comments, nil
@@ -485,6 +425,9 @@ func fetchReviewsPage(ctx context.Context, repo domain.RepoRef, number int, curs
           submittedAt
           url
           author { login }
+          viewerCanUpdate
+          viewerCanDelete
+          reactionGroups { content viewerHasReacted reactors { totalCount } }
         }
         pageInfo { hasNextPage endCursor }
       }
@@ -501,7 +444,7 @@ func fetchReviewsPage(ctx context.Context, repo domain.RepoRef, number int, curs
 			} `json:"repository"`
 		} `json:"data"`
 	}
-	if err := ghJSON(ctx, &result, "api", "graphql", "-f", "query="+query); err != nil {
+	if err := ghJSON(ctx, &result, graphqlArgs(repo, query)...); err != nil {
 		return nil, err
 	}
 	return &result.Data.Repository.PullRequest.Reviews, nil
@@ -523,6 +466,9 @@ func fetchIssueCommentsPage(ctx context.Context, repo domain.RepoRef, number int
           createdAt
           url
           author { login }
+          viewerCanUpdate
+          viewerCanDelete
+          reactionGroups { content viewerHasReacted reactors { totalCount } }
         }
         pageInfo { hasNextPage endCursor }
       }
@@ -539,7 +485,7 @@ func fetchIssueCommentsPage(ctx context.Context, repo domain.RepoRef, number int
 			} `json:"repository"`
 		} `json:"data"`
 	}
-	if err := ghJSON(ctx, &result, "api", "graphql", "-f", "query="+query); err != nil {
+	if err := ghJSON(ctx, &result, graphqlArgs(repo, query)...); err != nil {
 		return nil, err
 	}
 	return &result.Data.Repository.PullRequest.Comments, nil
@@ -555,6 +501,10 @@ func toDomainCommentThreads(threads []ghReviewThread) []domain.CommentThread {
 		if thread.Line != nil {
 			line = *thread.Line
 		}
+		startLine := 0
+		if thread.StartLine != nil && *thread.StartLine != line {
+			startLine = *thread.StartLine
+		}
 
 		comments := make([]domain.Comment, 0, len(thread.Comments.Nodes))
 		replyToID := ""
@@ -566,10 +516,14 @@ func toDomainCommentThreads(threads []ghReviewThread) []domain.CommentThread {
 			}
 			comments = append(comments, domain.Comment{
 				ID:        fmt.Sprintf("%d", c.DatabaseID),
+				NodeID:    c.ID,
 				Author:    c.Author.Login,
 				Body:      c.Body,
 				CreatedAt: c.CreatedAt,
 				URL:       c.URL,
+				CanEdit:   c.ViewerCanUpdate,
+				CanDelete: c.ViewerCanDelete,
+				Reactions: toDomainReactions(c.ReactionGroups),
 			})
 		}
 
@@ -579,6 +533,7 @@ func toDomainCommentThreads(threads []ghReviewThread) []domain.CommentThread {
 			ReplyToID: replyToID,
 			Path:      thread.Path,
 			Line:      line,
+			StartLine: startLine,
 			Resolved:  thread.IsResolved,
 			Comments:  comments,
 		})
@@ -601,10 +556,14 @@ func toDomainReviewItems(reviews []ghReviewSummary) []domain.DiscussionItem {
 			URL:         review.URL,
 			Comments: []domain.Comment{{
 				ID:        review.ID,
+				NodeID:    review.ID,
 				Author:    review.Author.Login,
 				Body:      review.Body,
 				CreatedAt: review.SubmittedAt,
 				URL:       review.URL,
+				CanEdit:   review.ViewerCanUpdate,
+				CanDelete: review.ViewerCanDelete,
+				Reactions: toDomainReactions(review.ReactionGroups),
 			}},
 		})
 	}
@@ -624,16 +583,37 @@ func toDomainIssueComments(comments []ghIssueComment) []domain.DiscussionItem {
 			URL:       comment.URL,
 			Comments: []domain.Comment{{
 				ID:        comment.ID,
+				NodeID:    comment.ID,
 				Author:    comment.Author.Login,
 				Body:      comment.Body,
 				CreatedAt: comment.CreatedAt,
 				URL:       comment.URL,
+				CanEdit:   comment.ViewerCanUpdate,
+				CanDelete: comment.ViewerCanDelete,
+				Reactions: toDomainReactions(comment.ReactionGroups),
 			}},
 		})
 	}
 	return items
 }
 
+// toDomainReactions converts reaction groups, leaving out the emoji nobody
+// reacted with.
+func toDomainReactions(groups []ghReactionGroup) []domain.Reaction {
+	var out []domain.Reaction
+	for _, g := range groups {
+		if g.Reactors.TotalCount == 0 {
+			continue
+		}
+		out = append(out, domain.Reaction{
+			Content:       domain.ReactionContent(g.Content),
+			Count:         g.Reactors.TotalCount,
+			ViewerReacted: g.ViewerHasReacted,
+		})
+	}
+	return out
+}
+
 // toDomainPR converts a ghPR to a domain.PR.
 func toDomainPR(g ghPR) domain.PR {
 	labels := make([]string, len(g.Labels))
@@ -645,7 +625,7 @@ func toDomainPR(g ghPR) domain.PR {
 		Number: g.Number,
 		Title:  g.Title,
 		Author: g.Author.Login,
-		State:  mapState(g.State),
+		State:  githubql.MapState(g.State),
 		Draft:  g.IsDraft,
 		Branch: domain.BranchInfo{
 			Head:    g.HeadRefName,
@@ -655,7 +635,7 @@ func toDomainPR(g ghPR) domain.PR {
 		},
 		Labels:         labels,
 		CI:             aggregateCI(g.StatusCheckRollup),
-		Review:         mapReviewDecision(g.ReviewDecision),
+		Review:         githubql.MapReviewDecision(g.ReviewDecision),
 		UpdatedAt:      g.UpdatedAt,
 		CreatedAt:      g.CreatedAt,
 		URL:            g.URL,
@@ -674,6 +654,9 @@ func toDomainPRDetail(g ghPR) domain.PRDetail {
 	// Add review requests (pending).
 	for _, rr := range g.ReviewRequests {
 		login := rr.Login
+		if login == "" {
+			login = rr.Slug
+		}
 		if login == "" {
 			login = rr.Name
 		}
@@ -735,25 +718,12 @@ func toDomainCheck(c ghCheck) domain.Check {
 	}
 	return domain.Check{
 		Name:     c.Name,
-		Status:   mapCheckStatus(c.Status, c.Conclusion),
+		Status:   githubql.MapCheckStatus(c.Status, c.Conclusion),
 		Duration: duration,
 		URL:      c.DetailsURL,
 	}
 }
 
-func mapState(s string) domain.PRState {
-	switch s {
-	case "OPEN":
-		return domain.PRStateOpen
-	case "CLOSED":
-		return domain.PRStateClosed
-	case "MERGED":
-		return domain.PRStateMerged
-	default:
-		return domain.PRStateOpen
-	}
-}
-
 func aggregateCI(checks []ghCheck) domain.CIStatus {
 	if len(checks) == 0 {
 		return domain.CINone
@@ -761,7 +731,7 @@ func aggregateCI(checks []ghCheck) domain.CIStatus {
 	hasPending := false
 	hasFail := false
 	for _, c := range checks {
-		st := mapCheckStatus(c.Status, c.Conclusion)
+		st := githubql.MapCheckStatus(c.Status, c.Conclusion)
 		switch st {
 		case domain.CIFail:
 			hasFail = true
@@ -778,39 +748,6 @@ func aggregateCI(checks []ghCheck) domain.CIStatus {
 	return domain.CIPass
 }
 
-func mapCheckStatus(status, conclusion string) domain.CIStatus {
-	switch status {
-	case "COMPLETED":
-		switch conclusion {
-		case "SUCCESS":
-			return domain.CIPass
-		case "FAILURE", "TIMED_OUT", "STARTUP_FAILURE":
-			return domain.CIFail
-		case "SKIPPED", "NEUTRAL":
-			return domain.CISkipped
-		default:
-			return domain.CIFail
-		}
-	case "IN_PROGRESS", "QUEUED", "PENDING", "WAITING", "REQUESTED":
-		return domain.CIPending
-	default:
-		return domain.CINone
-	}
-}
-
-func mapReviewDecision(decision string) domain.ReviewStatus {
-	switch decision {
-	case "APPROVED":
-		return domain.ReviewStatus{State: domain.ReviewApproved}
-	case "CHANGES_REQUESTED":
-		return domain.ReviewStatus{State: domain.ReviewChangesRequested}
-	case "REVIEW_REQUIRED":
-		return domain.ReviewStatus{State: domain.ReviewPending}
-	default:
-		return domain.ReviewStatus{State: domain.ReviewNone}
-	}
-}
-
 func mapReviewState(state string) domain.ReviewState {
 	switch state {
 	case "APPROVED":
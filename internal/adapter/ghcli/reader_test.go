@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/indrasvat/vivecaka/internal/adapter/githubql"
 	"github.com/indrasvat/vivecaka/internal/domain"
 )
 
@@ -102,6 +103,7 @@ func TestToDomainPRDetail_FromFixture(t *testing.T) {
 
 func TestToDomainCommentThreads(t *testing.T) {
 	line45 := 45
+	line40 := 40
 	line20 := 20
 	threads := toDomainCommentThreads([]ghReviewThread{
 		{
@@ -109,10 +111,11 @@ func TestToDomainCommentThreads(t *testing.T) {
 			IsResolved: false,
 			Path:       "internal/auth/middleware.go",
 			Line:       &line45,
+			StartLine:  &line40,
 			Comments: ghGraphQLCommentConnection{
 				Nodes: []ghGraphQLComment{
-					{DatabaseID: 1001, Body: "Please add logging.", CreatedAt: time.Now(), Author: ghActor{Login: "frank"}},
-					{DatabaseID: 1002, Body: "Fixing in next commit.", CreatedAt: time.Now(), Author: ghActor{Login: "alice"}},
+					{DatabaseID: 1001, Body: "Please add logging.", CreatedAt: time.Now(), Author: githubql.Actor{Login: "frank"}},
+					{DatabaseID: 1002, Body: "Fixing in next commit.", CreatedAt: time.Now(), Author: githubql.Actor{Login: "alice"}},
 				},
 			},
 		},
@@ -123,7 +126,7 @@ func TestToDomainCommentThreads(t *testing.T) {
 			Line:       &line20,
 			Comments: ghGraphQLCommentConnection{
 				Nodes: []ghGraphQLComment{
-					{DatabaseID: 1003, Body: "Looks good.", CreatedAt: time.Now(), Author: ghActor{Login: "bob"}},
+					{DatabaseID: 1003, Body: "Looks good.", CreatedAt: time.Now(), Author: githubql.Actor{Login: "bob"}},
 				},
 			},
 		},
@@ -135,6 +138,7 @@ func TestToDomainCommentThreads(t *testing.T) {
 	assert.Equal(t, "1001", threads[0].ReplyToID)
 	assert.Equal(t, "internal/auth/middleware.go", threads[0].Path)
 	assert.Equal(t, 45, threads[0].Line)
+	assert.Equal(t, 40, threads[0].StartLine)
 	require.Len(t, threads[0].Comments, 2)
 	assert.Equal(t, "frank", threads[0].Comments[0].Author)
 	assert.Equal(t, "alice", threads[0].Comments[1].Author)
@@ -145,6 +149,7 @@ func TestToDomainCommentThreads(t *testing.T) {
 	assert.True(t, threads[1].Resolved)
 	assert.Equal(t, "internal/auth/token.go", threads[1].Path)
 	assert.Equal(t, 20, threads[1].Line)
+	assert.Zero(t, threads[1].StartLine)
 }
 
 func TestToDomainCommentThreads_Empty(t *testing.T) {
@@ -158,9 +163,9 @@ func TestExpandReviewThreadComments(t *testing.T) {
 		ID: "PRRT_1",
 		Comments: ghGraphQLCommentConnection{
 			Nodes: []ghGraphQLComment{
-				{DatabaseID: 1001, Body: "root", CreatedAt: t0, Author: ghActor{Login: "alice"}},
+				{DatabaseID: 1001, Body: "root", CreatedAt: t0, Author: githubql.Actor{Login: "alice"}},
 			},
-			PageInfo: ghPageInfo{HasNextPage: true, EndCursor: "cursor-1"},
+			PageInfo: githubql.PageInfo{HasNextPage: true, EndCursor: "cursor-1"},
 		},
 	}}
 
@@ -173,17 +178,17 @@ func TestExpandReviewThreadComments(t *testing.T) {
 			require.Equal(t, "cursor-1", cursor)
 			return &ghGraphQLCommentConnection{
 				Nodes: []ghGraphQLComment{
-					{DatabaseID: 1002, Body: "reply-1", CreatedAt: t0.Add(time.Minute), Author: ghActor{Login: "bob"}},
+					{DatabaseID: 1002, Body: "reply-1", CreatedAt: t0.Add(time.Minute), Author: githubql.Actor{Login: "bob"}},
 				},
-				PageInfo: ghPageInfo{HasNextPage: true, EndCursor: "cursor-2"},
+				PageInfo: githubql.PageInfo{HasNextPage: true, EndCursor: "cursor-2"},
 			}, nil
 		case 2:
 			require.Equal(t, "cursor-2", cursor)
 			return &ghGraphQLCommentConnection{
 				Nodes: []ghGraphQLComment{
-					{DatabaseID: 1003, Body: "reply-2", CreatedAt: t0.Add(2 * time.Minute), Author: ghActor{Login: "carol"}},
+					{DatabaseID: 1003, Body: "reply-2", CreatedAt: t0.Add(2 * time.Minute), Author: githubql.Actor{Login: "carol"}},
 				},
-				PageInfo: ghPageInfo{HasNextPage: false},
+				PageInfo: githubql.PageInfo{HasNextPage: false},
 			}, nil
 		default:
 			t.Fatalf("unexpected extra fetch for cursor %q", cursor)
@@ -205,8 +210,8 @@ func TestExpandReviewThreadComments(t *testing.T) {
 
 func TestToDomainReviewItems(t *testing.T) {
 	items := toDomainReviewItems([]ghReviewSummary{
-		{ID: "PRR_1", Body: "LGTM", State: "APPROVED", SubmittedAt: time.Now(), URL: "https://example.com/review/1", Author: ghActor{Login: "indrasvat"}},
-		{ID: "PRR_2", Body: "   ", State: "COMMENTED", SubmittedAt: time.Now(), Author: ghActor{Login: "skip"}},
+		{ID: "PRR_1", Body: "LGTM", State: "APPROVED", SubmittedAt: time.Now(), URL: "https://example.com/review/1", Author: githubql.Actor{Login: "indrasvat"}},
+		{ID: "PRR_2", Body: "   ", State: "COMMENTED", SubmittedAt: time.Now(), Author: githubql.Actor{Login: "skip"}},
 	})
 
 	require.Len(t, items, 1)
@@ -218,8 +223,8 @@ func TestToDomainReviewItems(t *testing.T) {
 
 func TestToDomainIssueComments(t *testing.T) {
 	items := toDomainIssueComments([]ghIssueComment{
-		{ID: "IC_1", Body: "Please test the binary.", CreatedAt: time.Now(), URL: "https://example.com/comment/1", Author: ghActor{Login: "indrasvat"}},
-		{ID: "IC_2", Body: "   ", CreatedAt: time.Now(), Author: ghActor{Login: "skip"}},
+		{ID: "IC_1", Body: "Please test the binary.", CreatedAt: time.Now(), URL: "https://example.com/comment/1", Author: githubql.Actor{Login: "indrasvat"}},
+		{ID: "IC_2", Body: "   ", CreatedAt: time.Now(), Author: githubql.Actor{Login: "skip"}},
 	})
 
 	require.Len(t, items, 1)
@@ -228,6 +233,26 @@ func TestToDomainIssueComments(t *testing.T) {
 	assert.Equal(t, "Please test the binary.", items[0].Comments[0].Body)
 }
 
+func TestToDomainIssueCommentsViewerFields(t *testing.T) {
+	var comments []ghIssueComment
+	require.NoError(t, json.Unmarshal([]byte(`[{
+		"id": "IC_1", "body": "Ship it", "author": {"login": "me"},
+		"viewerCanUpdate": true, "viewerCanDelete": true,
+		"reactionGroups": [
+			{"content": "THUMBS_UP", "viewerHasReacted": true, "reactors": {"totalCount": 3}},
+			{"content": "EYES", "viewerHasReacted": false, "reactors": {"totalCount": 0}}
+		]
+	}]`), &comments))
+
+	items := toDomainIssueComments(comments)
+	require.Len(t, items, 1)
+	c := items[0].Comments[0]
+	assert.Equal(t, "IC_1", c.NodeID)
+	assert.True(t, c.CanEdit)
+	assert.True(t, c.CanDelete)
+	assert.Equal(t, []domain.Reaction{{Content: domain.ReactionThumbsUp, Count: 3, ViewerReacted: true}}, c.Reactions)
+}
+
 func TestToDomainCheck_AllStatuses(t *testing.T) {
 	data := loadFixture(t, "pr_checks.json")
 	var ghChecks []ghCheck
@@ -283,20 +308,6 @@ func TestAggregateCI(t *testing.T) {
 	}
 }
 
-func TestMapState(t *testing.T) {
-	assert.Equal(t, domain.PRStateOpen, mapState("OPEN"))
-	assert.Equal(t, domain.PRStateClosed, mapState("CLOSED"))
-	assert.Equal(t, domain.PRStateMerged, mapState("MERGED"))
-	assert.Equal(t, domain.PRStateOpen, mapState("UNKNOWN"))
-}
-
-func TestMapReviewDecision(t *testing.T) {
-	assert.Equal(t, domain.ReviewApproved, mapReviewDecision("APPROVED").State)
-	assert.Equal(t, domain.ReviewChangesRequested, mapReviewDecision("CHANGES_REQUESTED").State)
-	assert.Equal(t, domain.ReviewPending, mapReviewDecision("REVIEW_REQUIRED").State)
-	assert.Equal(t, domain.ReviewNone, mapReviewDecision("").State)
-}
-
 func TestMapReviewState(t *testing.T) {
 	assert.Equal(t, domain.ReviewApproved, mapReviewState("APPROVED"))
 	assert.Equal(t, domain.ReviewChangesRequested, mapReviewState("CHANGES_REQUESTED"))
@@ -305,25 +316,9 @@ func TestMapReviewState(t *testing.T) {
 	assert.Equal(t, domain.ReviewNone, mapReviewState(""))
 }
 
-func TestMapCheckStatus(t *testing.T) {
-	assert.Equal(t, domain.CIPass, mapCheckStatus("COMPLETED", "SUCCESS"))
-	assert.Equal(t, domain.CIFail, mapCheckStatus("COMPLETED", "FAILURE"))
-	assert.Equal(t, domain.CIFail, mapCheckStatus("COMPLETED", "TIMED_OUT"))
-	assert.Equal(t, domain.CIFail, mapCheckStatus("COMPLETED", "STARTUP_FAILURE"))
-	assert.Equal(t, domain.CISkipped, mapCheckStatus("COMPLETED", "SKIPPED"))
-	assert.Equal(t, domain.CISkipped, mapCheckStatus("COMPLETED", "NEUTRAL"))
-	assert.Equal(t, domain.CIFail, mapCheckStatus("COMPLETED", "CANCELED"))
-	assert.Equal(t, domain.CIPending, mapCheckStatus("IN_PROGRESS", ""))
-	assert.Equal(t, domain.CIPending, mapCheckStatus("QUEUED", ""))
-	assert.Equal(t, domain.CIPending, mapCheckStatus("PENDING", ""))
-	assert.Equal(t, domain.CIPending, mapCheckStatus("WAITING", ""))
-	assert.Equal(t, domain.CIPending, mapCheckStatus("REQUESTED", ""))
-	assert.Equal(t, domain.CINone, mapCheckStatus("UNKNOWN", ""))
-}
-
 func TestParseDiff_FromFixture(t *testing.T) {
 	data := loadFixture(t, "pr_diff.txt")
-	diff := ParseDiff(string(data))
+	diff := domain.ParseDiff(string(data))
 
 	require.Len(t, diff.Files, 2)
 
@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"os/exec"
 	"regexp"
+	"slices"
 	"strings"
 
+	"github.com/indrasvat/vivecaka/internal/adapter/githubql"
 	"github.com/indrasvat/vivecaka/internal/domain"
 )
 
@@ -28,10 +30,10 @@ func DetectRepo(ctx context.Context) (domain.RepoRef, error) {
 }
 
 var (
-	// SSH: git@github.com:owner/repo.git
-	sshPattern = regexp.MustCompile(`^git@github\.com:([^/]+)/(.+?)(?:\.git)?$`)
-	// HTTPS: https://github.com/owner/repo.git
-	httpsPattern = regexp.MustCompile(`^https?://github\.com/([^/]+)/(.+?)(?:\.git)?$`)
+	// SCP-like SSH: git@github.com:owner/repo.git
+	scpPattern = regexp.MustCompile(`^(?:[^@/]+@)?([^:/]+):([^/].*?)(?:\.git)?/?$`)
+	// URL form: https://github.com/owner/repo.git, ssh://git@host:22/owner/repo.git
+	urlPattern = regexp.MustCompile(`^(?:https?|ssh|git)://(?:[^@/]+@)?([^/:]+)(?::\d+)?/(.+?)(?:\.git)?/?$`)
 )
 
 // ListUserRepos fetches the authenticated user's repos via `gh repo list`.
@@ -74,16 +76,36 @@ func ValidateRepo(ctx context.Context, repo domain.RepoRef) error {
 	return err
 }
 
-// ParseRemoteURL extracts owner/name from a GitHub remote URL.
-// Supports both SSH and HTTPS formats.
+// ParseRemoteURL extracts the repo from a GitHub remote URL. SSH (both
+// scp-like and ssh://) and HTTPS formats are supported, for github.com and
+// any GitHub Enterprise Server host gh knows about (see githubql.KnownHosts).
 func ParseRemoteURL(url string) (domain.RepoRef, bool) {
+	return parseRemoteURL(url, githubql.KnownHosts())
+}
+
+func parseRemoteURL(url string, hosts []string) (domain.RepoRef, bool) {
 	url = strings.TrimSpace(url)
 
-	if m := sshPattern.FindStringSubmatch(url); m != nil {
-		return domain.RepoRef{Owner: m[1], Name: m[2]}, true
+	var host, path string
+	if m := urlPattern.FindStringSubmatch(url); m != nil {
+		host, path = m[1], m[2]
+	} else if m := scpPattern.FindStringSubmatch(url); m != nil {
+		host, path = m[1], m[2]
+	} else {
+		return domain.RepoRef{}, false
+	}
+
+	host = strings.ToLower(host)
+	if !slices.Contains(hosts, host) {
+		return domain.RepoRef{}, false
+	}
+	owner, name, ok := strings.Cut(path, "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return domain.RepoRef{}, false
 	}
-	if m := httpsPattern.FindStringSubmatch(url); m != nil {
-		return domain.RepoRef{Owner: m[1], Name: m[2]}, true
+	ref := domain.RepoRef{Owner: owner, Name: name}
+	if host != domain.DefaultHost {
+		ref.Host = host
 	}
-	return domain.RepoRef{}, false
+	return ref, true
 }
@@ -1,12 +1,21 @@
 package ghcli
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/indrasvat/vivecaka/internal/adapter/githubql"
+	"github.com/indrasvat/vivecaka/internal/domain"
 )
 
 func TestParseRemoteURL(t *testing.T) {
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+	t.Setenv("GH_HOST", "")
+
 	tests := []struct {
 		name  string
 		url   string
@@ -93,3 +102,74 @@ func TestParseRemoteURL(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRemoteURLEnterpriseHost(t *testing.T) {
+	hosts := []string{"github.com", "ghe.example.com"}
+	tests := []struct {
+		name string
+		url  string
+		want domain.RepoRef
+		ok   bool
+	}{
+		{
+			name: "SSH scp-like",
+			url:  "git@ghe.example.com:org/repo.git",
+			want: domain.RepoRef{Host: "ghe.example.com", Owner: "org", Name: "repo"}, ok: true,
+		},
+		{
+			name: "SSH URL with port",
+			url:  "ssh://git@ghe.example.com:2222/org/repo.git",
+			want: domain.RepoRef{Host: "ghe.example.com", Owner: "org", Name: "repo"}, ok: true,
+		},
+		{
+			name: "HTTPS mixed-case host",
+			url:  "https://GHE.example.com/org/repo",
+			want: domain.RepoRef{Host: "ghe.example.com", Owner: "org", Name: "repo"}, ok: true,
+		},
+		{
+			name: "HTTPS with credentials",
+			url:  "https://user@ghe.example.com/org/repo.git",
+			want: domain.RepoRef{Host: "ghe.example.com", Owner: "org", Name: "repo"}, ok: true,
+		},
+		{
+			name: "github.com keeps empty host",
+			url:  "ssh://git@github.com/org/repo.git",
+			want: domain.RepoRef{Owner: "org", Name: "repo"}, ok: true,
+		},
+		{
+			name: "unknown host",
+			url:  "git@git.other.com:org/repo.git",
+			ok:   false,
+		},
+		{
+			name: "nested path",
+			url:  "https://ghe.example.com/org/sub/repo.git",
+			ok:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, ok := parseRemoteURL(tt.url, hosts)
+			assert.Equal(t, tt.ok, ok)
+			if ok {
+				assert.Equal(t, tt.want, ref)
+			}
+		})
+	}
+}
+
+func TestKnownHosts(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("GH_CONFIG_DIR", dir)
+	t.Setenv("GH_HOST", "ghe.env.example.com")
+
+	hostsYAML := "github.com:\n    user: octocat\nGHE.corp.example.com:\n    user: octocat\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "hosts.yml"), []byte(hostsYAML), 0o600))
+
+	assert.Equal(t, []string{"github.com", "ghe.env.example.com", "ghe.corp.example.com"}, githubql.KnownHosts())
+
+	ref, ok := ParseRemoteURL("git@ghe.corp.example.com:org/repo.git")
+	require.True(t, ok)
+	assert.Equal(t, "ghe.corp.example.com/org/repo", ref.String())
+}
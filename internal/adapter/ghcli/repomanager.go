@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/gitutil"
 )
 
 // Compile-time check that Adapter implements domain.RepoManager.
@@ -80,27 +81,11 @@ func (a *Adapter) CloneRepo(ctx context.Context, repo domain.RepoRef, targetPath
 // It fetches the PR ref first (needed for fork branches), then creates the worktree.
 // Uses a unique local branch name (pr-<number>) to avoid collisions with existing
 // branches — e.g. a fork PR named "main" would otherwise overwrite the local main.
-func (a *Adapter) CreateWorktree(ctx context.Context, repoPath string, number int, branch, worktreePath string) error {
+func (a *Adapter) CreateWorktree(ctx context.Context, _ domain.RepoRef, repoPath string, number int, branch, worktreePath string) error {
 	// Use a unique local branch name to avoid colliding with existing branches.
 	localBranch := fmt.Sprintf("pr-%d", number)
-
-	// Step 1: Fetch the PR ref into our unique local branch.
-	fetchCmd := exec.CommandContext(ctx, "git", "-C", repoPath,
-		"fetch", "origin", fmt.Sprintf("pull/%d/head:%s", number, localBranch))
-	if out, err := fetchCmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("fetching PR #%d ref: %s: %w", number, strings.TrimSpace(string(out)), err)
-	}
-
-	// Step 2: Create the worktree.
-	wtCmd := exec.CommandContext(ctx, "git", "-C", repoPath,
-		"worktree", "add", worktreePath, localBranch)
-	if out, err := wtCmd.CombinedOutput(); err != nil {
-		// Cleanup on failure: remove worktree and the branch we created.
-		cleanCmd := exec.CommandContext(ctx, "git", "-C", repoPath, "worktree", "remove", worktreePath)
-		_ = cleanCmd.Run()
-		delCmd := exec.CommandContext(ctx, "git", "-C", repoPath, "branch", "-D", localBranch)
-		_ = delCmd.Run()
-		return fmt.Errorf("creating worktree: %s: %w", strings.TrimSpace(string(out)), err)
+	if err := gitutil.AddWorktree(ctx, repoPath, fmt.Sprintf("pull/%d/head", number), localBranch, worktreePath); err != nil {
+		return fmt.Errorf("creating worktree for PR #%d: %w", number, err)
 	}
 	return nil
 }
@@ -7,8 +7,14 @@ import (
 	"github.com/indrasvat/vivecaka/internal/domain"
 )
 
-// SubmitReview submits a review via gh pr review.
+// SubmitReview submits a review via gh pr review. A review with inline
+// comments goes to the REST reviews endpoint instead, which gh pr review
+// cannot reach.
 func (a *Adapter) SubmitReview(ctx context.Context, repo domain.RepoRef, number int, review domain.Review) error {
+	if len(review.Comments) > 0 {
+		return a.submitReviewWithComments(ctx, repo, number, review)
+	}
+
 	args := []string{"pr", "review", fmt.Sprintf("%d", number)}
 	args = append(args, repoArgs(repo)...)
 
@@ -33,9 +39,61 @@ func (a *Adapter) SubmitReview(ctx context.Context, repo domain.RepoRef, number
 	return nil
 }
 
+// submitReviewWithComments posts a review and its inline comments in one
+// request. gh api builds the comments array from repeated comments[][key]
+// fields, starting a new object each time path recurs.
+func (a *Adapter) submitReviewWithComments(ctx context.Context, repo domain.RepoRef, number int, review domain.Review) error {
+	args, err := reviewAPIArgs(repo, number, review)
+	if err != nil {
+		return err
+	}
+	if _, err := ghExec(ctx, args...); err != nil {
+		return fmt.Errorf("submitting review for PR #%d: %w", number, err)
+	}
+	return nil
+}
+
+// reviewAPIArgs builds the gh api invocation for submitReviewWithComments.
+func reviewAPIArgs(repo domain.RepoRef, number int, review domain.Review) ([]string, error) {
+	var event string
+	switch review.Action {
+	case domain.ReviewActionApprove:
+		event = "APPROVE"
+	case domain.ReviewActionRequestChanges:
+		event = "REQUEST_CHANGES"
+	case domain.ReviewActionComment:
+		event = "COMMENT"
+	default:
+		return nil, fmt.Errorf("unknown review action: %q", review.Action)
+	}
+
+	endpoint := fmt.Sprintf("repos/%s/pulls/%d/reviews", repo.FullName(), number)
+	args := []string{"api", endpoint, "--method", "POST",
+		"--raw-field", "event=" + event,
+	}
+	if review.Body != "" {
+		args = append(args, "--raw-field", "body="+review.Body)
+	}
+	for _, c := range review.Comments {
+		args = append(args,
+			"--raw-field", "comments[][path]="+c.Path,
+			"--field", fmt.Sprintf("comments[][line]=%d", c.Line),
+			"--raw-field", "comments[][side]="+c.Side,
+			"--raw-field", "comments[][body]="+c.Body,
+		)
+		if c.IsRange() {
+			args = append(args,
+				"--field", fmt.Sprintf("comments[][start_line]=%d", c.StartLine),
+				"--raw-field", "comments[][start_side]="+c.StartSide,
+			)
+		}
+	}
+	return append(args, hostArgs(repo)...), nil
+}
+
 // AddComment adds an inline review comment via the GitHub REST API.
 func (a *Adapter) AddComment(ctx context.Context, repo domain.RepoRef, number int, input domain.InlineCommentInput) error {
-	endpoint := fmt.Sprintf("repos/%s/pulls/%d/comments", repo, number)
+	endpoint := fmt.Sprintf("repos/%s/pulls/%d/comments", repo.FullName(), number)
 
 	args := []string{"api", endpoint, "--method", "POST",
 		"--raw-field", fmt.Sprintf("body=%s", input.Body),
@@ -44,9 +102,16 @@ func (a *Adapter) AddComment(ctx context.Context, repo domain.RepoRef, number in
 		"--raw-field", fmt.Sprintf("side=%s", input.Side),
 		"--raw-field", fmt.Sprintf("commit_id=%s", input.CommitID),
 	}
+	if input.IsRange() {
+		args = append(args,
+			"--field", fmt.Sprintf("start_line=%d", input.StartLine),
+			"--raw-field", "start_side="+input.StartSide,
+		)
+	}
 	if input.InReplyTo != "" {
 		args = append(args, "--raw-field", fmt.Sprintf("in_reply_to=%s", input.InReplyTo))
 	}
+	args = append(args, hostArgs(repo)...)
 
 	if _, err := ghExec(ctx, args...); err != nil {
 		return fmt.Errorf("adding comment to PR #%d: %w", number, err)
@@ -55,12 +120,13 @@ func (a *Adapter) AddComment(ctx context.Context, repo domain.RepoRef, number in
 }
 
 // ResolveThread resolves a review comment thread via the GraphQL API.
-func (a *Adapter) ResolveThread(ctx context.Context, _ domain.RepoRef, threadID string) error {
+func (a *Adapter) ResolveThread(ctx context.Context, repo domain.RepoRef, threadID string) error {
 	query := `mutation($id: ID!) { resolveReviewThread(input: {threadId: $id}) { thread { isResolved } } }`
 	args := []string{"api", "graphql",
 		"-f", fmt.Sprintf("query=%s", query),
 		"-f", fmt.Sprintf("id=%s", threadID),
 	}
+	args = append(args, hostArgs(repo)...)
 
 	if _, err := ghExec(ctx, args...); err != nil {
 		return fmt.Errorf("resolving thread %s: %w", threadID, err)
@@ -0,0 +1,58 @@
+package ghcli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+func TestReviewAPIArgs(t *testing.T) {
+	args, err := reviewAPIArgs(domain.RepoRef{Owner: "o", Name: "r"}, 7, domain.Review{
+		Action: domain.ReviewActionRequestChanges,
+		Body:   "see inline",
+		Comments: []domain.InlineCommentInput{
+			{Path: "a.go", Line: 3, Side: "RIGHT", Body: "nit"},
+			{Path: "b.go", Line: 9, Side: "LEFT", Body: "why?", StartLine: 7, StartSide: "LEFT"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api", "repos/o/r/pulls/7/reviews", "--method", "POST",
+		"--raw-field", "event=REQUEST_CHANGES",
+		"--raw-field", "body=see inline",
+		"--raw-field", "comments[][path]=a.go",
+		"--field", "comments[][line]=3",
+		"--raw-field", "comments[][side]=RIGHT",
+		"--raw-field", "comments[][body]=nit",
+		"--raw-field", "comments[][path]=b.go",
+		"--field", "comments[][line]=9",
+		"--raw-field", "comments[][side]=LEFT",
+		"--raw-field", "comments[][body]=why?",
+		"--field", "comments[][start_line]=7",
+		"--raw-field", "comments[][start_side]=LEFT",
+	}, args)
+	assert.False(t, isReadOnlyCall(args))
+
+	_, err = reviewAPIArgs(domain.RepoRef{Owner: "o", Name: "r"}, 7, domain.Review{Action: "bogus"})
+	assert.Error(t, err)
+}
+
+func TestMutationArgs(t *testing.T) {
+	args := mutationArgs(domain.RepoRef{Owner: "o", Name: "r", Host: "ghe.example.com"},
+		editMutations[domain.DiscussionInlineThread], "id=PRRC_1", "body=@fixed")
+	assert.Equal(t, []string{"api", "graphql",
+		"-f", "query=" + editMutations[domain.DiscussionInlineThread],
+		"-f", "id=PRRC_1",
+		"-f", "body=@fixed",
+		"--hostname", "ghe.example.com",
+	}, args)
+	assert.Contains(t, args[3], "updatePullRequestReviewComment")
+	assert.False(t, isReadOnlyCall(args))
+
+	for _, kind := range []domain.DiscussionKind{domain.DiscussionComment, domain.DiscussionReview, domain.DiscussionInlineThread} {
+		assert.Contains(t, editMutations, kind)
+		assert.Contains(t, deleteMutations, kind)
+	}
+}
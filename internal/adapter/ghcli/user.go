@@ -8,9 +8,14 @@ import (
 	"github.com/indrasvat/vivecaka/internal/domain"
 )
 
-// DetectUser fetches the current GitHub username via gh CLI.
-func DetectUser(ctx context.Context) (string, error) {
-	cmd := exec.CommandContext(ctx, "gh", "api", "user", "--jq", ".login")
+// DetectUser fetches the current GitHub username on host via gh CLI.
+// An empty host uses gh's default host.
+func DetectUser(ctx context.Context, host string) (string, error) {
+	args := []string{"api", "user", "--jq", ".login"}
+	if host != "" && host != domain.DefaultHost {
+		args = append(args, "--hostname", host)
+	}
+	cmd := exec.CommandContext(ctx, "gh", args...)
 	out, err := cmd.Output()
 	if err != nil {
 		return "", err
@@ -29,23 +29,29 @@ func (a *Adapter) Checkout(ctx context.Context, repo domain.RepoRef, number int)
 	return strings.TrimSpace(string(out)), nil
 }
 
-// Merge merges a PR via gh pr merge (post-MVP, not exposed in UI).
+// Merge merges a PR via gh pr merge, or enables auto-merge with opts.Auto.
 func (a *Adapter) Merge(ctx context.Context, repo domain.RepoRef, number int, opts domain.MergeOpts) error {
 	args := []string{"pr", "merge", fmt.Sprintf("%d", number)}
 	args = append(args, repoArgs(repo)...)
 
 	switch opts.Method {
-	case "squash":
+	case domain.MergeMethodSquash:
 		args = append(args, "--squash")
-	case "rebase":
+	case domain.MergeMethodRebase:
 		args = append(args, "--rebase")
 	default:
 		args = append(args, "--merge")
 	}
 
+	if opts.Auto {
+		args = append(args, "--auto")
+	}
 	if opts.DeleteBranch {
 		args = append(args, "--delete-branch")
 	}
+	if opts.CommitTitle != "" {
+		args = append(args, "--subject", opts.CommitTitle)
+	}
 	if opts.CommitMessage != "" {
 		args = append(args, "--body", opts.CommitMessage)
 	}
@@ -55,18 +61,3 @@ func (a *Adapter) Merge(ctx context.Context, repo domain.RepoRef, number int, op
 	}
 	return nil
 }
-
-// UpdateLabels updates labels on a PR via gh pr edit (post-MVP).
-func (a *Adapter) UpdateLabels(ctx context.Context, repo domain.RepoRef, number int, labels []string) error {
-	args := []string{"pr", "edit", fmt.Sprintf("%d", number)}
-	args = append(args, repoArgs(repo)...)
-
-	for _, l := range labels {
-		args = append(args, "--add-label", l)
-	}
-
-	if _, err := ghExec(ctx, args...); err != nil {
-		return fmt.Errorf("updating labels on PR #%d: %w", number, err)
-	}
-	return nil
-}
@@ -0,0 +1,341 @@
+package gitea
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/plugin"
+)
+
+var testRepo = domain.RepoRef{Owner: "owner", Name: "repo"}
+
+const apiRepo = "/api/v1/repos/owner/repo"
+
+// fakeForge is a minimal in-process Gitea API keyed by "METHOD path".
+type fakeForge struct {
+	t        *testing.T
+	routes   map[string]http.HandlerFunc
+	mu       sync.Mutex
+	requests []recordedRequest
+}
+
+type recordedRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Body   map[string]any
+}
+
+func newFake(t *testing.T) (*fakeForge, *Adapter) {
+	t.Helper()
+	f := &fakeForge{t: t, routes: map[string]http.HandlerFunc{}}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	return f, New(WithBaseURL(srv.URL), WithToken("secret"))
+}
+
+func (f *fakeForge) handle(method, path string, h http.HandlerFunc) {
+	f.routes[method+" "+path] = h
+}
+
+func (f *fakeForge) json(method, path string, v any) {
+	f.handle(method, path, func(w http.ResponseWriter, _ *http.Request) { writeJSON(w, v) })
+}
+
+func (f *fakeForge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	assert.Equal(f.t, "token secret", r.Header.Get("Authorization"))
+	rec := recordedRequest{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery}
+	raw, err := io.ReadAll(r.Body)
+	require.NoError(f.t, err)
+	if len(raw) > 0 {
+		require.NoError(f.t, json.Unmarshal(raw, &rec.Body))
+	}
+	f.mu.Lock()
+	f.requests = append(f.requests, rec)
+	f.mu.Unlock()
+
+	h, ok := f.routes[r.Method+" "+r.URL.Path]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		writeJSON(w, map[string]string{"message": "The target couldn't be found."})
+		return
+	}
+	h(w, r)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func pullJSON(number int, title, author string, merged bool, labels ...string) map[string]any {
+	state := "open"
+	if merged {
+		state = "closed"
+	}
+	ls := make([]map[string]any, len(labels))
+	for i, l := range labels {
+		ls[i] = map[string]any{"name": l}
+	}
+	return map[string]any{
+		"number":              number,
+		"title":               title,
+		"body":                "body of " + title,
+		"state":               state,
+		"merged":              merged,
+		"html_url":            "https://forge.example.com/owner/repo/pulls/1",
+		"created_at":          "2026-01-02T03:04:05Z",
+		"updated_at":          "2026-01-03T03:04:05Z",
+		"user":                map[string]any{"login": author},
+		"assignees":           []map[string]any{{"login": "bob"}},
+		"requested_reviewers": []map[string]any{{"login": "carol"}, {"login": "dave"}},
+		"labels":              ls,
+		"head":                map[string]any{"ref": "feature", "sha": "headsha"},
+		"base":                map[string]any{"ref": "main", "sha": "basesha"},
+	}
+}
+
+func TestRegistryDiscoversCapabilities(t *testing.T) {
+	reg := plugin.NewRegistry()
+	require.NoError(t, reg.Register(New(WithBaseURL("https://forge.example.com"))))
+
+	assert.Len(t, reg.GetReaders(), 1)
+	assert.Len(t, reg.GetReviewers(), 1)
+	assert.Len(t, reg.GetWriters(), 1)
+	assert.Len(t, reg.GetRepoManagers(), 1)
+}
+
+func TestListPRsFiltersClientSide(t *testing.T) {
+	f, a := newFake(t)
+	f.json(http.MethodGet, apiRepo+"/pulls", []map[string]any{
+		pullJSON(4, "WIP: draft work", "alice", false, "bug"),
+		pullJSON(3, "Fix bug", "alice", false, "bug"),
+		pullJSON(2, "Other", "bob", false, "bug"),
+		pullJSON(1, "Fix docs", "alice", false),
+	})
+
+	prs, err := a.ListPRs(t.Context(), testRepo, domain.ListOpts{
+		Author: "alice",
+		Labels: []string{"bug"},
+		Draft:  domain.DraftExclude,
+	})
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	assert.Equal(t, 3, prs[0].Number)
+	assert.Equal(t, "feature", prs[0].Branch.Head)
+	assert.Contains(t, f.requests[0].Query, "state=open")
+}
+
+func TestListPRsMergedUsesClosedState(t *testing.T) {
+	f, a := newFake(t)
+	f.json(http.MethodGet, apiRepo+"/pulls", []map[string]any{
+		pullJSON(2, "Merged", "alice", true),
+		{"number": 1, "title": "Closed", "state": "closed", "merged": false, "user": map[string]any{"login": "x"}},
+	})
+
+	prs, err := a.ListPRs(t.Context(), testRepo, domain.ListOpts{State: domain.PRStateMerged})
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	assert.Equal(t, domain.PRStateMerged, prs[0].State)
+	assert.Contains(t, f.requests[0].Query, "state=closed")
+}
+
+func TestGetPRCountUsesTotalHeader(t *testing.T) {
+	f, a := newFake(t)
+	f.handle(http.MethodGet, apiRepo+"/pulls", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Total-Count", "17")
+		writeJSON(w, []map[string]any{pullJSON(1, "x", "y", false)})
+	})
+
+	n, err := a.GetPRCount(t.Context(), testRepo, domain.PRStateOpen)
+	require.NoError(t, err)
+	assert.Equal(t, 17, n)
+}
+
+func TestGetPRAssemblesDetail(t *testing.T) {
+	f, a := newFake(t)
+	f.json(http.MethodGet, apiRepo+"/pulls/7", pullJSON(7, "Feature", "alice", false))
+	f.json(http.MethodGet, apiRepo+"/pulls/7/reviews", []map[string]any{
+		{"id": 1, "user": map[string]any{"login": "carol"}, "state": "REQUEST_CHANGES", "submitted_at": "2026-01-03T00:00:00Z"},
+		{"id": 2, "user": map[string]any{"login": "carol"}, "state": "APPROVED", "submitted_at": "2026-01-04T00:00:00Z"},
+		{"id": 3, "user": map[string]any{"login": "erin"}, "state": "APPROVED", "stale": true},
+	})
+	f.json(http.MethodGet, apiRepo+"/pulls/7/files", []map[string]any{
+		{"filename": "a.go", "status": "changed", "additions": 3, "deletions": 1},
+		{"filename": "b.go", "status": "deleted", "deletions": 9},
+	})
+	f.json(http.MethodGet, apiRepo+"/commits/headsha/status", map[string]any{
+		"state": "pending",
+		"statuses": []map[string]any{
+			{"context": "ci/build", "status": "pending", "created_at": "2026-01-03T00:00:00Z", "updated_at": "2026-01-03T00:00:00Z"},
+			{"context": "ci/build", "status": "success", "created_at": "2026-01-03T00:00:00Z", "updated_at": "2026-01-03T00:02:00Z"},
+			{"context": "ci/lint", "status": "failure", "target_url": "https://ci/lint"},
+		},
+	})
+
+	pr, err := a.GetPR(t.Context(), testRepo, 7)
+	require.NoError(t, err)
+
+	assert.Equal(t, "body of Feature", pr.Body)
+	assert.Equal(t, []domain.ReviewerInfo{
+		{Login: "dave", State: domain.ReviewPending},
+		{Login: "carol", State: domain.ReviewApproved},
+	}, pr.Reviewers)
+	assert.Equal(t, domain.ReviewPending, pr.Review.State)
+
+	require.Len(t, pr.Files, 2)
+	assert.Equal(t, "modified", pr.Files[0].Status)
+	assert.Equal(t, "removed", pr.Files[1].Status)
+
+	require.Len(t, pr.Checks, 2)
+	assert.Equal(t, "ci/build", pr.Checks[0].Name)
+	assert.Equal(t, domain.CIPass, pr.Checks[0].Status)
+	assert.Equal(t, "2m0s", pr.Checks[0].Duration.String())
+	assert.Equal(t, domain.CIFail, pr.CI)
+}
+
+func TestGetDiffUsesDiffEndpoint(t *testing.T) {
+	f, a := newFake(t)
+	f.handle(http.MethodGet, apiRepo+"/pulls/7.diff", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, "diff --git a/x.go b/x.go\n--- a/x.go\n+++ b/x.go\n@@ -1 +1 @@\n-a\n+b\n")
+	})
+
+	diff, err := a.GetDiff(t.Context(), testRepo, 7)
+	require.NoError(t, err)
+	require.Len(t, diff.Files, 1)
+	assert.Equal(t, "x.go", diff.Files[0].Path)
+}
+
+func TestGetCommentsGroupsByLine(t *testing.T) {
+	f, a := newFake(t)
+	f.json(http.MethodGet, apiRepo+"/pulls/7/reviews", []map[string]any{
+		{"id": 1, "state": "COMMENT", "comments_count": 2},
+		{"id": 2, "state": "COMMENT", "comments_count": 1},
+		{"id": 3, "state": "APPROVED", "comments_count": 0},
+	})
+	f.json(http.MethodGet, apiRepo+"/pulls/7/reviews/1/comments", []map[string]any{
+		{"id": 10, "body": "root", "path": "a.go", "position": 5, "created_at": "2026-01-03T00:00:00Z", "user": map[string]any{"login": "carol"}},
+		{"id": 11, "body": "old side", "path": "a.go", "original_position": 5, "created_at": "2026-01-03T00:01:00Z", "user": map[string]any{"login": "carol"}},
+	})
+	f.json(http.MethodGet, apiRepo+"/pulls/7/reviews/2/comments", []map[string]any{
+		{"id": 20, "body": "reply", "path": "a.go", "position": 5, "created_at": "2026-01-03T00:02:00Z",
+			"user": map[string]any{"login": "alice"}, "resolver": map[string]any{"login": "alice"}},
+	})
+
+	threads, err := a.GetComments(t.Context(), testRepo, 7)
+	require.NoError(t, err)
+	require.Len(t, threads, 2)
+
+	assert.Equal(t, "10", threads[0].ID)
+	assert.Equal(t, "10", threads[0].ReplyToID)
+	assert.Empty(t, threads[0].ThreadID)
+	assert.Equal(t, 5, threads[0].Line)
+	require.Len(t, threads[0].Comments, 2)
+	assert.Equal(t, "reply", threads[0].Comments[1].Body)
+
+	assert.Equal(t, "11", threads[1].ID)
+	for _, r := range f.requests {
+		assert.NotEqual(t, apiRepo+"/pulls/7/reviews/3/comments", r.Path)
+	}
+}
+
+func TestGetDiscussion(t *testing.T) {
+	f, a := newFake(t)
+	f.json(http.MethodGet, apiRepo+"/issues/7/comments", []map[string]any{
+		{"id": 1, "body": "hello", "user": map[string]any{"login": "bob"}},
+		{"id": 2, "body": "  "},
+	})
+	f.json(http.MethodGet, apiRepo+"/pulls/7/reviews", []map[string]any{
+		{"id": 5, "body": "Needs work", "state": "REQUEST_CHANGES", "user": map[string]any{"login": "carol"}},
+	})
+
+	items, err := a.GetDiscussion(t.Context(), testRepo, 7)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, domain.DiscussionComment, items[0].Kind)
+	assert.Equal(t, domain.DiscussionReview, items[1].Kind)
+	assert.Equal(t, domain.ReviewChangesRequested, items[1].ReviewState)
+}
+
+func TestSubmitReviewAndAddComment(t *testing.T) {
+	f, a := newFake(t)
+	f.json(http.MethodPost, apiRepo+"/pulls/7/reviews", map[string]any{})
+
+	require.NoError(t, a.SubmitReview(t.Context(), testRepo, 7, domain.Review{Action: domain.ReviewActionRequestChanges, Body: "fix"}))
+	assert.Equal(t, "REQUEST_CHANGES", f.requests[0].Body["event"])
+
+	require.NoError(t, a.AddComment(t.Context(), testRepo, 7, domain.InlineCommentInput{
+		Path: "a.go", Line: 3, Side: "RIGHT", Body: "nit", CommitID: "headsha", InReplyTo: "10",
+	}))
+	body := f.requests[1].Body
+	assert.Equal(t, "COMMENT", body["event"])
+	comments, ok := body["comments"].([]any)
+	require.True(t, ok)
+	require.Len(t, comments, 1)
+	c, ok := comments[0].(map[string]any)
+	require.True(t, ok)
+	assert.InDelta(t, 3, c["new_position"], 0)
+
+	assert.Error(t, a.SubmitReview(t.Context(), testRepo, 7, domain.Review{Action: "bogus"}))
+	assert.Error(t, a.ResolveThread(t.Context(), testRepo, "x"))
+}
+
+func TestRangeCommentsAreUnsupported(t *testing.T) {
+	f, a := newFake(t)
+	f.json(http.MethodPost, apiRepo+"/pulls/7/reviews", map[string]any{})
+	ranged := domain.InlineCommentInput{Path: "a.go", StartLine: 2, StartSide: "RIGHT", Line: 4, Side: "RIGHT", Body: "span"}
+
+	require.ErrorIs(t, a.AddComment(t.Context(), testRepo, 7, ranged), errors.ErrUnsupported)
+	err := a.SubmitReview(t.Context(), testRepo, 7, domain.Review{Action: domain.ReviewActionComment, Comments: []domain.InlineCommentInput{ranged}})
+	require.ErrorIs(t, err, errors.ErrUnsupported)
+	assert.Empty(t, f.requests, "nothing is posted")
+}
+
+func TestSubmitReviewSendsComments(t *testing.T) {
+	f, a := newFake(t)
+	f.json(http.MethodPost, apiRepo+"/pulls/7/reviews", map[string]any{})
+
+	require.NoError(t, a.SubmitReview(t.Context(), testRepo, 7, domain.Review{
+		Action:   domain.ReviewActionApprove,
+		Comments: []domain.InlineCommentInput{{Path: "a.go", Line: 4, Side: "LEFT", Body: "gone?"}},
+	}))
+	require.Len(t, f.requests, 1)
+	comments, ok := f.requests[0].Body["comments"].([]any)
+	require.True(t, ok)
+	require.Len(t, comments, 1)
+	c, ok := comments[0].(map[string]any)
+	require.True(t, ok)
+	assert.InDelta(t, 4, c["old_position"], 0)
+	assert.Equal(t, "gone?", c["body"])
+}
+
+func TestMerge(t *testing.T) {
+	f, a := newFake(t)
+	f.json(http.MethodPost, apiRepo+"/pulls/7/merge", map[string]any{})
+
+	require.NoError(t, a.Merge(t.Context(), testRepo, 7, domain.MergeOpts{Method: "squash", DeleteBranch: true}))
+	assert.Equal(t, "squash", f.requests[0].Body["Do"])
+	assert.Equal(t, true, f.requests[0].Body["delete_branch_after_merge"])
+	assert.NotContains(t, f.requests[0].Body, "merge_when_checks_succeed")
+
+	require.NoError(t, a.Merge(t.Context(), testRepo, 7, domain.MergeOpts{Auto: true, CommitTitle: "Add x"}))
+	assert.Equal(t, "merge", f.requests[1].Body["Do"])
+	assert.Equal(t, true, f.requests[1].Body["merge_when_checks_succeed"])
+	assert.Equal(t, "Add x", f.requests[1].Body["MergeTitleField"])
+}
+
+func TestErrorMapping(t *testing.T) {
+	_, a := newFake(t)
+	_, err := a.GetPR(t.Context(), testRepo, 404)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Contains(t, err.Error(), "couldn't be found")
+}
@@ -0,0 +1,170 @@
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// apiError is a non-2xx API response.
+type apiError struct {
+	StatusCode int
+	Message    string
+	err        error
+}
+
+func (e *apiError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("gitea api: HTTP %d", e.StatusCode)
+	}
+	return fmt.Sprintf("gitea api: HTTP %d: %s", e.StatusCode, e.Message)
+}
+
+func (e *apiError) Unwrap() error { return e.err }
+
+// do performs an authenticated request against /api/v1 and returns the body
+// and the X-Total-Count header (0 when absent).
+func (a *Adapter) do(ctx context.Context, method, path string, query url.Values, body any) ([]byte, int, error) {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, 0, fmt.Errorf("encoding request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	u := a.baseURL + "/api/v1/" + strings.TrimPrefix(path, "/")
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return nil, 0, fmt.Errorf("building request: %w", err)
+	}
+	req.Header.Set("Authorization", "token "+a.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("gitea api: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	out, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("reading response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, 0, newAPIError(resp, out)
+	}
+	total, _ := strconv.Atoi(resp.Header.Get("X-Total-Count"))
+	return out, total, nil
+}
+
+// newAPIError maps an HTTP error response to a domain-aware error.
+func newAPIError(resp *http.Response, body []byte) error {
+	status := resp.StatusCode
+	var payload struct {
+		Message string `json:"message"`
+	}
+	_ = json.Unmarshal(body, &payload)
+
+	e := &apiError{StatusCode: status, Message: payload.Message}
+	switch status {
+	case http.StatusUnauthorized:
+		e.err = domain.ErrNotAuthenticated
+	case http.StatusForbidden:
+		e.err = domain.ErrUnauthorized
+	case http.StatusNotFound:
+		e.err = domain.ErrNotFound
+	case http.StatusTooManyRequests:
+		e.err = newRateLimitError(resp, e.Message)
+	}
+	return e
+}
+
+// newRateLimitError reads the Retry-After header, if any.
+func newRateLimitError(resp *http.Response, message string) *domain.RateLimitError {
+	e := &domain.RateLimitError{Message: message}
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		e.RetryAfter = time.Duration(secs) * time.Second
+	}
+	return e
+}
+
+// getJSON performs a GET and decodes the JSON response into dst.
+// It returns the X-Total-Count header value.
+func (a *Adapter) getJSON(ctx context.Context, path string, query url.Values, dst any) (int, error) {
+	out, total, err := a.do(ctx, http.MethodGet, path, query, nil)
+	if err != nil {
+		return 0, err
+	}
+	if err := json.Unmarshal(out, dst); err != nil {
+		return 0, fmt.Errorf("parsing api response: %w", err)
+	}
+	return total, nil
+}
+
+// sendJSON performs a write request with a JSON body and decodes the response
+// into dst (which may be nil).
+func (a *Adapter) sendJSON(ctx context.Context, method, path string, body, dst any) error {
+	out, _, err := a.do(ctx, method, path, nil, body)
+	if err != nil {
+		return err
+	}
+	if dst == nil || len(out) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(out, dst); err != nil {
+		return fmt.Errorf("parsing api response: %w", err)
+	}
+	return nil
+}
+
+// pageLimit is the page size used when walking list endpoints. Gitea caps
+// it server-side (MAX_RESPONSE_ITEMS, 50 by default).
+const pageLimit = 50
+
+// getAll walks page/limit pagination. It stops at X-Total-Count when the
+// server sends it (the server may clamp limit), else at the first short page.
+func getAll[T any](ctx context.Context, a *Adapter, path string, query url.Values) ([]T, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("limit", strconv.Itoa(pageLimit))
+
+	var all []T
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+		var items []T
+		total, err := a.getJSON(ctx, path, query, &items)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		switch {
+		case len(items) == 0:
+			return all, nil
+		case total > 0:
+			if len(all) >= total {
+				return all, nil
+			}
+		case len(items) < pageLimit:
+			return all, nil
+		}
+	}
+}
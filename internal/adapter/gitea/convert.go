@@ -0,0 +1,436 @@
+package gitea
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+type gtUser struct {
+	Login string `json:"login"`
+}
+
+type gtBranch struct {
+	Ref  string `json:"ref"`
+	SHA  string `json:"sha"`
+	Repo *struct {
+		FullName string `json:"full_name"`
+	} `json:"repo"`
+}
+
+type gtPull struct {
+	Number             int       `json:"number"`
+	Title              string    `json:"title"`
+	Body               string    `json:"body"`
+	State              string    `json:"state"`
+	Draft              bool      `json:"draft"`
+	Merged             bool      `json:"merged"`
+	HTMLURL            string    `json:"html_url"`
+	Created            time.Time `json:"created_at"`
+	Updated            time.Time `json:"updated_at"`
+	User               gtUser    `json:"user"`
+	Assignees          []gtUser  `json:"assignees"`
+	RequestedReviewers []gtUser  `json:"requested_reviewers"`
+	Labels             []gtLabel `json:"labels"`
+	Head               gtBranch  `json:"head"`
+	Base               gtBranch  `json:"base"`
+}
+
+type gtLabel struct {
+	Name string `json:"name"`
+}
+
+type gtChangedFile struct {
+	Filename  string `json:"filename"`
+	Status    string `json:"status"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+}
+
+type gtStatus struct {
+	Context   string    `json:"context"`
+	State     string    `json:"status"`
+	TargetURL string    `json:"target_url"`
+	Created   time.Time `json:"created_at"`
+	Updated   time.Time `json:"updated_at"`
+}
+
+type gtCombinedStatus struct {
+	State    string     `json:"state"`
+	Statuses []gtStatus `json:"statuses"`
+}
+
+type gtReview struct {
+	ID        int64     `json:"id"`
+	User      gtUser    `json:"user"`
+	State     string    `json:"state"`
+	Body      string    `json:"body"`
+	Submitted time.Time `json:"submitted_at"`
+	HTMLURL   string    `json:"html_url"`
+	Comments  int       `json:"comments_count"`
+	Stale     bool      `json:"stale"`
+	Dismissed bool      `json:"dismissed"`
+}
+
+// gtReviewComment is an inline review comment. Position is the new-file line
+// and OriginalPosition the old-file line; exactly one is non-zero.
+type gtReviewComment struct {
+	ID               int64     `json:"id"`
+	Body             string    `json:"body"`
+	User             gtUser    `json:"user"`
+	Resolver         *gtUser   `json:"resolver"`
+	Path             string    `json:"path"`
+	Position         int       `json:"position"`
+	OriginalPosition int       `json:"original_position"`
+	Created          time.Time `json:"created_at"`
+	HTMLURL          string    `json:"html_url"`
+}
+
+type gtIssueComment struct {
+	ID      int64     `json:"id"`
+	Body    string    `json:"body"`
+	User    gtUser    `json:"user"`
+	Created time.Time `json:"created_at"`
+	HTMLURL string    `json:"html_url"`
+}
+
+// isDraft reports whether a pull request is a draft. Older Gitea releases
+// have no draft field and mark work in progress with a title prefix instead.
+func isDraft(p gtPull) bool {
+	if p.Draft {
+		return true
+	}
+	title := strings.ToUpper(strings.TrimSpace(p.Title))
+	return strings.HasPrefix(title, "WIP:") || strings.HasPrefix(title, "[WIP]")
+}
+
+// toDomainPR converts a Gitea pull request to a domain.PR. CI is filled in
+// separately from commit statuses.
+func toDomainPR(p gtPull) domain.PR {
+	labels := make([]string, len(p.Labels))
+	for i, l := range p.Labels {
+		labels[i] = l.Name
+	}
+	return domain.PR{
+		Number: p.Number,
+		Title:  p.Title,
+		Author: p.User.Login,
+		State:  mapState(p),
+		Draft:  isDraft(p),
+		Branch: domain.BranchInfo{
+			Head:    p.Head.Ref,
+			Base:    p.Base.Ref,
+			HeadSHA: p.Head.SHA,
+			BaseSHA: p.Base.SHA,
+		},
+		Labels:         labels,
+		CI:             domain.CINone,
+		Review:         domain.ReviewStatus{State: domain.ReviewNone},
+		UpdatedAt:      p.Updated,
+		CreatedAt:      p.Created,
+		URL:            p.HTMLURL,
+		LastActivityAt: p.Updated,
+	}
+}
+
+// toDomainPRDetail assembles a detail from the pull, its reviews, files and statuses.
+func toDomainPRDetail(p gtPull, reviews []gtReview, files []gtChangedFile, statuses []gtStatus) domain.PRDetail {
+	detail := domain.PRDetail{
+		PR:   toDomainPR(p),
+		Body: p.Body,
+	}
+
+	detail.Assignees = make([]string, len(p.Assignees))
+	for i, u := range p.Assignees {
+		detail.Assignees[i] = u.Login
+	}
+
+	detail.Reviewers = reviewersFrom(p.RequestedReviewers, reviews)
+	detail.Review = aggregateReview(detail.Reviewers)
+
+	detail.Files = make([]domain.FileChange, len(files))
+	for i, f := range files {
+		detail.Files[i] = domain.FileChange{
+			Path:      f.Filename,
+			Additions: f.Additions,
+			Deletions: f.Deletions,
+			Status:    mapFileStatus(f.Status),
+		}
+	}
+
+	detail.Checks = toDomainChecks(statuses)
+	detail.CI = aggregateCI(detail.Checks)
+	return detail
+}
+
+// reviewersFrom combines pending review requests with each reviewer's latest
+// verdict. Comment-only, stale and dismissed reviews do not count as verdicts.
+func reviewersFrom(requested []gtUser, reviews []gtReview) []domain.ReviewerInfo {
+	latest := make(map[string]domain.ReviewState)
+	var order []string
+	for _, r := range reviews {
+		if r.Dismissed || r.Stale {
+			continue
+		}
+		state := mapReviewState(r.State)
+		if state != domain.ReviewApproved && state != domain.ReviewChangesRequested {
+			continue
+		}
+		if _, seen := latest[r.User.Login]; !seen {
+			order = append(order, r.User.Login)
+		}
+		latest[r.User.Login] = state
+	}
+
+	out := make([]domain.ReviewerInfo, 0, len(requested)+len(order))
+	for _, u := range requested {
+		if _, reviewed := latest[u.Login]; !reviewed {
+			out = append(out, domain.ReviewerInfo{Login: u.Login, State: domain.ReviewPending})
+		}
+	}
+	for _, login := range order {
+		out = append(out, domain.ReviewerInfo{Login: login, State: latest[login]})
+	}
+	return out
+}
+
+func aggregateReview(reviewers []domain.ReviewerInfo) domain.ReviewStatus {
+	status := domain.ReviewStatus{State: domain.ReviewNone, Total: len(reviewers)}
+	pending := false
+	for _, r := range reviewers {
+		switch r.State {
+		case domain.ReviewChangesRequested:
+			status.State = domain.ReviewChangesRequested
+		case domain.ReviewApproved:
+			status.Approved++
+		case domain.ReviewPending:
+			pending = true
+		}
+	}
+	switch {
+	case status.State == domain.ReviewChangesRequested:
+	case pending:
+		status.State = domain.ReviewPending
+	case status.Approved > 0:
+		status.State = domain.ReviewApproved
+	}
+	return status
+}
+
+// toDomainChecks keeps the most recent status per context; Gitea returns
+// every status ever posted for the commit.
+func toDomainChecks(statuses []gtStatus) []domain.Check {
+	latest := make(map[string]gtStatus, len(statuses))
+	for _, s := range statuses {
+		if cur, ok := latest[s.Context]; !ok || s.Updated.After(cur.Updated) {
+			latest[s.Context] = s
+		}
+	}
+
+	checks := make([]domain.Check, 0, len(latest))
+	for _, s := range latest {
+		var duration time.Duration
+		if !s.Created.IsZero() && s.Updated.After(s.Created) {
+			duration = s.Updated.Sub(s.Created)
+		}
+		checks = append(checks, domain.Check{
+			Name:     s.Context,
+			Status:   mapStatusState(s.State),
+			Duration: duration,
+			URL:      s.TargetURL,
+		})
+	}
+	sort.Slice(checks, func(i, j int) bool { return checks[i].Name < checks[j].Name })
+	return checks
+}
+
+func aggregateCI(checks []domain.Check) domain.CIStatus {
+	if len(checks) == 0 {
+		return domain.CINone
+	}
+	hasPending := false
+	for _, c := range checks {
+		switch c.Status {
+		case domain.CIFail:
+			return domain.CIFail
+		case domain.CIPending:
+			hasPending = true
+		}
+	}
+	if hasPending {
+		return domain.CIPending
+	}
+	return domain.CIPass
+}
+
+// threadKey groups review comments into conversations the way Gitea's UI
+// does: by file and diff line on one side.
+type threadKey struct {
+	path string
+	line int
+	old  bool
+}
+
+func commentKey(c gtReviewComment) threadKey {
+	if c.Position > 0 {
+		return threadKey{path: c.Path, line: c.Position}
+	}
+	return threadKey{path: c.Path, line: c.OriginalPosition, old: true}
+}
+
+// toDomainThreads groups inline review comments into threads. Gitea has no
+// thread IDs, so the root comment ID serves as ID and reply target; replies
+// are posted at the same path and line. ThreadID stays empty because the
+// API cannot resolve conversations.
+func toDomainThreads(comments []gtReviewComment) []domain.CommentThread {
+	sort.SliceStable(comments, func(i, j int) bool { return comments[i].Created.Before(comments[j].Created) })
+
+	index := make(map[threadKey]int)
+	var threads []domain.CommentThread
+	for _, c := range comments {
+		key := commentKey(c)
+		i, ok := index[key]
+		if !ok {
+			id := strconv.FormatInt(c.ID, 10)
+			threads = append(threads, domain.CommentThread{
+				ID:        id,
+				ReplyToID: id,
+				Path:      c.Path,
+				Line:      key.line,
+				Resolved:  c.Resolver != nil,
+			})
+			i = len(threads) - 1
+			index[key] = i
+		}
+		threads[i].Comments = append(threads[i].Comments, domain.Comment{
+			ID:        strconv.FormatInt(c.ID, 10),
+			Author:    c.User.Login,
+			Body:      c.Body,
+			CreatedAt: c.Created,
+			URL:       c.HTMLURL,
+		})
+	}
+	return threads
+}
+
+func toDomainReviewItems(reviews []gtReview) []domain.DiscussionItem {
+	items := make([]domain.DiscussionItem, 0, len(reviews))
+	for _, r := range reviews {
+		if strings.TrimSpace(r.Body) == "" {
+			continue
+		}
+		id := strconv.FormatInt(r.ID, 10)
+		items = append(items, domain.DiscussionItem{
+			ID:          id,
+			Kind:        domain.DiscussionReview,
+			ReviewState: mapReviewState(r.State),
+			StateLabel:  strings.ToLower(strings.ReplaceAll(r.State, "_", " ")),
+			CreatedAt:   r.Submitted,
+			URL:         r.HTMLURL,
+			Comments: []domain.Comment{{
+				ID:        id,
+				Author:    r.User.Login,
+				Body:      r.Body,
+				CreatedAt: r.Submitted,
+				URL:       r.HTMLURL,
+			}},
+		})
+	}
+	return items
+}
+
+func toDomainIssueComments(comments []gtIssueComment) []domain.DiscussionItem {
+	items := make([]domain.DiscussionItem, 0, len(comments))
+	for _, c := range comments {
+		if strings.TrimSpace(c.Body) == "" {
+			continue
+		}
+		id := strconv.FormatInt(c.ID, 10)
+		items = append(items, domain.DiscussionItem{
+			ID:        id,
+			Kind:      domain.DiscussionComment,
+			CreatedAt: c.Created,
+			URL:       c.HTMLURL,
+			Comments: []domain.Comment{{
+				ID:        id,
+				Author:    c.User.Login,
+				Body:      c.Body,
+				CreatedAt: c.Created,
+				URL:       c.HTMLURL,
+			}},
+		})
+	}
+	return items
+}
+
+func mapState(p gtPull) domain.PRState {
+	switch {
+	case p.Merged:
+		return domain.PRStateMerged
+	case p.State == "closed":
+		return domain.PRStateClosed
+	default:
+		return domain.PRStateOpen
+	}
+}
+
+func mapFileStatus(status string) string {
+	switch status {
+	case "added":
+		return "added"
+	case "deleted", "removed":
+		return "removed"
+	case "renamed":
+		return "renamed"
+	default:
+		return "modified"
+	}
+}
+
+// mapStatusState maps a commit status state. "warning" is treated as a
+// non-blocking pass, matching Gitea's combined status.
+func mapStatusState(state string) domain.CIStatus {
+	switch state {
+	case "success", "warning":
+		return domain.CIPass
+	case "failure", "error":
+		return domain.CIFail
+	case "pending":
+		return domain.CIPending
+	case "skipped":
+		return domain.CISkipped
+	default:
+		return domain.CINone
+	}
+}
+
+func mapReviewState(state string) domain.ReviewState {
+	switch state {
+	case "APPROVED":
+		return domain.ReviewApproved
+	case "REQUEST_CHANGES":
+		return domain.ReviewChangesRequested
+	case "PENDING", "COMMENT", "REQUEST_REVIEW":
+		return domain.ReviewPending
+	default:
+		return domain.ReviewNone
+	}
+}
+
+// reviewEvent maps a domain review action to a Gitea review event.
+func reviewEvent(action domain.ReviewAction) (string, error) {
+	switch action {
+	case domain.ReviewActionApprove:
+		return "APPROVED", nil
+	case domain.ReviewActionRequestChanges:
+		return "REQUEST_CHANGES", nil
+	case domain.ReviewActionComment:
+		return "COMMENT", nil
+	default:
+		return "", fmt.Errorf("unknown review action: %q", action)
+	}
+}
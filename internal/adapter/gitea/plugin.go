@@ -0,0 +1,141 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/plugin"
+)
+
+// checkTimeout bounds the startup auth probe so a dead network fails fast.
+const checkTimeout = 10 * time.Second
+
+// Compile-time checks that Adapter satisfies every domain capability.
+var (
+	_ plugin.Plugin        = (*Adapter)(nil)
+	_ domain.PRReader      = (*Adapter)(nil)
+	_ domain.PRReviewer    = (*Adapter)(nil)
+	_ domain.PRWriter      = (*Adapter)(nil)
+	_ domain.RepoManager   = (*Adapter)(nil)
+	_ domain.HostSupporter = (*Adapter)(nil)
+)
+
+// Adapter talks to the Gitea REST API (/api/v1), which Forgejo also serves.
+// The API mirrors GitHub's closely, but filters, review threads and CI
+// statuses differ enough to need their own mapping.
+type Adapter struct {
+	baseURL    string // instance root, e.g. https://codeberg.org
+	token      string
+	httpClient *http.Client
+}
+
+// Option configures an Adapter.
+type Option func(*Adapter)
+
+// WithBaseURL points the adapter at an instance root (without /api/v1).
+func WithBaseURL(u string) Option {
+	return func(a *Adapter) { a.baseURL = strings.TrimSuffix(u, "/") }
+}
+
+// WithToken sets the API token explicitly, skipping environment resolution.
+func WithToken(token string) Option {
+	return func(a *Adapter) { a.token = token }
+}
+
+// WithHTTPClient overrides the HTTP client used for all requests.
+func WithHTTPClient(c *http.Client) Option {
+	return func(a *Adapter) { a.httpClient = c }
+}
+
+// New creates a new Gitea/Forgejo adapter. Without WithToken, the token is
+// read from GITEA_TOKEN, falling back to FORGEJO_TOKEN.
+func New(opts ...Option) *Adapter {
+	a := &Adapter{httpClient: &http.Client{Timeout: 60 * time.Second}}
+	for _, opt := range opts {
+		opt(a)
+	}
+	if a.token == "" {
+		for _, name := range []string{"GITEA_TOKEN", "FORGEJO_TOKEN"} {
+			if v := strings.TrimSpace(os.Getenv(name)); v != "" {
+				a.token = v
+				break
+			}
+		}
+	}
+	return a
+}
+
+// Info returns plugin metadata.
+func (a *Adapter) Info() plugin.PluginInfo {
+	return plugin.PluginInfo{
+		Name:        "gitea",
+		Version:     "1.0.0",
+		Description: "Gitea/Forgejo pull request adapter using the REST API",
+		Provides:    []string{"pr-reader", "pr-reviewer", "pr-writer"},
+	}
+}
+
+// Check verifies that an instance URL and token are configured and accepted.
+// Call this before starting the TUI to fail fast with a clear message.
+func (a *Adapter) Check() error {
+	if a.baseURL == "" {
+		return fmt.Errorf("gitea: no instance configured (set gitea.url in config.toml)")
+	}
+	if a.token == "" {
+		return fmt.Errorf("%w: set GITEA_TOKEN (or FORGEJO_TOKEN) to an access token", domain.ErrNotAuthenticated)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), checkTimeout)
+	defer cancel()
+
+	if _, err := a.CurrentUser(ctx); err != nil {
+		return fmt.Errorf("gitea api: %w", err)
+	}
+	return nil
+}
+
+// Init satisfies the plugin.Plugin interface.
+func (a *Adapter) Init(_ plugin.AppContext) tea.Cmd {
+	return nil
+}
+
+// SupportsHost reports whether host is the Gitea instance the adapter
+// points at.
+func (a *Adapter) SupportsHost(host string) bool {
+	u, err := url.Parse(a.baseURL)
+	return err == nil && u.Host != "" && strings.EqualFold(u.Host, host)
+}
+
+// CurrentUser returns the login of the authenticated user.
+func (a *Adapter) CurrentUser(ctx context.Context) (string, error) {
+	var user gtUser
+	if _, err := a.getJSON(ctx, "user", nil, &user); err != nil {
+		return "", err
+	}
+	if user.Login == "" {
+		return "", domain.ErrNotFound
+	}
+	return user.Login, nil
+}
+
+// repoPath returns the API path of a repository, with optional suffix segments.
+func repoPath(repo domain.RepoRef, suffix ...string) string {
+	p := "repos/" + repo.FullName()
+	for _, s := range suffix {
+		p += "/" + s
+	}
+	return p
+}
+
+// pullPath returns the API path of a pull request, with optional suffix segments.
+func pullPath(repo domain.RepoRef, number int, suffix ...string) string {
+	return repoPath(repo, append([]string{"pulls", fmt.Sprint(number)}, suffix...)...)
+}
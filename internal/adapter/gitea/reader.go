@@ -0,0 +1,258 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+const defaultPerPage = 50
+
+// apiState maps a domain state filter to the pulls "state" parameter.
+// Gitea has no merged state; merged PRs are closed PRs with merged=true.
+func apiState(state domain.PRState) string {
+	switch state {
+	case "":
+		return "open"
+	case "all":
+		return "all"
+	case domain.PRStateClosed, domain.PRStateMerged:
+		return "closed"
+	default:
+		return "open"
+	}
+}
+
+// matches applies the filters the pulls endpoint cannot express.
+func matches(p gtPull, opts domain.ListOpts) bool {
+	switch opts.State {
+	case domain.PRStateMerged:
+		if !p.Merged {
+			return false
+		}
+	case domain.PRStateClosed:
+		if p.Merged {
+			return false
+		}
+	}
+	switch opts.Draft {
+	case domain.DraftExclude:
+		if isDraft(p) {
+			return false
+		}
+	case domain.DraftOnly:
+		if !isDraft(p) {
+			return false
+		}
+	}
+	if opts.Author != "" && !strings.EqualFold(p.User.Login, opts.Author) {
+		return false
+	}
+	for _, want := range opts.Labels {
+		if !slices.ContainsFunc(p.Labels, func(l gtLabel) bool { return strings.EqualFold(l.Name, want) }) {
+			return false
+		}
+	}
+	if s := strings.ToLower(strings.TrimSpace(opts.Search)); s != "" {
+		if !strings.Contains(strings.ToLower(p.Title), s) && !strings.Contains(strings.ToLower(p.Body), s) {
+			return false
+		}
+	}
+	return true
+}
+
+// ListPRs fetches pull requests newest first. Author, label, draft, merged
+// and search filters are applied client-side while walking API pages so
+// that filtered-out PRs don't shrink the requested page.
+func (a *Adapter) ListPRs(ctx context.Context, repo domain.RepoRef, opts domain.ListOpts) ([]domain.PR, error) {
+	page := max(opts.Page, 1)
+	perPage := opts.PerPage
+	if perPage <= 0 {
+		perPage = defaultPerPage
+	}
+	want := page * perPage
+
+	q := url.Values{}
+	q.Set("state", apiState(opts.State))
+	q.Set("sort", "newest")
+	q.Set("limit", strconv.Itoa(pageLimit))
+
+	var (
+		filtered []gtPull
+		fetched  int
+	)
+	for apiPage := 1; len(filtered) < want; apiPage++ {
+		q.Set("page", strconv.Itoa(apiPage))
+		var pulls []gtPull
+		total, err := a.getJSON(ctx, repoPath(repo, "pulls"), q, &pulls)
+		if err != nil {
+			return nil, fmt.Errorf("listing pull requests: %w", err)
+		}
+		for _, p := range pulls {
+			if matches(p, opts) {
+				filtered = append(filtered, p)
+			}
+		}
+		fetched += len(pulls)
+		if len(pulls) == 0 || (total > 0 && fetched >= total) || (total == 0 && len(pulls) < pageLimit) {
+			break
+		}
+	}
+
+	startIdx := (page - 1) * perPage
+	if startIdx >= len(filtered) {
+		return []domain.PR{}, nil
+	}
+	end := min(startIdx+perPage, len(filtered))
+
+	prs := make([]domain.PR, 0, end-startIdx)
+	for _, p := range filtered[startIdx:end] {
+		prs = append(prs, toDomainPR(p))
+	}
+	return prs, nil
+}
+
+// GetPRCount returns the number of pull requests in the given state.
+// Open, closed-including-merged and all come from X-Total-Count; merged and
+// closed-unmerged require walking the closed list.
+func (a *Adapter) GetPRCount(ctx context.Context, repo domain.RepoRef, state domain.PRState) (int, error) {
+	if state == domain.PRStateMerged || state == domain.PRStateClosed {
+		pulls, err := getAll[gtPull](ctx, a, repoPath(repo, "pulls"), url.Values{"state": {"closed"}})
+		if err != nil {
+			return 0, fmt.Errorf("getting PR count: %w", err)
+		}
+		n := 0
+		for _, p := range pulls {
+			if p.Merged == (state == domain.PRStateMerged) {
+				n++
+			}
+		}
+		return n, nil
+	}
+
+	q := url.Values{"state": {apiState(state)}, "limit": {"1"}}
+	var pulls []gtPull
+	total, err := a.getJSON(ctx, repoPath(repo, "pulls"), q, &pulls)
+	if err != nil {
+		return 0, fmt.Errorf("getting PR count: %w", err)
+	}
+	return total, nil
+}
+
+// GetPR fetches a pull request with reviews, changed files and commit statuses.
+func (a *Adapter) GetPR(ctx context.Context, repo domain.RepoRef, number int) (*domain.PRDetail, error) {
+	var pull gtPull
+	if _, err := a.getJSON(ctx, pullPath(repo, number), nil, &pull); err != nil {
+		return nil, fmt.Errorf("getting PR #%d: %w", number, err)
+	}
+
+	var (
+		reviews  []gtReview
+		files    []gtChangedFile
+		statuses []gtStatus
+	)
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		var err error
+		reviews, err = getAll[gtReview](gctx, a, pullPath(repo, number, "reviews"), nil)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		files, err = getAll[gtChangedFile](gctx, a, pullPath(repo, number, "files"), nil)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		statuses, err = a.commitStatuses(gctx, repo, pull.Head.SHA)
+		return err
+	})
+	if err := g.Wait(); err != nil {
+		return nil, fmt.Errorf("getting PR #%d: %w", number, err)
+	}
+
+	detail := toDomainPRDetail(pull, reviews, files, statuses)
+	return &detail, nil
+}
+
+// commitStatuses fetches the combined commit status for sha.
+func (a *Adapter) commitStatuses(ctx context.Context, repo domain.RepoRef, sha string) ([]gtStatus, error) {
+	if sha == "" {
+		return nil, nil
+	}
+	var combined gtCombinedStatus
+	if _, err := a.getJSON(ctx, repoPath(repo, "commits", sha, "status"), nil, &combined); err != nil {
+		return nil, err
+	}
+	return combined.Statuses, nil
+}
+
+// GetDiff fetches the raw unified diff for a PR and parses it.
+func (a *Adapter) GetDiff(ctx context.Context, repo domain.RepoRef, number int) (*domain.Diff, error) {
+	out, _, err := a.do(ctx, http.MethodGet, repoPath(repo, "pulls", strconv.Itoa(number)+".diff"), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting diff for PR #%d: %w", number, err)
+	}
+	diff := domain.ParseDiff(string(out))
+	return &diff, nil
+}
+
+// GetChecks fetches commit statuses for a PR's head commit.
+func (a *Adapter) GetChecks(ctx context.Context, repo domain.RepoRef, number int) ([]domain.Check, error) {
+	var pull gtPull
+	if _, err := a.getJSON(ctx, pullPath(repo, number), nil, &pull); err != nil {
+		return nil, fmt.Errorf("getting checks for PR #%d: %w", number, err)
+	}
+	statuses, err := a.commitStatuses(ctx, repo, pull.Head.SHA)
+	if err != nil {
+		return nil, fmt.Errorf("getting checks for PR #%d: %w", number, err)
+	}
+	return toDomainChecks(statuses), nil
+}
+
+// GetComments fetches inline review comments from every review and groups
+// them into threads.
+func (a *Adapter) GetComments(ctx context.Context, repo domain.RepoRef, number int) ([]domain.CommentThread, error) {
+	reviews, err := getAll[gtReview](ctx, a, pullPath(repo, number, "reviews"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting comments for PR #%d: %w", number, err)
+	}
+
+	var comments []gtReviewComment
+	for _, r := range reviews {
+		if r.Comments == 0 {
+			continue
+		}
+		var page []gtReviewComment
+		path := pullPath(repo, number, "reviews", strconv.FormatInt(r.ID, 10), "comments")
+		if _, err := a.getJSON(ctx, path, nil, &page); err != nil {
+			return nil, fmt.Errorf("getting comments for PR #%d: %w", number, err)
+		}
+		comments = append(comments, page...)
+	}
+	return toDomainThreads(comments), nil
+}
+
+// GetDiscussion fetches non-inline PR discussion items (review bodies + issue comments).
+func (a *Adapter) GetDiscussion(ctx context.Context, repo domain.RepoRef, number int) ([]domain.DiscussionItem, error) {
+	comments, err := getAll[gtIssueComment](ctx, a, repoPath(repo, "issues", strconv.Itoa(number), "comments"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting PR conversation comments for PR #%d: %w", number, err)
+	}
+	reviews, err := getAll[gtReview](ctx, a, pullPath(repo, number, "reviews"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting reviews for PR #%d: %w", number, err)
+	}
+
+	discussion := toDomainIssueComments(comments)
+	discussion = append(discussion, toDomainReviewItems(reviews)...)
+	return discussion, nil
+}
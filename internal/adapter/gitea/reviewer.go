@@ -0,0 +1,79 @@
+package gitea
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// SubmitReview submits a review with the given verdict, body and inline
+// comments.
+func (a *Adapter) SubmitReview(ctx context.Context, repo domain.RepoRef, number int, review domain.Review) error {
+	event, err := reviewEvent(review.Action)
+	if err != nil {
+		return err
+	}
+	body := map[string]any{"event": event, "body": review.Body}
+	if len(review.Comments) > 0 {
+		comments := make([]map[string]any, 0, len(review.Comments))
+		for _, c := range review.Comments {
+			comment, err := reviewComment(c)
+			if err != nil {
+				return fmt.Errorf("submitting review for PR #%d: %w", number, err)
+			}
+			comments = append(comments, comment)
+		}
+		body["comments"] = comments
+	}
+	if err := a.sendJSON(ctx, http.MethodPost, pullPath(repo, number, "reviews"), body, nil); err != nil {
+		return fmt.Errorf("submitting review for PR #%d: %w", number, err)
+	}
+	return nil
+}
+
+// AddComment posts an inline comment as a single-comment review. Gitea has no
+// reply endpoint; a reply is a comment at the same path and line, which the
+// forge groups into the same conversation, so InReplyTo needs no special case.
+func (a *Adapter) AddComment(ctx context.Context, repo domain.RepoRef, number int, input domain.InlineCommentInput) error {
+	comment, err := reviewComment(input)
+	if err != nil {
+		return fmt.Errorf("adding comment to PR #%d: %w", number, err)
+	}
+	body := map[string]any{
+		"event":    "COMMENT",
+		"comments": []map[string]any{comment},
+	}
+	if input.CommitID != "" {
+		body["commit_id"] = input.CommitID
+	}
+	if err := a.sendJSON(ctx, http.MethodPost, pullPath(repo, number, "reviews"), body, nil); err != nil {
+		return fmt.Errorf("adding comment to PR #%d: %w", number, err)
+	}
+	return nil
+}
+
+// reviewComment converts an inline comment to a review comment, which
+// addresses lines by side. Review comments are on one line, so a comment on
+// a range of lines is unsupported.
+func reviewComment(input domain.InlineCommentInput) (map[string]any, error) {
+	if input.IsRange() {
+		return nil, fmt.Errorf("commenting on a range of lines of %s: not supported by the Gitea API: %w", input.Path, errors.ErrUnsupported)
+	}
+	comment := map[string]any{"path": input.Path, "body": input.Body}
+	if input.Side == "LEFT" {
+		comment["old_position"] = input.Line
+	} else {
+		comment["new_position"] = input.Line
+	}
+	return comment, nil
+}
+
+// ResolveThread is not supported: the Gitea API cannot resolve review
+// conversations. Threads from this adapter carry no ThreadID, so the UI
+// does not offer it.
+func (a *Adapter) ResolveThread(_ context.Context, _ domain.RepoRef, threadID string) error {
+	return fmt.Errorf("resolving thread %s: not supported by the Gitea API", threadID)
+}
@@ -0,0 +1,43 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// Checkout checks out a PR branch in the process CWD.
+func (a *Adapter) Checkout(ctx context.Context, repo domain.RepoRef, number int) (string, error) {
+	return a.CheckoutAt(ctx, repo, number, "")
+}
+
+// Merge merges a PR, or schedules it to merge once its checks succeed with
+// opts.Auto.
+func (a *Adapter) Merge(ctx context.Context, repo domain.RepoRef, number int, opts domain.MergeOpts) error {
+	method := opts.Method
+	switch method {
+	case domain.MergeMethodSquash, domain.MergeMethodRebase:
+	default:
+		method = domain.MergeMethodMerge
+	}
+
+	body := map[string]any{
+		"Do":                        method,
+		"delete_branch_after_merge": opts.DeleteBranch,
+	}
+	if opts.Auto {
+		body["merge_when_checks_succeed"] = true
+	}
+	if opts.CommitTitle != "" {
+		body["MergeTitleField"] = opts.CommitTitle
+	}
+	if opts.CommitMessage != "" {
+		body["MergeMessageField"] = opts.CommitMessage
+	}
+	if err := a.sendJSON(ctx, http.MethodPost, pullPath(repo, number, "merge"), body, nil); err != nil {
+		return fmt.Errorf("merging PR #%d: %w", number, err)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package githubql
+
+import (
+	"fmt"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// BranchesQuery builds the GraphQL query read by BranchesResult.
+func BranchesQuery(repo domain.RepoRef) string {
+	return fmt.Sprintf(`query {
+  repository(owner: %q, name: %q) {
+    defaultBranchRef { name }
+    refs(refPrefix: "refs/heads/", first: 100, orderBy: {field: TAG_COMMIT_DATE, direction: DESC}) { nodes { name } }
+  }
+}`, repo.Owner, repo.Name)
+}
+
+// BranchesResult is the data of a BranchesQuery response.
+type BranchesResult struct {
+	Repository struct {
+		DefaultBranchRef *struct {
+			Name string `json:"name"`
+		} `json:"defaultBranchRef"`
+		Refs struct {
+			Nodes []struct {
+				Name string `json:"name"`
+			} `json:"nodes"`
+		} `json:"refs"`
+	} `json:"repository"`
+}
+
+// Branches converts the response to domain.RepoBranches.
+func (r BranchesResult) Branches() *domain.RepoBranches {
+	b := &domain.RepoBranches{}
+	if r.Repository.DefaultBranchRef != nil {
+		b.Default = r.Repository.DefaultBranchRef.Name
+	}
+	for _, n := range r.Repository.Refs.Nodes {
+		b.Names = append(b.Names, n.Name)
+	}
+	return b
+}
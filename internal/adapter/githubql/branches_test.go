@@ -0,0 +1,25 @@
+package githubql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+func TestBranchesResult(t *testing.T) {
+	q := BranchesQuery(domain.RepoRef{Owner: "o", Name: "r"})
+	assert.Contains(t, q, `repository(owner: "o", name: "r")`)
+
+	var result BranchesResult
+	require.NoError(t, json.Unmarshal([]byte(`{"repository": {
+  "defaultBranchRef": {"name": "main"},
+  "refs": {"nodes": [{"name": "feat/x"}, {"name": "main"}]}
+}}`), &result))
+	assert.Equal(t, &domain.RepoBranches{Default: "main", Names: []string{"feat/x", "main"}}, result.Branches())
+
+	assert.Empty(t, BranchesResult{}.Branches().Default, "an empty repo has no default branch")
+}
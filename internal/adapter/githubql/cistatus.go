@@ -0,0 +1,50 @@
+package githubql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// CIStatusQuery builds a GraphQL query selecting the head commit rollup of
+// each PR in numbers, aliased pr<number> under repository.
+func CIStatusQuery(repo domain.RepoRef, numbers []int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "query {\n  repository(owner: %q, name: %q) {\n", repo.Owner, repo.Name)
+	seen := make(map[int]bool, len(numbers))
+	for _, n := range numbers {
+		if seen[n] {
+			continue
+		}
+		seen[n] = true
+		fmt.Fprintf(&b, "    pr%d: pullRequest(number: %d) { number commits(last: 1) { nodes { commit { statusCheckRollup { state } } } } }\n", n, n)
+	}
+	b.WriteString("  }\n}")
+	return b.String()
+}
+
+// CIStatusResult is the data of a CIStatusQuery response.
+type CIStatusResult struct {
+	Repository map[string]*rollupPR `json:"repository"`
+}
+
+// rollupPR is one aliased pull request in a CIStatusQuery response.
+type rollupPR struct {
+	Number  int        `json:"number"`
+	Commits HeadCommit `json:"commits"`
+}
+
+// Statuses maps each PR in the result to its CI status. A PR whose head
+// commit has no checks maps to CINone.
+func (r CIStatusResult) Statuses() map[int]domain.CIStatus {
+	statuses := make(map[int]domain.CIStatus, len(r.Repository))
+	for _, pr := range r.Repository {
+		if pr == nil || pr.Number == 0 {
+			continue
+		}
+		ci, _ := pr.Commits.CIStatus()
+		statuses[pr.Number] = ci
+	}
+	return statuses
+}
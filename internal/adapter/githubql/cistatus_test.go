@@ -0,0 +1,38 @@
+package githubql
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+func TestCIStatusQueryAliasesEachPR(t *testing.T) {
+	q := CIStatusQuery(domain.RepoRef{Owner: "o", Name: "r"}, []int{7, 9, 7})
+
+	assert.Contains(t, q, `repository(owner: "o", name: "r")`)
+	assert.Contains(t, q, "pr7: pullRequest(number: 7)")
+	assert.Contains(t, q, "pr9: pullRequest(number: 9)")
+	assert.Equal(t, 1, strings.Count(q, "pr7:"), "duplicate numbers are queried once")
+}
+
+func TestCIStatusResultStatuses(t *testing.T) {
+	data := `{
+  "repository": {
+    "pr7": {"number": 7, "commits": {"nodes": [{"commit": {"statusCheckRollup": {"state": "FAILURE"}}}]}},
+    "pr8": {"number": 8, "commits": {"nodes": [{"commit": {"statusCheckRollup": null}}]}},
+    "pr9": null
+  }
+}`
+	var result CIStatusResult
+	require.NoError(t, json.Unmarshal([]byte(data), &result))
+
+	assert.Equal(t, map[int]domain.CIStatus{
+		7: domain.CIFail,
+		8: domain.CINone,
+	}, result.Statuses())
+}
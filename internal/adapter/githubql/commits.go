@@ -0,0 +1,108 @@
+package githubql
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// CompareCommitsPath is the REST path of the changes from base to head.
+func CompareCommitsPath(repo domain.RepoRef, base, head string) string {
+	return fmt.Sprintf("repos/%s/compare/%s...%s", repo.FullName(), base, head)
+}
+
+// CommitsQuery builds the GraphQL query for the page of a PR's commits
+// after cursor, read by CommitsResult.
+func CommitsQuery(repo domain.RepoRef, number int, cursor string) string {
+	after := "null"
+	if cursor != "" {
+		after = fmt.Sprintf("%q", cursor)
+	}
+	return fmt.Sprintf(`query {
+  repository(owner: %q, name: %q) {
+    pullRequest(number: %d) {
+      commits(first: 100, after: %s) {
+        nodes {
+          commit {
+            oid
+            messageHeadline
+            messageBody
+            authoredDate
+            author { name user { login } }
+            parents(first: 1) { nodes { oid } }
+            statusCheckRollup { state }
+          }
+        }
+        pageInfo { hasNextPage endCursor }
+      }
+    }
+  }
+}`, repo.Owner, repo.Name, number, after)
+}
+
+// CommitsResult is the data of a CommitsQuery response.
+type CommitsResult struct {
+	Repository struct {
+		PullRequest struct {
+			Commits struct {
+				Nodes []struct {
+					Commit commit `json:"commit"`
+				} `json:"nodes"`
+				PageInfo PageInfo `json:"pageInfo"`
+			} `json:"commits"`
+		} `json:"pullRequest"`
+	} `json:"repository"`
+}
+
+type commit struct {
+	OID             string    `json:"oid"`
+	MessageHeadline string    `json:"messageHeadline"`
+	MessageBody     string    `json:"messageBody"`
+	AuthoredDate    time.Time `json:"authoredDate"`
+	Author          struct {
+		Name string `json:"name"`
+		User *Actor `json:"user"`
+	} `json:"author"`
+	Parents struct {
+		Nodes []struct {
+			OID string `json:"oid"`
+		} `json:"nodes"`
+	} `json:"parents"`
+	StatusCheckRollup *struct {
+		State string `json:"state"`
+	} `json:"statusCheckRollup"`
+}
+
+// Page converts the response to domain commits and returns the cursor of
+// the next page, or "" on the last page.
+func (r CommitsResult) Page() (commits []domain.Commit, next string) {
+	conn := r.Repository.PullRequest.Commits
+	commits = make([]domain.Commit, 0, len(conn.Nodes))
+	for _, n := range conn.Nodes {
+		c := n.Commit
+		commit := domain.Commit{
+			SHA:    c.OID,
+			Title:  c.MessageHeadline,
+			Body:   strings.TrimSpace(c.MessageBody),
+			Author: c.Author.Name,
+			Date:   c.AuthoredDate,
+			CI:     domain.CINone,
+		}
+		if c.Author.User != nil && c.Author.User.Login != "" {
+			commit.Author = c.Author.User.Login
+		}
+		if len(c.Parents.Nodes) > 0 {
+			commit.Parent = c.Parents.Nodes[0].OID
+		}
+		if c.StatusCheckRollup != nil {
+			commit.CI = MapRollupState(c.StatusCheckRollup.State)
+		}
+		commits = append(commits, commit)
+	}
+	if conn.PageInfo.HasNextPage {
+		next = conn.PageInfo.EndCursor
+	}
+	return commits, next
+}
@@ -0,0 +1,55 @@
+package githubql
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+func TestCommitsQuery(t *testing.T) {
+	repo := domain.RepoRef{Owner: "o", Name: "r"}
+	assert.Contains(t, CommitsQuery(repo, 7, ""), "commits(first: 100, after: null)")
+	assert.Contains(t, CommitsQuery(repo, 7, "Y3Vy"), `commits(first: 100, after: "Y3Vy")`)
+	assert.Equal(t, "repos/o/r/compare/aaa...bbb", CompareCommitsPath(repo, "aaa", "bbb"))
+}
+
+func TestCommitsResultPage(t *testing.T) {
+	var result CommitsResult
+	require.NoError(t, json.Unmarshal([]byte(`{"repository": {"pullRequest": {"commits": {
+  "nodes": [
+    {"commit": {
+      "oid": "aaa", "messageHeadline": "Extract parser", "messageBody": "So it can be reused.\n",
+      "authoredDate": "2026-01-02T03:04:05Z",
+      "author": {"name": "Alice A", "user": {"login": "alice"}},
+      "parents": {"nodes": [{"oid": "base"}]},
+      "statusCheckRollup": {"state": "FAILURE"}
+    }},
+    {"commit": {
+      "oid": "bbb", "messageHeadline": "Add feature", "messageBody": "",
+      "authoredDate": "2026-01-03T00:00:00Z",
+      "author": {"name": "Bob", "user": null},
+      "parents": {"nodes": [{"oid": "aaa"}]},
+      "statusCheckRollup": null
+    }}
+  ],
+  "pageInfo": {"hasNextPage": true, "endCursor": "Mg"}
+}}}}`), &result))
+
+	commits, next := result.Page()
+	assert.Equal(t, "Mg", next)
+	require.Len(t, commits, 2)
+	assert.Equal(t, domain.Commit{
+		SHA: "aaa", Title: "Extract parser", Body: "So it can be reused.", Author: "alice",
+		Date: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), Parent: "base", CI: domain.CIFail,
+	}, commits[0])
+	assert.Equal(t, "Bob", commits[1].Author, "commits by unknown users show the git author")
+	assert.Equal(t, domain.CINone, commits[1].CI)
+
+	_, next = CommitsResult{}.Page()
+	assert.Empty(t, next)
+}
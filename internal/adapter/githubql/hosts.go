@@ -0,0 +1,62 @@
+package githubql
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// ConfigDir mirrors gh's config directory lookup.
+func ConfigDir() string {
+	if dir := os.Getenv("GH_CONFIG_DIR"); dir != "" {
+		return dir
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gh")
+	}
+	if runtime.GOOS == "windows" {
+		if dir := os.Getenv("AppData"); dir != "" {
+			return filepath.Join(dir, "GitHub CLI")
+		}
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".config", "gh")
+}
+
+// KnownHosts returns the GitHub hosts gh can talk to: github.com, GH_HOST,
+// and every host gh is authenticated against in hosts.yml. Hosts are
+// lowercased. An unreadable hosts.yml is treated as empty.
+func KnownHosts() []string {
+	hosts := []string{domain.DefaultHost}
+	add := func(h string) {
+		h = strings.ToLower(strings.TrimSpace(h))
+		if h != "" && !slices.Contains(hosts, h) {
+			hosts = append(hosts, h)
+		}
+	}
+	add(os.Getenv("GH_HOST"))
+
+	raw, err := os.ReadFile(filepath.Join(ConfigDir(), "hosts.yml"))
+	if err != nil {
+		return hosts
+	}
+	var entries map[string]any
+	if err := yaml.Unmarshal(raw, &entries); err != nil {
+		return hosts
+	}
+	names := make([]string, 0, len(entries))
+	for h := range entries {
+		names = append(names, h)
+	}
+	slices.Sort(names)
+	for _, h := range names {
+		add(h)
+	}
+	return hosts
+}
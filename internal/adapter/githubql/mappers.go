@@ -0,0 +1,103 @@
+// Package githubql holds the GitHub GraphQL queries, REST paths and
+// response parsers shared by the gh CLI and native API adapters, and the
+// mapping of GitHub's enums to domain values.
+package githubql
+
+import "github.com/indrasvat/vivecaka/internal/domain"
+
+// Actor is a user or bot in a GraphQL response.
+type Actor struct {
+	Login string `json:"login"`
+}
+
+// PageInfo is the pageInfo of a GraphQL connection.
+type PageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// MapState maps a pull request state to a domain.PRState.
+func MapState(s string) domain.PRState {
+	switch s {
+	case "OPEN":
+		return domain.PRStateOpen
+	case "CLOSED":
+		return domain.PRStateClosed
+	case "MERGED":
+		return domain.PRStateMerged
+	default:
+		return domain.PRStateOpen
+	}
+}
+
+// MapCheckStatus maps a check run's status and conclusion to a CI status.
+func MapCheckStatus(status, conclusion string) domain.CIStatus {
+	switch status {
+	case "COMPLETED":
+		switch conclusion {
+		case "SUCCESS":
+			return domain.CIPass
+		case "FAILURE", "TIMED_OUT", "STARTUP_FAILURE":
+			return domain.CIFail
+		case "SKIPPED", "NEUTRAL":
+			return domain.CISkipped
+		default:
+			return domain.CIFail
+		}
+	case "IN_PROGRESS", "QUEUED", "PENDING", "WAITING", "REQUESTED":
+		return domain.CIPending
+	default:
+		return domain.CINone
+	}
+}
+
+// MapReviewDecision maps a pull request's review decision to a review
+// status.
+func MapReviewDecision(decision string) domain.ReviewStatus {
+	switch decision {
+	case "APPROVED":
+		return domain.ReviewStatus{State: domain.ReviewApproved}
+	case "CHANGES_REQUESTED":
+		return domain.ReviewStatus{State: domain.ReviewChangesRequested}
+	case "REVIEW_REQUIRED":
+		return domain.ReviewStatus{State: domain.ReviewPending}
+	default:
+		return domain.ReviewStatus{State: domain.ReviewNone}
+	}
+}
+
+// HeadCommit is a pull request's commits(last: 1) connection, selecting
+// only the head commit's status check rollup.
+type HeadCommit struct {
+	Nodes []struct {
+		Commit struct {
+			StatusCheckRollup *struct {
+				State string `json:"state"`
+			} `json:"statusCheckRollup"`
+		} `json:"commit"`
+	} `json:"nodes"`
+}
+
+// CIStatus returns the head commit's rollup CI status. ok is false when the
+// commit has no checks.
+func (c HeadCommit) CIStatus() (status domain.CIStatus, ok bool) {
+	n := len(c.Nodes)
+	if n == 0 || c.Nodes[n-1].Commit.StatusCheckRollup == nil {
+		return domain.CINone, false
+	}
+	return MapRollupState(c.Nodes[n-1].Commit.StatusCheckRollup.State), true
+}
+
+// MapRollupState maps a commit's StatusCheckRollup state to a CI status.
+func MapRollupState(state string) domain.CIStatus {
+	switch state {
+	case "SUCCESS":
+		return domain.CIPass
+	case "FAILURE", "ERROR":
+		return domain.CIFail
+	case "PENDING", "EXPECTED":
+		return domain.CIPending
+	default:
+		return domain.CINone
+	}
+}
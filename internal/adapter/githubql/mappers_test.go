@@ -0,0 +1,39 @@
+package githubql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+func TestMapState(t *testing.T) {
+	assert.Equal(t, domain.PRStateOpen, MapState("OPEN"))
+	assert.Equal(t, domain.PRStateClosed, MapState("CLOSED"))
+	assert.Equal(t, domain.PRStateMerged, MapState("MERGED"))
+	assert.Equal(t, domain.PRStateOpen, MapState("UNKNOWN"))
+}
+
+func TestMapReviewDecision(t *testing.T) {
+	assert.Equal(t, domain.ReviewApproved, MapReviewDecision("APPROVED").State)
+	assert.Equal(t, domain.ReviewChangesRequested, MapReviewDecision("CHANGES_REQUESTED").State)
+	assert.Equal(t, domain.ReviewPending, MapReviewDecision("REVIEW_REQUIRED").State)
+	assert.Equal(t, domain.ReviewNone, MapReviewDecision("").State)
+}
+
+func TestMapCheckStatus(t *testing.T) {
+	assert.Equal(t, domain.CIPass, MapCheckStatus("COMPLETED", "SUCCESS"))
+	assert.Equal(t, domain.CIFail, MapCheckStatus("COMPLETED", "FAILURE"))
+	assert.Equal(t, domain.CIFail, MapCheckStatus("COMPLETED", "TIMED_OUT"))
+	assert.Equal(t, domain.CIFail, MapCheckStatus("COMPLETED", "STARTUP_FAILURE"))
+	assert.Equal(t, domain.CISkipped, MapCheckStatus("COMPLETED", "SKIPPED"))
+	assert.Equal(t, domain.CISkipped, MapCheckStatus("COMPLETED", "NEUTRAL"))
+	assert.Equal(t, domain.CIFail, MapCheckStatus("COMPLETED", "CANCELED"))
+	assert.Equal(t, domain.CIPending, MapCheckStatus("IN_PROGRESS", ""))
+	assert.Equal(t, domain.CIPending, MapCheckStatus("QUEUED", ""))
+	assert.Equal(t, domain.CIPending, MapCheckStatus("PENDING", ""))
+	assert.Equal(t, domain.CIPending, MapCheckStatus("WAITING", ""))
+	assert.Equal(t, domain.CIPending, MapCheckStatus("REQUESTED", ""))
+	assert.Equal(t, domain.CINone, MapCheckStatus("UNKNOWN", ""))
+}
@@ -0,0 +1,119 @@
+package githubql
+
+import (
+	"fmt"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// MergeStatusQuery builds the GraphQL query read by MergeStatusResult.
+func MergeStatusQuery(repo domain.RepoRef, number int) string {
+	return fmt.Sprintf(`query {
+  repository(owner: %q, name: %q) {
+    mergeCommitAllowed squashMergeAllowed rebaseMergeAllowed autoMergeAllowed deleteBranchOnMerge
+    pullRequest(number: %d) {
+      state isDraft mergeable mergeStateStatus reviewDecision
+      commits(last: 1) { nodes { commit { statusCheckRollup { contexts(first: 100) { nodes {
+        __typename
+        ... on CheckRun { name status conclusion detailsUrl isRequired(pullRequestNumber: %d) }
+        ... on StatusContext { context state targetUrl isRequired(pullRequestNumber: %d) }
+      } } } } } }
+    }
+  }
+}`, repo.Owner, repo.Name, number, number, number)
+}
+
+// MergeStatusResult is the data of a MergeStatusQuery response.
+type MergeStatusResult struct {
+	Repository struct {
+		MergeCommitAllowed  bool `json:"mergeCommitAllowed"`
+		SquashMergeAllowed  bool `json:"squashMergeAllowed"`
+		RebaseMergeAllowed  bool `json:"rebaseMergeAllowed"`
+		AutoMergeAllowed    bool `json:"autoMergeAllowed"`
+		DeleteBranchOnMerge bool `json:"deleteBranchOnMerge"`
+		PullRequest         struct {
+			State            string `json:"state"`
+			IsDraft          bool   `json:"isDraft"`
+			Mergeable        string `json:"mergeable"`
+			MergeStateStatus string `json:"mergeStateStatus"`
+			ReviewDecision   string `json:"reviewDecision"`
+			Commits          struct {
+				Nodes []struct {
+					Commit struct {
+						StatusCheckRollup *struct {
+							Contexts struct {
+								Nodes []mergeCheck `json:"nodes"`
+							} `json:"contexts"`
+						} `json:"statusCheckRollup"`
+					} `json:"commit"`
+				} `json:"nodes"`
+			} `json:"commits"`
+		} `json:"pullRequest"`
+	} `json:"repository"`
+}
+
+// mergeCheck is a status check context in a MergeStatusQuery response:
+// a CheckRun (name/status/conclusion) or a commit StatusContext
+// (context/state).
+type mergeCheck struct {
+	Typename   string `json:"__typename"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+	DetailsURL string `json:"detailsUrl"`
+	Context    string `json:"context"`
+	State      string `json:"state"`
+	TargetURL  string `json:"targetUrl"`
+	IsRequired bool   `json:"isRequired"`
+}
+
+// Status converts the result to a domain.MergeStatus.
+func (r MergeStatusResult) Status() *domain.MergeStatus {
+	repo, pr := r.Repository, r.Repository.PullRequest
+	s := &domain.MergeStatus{
+		State:               MapState(pr.State),
+		Draft:               pr.IsDraft,
+		Mergeable:           mapMergeable(pr.Mergeable),
+		Blocked:             pr.MergeStateStatus == "BLOCKED",
+		Behind:              pr.MergeStateStatus == "BEHIND",
+		Review:              MapReviewDecision(pr.ReviewDecision).State,
+		AutoMergeAllowed:    repo.AutoMergeAllowed,
+		DeleteBranchOnMerge: repo.DeleteBranchOnMerge,
+	}
+	for _, m := range []struct {
+		allowed bool
+		method  string
+	}{
+		{repo.MergeCommitAllowed, domain.MergeMethodMerge},
+		{repo.SquashMergeAllowed, domain.MergeMethodSquash},
+		{repo.RebaseMergeAllowed, domain.MergeMethodRebase},
+	} {
+		if m.allowed {
+			s.Methods = append(s.Methods, m.method)
+		}
+	}
+	if n := len(pr.Commits.Nodes); n > 0 && pr.Commits.Nodes[n-1].Commit.StatusCheckRollup != nil {
+		for _, c := range pr.Commits.Nodes[n-1].Commit.StatusCheckRollup.Contexts.Nodes {
+			s.Checks = append(s.Checks, c.check())
+		}
+	}
+	return s
+}
+
+func (c mergeCheck) check() domain.Check {
+	if c.Typename == "StatusContext" {
+		return domain.Check{Name: c.Context, Status: MapRollupState(c.State), URL: c.TargetURL, Required: c.IsRequired}
+	}
+	return domain.Check{Name: c.Name, Status: MapCheckStatus(c.Status, c.Conclusion), URL: c.DetailsURL, Required: c.IsRequired}
+}
+
+func mapMergeable(m string) domain.Mergeability {
+	switch m {
+	case "MERGEABLE":
+		return domain.MergeableClean
+	case "CONFLICTING":
+		return domain.MergeableConflicting
+	default:
+		return domain.MergeableUnknown
+	}
+}
@@ -0,0 +1,52 @@
+package githubql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+func TestMergeStatusQuery(t *testing.T) {
+	q := MergeStatusQuery(domain.RepoRef{Owner: "o", Name: "r"}, 7)
+
+	assert.Contains(t, q, `repository(owner: "o", name: "r")`)
+	assert.Contains(t, q, "pullRequest(number: 7)")
+	assert.Contains(t, q, "isRequired(pullRequestNumber: 7)")
+}
+
+func TestMergeStatusResultStatus(t *testing.T) {
+	data := `{
+  "repository": {
+    "mergeCommitAllowed": false, "squashMergeAllowed": true, "rebaseMergeAllowed": true,
+    "autoMergeAllowed": true, "deleteBranchOnMerge": true,
+    "pullRequest": {
+      "state": "OPEN", "isDraft": false, "mergeable": "MERGEABLE",
+      "mergeStateStatus": "BLOCKED", "reviewDecision": "REVIEW_REQUIRED",
+      "commits": {"nodes": [{"commit": {"statusCheckRollup": {"contexts": {"nodes": [
+        {"__typename": "CheckRun", "name": "test", "status": "IN_PROGRESS", "conclusion": "", "detailsUrl": "https://ci/1", "isRequired": true},
+        {"__typename": "StatusContext", "context": "deploy", "state": "SUCCESS", "targetUrl": "https://ci/2", "isRequired": false}
+      ]}}}}]}
+    }
+  }
+}`
+	var result MergeStatusResult
+	require.NoError(t, json.Unmarshal([]byte(data), &result))
+
+	assert.Equal(t, &domain.MergeStatus{
+		State:     domain.PRStateOpen,
+		Mergeable: domain.MergeableClean,
+		Blocked:   true,
+		Review:    domain.ReviewPending,
+		Checks: []domain.Check{
+			{Name: "test", Status: domain.CIPending, URL: "https://ci/1", Required: true},
+			{Name: "deploy", Status: domain.CIPass, URL: "https://ci/2"},
+		},
+		Methods:             []string{domain.MergeMethodSquash, domain.MergeMethodRebase},
+		AutoMergeAllowed:    true,
+		DeleteBranchOnMerge: true,
+	}, result.Status())
+}
@@ -0,0 +1,54 @@
+package githubql
+
+import (
+	"fmt"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// MetadataOptionsQuery builds the GraphQL query read by
+// MetadataOptionsResult. It lists the first 100 labels and assignable
+// users.
+func MetadataOptionsQuery(repo domain.RepoRef) string {
+	return fmt.Sprintf(`query {
+  repository(owner: %q, name: %q) {
+    labels(first: 100, orderBy: {field: NAME, direction: ASC}) { nodes { name color description } }
+    assignableUsers(first: 100) { nodes { login } }
+  }
+}`, repo.Owner, repo.Name)
+}
+
+// MetadataOptionsResult is the data of a MetadataOptionsQuery response.
+type MetadataOptionsResult struct {
+	Repository struct {
+		Labels struct {
+			Nodes []domain.Label `json:"nodes"`
+		} `json:"labels"`
+		AssignableUsers struct {
+			Nodes []Actor `json:"nodes"`
+		} `json:"assignableUsers"`
+	} `json:"repository"`
+}
+
+// Options converts the response to domain.MetadataOptions, without teams.
+func (r MetadataOptionsResult) Options() *domain.MetadataOptions {
+	users := make([]string, len(r.Repository.AssignableUsers.Nodes))
+	for i, u := range r.Repository.AssignableUsers.Nodes {
+		users[i] = u.Login
+	}
+	return &domain.MetadataOptions{Labels: r.Repository.Labels.Nodes, Users: users}
+}
+
+// RepoTeam is a team in the REST list of teams with access to a repo.
+type RepoTeam struct {
+	Slug string `json:"slug"`
+}
+
+// TeamSlugs names teams the way reviewers are requested: "org/slug".
+func TeamSlugs(repo domain.RepoRef, teams []RepoTeam) []string {
+	out := make([]string, len(teams))
+	for i, t := range teams {
+		out[i] = repo.Owner + "/" + t.Slug
+	}
+	return out
+}
@@ -0,0 +1,28 @@
+package githubql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+func TestMetadataOptionsResult(t *testing.T) {
+	q := MetadataOptionsQuery(domain.RepoRef{Owner: "o", Name: "r"})
+	assert.Contains(t, q, `repository(owner: "o", name: "r")`)
+
+	data := `{"repository": {
+  "labels": {"nodes": [{"name": "bug", "color": "d73a4a", "description": "Something is broken"}]},
+  "assignableUsers": {"nodes": [{"login": "alice"}, {"login": "bob"}]}
+}}`
+	var result MetadataOptionsResult
+	require.NoError(t, json.Unmarshal([]byte(data), &result))
+	opts := result.Options()
+	assert.Equal(t, []domain.Label{{Name: "bug", Color: "d73a4a", Description: "Something is broken"}}, opts.Labels)
+	assert.Equal(t, []string{"alice", "bob"}, opts.Users)
+
+	assert.Equal(t, []string{"o/core", "o/docs"}, TeamSlugs(domain.RepoRef{Owner: "o", Name: "r"}, []RepoTeam{{Slug: "core"}, {Slug: "docs"}}))
+}
@@ -0,0 +1,47 @@
+package githubql
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// ParseRateLimit parses a GitHub /rate_limit response. vivecaka spends both
+// the REST ("core") and GraphQL budgets, so the tighter of the two, by
+// share remaining, is returned.
+func ParseRateLimit(data []byte) (domain.RateLimit, error) {
+	type resource struct {
+		Limit     int   `json:"limit"`
+		Remaining int   `json:"remaining"`
+		Reset     int64 `json:"reset"`
+	}
+	var payload struct {
+		Resources map[string]resource `json:"resources"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return domain.RateLimit{}, fmt.Errorf("parsing rate limit: %w", err)
+	}
+
+	var tightest domain.RateLimit
+	for _, name := range []string{"core", "graphql"} {
+		r, ok := payload.Resources[name]
+		if !ok || r.Limit <= 0 {
+			continue
+		}
+		// Compare remaining/limit ratios without floats.
+		if tightest.Limit == 0 || r.Remaining*tightest.Limit < tightest.Remaining*r.Limit {
+			tightest = domain.RateLimit{
+				Resource:  name,
+				Limit:     r.Limit,
+				Remaining: r.Remaining,
+				Reset:     time.Unix(r.Reset, 0),
+			}
+		}
+	}
+	if tightest.Limit == 0 {
+		return domain.RateLimit{}, fmt.Errorf("parsing rate limit: %w", domain.ErrNotFound)
+	}
+	return tightest, nil
+}
@@ -0,0 +1,37 @@
+package githubql
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+func TestParseRateLimitPicksTightestBudget(t *testing.T) {
+	rl, err := ParseRateLimit(readFixture(t, "rate_limit.json"))
+	require.NoError(t, err)
+	assert.Equal(t, domain.RateLimit{
+		Resource:  "graphql",
+		Limit:     5000,
+		Remaining: 300,
+		Reset:     time.Unix(1767271200, 0),
+	}, rl)
+}
+
+func TestParseRateLimitWithoutResources(t *testing.T) {
+	// GHES with rate limiting disabled has no budget to report.
+	_, err := ParseRateLimit([]byte(`{"resources":{}}`))
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return data
+}
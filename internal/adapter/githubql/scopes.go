@@ -0,0 +1,30 @@
+package githubql
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	neededScopeRe = regexp.MustCompile(`needs the "([^"]+)" scope`)
+	oneOfScopesRe = regexp.MustCompile(`requires one of the following scopes: \[([^\]]*)\]`)
+)
+
+// MissingScopes extracts the scopes GitHub says a token needs, from either
+// gh's hint or GitHub's GraphQL INSUFFICIENT_SCOPES message.
+func MissingScopes(msg string) []string {
+	if m := neededScopeRe.FindStringSubmatch(msg); m != nil {
+		return []string{m[1]}
+	}
+	m := oneOfScopesRe.FindStringSubmatch(msg)
+	if m == nil {
+		return nil
+	}
+	var scopes []string
+	for s := range strings.SplitSeq(m[1], ",") {
+		if s = strings.Trim(strings.TrimSpace(s), `'"`); s != "" {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
@@ -0,0 +1,32 @@
+package githubql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// SearchQuery builds a GitHub search string equivalent to gh pr list filters.
+func SearchQuery(repo domain.RepoRef, opts domain.ListOpts) string {
+	parts := []string{"repo:" + repo.FullName(), "is:pr"}
+	switch opts.State {
+	case "", domain.PRStateOpen:
+		parts = append(parts, "is:open")
+	case domain.PRStateClosed:
+		parts = append(parts, "is:closed", "is:unmerged")
+	case domain.PRStateMerged:
+		parts = append(parts, "is:merged")
+	}
+	if opts.Author != "" {
+		parts = append(parts, "author:"+opts.Author)
+	}
+	for _, l := range opts.Labels {
+		parts = append(parts, fmt.Sprintf("label:%q", l))
+	}
+	if s := strings.TrimSpace(opts.Search); s != "" {
+		parts = append(parts, s)
+	}
+	parts = append(parts, "sort:created-desc")
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,14 @@
+package githubql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+func TestSearchQuery(t *testing.T) {
+	got := SearchQuery(domain.RepoRef{Owner: "o", Name: "r"}, domain.ListOpts{Author: "alice", Labels: []string{"bug"}, Search: "fix"})
+	assert.Equal(t, `repo:o/r is:pr is:open author:alice label:"bug" fix sort:created-desc`, got)
+}
@@ -0,0 +1,431 @@
+package gitlab
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+var testRepo = domain.RepoRef{Owner: "group/sub", Name: "project"}
+
+const testProject = "/api/v4/projects/group%2Fsub%2Fproject"
+
+// fakeGitLab is a minimal in-process GitLab API keyed by escaped request path.
+type fakeGitLab struct {
+	t        *testing.T
+	routes   map[string]http.HandlerFunc
+	mu       sync.Mutex
+	requests []recordedRequest
+}
+
+type recordedRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Body   map[string]any
+}
+
+func newFake(t *testing.T) (*fakeGitLab, *Adapter) {
+	t.Helper()
+	f := &fakeGitLab{t: t, routes: map[string]http.HandlerFunc{}}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	return f, New(WithBaseURL(srv.URL), WithToken("glpat-test"))
+}
+
+func (f *fakeGitLab) handle(method, path string, h http.HandlerFunc) {
+	f.routes[method+" "+path] = h
+}
+
+func (f *fakeGitLab) json(method, path string, v any) {
+	f.handle(method, path, func(w http.ResponseWriter, _ *http.Request) { writeJSON(w, v) })
+}
+
+func (f *fakeGitLab) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	assert.Equal(f.t, "glpat-test", r.Header.Get("PRIVATE-TOKEN"))
+	rec := recordedRequest{Method: r.Method, Path: r.URL.EscapedPath(), Query: r.URL.RawQuery}
+	raw, err := io.ReadAll(r.Body)
+	require.NoError(f.t, err)
+	if len(raw) > 0 {
+		require.NoError(f.t, json.Unmarshal(raw, &rec.Body))
+	}
+	f.mu.Lock()
+	f.requests = append(f.requests, rec)
+	f.mu.Unlock()
+
+	h, ok := f.routes[r.Method+" "+r.URL.EscapedPath()]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		writeJSON(w, map[string]string{"message": "404 Not Found"})
+		return
+	}
+	h(w, r)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func mrJSON(iid int) map[string]any {
+	return map[string]any{
+		"iid":           iid,
+		"title":         "Add feature",
+		"description":   "Body text",
+		"state":         "opened",
+		"draft":         false,
+		"web_url":       "https://gitlab.example.com/group/sub/project/-/merge_requests/5",
+		"created_at":    "2026-01-02T03:04:05Z",
+		"updated_at":    "2026-01-03T03:04:05Z",
+		"author":        map[string]any{"username": "alice"},
+		"assignees":     []map[string]any{{"username": "bob"}},
+		"reviewers":     []map[string]any{{"username": "carol"}, {"username": "dave"}},
+		"labels":        []string{"backend"},
+		"source_branch": "feature",
+		"target_branch": "main",
+		"sha":           "headsha",
+		"head_pipeline": map[string]any{"id": 77, "status": "failed"},
+		"diff_refs":     map[string]any{"base_sha": "basesha", "head_sha": "headsha", "start_sha": "startsha"},
+	}
+}
+
+func TestListPRsMapsFilters(t *testing.T) {
+	f, a := newFake(t)
+	f.json(http.MethodGet, testProject+"/merge_requests", []map[string]any{mrJSON(5)})
+
+	prs, err := a.ListPRs(t.Context(), testRepo, domain.ListOpts{
+		State:   domain.PRStateMerged,
+		Author:  "alice",
+		Labels:  []string{"a", "b"},
+		Draft:   domain.DraftExclude,
+		Page:    2,
+		PerPage: 20,
+	})
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+
+	pr := prs[0]
+	assert.Equal(t, 5, pr.Number)
+	assert.Equal(t, "alice", pr.Author)
+	assert.Equal(t, domain.PRStateOpen, pr.State)
+	assert.Equal(t, "feature", pr.Branch.Head)
+	assert.Equal(t, "basesha", pr.Branch.BaseSHA)
+	assert.Equal(t, domain.CIFail, pr.CI)
+
+	q := f.requests[0].Query
+	for _, want := range []string{"state=merged", "author_username=alice", "labels=a%2Cb", "wip=no", "page=2", "per_page=20"} {
+		assert.Contains(t, q, want)
+	}
+}
+
+func TestGetPRCountUsesTotalHeader(t *testing.T) {
+	f, a := newFake(t)
+	f.handle(http.MethodGet, testProject+"/merge_requests", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Total", "42")
+		writeJSON(w, []map[string]any{mrJSON(1)})
+	})
+
+	n, err := a.GetPRCount(t.Context(), testRepo, domain.PRStateOpen)
+	require.NoError(t, err)
+	assert.Equal(t, 42, n)
+}
+
+func TestGetPRCombinesApprovalsDiffsAndPipeline(t *testing.T) {
+	f, a := newFake(t)
+	mr := testProject + "/merge_requests/5"
+	f.json(http.MethodGet, mr, mrJSON(5))
+	f.json(http.MethodGet, mr+"/approvals", map[string]any{
+		"approved":    true,
+		"approved_by": []map[string]any{{"user": map[string]any{"username": "carol"}}},
+	})
+	f.handle(http.MethodGet, mr+"/diffs", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "1" {
+			w.Header().Set("X-Next-Page", "2")
+			writeJSON(w, []map[string]any{{"old_path": "a.go", "new_path": "a.go", "diff": "@@ -1,2 +1,2 @@\n-old\n+new\n ctx\n"}})
+			return
+		}
+		writeJSON(w, []map[string]any{{"old_path": "b.go", "new_path": "b.go", "new_file": true, "diff": "@@ -0,0 +1 @@\n+hi\n"}})
+	})
+	f.json(http.MethodGet, testProject+"/pipelines/77/jobs", []map[string]any{
+		{"name": "test", "stage": "test", "status": "failed", "duration": 12.5, "web_url": "https://ci/1"},
+		{"name": "lint", "stage": "test", "status": "failed", "allow_failure": true},
+		{"name": "deploy", "stage": "deploy", "status": "manual"},
+	})
+
+	pr, err := a.GetPR(t.Context(), testRepo, 5)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Body text", pr.Body)
+	assert.Equal(t, []string{"bob"}, pr.Assignees)
+	assert.Equal(t, []domain.ReviewerInfo{
+		{Login: "carol", State: domain.ReviewApproved},
+		{Login: "dave", State: domain.ReviewPending},
+	}, pr.Reviewers)
+	assert.Equal(t, domain.ReviewApproved, pr.Review.State)
+	assert.Equal(t, 1, pr.Review.Approved)
+
+	require.Len(t, pr.Files, 2)
+	assert.Equal(t, domain.FileChange{Path: "a.go", Additions: 1, Deletions: 1, Status: "modified"}, pr.Files[0])
+	assert.Equal(t, "added", pr.Files[1].Status)
+
+	require.Len(t, pr.Checks, 3)
+	assert.Equal(t, "test: test", pr.Checks[0].Name)
+	assert.Equal(t, domain.CIFail, pr.Checks[0].Status)
+	assert.InDelta(t, 12.5, pr.Checks[0].Duration.Seconds(), 0.001)
+	assert.Equal(t, domain.CISkipped, pr.Checks[1].Status)
+	assert.Equal(t, domain.CISkipped, pr.Checks[2].Status)
+}
+
+func TestGetDiffReassemblesUnifiedDiff(t *testing.T) {
+	f, a := newFake(t)
+	f.json(http.MethodGet, testProject+"/merge_requests/5/diffs", []map[string]any{
+		{"old_path": "main.go", "new_path": "main.go", "diff": "@@ -1,3 +1,3 @@\n package main\n-var x = 1\n+var x = 2\n"},
+		{"old_path": "old.go", "new_path": "new.go", "renamed_file": true, "diff": ""},
+		{"old_path": "gone.go", "new_path": "gone.go", "deleted_file": true, "diff": "@@ -1 +0,0 @@\n-bye\n"},
+	})
+
+	diff, err := a.GetDiff(t.Context(), testRepo, 5)
+	require.NoError(t, err)
+	require.Len(t, diff.Files, 3)
+	assert.Equal(t, "main.go", diff.Files[0].Path)
+	require.Len(t, diff.Files[0].Hunks, 1)
+	assert.Equal(t, "new.go", diff.Files[1].Path)
+	assert.Equal(t, "gone.go", diff.Files[2].Path)
+}
+
+func discussionsFixture() []map[string]any {
+	line := 12
+	return []map[string]any{
+		{
+			"id": "d-inline",
+			"notes": []map[string]any{
+				{"id": 101, "type": "DiffNote", "body": "Why?", "author": map[string]any{"username": "carol"}, "created_at": "2026-01-04T00:00:00Z",
+					"resolvable": true, "resolved": true, "position": map[string]any{"new_path": "main.go", "old_path": "main.go", "new_line": line,
+						"line_range": map[string]any{"start": map[string]any{"new_line": 10}, "end": map[string]any{"new_line": line}}}},
+				{"id": 102, "type": "DiffNote", "body": "Because", "author": map[string]any{"username": "alice"}, "created_at": "2026-01-04T01:00:00Z",
+					"resolvable": true, "resolved": true},
+			},
+		},
+		{
+			"id": "d-general",
+			"notes": []map[string]any{
+				{"id": 201, "body": "Looks good overall", "author": map[string]any{"username": "dave"}, "created_at": "2026-01-05T00:00:00Z"},
+			},
+		},
+		{
+			"id": "d-system",
+			"notes": []map[string]any{
+				{"id": 301, "body": "added 1 commit", "system": true, "author": map[string]any{"username": "alice"}},
+			},
+		},
+	}
+}
+
+func TestGetCommentsMapsDiffNotes(t *testing.T) {
+	f, a := newFake(t)
+	f.json(http.MethodGet, testProject+"/merge_requests/5/discussions", discussionsFixture())
+
+	threads, err := a.GetComments(t.Context(), testRepo, 5)
+	require.NoError(t, err)
+	require.Len(t, threads, 1)
+
+	th := threads[0]
+	assert.Equal(t, "101", th.ID)
+	assert.Equal(t, "5:d-inline", th.ThreadID)
+	assert.Equal(t, "d-inline", th.ReplyToID)
+	assert.Equal(t, "main.go", th.Path)
+	assert.Equal(t, 12, th.Line)
+	assert.Equal(t, 10, th.StartLine)
+	assert.True(t, th.Resolved)
+	require.Len(t, th.Comments, 2)
+	assert.Contains(t, th.Comments[0].URL, "/-/merge_requests/5#note_101")
+}
+
+func TestGetDiscussionSkipsDiffAndSystemNotes(t *testing.T) {
+	f, a := newFake(t)
+	f.json(http.MethodGet, testProject+"/merge_requests/5/discussions", discussionsFixture())
+
+	items, err := a.GetDiscussion(t.Context(), testRepo, 5)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, domain.DiscussionComment, items[0].Kind)
+	assert.Equal(t, "dave", items[0].Comments[0].Author)
+}
+
+func TestSubmitReviewApprovePostsNote(t *testing.T) {
+	f, a := newFake(t)
+	mr := testProject + "/merge_requests/5"
+	f.json(http.MethodPost, mr+"/approve", map[string]any{})
+	f.json(http.MethodPost, mr+"/notes", map[string]any{})
+
+	err := a.SubmitReview(t.Context(), testRepo, 5, domain.Review{Action: domain.ReviewActionApprove, Body: "LGTM"})
+	require.NoError(t, err)
+	require.Len(t, f.requests, 2)
+	assert.Equal(t, mr+"/approve", f.requests[0].Path)
+	assert.Equal(t, "LGTM", f.requests[1].Body["body"])
+}
+
+func TestSubmitReviewRequestChangesToleratesNoApproval(t *testing.T) {
+	f, a := newFake(t)
+	f.json(http.MethodPost, testProject+"/merge_requests/5/notes", map[string]any{})
+
+	err := a.SubmitReview(t.Context(), testRepo, 5, domain.Review{Action: domain.ReviewActionRequestChanges, Body: "Please fix"})
+	require.NoError(t, err)
+	require.Len(t, f.requests, 2)
+	assert.Equal(t, testProject+"/merge_requests/5/unapprove", f.requests[0].Path)
+}
+
+func TestSubmitReviewPostsCommentsFirst(t *testing.T) {
+	f, a := newFake(t)
+	mr := testProject + "/merge_requests/5"
+	f.json(http.MethodGet, mr, mrJSON(5))
+	f.json(http.MethodPost, mr+"/discussions", map[string]any{})
+	f.json(http.MethodPost, mr+"/approve", map[string]any{})
+
+	err := a.SubmitReview(t.Context(), testRepo, 5, domain.Review{
+		Action:   domain.ReviewActionApprove,
+		Comments: []domain.InlineCommentInput{{Path: "main.go", Line: 7, Side: "RIGHT", Body: "nice"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, f.requests, 3)
+	assert.Equal(t, mr+"/discussions", f.requests[1].Path)
+	assert.Equal(t, mr+"/approve", f.requests[2].Path)
+
+	err = a.SubmitReview(t.Context(), testRepo, 5, domain.Review{Action: "bogus", Comments: []domain.InlineCommentInput{{Path: "x"}}})
+	require.Error(t, err)
+	assert.Len(t, f.requests, 3, "an invalid review posts nothing")
+}
+
+func TestAddCommentBuildsPosition(t *testing.T) {
+	f, a := newFake(t)
+	mr := testProject + "/merge_requests/5"
+	f.json(http.MethodGet, mr, mrJSON(5))
+	f.json(http.MethodPost, mr+"/discussions", map[string]any{})
+
+	err := a.AddComment(t.Context(), testRepo, 5, domain.InlineCommentInput{Path: "main.go", Line: 7, Side: "LEFT", Body: "hmm"})
+	require.NoError(t, err)
+	require.Len(t, f.requests, 2)
+
+	pos, ok := f.requests[1].Body["position"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "startsha", pos["start_sha"])
+	assert.InDelta(t, 7, pos["old_line"], 0)
+	assert.NotContains(t, pos, "new_line")
+}
+
+func TestAddCommentBuildsLineRange(t *testing.T) {
+	f, a := newFake(t)
+	mr := testProject + "/merge_requests/5"
+	f.json(http.MethodGet, mr, mrJSON(5))
+	f.json(http.MethodGet, mr+"/diffs", []map[string]any{
+		{"old_path": "main.go", "new_path": "main.go", "diff": "@@ -1,3 +1,4 @@\n package main\n-var x = 1\n+var x = 2\n+var y = 3\n func main() {}\n"},
+	})
+	f.json(http.MethodPost, mr+"/discussions", map[string]any{})
+
+	err := a.AddComment(t.Context(), testRepo, 5, domain.InlineCommentInput{
+		Path: "main.go", StartLine: 2, StartSide: "RIGHT", Line: 4, Side: "RIGHT", Body: "both",
+	})
+	require.NoError(t, err)
+	require.Len(t, f.requests, 3)
+
+	pos := f.requests[2].Body["position"].(map[string]any)
+	assert.InDelta(t, 4, pos["new_line"], 0)
+	sum := sha1.Sum([]byte("main.go")) //nolint:gosec // GitLab's line code format
+	code := hex.EncodeToString(sum[:])
+	assert.Equal(t, map[string]any{
+		"start": map[string]any{"line_code": code + "_3_2", "type": "new", "new_line": float64(2)},
+		"end":   map[string]any{"line_code": code + "_3_4", "type": "old", "old_line": float64(3), "new_line": float64(4)},
+	}, pos["line_range"])
+
+	err = a.AddComment(t.Context(), testRepo, 5, domain.InlineCommentInput{
+		Path: "main.go", StartLine: 9, StartSide: "RIGHT", Line: 4, Side: "RIGHT", Body: "gone",
+	})
+	require.ErrorContains(t, err, "line 9 of main.go is not in the diff")
+}
+
+func TestAddCommentReply(t *testing.T) {
+	f, a := newFake(t)
+	f.json(http.MethodPost, testProject+"/merge_requests/5/discussions/d-inline/notes", map[string]any{})
+
+	err := a.AddComment(t.Context(), testRepo, 5, domain.InlineCommentInput{Body: "ok", InReplyTo: "d-inline"})
+	require.NoError(t, err)
+	require.Len(t, f.requests, 1)
+}
+
+func TestResolveThreadDecodesID(t *testing.T) {
+	f, a := newFake(t)
+	f.json(http.MethodPut, testProject+"/merge_requests/5/discussions/d-inline", map[string]any{})
+
+	require.NoError(t, a.ResolveThread(t.Context(), testRepo, "5:d-inline"))
+	assert.Equal(t, true, f.requests[0].Body["resolved"])
+
+	assert.Error(t, a.ResolveThread(t.Context(), testRepo, "garbage"))
+}
+
+func TestMergeWhenPipelineSucceeds(t *testing.T) {
+	f, a := newFake(t)
+	f.json(http.MethodPut, testProject+"/merge_requests/5/merge", map[string]any{})
+
+	err := a.Merge(t.Context(), testRepo, 5, domain.MergeOpts{Method: "squash", Auto: true, CommitTitle: "Add x", CommitMessage: "details"})
+	require.NoError(t, err)
+	require.Len(t, f.requests, 1)
+	assert.Equal(t, true, f.requests[0].Body["squash"])
+	assert.Equal(t, true, f.requests[0].Body["merge_when_pipeline_succeeds"])
+	assert.Equal(t, "Add x\n\ndetails", f.requests[0].Body["squash_commit_message"])
+}
+
+func TestErrorMapping(t *testing.T) {
+	f, a := newFake(t)
+	f.handle(http.MethodGet, "/api/v4/user", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		writeJSON(w, map[string]string{"message": "401 Unauthorized"})
+	})
+
+	_, err := a.CurrentUser(t.Context())
+	assert.ErrorIs(t, err, domain.ErrNotAuthenticated)
+
+	_, err = a.GetPR(t.Context(), testRepo, 999)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestRateLimitErrorReadsHeaders(t *testing.T) {
+	f, a := newFake(t)
+	f.handle(http.MethodGet, "/api/v4/user", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.Header().Set("RateLimit-Reset", "1767272400")
+		w.WriteHeader(http.StatusTooManyRequests)
+		writeJSON(w, map[string]string{"message": "Retry later"})
+	})
+
+	_, err := a.CurrentUser(t.Context())
+	assert.ErrorIs(t, err, domain.ErrRateLimited)
+	var rle *domain.RateLimitError
+	require.True(t, errors.As(err, &rle))
+	assert.Equal(t, 30*time.Second, rle.RetryAfter)
+	assert.Equal(t, time.Unix(1767272400, 0), rle.Reset)
+}
+
+func TestCheckRequiresToken(t *testing.T) {
+	t.Setenv("GITLAB_TOKEN", "")
+	t.Setenv("GL_TOKEN", "")
+	err := New(WithBaseURL("http://127.0.0.1:0")).Check()
+	assert.ErrorIs(t, err, domain.ErrNotAuthenticated)
+}
+
+func TestSupportsHostMatchesInstance(t *testing.T) {
+	assert.True(t, New().SupportsHost("gitlab.com"))
+	assert.True(t, New(WithBaseURL("https://GitLab.Example.com/")).SupportsHost("gitlab.example.com"))
+	assert.False(t, New().SupportsHost("github.com"))
+}
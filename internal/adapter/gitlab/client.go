@@ -0,0 +1,182 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// apiError is a non-2xx API response.
+type apiError struct {
+	StatusCode int
+	Message    string
+	err        error
+}
+
+func (e *apiError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("gitlab api: HTTP %d", e.StatusCode)
+	}
+	return fmt.Sprintf("gitlab api: HTTP %d: %s", e.StatusCode, e.Message)
+}
+
+func (e *apiError) Unwrap() error { return e.err }
+
+// pageMeta carries GitLab's pagination headers.
+type pageMeta struct {
+	NextPage int
+	Total    int
+}
+
+// do performs an authenticated request against /api/v4 and returns the body
+// and pagination metadata.
+func (a *Adapter) do(ctx context.Context, method, path string, query url.Values, body any) ([]byte, pageMeta, error) {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, pageMeta{}, fmt.Errorf("encoding request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	u := a.baseURL + "/api/v4/" + strings.TrimPrefix(path, "/")
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return nil, pageMeta{}, fmt.Errorf("building request: %w", err)
+	}
+	req.Header.Set("PRIVATE-TOKEN", a.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, pageMeta{}, fmt.Errorf("gitlab api: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	out, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, pageMeta{}, fmt.Errorf("reading response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, pageMeta{}, newAPIError(resp, out)
+	}
+
+	meta := pageMeta{}
+	meta.NextPage, _ = strconv.Atoi(resp.Header.Get("X-Next-Page"))
+	meta.Total, _ = strconv.Atoi(resp.Header.Get("X-Total"))
+	return out, meta, nil
+}
+
+// newAPIError maps an HTTP error response to a domain-aware error.
+// GitLab reports errors as {"message": ...} or {"error": ...}; message may be
+// a string or an object of field errors.
+func newAPIError(resp *http.Response, body []byte) error {
+	status := resp.StatusCode
+	var payload struct {
+		Message any    `json:"message"`
+		Error   string `json:"error"`
+	}
+	_ = json.Unmarshal(body, &payload)
+
+	msg := payload.Error
+	switch m := payload.Message.(type) {
+	case string:
+		msg = m
+	case nil:
+	default:
+		if raw, err := json.Marshal(m); err == nil {
+			msg = string(raw)
+		}
+	}
+
+	e := &apiError{StatusCode: status, Message: msg}
+	switch status {
+	case http.StatusUnauthorized:
+		e.err = domain.ErrNotAuthenticated
+	case http.StatusForbidden:
+		e.err = domain.ErrUnauthorized
+	case http.StatusNotFound:
+		e.err = domain.ErrNotFound
+	case http.StatusTooManyRequests:
+		e.err = newRateLimitError(resp, e.Message)
+	}
+	return e
+}
+
+// newRateLimitError reads GitLab's Retry-After and RateLimit-Reset headers.
+func newRateLimitError(resp *http.Response, message string) *domain.RateLimitError {
+	e := &domain.RateLimitError{Message: message}
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		e.RetryAfter = time.Duration(secs) * time.Second
+	}
+	if reset, err := strconv.ParseInt(resp.Header.Get("RateLimit-Reset"), 10, 64); err == nil {
+		e.Reset = time.Unix(reset, 0)
+	}
+	return e
+}
+
+// getJSON performs a GET and decodes the JSON response into dst.
+func (a *Adapter) getJSON(ctx context.Context, path string, query url.Values, dst any) (pageMeta, error) {
+	out, meta, err := a.do(ctx, http.MethodGet, path, query, nil)
+	if err != nil {
+		return meta, err
+	}
+	if err := json.Unmarshal(out, dst); err != nil {
+		return meta, fmt.Errorf("parsing api response: %w", err)
+	}
+	return meta, nil
+}
+
+// sendJSON performs a write request with a JSON body and decodes the response
+// into dst (which may be nil).
+func (a *Adapter) sendJSON(ctx context.Context, method, path string, body, dst any) error {
+	out, _, err := a.do(ctx, method, path, nil, body)
+	if err != nil {
+		return err
+	}
+	if dst == nil || len(out) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(out, dst); err != nil {
+		return fmt.Errorf("parsing api response: %w", err)
+	}
+	return nil
+}
+
+// getAll follows X-Next-Page headers and collects every page of a list endpoint.
+func getAll[T any](ctx context.Context, a *Adapter, path string, query url.Values) ([]T, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("per_page", "100")
+
+	var all []T
+	for page := 1; page > 0; {
+		query.Set("page", strconv.Itoa(page))
+		var items []T
+		meta, err := a.getJSON(ctx, path, query, &items)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		page = meta.NextPage
+	}
+	return all, nil
+}
@@ -0,0 +1,434 @@
+package gitlab
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+type glUser struct {
+	Username string `json:"username"`
+}
+
+type glPipeline struct {
+	ID     int    `json:"id"`
+	Status string `json:"status"`
+	WebURL string `json:"web_url"`
+}
+
+// glMergeRequest is the merge request object returned by list and detail endpoints.
+// HeadPipeline and DiffRefs are only populated by the single-MR endpoint.
+type glMergeRequest struct {
+	IID          int         `json:"iid"`
+	Title        string      `json:"title"`
+	Description  string      `json:"description"`
+	State        string      `json:"state"`
+	Draft        bool        `json:"draft"`
+	WorkInProg   bool        `json:"work_in_progress"`
+	WebURL       string      `json:"web_url"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+	Author       glUser      `json:"author"`
+	Assignees    []glUser    `json:"assignees"`
+	Reviewers    []glUser    `json:"reviewers"`
+	Labels       []string    `json:"labels"`
+	SourceBranch string      `json:"source_branch"`
+	TargetBranch string      `json:"target_branch"`
+	SHA          string      `json:"sha"`
+	HeadPipeline *glPipeline `json:"head_pipeline"`
+	DiffRefs     *glDiffRefs `json:"diff_refs"`
+}
+
+type glDiffRefs struct {
+	BaseSHA  string `json:"base_sha"`
+	HeadSHA  string `json:"head_sha"`
+	StartSHA string `json:"start_sha"`
+}
+
+type glApprovals struct {
+	Approved   bool `json:"approved"`
+	ApprovedBy []struct {
+		User glUser `json:"user"`
+	} `json:"approved_by"`
+}
+
+// glDiff is one file entry from the merge request diffs endpoint.
+type glDiff struct {
+	OldPath     string `json:"old_path"`
+	NewPath     string `json:"new_path"`
+	Diff        string `json:"diff"`
+	NewFile     bool   `json:"new_file"`
+	RenamedFile bool   `json:"renamed_file"`
+	DeletedFile bool   `json:"deleted_file"`
+}
+
+type glJob struct {
+	Name         string  `json:"name"`
+	Stage        string  `json:"stage"`
+	Status       string  `json:"status"`
+	AllowFailure bool    `json:"allow_failure"`
+	Duration     float64 `json:"duration"`
+	WebURL       string  `json:"web_url"`
+}
+
+type glPosition struct {
+	OldPath   string       `json:"old_path"`
+	NewPath   string       `json:"new_path"`
+	OldLine   *int         `json:"old_line"`
+	NewLine   *int         `json:"new_line"`
+	LineRange *glLineRange `json:"line_range"`
+}
+
+// glLineRange is the span of a multi-line diff note.
+type glLineRange struct {
+	Start struct {
+		OldLine *int `json:"old_line"`
+		NewLine *int `json:"new_line"`
+	} `json:"start"`
+}
+
+type glNote struct {
+	ID         int         `json:"id"`
+	Type       string      `json:"type"`
+	Body       string      `json:"body"`
+	Author     glUser      `json:"author"`
+	CreatedAt  time.Time   `json:"created_at"`
+	System     bool        `json:"system"`
+	Resolvable bool        `json:"resolvable"`
+	Resolved   bool        `json:"resolved"`
+	Position   *glPosition `json:"position"`
+}
+
+type glDiscussion struct {
+	ID    string   `json:"id"`
+	Notes []glNote `json:"notes"`
+}
+
+// encodeThreadID packs a merge request IID and discussion ID into the opaque
+// domain thread ID, since resolving a discussion needs both.
+func encodeThreadID(iid int, discussionID string) string {
+	return strconv.Itoa(iid) + ":" + discussionID
+}
+
+// decodeThreadID reverses encodeThreadID.
+func decodeThreadID(threadID string) (int, string, bool) {
+	iidStr, discussionID, ok := strings.Cut(threadID, ":")
+	if !ok || discussionID == "" {
+		return 0, "", false
+	}
+	iid, err := strconv.Atoi(iidStr)
+	if err != nil {
+		return 0, "", false
+	}
+	return iid, discussionID, true
+}
+
+// toDomainPR converts a GitLab merge request to a domain.PR.
+func toDomainPR(mr glMergeRequest) domain.PR {
+	pr := domain.PR{
+		Number: mr.IID,
+		Title:  mr.Title,
+		Author: mr.Author.Username,
+		State:  mapState(mr.State),
+		Draft:  mr.Draft || mr.WorkInProg,
+		Branch: domain.BranchInfo{
+			Head:    mr.SourceBranch,
+			Base:    mr.TargetBranch,
+			HeadSHA: mr.SHA,
+		},
+		Labels:         mr.Labels,
+		CI:             domain.CINone,
+		Review:         domain.ReviewStatus{State: domain.ReviewNone},
+		UpdatedAt:      mr.UpdatedAt,
+		CreatedAt:      mr.CreatedAt,
+		URL:            mr.WebURL,
+		LastActivityAt: mr.UpdatedAt,
+	}
+	if pr.Labels == nil {
+		pr.Labels = []string{}
+	}
+	if mr.DiffRefs != nil {
+		pr.Branch.BaseSHA = mr.DiffRefs.BaseSHA
+		if mr.DiffRefs.HeadSHA != "" {
+			pr.Branch.HeadSHA = mr.DiffRefs.HeadSHA
+		}
+	}
+	if mr.HeadPipeline != nil {
+		pr.CI = mapPipelineStatus(mr.HeadPipeline.Status)
+	}
+	return pr
+}
+
+// toDomainPRDetail converts a merge request plus its approvals and diffs.
+func toDomainPRDetail(mr glMergeRequest, approvals glApprovals, diffs []glDiff) domain.PRDetail {
+	detail := domain.PRDetail{
+		PR:   toDomainPR(mr),
+		Body: mr.Description,
+	}
+
+	detail.Assignees = make([]string, len(mr.Assignees))
+	for i, u := range mr.Assignees {
+		detail.Assignees[i] = u.Username
+	}
+
+	approvedBy := make(map[string]bool, len(approvals.ApprovedBy))
+	for _, a := range approvals.ApprovedBy {
+		approvedBy[a.User.Username] = true
+		detail.Reviewers = append(detail.Reviewers, domain.ReviewerInfo{Login: a.User.Username, State: domain.ReviewApproved})
+	}
+	for _, r := range mr.Reviewers {
+		if !approvedBy[r.Username] {
+			detail.Reviewers = append(detail.Reviewers, domain.ReviewerInfo{Login: r.Username, State: domain.ReviewPending})
+		}
+	}
+
+	total := len(detail.Reviewers)
+	switch {
+	case approvals.Approved && len(approvedBy) > 0:
+		detail.Review = domain.ReviewStatus{State: domain.ReviewApproved, Approved: len(approvedBy), Total: total}
+	case total > 0:
+		detail.Review = domain.ReviewStatus{State: domain.ReviewPending, Approved: len(approvedBy), Total: total}
+	}
+
+	detail.Files = make([]domain.FileChange, len(diffs))
+	for i, d := range diffs {
+		adds, dels := countDiffLines(d.Diff)
+		detail.Files[i] = domain.FileChange{
+			Path:      d.NewPath,
+			Additions: adds,
+			Deletions: dels,
+			Status:    fileStatus(d),
+		}
+	}
+	return detail
+}
+
+// toDomainCheck converts a pipeline job to a domain.Check.
+func toDomainCheck(j glJob) domain.Check {
+	status := mapPipelineStatus(j.Status)
+	if status == domain.CIFail && j.AllowFailure {
+		status = domain.CISkipped
+	}
+	name := j.Name
+	if j.Stage != "" {
+		name = j.Stage + ": " + j.Name
+	}
+	return domain.Check{
+		Name:     name,
+		Status:   status,
+		Duration: time.Duration(j.Duration * float64(time.Second)),
+		URL:      j.WebURL,
+	}
+}
+
+// toDomainThreads converts positioned (diff note) discussions to comment threads.
+func toDomainThreads(iid int, webURL string, discussions []glDiscussion) []domain.CommentThread {
+	var threads []domain.CommentThread
+	for _, d := range discussions {
+		notes := userNotes(d.Notes)
+		if len(notes) == 0 || notes[0].Position == nil {
+			continue
+		}
+		pos := notes[0].Position
+		path, line := pos.NewPath, 0
+		switch {
+		case pos.NewLine != nil:
+			line = *pos.NewLine
+		case pos.OldLine != nil:
+			path, line = pos.OldPath, *pos.OldLine
+		}
+		startLine := 0
+		if r := pos.LineRange; r != nil {
+			switch {
+			case r.Start.NewLine != nil:
+				startLine = *r.Start.NewLine
+			case r.Start.OldLine != nil:
+				startLine = *r.Start.OldLine
+			}
+			if startLine == line {
+				startLine = 0
+			}
+		}
+
+		threads = append(threads, domain.CommentThread{
+			ID:        strconv.Itoa(notes[0].ID),
+			ThreadID:  encodeThreadID(iid, d.ID),
+			ReplyToID: d.ID,
+			Path:      path,
+			Line:      line,
+			StartLine: startLine,
+			Resolved:  discussionResolved(notes),
+			Comments:  toDomainComments(notes, webURL),
+		})
+	}
+	return threads
+}
+
+// toDomainDiscussion converts non-positioned discussions to discussion items.
+func toDomainDiscussion(webURL string, discussions []glDiscussion) []domain.DiscussionItem {
+	var items []domain.DiscussionItem
+	for _, d := range discussions {
+		notes := userNotes(d.Notes)
+		if len(notes) == 0 || notes[0].Position != nil || strings.TrimSpace(notes[0].Body) == "" {
+			continue
+		}
+		items = append(items, domain.DiscussionItem{
+			ID:        strconv.Itoa(notes[0].ID),
+			Kind:      domain.DiscussionComment,
+			CreatedAt: notes[0].CreatedAt,
+			URL:       noteURL(webURL, notes[0].ID),
+			Comments:  toDomainComments(notes, webURL),
+		})
+	}
+	return items
+}
+
+func toDomainComments(notes []glNote, webURL string) []domain.Comment {
+	comments := make([]domain.Comment, len(notes))
+	for i, n := range notes {
+		comments[i] = domain.Comment{
+			ID:        strconv.Itoa(n.ID),
+			Author:    n.Author.Username,
+			Body:      n.Body,
+			CreatedAt: n.CreatedAt,
+			URL:       noteURL(webURL, n.ID),
+		}
+	}
+	return comments
+}
+
+// userNotes drops system notes ("added 1 commit", "changed the description", ...).
+func userNotes(notes []glNote) []glNote {
+	out := make([]glNote, 0, len(notes))
+	for _, n := range notes {
+		if !n.System {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// discussionResolved reports whether every resolvable note is resolved.
+func discussionResolved(notes []glNote) bool {
+	resolvable := false
+	for _, n := range notes {
+		if !n.Resolvable {
+			continue
+		}
+		resolvable = true
+		if !n.Resolved {
+			return false
+		}
+	}
+	return resolvable
+}
+
+func noteURL(webURL string, id int) string {
+	if webURL == "" {
+		return ""
+	}
+	return webURL + "#note_" + strconv.Itoa(id)
+}
+
+// unifiedDiff reassembles a git-style unified diff from per-file diff entries
+// so it can be parsed with the same parser used for GitHub diffs.
+func unifiedDiff(diffs []glDiff) string {
+	var b strings.Builder
+	for _, d := range diffs {
+		b.WriteString("diff --git a/" + d.OldPath + " b/" + d.NewPath + "\n")
+		switch {
+		case d.NewFile:
+			b.WriteString("new file mode 100644\n")
+		case d.DeletedFile:
+			b.WriteString("deleted file mode 100644\n")
+		case d.RenamedFile:
+			b.WriteString("rename from " + d.OldPath + "\nrename to " + d.NewPath + "\n")
+		}
+		oldName, newName := "a/"+d.OldPath, "b/"+d.NewPath
+		if d.NewFile {
+			oldName = "/dev/null"
+		}
+		if d.DeletedFile {
+			newName = "/dev/null"
+		}
+		if d.Diff != "" {
+			b.WriteString("--- " + oldName + "\n+++ " + newName + "\n")
+			b.WriteString(d.Diff)
+			if !strings.HasSuffix(d.Diff, "\n") {
+				b.WriteString("\n")
+			}
+		}
+	}
+	return b.String()
+}
+
+// countDiffLines counts added and removed lines in a diff body.
+func countDiffLines(diff string) (adds, dels int) {
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+		case strings.HasPrefix(line, "+"):
+			adds++
+		case strings.HasPrefix(line, "-"):
+			dels++
+		}
+	}
+	return adds, dels
+}
+
+func fileStatus(d glDiff) string {
+	switch {
+	case d.NewFile:
+		return "added"
+	case d.DeletedFile:
+		return "removed"
+	case d.RenamedFile:
+		return "renamed"
+	default:
+		return "modified"
+	}
+}
+
+func mapState(s string) domain.PRState {
+	switch s {
+	case "merged":
+		return domain.PRStateMerged
+	case "closed", "locked":
+		return domain.PRStateClosed
+	default:
+		return domain.PRStateOpen
+	}
+}
+
+// glState maps a domain state filter to the merge request "state" parameter.
+func glState(state domain.PRState) string {
+	switch state {
+	case "":
+		return "opened"
+	case "all":
+		return "all"
+	case domain.PRStateClosed:
+		return "closed"
+	case domain.PRStateMerged:
+		return "merged"
+	default:
+		return "opened"
+	}
+}
+
+func mapPipelineStatus(status string) domain.CIStatus {
+	switch status {
+	case "success":
+		return domain.CIPass
+	case "failed", "canceled":
+		return domain.CIFail
+	case "skipped", "manual":
+		return domain.CISkipped
+	case "created", "waiting_for_resource", "preparing", "pending", "running", "scheduled":
+		return domain.CIPending
+	default:
+		return domain.CINone
+	}
+}
@@ -0,0 +1,150 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/plugin"
+)
+
+const (
+	defaultBaseURL = "https://gitlab.com"
+
+	// checkTimeout bounds the startup auth probe so a dead network fails fast.
+	checkTimeout = 10 * time.Second
+)
+
+// Compile-time checks that Adapter satisfies every domain capability.
+var (
+	_ plugin.Plugin        = (*Adapter)(nil)
+	_ domain.PRReader      = (*Adapter)(nil)
+	_ domain.PRReviewer    = (*Adapter)(nil)
+	_ domain.PRWriter      = (*Adapter)(nil)
+	_ domain.RepoManager   = (*Adapter)(nil)
+	_ domain.HostSupporter = (*Adapter)(nil)
+)
+
+// Adapter talks to the GitLab REST API (v4) and maps merge requests onto the
+// domain's pull request model. RepoRef.Owner is the full namespace path
+// (group or group/subgroup) and RepoRef.Name the project path.
+type Adapter struct {
+	baseURL    string // instance root, e.g. https://gitlab.com
+	token      string
+	httpClient *http.Client
+}
+
+// Option configures an Adapter.
+type Option func(*Adapter)
+
+// WithBaseURL points the adapter at a GitLab instance root (without /api/v4),
+// e.g. a self-hosted server or an httptest server.
+func WithBaseURL(u string) Option {
+	return func(a *Adapter) {
+		if u != "" {
+			a.baseURL = strings.TrimSuffix(u, "/")
+		}
+	}
+}
+
+// WithToken sets the API token explicitly, skipping environment resolution.
+func WithToken(token string) Option {
+	return func(a *Adapter) { a.token = token }
+}
+
+// WithHTTPClient overrides the HTTP client used for all requests.
+func WithHTTPClient(c *http.Client) Option {
+	return func(a *Adapter) { a.httpClient = c }
+}
+
+// New creates a new GitLab adapter. Without WithToken, the token is read from
+// GITLAB_TOKEN, falling back to GL_TOKEN.
+func New(opts ...Option) *Adapter {
+	a := &Adapter{
+		baseURL:    defaultBaseURL,
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	if a.token == "" {
+		for _, name := range []string{"GITLAB_TOKEN", "GL_TOKEN"} {
+			if v := strings.TrimSpace(os.Getenv(name)); v != "" {
+				a.token = v
+				break
+			}
+		}
+	}
+	return a
+}
+
+// Info returns plugin metadata.
+func (a *Adapter) Info() plugin.PluginInfo {
+	return plugin.PluginInfo{
+		Name:        "gitlab",
+		Version:     "1.0.0",
+		Description: "GitLab merge request adapter using the REST API",
+		Provides:    []string{"pr-reader", "pr-reviewer", "pr-writer"},
+	}
+}
+
+// Check verifies that a token is configured and accepted by the instance.
+// Call this before starting the TUI to fail fast with a clear message.
+func (a *Adapter) Check() error {
+	if a.token == "" {
+		return fmt.Errorf("%w: set GITLAB_TOKEN to a personal access token with api scope", domain.ErrNotAuthenticated)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), checkTimeout)
+	defer cancel()
+
+	if _, err := a.CurrentUser(ctx); err != nil {
+		return fmt.Errorf("gitlab api: %w", err)
+	}
+	return nil
+}
+
+// Init satisfies the plugin.Plugin interface.
+func (a *Adapter) Init(_ plugin.AppContext) tea.Cmd {
+	return nil
+}
+
+// SupportsHost reports whether host is the GitLab instance the adapter
+// points at.
+func (a *Adapter) SupportsHost(host string) bool {
+	u, err := url.Parse(a.baseURL)
+	return err == nil && u.Host != "" && strings.EqualFold(u.Host, host)
+}
+
+// CurrentUser returns the username of the authenticated user.
+func (a *Adapter) CurrentUser(ctx context.Context) (string, error) {
+	var user glUser
+	if _, err := a.getJSON(ctx, "user", nil, &user); err != nil {
+		return "", err
+	}
+	if user.Username == "" {
+		return "", domain.ErrNotFound
+	}
+	return user.Username, nil
+}
+
+// projectPath returns the URL-encoded project ID path segment for repo.
+func projectPath(repo domain.RepoRef) string {
+	return "projects/" + url.PathEscape(repo.FullName())
+}
+
+// mrPath returns the API path of a merge request, with optional suffix segments.
+func mrPath(repo domain.RepoRef, iid int, suffix ...string) string {
+	p := fmt.Sprintf("%s/merge_requests/%d", projectPath(repo), iid)
+	for _, s := range suffix {
+		p += "/" + s
+	}
+	return p
+}
@@ -0,0 +1,184 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+const defaultPerPage = 50
+
+// listQuery builds merge request list parameters equivalent to the gh filters.
+func listQuery(opts domain.ListOpts) url.Values {
+	q := url.Values{}
+	q.Set("state", glState(opts.State))
+	q.Set("order_by", "created_at")
+	q.Set("sort", "desc")
+	if opts.Author != "" {
+		q.Set("author_username", opts.Author)
+	}
+	if len(opts.Labels) > 0 {
+		q.Set("labels", strings.Join(opts.Labels, ","))
+	}
+	if s := strings.TrimSpace(opts.Search); s != "" {
+		q.Set("search", s)
+	}
+	switch opts.Draft {
+	case domain.DraftExclude:
+		q.Set("wip", "no")
+	case domain.DraftOnly:
+		q.Set("wip", "yes")
+	}
+	return q
+}
+
+// ListPRs fetches one page of merge requests. Page is 1-based and maps
+// directly onto GitLab's offset pagination. The list endpoint does not
+// include pipelines, so CI is hydrated by GetPR/GetChecks.
+func (a *Adapter) ListPRs(ctx context.Context, repo domain.RepoRef, opts domain.ListOpts) ([]domain.PR, error) {
+	perPage := opts.PerPage
+	if perPage <= 0 {
+		perPage = defaultPerPage
+	}
+	q := listQuery(opts)
+	q.Set("page", strconv.Itoa(max(opts.Page, 1)))
+	q.Set("per_page", strconv.Itoa(perPage))
+
+	var mrs []glMergeRequest
+	if _, err := a.getJSON(ctx, projectPath(repo)+"/merge_requests", q, &mrs); err != nil {
+		return nil, fmt.Errorf("listing merge requests: %w", err)
+	}
+
+	prs := make([]domain.PR, len(mrs))
+	for i, mr := range mrs {
+		prs[i] = toDomainPR(mr)
+	}
+	return prs, nil
+}
+
+// GetPRCount returns the number of merge requests in the given state using
+// the X-Total header of a single-item page.
+func (a *Adapter) GetPRCount(ctx context.Context, repo domain.RepoRef, state domain.PRState) (int, error) {
+	q := url.Values{}
+	q.Set("state", glState(state))
+	q.Set("per_page", "1")
+
+	var mrs []glMergeRequest
+	meta, err := a.getJSON(ctx, projectPath(repo)+"/merge_requests", q, &mrs)
+	if err != nil {
+		return 0, fmt.Errorf("getting merge request count: %w", err)
+	}
+	return meta.Total, nil
+}
+
+// GetPR fetches a merge request with approvals and changed files.
+func (a *Adapter) GetPR(ctx context.Context, repo domain.RepoRef, number int) (*domain.PRDetail, error) {
+	var (
+		mr        glMergeRequest
+		approvals glApprovals
+		diffs     []glDiff
+	)
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		_, err := a.getJSON(gctx, mrPath(repo, number), nil, &mr)
+		return err
+	})
+	g.Go(func() error {
+		_, err := a.getJSON(gctx, mrPath(repo, number, "approvals"), nil, &approvals)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		diffs, err = a.getDiffs(gctx, repo, number)
+		return err
+	})
+	if err := g.Wait(); err != nil {
+		return nil, fmt.Errorf("getting merge request !%d: %w", number, err)
+	}
+
+	detail := toDomainPRDetail(mr, approvals, diffs)
+	if mr.HeadPipeline != nil {
+		checks, err := a.pipelineChecks(ctx, repo, mr.HeadPipeline.ID)
+		if err != nil {
+			return nil, fmt.Errorf("getting pipeline for merge request !%d: %w", number, err)
+		}
+		detail.Checks = checks
+	}
+	return &detail, nil
+}
+
+func (a *Adapter) getDiffs(ctx context.Context, repo domain.RepoRef, number int) ([]glDiff, error) {
+	return getAll[glDiff](ctx, a, mrPath(repo, number, "diffs"), nil)
+}
+
+// GetDiff reassembles the merge request diffs into a unified diff and parses it.
+func (a *Adapter) GetDiff(ctx context.Context, repo domain.RepoRef, number int) (*domain.Diff, error) {
+	diffs, err := a.getDiffs(ctx, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("getting diff for merge request !%d: %w", number, err)
+	}
+	diff := domain.ParseDiff(unifiedDiff(diffs))
+	return &diff, nil
+}
+
+// GetChecks fetches the jobs of the merge request's head pipeline.
+func (a *Adapter) GetChecks(ctx context.Context, repo domain.RepoRef, number int) ([]domain.Check, error) {
+	var mr glMergeRequest
+	if _, err := a.getJSON(ctx, mrPath(repo, number), nil, &mr); err != nil {
+		return nil, fmt.Errorf("getting checks for merge request !%d: %w", number, err)
+	}
+	if mr.HeadPipeline == nil {
+		return []domain.Check{}, nil
+	}
+	checks, err := a.pipelineChecks(ctx, repo, mr.HeadPipeline.ID)
+	if err != nil {
+		return nil, fmt.Errorf("getting checks for merge request !%d: %w", number, err)
+	}
+	return checks, nil
+}
+
+func (a *Adapter) pipelineChecks(ctx context.Context, repo domain.RepoRef, pipelineID int) ([]domain.Check, error) {
+	path := fmt.Sprintf("%s/pipelines/%d/jobs", projectPath(repo), pipelineID)
+	jobs, err := getAll[glJob](ctx, a, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	checks := make([]domain.Check, len(jobs))
+	for i, j := range jobs {
+		checks[i] = toDomainCheck(j)
+	}
+	return checks, nil
+}
+
+func (a *Adapter) getDiscussions(ctx context.Context, repo domain.RepoRef, number int) ([]glDiscussion, error) {
+	return getAll[glDiscussion](ctx, a, mrPath(repo, number, "discussions"), nil)
+}
+
+// mrWebURL returns the merge request's web URL for building note links.
+func (a *Adapter) mrWebURL(repo domain.RepoRef, number int) string {
+	return fmt.Sprintf("%s/%s/-/merge_requests/%d", a.baseURL, repo, number)
+}
+
+// GetComments fetches diff note discussions as inline comment threads.
+func (a *Adapter) GetComments(ctx context.Context, repo domain.RepoRef, number int) ([]domain.CommentThread, error) {
+	discussions, err := a.getDiscussions(ctx, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("getting comments for merge request !%d: %w", number, err)
+	}
+	return toDomainThreads(number, a.mrWebURL(repo, number), discussions), nil
+}
+
+// GetDiscussion fetches general (non-diff) discussions on a merge request.
+func (a *Adapter) GetDiscussion(ctx context.Context, repo domain.RepoRef, number int) ([]domain.DiscussionItem, error) {
+	discussions, err := a.getDiscussions(ctx, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("getting discussion for merge request !%d: %w", number, err)
+	}
+	return toDomainDiscussion(a.mrWebURL(repo, number), discussions), nil
+}
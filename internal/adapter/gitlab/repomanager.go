@@ -0,0 +1,50 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/gitutil"
+)
+
+// CheckoutAt fetches refs/merge-requests/<iid>/head and checks it out as a
+// local branch named after the source branch. If workDir is "", uses the
+// process CWD. An existing local branch is fast-forwarded rather than reset.
+func (a *Adapter) CheckoutAt(ctx context.Context, repo domain.RepoRef, number int, workDir string) (string, error) {
+	var mr glMergeRequest
+	if _, err := a.getJSON(ctx, mrPath(repo, number), nil, &mr); err != nil {
+		return "", fmt.Errorf("checking out merge request !%d: %w", number, err)
+	}
+	branch := mr.SourceBranch
+	if branch == "" {
+		branch = fmt.Sprintf("mr-%d", number)
+	}
+	if err := gitutil.CheckoutRef(ctx, workDir, fmt.Sprintf("merge-requests/%d/head", number), branch); err != nil {
+		return "", fmt.Errorf("checking out merge request !%d: %w", number, err)
+	}
+	return branch, nil
+}
+
+// CloneRepo clones a project to the specified local path over HTTPS.
+// If the target path exists and is a valid clone, it skips cloning and fetches instead.
+func (a *Adapter) CloneRepo(ctx context.Context, repo domain.RepoRef, targetPath string) error {
+	if err := gitutil.Clone(ctx, a.cloneURL(repo), targetPath); err != nil {
+		return fmt.Errorf("cloning %s: %w", repo, err)
+	}
+	return nil
+}
+
+// CreateWorktree creates a git worktree for a merge request at the given path.
+// It fetches the merge request ref into a unique local branch (mr-<iid>) first.
+func (a *Adapter) CreateWorktree(ctx context.Context, _ domain.RepoRef, repoPath string, number int, _ string, worktreePath string) error {
+	if err := gitutil.AddWorktree(ctx, repoPath, fmt.Sprintf("merge-requests/%d/head", number), fmt.Sprintf("mr-%d", number), worktreePath); err != nil {
+		return fmt.Errorf("creating worktree for merge request !%d: %w", number, err)
+	}
+	return nil
+}
+
+// cloneURL returns the HTTPS clone URL for a project on the adapter's instance.
+func (a *Adapter) cloneURL(repo domain.RepoRef) string {
+	return a.baseURL + "/" + repo.FullName() + ".git"
+}
@@ -0,0 +1,201 @@
+package gitlab
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // GitLab's line code format, not for security
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// SubmitReview maps review actions onto GitLab: inline comments are posted
+// as diff discussions first, approve uses the approvals endpoint,
+// request-changes revokes the caller's approval, and any body is posted as
+// a merge request note. GitLab has no single review submission, so a
+// failure can leave earlier comments posted.
+func (a *Adapter) SubmitReview(ctx context.Context, repo domain.RepoRef, number int, review domain.Review) error {
+	switch review.Action {
+	case domain.ReviewActionApprove, domain.ReviewActionRequestChanges, domain.ReviewActionComment:
+	default:
+		return fmt.Errorf("unknown review action: %q", review.Action)
+	}
+	for _, c := range review.Comments {
+		if err := a.AddComment(ctx, repo, number, c); err != nil {
+			return fmt.Errorf("submitting review for merge request !%d: %w", number, err)
+		}
+	}
+
+	var err error
+	switch review.Action {
+	case domain.ReviewActionApprove:
+		err = a.sendJSON(ctx, http.MethodPost, mrPath(repo, number, "approve"), nil, nil)
+	case domain.ReviewActionRequestChanges:
+		// GitLab has no request-changes verdict on older instances; revoking an
+		// approval is the closest equivalent. Not having approved is not an error.
+		err = a.sendJSON(ctx, http.MethodPost, mrPath(repo, number, "unapprove"), nil, nil)
+		if errors.Is(err, domain.ErrNotFound) {
+			err = nil
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("submitting review for merge request !%d: %w", number, err)
+	}
+
+	if strings.TrimSpace(review.Body) == "" {
+		return nil
+	}
+	if err := a.sendJSON(ctx, http.MethodPost, mrPath(repo, number, "notes"), map[string]any{"body": review.Body}, nil); err != nil {
+		return fmt.Errorf("submitting review for merge request !%d: %w", number, err)
+	}
+	return nil
+}
+
+// AddComment starts a diff discussion on a line, or replies to an existing
+// discussion when InReplyTo holds a discussion ID.
+func (a *Adapter) AddComment(ctx context.Context, repo domain.RepoRef, number int, input domain.InlineCommentInput) error {
+	if input.InReplyTo != "" {
+		path := mrPath(repo, number, "discussions", input.InReplyTo, "notes")
+		if err := a.sendJSON(ctx, http.MethodPost, path, map[string]any{"body": input.Body}, nil); err != nil {
+			return fmt.Errorf("adding comment to merge request !%d: %w", number, err)
+		}
+		return nil
+	}
+
+	// Diff positions need the merge request's base/start/head SHAs.
+	var mr glMergeRequest
+	if _, err := a.getJSON(ctx, mrPath(repo, number), nil, &mr); err != nil {
+		return fmt.Errorf("adding comment to merge request !%d: %w", number, err)
+	}
+	if mr.DiffRefs == nil {
+		return fmt.Errorf("adding comment to merge request !%d: missing diff refs", number)
+	}
+
+	position := map[string]any{
+		"position_type": "text",
+		"base_sha":      mr.DiffRefs.BaseSHA,
+		"start_sha":     mr.DiffRefs.StartSHA,
+		"head_sha":      mr.DiffRefs.HeadSHA,
+		"old_path":      input.Path,
+		"new_path":      input.Path,
+	}
+	if input.Side == "LEFT" {
+		position["old_line"] = input.Line
+	} else {
+		position["new_line"] = input.Line
+	}
+	if input.IsRange() {
+		lineRange, err := a.lineRange(ctx, repo, number, input)
+		if err != nil {
+			return fmt.Errorf("adding comment to merge request !%d: %w", number, err)
+		}
+		position["line_range"] = lineRange
+	}
+
+	body := map[string]any{"body": input.Body, "position": position}
+	if err := a.sendJSON(ctx, http.MethodPost, mrPath(repo, number, "discussions"), body, nil); err != nil {
+		return fmt.Errorf("adding comment to merge request !%d: %w", number, err)
+	}
+	return nil
+}
+
+// lineRange builds the line_range of a comment on a range of lines. GitLab
+// addresses each end by a line code holding both of the line's numbers, so
+// they are looked up in the merge request's diff.
+func (a *Adapter) lineRange(ctx context.Context, repo domain.RepoRef, number int, input domain.InlineCommentInput) (map[string]any, error) {
+	diffs, err := a.getDiffs(ctx, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	diff := domain.ParseDiff(unifiedDiff(diffs))
+	for _, file := range diff.Files {
+		if file.Path != input.Path {
+			continue
+		}
+		start, ok := linePoint(file, input.StartSide, input.StartLine)
+		if !ok {
+			return nil, fmt.Errorf("line %d of %s is not in the diff", input.StartLine, input.Path)
+		}
+		end, ok := linePoint(file, input.Side, input.Line)
+		if !ok {
+			return nil, fmt.Errorf("line %d of %s is not in the diff", input.Line, input.Path)
+		}
+		return map[string]any{"start": start, "end": end}, nil
+	}
+	return nil, fmt.Errorf("%s is not in the diff", input.Path)
+}
+
+// linePoint finds the diff line at line on side and describes it as one end
+// of a line_range. An added line's code carries the old line it was added
+// before, and a deleted line's the new one, as GitLab numbers them.
+func linePoint(file domain.FileDiff, side string, line int) (map[string]any, bool) {
+	fileHash := sha1.Sum([]byte(file.Path)) //nolint:gosec // GitLab's line code format, not security-sensitive
+	for _, hunk := range file.Hunks {
+		oldNum, newNum := hunkStart(hunk.Header)
+		for _, l := range hunk.Lines {
+			if l.Type != domain.DiffAdd {
+				oldNum = l.OldNum
+			}
+			if l.Type != domain.DiffDelete {
+				newNum = l.NewNum
+			}
+			match := l.NewNum == line && l.Type != domain.DiffDelete
+			if side == "LEFT" {
+				match = l.OldNum == line && l.Type != domain.DiffAdd
+			}
+			if match {
+				point := map[string]any{
+					"line_code": fmt.Sprintf("%s_%d_%d", hex.EncodeToString(fileHash[:]), oldNum, newNum),
+					"type":      "old",
+				}
+				if l.Type == domain.DiffAdd {
+					point["type"] = "new"
+				} else {
+					point["old_line"] = oldNum
+				}
+				if l.Type != domain.DiffDelete {
+					point["new_line"] = newNum
+				}
+				return point, true
+			}
+			if l.Type != domain.DiffAdd {
+				oldNum++
+			}
+			if l.Type != domain.DiffDelete {
+				newNum++
+			}
+		}
+	}
+	return nil, false
+}
+
+// hunkStart returns the first old and new line numbers of a hunk from its
+// "@@ -old,n +new,m @@" header.
+func hunkStart(header string) (oldNum, newNum int) {
+	fields := strings.Fields(header)
+	if len(fields) < 3 {
+		return 0, 0
+	}
+	oldStart, _, _ := strings.Cut(strings.TrimPrefix(fields[1], "-"), ",")
+	newStart, _, _ := strings.Cut(strings.TrimPrefix(fields[2], "+"), ",")
+	oldNum, _ = strconv.Atoi(oldStart)
+	newNum, _ = strconv.Atoi(newStart)
+	return oldNum, newNum
+}
+
+// ResolveThread resolves a merge request discussion.
+func (a *Adapter) ResolveThread(ctx context.Context, repo domain.RepoRef, threadID string) error {
+	number, discussionID, ok := decodeThreadID(threadID)
+	if !ok {
+		return fmt.Errorf("resolving thread %s: invalid thread id", threadID)
+	}
+	path := mrPath(repo, number, "discussions", discussionID)
+	if err := a.sendJSON(ctx, http.MethodPut, path, map[string]any{"resolved": true}, nil); err != nil {
+		return fmt.Errorf("resolving thread %s: %w", threadID, err)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// Checkout checks out a merge request branch in the process CWD.
+func (a *Adapter) Checkout(ctx context.Context, repo domain.RepoRef, number int) (string, error) {
+	return a.CheckoutAt(ctx, repo, number, "")
+}
+
+// Merge accepts a merge request, or sets it to merge when its pipeline
+// succeeds with opts.Auto. "squash" squashes commits; "rebase" is not a
+// per-request option on GitLab and falls back to the project's configured
+// merge method. GitLab takes one commit message, so a title is its first
+// line.
+func (a *Adapter) Merge(ctx context.Context, repo domain.RepoRef, number int, opts domain.MergeOpts) error {
+	body := map[string]any{
+		"squash":                      opts.Method == domain.MergeMethodSquash,
+		"should_remove_source_branch": opts.DeleteBranch,
+	}
+	if opts.Auto {
+		body["merge_when_pipeline_succeeds"] = true
+	}
+	if msg := strings.TrimSpace(opts.CommitTitle + "\n\n" + opts.CommitMessage); msg != "" {
+		key := "merge_commit_message"
+		if opts.Method == domain.MergeMethodSquash {
+			key = "squash_commit_message"
+		}
+		body[key] = msg
+	}
+	if err := a.sendJSON(ctx, http.MethodPut, mrPath(repo, number, "merge"), body, nil); err != nil {
+		return fmt.Errorf("merging merge request !%d: %w", number, err)
+	}
+	return nil
+}
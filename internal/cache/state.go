@@ -34,13 +34,22 @@ type PRReviewState struct {
 
 	ActiveScope string                     `json:"active_scope,omitempty"`
 	ViewedFiles map[string]FileReviewState `json:"viewed_files,omitempty"`
+
+	// PendingComments are inline comments not yet submitted, sent with the
+	// next review.
+	PendingComments []domain.InlineCommentInput `json:"pending_comments,omitempty"`
 }
 
-// FileReviewState records when and at what digest a file was reviewed.
+// FileReviewState records when a file was last marked viewed and which of
+// its hunks were, by digest.
 type FileReviewState struct {
 	ViewedAt      time.Time `json:"viewed_at,omitempty"`
 	ViewedHeadSHA string    `json:"viewed_head_sha,omitempty"`
-	PatchDigest   string    `json:"patch_digest,omitempty"`
+	ViewedHunks   []string  `json:"viewed_hunks,omitempty"`
+
+	// PatchDigest is the digest of the whole file, from state saved before
+	// hunks were tracked. It is only decoded, to migrate such entries.
+	PatchDigest string `json:"patch_digest,omitempty"`
 }
 
 // StatePath returns the state file path for a given repo.
@@ -31,7 +31,8 @@ func TestSaveAndLoadRepoState(t *testing.T) {
 				LastReviewHeadSHA: "abc123",
 				ActiveScope:       "since_review",
 				LastReviewFiles:   map[string]string{"README.md": "digest-1"},
-				ViewedFiles:       map[string]FileReviewState{"README.md": {PatchDigest: "digest-1"}},
+				ViewedFiles:       map[string]FileReviewState{"README.md": {ViewedHunks: []string{"hunk-1"}}},
+				PendingComments:   []domain.InlineCommentInput{{Path: "main.go", Line: 3, Side: "RIGHT", Body: "nit"}},
 			},
 		},
 	}
@@ -49,6 +50,7 @@ func TestSaveAndLoadRepoState(t *testing.T) {
 	assert.True(t, ok, "expected PR 42 in last viewed")
 	assert.Equal(t, "abc123", loaded.PRReviews[42].LastReviewHeadSHA)
 	assert.Equal(t, "since_review", loaded.PRReviews[42].ActiveScope)
+	assert.Equal(t, state.PRReviews[42].PendingComments, loaded.PRReviews[42].PendingComments)
 }
 
 func TestLoadRepoStateMissing(t *testing.T) {
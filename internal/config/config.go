@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"slices"
 	"strings"
@@ -12,11 +13,17 @@ import (
 
 // Config holds all application configuration.
 type Config struct {
-	General       GeneralConfig       `toml:"general"`
-	Diff          DiffConfig          `toml:"diff"`
-	Repos         ReposConfig         `toml:"repos"`
-	Keybindings   map[string]string   `toml:"keybindings"`
-	Notifications NotificationsConfig `toml:"notifications"`
+	General         GeneralConfig       `toml:"general"`
+	Diff            DiffConfig          `toml:"diff"`
+	Repos           ReposConfig         `toml:"repos"`
+	Keybindings     map[string]string   `toml:"keybindings"`
+	Notifications   NotificationsConfig `toml:"notifications"`
+	GitLab          GitLabConfig        `toml:"gitlab"`
+	Gitea           GiteaConfig         `toml:"gitea"`
+	Routes          []RouteConfig       `toml:"routes,omitempty"`
+	Hooks           HooksConfig         `toml:"hooks"`
+	Snippets        []SnippetConfig     `toml:"snippets,omitempty"`
+	ReviewTemplates []TemplateConfig    `toml:"review_templates,omitempty"`
 
 	path string `toml:"-"` // source file path (not serialized)
 }
@@ -32,6 +39,7 @@ type GeneralConfig struct {
 	CacheTTL        int    `toml:"cache_ttl"`
 	StaleDays       int    `toml:"stale_days"`
 	Debug           bool   `toml:"debug"`
+	Backend         string `toml:"backend"`
 }
 
 // DiffConfig holds diff viewer settings.
@@ -55,6 +63,70 @@ type NotificationsConfig struct {
 	CIChanges      bool `toml:"ci_changes"`
 }
 
+// GitLabConfig holds settings for the GitLab backend.
+type GitLabConfig struct {
+	URL string `toml:"url"` // instance root, e.g. https://gitlab.example.com
+}
+
+// GiteaConfig holds settings for the Gitea/Forgejo backend.
+type GiteaConfig struct {
+	URL string `toml:"url"` // instance root, e.g. https://codeberg.org
+}
+
+// HooksConfig maps action events to shell commands. Each command runs with
+// sh -c and gets the event as JSON on stdin; an empty command is skipped.
+type HooksConfig struct {
+	PROpened        string `toml:"pr_opened"`
+	ReviewSubmitted string `toml:"review_submitted"`
+	CheckoutDone    string `toml:"checkout_done"`
+	CommentAdded    string `toml:"comment_added"`
+	ThreadResolved  string `toml:"thread_resolved"`
+	Timeout         int    `toml:"timeout"` // seconds a command may run
+}
+
+// Commands returns the configured commands keyed by event name.
+func (h HooksConfig) Commands() map[string]string {
+	cmds := make(map[string]string)
+	for event, cmd := range map[string]string{
+		"pr_opened":        h.PROpened,
+		"review_submitted": h.ReviewSubmitted,
+		"checkout_done":    h.CheckoutDone,
+		"comment_added":    h.CommentAdded,
+		"thread_resolved":  h.ThreadResolved,
+	} {
+		if strings.TrimSpace(cmd) != "" {
+			cmds[event] = cmd
+		}
+	}
+	return cmds
+}
+
+// RouteConfig sends the repos matching a pattern to a backend first. The
+// pattern is "host", "host/owner" or "host/owner/name", with * wildcards;
+// the backend is one of the general.backend values or an external plugin's
+// name.
+type RouteConfig struct {
+	Repos   string `toml:"repos"`   // e.g. "ghe.example.com" or "github.com/acme/*"
+	Backend string `toml:"backend"` // e.g. "api"
+}
+
+// SnippetConfig is a canned response offered by the snippet picker. Repos
+// limits it to the repos matching a pattern, as in [[routes]]; without it
+// the snippet is offered everywhere. The body may use {{author}},
+// {{number}}, {{file}} and {{line}}.
+type SnippetConfig struct {
+	Name  string `toml:"name"`
+	Body  string `toml:"body"`
+	Repos string `toml:"repos,omitempty"`
+}
+
+// TemplateConfig prefills the review body for the repos matching Repos, or
+// for every repo without a more specific template when Repos is empty.
+type TemplateConfig struct {
+	Repos string `toml:"repos,omitempty"`
+	Body  string `toml:"body"`
+}
+
 // Default returns the default configuration.
 func Default() *Config {
 	return &Config{
@@ -67,6 +139,7 @@ func Default() *Config {
 			ShowBanner:      true,
 			CacheTTL:        5,
 			StaleDays:       7,
+			Backend:         "gh",
 		},
 		Diff: DiffConfig{
 			Mode:          "unified",
@@ -80,14 +153,21 @@ func Default() *Config {
 			ReviewRequests: true,
 			CIChanges:      true,
 		},
+		GitLab: GitLabConfig{
+			URL: "https://gitlab.com",
+		},
+		Hooks: HooksConfig{
+			Timeout: 10,
+		},
 	}
 }
 
 var (
-	validSorts   = []string{"updated", "created", "number", "title", "author"}
-	validFilters = []string{"open", "closed", "merged", "all"}
-	validModes   = []string{"unified", "split"}
-	validStyles  = []string{"dark", "light", "notty"}
+	validSorts    = []string{"updated", "created", "number", "title", "author"}
+	validFilters  = []string{"open", "closed", "merged", "all"}
+	validModes    = []string{"unified", "split"}
+	validStyles   = []string{"dark", "light", "notty"}
+	validBackends = []string{"gh", "api", "gitlab", "gitea"}
 )
 
 // ShellMetaChars contains characters that have special meaning in POSIX shells.
@@ -114,6 +194,48 @@ func (c *Config) Validate() error {
 	if c.General.DefaultFilter != "" && !slices.Contains(validFilters, c.General.DefaultFilter) {
 		return fmt.Errorf("general.default_filter must be one of %v, got %q", validFilters, c.General.DefaultFilter)
 	}
+	if c.General.Backend != "" && !slices.Contains(validBackends, c.General.Backend) {
+		return fmt.Errorf("general.backend must be one of %v, got %q", validBackends, c.General.Backend)
+	}
+	if c.GitLab.URL != "" && !strings.HasPrefix(c.GitLab.URL, "https://") && !strings.HasPrefix(c.GitLab.URL, "http://") {
+		return fmt.Errorf("gitlab.url must start with http:// or https://, got %q", c.GitLab.URL)
+	}
+	if c.Gitea.URL != "" && !strings.HasPrefix(c.Gitea.URL, "https://") && !strings.HasPrefix(c.Gitea.URL, "http://") {
+		return fmt.Errorf("gitea.url must start with http:// or https://, got %q", c.Gitea.URL)
+	}
+	if c.General.Backend == "gitea" && c.Gitea.URL == "" {
+		return fmt.Errorf("gitea.url is required when general.backend is \"gitea\"")
+	}
+	if c.Hooks.Timeout <= 0 {
+		return fmt.Errorf("hooks.timeout must be > 0, got %d", c.Hooks.Timeout)
+	}
+	for i, r := range c.Routes {
+		if r.Repos == "" {
+			return fmt.Errorf("routes[%d].repos must be host, host/owner or host/owner/name, got %q", i, r.Repos)
+		}
+		if err := validateRepoPattern(r.Repos); err != nil {
+			return fmt.Errorf("routes[%d].repos %w", i, err)
+		}
+		if r.Backend == "" {
+			return fmt.Errorf("routes[%d].backend is required", i)
+		}
+		if r.Backend == "gitea" && c.Gitea.URL == "" {
+			return fmt.Errorf("gitea.url is required when routes[%d].backend is \"gitea\"", i)
+		}
+	}
+	for i, sn := range c.Snippets {
+		if strings.TrimSpace(sn.Name) == "" || sn.Body == "" {
+			return fmt.Errorf("snippets[%d] needs a name and a body", i)
+		}
+		if err := validateRepoPattern(sn.Repos); err != nil {
+			return fmt.Errorf("snippets[%d].repos %w", i, err)
+		}
+	}
+	for i, t := range c.ReviewTemplates {
+		if err := validateRepoPattern(t.Repos); err != nil {
+			return fmt.Errorf("review_templates[%d].repos %w", i, err)
+		}
+	}
 	if c.Diff.ContextLines < 0 {
 		return fmt.Errorf("diff.context_lines must be >= 0, got %d", c.Diff.ContextLines)
 	}
@@ -129,11 +251,45 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// validateRepoPattern checks a repos pattern: host, host/owner or
+// host/owner/name with * wildcards. An empty pattern is valid.
+func validateRepoPattern(p string) error {
+	if strings.Count(p, "/") > 2 {
+		return fmt.Errorf("must be host, host/owner or host/owner/name, got %q", p)
+	}
+	if _, err := path.Match(p, ""); err != nil {
+		return fmt.Errorf("%q: %w", p, err)
+	}
+	return nil
+}
+
 // ConfigPath returns the path from which this config was loaded (empty if default).
 func (c *Config) ConfigPath() string {
 	return c.path
 }
 
+// Value returns the setting at a dotted key such as "general.theme", as it
+// would be written to the config file, or nil if there is none.
+func (c *Config) Value(key string) any {
+	out, err := toml.Marshal(c)
+	if err != nil {
+		return nil
+	}
+	var tree map[string]any
+	if err := toml.Unmarshal(out, &tree); err != nil {
+		return nil
+	}
+	var v any = tree
+	for part := range strings.SplitSeq(key, ".") {
+		table, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = table[part]
+	}
+	return v
+}
+
 // UpdateFavorites writes the favorites list back to the config TOML file.
 // If the config was loaded from a file, it updates that file. Otherwise it
 // writes to the default XDG config path.
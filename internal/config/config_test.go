@@ -20,6 +20,8 @@ func TestDefaultConfig(t *testing.T) {
 	assert.True(t, cfg.General.ShowBanner)
 	assert.Equal(t, 5, cfg.General.CacheTTL)
 	assert.Equal(t, 7, cfg.General.StaleDays)
+	assert.Equal(t, "gh", cfg.General.Backend)
+	assert.Equal(t, "https://gitlab.com", cfg.GitLab.URL)
 	assert.Equal(t, "unified", cfg.Diff.Mode)
 	assert.True(t, cfg.Diff.LineNumbers)
 	assert.Equal(t, 3, cfg.Diff.ContextLines)
@@ -35,6 +37,14 @@ func TestDefaultConfigValidates(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestValueLooksUpDottedKeys(t *testing.T) {
+	cfg := Default()
+	assert.Equal(t, "default-dark", cfg.Value("general.theme"))
+	assert.EqualValues(t, 50, cfg.Value("general.page_size"))
+	assert.Nil(t, cfg.Value("general.missing"))
+	assert.Nil(t, cfg.Value("general.theme.name"), "a leaf has no children")
+}
+
 func TestValidateInvalidRefreshInterval(t *testing.T) {
 	cfg := Default()
 	cfg.General.RefreshInterval = -1
@@ -77,6 +87,108 @@ func TestValidateInvalidFilter(t *testing.T) {
 	assert.Error(t, err, "Validate() with invalid default_filter should return error")
 }
 
+func TestValidateInvalidBackend(t *testing.T) {
+	cfg := Default()
+	cfg.General.Backend = "svn"
+	err := cfg.Validate()
+	assert.Error(t, err, "Validate() with invalid backend should return error")
+}
+
+func TestValidateAcceptsAllValidBackends(t *testing.T) {
+	for _, backend := range validBackends {
+		cfg := Default()
+		cfg.General.Backend = backend
+		cfg.Gitea.URL = "https://forge.example.com"
+		err := cfg.Validate()
+		assert.NoError(t, err, "Validate() with backend=%q should not return error", backend)
+	}
+}
+
+func TestValidateInvalidGitLabURL(t *testing.T) {
+	cfg := Default()
+	cfg.GitLab.URL = "gitlab.example.com"
+	err := cfg.Validate()
+	assert.Error(t, err, "Validate() with scheme-less gitlab.url should return error")
+}
+
+func TestValidateGiteaBackendRequiresURL(t *testing.T) {
+	cfg := Default()
+	cfg.General.Backend = "gitea"
+	assert.Error(t, cfg.Validate(), "Validate() with gitea backend and no gitea.url should return error")
+
+	cfg.Gitea.URL = "https://codeberg.org"
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidateRoutes(t *testing.T) {
+	cfg := Default()
+	cfg.Routes = []RouteConfig{{Repos: "ghe.example.com/acme/*", Backend: "api"}}
+	assert.NoError(t, cfg.Validate())
+
+	for _, r := range []RouteConfig{
+		{Repos: "", Backend: "api"},
+		{Repos: "a/b/c/d", Backend: "api"},
+		{Repos: "ghe.example.com/[", Backend: "api"},
+		{Repos: "ghe.example.com"},
+		{Repos: "forge.example.com", Backend: "gitea"},
+	} {
+		cfg.Routes = []RouteConfig{r}
+		assert.Error(t, cfg.Validate(), "Validate() with route %+v should return error", r)
+	}
+}
+
+func TestValidateSnippetsAndTemplates(t *testing.T) {
+	cfg := Default()
+	cfg.Snippets = []SnippetConfig{
+		{Name: "nit", Body: "nit: "},
+		{Name: "test", Body: "Please add a test for {{file}}.", Repos: "github.com/acme/*"},
+	}
+	cfg.ReviewTemplates = []TemplateConfig{{Body: "LGTM"}, {Repos: "github.com/acme", Body: "## Checklist"}}
+	assert.NoError(t, cfg.Validate())
+
+	for _, sn := range []SnippetConfig{
+		{Name: " ", Body: "x"},
+		{Name: "empty"},
+		{Name: "bad", Body: "x", Repos: "a/b/c/d"},
+	} {
+		cfg.Snippets = []SnippetConfig{sn}
+		assert.Error(t, cfg.Validate(), "Validate() with snippet %+v should return error", sn)
+	}
+
+	cfg.Snippets = nil
+	cfg.ReviewTemplates = []TemplateConfig{{Repos: "github.com/[", Body: "x"}}
+	assert.Error(t, cfg.Validate())
+}
+
+func TestLoadSnippets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	data := `
+[[snippets]]
+name = "nit"
+body = "nit: "
+
+[[snippets]]
+name = "thanks"
+body = "Thanks @{{author}}!"
+repos = "github.com/acme/*"
+
+[[review_templates]]
+repos = "github.com/acme/*"
+body = '''
+## Summary
+
+## Testing
+'''
+`
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+	cfg, err := LoadFrom(path)
+	require.NoError(t, err)
+	require.Len(t, cfg.Snippets, 2)
+	assert.Equal(t, SnippetConfig{Name: "thanks", Body: "Thanks @{{author}}!", Repos: "github.com/acme/*"}, cfg.Snippets[1])
+	require.Len(t, cfg.ReviewTemplates, 1)
+	assert.Equal(t, "## Summary\n\n## Testing\n", cfg.ReviewTemplates[0].Body)
+}
+
 func TestValidateInvalidContextLines(t *testing.T) {
 	cfg := Default()
 	cfg.Diff.ContextLines = -1
@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// Commit is a commit on a branch or in a PR.
+type Commit struct {
+	SHA    string    `json:"sha"`
+	Title  string    `json:"title"` // first line of the message
+	Body   string    `json:"body,omitempty"`
+	Author string    `json:"author"`
+	Date   time.Time `json:"date"`
+	// Parent is the SHA of the first parent, when the reader reports it.
+	Parent string `json:"parent,omitempty"`
+	// CI is the rollup of the checks run on the commit, when the reader
+	// reports it.
+	CI CIStatus `json:"ci,omitempty"`
+}
+
+// ShortSHA returns the first seven characters of the commit's SHA.
+func (c Commit) ShortSHA() string {
+	if len(c.SHA) > 7 {
+		return c.SHA[:7]
+	}
+	return c.SHA
+}
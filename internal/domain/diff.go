@@ -36,3 +36,20 @@ const (
 )
 
 func (t DiffLineType) String() string { return string(t) }
+
+// Stats counts the added and deleted lines across the diff.
+func (d Diff) Stats() (additions, deletions int) {
+	for _, f := range d.Files {
+		for _, h := range f.Hunks {
+			for _, l := range h.Lines {
+				switch l.Type {
+				case DiffAdd:
+					additions++
+				case DiffDelete:
+					deletions++
+				}
+			}
+		}
+	}
+	return additions, deletions
+}
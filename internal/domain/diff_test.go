@@ -20,3 +20,15 @@ func TestDiffLineTypeString(t *testing.T) {
 		assert.Equal(t, tt.want, got)
 	}
 }
+
+func TestDiffStats(t *testing.T) {
+	d := Diff{Files: []FileDiff{
+		{Path: "a.go", Hunks: []Hunk{{Lines: []DiffLine{
+			{Type: DiffContext}, {Type: DiffAdd}, {Type: DiffAdd}, {Type: DiffDelete},
+		}}}},
+		{Path: "b.go", Hunks: []Hunk{{Lines: []DiffLine{{Type: DiffDelete}}}}},
+	}}
+	adds, dels := d.Stats()
+	assert.Equal(t, 2, adds)
+	assert.Equal(t, 2, dels)
+}
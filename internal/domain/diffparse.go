@@ -1,13 +1,11 @@
-package ghcli
+package domain
 
 import (
 	"strings"
-
-	"github.com/indrasvat/vivecaka/internal/domain"
 )
 
-// ParseDiff parses a unified diff string into a domain.Diff.
-func ParseDiff(raw string) domain.Diff {
+// ParseDiff parses a unified diff string into a Diff.
+func ParseDiff(raw string) Diff {
 	p := &diffParser{}
 	return p.parse(raw)
 }
@@ -18,10 +16,10 @@ type diffParser struct {
 	newLine int
 }
 
-func (p *diffParser) parse(raw string) domain.Diff {
-	var diff domain.Diff
-	var currentFile *domain.FileDiff
-	var currentHunk *domain.Hunk
+func (p *diffParser) parse(raw string) Diff {
+	var diff Diff
+	var currentFile *FileDiff
+	var currentHunk *Hunk
 
 	for _, line := range strings.Split(raw, "\n") {
 		switch {
@@ -33,7 +31,7 @@ func (p *diffParser) parse(raw string) domain.Diff {
 				}
 				diff.Files = append(diff.Files, *currentFile)
 			}
-			currentFile = &domain.FileDiff{}
+			currentFile = &FileDiff{}
 			currentHunk = nil
 			parseDiffHeader(line, currentFile)
 
@@ -52,7 +50,7 @@ func (p *diffParser) parse(raw string) domain.Diff {
 				if currentHunk != nil {
 					currentFile.Hunks = append(currentFile.Hunks, *currentHunk)
 				}
-				currentHunk = &domain.Hunk{Header: line}
+				currentHunk = &Hunk{Header: line}
 				p.parseHunkHeader(line)
 			}
 
@@ -75,7 +73,7 @@ func (p *diffParser) parse(raw string) domain.Diff {
 }
 
 // parseDiffHeader extracts file paths from a "diff --git a/path b/path" line.
-func parseDiffHeader(line string, f *domain.FileDiff) {
+func parseDiffHeader(line string, f *FileDiff) {
 	_, after, ok := strings.Cut(line, " b/")
 	if ok {
 		f.Path = after
@@ -122,11 +120,11 @@ func parseRangeStart(s string) int {
 	return n
 }
 
-// parseDiffLine converts a raw diff line into a domain.DiffLine.
-func (p *diffParser) parseDiffLine(line string) *domain.DiffLine {
+// parseDiffLine converts a raw diff line into a DiffLine.
+func (p *diffParser) parseDiffLine(line string) *DiffLine {
 	if line == "" {
-		dl := &domain.DiffLine{
-			Type:    domain.DiffContext,
+		dl := &DiffLine{
+			Type:    DiffContext,
 			Content: "",
 			OldNum:  p.oldLine,
 			NewNum:  p.newLine,
@@ -138,16 +136,16 @@ func (p *diffParser) parseDiffLine(line string) *domain.DiffLine {
 
 	switch line[0] {
 	case '+':
-		dl := &domain.DiffLine{
-			Type:    domain.DiffAdd,
+		dl := &DiffLine{
+			Type:    DiffAdd,
 			Content: line[1:],
 			NewNum:  p.newLine,
 		}
 		p.newLine++
 		return dl
 	case '-':
-		dl := &domain.DiffLine{
-			Type:    domain.DiffDelete,
+		dl := &DiffLine{
+			Type:    DiffDelete,
 			Content: line[1:],
 			OldNum:  p.oldLine,
 		}
@@ -160,8 +158,8 @@ func (p *diffParser) parseDiffLine(line string) *domain.DiffLine {
 		if line[0] == ' ' {
 			content = line[1:]
 		}
-		dl := &domain.DiffLine{
-			Type:    domain.DiffContext,
+		dl := &DiffLine{
+			Type:    DiffContext,
 			Content: content,
 			OldNum:  p.oldLine,
 			NewNum:  p.newLine,
@@ -1,12 +1,10 @@
-package ghcli
+package domain
 
 import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-
-	"github.com/indrasvat/vivecaka/internal/domain"
 )
 
 const sampleDiff = `diff --git a/main.go b/main.go
@@ -39,7 +37,7 @@ func TestParseDiffBasic(t *testing.T) {
 	h1 := f.Hunks[0]
 	addCount := 0
 	for _, l := range h1.Lines {
-		if l.Type == domain.DiffAdd {
+		if l.Type == DiffAdd {
 			addCount++
 			assert.Equal(t, `import "os"`, l.Content)
 		}
@@ -113,7 +111,7 @@ func TestParseDiffLineNumbers(t *testing.T) {
 	assert.Equal(t, 5, lines[0].NewNum, "first context new")
 
 	// Addition should be new=6, old=0.
-	require.Equal(t, domain.DiffAdd, lines[1].Type)
+	require.Equal(t, DiffAdd, lines[1].Type)
 	assert.Equal(t, 6, lines[1].NewNum, "add line new")
 	assert.Equal(t, 0, lines[1].OldNum, "add line old")
 }
@@ -133,7 +131,7 @@ func TestParseDiffNoNewlineMarker(t *testing.T) {
 	// Should have 2 lines: delete and add. The "no newline" marker is skipped.
 	realLines := 0
 	for _, l := range lines {
-		if l.Type == domain.DiffAdd || l.Type == domain.DiffDelete {
+		if l.Type == DiffAdd || l.Type == DiffDelete {
 			realLines++
 		}
 	}
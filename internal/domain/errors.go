@@ -3,6 +3,7 @@ package domain
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 // Sentinel errors for common failure modes.
@@ -11,8 +12,42 @@ var (
 	ErrUnauthorized     = errors.New("unauthorized")
 	ErrNotAuthenticated = errors.New("not authenticated: run 'gh auth login'")
 	ErrRateLimited      = errors.New("rate limited")
+	ErrUnavailable      = errors.New("backend unavailable")
+
+	// Classified host failures, carried by HostError. SSO and scope
+	// failures also match ErrUnauthorized.
+	ErrSSORequired       = errors.New("SSO authorization required")
+	ErrInsufficientScope = errors.New("token is missing required scopes")
+	ErrNetwork           = errors.New("network unreachable")
+	ErrArchived          = errors.New("repository is archived")
+	ErrLocked            = errors.New("conversation is locked")
 )
 
+// HostError is a classified failure reported by a PR host. Kind is one of
+// the sentinel errors above, so callers test it with errors.Is; Remedy tells
+// the user how to recover.
+type HostError struct {
+	Kind    error
+	Message string // the host's own wording
+	Remedy  string // e.g. "run gh auth refresh -s read:org"
+	URL     string // page that resolves the failure, e.g. SSO authorization
+	Scopes  []string
+}
+
+func (e *HostError) Error() string {
+	if e.Message == "" {
+		return e.Kind.Error()
+	}
+	return e.Kind.Error() + ": " + e.Message
+}
+
+func (e *HostError) Unwrap() error { return e.Kind }
+
+// Is makes SSO and scope failures match ErrUnauthorized as well as their kind.
+func (e *HostError) Is(target error) bool {
+	return target == ErrUnauthorized && (e.Kind == ErrSSORequired || e.Kind == ErrInsufficientScope)
+}
+
 // ValidationError represents a validation failure on a specific field.
 type ValidationError struct {
 	Field   string
@@ -22,3 +57,78 @@ type ValidationError struct {
 func (e *ValidationError) Error() string {
 	return fmt.Sprintf("validation error on %s: %s", e.Field, e.Message)
 }
+
+const (
+	// defaultRateLimitWait is how long to back off when a host gives no
+	// reset time. GitHub asks for at least a minute on secondary limits.
+	defaultRateLimitWait = time.Minute
+	// maxRateLimitRetries bounds automatic retries of a rate-limited call.
+	maxRateLimitRetries = 3
+	// maxRateLimitRetryWait is the longest wait worth blocking a request
+	// on; anything longer is surfaced so the caller can pause instead.
+	maxRateLimitRetryWait = 10 * time.Second
+)
+
+// RateLimitError reports that a host refused a request for exceeding its
+// API rate limit. It matches ErrRateLimited with errors.Is.
+type RateLimitError struct {
+	// Secondary marks GitHub's secondary (abuse) limits, which are
+	// short-lived and not reflected in the remaining budget.
+	Secondary bool
+	// Reset is when the primary budget refills; zero if unknown.
+	Reset time.Time
+	// RetryAfter is the wait the host asked for; zero if unknown.
+	RetryAfter time.Duration
+	Message    string
+}
+
+func (e *RateLimitError) Error() string {
+	kind := ErrRateLimited.Error()
+	if e.Secondary {
+		kind += " (secondary)"
+	}
+	if e.Message == "" {
+		return kind
+	}
+	return kind + ": " + e.Message
+}
+
+// Is makes errors.Is(err, ErrRateLimited) match.
+func (e *RateLimitError) Is(target error) bool { return target == ErrRateLimited }
+
+// ResumeAt returns when requests may be sent again.
+func (e *RateLimitError) ResumeAt(now time.Time) time.Time {
+	switch {
+	case e.RetryAfter > 0:
+		return now.Add(e.RetryAfter)
+	case e.Reset.After(now):
+		return e.Reset
+	default:
+		return now.Add(defaultRateLimitWait)
+	}
+}
+
+// RetryDelay returns how long to wait before retry number attempt
+// (starting at 0), or false when the call should not be retried because
+// retries are exhausted or the wait is too long to block on. Secondary
+// limits without a hint back off exponentially from one second.
+func (e *RateLimitError) RetryDelay(now time.Time, attempt int) (time.Duration, bool) {
+	if attempt >= maxRateLimitRetries {
+		return 0, false
+	}
+	var wait time.Duration
+	switch {
+	case e.RetryAfter > 0:
+		wait = e.RetryAfter
+	case !e.Reset.IsZero():
+		wait = max(e.Reset.Sub(now), 0)
+	case e.Secondary:
+		wait = time.Second << attempt
+	default:
+		return 0, false
+	}
+	if wait > maxRateLimitRetryWait {
+		return 0, false
+	}
+	return wait, true
+}
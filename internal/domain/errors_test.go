@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -73,3 +74,72 @@ func TestValidationErrorNotMatchSentinel(t *testing.T) {
 	ve := &ValidationError{Field: "x", Message: "y"}
 	assert.False(t, errors.Is(ve, ErrNotFound))
 }
+
+func TestRateLimitErrorMatchesSentinel(t *testing.T) {
+	wrapped := fmt.Errorf("listing PRs: %w", &RateLimitError{Secondary: true, Message: "slow down"})
+	assert.True(t, errors.Is(wrapped, ErrRateLimited))
+	assert.False(t, errors.Is(wrapped, ErrNotFound))
+	assert.Equal(t, "listing PRs: rate limited (secondary): slow down", wrapped.Error())
+
+	var rle *RateLimitError
+	require.True(t, errors.As(wrapped, &rle))
+	assert.True(t, rle.Secondary)
+}
+
+func TestHostErrorMatchesKind(t *testing.T) {
+	wrapped := fmt.Errorf("listing PRs: %w", &HostError{Kind: ErrNotFound, Message: "no such repo"})
+	assert.True(t, errors.Is(wrapped, ErrNotFound))
+	assert.False(t, errors.Is(wrapped, ErrUnauthorized))
+	assert.Equal(t, "listing PRs: not found: no such repo", wrapped.Error())
+	assert.Equal(t, "repository is archived", (&HostError{Kind: ErrArchived}).Error())
+}
+
+func TestHostErrorAuthKindsMatchUnauthorized(t *testing.T) {
+	for _, kind := range []error{ErrSSORequired, ErrInsufficientScope} {
+		err := &HostError{Kind: kind}
+		assert.True(t, errors.Is(err, kind))
+		assert.True(t, errors.Is(err, ErrUnauthorized), "%v", kind)
+	}
+	assert.False(t, errors.Is(&HostError{Kind: ErrLocked}, ErrUnauthorized))
+}
+
+func TestRateLimitErrorResumeAt(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	reset := now.Add(30 * time.Minute)
+
+	assert.Equal(t, now.Add(90*time.Second), (&RateLimitError{RetryAfter: 90 * time.Second, Reset: reset}).ResumeAt(now))
+	assert.Equal(t, reset, (&RateLimitError{Reset: reset}).ResumeAt(now))
+	assert.Equal(t, now.Add(time.Minute), (&RateLimitError{Secondary: true}).ResumeAt(now))
+}
+
+func TestRateLimitErrorRetryDelay(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		err     RateLimitError
+		attempt int
+		want    time.Duration
+		ok      bool
+	}{
+		{"secondary backs off exponentially", RateLimitError{Secondary: true}, 2, 4 * time.Second, true},
+		{"retry-after honored", RateLimitError{RetryAfter: 3 * time.Second}, 0, 3 * time.Second, true},
+		{"reset soon", RateLimitError{Reset: now.Add(5 * time.Second)}, 0, 5 * time.Second, true},
+		{"reset too far", RateLimitError{Reset: now.Add(time.Hour)}, 0, 0, false},
+		{"retry-after too long", RateLimitError{Secondary: true, RetryAfter: time.Minute}, 0, 0, false},
+		{"primary with unknown reset", RateLimitError{}, 0, 0, false},
+		{"retries exhausted", RateLimitError{Secondary: true}, 3, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.err.RetryDelay(now, tt.attempt)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRateLimitLow(t *testing.T) {
+	assert.True(t, RateLimit{Limit: 5000, Remaining: 499}.Low())
+	assert.False(t, RateLimit{Limit: 5000, Remaining: 500}.Low())
+	assert.False(t, RateLimit{}.Low())
+}
@@ -13,6 +13,41 @@ type PRReader interface {
 	GetPRCount(ctx context.Context, repo RepoRef, state PRState) (int, error)
 }
 
+// PRPager is implemented by readers that paginate PR lists with cursors, so
+// loading a later page fetches only that page.
+type PRPager interface {
+	ListPRPage(ctx context.Context, repo RepoRef, opts ListOpts) (PRPage, error)
+}
+
+// ListPage lists one page of PRs from reader. Readers that paginate with
+// cursors fetch only that page; others are asked for opts.Page, and a full
+// page is taken to mean more may follow.
+func ListPage(ctx context.Context, reader PRReader, repo RepoRef, opts ListOpts) (PRPage, error) {
+	if pager, ok := reader.(PRPager); ok {
+		return pager.ListPRPage(ctx, repo, opts)
+	}
+	prs, err := reader.ListPRs(ctx, repo, opts)
+	if err != nil {
+		return PRPage{}, err
+	}
+	return PRPage{PRs: prs, HasMore: opts.PerPage > 0 && len(prs) >= opts.PerPage}, nil
+}
+
+// CIStatusReader is implemented by readers that can fetch the CI status of
+// several PRs in one request, so list rows can be refreshed in batches.
+// PRs that no longer exist are left out of the result.
+type CIStatusReader interface {
+	GetCIStatuses(ctx context.Context, repo RepoRef, numbers []int) (map[int]CIStatus, error)
+}
+
+// CommitReader is implemented by readers that can list a PR's commits,
+// oldest first, and diff a range of commits, so a PR can be reviewed
+// commit by commit. CompareCommits returns the changes from base to head.
+type CommitReader interface {
+	GetCommits(ctx context.Context, repo RepoRef, number int) ([]Commit, error)
+	CompareCommits(ctx context.Context, repo RepoRef, base, head string) (*Diff, error)
+}
+
 // PRReviewer provides review capabilities.
 type PRReviewer interface {
 	SubmitReview(ctx context.Context, repo RepoRef, number int, review Review) error
@@ -20,12 +55,58 @@ type PRReviewer interface {
 	ResolveThread(ctx context.Context, repo RepoRef, threadID string) error
 }
 
+// CommentManager is implemented by reviewers that can manage the PR
+// conversation beyond inline review comments: post timeline comments, edit
+// and delete comments, unresolve threads and react with emoji.
+type CommentManager interface {
+	AddPRComment(ctx context.Context, repo RepoRef, number int, body string) error
+	EditComment(ctx context.Context, repo RepoRef, ref CommentRef, body string) error
+	DeleteComment(ctx context.Context, repo RepoRef, ref CommentRef) error
+	UnresolveThread(ctx context.Context, repo RepoRef, threadID string) error
+	AddReaction(ctx context.Context, repo RepoRef, ref CommentRef, content ReactionContent) error
+	RemoveReaction(ctx context.Context, repo RepoRef, ref CommentRef, content ReactionContent) error
+}
+
 // PRWriter provides write capabilities.
-// Only Checkout is exposed in MVP. Merge and UpdateLabels are for plugin extensibility.
 type PRWriter interface {
 	Checkout(ctx context.Context, repo RepoRef, number int) (branch string, err error)
 	Merge(ctx context.Context, repo RepoRef, number int, opts MergeOpts) error
-	UpdateLabels(ctx context.Context, repo RepoRef, number int, labels []string) error
+}
+
+// MergeStatusReader is implemented by writers that can report whether a PR
+// can be merged and what blocks it.
+type MergeStatusReader interface {
+	GetMergeStatus(ctx context.Context, repo RepoRef, number int) (*MergeStatus, error)
+}
+
+// MetadataEditor is implemented by writers that can edit a PR's labels,
+// assignees and requested reviewers.
+type MetadataEditor interface {
+	GetMetadataOptions(ctx context.Context, repo RepoRef) (*MetadataOptions, error)
+	EditMetadata(ctx context.Context, repo RepoRef, number int, edit MetadataEdit) error
+}
+
+// PRLifecycleManager is implemented by writers that can change a PR's
+// state: close or reopen it, optionally with a comment, convert it between
+// draft and ready for review, and bring its head branch up to date with the
+// base. UpdateBranch takes MergeMethodMerge or MergeMethodRebase.
+type PRLifecycleManager interface {
+	ClosePR(ctx context.Context, repo RepoRef, number int, comment string) error
+	ReopenPR(ctx context.Context, repo RepoRef, number int, comment string) error
+	SetDraft(ctx context.Context, repo RepoRef, number int, draft bool) error
+	UpdateBranch(ctx context.Context, repo RepoRef, number int, method string) error
+}
+
+// PRCreator is implemented by writers that can open pull requests from a
+// branch of a local clone. CompareBranch and PushBranch work in the clone
+// at dir; CreatePR needs the head branch to exist on the host and returns
+// the new PR's number. A number with an error means the PR was opened but
+// a follow-up edit, such as requesting reviewers, failed.
+type PRCreator interface {
+	ListBranches(ctx context.Context, repo RepoRef) (*RepoBranches, error)
+	CompareBranch(ctx context.Context, dir, base, head string) (*BranchComparison, error)
+	PushBranch(ctx context.Context, dir, branch string) error
+	CreatePR(ctx context.Context, repo RepoRef, pr NewPR) (int, error)
 }
 
 // RepoManager provides local git repository management capabilities.
@@ -37,7 +118,21 @@ type RepoManager interface {
 	CheckoutAt(ctx context.Context, repo RepoRef, number int, workDir string) (branch string, err error)
 	// CloneRepo clones a repository to the specified local path.
 	CloneRepo(ctx context.Context, repo RepoRef, targetPath string) error
-	// CreateWorktree creates a git worktree for a PR branch at the given path.
-	// It fetches the PR ref first, then creates the worktree.
-	CreateWorktree(ctx context.Context, repoPath string, number int, branch, worktreePath string) error
+	// CreateWorktree creates a git worktree for a PR of repo, from the clone
+	// at repoPath, at the given path. It fetches the PR ref first, then
+	// creates the worktree.
+	CreateWorktree(ctx context.Context, repo RepoRef, repoPath string, number int, branch, worktreePath string) error
+}
+
+// RateLimitReporter is implemented by adapters that can report the API
+// budget left on a repo's host. Querying it must not consume budget.
+type RateLimitReporter interface {
+	RateLimit(ctx context.Context, repo RepoRef) (RateLimit, error)
+}
+
+// HostSupporter is implemented by backends that know which hosts they can
+// serve. host is lowercase, e.g. "github.com". Requests for a repo only fail
+// over to backends that its routes name or that support its host.
+type HostSupporter interface {
+	SupportsHost(host string) bool
 }
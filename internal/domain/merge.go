@@ -0,0 +1,99 @@
+package domain
+
+import "strings"
+
+// Mergeability is whether a PR's head merges cleanly into its base.
+type Mergeability string
+
+const (
+	MergeableClean       Mergeability = "mergeable"
+	MergeableConflicting Mergeability = "conflicting"
+	MergeableUnknown     Mergeability = "unknown" // not computed yet, or not reported
+)
+
+// MergeStatus reports whether a PR can be merged and what stands in the way.
+type MergeStatus struct {
+	State     PRState      `json:"state"`
+	Draft     bool         `json:"draft"`
+	Mergeable Mergeability `json:"mergeable"`
+	// Blocked is set when branch protection forbids merging now, and Behind
+	// when the head branch must first be brought up to date with the base.
+	Blocked bool        `json:"blocked"`
+	Behind  bool        `json:"behind"`
+	Review  ReviewState `json:"review"` // the review decision; ReviewPending when a review is required
+	Checks  []Check     `json:"checks"` // head commit checks
+	// Methods lists the merge methods the repo allows, in the order the
+	// host offers them.
+	Methods             []string `json:"methods"`
+	AutoMergeAllowed    bool     `json:"auto_merge_allowed"`
+	DeleteBranchOnMerge bool     `json:"delete_branch_on_merge"` // the repo deletes head branches itself
+}
+
+// RequiredChecks returns the checks branch protection requires.
+func (s MergeStatus) RequiredChecks() []Check {
+	var out []Check
+	for _, c := range s.Checks {
+		if c.Required {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Blocker returns why the PR cannot be merged now, or "" if it can.
+func (s MergeStatus) Blocker() string {
+	hard, waiting := s.blockers()
+	if hard != "" {
+		return hard
+	}
+	return waiting
+}
+
+// CanAutoMerge reports whether auto-merge can be enabled: the repo allows
+// it, and the PR only waits for required checks to finish or for an
+// approving review.
+func (s MergeStatus) CanAutoMerge() bool {
+	hard, waiting := s.blockers()
+	return s.AutoMergeAllowed && hard == "" && waiting != ""
+}
+
+// blockers returns why the PR cannot be merged now. hard reasons need
+// someone to act; waiting is set when the PR only waits for something
+// auto-merge can wait out.
+func (s MergeStatus) blockers() (hard, waiting string) {
+	switch {
+	case s.State == PRStateMerged:
+		return "the PR is already merged", ""
+	case s.State == PRStateClosed:
+		return "the PR is closed", ""
+	case s.Draft:
+		return "the PR is a draft; mark it ready for review first", ""
+	case s.Mergeable == MergeableConflicting:
+		return "the branch has conflicts that must be resolved", ""
+	case s.Behind:
+		return "the branch is out of date with the base branch; update it first", ""
+	case !s.Blocked:
+		return "", ""
+	case s.Review == ReviewChangesRequested:
+		return "a reviewer requested changes", ""
+	}
+
+	var failing, pending []string
+	for _, c := range s.RequiredChecks() {
+		switch c.Status {
+		case CIFail:
+			failing = append(failing, c.Name)
+		case CIPending:
+			pending = append(pending, c.Name)
+		}
+	}
+	switch {
+	case len(failing) > 0:
+		return "required checks failed: " + strings.Join(failing, ", "), ""
+	case len(pending) > 0:
+		return "", "required checks are still running: " + strings.Join(pending, ", ")
+	case s.Review == ReviewPending:
+		return "", "an approving review is required"
+	}
+	return "branch protection rules block merging", ""
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeStatusBlocker(t *testing.T) {
+	open := MergeStatus{State: PRStateOpen, Mergeable: MergeableClean, AutoMergeAllowed: true}
+	with := func(f func(*MergeStatus)) MergeStatus {
+		s := open
+		f(&s)
+		return s
+	}
+
+	tests := []struct {
+		name    string
+		status  MergeStatus
+		blocker string
+		auto    bool
+	}{
+		{"clean", open, "", false},
+		{"unknown mergeability", with(func(s *MergeStatus) { s.Mergeable = MergeableUnknown }), "", false},
+		{"merged", with(func(s *MergeStatus) { s.State = PRStateMerged }), "the PR is already merged", false},
+		{"draft", with(func(s *MergeStatus) { s.Draft = true }), "the PR is a draft; mark it ready for review first", false},
+		{"conflicts", with(func(s *MergeStatus) { s.Mergeable = MergeableConflicting }), "the branch has conflicts that must be resolved", false},
+		{"behind", with(func(s *MergeStatus) { s.Behind = true }), "the branch is out of date with the base branch; update it first", false},
+		{"changes requested", with(func(s *MergeStatus) {
+			s.Blocked, s.Review = true, ReviewChangesRequested
+		}), "a reviewer requested changes", false},
+		{"failing required check", with(func(s *MergeStatus) {
+			s.Blocked = true
+			s.Checks = []Check{{Name: "lint", Status: CIFail}, {Name: "test", Status: CIFail, Required: true}}
+		}), "required checks failed: test", false},
+		{"pending required check", with(func(s *MergeStatus) {
+			s.Blocked = true
+			s.Checks = []Check{{Name: "test", Status: CIPending, Required: true}}
+		}), "required checks are still running: test", true},
+		{"review required", with(func(s *MergeStatus) {
+			s.Blocked, s.Review = true, ReviewPending
+		}), "an approving review is required", true},
+		{"auto-merge disabled", with(func(s *MergeStatus) {
+			s.Blocked, s.Review, s.AutoMergeAllowed = true, ReviewPending, false
+		}), "an approving review is required", false},
+		{"other protection", with(func(s *MergeStatus) { s.Blocked = true }), "branch protection rules block merging", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.blocker, tt.status.Blocker())
+			assert.Equal(t, tt.auto, tt.status.CanAutoMerge())
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+)
+
+// Label is a label defined in a repo.
+type Label struct {
+	Name        string `json:"name"`
+	Color       string `json:"color,omitempty"` // hex without '#'
+	Description string `json:"description,omitempty"`
+}
+
+// MetadataOptions are the values a PR's labels, assignees and reviewers can
+// be set to.
+type MetadataOptions struct {
+	Labels []Label  `json:"labels"`
+	Users  []string `json:"users"` // logins that can be assigned or requested to review
+	Teams  []string `json:"teams"` // "org/slug" of teams that can be requested to review
+}
+
+// MetadataEdit is a change to a PR's labels, assignees and requested
+// reviewers. Team reviewers are given as "org/slug".
+type MetadataEdit struct {
+	AddLabels       []string `json:"add_labels,omitempty"`
+	RemoveLabels    []string `json:"remove_labels,omitempty"`
+	AddAssignees    []string `json:"add_assignees,omitempty"`
+	RemoveAssignees []string `json:"remove_assignees,omitempty"`
+	AddReviewers    []string `json:"add_reviewers,omitempty"`
+	RemoveReviewers []string `json:"remove_reviewers,omitempty"`
+}
+
+// IsTeam reports whether a reviewer names a team ("org/slug") rather than
+// a user.
+func IsTeam(reviewer string) bool { return strings.Contains(reviewer, "/") }
+
+// Empty reports whether the edit changes nothing.
+func (e MetadataEdit) Empty() bool {
+	return len(e.AddLabels)+len(e.RemoveLabels)+len(e.AddAssignees)+
+		len(e.RemoveAssignees)+len(e.AddReviewers)+len(e.RemoveReviewers) == 0
+}
+
+// ApplyLabels returns labels with the edit's label changes applied.
+func (e MetadataEdit) ApplyLabels(labels []string) []string {
+	return applySet(labels, e.AddLabels, e.RemoveLabels)
+}
+
+// Apply applies the edit to d, as the host will once it succeeds. Added
+// reviewers are pending; removing a reviewer withdraws only a pending
+// request, since submitted reviews stay.
+func (e MetadataEdit) Apply(d *PRDetail) {
+	d.Labels = e.ApplyLabels(d.Labels)
+	d.Assignees = applySet(d.Assignees, e.AddAssignees, e.RemoveAssignees)
+	reviewers := slices.DeleteFunc(slices.Clone(d.Reviewers), func(r ReviewerInfo) bool {
+		return r.State == ReviewPending && slices.Contains(e.RemoveReviewers, r.Login)
+	})
+	for _, login := range e.AddReviewers {
+		if !slices.ContainsFunc(reviewers, func(r ReviewerInfo) bool {
+			return r.Login == login && r.State == ReviewPending
+		}) {
+			reviewers = append(reviewers, ReviewerInfo{Login: login, State: ReviewPending})
+		}
+	}
+	d.Reviewers = reviewers
+}
+
+// RequestedReviewers returns the reviewers whose review is still pending.
+func (d PRDetail) RequestedReviewers() []string {
+	var out []string
+	for _, r := range d.Reviewers {
+		if r.State == ReviewPending {
+			out = append(out, r.Login)
+		}
+	}
+	return out
+}
+
+// applySet returns values without remove and with add appended, keeping
+// order and skipping duplicates.
+func applySet(values, add, remove []string) []string {
+	out := make([]string, 0, len(values)+len(add))
+	for _, v := range values {
+		if !slices.Contains(remove, v) {
+			out = append(out, v)
+		}
+	}
+	for _, v := range add {
+		if !slices.Contains(out, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetadataEditEmpty(t *testing.T) {
+	assert.True(t, MetadataEdit{}.Empty())
+	assert.False(t, MetadataEdit{RemoveReviewers: []string{"org/core"}}.Empty())
+}
+
+func TestIsTeam(t *testing.T) {
+	assert.True(t, IsTeam("acme/core"))
+	assert.False(t, IsTeam("alice"))
+}
+
+func TestMetadataEditApply(t *testing.T) {
+	d := PRDetail{
+		PR:        PR{Labels: []string{"bug", "wip"}},
+		Assignees: []string{"alice"},
+		Reviewers: []ReviewerInfo{
+			{Login: "bob", State: ReviewPending},
+			{Login: "carol", State: ReviewApproved},
+		},
+	}
+	edit := MetadataEdit{
+		AddLabels:       []string{"ready", "bug"},
+		RemoveLabels:    []string{"wip"},
+		AddAssignees:    []string{"dave"},
+		RemoveAssignees: []string{"alice"},
+		AddReviewers:    []string{"acme/core", "carol"},
+		RemoveReviewers: []string{"bob", "carol"},
+	}
+	edit.Apply(&d)
+
+	assert.Equal(t, []string{"bug", "ready"}, d.Labels)
+	assert.Equal(t, []string{"dave"}, d.Assignees)
+	assert.Equal(t, []ReviewerInfo{
+		{Login: "carol", State: ReviewApproved}, // submitted reviews stay
+		{Login: "acme/core", State: ReviewPending},
+		{Login: "carol", State: ReviewPending}, // re-requested
+	}, d.Reviewers)
+	assert.Equal(t, []string{"acme/core", "carol"}, d.RequestedReviewers())
+}
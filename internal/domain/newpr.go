@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// NewPR is what a pull request is opened with. Team reviewers are given as
+// "org/slug".
+type NewPR struct {
+	Base      string   `json:"base"`
+	Head      string   `json:"head"`
+	Title     string   `json:"title"`
+	Body      string   `json:"body"`
+	Draft     bool     `json:"draft"`
+	Reviewers []string `json:"reviewers,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+}
+
+// RepoBranches lists a repo's branches and names the default one.
+type RepoBranches struct {
+	Default string   `json:"default"`
+	Names   []string `json:"names"`
+}
+
+// BranchComparison is what a PR from Head into Base would contain, as seen
+// from a local clone.
+type BranchComparison struct {
+	Base    string   `json:"base"`
+	Head    string   `json:"head"`
+	Commits []Commit `json:"commits"` // oldest first
+	Diff    Diff     `json:"diff"`
+	// Pushed is set when the host already has Head at the local commit, so
+	// the PR can be opened without pushing first.
+	Pushed bool `json:"pushed"`
+}
+
+// SuggestedTitle is the title a PR from the branch starts with: the commit
+// title for a single commit, or else the humanized branch name.
+func (c BranchComparison) SuggestedTitle() string {
+	if len(c.Commits) == 1 {
+		return c.Commits[0].Title
+	}
+	name := strings.NewReplacer("-", " ", "_", " ").Replace(path.Base(c.Head))
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToUpper(r)) + name[size:]
+}
+
+// SuggestedBody is the body a PR from the branch starts with: the commit
+// body for a single commit, or else a list of the commit titles, followed
+// by the repo's PR template if it has one.
+func (c BranchComparison) SuggestedBody(template string) string {
+	var parts []string
+	switch {
+	case len(c.Commits) == 1 && c.Commits[0].Body != "":
+		parts = append(parts, c.Commits[0].Body)
+	case len(c.Commits) > 1:
+		titles := make([]string, len(c.Commits))
+		for i, commit := range c.Commits {
+			titles[i] = "- " + commit.Title
+		}
+		parts = append(parts, strings.Join(titles, "\n"))
+	}
+	if t := strings.TrimSpace(template); t != "" {
+		parts = append(parts, t)
+	}
+	return strings.Join(parts, "\n\n")
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBranchComparisonSuggestions(t *testing.T) {
+	one := BranchComparison{Head: "feat/login", Commits: []Commit{{Title: "Add login", Body: "Uses OAuth."}}}
+	assert.Equal(t, "Add login", one.SuggestedTitle())
+	assert.Equal(t, "Uses OAuth.", one.SuggestedBody(""))
+	assert.Equal(t, "Uses OAuth.\n\n## Checklist", one.SuggestedBody("## Checklist\n"))
+
+	many := BranchComparison{Head: "feat/add-oauth_login", Commits: []Commit{{Title: "Add client"}, {Title: "Wire it up"}}}
+	assert.Equal(t, "Add oauth login", many.SuggestedTitle())
+	assert.Equal(t, "- Add client\n- Wire it up", many.SuggestedBody("  "))
+
+	assert.Equal(t, "## Checklist", BranchComparison{Head: "x", Commits: []Commit{{Title: "x"}}}.SuggestedBody("## Checklist"))
+}
+
+func TestCommitShortSHA(t *testing.T) {
+	assert.Equal(t, "abc1234", Commit{SHA: "abc1234def"}.ShortSHA())
+	assert.Equal(t, "abc", Commit{SHA: "abc"}.ShortSHA())
+}
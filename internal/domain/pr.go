@@ -1,24 +1,111 @@
 package domain
 
 import (
+	"fmt"
+	"path"
+	"slices"
 	"strings"
 	"time"
 )
 
-// RepoRef identifies a GitHub repository.
+// DefaultHost is the host assumed when a RepoRef has no explicit Host.
+const DefaultHost = "github.com"
+
+// RepoRef identifies a repository. Host is empty for github.com and set for
+// GitHub Enterprise Server (or other self-hosted forge) instances.
 type RepoRef struct {
+	Host  string `json:"host,omitempty"`
 	Owner string `json:"owner"`
 	Name  string `json:"name"`
 }
 
-func (r RepoRef) String() string { return r.Owner + "/" + r.Name }
+// String returns the repo in the form accepted by gh --repo: "owner/name"
+// for github.com and "host/owner/name" for any other host.
+func (r RepoRef) String() string {
+	if r.IsDefaultHost() {
+		return r.FullName()
+	}
+	return r.Host + "/" + r.FullName()
+}
+
+// FullName returns "owner/name" without the host, as used in API paths.
+func (r RepoRef) FullName() string { return r.Owner + "/" + r.Name }
+
+// HostName returns the repo host, falling back to DefaultHost.
+func (r RepoRef) HostName() string {
+	if r.IsDefaultHost() {
+		return DefaultHost
+	}
+	return strings.ToLower(r.Host)
+}
+
+// IsDefaultHost reports whether the repo lives on github.com.
+func (r RepoRef) IsDefaultHost() bool {
+	return r.Host == "" || strings.EqualFold(r.Host, DefaultHost)
+}
+
+// Equal compares two RepoRefs case-insensitively (GitHub is
+// case-insensitive), treating an empty Host as github.com.
+func (r RepoRef) Equal(other RepoRef) bool {
+	return r.HostName() == other.HostName() &&
+		strings.EqualFold(r.Owner, other.Owner) &&
+		strings.EqualFold(r.Name, other.Name)
+}
+
+// Matches reports whether the repo matches pattern, which has one to
+// three slash-separated segments matched with path.Match against the repo's
+// host, owner and name, ignoring case: "ghe.example.com" matches every repo
+// on that host and "github.com/acme/*" every repo of one owner.
+func (r RepoRef) Matches(pattern string) bool {
+	pattern = strings.ToLower(pattern)
+	n := strings.Count(pattern, "/") + 1
+	if n > 3 {
+		return false
+	}
+	key := strings.ToLower(strings.Join([]string{r.HostName(), r.Owner, r.Name}[:n], "/"))
+	ok, err := path.Match(pattern, key)
+	return ok && err == nil
+}
 
 // SafeFilename returns a filesystem-safe string for use in file path
 // construction. It strips path separators and null bytes from Owner and
 // Name to prevent directory traversal attacks when building cache or
-// state file paths.
+// state file paths. Repos on a non-default host are prefixed with the
+// host so same-named repos on different instances never collide.
 func (r RepoRef) SafeFilename() string {
-	return sanitizePathComponent(r.Owner) + "_" + sanitizePathComponent(r.Name)
+	name := sanitizePathComponent(r.Owner) + "_" + sanitizePathComponent(r.Name)
+	if r.IsDefaultHost() {
+		return name
+	}
+	return sanitizePathComponent(r.HostName()) + "_" + name
+}
+
+// ParseRepoRef parses "owner/name" or "host/owner/name". A leading segment
+// is treated as a host when it looks like one (contains a dot or port, or
+// is "localhost"); otherwise extra segments belong to a nested namespace
+// (GitLab group/subgroup/project) and the owner is everything before the
+// last slash.
+func ParseRepoRef(value string) (RepoRef, error) {
+	parts := strings.Split(strings.TrimSpace(value), "/")
+	if len(parts) < 2 || slices.Contains(parts, "") {
+		return RepoRef{}, fmt.Errorf("expected [host/]owner/name, got %q", value)
+	}
+	var ref RepoRef
+	if len(parts) >= 3 && looksLikeHost(parts[0]) {
+		ref.Host = strings.ToLower(parts[0])
+		if ref.IsDefaultHost() {
+			ref.Host = ""
+		}
+		parts = parts[1:]
+	}
+	last := len(parts) - 1
+	ref.Owner = strings.Join(parts[:last], "/")
+	ref.Name = parts[last]
+	return ref, nil
+}
+
+func looksLikeHost(s string) bool {
+	return strings.ContainsAny(s, ".:") || strings.EqualFold(s, "localhost")
 }
 
 // sanitizePathComponent removes characters that could cause path traversal.
@@ -126,6 +213,7 @@ type Check struct {
 	Status   CIStatus      `json:"status"`
 	Duration time.Duration `json:"duration"`
 	URL      string        `json:"url"`
+	Required bool          `json:"required,omitempty"` // branch protection requires it to pass
 }
 
 // FileChange represents a file changed in a PR.
@@ -21,6 +21,75 @@ func TestRepoRefString(t *testing.T) {
 	}
 }
 
+func TestRepoRefStringWithHost(t *testing.T) {
+	ghes := RepoRef{Host: "ghe.example.com", Owner: "org", Name: "repo"}
+	assert.Equal(t, "ghe.example.com/org/repo", ghes.String())
+	assert.Equal(t, "org/repo", ghes.FullName())
+	assert.Equal(t, "ghe.example.com", ghes.HostName())
+	assert.False(t, ghes.IsDefaultHost())
+
+	explicit := RepoRef{Host: "GitHub.com", Owner: "org", Name: "repo"}
+	assert.Equal(t, "org/repo", explicit.String())
+	assert.Equal(t, DefaultHost, explicit.HostName())
+	assert.True(t, explicit.IsDefaultHost())
+}
+
+func TestRepoRefEqual(t *testing.T) {
+	base := RepoRef{Owner: "Org", Name: "Repo"}
+	assert.True(t, base.Equal(RepoRef{Owner: "org", Name: "repo"}))
+	assert.True(t, base.Equal(RepoRef{Host: "github.com", Owner: "org", Name: "repo"}))
+	assert.False(t, base.Equal(RepoRef{Host: "ghe.example.com", Owner: "org", Name: "repo"}))
+	assert.True(t, RepoRef{Host: "GHE.example.com", Owner: "o", Name: "r"}.
+		Equal(RepoRef{Host: "ghe.example.com", Owner: "o", Name: "r"}))
+}
+
+func TestRepoRefSafeFilename(t *testing.T) {
+	tests := []struct {
+		ref  RepoRef
+		want string
+	}{
+		{RepoRef{Owner: "octocat", Name: "hello"}, "octocat_hello"},
+		{RepoRef{Host: "github.com", Owner: "octocat", Name: "hello"}, "octocat_hello"},
+		{RepoRef{Host: "ghe.example.com", Owner: "octocat", Name: "hello"}, "ghe.example.com_octocat_hello"},
+		{RepoRef{Owner: "../evil", Name: "x/y"}, "__evil_x_y"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.ref.SafeFilename())
+	}
+}
+
+func TestParseRepoRef(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    RepoRef
+		wantErr bool
+	}{
+		{in: "octocat/hello", want: RepoRef{Owner: "octocat", Name: "hello"}},
+		{in: " octocat/hello ", want: RepoRef{Owner: "octocat", Name: "hello"}},
+		{in: "ghe.example.com/org/repo", want: RepoRef{Host: "ghe.example.com", Owner: "org", Name: "repo"}},
+		{in: "GHE.Example.com/org/repo", want: RepoRef{Host: "ghe.example.com", Owner: "org", Name: "repo"}},
+		{in: "github.com/org/repo", want: RepoRef{Owner: "org", Name: "repo"}},
+		{in: "localhost:3000/org/repo", want: RepoRef{Host: "localhost:3000", Owner: "org", Name: "repo"}},
+		{in: "group/sub/project", want: RepoRef{Owner: "group/sub", Name: "project"}},
+		{in: "gitlab.example.com/group/sub/project", want: RepoRef{Host: "gitlab.example.com", Owner: "group/sub", Name: "project"}},
+		{in: "octocat", wantErr: true},
+		{in: "octocat/", wantErr: true},
+		{in: "group//project", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseRepoRef(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestPRStateString(t *testing.T) {
 	tests := []struct {
 		s    PRState
@@ -68,3 +137,14 @@ func TestReviewStateString(t *testing.T) {
 		assert.Equal(t, tt.want, got)
 	}
 }
+
+func TestRepoRefMatches(t *testing.T) {
+	repo := RepoRef{Owner: "Acme", Name: "api"}
+	assert.True(t, repo.Matches("github.com"))
+	assert.True(t, repo.Matches("github.com/acme/*"))
+	assert.True(t, repo.Matches("*/acme/api"))
+	assert.False(t, repo.Matches("github.com/other"))
+	assert.False(t, repo.Matches("ghe.example.com"))
+	assert.False(t, repo.Matches("a/b/c/d"))
+	assert.False(t, repo.Matches("["), "malformed patterns never match")
+}
@@ -29,6 +29,29 @@ type ListOpts struct {
 	SortDesc bool        `json:"sort_desc,omitempty"`
 	Page     int         `json:"page,omitempty"`
 	PerPage  int         `json:"per_page,omitempty"`
+	// Cursor resumes a listing where a previous PRPage ended. Readers that
+	// paginate with cursors ignore Page when it is set.
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// PRPage is one page of a cursor-paginated PR listing.
+type PRPage struct {
+	PRs        []PR
+	NextCursor string // opaque; pass back in ListOpts.Cursor for the next page
+	HasMore    bool
+}
+
+// RateLimit is a snapshot of a host's API request budget.
+type RateLimit struct {
+	Resource  string    `json:"resource"` // e.g. "core", "graphql"
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+}
+
+// Low reports whether less than a tenth of the budget remains.
+func (r RateLimit) Low() bool {
+	return r.Limit > 0 && r.Remaining*10 < r.Limit
 }
 
 // DraftFilter controls how draft PRs are included in results.
@@ -40,13 +63,26 @@ const (
 	DraftOnly    DraftFilter = "only"
 )
 
-// MergeOpts controls PR merge behavior (post-MVP).
+// MergeOpts controls PR merge behavior.
 type MergeOpts struct {
-	Method        string `json:"method"` // "merge", "squash", "rebase"
-	DeleteBranch  bool   `json:"delete_branch"`
+	Method       string `json:"method"` // MergeMethodMerge, MergeMethodSquash or MergeMethodRebase
+	DeleteBranch bool   `json:"delete_branch"`
+	// CommitTitle and CommitMessage override the merge or squash commit's
+	// title and body; empty keeps the host's default. Rebase ignores both.
+	CommitTitle   string `json:"commit_title,omitempty"`
 	CommitMessage string `json:"commit_message,omitempty"`
+	// Auto enables auto-merge instead of merging now: the PR merges once
+	// its required checks and reviews pass.
+	Auto bool `json:"auto,omitempty"`
 }
 
+// Merge methods.
+const (
+	MergeMethodMerge  = "merge"
+	MergeMethodSquash = "squash"
+	MergeMethodRebase = "rebase"
+)
+
 // ToastLevel indicates the severity of a toast notification.
 type ToastLevel string
 
@@ -9,17 +9,91 @@ type CommentThread struct {
 	ReplyToID string    `json:"reply_to_id,omitempty"` // Root review comment database ID used for replies.
 	Path      string    `json:"path"`
 	Line      int       `json:"line"`
+	StartLine int       `json:"start_line,omitempty"` // First line of a multi-line thread; 0 for one line.
 	Resolved  bool      `json:"resolved"`
 	Comments  []Comment `json:"comments"`
 }
 
 // Comment represents a single comment within a thread.
 type Comment struct {
-	ID        string    `json:"id"`
-	Author    string    `json:"author"`
-	Body      string    `json:"body"`
-	CreatedAt time.Time `json:"created_at"`
-	URL       string    `json:"url,omitempty"`
+	ID        string     `json:"id"`
+	NodeID    string     `json:"node_id,omitempty"` // GraphQL node ID, used to edit, delete and react.
+	Author    string     `json:"author"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"created_at"`
+	URL       string     `json:"url,omitempty"`
+	CanEdit   bool       `json:"can_edit,omitempty"`   // The viewer may edit the comment.
+	CanDelete bool       `json:"can_delete,omitempty"` // The viewer may delete the comment.
+	Reactions []Reaction `json:"reactions,omitempty"`  // Only reactions someone has left.
+}
+
+// Reaction counts the people who reacted to a comment with one emoji.
+type Reaction struct {
+	Content       ReactionContent `json:"content"`
+	Count         int             `json:"count"`
+	ViewerReacted bool            `json:"viewer_reacted,omitempty"`
+}
+
+// Reaction returns the comment's reaction with content, if anyone left it.
+func (c Comment) Reaction(content ReactionContent) (Reaction, bool) {
+	for _, r := range c.Reactions {
+		if r.Content == content {
+			return r, true
+		}
+	}
+	return Reaction{}, false
+}
+
+// ReactionContent is an emoji reaction, named as in GitHub's API.
+type ReactionContent string
+
+const (
+	ReactionThumbsUp   ReactionContent = "THUMBS_UP"
+	ReactionThumbsDown ReactionContent = "THUMBS_DOWN"
+	ReactionLaugh      ReactionContent = "LAUGH"
+	ReactionHooray     ReactionContent = "HOORAY"
+	ReactionConfused   ReactionContent = "CONFUSED"
+	ReactionHeart      ReactionContent = "HEART"
+	ReactionRocket     ReactionContent = "ROCKET"
+	ReactionEyes       ReactionContent = "EYES"
+)
+
+// ReactionContents lists every reaction in the order GitHub shows them.
+var ReactionContents = []ReactionContent{
+	ReactionThumbsUp, ReactionThumbsDown, ReactionLaugh, ReactionHooray,
+	ReactionConfused, ReactionHeart, ReactionRocket, ReactionEyes,
+}
+
+// Emoji returns the emoji for the reaction.
+func (r ReactionContent) Emoji() string {
+	switch r {
+	case ReactionThumbsUp:
+		return "👍"
+	case ReactionThumbsDown:
+		return "👎"
+	case ReactionLaugh:
+		return "😄"
+	case ReactionHooray:
+		return "🎉"
+	case ReactionConfused:
+		return "😕"
+	case ReactionHeart:
+		return "❤️"
+	case ReactionRocket:
+		return "🚀"
+	case ReactionEyes:
+		return "👀"
+	default:
+		return string(r)
+	}
+}
+
+// CommentRef identifies a comment to edit, delete or react to. Kind tells
+// adapters which kind of comment NodeID names: a timeline comment, a review
+// body or a comment in an inline thread.
+type CommentRef struct {
+	NodeID string         `json:"node_id"`
+	Kind   DiscussionKind `json:"kind"`
 }
 
 // DiscussionKind identifies the source/type of a PR discussion item.
@@ -48,19 +122,31 @@ type DiscussionItem struct {
 }
 
 // InlineCommentInput is used when creating a new inline comment on a diff line.
+// A comment on a range of lines sets StartLine and StartSide to the first
+// line; Line and Side are always the last.
 type InlineCommentInput struct {
 	Path      string `json:"path"`                  // File path relative to repo root
 	Line      int    `json:"line"`                  // Line number in the diff
 	Side      string `json:"side"`                  // "LEFT" or "RIGHT"
+	StartLine int    `json:"start_line,omitempty"`  // First line of a range
+	StartSide string `json:"start_side,omitempty"`  // "LEFT" or "RIGHT"
 	Body      string `json:"body"`                  // Comment body (markdown)
 	CommitID  string `json:"commit_id"`             // SHA of the commit to comment on
 	InReplyTo string `json:"in_reply_to,omitempty"` // Thread ID if replying
 }
 
+// IsRange reports whether the comment spans more than one line.
+func (c InlineCommentInput) IsRange() bool {
+	return c.StartLine > 0 && (c.StartLine != c.Line || c.StartSide != c.Side)
+}
+
 // Review represents a review submission.
 type Review struct {
 	Action ReviewAction `json:"action"`
 	Body   string       `json:"body"`
+	// Comments are new inline comments submitted with the review. Replies
+	// are not part of a review; they are posted with AddComment.
+	Comments []InlineCommentInput `json:"comments,omitempty"`
 }
 
 // ReviewAction represents the type of review being submitted.
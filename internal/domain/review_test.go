@@ -20,3 +20,17 @@ func TestReviewActionString(t *testing.T) {
 		assert.Equal(t, tt.want, got)
 	}
 }
+
+func TestCommentReaction(t *testing.T) {
+	c := Comment{Reactions: []Reaction{{Content: ReactionRocket, Count: 2, ViewerReacted: true}}}
+	r, ok := c.Reaction(ReactionRocket)
+	assert.True(t, ok)
+	assert.Equal(t, 2, r.Count)
+	_, ok = c.Reaction(ReactionEyes)
+	assert.False(t, ok)
+
+	for _, content := range ReactionContents {
+		assert.NotEqual(t, string(content), content.Emoji(), "every reaction has an emoji")
+	}
+	assert.Equal(t, "SHRUG", ReactionContent("SHRUG").Emoji())
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Snippet is a canned response that can be inserted into comments and
+// review bodies.
+type Snippet struct {
+	Name string `json:"name"`
+	Body string `json:"body"`
+}
+
+// SnippetVars holds the values for a snippet's placeholders.
+type SnippetVars struct {
+	Author string // PR author
+	Number int    // PR number
+	File   string // file being commented on
+	Line   int    // line being commented on
+}
+
+// Expand fills the {{author}}, {{number}}, {{file}} and {{line}}
+// placeholders in body. A placeholder without a value, such as {{file}} in
+// a review body, expands to nothing; other text is left alone.
+func (v SnippetVars) Expand(body string) string {
+	itoa := func(n int) string {
+		if n == 0 {
+			return ""
+		}
+		return strconv.Itoa(n)
+	}
+	return strings.NewReplacer(
+		"{{author}}", v.Author,
+		"{{number}}", itoa(v.Number),
+		"{{file}}", v.File,
+		"{{line}}", itoa(v.Line),
+	).Replace(body)
+}
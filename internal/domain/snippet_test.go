@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnippetVarsExpand(t *testing.T) {
+	vars := SnippetVars{Author: "octocat", Number: 42, File: "main.go", Line: 7}
+	assert.Equal(t, "Thanks @octocat for #42!", vars.Expand("Thanks @{{author}} for #{{number}}!"))
+	assert.Equal(t, "See main.go:7", vars.Expand("See {{file}}:{{line}}"))
+	assert.Equal(t, "{{other}} stays", vars.Expand("{{other}} stays"))
+
+	review := SnippetVars{Author: "octocat", Number: 42}
+	assert.Equal(t, "at :", review.Expand("at {{file}}:{{line}}"), "unset placeholders expand to nothing")
+}
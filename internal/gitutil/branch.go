@@ -0,0 +1,72 @@
+package gitutil
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// CompareBranch compares head with origin's base in the clone at dir:
+// the commits head adds, their diff, and whether origin has head at the
+// same commit. Both branches are fetched first; a failed fetch falls back
+// to what the clone already knows.
+func CompareBranch(ctx context.Context, dir, base, head string) (*domain.BranchComparison, error) {
+	_, _ = Run(ctx, dir, "fetch", "origin", base)
+	_, _ = Run(ctx, dir, "fetch", "origin", head)
+
+	baseRef := "refs/remotes/origin/" + base
+	if _, err := Run(ctx, dir, "rev-parse", "--verify", "--quiet", baseRef); err != nil {
+		return nil, fmt.Errorf("base branch %s not found on origin", base)
+	}
+	log, err := Run(ctx, dir, "log", "--reverse", "--format=%H%x1f%an%x1f%aI%x1f%B%x1e", baseRef+".."+head)
+	if err != nil {
+		return nil, fmt.Errorf("listing commits of %s: %w", head, err)
+	}
+	raw, err := Run(ctx, dir, "diff", baseRef+"..."+head)
+	if err != nil {
+		return nil, fmt.Errorf("diffing %s against %s: %w", head, base, err)
+	}
+
+	c := &domain.BranchComparison{Base: base, Head: head, Commits: parseCommitLog(log), Diff: domain.ParseDiff(raw)}
+	local, err := Run(ctx, dir, "rev-parse", head)
+	if err != nil {
+		return nil, fmt.Errorf("resolving %s: %w", head, err)
+	}
+	remote, err := Run(ctx, dir, "rev-parse", "--verify", "--quiet", "refs/remotes/origin/"+head)
+	c.Pushed = err == nil && remote == local
+	return c, nil
+}
+
+// PushBranch pushes branch from the clone at dir to origin and sets
+// it as the branch's upstream.
+func PushBranch(ctx context.Context, dir, branch string) error {
+	if _, err := Run(ctx, dir, "push", "--set-upstream", "origin", branch); err != nil {
+		return fmt.Errorf("pushing %s: %w", branch, err)
+	}
+	return nil
+}
+
+// parseCommitLog reads git log output in the format written by
+// CompareBranch: records separated by 0x1e, fields by 0x1f.
+func parseCommitLog(log string) []domain.Commit {
+	var commits []domain.Commit
+	for _, rec := range strings.Split(log, "\x1e") {
+		fields := strings.SplitN(strings.TrimSpace(rec), "\x1f", 4)
+		if len(fields) != 4 {
+			continue
+		}
+		title, body, _ := strings.Cut(strings.TrimSpace(fields[3]), "\n")
+		date, _ := time.Parse(time.RFC3339, fields[2])
+		commits = append(commits, domain.Commit{
+			SHA:    fields[0],
+			Author: fields[1],
+			Date:   date,
+			Title:  title,
+			Body:   strings.TrimSpace(body),
+		})
+	}
+	return commits
+}
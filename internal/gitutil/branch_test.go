@@ -0,0 +1,25 @@
+package gitutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+func TestParseCommitLog(t *testing.T) {
+	log := "aaa\x1fAlice\x1f2026-01-02T03:04:05Z\x1fAdd x\n\nBecause.\n\x1e\n" +
+		"bbb\x1fBob\x1f2026-01-03T00:00:00+02:00\x1fFix y\n\x1e"
+	commits := parseCommitLog(log)
+	require.Len(t, commits, 2)
+	assert.Equal(t, domain.Commit{
+		SHA: "aaa", Author: "Alice", Title: "Add x", Body: "Because.",
+		Date: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}, commits[0])
+	assert.Equal(t, "Fix y", commits[1].Title)
+	assert.Empty(t, commits[1].Body)
+	assert.Empty(t, parseCommitLog(""))
+}
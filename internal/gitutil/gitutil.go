@@ -0,0 +1,94 @@
+// Package gitutil runs the local git operations shared by the backends:
+// checking out a fetched PR ref, cloning, adding worktrees, and comparing
+// and pushing the branches of a new PR.
+package gitutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Run runs a git command in dir (or the CWD when dir is "") and returns its
+// trimmed output. Failures include git's stderr in the error.
+func Run(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("%s: %w", strings.TrimSpace(string(exitErr.Stderr)), err)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// CheckoutRef fetches ref from origin and checks it out as the local branch
+// named branch, in dir (or the CWD when dir is ""). An existing local branch
+// is fast-forwarded rather than reset.
+func CheckoutRef(ctx context.Context, dir, ref, branch string) error {
+	if _, err := Run(ctx, dir, "fetch", "origin", ref); err != nil {
+		return err
+	}
+
+	if _, err := Run(ctx, dir, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch); err == nil {
+		if _, err := Run(ctx, dir, "checkout", branch); err != nil {
+			return err
+		}
+		if _, err := Run(ctx, dir, "merge", "--ff-only", "FETCH_HEAD"); err != nil {
+			return fmt.Errorf("updating branch %s: %w", branch, err)
+		}
+		return nil
+	}
+
+	_, err := Run(ctx, dir, "checkout", "-b", branch, "FETCH_HEAD")
+	return err
+}
+
+// Clone clones url to targetPath. If targetPath is already a clone, it
+// fetches instead; a directory there that is not a clone is replaced.
+func Clone(ctx context.Context, url, targetPath string) error {
+	if info, err := os.Stat(filepath.Join(targetPath, ".git")); err == nil && info.IsDir() {
+		if _, err := Run(ctx, "", "-C", targetPath, "fetch", "--all"); err != nil {
+			return fmt.Errorf("fetching in existing clone: %w", err)
+		}
+		return nil
+	}
+
+	// If target exists but is corrupted (no .git), remove it.
+	if _, err := os.Stat(targetPath); err == nil {
+		if err := os.RemoveAll(targetPath); err != nil {
+			return fmt.Errorf("removing corrupted clone: %w", err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0o755); err != nil {
+		return fmt.Errorf("creating parent dir: %w", err)
+	}
+
+	if _, err := Run(ctx, "", "clone", url, targetPath); err != nil {
+		_ = os.RemoveAll(targetPath)
+		return err
+	}
+	return nil
+}
+
+// AddWorktree fetches ref from origin into the local branch localBranch of
+// the clone at repoPath and checks it out in a new worktree at
+// worktreePath. The branch is removed again if the worktree cannot be added.
+func AddWorktree(ctx context.Context, repoPath, ref, localBranch, worktreePath string) error {
+	if _, err := Run(ctx, "", "-C", repoPath, "fetch", "origin", ref+":"+localBranch); err != nil {
+		return fmt.Errorf("fetching %s: %w", ref, err)
+	}
+	if _, err := Run(ctx, "", "-C", repoPath, "worktree", "add", worktreePath, localBranch); err != nil {
+		_, _ = Run(ctx, "", "-C", repoPath, "worktree", "remove", worktreePath)
+		_, _ = Run(ctx, "", "-C", repoPath, "branch", "-D", localBranch)
+		return fmt.Errorf("creating worktree: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package gitutil
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newOrigin creates a repo with one commit on main and the ref
+// refs/pull/1/head pointing at a second commit.
+func newOrigin(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	git := func(args ...string) string {
+		out, err := Run(context.Background(), dir, args...)
+		require.NoError(t, err)
+		return out
+	}
+	git("init", "-q", "-b", "main")
+	git("config", "user.email", "test@example.com")
+	git("config", "user.name", "Test")
+	git("commit", "-q", "--allow-empty", "-m", "base")
+	git("checkout", "-q", "-b", "feature")
+	git("commit", "-q", "--allow-empty", "-m", "change")
+	git("update-ref", "refs/pull/1/head", git("rev-parse", "HEAD"))
+	git("checkout", "-q", "main")
+	return dir
+}
+
+func TestRunIncludesStderr(t *testing.T) {
+	_, err := Run(context.Background(), t.TempDir(), "rev-parse", "--verify", "no-such-ref")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not a git repository")
+}
+
+func TestCloneCheckoutAndWorktree(t *testing.T) {
+	ctx := context.Background()
+	origin := newOrigin(t)
+	clone := filepath.Join(t.TempDir(), "nested", "clone")
+
+	require.NoError(t, Clone(ctx, origin, clone))
+	require.NoError(t, Clone(ctx, origin, clone), "an existing clone is fetched")
+
+	require.NoError(t, CheckoutRef(ctx, clone, "pull/1/head", "feature"))
+	branch, err := Run(ctx, clone, "branch", "--show-current")
+	require.NoError(t, err)
+	assert.Equal(t, "feature", branch)
+	require.NoError(t, CheckoutRef(ctx, clone, "pull/1/head", "feature"), "an existing branch is fast-forwarded")
+
+	worktree := filepath.Join(t.TempDir(), "wt")
+	require.NoError(t, AddWorktree(ctx, clone, "pull/1/head", "pr-1", worktree))
+	_, err = os.Stat(filepath.Join(worktree, ".git"))
+	assert.NoError(t, err)
+
+	err = AddWorktree(ctx, clone, "pull/1/head", "pr-2", worktree)
+	require.Error(t, err, "the worktree path is taken")
+	_, err = Run(ctx, clone, "rev-parse", "--verify", "--quiet", "refs/heads/pr-2")
+	assert.Error(t, err, "the fetched branch is removed again")
+}
+
+func TestCloneReplacesNonClone(t *testing.T) {
+	origin := newOrigin(t)
+	target := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(target, "stray"), nil, 0o600))
+
+	require.NoError(t, Clone(context.Background(), origin, target))
+	_, err := os.Stat(filepath.Join(target, "stray"))
+	assert.True(t, os.IsNotExist(err))
+}
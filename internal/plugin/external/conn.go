@@ -0,0 +1,232 @@
+package external
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/logging"
+)
+
+// maxLineSize bounds one JSON-RPC message, so a plugin cannot make the host
+// buffer without limit.
+const maxLineSize = 16 << 20
+
+// ErrCrashed is returned for calls to a plugin whose process exited or
+// broke the protocol. It matches domain.ErrUnavailable.
+var ErrCrashed = fmt.Errorf("plugin crashed: %w", domain.ErrUnavailable)
+
+// conn is one running plugin process.
+type conn struct {
+	name  string
+	cmd   *exec.Cmd
+	stdin *os.File
+
+	nextID   atomic.Int64
+	writeMu  sync.Mutex
+	mu       sync.Mutex
+	pending  map[int64]chan incoming
+	onNotify func(notifyParams)
+
+	done     chan struct{} // closed once the process is gone
+	failOnce sync.Once
+	err      error // why done was closed
+}
+
+// startConn starts the plugin at path. onNotify is called from the read
+// loop for each notify notification.
+func startConn(path string, onNotify func(notifyParams)) (*conn, error) {
+	stdinR, stdinW, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("starting plugin %s: %w", path, err)
+	}
+	defer stdinR.Close()
+
+	// The process is not tied to a context: it lives as long as the host.
+	cmd := exec.Command(path) //nolint:gosec // plugins are executables the user installed
+	cmd.Stdin = stdinR
+	cmd.Stderr = stderrLogger{path: path}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		_ = stdinW.Close()
+		return nil, fmt.Errorf("starting plugin %s: %w", path, err)
+	}
+	if err := cmd.Start(); err != nil {
+		_ = stdinW.Close()
+		return nil, fmt.Errorf("starting plugin %s: %w", path, err)
+	}
+
+	c := &conn{
+		name:     path,
+		cmd:      cmd,
+		stdin:    stdinW,
+		pending:  make(map[int64]chan incoming),
+		onNotify: onNotify,
+		done:     make(chan struct{}),
+	}
+	go c.readLoop(bufio.NewScanner(stdout))
+	return c, nil
+}
+
+// readLoop dispatches the plugin's output until it ends, then reaps the
+// process. Output that is not JSON-RPC kills the plugin: the stream cannot
+// be trusted after it.
+func (c *conn) readLoop(sc *bufio.Scanner) {
+	sc.Buffer(make([]byte, 64<<10), maxLineSize)
+	for sc.Scan() {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var msg incoming
+		if err := json.Unmarshal(line, &msg); err != nil {
+			c.fail(fmt.Errorf("%w: malformed output: %v", ErrCrashed, err))
+			break
+		}
+		switch {
+		case msg.Method == methodNotify:
+			var n notifyParams
+			if err := json.Unmarshal(msg.Params, &n); err == nil && c.onNotify != nil {
+				c.onNotify(n)
+			}
+		case msg.Method == "" && msg.ID != nil:
+			c.mu.Lock()
+			ch := c.pending[*msg.ID]
+			c.mu.Unlock()
+			select {
+			case ch <- msg:
+			default: // unknown or repeated id
+			}
+		default:
+			logging.Log.Debug("ignoring plugin message", "plugin", c.name, "method", msg.Method)
+		}
+	}
+	cause := sc.Err()
+	if cause == nil {
+		cause = errors.New("plugin closed its output")
+	}
+	c.fail(fmt.Errorf("%w: %v", ErrCrashed, cause))
+	if err := c.cmd.Wait(); err != nil {
+		logging.Log.Warn("plugin exited", "plugin", c.name, "err", err)
+	}
+}
+
+// fail marks the connection dead and kills the process.
+func (c *conn) fail(err error) {
+	c.failOnce.Do(func() {
+		c.err = err
+		close(c.done)
+		_ = c.stdin.Close()
+		_ = c.cmd.Process.Kill()
+	})
+}
+
+// alive reports whether the process is still usable.
+func (c *conn) alive() bool {
+	select {
+	case <-c.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// call sends a request and decodes its result into result, which may be
+// nil. It returns when the plugin answers, ctx ends, or the plugin dies.
+func (c *conn) call(ctx context.Context, method string, params, result any) error {
+	id := c.nextID.Add(1)
+	ch := make(chan incoming, 1)
+	c.mu.Lock()
+	c.pending[id] = ch
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+	}()
+
+	if err := c.write(ctx, message{JSONRPC: "2.0", ID: &id, Method: method, Params: params}); err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.done:
+		return c.err
+	case resp := <-ch:
+		if resp.Error != nil {
+			return resp.Error
+		}
+		if result == nil || len(resp.Result) == 0 {
+			return nil
+		}
+		if err := json.Unmarshal(resp.Result, result); err != nil {
+			return fmt.Errorf("decoding %s result: %w", method, err)
+		}
+		return nil
+	}
+}
+
+// write sends one message. A plugin that stops reading its input would
+// block the write forever, so it gets the call's deadline.
+func (c *conn) write(ctx context.Context, msg message) error {
+	line, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("encoding %s: %w", msg.Method, err)
+	}
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if !c.alive() {
+		return c.err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = c.stdin.SetWriteDeadline(deadline)
+	}
+	if _, err := c.stdin.Write(append(line, '\n')); err != nil {
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			return context.DeadlineExceeded
+		}
+		c.fail(fmt.Errorf("%w: %v", ErrCrashed, err))
+		return c.err
+	}
+	return nil
+}
+
+// close asks the plugin to shut down, then kills it if it has not exited
+// within grace.
+func (c *conn) close(grace time.Duration) {
+	if !c.alive() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), grace)
+	defer cancel()
+	_ = c.call(ctx, methodShutdown, nil, nil)
+	_ = c.stdin.Close()
+	select {
+	case <-c.done:
+	case <-ctx.Done():
+		c.fail(fmt.Errorf("%w: shut down", ErrCrashed))
+	}
+}
+
+// stderrLogger sends a plugin's stderr to the debug log.
+type stderrLogger struct{ path string }
+
+func (l stderrLogger) Write(p []byte) (int, error) {
+	for line := range bytes.Lines(p) {
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			logging.Log.Debug("plugin stderr", "plugin", l.path, "line", string(line))
+		}
+	}
+	return len(p), nil
+}
@@ -0,0 +1,231 @@
+package external
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/plugin"
+)
+
+// fakeModeEnv makes the test binary act as a plugin. The value picks how it
+// behaves.
+const fakeModeEnv = "VIVECAKA_FAKE_PLUGIN"
+
+func TestMain(m *testing.M) {
+	if mode := os.Getenv(fakeModeEnv); mode != "" {
+		fakePlugin(mode)
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// fakePlugin serves the protocol on stdio. In "crash" mode it exits on
+// pr.count, in "garbage" mode it answers pr.count with non-JSON, and in
+// "v2" mode it claims protocol version 2.
+func fakePlugin(mode string) {
+	in := bufio.NewScanner(os.Stdin)
+	out := json.NewEncoder(os.Stdout)
+	for in.Scan() {
+		var req struct {
+			ID     int64           `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if err := json.Unmarshal(in.Bytes(), &req); err != nil {
+			os.Exit(2)
+		}
+		resp := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+		switch req.Method {
+		case "initialize":
+			version := ProtocolVersion
+			if mode == "v2" {
+				version = 2
+			}
+			resp["result"] = map[string]any{
+				"protocol_version": version,
+				"name":             "fake",
+				"provides":         []string{"pr-reader"},
+				"hooks":            []string{"before_fetch", "before_render"},
+				"keys":             []map[string]string{{"key": "ctrl+k", "view": "pr_list", "action": "ping"}},
+			}
+		case "pr.count":
+			switch mode {
+			case "crash":
+				os.Exit(1)
+			case "garbage":
+				fmt.Println("not json")
+				continue
+			}
+			resp["result"] = 42
+		case "pr.get":
+			resp["error"] = map[string]any{"code": 404, "message": "no such PR"}
+		case "hook":
+			if mode == "crash" {
+				os.Exit(1)
+			}
+			resp["error"] = map[string]any{"code": 1, "message": "vetoed"}
+		case "key":
+			_ = out.Encode(map[string]any{"jsonrpc": "2.0", "method": "notify", "params": map[string]string{"message": "pinged"}})
+			resp["result"] = map[string]string{"message": "pong " + string(req.Params)}
+		case "shutdown":
+			_ = out.Encode(resp)
+			return
+		}
+		_ = out.Encode(resp)
+	}
+}
+
+// fakePath returns an executable that runs the test binary as a plugin in
+// mode.
+func fakePath(t *testing.T, mode string) string {
+	t.Helper()
+	t.Setenv(fakeModeEnv, mode)
+	return os.Args[0]
+}
+
+func loadFake(t *testing.T, mode string) *Plugin {
+	t.Helper()
+	p, err := Load(fakePath(t, mode), "test")
+	require.NoError(t, err)
+	t.Cleanup(p.Close)
+	return p
+}
+
+// recordingApp is an AppContext that records sent messages.
+type recordingApp struct {
+	mu   sync.Mutex
+	msgs []tea.Msg
+}
+
+func (a *recordingApp) ConfigValue(string) any { return nil }
+func (a *recordingApp) ThemeName() string      { return "" }
+func (a *recordingApp) CurrentRepo() domain.RepoRef {
+	return domain.RepoRef{Owner: "o", Name: "r"}
+}
+func (a *recordingApp) SendMessage(msg tea.Msg) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.msgs = append(a.msgs, msg)
+}
+
+func (a *recordingApp) sent() []tea.Msg {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]tea.Msg(nil), a.msgs...)
+}
+
+func TestLoadHandshake(t *testing.T) {
+	p := loadFake(t, "ok")
+	assert.Equal(t, plugin.PluginInfo{Name: "fake", Provides: []string{"pr-reader"}}, p.Info())
+
+	reader, ok := p.Capabilities().(domain.PRReader)
+	require.True(t, ok, "a pr-reader plugin is a domain.PRReader")
+	_, ok = p.Capabilities().(domain.PRReviewer)
+	assert.False(t, ok)
+
+	count, err := reader.GetPRCount(context.Background(), domain.RepoRef{Owner: "o", Name: "r"}, domain.PRStateOpen)
+	require.NoError(t, err)
+	assert.Equal(t, 42, count)
+}
+
+func TestLoadRejectsOtherProtocolVersion(t *testing.T) {
+	_, err := Load(fakePath(t, "v2"), "test")
+	require.ErrorContains(t, err, "speaks protocol 2")
+}
+
+func TestPluginErrorsMapToDomainErrors(t *testing.T) {
+	reader := loadFake(t, "ok").Capabilities().(domain.PRReader)
+	_, err := reader.GetPR(context.Background(), domain.RepoRef{Owner: "o", Name: "r"}, 1)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+	assert.ErrorContains(t, err, "no such PR")
+}
+
+func TestCrashedPluginRestartsThenDisables(t *testing.T) {
+	reader := loadFake(t, "crash").Capabilities().(domain.PRReader)
+	repo := domain.RepoRef{Owner: "o", Name: "r"}
+
+	// The first call crashes the initial process, each later one a restart.
+	for range maxRestarts + 1 {
+		_, err := reader.GetPRCount(context.Background(), repo, domain.PRStateOpen)
+		require.ErrorIs(t, err, ErrCrashed)
+	}
+	_, err := reader.GetPRCount(context.Background(), repo, domain.PRStateOpen)
+	require.ErrorIs(t, err, ErrDisabled)
+}
+
+func TestMalformedOutputKillsPlugin(t *testing.T) {
+	p := loadFake(t, "garbage")
+	reader := p.Capabilities().(domain.PRReader)
+	_, err := reader.GetPRCount(context.Background(), domain.RepoRef{}, domain.PRStateOpen)
+	require.ErrorIs(t, err, ErrCrashed)
+	assert.ErrorContains(t, err, "malformed output")
+}
+
+func TestHooks(t *testing.T) {
+	regs := loadFake(t, "ok").Hooks()
+	require.Len(t, regs, 1, "before_render is not offered to external plugins")
+	assert.Equal(t, plugin.HookBeforeFetch, regs[0].Point)
+
+	err := regs[0].Handler(context.Background(), plugin.FetchEvent{})
+	require.ErrorContains(t, err, "vetoed", "a plugin's own error cancels the fetch")
+
+	crashing := loadFake(t, "crash").Hooks()
+	require.NoError(t, crashing[0].Handler(context.Background(), plugin.FetchEvent{}),
+		"a crashed plugin never fails a hook")
+}
+
+func TestKeyBindings(t *testing.T) {
+	p := loadFake(t, "ok")
+	app := &recordingApp{}
+	p.Init(app)
+
+	keys := p.KeyBindings()
+	require.Len(t, keys, 1)
+	assert.Equal(t, []string{"ctrl+k"}, keys[0].Key.Keys())
+	assert.Equal(t, "pr_list", keys[0].View)
+
+	msg := keys[0].Action()()
+	notify, ok := msg.(plugin.NotifyMsg)
+	require.True(t, ok)
+	assert.Contains(t, notify.Message, `"action":"ping"`)
+	assert.Contains(t, notify.Message, `"owner":"o"`)
+
+	assert.Eventually(t, func() bool {
+		return len(app.sent()) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, plugin.NotifyMsg{Message: "pinged", Level: domain.ToastInfo}, app.sent()[0])
+}
+
+func TestDiscover(t *testing.T) {
+	dir := t.TempDir()
+	script := "#!/bin/sh\nexec " + fakePath(t, "ok") + "\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "fake"), []byte(script), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README"), []byte("not a plugin"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".hidden"), []byte(script), 0o755))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "sub"), 0o755))
+
+	host, err := Discover(dir, "test")
+	require.NoError(t, err)
+	defer host.Close()
+	plugins := host.Plugins()
+	require.Len(t, plugins, 1)
+	assert.Equal(t, "fake", plugins[0].Info().Name)
+}
+
+func TestDiscoverMissingDir(t *testing.T) {
+	host, err := Discover(filepath.Join(t.TempDir(), "missing"), "test")
+	require.NoError(t, err)
+	assert.Empty(t, host.Plugins())
+}
@@ -0,0 +1,71 @@
+package external
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/indrasvat/vivecaka/internal/config"
+	"github.com/indrasvat/vivecaka/internal/plugin"
+)
+
+// Dir returns the directory external plugins are discovered in.
+func Dir() string {
+	return filepath.Join(config.DataDir(), "plugins")
+}
+
+// Host owns the external plugins started by Discover.
+type Host struct {
+	plugins []*Plugin
+}
+
+// Discover starts every executable in dir, in name order. Hidden files,
+// directories and non-executables are skipped, and a missing dir is not an
+// error. Plugins that fail to start are left out and their errors joined,
+// so one broken plugin does not keep the others from loading.
+func Discover(dir, appVersion string) (*Host, error) {
+	h := &Host{}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return h, err
+	}
+	var errs []error
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil || info.Mode()&0o111 == 0 {
+			continue
+		}
+		p, err := Load(filepath.Join(dir, e.Name()), appVersion)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		h.plugins = append(h.plugins, p)
+	}
+	return h, errors.Join(errs...)
+}
+
+// Plugins returns the started plugins, wrapped to expose the capabilities
+// each provides.
+func (h *Host) Plugins() []plugin.Plugin {
+	out := make([]plugin.Plugin, len(h.plugins))
+	for i, p := range h.plugins {
+		out[i] = p.Capabilities()
+	}
+	return out
+}
+
+// Close shuts every plugin down.
+func (h *Host) Close() {
+	for _, p := range h.plugins {
+		p.Close()
+	}
+}
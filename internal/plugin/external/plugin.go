@@ -0,0 +1,353 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/logging"
+	"github.com/indrasvat/vivecaka/internal/plugin"
+)
+
+const (
+	// handshakeTimeout bounds startup, including initialize.
+	handshakeTimeout = 5 * time.Second
+	// callTimeout bounds every call, so a hung plugin cannot hold up a view.
+	callTimeout = 15 * time.Second
+	// shutdownGrace is how long a plugin gets to exit before it is killed.
+	shutdownGrace = 2 * time.Second
+	// maxRestarts is how often a crashed plugin is restarted before it is
+	// disabled for the rest of the session.
+	maxRestarts = 3
+)
+
+// ErrDisabled is returned for calls to a plugin that crashed more than
+// maxRestarts times. It matches domain.ErrUnavailable.
+var ErrDisabled = fmt.Errorf("plugin disabled after repeated crashes: %w", domain.ErrUnavailable)
+
+// hookPoints are the hooks offered to external plugins. before_render runs
+// on the UI goroutine every frame, which a process round trip cannot afford.
+var hookPoints = append([]plugin.HookPoint{
+	plugin.HookBeforeFetch,
+	plugin.HookAfterFetch,
+	plugin.HookOnPRSelect,
+	plugin.HookOnViewChange,
+}, plugin.ActionHooks...)
+
+// Plugin is an external plugin process. A crashed process is restarted on
+// the next call, up to maxRestarts times.
+type Plugin struct {
+	path       string
+	appVersion string
+	info       initializeResult
+
+	mu       sync.Mutex
+	conn     *conn
+	restarts int
+	app      plugin.AppContext
+}
+
+// Load starts the plugin at path and performs the handshake.
+func Load(path, appVersion string) (*Plugin, error) {
+	p := &Plugin{path: path, appVersion: appVersion}
+	c, info, err := p.start()
+	if err != nil {
+		return nil, err
+	}
+	p.conn, p.info = c, info
+	return p, nil
+}
+
+// start launches the process and negotiates the protocol.
+func (p *Plugin) start() (*conn, initializeResult, error) {
+	c, err := startConn(p.path, p.notify)
+	if err != nil {
+		return nil, initializeResult{}, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), handshakeTimeout)
+	defer cancel()
+
+	hooks := make([]string, len(hookPoints))
+	for i, h := range hookPoints {
+		hooks[i] = string(h)
+	}
+	var info initializeResult
+	err = c.call(ctx, methodInitialize, initializeParams{
+		ProtocolVersion: ProtocolVersion,
+		AppVersion:      p.appVersion,
+		Hooks:           hooks,
+	}, &info)
+	switch {
+	case err != nil:
+		err = fmt.Errorf("plugin %s: initialize: %w", p.path, err)
+	case info.ProtocolVersion != ProtocolVersion:
+		err = fmt.Errorf("plugin %s speaks protocol %d, vivecaka speaks %d", p.path, info.ProtocolVersion, ProtocolVersion)
+	case info.Name == "":
+		err = fmt.Errorf("plugin %s: initialize returned no name", p.path)
+	}
+	if err != nil {
+		c.fail(err)
+		return nil, initializeResult{}, err
+	}
+	return c, info, nil
+}
+
+// call runs method on the plugin, restarting it first if it crashed.
+func (p *Plugin) call(ctx context.Context, method string, params, result any) error {
+	c, err := p.connection()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, callTimeout)
+	defer cancel()
+	if err := c.call(ctx, method, params, result); err != nil {
+		return fmt.Errorf("plugin %s: %s: %w", p.info.Name, method, err)
+	}
+	return nil
+}
+
+// connection returns the live process, restarting a crashed one.
+func (p *Plugin) connection() (*conn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.conn != nil && p.conn.alive() {
+		return p.conn, nil
+	}
+	if p.restarts >= maxRestarts {
+		return nil, fmt.Errorf("plugin %s: %w", p.info.Name, ErrDisabled)
+	}
+	p.restarts++
+	logging.Log.Warn("restarting plugin", "plugin", p.info.Name, "restart", p.restarts, "err", p.conn.err)
+	c, _, err := p.start()
+	if err != nil {
+		return nil, err
+	}
+	p.conn = c
+	return c, nil
+}
+
+// Close shuts the plugin down.
+func (p *Plugin) Close() {
+	p.mu.Lock()
+	c := p.conn
+	p.mu.Unlock()
+	if c != nil {
+		c.close(shutdownGrace)
+	}
+}
+
+// notify shows a plugin's notification as a toast.
+func (p *Plugin) notify(n notifyParams) {
+	p.mu.Lock()
+	app := p.app
+	p.mu.Unlock()
+	if app == nil {
+		return
+	}
+	level := n.Level
+	if level == "" {
+		level = domain.ToastInfo
+	}
+	app.SendMessage(plugin.NotifyMsg{Message: n.Message, Level: level})
+}
+
+// Info returns the metadata the plugin reported at startup.
+func (p *Plugin) Info() plugin.PluginInfo {
+	return plugin.PluginInfo{
+		Name:        p.info.Name,
+		Version:     p.info.Version,
+		Description: p.info.Description,
+		Provides:    p.info.Provides,
+	}
+}
+
+// Init keeps the app context for notifications and key actions.
+func (p *Plugin) Init(app plugin.AppContext) tea.Cmd {
+	p.mu.Lock()
+	p.app = app
+	p.mu.Unlock()
+	return nil
+}
+
+// Hooks subscribes the plugin to the hook points it asked for. A hook the
+// plugin fails on purpose returns its error (so before_fetch can cancel a
+// fetch), but a crashed or hung plugin is only logged: it never blocks the
+// app.
+func (p *Plugin) Hooks() []plugin.HookRegistration {
+	var regs []plugin.HookRegistration
+	for _, name := range p.info.Hooks {
+		point := plugin.HookPoint(name)
+		if !slices.Contains(hookPoints, point) {
+			logging.Log.Warn("plugin asked for an unknown hook", "plugin", p.info.Name, "hook", name)
+			continue
+		}
+		regs = append(regs, plugin.HookRegistration{
+			Point: point,
+			Handler: func(ctx context.Context, data any) error {
+				err := p.call(ctx, methodHook, hookParams{Point: point, Event: hookEvent(data)}, nil)
+				if rpcErr := (*rpcError)(nil); errors.As(err, &rpcErr) {
+					return err
+				}
+				if err != nil {
+					logging.Log.Warn("plugin hook failed", "plugin", p.info.Name, "hook", point, "err", err)
+				}
+				return nil
+			},
+		})
+	}
+	return regs
+}
+
+// KeyBindings maps the plugin's keys to "key" calls. The result message, or
+// the failure, is shown as a toast.
+func (p *Plugin) KeyBindings() []plugin.KeyRegistration {
+	regs := make([]plugin.KeyRegistration, 0, len(p.info.Keys))
+	for _, k := range p.info.Keys {
+		regs = append(regs, plugin.KeyRegistration{
+			Key:  key.NewBinding(key.WithKeys(k.Key), key.WithHelp(k.Key, k.Help)),
+			View: k.View,
+			Action: func() tea.Cmd {
+				params := keyParams{Action: k.Action, View: k.View}
+				p.mu.Lock()
+				if p.app != nil {
+					params.Repo = p.app.CurrentRepo()
+				}
+				p.mu.Unlock()
+				return func() tea.Msg {
+					var res keyResult
+					if err := p.call(context.Background(), methodKey, params, &res); err != nil {
+						return plugin.NotifyMsg{Message: err.Error(), Level: domain.ToastError}
+					}
+					if res.Message == "" {
+						return nil
+					}
+					return plugin.NotifyMsg{Message: res.Message, Level: domain.ToastInfo}
+				}
+			},
+		})
+	}
+	return regs
+}
+
+// Capabilities wraps the plugin in a type that implements exactly the
+// domain interfaces it provides, for the registry to discover.
+func (p *Plugin) Capabilities() plugin.Plugin {
+	reader := slices.Contains(p.info.Provides, providesReader)
+	reviewer := slices.Contains(p.info.Provides, providesReviewer)
+	switch {
+	case reader && reviewer:
+		return &readerReviewerPlugin{p, readerMethods{p}, reviewerMethods{p}}
+	case reader:
+		return &readerPlugin{p, readerMethods{p}}
+	case reviewer:
+		return &reviewerPlugin{p, reviewerMethods{p}}
+	default:
+		return p
+	}
+}
+
+type readerPlugin struct {
+	*Plugin
+	readerMethods
+}
+
+type reviewerPlugin struct {
+	*Plugin
+	reviewerMethods
+}
+
+type readerReviewerPlugin struct {
+	*Plugin
+	readerMethods
+	reviewerMethods
+}
+
+var (
+	_ plugin.HookPlugin = (*Plugin)(nil)
+	_ plugin.KeyPlugin  = (*Plugin)(nil)
+	_ domain.PRReader   = (*readerPlugin)(nil)
+	_ domain.PRReviewer = (*reviewerPlugin)(nil)
+	_ domain.PRReader   = (*readerReviewerPlugin)(nil)
+	_ domain.PRReviewer = (*readerReviewerPlugin)(nil)
+)
+
+// readerMethods implements domain.PRReader over the plugin.
+type readerMethods struct{ p *Plugin }
+
+func (r readerMethods) ListPRs(ctx context.Context, repo domain.RepoRef, opts domain.ListOpts) ([]domain.PR, error) {
+	var prs []domain.PR
+	if err := r.p.call(ctx, methodListPRs, listParams{Repo: repo, Opts: opts}, &prs); err != nil {
+		return nil, err
+	}
+	return prs, nil
+}
+
+func (r readerMethods) GetPR(ctx context.Context, repo domain.RepoRef, number int) (*domain.PRDetail, error) {
+	var detail domain.PRDetail
+	if err := r.p.call(ctx, methodGetPR, prParams{Repo: repo, Number: number}, &detail); err != nil {
+		return nil, err
+	}
+	return &detail, nil
+}
+
+func (r readerMethods) GetDiff(ctx context.Context, repo domain.RepoRef, number int) (*domain.Diff, error) {
+	var diff domain.Diff
+	if err := r.p.call(ctx, methodGetDiff, prParams{Repo: repo, Number: number}, &diff); err != nil {
+		return nil, err
+	}
+	return &diff, nil
+}
+
+func (r readerMethods) GetChecks(ctx context.Context, repo domain.RepoRef, number int) ([]domain.Check, error) {
+	var checks []domain.Check
+	if err := r.p.call(ctx, methodGetChecks, prParams{Repo: repo, Number: number}, &checks); err != nil {
+		return nil, err
+	}
+	return checks, nil
+}
+
+func (r readerMethods) GetComments(ctx context.Context, repo domain.RepoRef, number int) ([]domain.CommentThread, error) {
+	var threads []domain.CommentThread
+	if err := r.p.call(ctx, methodGetComments, prParams{Repo: repo, Number: number}, &threads); err != nil {
+		return nil, err
+	}
+	return threads, nil
+}
+
+func (r readerMethods) GetDiscussion(ctx context.Context, repo domain.RepoRef, number int) ([]domain.DiscussionItem, error) {
+	var items []domain.DiscussionItem
+	if err := r.p.call(ctx, methodGetDiscussion, prParams{Repo: repo, Number: number}, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r readerMethods) GetPRCount(ctx context.Context, repo domain.RepoRef, state domain.PRState) (int, error) {
+	var count int
+	if err := r.p.call(ctx, methodGetPRCount, countParams{Repo: repo, State: state}, &count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// reviewerMethods implements domain.PRReviewer over the plugin.
+type reviewerMethods struct{ p *Plugin }
+
+func (r reviewerMethods) SubmitReview(ctx context.Context, repo domain.RepoRef, number int, review domain.Review) error {
+	return r.p.call(ctx, methodSubmitReview, reviewParams{Repo: repo, Number: number, Review: review}, nil)
+}
+
+func (r reviewerMethods) AddComment(ctx context.Context, repo domain.RepoRef, number int, input domain.InlineCommentInput) error {
+	return r.p.call(ctx, methodAddComment, commentParams{Repo: repo, Number: number, Comment: input}, nil)
+}
+
+func (r reviewerMethods) ResolveThread(ctx context.Context, repo domain.RepoRef, threadID string) error {
+	return r.p.call(ctx, methodResolveThread, resolveParams{Repo: repo, ThreadID: threadID}, nil)
+}
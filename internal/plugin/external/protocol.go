@@ -0,0 +1,228 @@
+// Package external hosts out-of-process plugins: executables in the plugins
+// directory that vivecaka starts and talks to with JSON-RPC 2.0 over their
+// stdin and stdout, one JSON object per line. A plugin's stderr goes to the
+// debug log.
+//
+// The host opens every session with "initialize", in which both sides state
+// the protocol version they speak; the plugin answers with its name and what
+// it provides. After that the host calls
+//
+//	pr.list, pr.get, pr.diff, pr.checks,     if it provides "pr-reader"
+//	pr.comments, pr.discussion, pr.count
+//	pr.review, pr.comment, thread.resolve    if it provides "pr-reviewer"
+//	hook                                     for each hook it subscribed to
+//	key                                      when one of its keys is pressed
+//
+// and finally "shutdown". Plugins may send a "notify" notification at any
+// time to show a toast. Params and results of PR calls use the JSON
+// encoding of the internal/domain types. docs/PLUGINS.md describes the
+// protocol in full and examples/plugins/hello is a reference plugin.
+package external
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/plugin"
+)
+
+// ProtocolVersion is the protocol version this host speaks. It changes only
+// for incompatible changes; plugins reporting another version are not
+// loaded.
+const ProtocolVersion = 1
+
+// Method names.
+const (
+	methodInitialize = "initialize"
+	methodShutdown   = "shutdown"
+	methodNotify     = "notify"
+	methodHook       = "hook"
+	methodKey        = "key"
+
+	methodListPRs       = "pr.list"
+	methodGetPR         = "pr.get"
+	methodGetDiff       = "pr.diff"
+	methodGetChecks     = "pr.checks"
+	methodGetComments   = "pr.comments"
+	methodGetDiscussion = "pr.discussion"
+	methodGetPRCount    = "pr.count"
+
+	methodSubmitReview  = "pr.review"
+	methodAddComment    = "pr.comment"
+	methodResolveThread = "thread.resolve"
+)
+
+// Capabilities a plugin can provide.
+const (
+	providesReader   = "pr-reader"
+	providesReviewer = "pr-reviewer"
+)
+
+// message is a JSON-RPC 2.0 request, response or notification.
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      *int64          `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  any             `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// incoming is a message read from a plugin; params stay raw until the
+// method is known.
+type incoming struct {
+	ID     *int64          `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+// rpcError is a JSON-RPC error object. Plugins return one to fail a call;
+// the message is shown to the user.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("%s (code %d)", e.Message, e.Code)
+}
+
+// Unwrap lets HTTP-style codes match the domain errors, so plugin failures
+// are reported like a built-in backend's.
+func (e *rpcError) Unwrap() error {
+	switch e.Code {
+	case 401, 403:
+		return domain.ErrUnauthorized
+	case 404:
+		return domain.ErrNotFound
+	case 429:
+		return domain.ErrRateLimited
+	default:
+		return nil
+	}
+}
+
+// initializeParams opens a session.
+type initializeParams struct {
+	ProtocolVersion int      `json:"protocol_version"`
+	AppVersion      string   `json:"app_version"`
+	Hooks           []string `json:"hooks"` // hook points a plugin may subscribe to
+}
+
+// initializeResult describes a plugin.
+type initializeResult struct {
+	ProtocolVersion int          `json:"protocol_version"`
+	Name            string       `json:"name"`
+	Version         string       `json:"version"`
+	Description     string       `json:"description"`
+	Provides        []string     `json:"provides"`
+	Hooks           []string     `json:"hooks"`
+	Keys            []keyBinding `json:"keys"`
+}
+
+// keyBinding is a key a plugin handles. Pressing it calls "key" with the
+// action; View scopes it like plugin.KeyRegistration.View.
+type keyBinding struct {
+	Key    string `json:"key"` // as in config keybindings, e.g. "ctrl+k" or "X"
+	View   string `json:"view,omitempty"`
+	Action string `json:"action"`
+	Help   string `json:"help,omitempty"`
+}
+
+// keyParams reports a key press.
+type keyParams struct {
+	Action string         `json:"action"`
+	View   string         `json:"view"`
+	Repo   domain.RepoRef `json:"repo"`
+}
+
+// keyResult is a key action's outcome; a non-empty message is shown as a
+// toast.
+type keyResult struct {
+	Message string `json:"message,omitempty"`
+}
+
+// notifyParams asks the host to show a toast.
+type notifyParams struct {
+	Message string            `json:"message"`
+	Level   domain.ToastLevel `json:"level,omitempty"`
+}
+
+// hookParams delivers a hook event.
+type hookParams struct {
+	Point plugin.HookPoint `json:"point"`
+	Event any              `json:"event"`
+}
+
+// fetchEvent is the wire form of plugin.FetchEvent.
+type fetchEvent struct {
+	Repo  domain.RepoRef  `json:"repo"`
+	Opts  domain.ListOpts `json:"opts"`
+	PRs   []domain.PR     `json:"prs,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
+
+// hookEvent converts a hook payload to its wire form.
+func hookEvent(data any) any {
+	switch e := data.(type) {
+	case plugin.FetchEvent:
+		w := fetchEvent{Repo: e.Repo, Opts: e.Opts, PRs: e.PRs}
+		if e.Err != nil {
+			w.Error = e.Err.Error()
+		}
+		return w
+	case plugin.PRSelectEvent:
+		return struct {
+			Repo domain.RepoRef `json:"repo"`
+			PR   domain.PR      `json:"pr"`
+		}{e.Repo, e.PR}
+	case plugin.ViewChangeEvent:
+		return struct {
+			From string `json:"from"`
+			To   string `json:"to"`
+		}{e.From, e.To}
+	default:
+		return data
+	}
+}
+
+// prParams addresses a PR.
+type prParams struct {
+	Repo   domain.RepoRef `json:"repo"`
+	Number int            `json:"number"`
+}
+
+// listParams are the params of pr.list.
+type listParams struct {
+	Repo domain.RepoRef  `json:"repo"`
+	Opts domain.ListOpts `json:"opts"`
+}
+
+// countParams are the params of pr.count.
+type countParams struct {
+	Repo  domain.RepoRef `json:"repo"`
+	State domain.PRState `json:"state"`
+}
+
+// reviewParams are the params of pr.review.
+type reviewParams struct {
+	Repo   domain.RepoRef `json:"repo"`
+	Number int            `json:"number"`
+	Review domain.Review  `json:"review"`
+}
+
+// commentParams are the params of pr.comment.
+type commentParams struct {
+	Repo    domain.RepoRef            `json:"repo"`
+	Number  int                       `json:"number"`
+	Comment domain.InlineCommentInput `json:"comment"`
+}
+
+// resolveParams are the params of thread.resolve.
+type resolveParams struct {
+	Repo     domain.RepoRef `json:"repo"`
+	ThreadID string         `json:"thread_id"`
+}
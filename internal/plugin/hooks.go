@@ -3,51 +3,139 @@ package plugin
 import (
 	"context"
 	"sync"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
 )
 
 // HookPoint identifies a lifecycle event that plugins can hook into.
 type HookPoint string
 
+// Hook points and the payload each is emitted with.
 const (
-	HookBeforeFetch  HookPoint = "before_fetch"
-	HookAfterFetch   HookPoint = "after_fetch"
-	HookBeforeRender HookPoint = "before_render"
-	HookOnPRSelect   HookPoint = "on_pr_select"
-	HookOnViewChange HookPoint = "on_view_change"
+	HookBeforeFetch  HookPoint = "before_fetch"   // FetchEvent; an error cancels the fetch
+	HookAfterFetch   HookPoint = "after_fetch"    // FetchEvent with the result
+	HookBeforeRender HookPoint = "before_render"  // RenderEvent; runs on the UI goroutine
+	HookOnPRSelect   HookPoint = "on_pr_select"   // PRSelectEvent
+	HookOnViewChange HookPoint = "on_view_change" // ViewChangeEvent
+
+	// Action hooks run after the user's action succeeded, with an ActionEvent.
+	HookPROpened        HookPoint = "pr_opened"
+	HookReviewSubmitted HookPoint = "review_submitted"
+	HookCheckoutDone    HookPoint = "checkout_done"
+	HookCommentAdded    HookPoint = "comment_added"
+	HookThreadResolved  HookPoint = "thread_resolved"
 )
 
+// ActionHooks lists the action hook points.
+var ActionHooks = []HookPoint{HookPROpened, HookReviewSubmitted, HookCheckoutDone, HookCommentAdded, HookThreadResolved}
+
+// FetchEvent is the payload of HookBeforeFetch and HookAfterFetch. PRs and
+// Err are only set after the fetch.
+type FetchEvent struct {
+	Repo domain.RepoRef
+	Opts domain.ListOpts
+	PRs  []domain.PR
+	Err  error
+}
+
+// RenderEvent is the payload of HookBeforeRender.
+type RenderEvent struct {
+	View          string
+	Width, Height int
+}
+
+// PRSelectEvent is the payload of HookOnPRSelect, emitted when the
+// highlighted PR in the list changes.
+type PRSelectEvent struct {
+	Repo domain.RepoRef
+	PR   domain.PR
+}
+
+// ViewChangeEvent is the payload of HookOnViewChange. Views are named
+// "pr_list", "pr_detail", "diff", "review", "help", "repo_switch", "inbox",
+// "filter", "confirm", "smart_checkout", "merge", "metadata", "create_pr", or a plugin
+// view's name.
+type ViewChangeEvent struct {
+	From, To string
+}
+
+// ActionEvent is the payload of the action hooks. PR always has a Number;
+// its other fields are set when the PR was loaded. The remaining fields
+// describe the action and are set by the hooks they apply to.
+type ActionEvent struct {
+	Event    HookPoint                  `json:"event"`
+	Repo     domain.RepoRef             `json:"repo"`
+	PR       domain.PR                  `json:"pr"`
+	Review   *domain.Review             `json:"review,omitempty"`    // review_submitted
+	Comment  *domain.InlineCommentInput `json:"comment,omitempty"`   // comment_added: only the body for a PR comment
+	Branch   string                     `json:"branch,omitempty"`    // checkout_done
+	Path     string                     `json:"path,omitempty"`      // checkout_done: the working tree
+	ThreadID string                     `json:"thread_id,omitempty"` // thread_resolved
+}
+
 // HookHandler is a function that handles a lifecycle event.
 type HookHandler func(ctx context.Context, data any) error
 
 // HookManager manages event hooks with thread-safe registration and emission.
 type HookManager struct {
 	mu    sync.RWMutex
-	hooks map[HookPoint][]HookHandler
+	hooks map[HookPoint][]hookEntry
+}
+
+// hookEntry is a handler and the plugin that registered it, if any.
+type hookEntry struct {
+	owner   string
+	handler HookHandler
 }
 
 // NewHookManager creates a new HookManager.
 func NewHookManager() *HookManager {
 	return &HookManager{
-		hooks: make(map[HookPoint][]HookHandler),
+		hooks: make(map[HookPoint][]hookEntry),
 	}
 }
 
 // On registers a handler for a hook point.
 func (hm *HookManager) On(point HookPoint, handler HookHandler) {
+	hm.on("", point, handler)
+}
+
+// on registers a handler on behalf of the plugin named owner.
+func (hm *HookManager) on(owner string, point HookPoint, handler HookHandler) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	hm.hooks[point] = append(hm.hooks[point], hookEntry{owner: owner, handler: handler})
+}
+
+// removeOwner drops every handler the plugin named owner registered.
+func (hm *HookManager) removeOwner(owner string) {
 	hm.mu.Lock()
 	defer hm.mu.Unlock()
-	hm.hooks[point] = append(hm.hooks[point], handler)
+	for point, entries := range hm.hooks {
+		// Emit may be iterating entries, so filter into a new slice.
+		kept := make([]hookEntry, 0, len(entries))
+		for _, e := range entries {
+			if e.owner != owner {
+				kept = append(kept, e)
+			}
+		}
+		hm.hooks[point] = kept
+	}
 }
 
 // Emit calls all handlers for a hook point in registration order.
 // If any handler returns an error, emission stops and the error is returned.
+// Emitting on a nil HookManager does nothing.
 func (hm *HookManager) Emit(ctx context.Context, point HookPoint, data any) error {
+	if hm == nil {
+		return nil
+	}
 	hm.mu.RLock()
 	handlers := hm.hooks[point]
 	hm.mu.RUnlock()
 
-	for _, handler := range handlers {
-		if err := handler(ctx, data); err != nil {
+	for _, e := range handlers {
+		if err := e.handler(ctx, data); err != nil {
 			return err
 		}
 	}
@@ -128,3 +128,8 @@ func TestHookPointConstants(t *testing.T) {
 		assert.NotEmpty(t, string(p), "HookPoint should not be empty string")
 	}
 }
+
+func TestHookManagerNilEmitIsNoop(t *testing.T) {
+	var hm *HookManager
+	assert.NoError(t, hm.Emit(context.Background(), HookBeforeRender, RenderEvent{}))
+}
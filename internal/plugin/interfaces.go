@@ -23,10 +23,10 @@ type PluginInfo struct {
 
 // AppContext provides plugins access to application state during Init().
 type AppContext interface {
-	ConfigValue(key string) any
+	ConfigValue(key string) any // dotted config key, e.g. "general.theme"; nil if unset
 	ThemeName() string
 	CurrentRepo() domain.RepoRef
-	SendMessage(tea.Msg)
+	SendMessage(tea.Msg) // safe to call from any goroutine, including Init
 }
 
 // ViewPlugin provides custom UI views.
@@ -52,6 +52,30 @@ type KeyPlugin interface {
 // KeyRegistration describes a custom key binding provided by a plugin.
 type KeyRegistration struct {
 	Key    key.Binding    // The key binding
-	View   string         // Which view this applies to ("" = global)
+	View   string         // Which view this applies to ("" = global); see HookOnViewChange for names
 	Action func() tea.Cmd // Action to execute
 }
+
+// HookPlugin handles application lifecycle events.
+type HookPlugin interface {
+	Plugin
+	Hooks() []HookRegistration
+}
+
+// HookRegistration subscribes a handler to a hook point.
+type HookRegistration struct {
+	Point   HookPoint
+	Handler HookHandler
+}
+
+// OpenViewMsg asks the app to show the plugin view registered under Name.
+// Key binding actions return it to open their plugin's views.
+type OpenViewMsg struct {
+	Name string
+}
+
+// NotifyMsg asks the app to show a toast on a plugin's behalf.
+type NotifyMsg struct {
+	Message string
+	Level   domain.ToastLevel
+}
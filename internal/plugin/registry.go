@@ -2,6 +2,7 @@ package plugin
 
 import (
 	"fmt"
+	"slices"
 	"sync"
 
 	"github.com/indrasvat/vivecaka/internal/domain"
@@ -9,27 +10,45 @@ import (
 
 // Registry manages plugin lifecycle and capability discovery.
 type Registry struct {
-	mu           sync.RWMutex
-	plugins      map[string]Plugin
-	readers      []domain.PRReader
-	reviewers    []domain.PRReviewer
-	writers      []domain.PRWriter
-	repoManagers []domain.RepoManager
-	views        []ViewRegistration
-	keys         []KeyRegistration
-	hooks        *HookManager
+	mu      sync.RWMutex
+	plugins map[string]*registration
+	order   []string // plugin names in registration order
+	hooks   *HookManager
+}
+
+// registration is a plugin and the capabilities it contributed, so they can
+// be pruned when it is unregistered.
+type registration struct {
+	plugin      Plugin
+	reader      domain.PRReader
+	reviewer    domain.PRReviewer
+	writer      domain.PRWriter
+	repoManager domain.RepoManager
+	views       []ViewRegistration
+	keys        []KeyRegistration
 }
 
 // NewRegistry creates a new plugin registry.
 func NewRegistry() *Registry {
 	return &Registry{
-		plugins: make(map[string]Plugin),
+		plugins: make(map[string]*registration),
 		hooks:   NewHookManager(),
 	}
 }
 
 // Register adds a plugin and auto-discovers its capabilities via type assertion.
 func (r *Registry) Register(p Plugin) error {
+	reader, _ := p.(domain.PRReader)
+	return r.register(p, reader)
+}
+
+// RegisterWithReader registers p like Register, with reader as the PRReader
+// it provides in place of its own, e.g. to record p's responses.
+func (r *Registry) RegisterWithReader(p Plugin, reader domain.PRReader) error {
+	return r.register(p, reader)
+}
+
+func (r *Registry) register(p Plugin, reader domain.PRReader) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -37,66 +56,114 @@ func (r *Registry) Register(p Plugin) error {
 	if _, exists := r.plugins[info.Name]; exists {
 		return fmt.Errorf("plugin %q already registered", info.Name)
 	}
-	r.plugins[info.Name] = p
-
-	if reader, ok := p.(domain.PRReader); ok {
-		r.readers = append(r.readers, reader)
-	}
-	if reviewer, ok := p.(domain.PRReviewer); ok {
-		r.reviewers = append(r.reviewers, reviewer)
-	}
-	if writer, ok := p.(domain.PRWriter); ok {
-		r.writers = append(r.writers, writer)
-	}
-	if rm, ok := p.(domain.RepoManager); ok {
-		r.repoManagers = append(r.repoManagers, rm)
-	}
+	reg := &registration{plugin: p, reader: reader}
+	reg.reviewer, _ = p.(domain.PRReviewer)
+	reg.writer, _ = p.(domain.PRWriter)
+	reg.repoManager, _ = p.(domain.RepoManager)
 	if vp, ok := p.(ViewPlugin); ok {
-		r.views = append(r.views, vp.Views()...)
+		reg.views = vp.Views()
 	}
 	if kp, ok := p.(KeyPlugin); ok {
-		r.keys = append(r.keys, kp.KeyBindings()...)
+		reg.keys = kp.KeyBindings()
 	}
+	if hp, ok := p.(HookPlugin); ok {
+		for _, h := range hp.Hooks() {
+			r.hooks.on(info.Name, h.Point, h.Handler)
+		}
+	}
+	r.plugins[info.Name] = reg
+	r.order = append(r.order, info.Name)
 	return nil
 }
 
-// Unregister removes a plugin by name.
+// Unregister removes a plugin by name, along with the capabilities, views,
+// key bindings and hooks it contributed.
 func (r *Registry) Unregister(name string) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	if _, ok := r.plugins[name]; !ok {
+		return
+	}
 	delete(r.plugins, name)
-	// Note: capability slices are not pruned for simplicity.
-	// Full cleanup would require tracking which plugin provided each capability.
+	r.order = slices.DeleteFunc(r.order, func(n string) bool { return n == name })
+	r.hooks.removeOwner(name)
+}
+
+// Plugins returns the registered plugins in registration order.
+func (r *Registry) Plugins() []Plugin {
+	return collect(r, func(reg *registration) []Plugin { return []Plugin{reg.plugin} })
 }
 
 // GetReaders returns all registered PRReader implementations.
 func (r *Registry) GetReaders() []domain.PRReader {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-	return r.readers
+	return collect(r, func(reg *registration) []domain.PRReader { return nonNil(reg.reader) })
+}
+
+// Backends returns the plugins that provide a PR capability, with the
+// capabilities each provides, in registration order.
+func (r *Registry) Backends() []Backend {
+	return collect(r, func(reg *registration) []Backend {
+		if reg.reader == nil && reg.reviewer == nil && reg.writer == nil && reg.repoManager == nil {
+			return nil
+		}
+		b := Backend{
+			Name:        reg.plugin.Info().Name,
+			Reader:      reg.reader,
+			Reviewer:    reg.reviewer,
+			Writer:      reg.writer,
+			RepoManager: reg.repoManager,
+		}
+		b.Hosts, _ = reg.plugin.(domain.HostSupporter)
+		return []Backend{b}
+	})
 }
 
 // GetReviewers returns all registered PRReviewer implementations.
 func (r *Registry) GetReviewers() []domain.PRReviewer {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-	return r.reviewers
+	return collect(r, func(reg *registration) []domain.PRReviewer { return nonNil(reg.reviewer) })
 }
 
 // GetWriters returns all registered PRWriter implementations.
 func (r *Registry) GetWriters() []domain.PRWriter {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-	return r.writers
+	return collect(r, func(reg *registration) []domain.PRWriter { return nonNil(reg.writer) })
 }
 
 // GetRepoManagers returns all registered RepoManager implementations.
 // Future-ready: for plugin authors to provide custom repo management.
 // Current MVP wiring uses direct injection via tui.WithRepoManager().
 func (r *Registry) GetRepoManagers() []domain.RepoManager {
+	return collect(r, func(reg *registration) []domain.RepoManager { return nonNil(reg.repoManager) })
+}
+
+// GetViews returns all views registered by ViewPlugins.
+func (r *Registry) GetViews() []ViewRegistration {
+	return collect(r, func(reg *registration) []ViewRegistration { return reg.views })
+}
+
+// GetKeyBindings returns all key bindings registered by KeyPlugins.
+func (r *Registry) GetKeyBindings() []KeyRegistration {
+	return collect(r, func(reg *registration) []KeyRegistration { return reg.keys })
+}
+
+// collect gathers what each registered plugin contributes, in registration
+// order.
+func collect[T any](r *Registry, get func(*registration) []T) []T {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
-	return r.repoManagers
+	var out []T
+	for _, name := range r.order {
+		out = append(out, get(r.plugins[name])...)
+	}
+	return out
+}
+
+// nonNil returns v as a one-element slice, or nil if v is a nil interface.
+func nonNil[T comparable](v T) []T {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return []T{v}
 }
 
 // Hooks returns the hook manager.
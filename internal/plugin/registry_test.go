@@ -82,9 +82,6 @@ func (m *mockWriterPlugin) Checkout(_ context.Context, _ domain.RepoRef, _ int)
 func (m *mockWriterPlugin) Merge(_ context.Context, _ domain.RepoRef, _ int, _ domain.MergeOpts) error {
 	return nil
 }
-func (m *mockWriterPlugin) UpdateLabels(_ context.Context, _ domain.RepoRef, _ int, _ []string) error {
-	return nil
-}
 
 // mockRepoManagerPlugin implements Plugin + domain.RepoManager.
 type mockRepoManagerPlugin struct {
@@ -97,7 +94,7 @@ func (m *mockRepoManagerPlugin) CheckoutAt(_ context.Context, _ domain.RepoRef,
 func (m *mockRepoManagerPlugin) CloneRepo(_ context.Context, _ domain.RepoRef, _ string) error {
 	return nil
 }
-func (m *mockRepoManagerPlugin) CreateWorktree(_ context.Context, _ string, _ int, _, _ string) error {
+func (m *mockRepoManagerPlugin) CreateWorktree(_ context.Context, _ domain.RepoRef, _ string, _ int, _, _ string) error {
 	return nil
 }
 
@@ -201,6 +198,73 @@ func TestRegistryUnregister(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestRegistryPluginsInRegistrationOrder(t *testing.T) {
+	reg := NewRegistry()
+	for _, name := range []string{"b", "a", "c"} {
+		require.NoError(t, reg.Register(&mockPlugin{name: name}))
+	}
+	reg.Unregister("a")
+
+	var names []string
+	for _, p := range reg.Plugins() {
+		names = append(names, p.Info().Name)
+	}
+	assert.Equal(t, []string{"b", "c"}, names)
+}
+
+// mockUIPlugin implements Plugin + ViewPlugin + KeyPlugin + HookPlugin.
+type mockUIPlugin struct {
+	mockPlugin
+	called bool
+}
+
+func (m *mockUIPlugin) Views() []ViewRegistration {
+	return []ViewRegistration{{Name: "stats", Position: "tab"}}
+}
+
+func (m *mockUIPlugin) KeyBindings() []KeyRegistration {
+	return []KeyRegistration{{View: "pr_list"}}
+}
+
+func (m *mockUIPlugin) Hooks() []HookRegistration {
+	return []HookRegistration{{Point: HookAfterFetch, Handler: func(context.Context, any) error {
+		m.called = true
+		return nil
+	}}}
+}
+
+func TestRegistryAutoDiscoverUI(t *testing.T) {
+	reg := NewRegistry()
+	p := &mockUIPlugin{mockPlugin: mockPlugin{name: "ui"}}
+	require.NoError(t, reg.Register(p))
+
+	require.Len(t, reg.GetViews(), 1)
+	assert.Equal(t, "stats", reg.GetViews()[0].Name)
+	require.Len(t, reg.GetKeyBindings(), 1)
+	assert.Equal(t, "pr_list", reg.GetKeyBindings()[0].View)
+
+	require.NoError(t, reg.Hooks().Emit(context.Background(), HookAfterFetch, FetchEvent{}))
+	assert.True(t, p.called, "hook handlers are subscribed on register")
+}
+
+func TestRegistryUnregisterPrunesCapabilities(t *testing.T) {
+	reg := NewRegistry()
+	ui := &mockUIPlugin{mockPlugin: mockPlugin{name: "ui"}}
+	require.NoError(t, reg.Register(ui))
+	require.NoError(t, reg.Register(&mockReaderPlugin{mockPlugin: mockPlugin{name: "reader"}}))
+	require.NoError(t, reg.Register(&mockReaderPlugin{mockPlugin: mockPlugin{name: "other"}}))
+
+	reg.Unregister("ui")
+	reg.Unregister("reader")
+
+	assert.Empty(t, reg.GetViews())
+	assert.Empty(t, reg.GetKeyBindings())
+	require.NoError(t, reg.Hooks().Emit(context.Background(), HookAfterFetch, FetchEvent{}))
+	assert.False(t, ui.called, "an unregistered plugin's hooks are dropped")
+	require.Len(t, reg.Backends(), 1)
+	assert.Equal(t, "other", reg.Backends()[0].Name)
+}
+
 func TestRegistryAutoDiscoverAllCapabilities(t *testing.T) {
 	reg := NewRegistry()
 	p := &mockFullPlugin{
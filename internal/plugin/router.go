@@ -0,0 +1,256 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/logging"
+)
+
+// Backend is what one plugin contributes to a Router: its name, the
+// capabilities it provides (nil when it does not) and, if it says, which
+// hosts it can serve.
+type Backend struct {
+	Name        string
+	Reader      domain.PRReader
+	Reviewer    domain.PRReviewer
+	Writer      domain.PRWriter
+	RepoManager domain.RepoManager
+	Hosts       domain.HostSupporter
+}
+
+// supports reports whether the backend says it can serve repo's host.
+func (b Backend) supports(repo domain.RepoRef) bool {
+	return b.Hosts != nil && b.Hosts.SupportsHost(repo.HostName())
+}
+
+// Route sends repos matching Pattern to the plugin named Backend. Pattern
+// is matched with domain.RepoRef.Matches, so "ghe.example.com" matches
+// every repo on that host and "github.com/acme/*" every repo of one owner. Repos without a host are on github.com.
+type Route struct {
+	Pattern string
+	Backend string
+}
+
+// matches reports whether repo matches the route's pattern.
+func (r Route) matches(repo domain.RepoRef) bool { return repo.Matches(r.Pattern) }
+
+// Router serves each request with a backend chosen by the request's repo.
+// The candidates are the backends of the routes the repo matches, in route
+// order, or the first backend with the capability when no route matches,
+// then every backend that supports the repo's host. Reads fail over to the
+// next candidate on transient errors; writes go to the first candidate
+// only, as retrying one elsewhere could apply it twice. Which backend served
+// each request is logged at debug level.
+type Router struct {
+	backends []Backend
+	routes   []Route
+}
+
+// NewRouter creates a Router. Routes naming no backend are ignored.
+func NewRouter(backends []Backend, routes []Route) *Router {
+	return &Router{backends: backends, routes: routes}
+}
+
+var (
+	_ domain.PRReader          = (*Router)(nil)
+	_ domain.PRPager           = (*Router)(nil)
+	_ domain.CIStatusReader    = (*Router)(nil)
+	_ domain.CommitReader      = (*Router)(nil)
+	_ domain.RateLimitReporter = (*Router)(nil)
+)
+
+// candidates returns the backends to try for repo that have a capability,
+// preferred first.
+func (r *Router) candidates(repo domain.RepoRef, has func(Backend) bool) []Backend {
+	var out []Backend
+	add := func(b Backend) {
+		if has(b) && !slices.ContainsFunc(out, func(c Backend) bool { return c.Name == b.Name }) {
+			out = append(out, b)
+		}
+	}
+	routed := false
+	for _, route := range r.routes {
+		if !route.matches(repo) {
+			continue
+		}
+		routed = true
+		for _, b := range r.backends {
+			if b.Name == route.Backend {
+				add(b)
+			}
+		}
+	}
+	if !routed {
+		if i := slices.IndexFunc(r.backends, has); i >= 0 {
+			add(r.backends[i])
+		}
+	}
+	// Backends for another host would look the repo up in the wrong
+	// place, so only those that serve its host are fallbacks.
+	for _, b := range r.backends {
+		if b.supports(repo) {
+			add(b)
+		}
+	}
+	return out
+}
+
+// hasReader reports whether b provides a PRReader.
+func hasReader(b Backend) bool { return b.Reader != nil }
+
+// Transient reports whether err is worth retrying on another reader: the
+// host was unreachable, rate limited or too slow, or the backend is down.
+func Transient(err error) bool {
+	return errors.Is(err, domain.ErrNetwork) ||
+		errors.Is(err, domain.ErrRateLimited) ||
+		errors.Is(err, domain.ErrUnavailable) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
+
+// route calls fn on each candidate reader for repo until one succeeds or
+// fails with an error that is not transient. When the readers fail, the
+// preferred reader's error is returned. Readers that do not support the
+// operation are skipped by fn returning errors.ErrUnsupported.
+func route[T any](ctx context.Context, r *Router, op string, repo domain.RepoRef, fn func(domain.PRReader) (T, error)) (T, error) {
+	var zero T
+	var first error
+	for _, b := range r.candidates(repo, hasReader) {
+		v, err := fn(b.Reader)
+		if errors.Is(err, errors.ErrUnsupported) {
+			continue
+		}
+		if err == nil {
+			logging.Log.Debug("pr reader", "op", op, "repo", repo.String(), "backend", b.Name)
+			return v, nil
+		}
+		logging.Log.Debug("pr reader failed", "op", op, "repo", repo.String(), "backend", b.Name, "err", err)
+		if first == nil {
+			first = err
+		}
+		// Stop when the error is final or the caller gave up: another
+		// reader cannot do better.
+		if !Transient(err) || ctx.Err() != nil {
+			return zero, first
+		}
+	}
+	if first == nil {
+		first = fmt.Errorf("%s: no reader for %s: %w", op, repo, errors.ErrUnsupported)
+	}
+	return zero, first
+}
+
+// ListPRs lists PRs from the first reader that can serve repo.
+func (r *Router) ListPRs(ctx context.Context, repo domain.RepoRef, opts domain.ListOpts) ([]domain.PR, error) {
+	return route(ctx, r, "ListPRs", repo, func(pr domain.PRReader) ([]domain.PR, error) {
+		return pr.ListPRs(ctx, repo, opts)
+	})
+}
+
+// ListPRPage lists one page of PRs, as domain.ListPage does. A cursor is
+// only meaningful to the reader that issued it, so later pages never fail
+// over.
+func (r *Router) ListPRPage(ctx context.Context, repo domain.RepoRef, opts domain.ListOpts) (domain.PRPage, error) {
+	first := true
+	return route(ctx, r, "ListPRPage", repo, func(pr domain.PRReader) (domain.PRPage, error) {
+		if opts.Cursor != "" && !first {
+			return domain.PRPage{}, errors.ErrUnsupported
+		}
+		first = false
+		return domain.ListPage(ctx, pr, repo, opts)
+	})
+}
+
+// GetPR fetches a PR from the first reader that can serve repo.
+func (r *Router) GetPR(ctx context.Context, repo domain.RepoRef, number int) (*domain.PRDetail, error) {
+	return route(ctx, r, "GetPR", repo, func(pr domain.PRReader) (*domain.PRDetail, error) {
+		return pr.GetPR(ctx, repo, number)
+	})
+}
+
+// GetDiff fetches a diff from the first reader that can serve repo.
+func (r *Router) GetDiff(ctx context.Context, repo domain.RepoRef, number int) (*domain.Diff, error) {
+	return route(ctx, r, "GetDiff", repo, func(pr domain.PRReader) (*domain.Diff, error) {
+		return pr.GetDiff(ctx, repo, number)
+	})
+}
+
+// GetChecks fetches checks from the first reader that can serve repo.
+func (r *Router) GetChecks(ctx context.Context, repo domain.RepoRef, number int) ([]domain.Check, error) {
+	return route(ctx, r, "GetChecks", repo, func(pr domain.PRReader) ([]domain.Check, error) {
+		return pr.GetChecks(ctx, repo, number)
+	})
+}
+
+// GetComments fetches comment threads from the first reader that can serve
+// repo.
+func (r *Router) GetComments(ctx context.Context, repo domain.RepoRef, number int) ([]domain.CommentThread, error) {
+	return route(ctx, r, "GetComments", repo, func(pr domain.PRReader) ([]domain.CommentThread, error) {
+		return pr.GetComments(ctx, repo, number)
+	})
+}
+
+// GetDiscussion fetches the discussion from the first reader that can serve
+// repo.
+func (r *Router) GetDiscussion(ctx context.Context, repo domain.RepoRef, number int) ([]domain.DiscussionItem, error) {
+	return route(ctx, r, "GetDiscussion", repo, func(pr domain.PRReader) ([]domain.DiscussionItem, error) {
+		return pr.GetDiscussion(ctx, repo, number)
+	})
+}
+
+// GetPRCount counts PRs with the first reader that can serve repo.
+func (r *Router) GetPRCount(ctx context.Context, repo domain.RepoRef, state domain.PRState) (int, error) {
+	return route(ctx, r, "GetPRCount", repo, func(pr domain.PRReader) (int, error) {
+		return pr.GetPRCount(ctx, repo, state)
+	})
+}
+
+// GetCIStatuses fetches CI statuses from the first reader for repo that can
+// batch them.
+func (r *Router) GetCIStatuses(ctx context.Context, repo domain.RepoRef, numbers []int) (map[int]domain.CIStatus, error) {
+	return route(ctx, r, "GetCIStatuses", repo, func(pr domain.PRReader) (map[int]domain.CIStatus, error) {
+		ci, ok := pr.(domain.CIStatusReader)
+		if !ok {
+			return nil, errors.ErrUnsupported
+		}
+		return ci.GetCIStatuses(ctx, repo, numbers)
+	})
+}
+
+// GetCommits lists a PR's commits from the first reader for repo that can.
+func (r *Router) GetCommits(ctx context.Context, repo domain.RepoRef, number int) ([]domain.Commit, error) {
+	return route(ctx, r, "GetCommits", repo, func(pr domain.PRReader) ([]domain.Commit, error) {
+		cr, ok := pr.(domain.CommitReader)
+		if !ok {
+			return nil, errors.ErrUnsupported
+		}
+		return cr.GetCommits(ctx, repo, number)
+	})
+}
+
+// CompareCommits diffs a commit range with the first reader for repo that
+// can.
+func (r *Router) CompareCommits(ctx context.Context, repo domain.RepoRef, base, head string) (*domain.Diff, error) {
+	return route(ctx, r, "CompareCommits", repo, func(pr domain.PRReader) (*domain.Diff, error) {
+		cr, ok := pr.(domain.CommitReader)
+		if !ok {
+			return nil, errors.ErrUnsupported
+		}
+		return cr.CompareCommits(ctx, repo, base, head)
+	})
+}
+
+// RateLimit reports the budget of the first reader for repo that reports
+// one.
+func (r *Router) RateLimit(ctx context.Context, repo domain.RepoRef) (domain.RateLimit, error) {
+	return route(ctx, r, "RateLimit", repo, func(pr domain.PRReader) (domain.RateLimit, error) {
+		rl, ok := pr.(domain.RateLimitReporter)
+		if !ok {
+			return domain.RateLimit{}, errors.ErrUnsupported
+		}
+		return rl.RateLimit(ctx, repo)
+	})
+}
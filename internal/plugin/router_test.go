@@ -0,0 +1,188 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// stubReader lists one PR numbered after it, or fails with err.
+type stubReader struct {
+	domain.PRReader
+	number int
+	err    error
+	calls  int
+}
+
+func (s *stubReader) ListPRs(context.Context, domain.RepoRef, domain.ListOpts) ([]domain.PR, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return []domain.PR{{Number: s.number}}, nil
+}
+
+// stubPager also paginates with cursors.
+type stubPager struct{ stubReader }
+
+func (s *stubPager) ListPRPage(context.Context, domain.RepoRef, domain.ListOpts) (domain.PRPage, error) {
+	s.calls++
+	if s.err != nil {
+		return domain.PRPage{}, s.err
+	}
+	return domain.PRPage{PRs: []domain.PR{{Number: s.number}}, NextCursor: "next", HasMore: true}, nil
+}
+
+// stubWriter records the repos it merged, failing with err.
+type stubWriter struct {
+	domain.PRWriter
+	merged []domain.RepoRef
+	err    error
+}
+
+func (s *stubWriter) Merge(_ context.Context, repo domain.RepoRef, _ int, _ domain.MergeOpts) error {
+	s.merged = append(s.merged, repo)
+	return s.err
+}
+
+// hosts is a HostSupporter for a fixed list of hosts.
+type hosts []string
+
+func (h hosts) SupportsHost(host string) bool { return slices.Contains(h, host) }
+
+func listed(t *testing.T, r *Router, repo domain.RepoRef) int {
+	t.Helper()
+	prs, err := r.ListPRs(context.Background(), repo, domain.ListOpts{})
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	return prs[0].Number
+}
+
+func TestRouteMatches(t *testing.T) {
+	repo := domain.RepoRef{Owner: "Acme", Name: "api"}
+	ghe := domain.RepoRef{Host: "ghe.example.com", Owner: "acme", Name: "api"}
+
+	assert.True(t, Route{Pattern: "github.com"}.matches(repo), "repos without a host are on github.com")
+	assert.True(t, Route{Pattern: "github.com/acme/*"}.matches(repo), "owners match ignoring case")
+	assert.True(t, Route{Pattern: "*/acme/api"}.matches(ghe))
+	assert.True(t, Route{Pattern: "ghe.example.com"}.matches(ghe))
+	assert.False(t, Route{Pattern: "ghe.example.com"}.matches(repo))
+	assert.False(t, Route{Pattern: "github.com/other"}.matches(repo))
+	assert.False(t, Route{Pattern: "a/b/c/d"}.matches(repo))
+}
+
+func TestRouterRoutesByRepo(t *testing.T) {
+	public := &stubReader{number: 1}
+	enterprise := &stubReader{number: 2}
+	r := NewRouter(
+		[]Backend{{Name: "ghcli", Reader: public}, {Name: "github-api", Reader: enterprise}},
+		[]Route{{Pattern: "ghe.example.com", Backend: "github-api"}},
+	)
+
+	assert.Equal(t, 2, listed(t, r, domain.RepoRef{Host: "ghe.example.com", Owner: "o", Name: "r"}))
+	assert.Equal(t, 1, listed(t, r, domain.RepoRef{Owner: "o", Name: "r"}), "unrouted repos go to the first reader")
+}
+
+func TestRouterFailsOverOnTransientErrors(t *testing.T) {
+	down := &stubReader{err: &domain.HostError{Kind: domain.ErrNetwork}}
+	up := &stubReader{number: 2}
+	r := NewRouter([]Backend{{Name: "down", Reader: down}, {Name: "up", Reader: up, Hosts: hosts{"github.com"}}}, nil)
+	repo := domain.RepoRef{Owner: "o", Name: "r"}
+
+	assert.Equal(t, 2, listed(t, r, repo))
+
+	down.err = domain.ErrNotFound
+	_, err := r.ListPRs(context.Background(), repo, domain.ListOpts{})
+	require.ErrorIs(t, err, domain.ErrNotFound, "final errors are not failed over")
+	assert.Equal(t, 1, up.calls)
+
+	up.err = domain.ErrRateLimited
+	down.err = domain.ErrUnavailable
+	_, err = r.ListPRs(context.Background(), repo, domain.ListOpts{})
+	require.ErrorIs(t, err, domain.ErrUnavailable, "the preferred reader's error is returned")
+
+	up.err = domain.ErrNotFound
+	_, err = r.ListPRs(context.Background(), repo, domain.ListOpts{})
+	require.ErrorIs(t, err, domain.ErrUnavailable, "even when a fallback's error is final")
+}
+
+func TestRouterFailsOverOnlyToReadersForTheHost(t *testing.T) {
+	down := &stubReader{err: domain.ErrNetwork}
+	gitlab := &stubReader{number: 2}
+	r := NewRouter(
+		[]Backend{{Name: "ghcli", Reader: down, Hosts: hosts{"github.com"}}, {Name: "gitlab", Reader: gitlab, Hosts: hosts{"gitlab.com"}}},
+		[]Route{{Pattern: "gitlab.com", Backend: "gitlab"}},
+	)
+
+	_, err := r.ListPRs(context.Background(), domain.RepoRef{Owner: "o", Name: "r"}, domain.ListOpts{})
+	require.ErrorIs(t, err, domain.ErrNetwork)
+	assert.Zero(t, gitlab.calls, "a github.com repo never fails over to gitlab")
+
+	assert.Equal(t, 2, listed(t, r, domain.RepoRef{Host: "gitlab.com", Owner: "o", Name: "r"}))
+	down.calls = 0
+	gitlab.err = domain.ErrNetwork
+	_, err = r.ListPRs(context.Background(), domain.RepoRef{Host: "gitlab.com", Owner: "o", Name: "r"}, domain.ListOpts{})
+	require.ErrorIs(t, err, domain.ErrNetwork)
+	assert.Zero(t, down.calls, "a gitlab.com repo never fails over to gh")
+}
+
+func TestRouterPagesNeverFailOverMidListing(t *testing.T) {
+	pager := &stubPager{stubReader{number: 1}}
+	plain := &stubReader{number: 2}
+	r := NewRouter([]Backend{{Name: "pager", Reader: pager}, {Name: "plain", Reader: plain, Hosts: hosts{"github.com"}}}, nil)
+	repo := domain.RepoRef{Owner: "o", Name: "r"}
+
+	page, err := r.ListPRPage(context.Background(), repo, domain.ListOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, "next", page.NextCursor)
+
+	pager.err = domain.ErrNetwork
+	_, err = r.ListPRPage(context.Background(), repo, domain.ListOpts{Cursor: "next"})
+	require.ErrorIs(t, err, domain.ErrNetwork)
+	assert.Zero(t, plain.calls)
+
+	page, err = r.ListPRPage(context.Background(), repo, domain.ListOpts{PerPage: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 2, page.PRs[0].Number, "a first page fails over to a reader without cursors")
+	assert.True(t, page.HasMore)
+}
+
+func TestRouterSkipsReadersWithoutOptionalCapabilities(t *testing.T) {
+	r := NewRouter([]Backend{{Name: "plain", Reader: &stubReader{}}}, nil)
+	_, err := r.GetCIStatuses(context.Background(), domain.RepoRef{Owner: "o", Name: "r"}, []int{1})
+	require.Error(t, err)
+	_, err = r.GetCommits(context.Background(), domain.RepoRef{Owner: "o", Name: "r"}, 1)
+	require.ErrorIs(t, err, errors.ErrUnsupported)
+}
+
+func TestRouterRoutesWritesWithoutFailover(t *testing.T) {
+	gh := &stubWriter{err: domain.ErrNetwork}
+	gitlab := &stubWriter{}
+	r := NewRouter(
+		[]Backend{
+			{Name: "ghcli", Writer: gh, Hosts: hosts{"github.com"}},
+			{Name: "gitlab", Writer: gitlab, Hosts: hosts{"gitlab.com"}},
+			{Name: "reader-only", Reader: &stubReader{}},
+		},
+		[]Route{{Pattern: "gitlab.com", Backend: "gitlab"}, {Pattern: "forge.example.com", Backend: "reader-only"}},
+	)
+	ctx := context.Background()
+	hub := domain.RepoRef{Owner: "o", Name: "r"}
+	lab := domain.RepoRef{Host: "gitlab.com", Owner: "o", Name: "r"}
+
+	require.NoError(t, r.Merge(ctx, lab, 1, domain.MergeOpts{}))
+	assert.Equal(t, []domain.RepoRef{lab}, gitlab.merged, "writes follow routes")
+
+	require.ErrorIs(t, r.Merge(ctx, hub, 1, domain.MergeOpts{}), domain.ErrNetwork)
+	assert.Equal(t, []domain.RepoRef{hub}, gh.merged)
+	assert.Len(t, gitlab.merged, 1, "writes never fail over")
+
+	err := r.Merge(ctx, domain.RepoRef{Host: "forge.example.com", Owner: "o", Name: "r"}, 1, domain.MergeOpts{})
+	require.ErrorIs(t, err, errors.ErrUnsupported, "a routed backend that cannot write is not replaced by one for another host")
+}
@@ -0,0 +1,307 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/logging"
+)
+
+var (
+	_ domain.PRReviewer         = (*Router)(nil)
+	_ domain.CommentManager     = (*Router)(nil)
+	_ domain.PRWriter           = (*Router)(nil)
+	_ domain.MergeStatusReader  = (*Router)(nil)
+	_ domain.MetadataEditor     = (*Router)(nil)
+	_ domain.PRLifecycleManager = (*Router)(nil)
+	_ domain.PRCreator          = (*Router)(nil)
+	_ domain.RepoManager        = (*Router)(nil)
+)
+
+// target returns the capability that get finds on the preferred backend
+// for repo. Writes never fail over, so only that backend is ever asked.
+func target[T any](r *Router, op string, repo domain.RepoRef, get func(Backend) (T, bool)) (T, error) {
+	has := func(b Backend) bool {
+		_, ok := get(b)
+		return ok
+	}
+	if c := r.candidates(repo, has); len(c) > 0 {
+		logging.Log.Debug("pr backend", "op", op, "repo", repo.String(), "backend", c[0].Name)
+		v, _ := get(c[0])
+		return v, nil
+	}
+	var zero T
+	return zero, fmt.Errorf("%s: no backend for %s: %w", op, repo, errors.ErrUnsupported)
+}
+
+func reviewer(b Backend) (domain.PRReviewer, bool) { return b.Reviewer, b.Reviewer != nil }
+
+func commentManager(b Backend) (domain.CommentManager, bool) {
+	cm, ok := b.Reviewer.(domain.CommentManager)
+	return cm, ok
+}
+
+func writer(b Backend) (domain.PRWriter, bool) { return b.Writer, b.Writer != nil }
+
+func mergeStatusReader(b Backend) (domain.MergeStatusReader, bool) {
+	ms, ok := b.Writer.(domain.MergeStatusReader)
+	return ms, ok
+}
+
+func metadataEditor(b Backend) (domain.MetadataEditor, bool) {
+	me, ok := b.Writer.(domain.MetadataEditor)
+	return me, ok
+}
+
+func lifecycleManager(b Backend) (domain.PRLifecycleManager, bool) {
+	lm, ok := b.Writer.(domain.PRLifecycleManager)
+	return lm, ok
+}
+
+func creator(b Backend) (domain.PRCreator, bool) {
+	pc, ok := b.Writer.(domain.PRCreator)
+	return pc, ok
+}
+
+func repoManager(b Backend) (domain.RepoManager, bool) { return b.RepoManager, b.RepoManager != nil }
+
+// SubmitReview submits a review with the backend for repo.
+func (r *Router) SubmitReview(ctx context.Context, repo domain.RepoRef, number int, review domain.Review) error {
+	rv, err := target(r, "SubmitReview", repo, reviewer)
+	if err != nil {
+		return err
+	}
+	return rv.SubmitReview(ctx, repo, number, review)
+}
+
+// AddComment adds an inline comment with the backend for repo.
+func (r *Router) AddComment(ctx context.Context, repo domain.RepoRef, number int, input domain.InlineCommentInput) error {
+	rv, err := target(r, "AddComment", repo, reviewer)
+	if err != nil {
+		return err
+	}
+	return rv.AddComment(ctx, repo, number, input)
+}
+
+// ResolveThread resolves a review thread with the backend for repo.
+func (r *Router) ResolveThread(ctx context.Context, repo domain.RepoRef, threadID string) error {
+	rv, err := target(r, "ResolveThread", repo, reviewer)
+	if err != nil {
+		return err
+	}
+	return rv.ResolveThread(ctx, repo, threadID)
+}
+
+// AddPRComment posts a timeline comment with the backend for repo.
+func (r *Router) AddPRComment(ctx context.Context, repo domain.RepoRef, number int, body string) error {
+	cm, err := target(r, "AddPRComment", repo, commentManager)
+	if err != nil {
+		return err
+	}
+	return cm.AddPRComment(ctx, repo, number, body)
+}
+
+// EditComment edits a comment with the backend for repo.
+func (r *Router) EditComment(ctx context.Context, repo domain.RepoRef, ref domain.CommentRef, body string) error {
+	cm, err := target(r, "EditComment", repo, commentManager)
+	if err != nil {
+		return err
+	}
+	return cm.EditComment(ctx, repo, ref, body)
+}
+
+// DeleteComment deletes a comment with the backend for repo.
+func (r *Router) DeleteComment(ctx context.Context, repo domain.RepoRef, ref domain.CommentRef) error {
+	cm, err := target(r, "DeleteComment", repo, commentManager)
+	if err != nil {
+		return err
+	}
+	return cm.DeleteComment(ctx, repo, ref)
+}
+
+// UnresolveThread unresolves a review thread with the backend for repo.
+func (r *Router) UnresolveThread(ctx context.Context, repo domain.RepoRef, threadID string) error {
+	cm, err := target(r, "UnresolveThread", repo, commentManager)
+	if err != nil {
+		return err
+	}
+	return cm.UnresolveThread(ctx, repo, threadID)
+}
+
+// AddReaction reacts to a comment with the backend for repo.
+func (r *Router) AddReaction(ctx context.Context, repo domain.RepoRef, ref domain.CommentRef, content domain.ReactionContent) error {
+	cm, err := target(r, "AddReaction", repo, commentManager)
+	if err != nil {
+		return err
+	}
+	return cm.AddReaction(ctx, repo, ref, content)
+}
+
+// RemoveReaction removes a reaction with the backend for repo.
+func (r *Router) RemoveReaction(ctx context.Context, repo domain.RepoRef, ref domain.CommentRef, content domain.ReactionContent) error {
+	cm, err := target(r, "RemoveReaction", repo, commentManager)
+	if err != nil {
+		return err
+	}
+	return cm.RemoveReaction(ctx, repo, ref, content)
+}
+
+// Checkout checks out a PR with the backend for repo.
+func (r *Router) Checkout(ctx context.Context, repo domain.RepoRef, number int) (string, error) {
+	w, err := target(r, "Checkout", repo, writer)
+	if err != nil {
+		return "", err
+	}
+	return w.Checkout(ctx, repo, number)
+}
+
+// Merge merges a PR with the backend for repo.
+func (r *Router) Merge(ctx context.Context, repo domain.RepoRef, number int, opts domain.MergeOpts) error {
+	w, err := target(r, "Merge", repo, writer)
+	if err != nil {
+		return err
+	}
+	return w.Merge(ctx, repo, number, opts)
+}
+
+// GetMergeStatus reports a PR's merge status with the backend for repo.
+func (r *Router) GetMergeStatus(ctx context.Context, repo domain.RepoRef, number int) (*domain.MergeStatus, error) {
+	ms, err := target(r, "GetMergeStatus", repo, mergeStatusReader)
+	if err != nil {
+		return nil, err
+	}
+	return ms.GetMergeStatus(ctx, repo, number)
+}
+
+// GetMetadataOptions lists labels, users and teams with the backend for
+// repo.
+func (r *Router) GetMetadataOptions(ctx context.Context, repo domain.RepoRef) (*domain.MetadataOptions, error) {
+	me, err := target(r, "GetMetadataOptions", repo, metadataEditor)
+	if err != nil {
+		return nil, err
+	}
+	return me.GetMetadataOptions(ctx, repo)
+}
+
+// EditMetadata edits a PR's metadata with the backend for repo.
+func (r *Router) EditMetadata(ctx context.Context, repo domain.RepoRef, number int, edit domain.MetadataEdit) error {
+	me, err := target(r, "EditMetadata", repo, metadataEditor)
+	if err != nil {
+		return err
+	}
+	return me.EditMetadata(ctx, repo, number, edit)
+}
+
+// ClosePR closes a PR with the backend for repo.
+func (r *Router) ClosePR(ctx context.Context, repo domain.RepoRef, number int, comment string) error {
+	lm, err := target(r, "ClosePR", repo, lifecycleManager)
+	if err != nil {
+		return err
+	}
+	return lm.ClosePR(ctx, repo, number, comment)
+}
+
+// ReopenPR reopens a PR with the backend for repo.
+func (r *Router) ReopenPR(ctx context.Context, repo domain.RepoRef, number int, comment string) error {
+	lm, err := target(r, "ReopenPR", repo, lifecycleManager)
+	if err != nil {
+		return err
+	}
+	return lm.ReopenPR(ctx, repo, number, comment)
+}
+
+// SetDraft converts a PR to or from a draft with the backend for repo.
+func (r *Router) SetDraft(ctx context.Context, repo domain.RepoRef, number int, draft bool) error {
+	lm, err := target(r, "SetDraft", repo, lifecycleManager)
+	if err != nil {
+		return err
+	}
+	return lm.SetDraft(ctx, repo, number, draft)
+}
+
+// UpdateBranch updates a PR's head branch with the backend for repo.
+func (r *Router) UpdateBranch(ctx context.Context, repo domain.RepoRef, number int, method string) error {
+	lm, err := target(r, "UpdateBranch", repo, lifecycleManager)
+	if err != nil {
+		return err
+	}
+	return lm.UpdateBranch(ctx, repo, number, method)
+}
+
+// ListBranches lists repo's branches with the backend for repo.
+func (r *Router) ListBranches(ctx context.Context, repo domain.RepoRef) (*domain.RepoBranches, error) {
+	pc, err := target(r, "ListBranches", repo, creator)
+	if err != nil {
+		return nil, err
+	}
+	return pc.ListBranches(ctx, repo)
+}
+
+// CompareBranch compares two branches of the clone at dir. The comparison
+// is local, so any backend that can open PRs serves it.
+func (r *Router) CompareBranch(ctx context.Context, dir, base, head string) (*domain.BranchComparison, error) {
+	pc, err := r.localCreator("CompareBranch")
+	if err != nil {
+		return nil, err
+	}
+	return pc.CompareBranch(ctx, dir, base, head)
+}
+
+// PushBranch pushes a branch of the clone at dir to its remote. The push is
+// a local git operation, so any backend that can open PRs serves it.
+func (r *Router) PushBranch(ctx context.Context, dir, branch string) error {
+	pc, err := r.localCreator("PushBranch")
+	if err != nil {
+		return err
+	}
+	return pc.PushBranch(ctx, dir, branch)
+}
+
+// localCreator returns the first backend that can open PRs, for the git
+// operations of PRCreator that do not involve a host.
+func (r *Router) localCreator(op string) (domain.PRCreator, error) {
+	for _, b := range r.backends {
+		if pc, ok := creator(b); ok {
+			return pc, nil
+		}
+	}
+	return nil, fmt.Errorf("%s: no backend: %w", op, errors.ErrUnsupported)
+}
+
+// CreatePR opens a PR with the backend for repo.
+func (r *Router) CreatePR(ctx context.Context, repo domain.RepoRef, pr domain.NewPR) (int, error) {
+	pc, err := target(r, "CreatePR", repo, creator)
+	if err != nil {
+		return 0, err
+	}
+	return pc.CreatePR(ctx, repo, pr)
+}
+
+// CheckoutAt checks out a PR in workDir with the backend for repo.
+func (r *Router) CheckoutAt(ctx context.Context, repo domain.RepoRef, number int, workDir string) (string, error) {
+	rm, err := target(r, "CheckoutAt", repo, repoManager)
+	if err != nil {
+		return "", err
+	}
+	return rm.CheckoutAt(ctx, repo, number, workDir)
+}
+
+// CloneRepo clones repo with the backend for repo.
+func (r *Router) CloneRepo(ctx context.Context, repo domain.RepoRef, targetPath string) error {
+	rm, err := target(r, "CloneRepo", repo, repoManager)
+	if err != nil {
+		return err
+	}
+	return rm.CloneRepo(ctx, repo, targetPath)
+}
+
+// CreateWorktree adds a worktree for a PR with the backend for repo.
+func (r *Router) CreateWorktree(ctx context.Context, repo domain.RepoRef, repoPath string, number int, branch, worktreePath string) error {
+	rm, err := target(r, "CreateWorktree", repo, repoManager)
+	if err != nil {
+		return err
+	}
+	return rm.CreateWorktree(ctx, repo, repoPath, number, branch, worktreePath)
+}
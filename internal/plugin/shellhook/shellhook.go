@@ -0,0 +1,145 @@
+// Package shellhook runs the shell commands configured under [hooks] in
+// config.toml when the user acts on a PR. Each command runs with sh -c and
+// reads the plugin.ActionEvent as JSON on stdin; its output and failures are
+// shown as toasts and written to the debug log.
+package shellhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/indrasvat/vivecaka/internal/config"
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/logging"
+	"github.com/indrasvat/vivecaka/internal/plugin"
+)
+
+const (
+	// maxToastLen bounds how much command output a toast shows; the debug
+	// log has all of it.
+	maxToastLen = 200
+	// waitDelay is how long a timed-out command's output pipes stay open,
+	// so a background child holding them cannot hang the hook.
+	waitDelay = time.Second
+)
+
+// Plugin subscribes the configured commands to their action hooks.
+type Plugin struct {
+	commands map[plugin.HookPoint]string
+	timeout  time.Duration
+
+	mu  sync.Mutex
+	app plugin.AppContext
+}
+
+var _ plugin.HookPlugin = (*Plugin)(nil)
+
+// New creates the plugin for cfg.
+func New(cfg config.HooksConfig) *Plugin {
+	p := &Plugin{
+		commands: make(map[plugin.HookPoint]string),
+		timeout:  time.Duration(cfg.Timeout) * time.Second,
+	}
+	for event, cmd := range cfg.Commands() {
+		p.commands[plugin.HookPoint(event)] = cmd
+	}
+	return p
+}
+
+// Info returns plugin metadata.
+func (p *Plugin) Info() plugin.PluginInfo {
+	return plugin.PluginInfo{
+		Name:        "shell-hooks",
+		Version:     "1.0.0",
+		Description: "Runs the shell commands configured under [hooks]",
+		Provides:    []string{"hooks"},
+	}
+}
+
+// Init keeps the app context to show command results.
+func (p *Plugin) Init(app plugin.AppContext) tea.Cmd {
+	p.mu.Lock()
+	p.app = app
+	p.mu.Unlock()
+	return nil
+}
+
+// Hooks subscribes each configured command, in ActionHooks order.
+func (p *Plugin) Hooks() []plugin.HookRegistration {
+	var regs []plugin.HookRegistration
+	for _, point := range plugin.ActionHooks {
+		command, ok := p.commands[point]
+		if !ok {
+			continue
+		}
+		regs = append(regs, plugin.HookRegistration{
+			Point: point,
+			Handler: func(ctx context.Context, data any) error {
+				p.run(ctx, point, command, data)
+				return nil
+			},
+		})
+	}
+	return regs
+}
+
+// run runs command for an event and reports the outcome. Failures are not
+// returned: a broken hook must not stop other handlers.
+func (p *Plugin) run(ctx context.Context, point plugin.HookPoint, command string, data any) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		p.report(point, "", fmt.Errorf("encoding event: %w", err))
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command) //nolint:gosec // the user's own configured command
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(), "VIVECAKA_EVENT="+string(point))
+	cmd.WaitDelay = waitDelay
+	if event, ok := data.(plugin.ActionEvent); ok && event.Path != "" {
+		cmd.Dir = event.Path // run post-checkout commands in the checkout
+	}
+	start := time.Now()
+	out, err := cmd.CombinedOutput()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("timed out after %s", p.timeout)
+	}
+	logging.Log.Debug("shell hook ran", "hook", point, "command", command,
+		"elapsed", time.Since(start), "output", string(out), "err", err)
+	p.report(point, strings.TrimSpace(string(out)), err)
+}
+
+// report shows a command's output, or its failure, as a toast.
+func (p *Plugin) report(point plugin.HookPoint, output string, err error) {
+	p.mu.Lock()
+	app := p.app
+	p.mu.Unlock()
+	if app == nil {
+		return
+	}
+	msg := plugin.NotifyMsg{Message: fmt.Sprintf("%s hook: %s", point, output), Level: domain.ToastInfo}
+	switch {
+	case err != nil && output != "":
+		msg = plugin.NotifyMsg{Message: fmt.Sprintf("%s hook failed: %v: %s", point, err, output), Level: domain.ToastError}
+	case err != nil:
+		msg = plugin.NotifyMsg{Message: fmt.Sprintf("%s hook failed: %v", point, err), Level: domain.ToastError}
+	case output == "":
+		return
+	}
+	if r := []rune(msg.Message); len(r) > maxToastLen {
+		msg.Message = string(r[:maxToastLen-1]) + "…"
+	}
+	app.SendMessage(msg)
+}
@@ -0,0 +1,138 @@
+package shellhook
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/indrasvat/vivecaka/internal/config"
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/plugin"
+)
+
+// recordingApp is an AppContext that records sent messages.
+type recordingApp struct {
+	mu   sync.Mutex
+	msgs []tea.Msg
+}
+
+func (a *recordingApp) ConfigValue(string) any      { return nil }
+func (a *recordingApp) ThemeName() string           { return "" }
+func (a *recordingApp) CurrentRepo() domain.RepoRef { return domain.RepoRef{} }
+
+func (a *recordingApp) SendMessage(msg tea.Msg) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.msgs = append(a.msgs, msg)
+}
+
+func (a *recordingApp) sent() []tea.Msg {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]tea.Msg(nil), a.msgs...)
+}
+
+// last returns the last toast the plugin asked for.
+func (a *recordingApp) last() plugin.NotifyMsg {
+	sent := a.sent()
+	return sent[len(sent)-1].(plugin.NotifyMsg)
+}
+
+func newPlugin(cfg config.HooksConfig) *Plugin {
+	cfg.Timeout = 5
+	return New(cfg)
+}
+
+// handler returns the plugin's only hook handler.
+func handler(t *testing.T, p *Plugin) plugin.HookHandler {
+	t.Helper()
+	regs := p.Hooks()
+	require.Len(t, regs, 1)
+	return regs[0].Handler
+}
+
+var event = plugin.ActionEvent{
+	Event:  plugin.HookReviewSubmitted,
+	Repo:   domain.RepoRef{Owner: "o", Name: "r"},
+	PR:     domain.PR{Number: 7, Title: "Fix"},
+	Review: &domain.Review{Action: domain.ReviewActionApprove},
+}
+
+func TestHooksOnlyForConfiguredEvents(t *testing.T) {
+	p := newPlugin(config.HooksConfig{CheckoutDone: "true", ThreadResolved: "true", PROpened: " "})
+	var points []plugin.HookPoint
+	for _, r := range p.Hooks() {
+		points = append(points, r.Point)
+	}
+	assert.Equal(t, []plugin.HookPoint{plugin.HookCheckoutDone, plugin.HookThreadResolved}, points)
+}
+
+func TestCommandReadsEventOnStdin(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "event.json")
+	p := newPlugin(config.HooksConfig{ReviewSubmitted: `cat > ` + out + `; echo "logged $VIVECAKA_EVENT"`})
+	app := &recordingApp{}
+	p.Init(app)
+
+	require.NoError(t, handler(t, p)(context.Background(), event))
+
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	var got plugin.ActionEvent
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, event.PR.Number, got.PR.Number)
+	assert.Equal(t, domain.ReviewActionApprove, got.Review.Action)
+	assert.Equal(t, plugin.NotifyMsg{Message: "review_submitted hook: logged review_submitted", Level: domain.ToastInfo}, app.last())
+}
+
+func TestCheckoutCommandRunsInCheckout(t *testing.T) {
+	dir := t.TempDir()
+	p := newPlugin(config.HooksConfig{CheckoutDone: "pwd"})
+	app := &recordingApp{}
+	p.Init(app)
+
+	require.NoError(t, handler(t, p)(context.Background(), plugin.ActionEvent{Event: plugin.HookCheckoutDone, Path: dir}))
+	resolved, err := filepath.EvalSymlinks(dir)
+	require.NoError(t, err)
+	assert.Contains(t, app.last().Message, resolved)
+}
+
+func TestFailuresBecomeErrorToasts(t *testing.T) {
+	p := newPlugin(config.HooksConfig{ReviewSubmitted: "echo boom >&2; exit 3"})
+	app := &recordingApp{}
+	p.Init(app)
+
+	require.NoError(t, handler(t, p)(context.Background(), event), "a failing command never fails the hook")
+	msg := app.last()
+	assert.Equal(t, domain.ToastError, msg.Level)
+	assert.Contains(t, msg.Message, "exit status 3")
+	assert.Contains(t, msg.Message, "boom")
+}
+
+func TestTimeout(t *testing.T) {
+	p := newPlugin(config.HooksConfig{ReviewSubmitted: "sleep 10"})
+	p.timeout = 50 * time.Millisecond
+	app := &recordingApp{}
+	p.Init(app)
+
+	start := time.Now()
+	require.NoError(t, handler(t, p)(context.Background(), event))
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Contains(t, app.last().Message, "timed out after 50ms")
+}
+
+func TestSilentSuccessShowsNothing(t *testing.T) {
+	p := newPlugin(config.HooksConfig{ReviewSubmitted: "true"})
+	app := &recordingApp{}
+	p.Init(app)
+
+	require.NoError(t, handler(t, p)(context.Background(), event))
+	assert.Empty(t, app.sent())
+}
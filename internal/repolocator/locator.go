@@ -116,7 +116,12 @@ func (l *Locator) All() ([]domain.RepoLocation, error) {
 
 // CacheClonePath returns the deterministic managed clone path for a repo.
 func (l *Locator) CacheClonePath(repo domain.RepoRef) string {
-	return filepath.Join(config.CacheDir(), "clones", repo.Owner, repo.Name)
+	if repo.IsDefaultHost() {
+		return filepath.Join(config.CacheDir(), "clones", repo.Owner, repo.Name)
+	}
+	// Enterprise clones live under their host so same-named repos on
+	// different instances never share a checkout.
+	return filepath.Join(config.CacheDir(), "clones", repo.HostName(), repo.Owner, repo.Name)
 }
 
 // withLock acquires an exclusive file lock around read-modify-write operations.
@@ -175,7 +180,7 @@ func (l *Locator) save(entries []domain.RepoLocation) error {
 
 // repoEqual compares two RepoRefs case-insensitively (GitHub is case-insensitive).
 func repoEqual(a, b domain.RepoRef) bool {
-	return strings.EqualFold(a.Owner, b.Owner) && strings.EqualFold(a.Name, b.Name)
+	return a.Equal(b)
 }
 
 // isValidRepoDir checks if a path exists and contains a git repo matching the expected remote.
@@ -194,6 +199,9 @@ func isValidRepoDir(path string, expected domain.RepoRef) bool {
 	}
 	remote := strings.TrimSpace(string(out))
 	// Match either SSH or HTTPS remote format.
-	expectedStr := strings.ToLower(expected.Owner + "/" + expected.Name)
-	return strings.Contains(strings.ToLower(remote), expectedStr)
+	remote = strings.ToLower(remote)
+	if !expected.IsDefaultHost() && !strings.Contains(remote, expected.HostName()) {
+		return false
+	}
+	return strings.Contains(remote, strings.ToLower(expected.FullName()))
 }
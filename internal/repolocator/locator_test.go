@@ -101,6 +101,36 @@ func TestCaseInsensitiveLookup(t *testing.T) {
 	assert.Equal(t, "/path", path)
 }
 
+func TestLookupDistinguishesHosts(t *testing.T) {
+	loc := testLocator(t)
+	dotcom := domain.RepoRef{Owner: "org", Name: "repo"}
+	ghes := domain.RepoRef{Host: "ghe.example.com", Owner: "org", Name: "repo"}
+
+	require.NoError(t, loc.Register(dotcom, "/dotcom", "detected"))
+	require.NoError(t, loc.Register(ghes, "/ghes", "detected"))
+
+	path, found := loc.Lookup(dotcom)
+	assert.True(t, found)
+	assert.Equal(t, "/dotcom", path)
+
+	path, found = loc.Lookup(ghes)
+	assert.True(t, found)
+	assert.Equal(t, "/ghes", path)
+
+	entries, err := loc.All()
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestCacheClonePathIncludesEnterpriseHost(t *testing.T) {
+	loc := testLocator(t)
+	dotcom := loc.CacheClonePath(domain.RepoRef{Owner: "org", Name: "repo"})
+	ghes := loc.CacheClonePath(domain.RepoRef{Host: "ghe.example.com", Owner: "org", Name: "repo"})
+
+	clonesDir := filepath.Dir(filepath.Dir(dotcom))
+	assert.Equal(t, filepath.Join(clonesDir, "ghe.example.com", "org", "repo"), ghes)
+}
+
 func TestCacheClonePath(t *testing.T) {
 	loc := testLocator(t)
 	repo := domain.RepoRef{Owner: "steipete", Name: "CodexBar"}
@@ -0,0 +1,224 @@
+package reviewprogress
+
+import "github.com/indrasvat/vivecaka/internal/domain"
+
+// interdiffContext is the number of unchanged patch lines kept around each
+// change of an interdiff.
+const interdiffContext = 3
+
+// maxInterdiffCells bounds the line matching of one file's patches. Larger
+// files are shown as the old patch replaced by the new one.
+const maxInterdiffCells = 1 << 22
+
+// Interdiff returns what changed between two versions of a PR's patch,
+// before and after a push, each taken against its own merge base, as git
+// range-diff does: the lines of the patches are compared, not the files.
+// Added lines are in the new patch only, deleted lines in the old one. Each
+// line's content starts with its marker in the patch ('+', '-' or ' '), and
+// its numbers are its line numbers in the file.
+//
+// Context lines of the patches are never reported as changed, so a rebase
+// that moved the base only shows where it changed the PR's own lines.
+// Files whose patch is unchanged are left out.
+func Interdiff(before, after *domain.Diff) *domain.Diff {
+	out := &domain.Diff{}
+	oldFiles := make(map[string]domain.FileDiff)
+	if before != nil {
+		for _, f := range before.Files {
+			oldFiles[f.Path] = f
+		}
+	}
+	matched := make(map[string]bool)
+	if after != nil {
+		for _, f := range after.Files {
+			prev, ok := oldFiles[f.Path]
+			if !ok && f.OldPath != "" {
+				prev, ok = oldFiles[f.OldPath]
+			}
+			if ok {
+				matched[prev.Path] = true
+			}
+			if hunks := interdiffHunks(patchLines(prev), patchLines(f)); len(hunks) > 0 {
+				out.Files = append(out.Files, domain.FileDiff{Path: f.Path, OldPath: f.OldPath, Hunks: hunks})
+			}
+		}
+	}
+	if before != nil {
+		for _, f := range before.Files {
+			if matched[f.Path] {
+				continue
+			}
+			if hunks := interdiffHunks(patchLines(f), nil); len(hunks) > 0 {
+				out.Files = append(out.Files, domain.FileDiff{Path: f.Path, OldPath: f.OldPath, Hunks: hunks})
+			}
+		}
+	}
+	return out
+}
+
+// patchLine is one line of a file's patch with the header of its hunk.
+type patchLine struct {
+	domain.DiffLine
+	header string
+}
+
+// num is the line's number in the file it belongs to.
+func (l patchLine) num() int {
+	if l.Type == domain.DiffDelete {
+		return l.OldNum
+	}
+	return l.NewNum
+}
+
+func patchMarker(t domain.DiffLineType) byte {
+	switch t {
+	case domain.DiffAdd:
+		return '+'
+	case domain.DiffDelete:
+		return '-'
+	default:
+		return ' '
+	}
+}
+
+func patchLines(f domain.FileDiff) []patchLine {
+	var lines []patchLine
+	for _, h := range f.Hunks {
+		for _, l := range h.Lines {
+			lines = append(lines, patchLine{DiffLine: l, header: h.Header})
+		}
+	}
+	return lines
+}
+
+// interdiffOp is one step of the edit script from the old patch to the new.
+type interdiffOp struct {
+	kind domain.DiffLineType
+	line patchLine
+	old  int // line number in the old patch's file, for context
+}
+
+// interdiffHunks groups the changes between two patches of a file into
+// hunks with interdiffContext lines around them.
+func interdiffHunks(before, after []patchLine) []domain.Hunk {
+	ops := interdiffOps(before, after)
+	var changes []int
+	for i, op := range ops {
+		if op.kind != domain.DiffContext {
+			changes = append(changes, i)
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	var hunks []domain.Hunk
+	for i := 0; i < len(changes); {
+		from := max(0, changes[i]-interdiffContext)
+		to := changes[i]
+		for i < len(changes) && changes[i]-to <= 2*interdiffContext {
+			to = changes[i]
+			i++
+		}
+		to = min(len(ops), to+interdiffContext+1)
+
+		h := domain.Hunk{Header: ops[from].line.header}
+		for _, op := range ops[from:to] {
+			l := domain.DiffLine{Type: op.kind, Content: string(patchMarker(op.line.Type)) + op.line.Content}
+			switch op.kind {
+			case domain.DiffAdd:
+				l.NewNum = op.line.num()
+			case domain.DiffDelete:
+				l.OldNum = op.line.num()
+			default:
+				l.OldNum, l.NewNum = op.old, op.line.num()
+			}
+			h.Lines = append(h.Lines, l)
+		}
+		hunks = append(hunks, h)
+	}
+	return hunks
+}
+
+// interdiffOps matches the lines of two patches of a file. Patch context
+// lines that only one side has are rebase noise: the old ones are dropped
+// and the new ones kept as context.
+func interdiffOps(before, after []patchLine) []interdiffOp {
+	var ops []interdiffOp
+	emit := func(kind domain.DiffLineType, l patchLine, oldNum int) {
+		if l.Type == domain.DiffContext && kind != domain.DiffContext {
+			if kind == domain.DiffDelete {
+				return
+			}
+			kind, oldNum = domain.DiffContext, 0
+		}
+		ops = append(ops, interdiffOp{kind: kind, line: l, old: oldNum})
+	}
+	keys := func(lines []patchLine) []string {
+		out := make([]string, len(lines))
+		for i, l := range lines {
+			out[i] = string(patchMarker(l.Type)) + l.Content
+		}
+		return out
+	}
+	ka, kb := keys(before), keys(after)
+
+	prefix := 0
+	for prefix < len(ka) && prefix < len(kb) && ka[prefix] == kb[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(ka)-prefix && suffix < len(kb)-prefix && ka[len(ka)-1-suffix] == kb[len(kb)-1-suffix] {
+		suffix++
+	}
+	for i := range prefix {
+		emit(domain.DiffContext, after[i], before[i].num())
+	}
+
+	a, b := before[prefix:len(before)-suffix], after[prefix:len(after)-suffix]
+	ka, kb = ka[prefix:len(ka)-suffix], kb[prefix:len(kb)-suffix]
+	if len(a)*len(b) > maxInterdiffCells {
+		for _, l := range a {
+			emit(domain.DiffDelete, l, 0)
+		}
+		for _, l := range b {
+			emit(domain.DiffAdd, l, 0)
+		}
+	} else {
+		// lcs[i][j] is the length of the longest common subsequence of
+		// a[i:] and b[j:].
+		lcs := make([][]int, len(a)+1)
+		for i := range lcs {
+			lcs[i] = make([]int, len(b)+1)
+		}
+		for i := len(a) - 1; i >= 0; i-- {
+			for j := len(b) - 1; j >= 0; j-- {
+				if ka[i] == kb[j] {
+					lcs[i][j] = lcs[i+1][j+1] + 1
+				} else {
+					lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+				}
+			}
+		}
+		i, j := 0, 0
+		for i < len(a) || j < len(b) {
+			switch {
+			case i < len(a) && j < len(b) && ka[i] == kb[j]:
+				emit(domain.DiffContext, b[j], a[i].num())
+				i++
+				j++
+			case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+				emit(domain.DiffDelete, a[i], 0)
+				i++
+			default:
+				emit(domain.DiffAdd, b[j], 0)
+				j++
+			}
+		}
+	}
+
+	for k := suffix; k > 0; k-- {
+		emit(domain.DiffContext, after[len(after)-k], before[len(before)-k].num())
+	}
+	return ops
+}
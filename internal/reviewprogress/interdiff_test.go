@@ -0,0 +1,94 @@
+package reviewprogress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+func patch(path, header string, start int, lines ...string) domain.FileDiff {
+	h := domain.Hunk{Header: header}
+	oldNum, newNum := start, start
+	for _, l := range lines {
+		line := domain.DiffLine{Content: l[1:]}
+		switch l[0] {
+		case '+':
+			line.Type, line.NewNum = domain.DiffAdd, newNum
+			newNum++
+		case '-':
+			line.Type, line.OldNum = domain.DiffDelete, oldNum
+			oldNum++
+		default:
+			line.Type, line.OldNum, line.NewNum = domain.DiffContext, oldNum, newNum
+			oldNum++
+			newNum++
+		}
+		h.Lines = append(h.Lines, line)
+	}
+	return domain.FileDiff{Path: path, Hunks: []domain.Hunk{h}}
+}
+
+func TestInterdiffIgnoresRebaseNoise(t *testing.T) {
+	before := &domain.Diff{Files: []domain.FileDiff{
+		patch("a.go", "@@ -10,3 +10,4 @@ func A()", 10, " x := 1", "+y := 2", " return x"),
+	}}
+	// Rebased: the base moved the hunk down and changed a context line.
+	after := &domain.Diff{Files: []domain.FileDiff{
+		patch("a.go", "@@ -40,3 +40,4 @@ func A()", 40, " x := 10", "+y := 2", " return x"),
+	}}
+
+	assert.Empty(t, Interdiff(before, after).Files)
+}
+
+func TestInterdiffShowsAmendedLines(t *testing.T) {
+	before := &domain.Diff{Files: []domain.FileDiff{
+		patch("a.go", "@@ -10,3 +10,4 @@", 10, " x := 1", "+y := 2", " return x"),
+		patch("dropped.go", "@@ -1,1 +1,2 @@", 1, " package a", "+var v int"),
+	}}
+	after := &domain.Diff{Files: []domain.FileDiff{
+		patch("a.go", "@@ -12,3 +12,4 @@", 12, " x := 1", "+y := 3", " return x"),
+		patch("new.go", "@@ -0,0 +1,1 @@", 1, "+package a"),
+	}}
+
+	got := Interdiff(before, after)
+	require.Len(t, got.Files, 3)
+
+	a := got.Files[0]
+	assert.Equal(t, "a.go", a.Path)
+	require.Len(t, a.Hunks, 1)
+	assert.Equal(t, "@@ -12,3 +12,4 @@", a.Hunks[0].Header)
+	assert.Equal(t, []domain.DiffLine{
+		{Type: domain.DiffContext, Content: " x := 1", OldNum: 10, NewNum: 12},
+		{Type: domain.DiffDelete, Content: "+y := 2", OldNum: 11},
+		{Type: domain.DiffAdd, Content: "+y := 3", NewNum: 13},
+		{Type: domain.DiffContext, Content: " return x", OldNum: 12, NewNum: 14},
+	}, a.Hunks[0].Lines)
+
+	assert.Equal(t, "new.go", got.Files[1].Path)
+	assert.Equal(t, domain.DiffAdd, got.Files[1].Hunks[0].Lines[0].Type)
+
+	dropped := got.Files[2]
+	assert.Equal(t, "dropped.go", dropped.Path)
+	require.Len(t, dropped.Hunks[0].Lines, 1, "only the PR's own lines of a dropped file")
+	assert.Equal(t, domain.DiffLine{Type: domain.DiffDelete, Content: "+var v int", OldNum: 2}, dropped.Hunks[0].Lines[0])
+}
+
+func TestInterdiffSplitsDistantChanges(t *testing.T) {
+	lines := func(changed string) []string {
+		out := []string{"+" + "first " + changed}
+		for range 10 {
+			out = append(out, "+same")
+		}
+		return append(out, "+last "+changed)
+	}
+	before := &domain.Diff{Files: []domain.FileDiff{patch("a.go", "@@", 1, lines("v1")...)}}
+	after := &domain.Diff{Files: []domain.FileDiff{patch("a.go", "@@", 1, lines("v2")...)}}
+
+	hunks := Interdiff(before, after).Files[0].Hunks
+	require.Len(t, hunks, 2)
+	assert.Len(t, hunks[0].Lines, 2+interdiffContext)
+	assert.Len(t, hunks[1].Lines, interdiffContext+2)
+}
@@ -4,6 +4,7 @@ import (
 	"crypto/sha1" //nolint:gosec // deterministic digest, not for security
 	"encoding/hex"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -49,19 +50,29 @@ func (s Scope) Label() string {
 	}
 }
 
-// File describes one file under the current review context.
+// File describes one file under the current review context. A file is
+// viewed when all of its hunks are.
 type File struct {
 	Path               string
 	Additions          int
 	Deletions          int
 	Status             string
 	PatchDigest        string
+	Hunks              []Hunk
+	ViewedHunks        int
 	Viewed             bool
 	ChangedSinceVisit  bool
 	ChangedSinceReview bool
 	Actionable         bool
 }
 
+// Hunk is the review state of one hunk of a file's patch. Files without
+// a parsed patch have a single hunk standing for the whole file.
+type Hunk struct {
+	Digest string
+	Viewed bool
+}
+
 // Context is the computed incremental review state for a PR revision.
 type Context struct {
 	Scope                Scope
@@ -71,19 +82,23 @@ type Context struct {
 	LastReviewHeadSHA    string
 	ViewedFiles          int
 	TotalFiles           int
+	ViewedHunks          int
+	TotalHunks           int
 	SinceVisitFiles      int
 	SinceReviewFiles     int
 	ActionableFiles      int
 	Files                []File
 	CurrentDigests       map[string]string
+	CurrentHunkDigests   map[string][]string
 	HasReviewBaseline    bool
 	HasVisitBaseline     bool
 	NextActionablePath   string
 	DegradedDigestSource bool
 }
 
-// Build derives a review context from file metadata, current digests, and persisted state.
-func Build(detail *domain.PRDetail, digests map[string]string, state cache.PRReviewState, degraded bool) *Context {
+// Build derives a review context from file metadata, current file and hunk
+// digests, and persisted state.
+func Build(detail *domain.PRDetail, digests map[string]string, hunkDigests map[string][]string, state cache.PRReviewState, degraded bool) *Context {
 	if detail == nil {
 		return nil
 	}
@@ -104,6 +119,7 @@ func Build(detail *domain.PRDetail, digests map[string]string, state cache.PRRev
 		LastReviewHeadSHA:    state.LastReviewHeadSHA,
 		TotalFiles:           len(detail.Files),
 		CurrentDigests:       digests,
+		CurrentHunkDigests:   hunkDigests,
 		HasReviewBaseline:    len(state.LastReviewFiles) > 0 || state.LastReviewHeadSHA != "",
 		HasVisitBaseline:     len(state.LastVisitFiles) > 0 || state.LastVisitHeadSHA != "",
 		DegradedDigestSource: degraded,
@@ -117,10 +133,7 @@ func Build(detail *domain.PRDetail, digests map[string]string, state cache.PRRev
 			ctx.CurrentDigests[fc.Path] = digest
 		}
 
-		viewed := false
-		if snap, ok := state.ViewedFiles[fc.Path]; ok && snap.PatchDigest == digest {
-			viewed = true
-		}
+		hunks, viewedHunks := buildHunks(hunkDigests[fc.Path], digest, state.ViewedFiles[fc.Path])
 
 		changedSinceVisit := false
 		if len(state.LastVisitFiles) > 0 {
@@ -138,7 +151,9 @@ func Build(detail *domain.PRDetail, digests map[string]string, state cache.PRRev
 			Deletions:          fc.Deletions,
 			Status:             fc.Status,
 			PatchDigest:        digest,
-			Viewed:             viewed,
+			Hunks:              hunks,
+			ViewedHunks:        viewedHunks,
+			Viewed:             viewedHunks == len(hunks),
 			ChangedSinceVisit:  changedSinceVisit,
 			ChangedSinceReview: changedSinceReview,
 		}
@@ -146,6 +161,8 @@ func Build(detail *domain.PRDetail, digests map[string]string, state cache.PRRev
 		if file.Viewed {
 			ctx.ViewedFiles++
 		}
+		ctx.ViewedHunks += file.ViewedHunks
+		ctx.TotalHunks += len(file.Hunks)
 		if file.ChangedSinceVisit {
 			ctx.SinceVisitFiles++
 		}
@@ -164,6 +181,60 @@ func Build(detail *domain.PRDetail, digests map[string]string, state cache.PRRev
 	return ctx
 }
 
+// buildHunks marks the hunks whose digest was viewed. A file without hunk
+// digests is a single hunk with the file's digest. A legacy entry viewed at
+// the file's current digest marks every hunk viewed.
+func buildHunks(digests []string, fileDigest string, snap cache.FileReviewState) ([]Hunk, int) {
+	if len(digests) == 0 {
+		digests = []string{fileDigest}
+	}
+	all := snap.PatchDigest != "" && snap.PatchDigest == fileDigest
+	seen := make(map[string]bool, len(snap.ViewedHunks))
+	for _, d := range snap.ViewedHunks {
+		seen[d] = true
+	}
+	hunks := make([]Hunk, len(digests))
+	viewed := 0
+	for i, d := range digests {
+		hunks[i] = Hunk{Digest: d, Viewed: all || seen[d]}
+		if hunks[i].Viewed {
+			viewed++
+		}
+	}
+	return hunks, viewed
+}
+
+// MigrateViewed rewrites the legacy viewed entries of state, which carry a
+// whole-file digest, as the hunks of ctx they mark viewed. Entries that no
+// longer mark anything viewed are dropped. It reports whether state changed.
+func MigrateViewed(ctx *Context, state cache.PRReviewState) (cache.PRReviewState, bool) {
+	if ctx == nil {
+		return state, false
+	}
+	changed := false
+	for path, snap := range state.ViewedFiles {
+		if snap.PatchDigest == "" {
+			continue
+		}
+		changed = true
+		snap.PatchDigest = ""
+		snap.ViewedHunks = nil
+		if file, ok := ctx.FindFile(path); ok {
+			for _, h := range file.Hunks {
+				if h.Viewed {
+					snap.ViewedHunks = append(snap.ViewedHunks, h.Digest)
+				}
+			}
+		}
+		if len(snap.ViewedHunks) == 0 {
+			delete(state.ViewedFiles, path)
+			continue
+		}
+		state.ViewedFiles[path] = snap
+	}
+	return state, changed
+}
+
 func actionable(file File, scope Scope, hasVisit, hasReview bool) bool {
 	switch scope {
 	case ScopeSinceVisit:
@@ -220,6 +291,37 @@ func DigestsFromDiff(diff *domain.Diff) map[string]string {
 	return out
 }
 
+// HunkDigestsFromDiff computes a digest of each hunk of each file, in
+// order. The hunk's lines go into it, not its position, so a hunk keeps its
+// digest when changes elsewhere in the file move it. Identical hunks in one
+// file are told apart by their index among the hunks with the same lines.
+func HunkDigestsFromDiff(diff *domain.Diff) map[string][]string {
+	if diff == nil {
+		return nil
+	}
+	out := make(map[string][]string, len(diff.Files))
+	for _, file := range diff.Files {
+		digests := make([]string, 0, len(file.Hunks))
+		seen := make(map[string]int, len(file.Hunks))
+		for _, hunk := range file.Hunks {
+			var b strings.Builder
+			for _, line := range hunk.Lines {
+				b.WriteString(string(line.Type))
+				b.WriteByte('|')
+				b.WriteString(line.Content)
+				b.WriteString("\n")
+			}
+			content := b.String()
+			b.WriteString(strconv.Itoa(seen[content]))
+			seen[content]++
+			sum := sha1.Sum([]byte(b.String())) //nolint:gosec // deterministic content fingerprint, not security-sensitive
+			digests = append(digests, hex.EncodeToString(sum[:]))
+		}
+		out[file.Path] = digests
+	}
+	return out
+}
+
 // SnapshotFromContext captures the current file digests as a persisted snapshot.
 func SnapshotFromContext(ctx *Context, now time.Time) (headSHA string, files map[string]string) {
 	if ctx == nil {
@@ -245,6 +347,75 @@ func (ctx *Context) FindFile(path string) (File, bool) {
 	return File{}, false
 }
 
+// NextActionableHunk returns the review target after the given hunk of
+// path: the next unviewed hunk of an actionable file, or the first hunk of
+// an actionable file whose hunks are all viewed. A hunk of -1 starts at the
+// beginning of path, and an unknown path at the first file. It wraps
+// around and returns "" when nothing is actionable.
+func (ctx *Context) NextActionableHunk(path string, hunk int) (string, int) {
+	if ctx == nil || ctx.ActionableFiles == 0 {
+		return "", 0
+	}
+	start := -1
+	for i, file := range ctx.Files {
+		if file.Path == path {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		start, hunk = 0, -1
+	}
+
+	targets := func(file File) []int {
+		if !file.Actionable {
+			return nil
+		}
+		var idx []int
+		for i, h := range file.Hunks {
+			if !h.Viewed {
+				idx = append(idx, i)
+			}
+		}
+		if len(idx) == 0 {
+			idx = []int{0}
+		}
+		return idx
+	}
+	for _, t := range targets(ctx.Files[start]) {
+		if t > hunk {
+			return ctx.Files[start].Path, t
+		}
+	}
+	for n := 1; n <= len(ctx.Files); n++ {
+		file := ctx.Files[(start+n)%len(ctx.Files)]
+		if t := targets(file); len(t) > 0 {
+			return file.Path, t[0]
+		}
+	}
+	return "", 0
+}
+
+// Baseline returns the head the PR was at when the active scope's baseline
+// was taken, and what took it, "visit" or "review": the last visit for
+// ScopeSinceVisit, the last review otherwise, each falling back to the
+// other. It returns "" when neither was recorded.
+func (ctx *Context) Baseline() (since, headSHA string) {
+	if ctx == nil {
+		return "", ""
+	}
+	visit, review := ctx.LastVisitHeadSHA, ctx.LastReviewHeadSHA
+	switch {
+	case ctx.Scope == ScopeSinceVisit && visit != "":
+		return "visit", visit
+	case review != "":
+		return "review", review
+	case visit != "":
+		return "visit", visit
+	}
+	return "", ""
+}
+
 // ProgressSummary is a compact snapshot of incremental review progress.
 type ProgressSummary struct {
 	ViewedFiles    int
@@ -1,6 +1,8 @@
 package reviewprogress
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -52,11 +54,11 @@ func TestBuild_ContextFlagsAndActionable(t *testing.T) {
 			"internal/tui/app.go": "digest-old",
 		},
 		ViewedFiles: map[string]cache.FileReviewState{
-			"README.md": {PatchDigest: "digest-a"},
+			"README.md": {ViewedHunks: []string{"digest-a"}},
 		},
 	}
 
-	ctx := Build(detail, digests, state, false)
+	ctx := Build(detail, digests, nil, state, false)
 	require.NotNil(t, ctx)
 	assert.Equal(t, 3, ctx.TotalFiles)
 	assert.Equal(t, 1, ctx.ViewedFiles)
@@ -70,7 +72,7 @@ func TestBuild_UnviewedWithoutBaselines(t *testing.T) {
 		"README.md":           "digest-a",
 		"internal/tui/app.go": "digest-b",
 		"docs/PRD.md":         "digest-c",
-	}, cache.PRReviewState{}, false)
+	}, nil, cache.PRReviewState{}, false)
 
 	require.NotNil(t, ctx)
 	assert.Equal(t, ScopeSinceReview, ctx.Scope)
@@ -83,10 +85,10 @@ func TestBuild_ViewedDigestCarriesAcrossHeadChanges(t *testing.T) {
 		"README.md":           "same-digest",
 		"internal/tui/app.go": "new-digest",
 		"docs/PRD.md":         "another-digest",
-	}, cache.PRReviewState{
+	}, nil, cache.PRReviewState{
 		ActiveScope: string(ScopeUnviewed),
 		ViewedFiles: map[string]cache.FileReviewState{
-			"README.md": {PatchDigest: "same-digest", ViewedHeadSHA: "head-1"},
+			"README.md": {ViewedHunks: []string{"same-digest"}, ViewedHeadSHA: "head-1"},
 		},
 	}, false)
 
@@ -95,6 +97,104 @@ func TestBuild_ViewedDigestCarriesAcrossHeadChanges(t *testing.T) {
 	assert.True(t, file.Viewed)
 }
 
+func TestBuild_HunkViewedState(t *testing.T) {
+	ctx := Build(testDetail(), map[string]string{
+		"README.md":           "digest-a",
+		"internal/tui/app.go": "digest-b",
+		"docs/PRD.md":         "digest-c",
+	}, map[string][]string{
+		"README.md":           {"hunk-1", "hunk-2", "hunk-3"},
+		"internal/tui/app.go": {"hunk-4"},
+	}, cache.PRReviewState{
+		ActiveScope: string(ScopeUnviewed),
+		ViewedFiles: map[string]cache.FileReviewState{
+			"README.md":           {ViewedHunks: []string{"hunk-1", "hunk-3", "gone"}},
+			"internal/tui/app.go": {ViewedHunks: []string{"hunk-4"}},
+		},
+	}, false)
+
+	readme, ok := ctx.FindFile("README.md")
+	require.True(t, ok)
+	assert.Equal(t, []Hunk{{"hunk-1", true}, {"hunk-2", false}, {"hunk-3", true}}, readme.Hunks)
+	assert.False(t, readme.Viewed, "a changed hunk leaves the file unviewed")
+	assert.Equal(t, 3, ctx.ViewedHunks)
+	assert.Equal(t, 5, ctx.TotalHunks, "a file without hunk digests counts as one hunk")
+	assert.Equal(t, 1, ctx.ViewedFiles)
+
+	path, hunk := ctx.NextActionableHunk("", -1)
+	assert.Equal(t, "README.md", path)
+	assert.Equal(t, 1, hunk)
+	path, hunk = ctx.NextActionableHunk("README.md", 1)
+	assert.Equal(t, "docs/PRD.md", path, "skips the viewed file")
+	assert.Equal(t, 0, hunk)
+	path, hunk = ctx.NextActionableHunk("docs/PRD.md", 0)
+	assert.Equal(t, "README.md", path, "wraps around")
+	assert.Equal(t, 1, hunk)
+}
+
+func TestMigrateViewedFromLegacyStateFile(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	repo := domain.RepoRef{Owner: "test", Name: "legacy"}
+	require.NoError(t, os.MkdirAll(filepath.Dir(cache.StatePath(repo)), 0o755))
+	require.NoError(t, os.WriteFile(cache.StatePath(repo), []byte(`{
+		"pr_reviews": {"42": {"viewed_files": {
+			"README.md": {"viewed_head_sha": "head-1", "patch_digest": "digest-a"},
+			"internal/tui/app.go": {"viewed_head_sha": "head-1", "patch_digest": "digest-old"}
+		}}}
+	}`), 0o600))
+
+	loaded, err := cache.LoadRepoState(repo)
+	require.NoError(t, err)
+	state := loaded.ReviewState(42)
+	ctx := Build(testDetail(), map[string]string{
+		"README.md":           "digest-a",
+		"internal/tui/app.go": "digest-b",
+	}, map[string][]string{"README.md": {"hunk-1", "hunk-2"}}, state, false)
+
+	readme, _ := ctx.FindFile("README.md")
+	assert.True(t, readme.Viewed, "a file viewed at its current digest stays viewed")
+	assert.Equal(t, 2, readme.ViewedHunks)
+	app, _ := ctx.FindFile("internal/tui/app.go")
+	assert.False(t, app.Viewed, "a file changed since it was viewed is unviewed")
+
+	migrated, changed := MigrateViewed(ctx, state)
+	require.True(t, changed)
+	assert.Equal(t, map[string]cache.FileReviewState{
+		"README.md": {ViewedHeadSHA: "head-1", ViewedHunks: []string{"hunk-1", "hunk-2"}},
+	}, migrated.ViewedFiles)
+	_, changed = MigrateViewed(ctx, migrated)
+	assert.False(t, changed)
+}
+
+func TestHunkDigestsFromDiff(t *testing.T) {
+	hunk := func(header string, num int) domain.Hunk {
+		return domain.Hunk{Header: header, Lines: []domain.DiffLine{
+			{Type: domain.DiffContext, Content: "func main() {", OldNum: num, NewNum: num},
+			{Type: domain.DiffAdd, Content: "\tfmt.Println()", NewNum: num + 1},
+		}}
+	}
+	before := HunkDigestsFromDiff(&domain.Diff{Files: []domain.FileDiff{{Path: "a.go", Hunks: []domain.Hunk{
+		hunk("@@ -10,1 +10,2 @@", 10),
+	}}}})
+	after := HunkDigestsFromDiff(&domain.Diff{Files: []domain.FileDiff{{Path: "a.go", Hunks: []domain.Hunk{
+		{Header: "@@ -1,0 +1,1 @@", Lines: []domain.DiffLine{{Type: domain.DiffAdd, Content: "// new", NewNum: 1}}},
+		hunk("@@ -10,1 +11,2 @@", 11),
+	}}}})
+
+	require.Len(t, after["a.go"], 2)
+	assert.Equal(t, before["a.go"][0], after["a.go"][1], "a moved hunk keeps its digest")
+	assert.NotEqual(t, after["a.go"][0], after["a.go"][1])
+	assert.Nil(t, HunkDigestsFromDiff(nil))
+
+	dup := HunkDigestsFromDiff(&domain.Diff{Files: []domain.FileDiff{{Path: "a.go", Hunks: []domain.Hunk{
+		hunk("@@ -10,1 +10,2 @@", 10),
+		hunk("@@ -40,1 +41,2 @@", 40),
+	}}}})
+	require.Len(t, dup["a.go"], 2)
+	assert.Equal(t, before["a.go"][0], dup["a.go"][0])
+	assert.NotEqual(t, dup["a.go"][0], dup["a.go"][1], "identical hunks get distinct digests")
+}
+
 func TestNextActionableAfter(t *testing.T) {
 	ctx := &Context{
 		Files: []File{
@@ -189,3 +289,22 @@ func TestSnapshotFromContext(t *testing.T) {
 	assert.Equal(t, "head-2", head)
 	assert.Equal(t, map[string]string{"a.go": "digest-a"}, files)
 }
+
+func TestContextBaseline(t *testing.T) {
+	ctx := &Context{Scope: ScopeSinceReview, LastVisitHeadSHA: "visit-sha", LastReviewHeadSHA: "review-sha"}
+	since, sha := ctx.Baseline()
+	assert.Equal(t, "review", since)
+	assert.Equal(t, "review-sha", sha)
+
+	ctx.Scope = ScopeSinceVisit
+	since, sha = ctx.Baseline()
+	assert.Equal(t, "visit", since)
+	assert.Equal(t, "visit-sha", sha)
+
+	ctx.LastVisitHeadSHA = ""
+	_, sha = ctx.Baseline()
+	assert.Equal(t, "review-sha", sha, "falls back to the other baseline")
+
+	since, sha = (&Context{}).Baseline()
+	assert.Empty(t, since+sha)
+}
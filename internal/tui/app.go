@@ -2,9 +2,11 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"slices"
 	"strings"
 	"time"
 
@@ -15,6 +17,8 @@ import (
 	"github.com/indrasvat/vivecaka/internal/cache"
 	"github.com/indrasvat/vivecaka/internal/config"
 	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/logging"
+	"github.com/indrasvat/vivecaka/internal/plugin"
 	"github.com/indrasvat/vivecaka/internal/repolocator"
 	"github.com/indrasvat/vivecaka/internal/reviewprogress"
 	"github.com/indrasvat/vivecaka/internal/tui/components"
@@ -81,6 +85,23 @@ type App struct {
 	refreshInterval  int  // from config (seconds); 0 = disabled
 	prevPRCount      int  // track count for new-PR detection
 
+	// Rate limiting
+	rateLimits       domain.RateLimitReporter // nil when the backend reports no budget
+	rateLimit        domain.RateLimit
+	rateLimitedUntil time.Time // auto-refresh is held until the limit resets
+
+	// CI hydration
+	ciStatuses domain.CIStatusReader // nil when the backend cannot batch CI lookups
+
+	// Plugins
+	plugins      *plugin.Registry    // nil when no registry is mounted
+	hooks        *plugin.HookManager // nil-safe; emits nothing without a registry
+	pluginViews  []plugin.ViewRegistration
+	pluginKeys   []plugin.KeyRegistration
+	pluginView   int           // index into pluginViews of the view shown in ViewPlugin
+	sender       func(tea.Msg) // delivers plugin messages to the running program
+	lastSelected int           // PR number last reported to on_pr_select
+
 	// Shared
 	keys   core.KeyMap
 	styles core.Styles
@@ -104,8 +125,15 @@ type App struct {
 	getReviewContext *usecase.GetReviewContext
 	reviewPR         *usecase.ReviewPR
 	checkoutPR       *usecase.CheckoutPR
+	mergePR          *usecase.MergePR
+	editMetadata     *usecase.EditMetadata // nil when the writer cannot edit metadata
+	prLifecycle      *usecase.PRLifecycle  // nil when the writer cannot change PR state
+	createPR         *usecase.CreatePR     // nil when the writer cannot create PRs
+	prCommits        *usecase.PRCommits    // nil when the reader cannot list commits
+	getInterdiff     *usecase.GetInterdiff // nil when the reader cannot compare commits
 	addComment       *usecase.AddComment
 	resolveThread    *usecase.ResolveThread
+	manageComments   *usecase.ManageComments // nil when the reviewer cannot manage comments
 	getInboxPRs      *usecase.GetInboxPRs
 
 	// View models
@@ -122,6 +150,14 @@ type App struct {
 	// Overlays
 	confirmDialog  views.ConfirmModel
 	checkoutDialog views.CheckoutDialogModel
+	mergeDialog    views.MergeDialogModel
+	metadataPicker views.MetadataPickerModel
+	createPRDialog views.CreatePRModel
+
+	// Labels, users and teams offered by the metadata picker, cached for
+	// metadataOptionsRepo.
+	metadataOptions     *domain.MetadataOptions
+	metadataOptionsRepo domain.RepoRef
 
 	// Filters
 	filterOpts domain.ListOpts
@@ -131,6 +167,8 @@ type App struct {
 	currentReviewContext *reviewprogress.Context
 	currentReviewDiff    *domain.Diff
 	currentReviewPR      int
+	diffScope            string // what the diff view shows in place of the PR diff; "" for the PR diff
+	interdiff            bool   // diffScope is an interdiff
 
 	// Components
 	banner *components.Banner
@@ -163,12 +201,15 @@ func New(cfg *config.Config, opts ...Option) *App {
 		diffView:       views.NewDiffViewModel(styles, keys),
 		reviewForm:     views.NewReviewModel(styles, keys),
 		repoSwitcher:   views.NewRepoSwitcherModel(styles, keys),
-		helpOverlay:    views.NewHelpModel(styles),
+		helpOverlay:    views.NewHelpModel(styles, keys),
 		inbox:          views.NewInboxModel(styles, keys),
 		tutorial:       views.NewTutorialModel(styles),
 		filterPanel:    views.NewFilterModel(styles, keys),
 		confirmDialog:  views.NewConfirmModel(styles),
 		checkoutDialog: views.NewCheckoutDialogModel(styles, keys),
+		mergeDialog:    views.NewMergeDialogModel(styles),
+		metadataPicker: views.NewMetadataPickerModel(styles),
+		createPRDialog: views.NewCreatePRModel(styles, keys),
 
 		// Infrastructure
 		repoLocator: repolocator.New(),
@@ -198,14 +239,33 @@ func New(cfg *config.Config, opts ...Option) *App {
 		a.getPRDetail = usecase.NewGetPRDetail(a.reader)
 		a.getReviewContext = usecase.NewGetReviewContext(a.reader)
 		a.getInboxPRs = usecase.NewGetInboxPRs(a.reader)
+		a.rateLimits, _ = a.reader.(domain.RateLimitReporter)
+		a.ciStatuses, _ = a.reader.(domain.CIStatusReader)
+		if cr, ok := a.reader.(domain.CommitReader); ok {
+			a.prCommits = usecase.NewPRCommits(cr)
+			a.getInterdiff = usecase.NewGetInterdiff(cr)
+		}
 	}
 	if a.reviewer != nil {
 		a.reviewPR = usecase.NewReviewPR(a.reviewer)
 		a.addComment = usecase.NewAddComment(a.reviewer)
 		a.resolveThread = usecase.NewResolveThread(a.reviewer)
+		if cm, ok := a.reviewer.(domain.CommentManager); ok {
+			a.manageComments = usecase.NewManageComments(cm)
+		}
 	}
 	if a.writer != nil {
 		a.checkoutPR = usecase.NewCheckoutPR(a.writer)
+		a.mergePR = usecase.NewMergePR(a.writer)
+		if me, ok := a.writer.(domain.MetadataEditor); ok {
+			a.editMetadata = usecase.NewEditMetadata(me)
+		}
+		if lm, ok := a.writer.(domain.PRLifecycleManager); ok {
+			a.prLifecycle = usecase.NewPRLifecycle(lm)
+		}
+		if pc, ok := a.writer.(domain.PRCreator); ok {
+			a.createPR = usecase.NewCreatePR(pc)
+		}
 	}
 	if a.repoManager != nil {
 		a.smartCheckout = usecase.NewSmartCheckout(a.repoManager, a.repoLocator)
@@ -222,8 +282,9 @@ func New(cfg *config.Config, opts ...Option) *App {
 
 func (a *App) Init() tea.Cmd {
 	cmds := []tea.Cmd{
-		detectUserCmd(),
+		detectUserCmd(a.repo.Host),
 		a.banner.StartAutoDismiss(2 * time.Second), // Show banner for 2 seconds
+		a.initPlugins(),
 	}
 	if !a.repoExplicit {
 		cmds = append(cmds, detectBranchCmd())
@@ -248,6 +309,7 @@ func (a *App) startRepoLoad(repo domain.RepoRef, includeCache bool) []tea.Cmd {
 	a.header.SetTotalCount(0)
 	a.loadRepoState()
 	a.repoSwitcher.SetCurrentRepo(a.repo)
+	a.applySnippets()
 
 	if a.listPRs == nil {
 		return []tea.Cmd{func() tea.Msg { return viewReadyMsg{} }}
@@ -263,7 +325,7 @@ func (a *App) startRepoLoad(repo domain.RepoRef, includeCache bool) []tea.Cmd {
 		cmds = append(cmds, loadCachedPRsCmd(a.repo))
 	}
 	cmds = append(cmds,
-		loadPRsCmd(a.listPRs, a.repo, a.filterOpts),
+		loadPRsCmd(a.listPRs, a.hooks, a.repo, a.filterOpts),
 		loadPRCountCmd(a.reader, a.repo, state),
 	)
 	return cmds
@@ -314,9 +376,13 @@ func (a *App) handleRefreshTick() (tea.Model, tea.Cmd) {
 		// Trigger auto-refresh.
 		a.refreshCountdown = a.refreshInterval
 		a.header.SetRefreshCountdown(a.refreshCountdown, false)
+		// Polling a rate-limited host only pushes the reset further out.
+		if time.Now().Before(a.rateLimitedUntil) {
+			return a, a.refreshTick()
+		}
 		if a.listPRs != nil && a.repo.Owner != "" && a.view == core.ViewPRList {
 			a.prevPRCount = a.prList.TotalPRs()
-			return a, tea.Batch(a.refreshTick(), loadPRsCmd(a.listPRs, a.repo, a.filterOpts))
+			return a, tea.Batch(a.refreshTick(), loadPRsCmd(a.listPRs, a.hooks, a.repo, a.filterOpts))
 		}
 		return a, a.refreshTick()
 	}
@@ -325,6 +391,12 @@ func (a *App) handleRefreshTick() (tea.Model, tea.Cmd) {
 }
 
 func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	from := a.hookViewName()
+	model, cmd := a.update(msg)
+	return model, tea.Batch(cmd, a.lifecycleHooks(from))
+}
+
+func (a *App) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	switch typedMsg := msg.(type) {
@@ -348,6 +420,8 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if cmd != nil {
 		cmds = append(cmds, cmd)
 	}
+	// Plugin views may be waiting on messages their plugin sent.
+	cmds = append(cmds, a.updatePluginViews(msg))
 	return a, tea.Batch(cmds...)
 }
 
@@ -363,6 +437,7 @@ func (a *App) handleAppMessage(msg tea.Msg) (bool, tea.Cmd) {
 		if len(typedMsg.PRs) > 0 && a.prList.IsLoading() {
 			a.prList.SetPRs(typedMsg.PRs)
 			a.header.SetPRCount(a.prList.TotalPRs())
+			return true, a.hydrateCI()
 		}
 		return true, nil
 	case views.BranchDetectedMsg:
@@ -398,6 +473,10 @@ func (a *App) handleAppMessage(msg tea.Msg) (bool, tea.Cmd) {
 	case views.OpenExternalDiffMsg:
 		_, cmd := a.handleOpenExternalDiff(typedMsg)
 		return true, cmd
+	case plugin.OpenViewMsg:
+		return true, a.openPluginView(typedMsg.Name)
+	case plugin.NotifyMsg:
+		return true, a.toasts.Add(typedMsg.Message, typedMsg.Level, 3*time.Second)
 	case views.OpenFilterMsg:
 		a.prevView = a.view
 		a.view = core.ViewFilter
@@ -411,7 +490,7 @@ func (a *App) handleAppMessage(msg tea.Msg) (bool, tea.Cmd) {
 		a.saveRepoState()
 		a.view = a.prevView
 		if a.listPRs != nil && a.repo.Owner != "" {
-			return true, loadPRsCmd(a.listPRs, a.repo, typedMsg.Opts)
+			return true, loadPRsCmd(a.listPRs, a.hooks, a.repo, typedMsg.Opts)
 		}
 		return true, nil
 	case views.CloseFilterMsg:
@@ -425,10 +504,17 @@ func (a *App) handleAppMessage(msg tea.Msg) (bool, tea.Cmd) {
 	case views.InlineCommentAddedMsg:
 		_, cmd := a.handleInlineCommentAdded(typedMsg)
 		return true, cmd
+	case views.PendingCommentsChangedMsg:
+		return true, a.handlePendingCommentsChanged(typedMsg)
 	case views.StartReviewMsg:
 		a.prevView = a.view
 		a.view = core.ViewReview
 		a.reviewForm.SetPRNumber(typedMsg.Number)
+		a.reviewForm.SetPendingCount(len(a.repoState.ReviewState(typedMsg.Number).PendingComments))
+		a.reviewForm.SetPRAuthor(a.prDetailAuthor())
+		if tmpl := reviewTemplateFor(a.cfg, a.repo); tmpl != "" {
+			a.reviewForm.SetTemplate(tmpl)
+		}
 		return true, a.reviewForm.Init()
 	case views.SubmitReviewMsg:
 		_, cmd := a.handleSubmitReview(typedMsg)
@@ -445,6 +531,9 @@ func (a *App) handleAppMessage(msg tea.Msg) (bool, tea.Cmd) {
 	case views.ToggleViewedFileMsg:
 		a.handleToggleViewedFile(typedMsg)
 		return true, nil
+	case views.ToggleViewedHunkMsg:
+		a.handleToggleViewedHunk(typedMsg)
+		return true, nil
 	case views.CloseReviewMsg:
 		a.view = core.ViewPRDetail
 		return true, nil
@@ -467,11 +556,69 @@ func (a *App) handleAppMessage(msg tea.Msg) (bool, tea.Cmd) {
 		_, cmd := a.handleCloneDone(typedMsg)
 		return true, cmd
 	case views.SmartCheckoutDoneMsg:
-		a.handleSmartCheckoutDone(typedMsg)
-		return true, nil
+		return true, a.handleSmartCheckoutDone(typedMsg)
 	case views.CheckoutDialogCloseMsg:
 		a.view = a.prevView
 		return true, nil
+	case views.MergePRMsg:
+		return true, a.handleMergePR(typedMsg)
+	case views.MergeStatusLoadedMsg:
+		return true, a.mergeDialog.SetStatus(typedMsg)
+	case views.SubmitMergeMsg:
+		return true, a.handleSubmitMerge(typedMsg)
+	case views.MergeDoneMsg:
+		return true, a.handleMergeDone(typedMsg)
+	case views.MergeDialogCloseMsg:
+		a.view = a.prevView
+		return true, nil
+	case views.EditMetadataMsg:
+		return true, a.handleEditMetadata(typedMsg)
+	case metadataOptionsLoadedMsg:
+		return true, a.handleMetadataOptionsLoaded(typedMsg)
+	case views.ApplyMetadataMsg:
+		return true, a.handleApplyMetadata(typedMsg)
+	case metadataEditedMsg:
+		return true, a.handleMetadataEdited(typedMsg)
+	case views.MetadataPickerCloseMsg:
+		a.view = a.prevView
+		return true, nil
+	case views.NewPRMsg:
+		return true, a.handleNewPR()
+	case views.NewPRBasesLoadedMsg:
+		return true, a.handleNewPRBasesLoaded(typedMsg)
+	case views.CompareBranchMsg:
+		return true, a.handleCompareBranch(typedMsg)
+	case views.BranchComparedMsg:
+		return true, a.createPRDialog.SetComparison(typedMsg)
+	case views.SubmitNewPRMsg:
+		return true, a.handleSubmitNewPR(typedMsg)
+	case views.PRCreatedMsg:
+		return true, a.handlePRCreated(typedMsg)
+	case views.CreatePRCloseMsg:
+		a.view = a.prevView
+		return true, nil
+	case views.LoadCommitsMsg:
+		return true, a.handleLoadCommits(typedMsg)
+	case views.CommitsLoadedMsg:
+		a.prDetail.SetCommits(typedMsg)
+		return true, nil
+	case views.OpenCommitDiffMsg:
+		return true, a.handleOpenCommitDiff(typedMsg)
+	case views.ScopedDiffLoadedMsg:
+		return true, a.handleScopedDiffLoaded(typedMsg)
+	case views.ToggleInterdiffMsg:
+		return true, a.handleToggleInterdiff(typedMsg)
+	case views.ClosePRMsg:
+		return true, a.handleClosePR(typedMsg)
+	case views.ToggleDraftMsg:
+		return true, a.handleToggleDraft(typedMsg)
+	case views.UpdateBranchMsg:
+		return true, a.handleUpdateBranch(typedMsg)
+	case updateBranchStatusMsg:
+		a.handleUpdateBranchStatus(typedMsg)
+		return true, nil
+	case prStateChangedMsg:
+		return true, a.handlePRStateChanged(typedMsg)
 	case views.CopyCdCommandMsg:
 		return true, a.handleCopyCdCommand(typedMsg)
 	case views.CopyURLMsg:
@@ -515,9 +662,17 @@ func (a *App) handleAppMessage(msg tea.Msg) (bool, tea.Cmd) {
 		_, cmd := a.handleResolveThread(typedMsg)
 		return true, cmd
 	case views.UnresolveThreadMsg:
-		return true, a.toasts.Add("Unresolve not implemented yet", domain.ToastInfo, 3*time.Second)
-	case views.ReplyToThreadMsg:
-		return true, a.toasts.Add("Reply not implemented yet", domain.ToastInfo, 3*time.Second)
+		return true, a.handleUnresolveThread(typedMsg)
+	case views.PostCommentMsg:
+		return true, a.handlePostComment(typedMsg)
+	case views.EditCommentMsg:
+		return true, a.handleEditComment(typedMsg)
+	case views.DeleteCommentMsg:
+		return true, a.handleDeleteCommentConfirm(typedMsg)
+	case views.ToggleReactionMsg:
+		return true, a.handleToggleReaction(typedMsg)
+	case commentActionDoneMsg:
+		return true, a.handleCommentActionDone(typedMsg)
 	case resolveThreadDoneMsg:
 		_, cmd := a.handleResolveThreadDone(typedMsg)
 		return true, cmd
@@ -542,9 +697,24 @@ func (a *App) handleAppMessage(msg tea.Msg) (bool, tea.Cmd) {
 			return true, a.loadingTick()
 		}
 		return true, nil
+	case rateLimitLoadedMsg:
+		a.handleRateLimitLoaded(typedMsg)
+		return true, nil
+	case views.CIStatusesLoadedMsg:
+		return true, a.handleCIStatusesLoaded(typedMsg)
+	case views.InboxPRsLoadedMsg:
+		return true, a.handleInboxPRsLoaded(typedMsg)
 	case refreshTickMsg:
 		_, cmd := a.handleRefreshTick()
 		return true, cmd
+	case views.OpenEditorMsg:
+		return true, openEditorCmd(typedMsg)
+	case views.EditorDoneMsg:
+		// The view that opened the editor takes the text.
+		if typedMsg.Err != nil {
+			return true, a.toasts.Add(fmt.Sprintf("Editor error: %v", typedMsg.Err), domain.ToastError, 5*time.Second)
+		}
+		return false, nil
 	case externalDiffDoneMsg:
 		if typedMsg.Err != nil {
 			return true, a.toasts.Add(
@@ -580,13 +750,16 @@ func (a *App) handleWindowSize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 	a.filterPanel.SetSize(a.width, contentHeight)
 	a.confirmDialog.SetSize(a.width, contentHeight)
 	a.checkoutDialog.SetSize(a.width, contentHeight)
+	a.mergeDialog.SetSize(a.width, contentHeight)
+	a.metadataPicker.SetSize(a.width, contentHeight)
+	a.createPRDialog.SetSize(a.width, contentHeight)
 
 	// Components.
 	a.header.SetWidth(a.width)
 	a.status.SetWidth(a.width)
 	a.toasts.SetWidth(a.width)
 
-	return a, nil
+	return a, a.updatePluginViews(tea.WindowSizeMsg{Width: a.width, Height: contentHeight})
 }
 
 func (a *App) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
@@ -617,6 +790,24 @@ func (a *App) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a, cmd
 	}
 
+	// Merge dialog intercepts all keys when visible.
+	if a.view == core.ViewMerge {
+		cmd := a.mergeDialog.Update(msg)
+		return a, cmd
+	}
+
+	// Metadata picker intercepts all keys when visible.
+	if a.view == core.ViewMetadata {
+		cmd := a.metadataPicker.Update(msg)
+		return a, cmd
+	}
+
+	// Create PR dialog intercepts all keys when visible.
+	if a.view == core.ViewCreatePR {
+		cmd := a.createPRDialog.Update(msg)
+		return a, cmd
+	}
+
 	// Tutorial intercepts all keys when visible.
 	if a.tutorial.Visible() {
 		cmd := a.tutorial.Update(msg)
@@ -645,6 +836,23 @@ func (a *App) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		cmd := a.reviewForm.Update(msg)
 		return a, cmd
+	case core.ViewPRDetail:
+		if a.prDetail.IsInputActive() {
+			if msg.Type == tea.KeyCtrlC {
+				return a, tea.Quit
+			}
+			return a, a.prDetail.Update(msg)
+		}
+	case core.ViewDiff:
+		if a.diffView.IsInputActive() {
+			if msg.Type == tea.KeyCtrlC {
+				return a, tea.Quit
+			}
+			cmd := a.diffView.Update(msg)
+			return a, cmd
+		}
+	case core.ViewPlugin:
+		return a, a.handlePluginViewKey(msg)
 	}
 
 	// Global keys always active.
@@ -686,7 +894,7 @@ func (a *App) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case key.Matches(msg, a.keys.Refresh):
 		if a.view == core.ViewPRList && a.listPRs != nil && a.repo.Owner != "" {
 			cmd := a.startRefreshTimer()
-			return a, tea.Batch(cmd, loadPRsCmd(a.listPRs, a.repo, a.filterOpts))
+			return a, tea.Batch(cmd, loadPRsCmd(a.listPRs, a.hooks, a.repo, a.filterOpts))
 		}
 		return a, nil
 
@@ -710,6 +918,10 @@ func (a *App) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	if cmd, ok := a.pluginKeyCmd(msg); ok {
+		return a, cmd
+	}
+
 	// Dispatch to active view.
 	cmd := a.dispatchKeyToView(msg)
 	return a, cmd
@@ -758,11 +970,18 @@ func (a *App) handleRepoDetected(msg views.RepoDetectedMsg) (tea.Model, tea.Cmd)
 	// Prepend CWD repo to favorites if not already there.
 	a.ensureCWDRepoInFavorites()
 
+	var cmds []tea.Cmd
+	// The user was detected against the default host at startup; an
+	// Enterprise remote has its own account.
+	if !msg.Repo.IsDefaultHost() {
+		cmds = append(cmds, detectUserCmd(msg.Repo.Host))
+	}
 	if a.listPRs != nil {
-		return a, tea.Batch(a.startRepoLoad(a.repo, true)...)
+		cmds = append(cmds, a.startRepoLoad(a.repo, true)...)
+		return a, tea.Batch(cmds...)
 	}
 	a.view = core.ViewPRList
-	return a, nil
+	return a, tea.Batch(cmds...)
 }
 
 func (a *App) handleUserDetected(msg views.UserDetectedMsg) (tea.Model, tea.Cmd) {
@@ -781,10 +1000,7 @@ func (a *App) handleUserDetected(msg views.UserDetectedMsg) (tea.Model, tea.Cmd)
 
 func (a *App) handlePRsLoaded(msg views.PRsLoadedMsg) (tea.Model, tea.Cmd) {
 	if msg.Err != nil {
-		cmd := a.toasts.Add(
-			fmt.Sprintf("Error loading PRs: %v", msg.Err),
-			domain.ToastError, 5*time.Second,
-		)
+		cmd := a.errorToast("Error loading PRs", msg.Err)
 		// Only transition to PR list from loading state — don't dismiss modals/dialogs.
 		if a.view == core.ViewLoading {
 			a.view = core.ViewPRList
@@ -795,6 +1011,7 @@ func (a *App) handlePRsLoaded(msg views.PRsLoadedMsg) (tea.Model, tea.Cmd) {
 	// Store PRs but don't switch view while the user is in a modal or detail view.
 	// Only transition from loading/banner states.
 	a.prList.SetPRs(msg.PRs)
+	a.prList.SetNextCursor(msg.NextCursor)
 	a.header.SetPRCount(a.prList.TotalPRs())
 	a.header.SetFilter(a.prList.FilterLabel())
 	if a.view == core.ViewLoading || a.view == core.ViewPRList {
@@ -802,7 +1019,7 @@ func (a *App) handlePRsLoaded(msg views.PRsLoadedMsg) (tea.Model, tea.Cmd) {
 	}
 
 	// Save fresh PRs to cache (fire and forget).
-	var cmds []tea.Cmd
+	cmds := []tea.Cmd{a.fetchRateLimit(), a.hydrateCI()}
 	if a.repo.Owner != "" && len(msg.PRs) > 0 {
 		cmds = append(cmds, saveCacheCmd(a.repo, msg.PRs))
 	}
@@ -834,24 +1051,149 @@ func (a *App) handleLoadMorePRs(msg views.LoadMorePRsMsg) (tea.Model, tea.Cmd) {
 	// Create opts with pagination
 	opts := a.filterOpts
 	opts.Page = msg.Page
-	return a, tea.Batch(spinnerCmd, loadMorePRsCmd(a.listPRs, a.repo, opts, msg.Page))
+	opts.Cursor = msg.Cursor
+	return a, tea.Batch(spinnerCmd, loadMorePRsCmd(a.listPRs, a.hooks, a.repo, opts, msg.Page))
 }
 
 func (a *App) handleMorePRsLoaded(msg views.MorePRsLoadedMsg) (tea.Model, tea.Cmd) {
 	if msg.Err != nil {
-		cmd := a.toasts.Add(
-			fmt.Sprintf("Error loading more PRs: %v", msg.Err),
-			domain.ToastError, 5*time.Second,
-		)
+		cmd := a.errorToast("Error loading more PRs", msg.Err)
 		// Reset loading state
 		a.prList.AppendPRs(nil, false)
 		return a, cmd
 	}
 	// Append the new PRs
 	a.prList.AppendPRs(msg.PRs, msg.HasMore)
+	a.prList.SetNextCursor(msg.NextCursor)
 	// Update header count to show total loaded PRs
 	a.header.SetPRCount(a.prList.TotalPRs())
-	return a, nil
+	return a, a.hydrateCI()
+}
+
+// hydrateCI fetches CI statuses for the loaded PRs whose status is unknown
+// or pending, one query per batch of ciBatchSize so rows update as each
+// batch lands. Readers that list PRs with their head commit rollup leave
+// only pending rows to fetch.
+func (a *App) hydrateCI() tea.Cmd {
+	if a.ciStatuses == nil || a.repo.Owner == "" || time.Now().Before(a.rateLimitedUntil) {
+		return nil
+	}
+	numbers := a.prList.TakeCIHydration()
+	var cmds []tea.Cmd
+	for batch := range slices.Chunk(numbers, ciBatchSize) {
+		cmds = append(cmds, loadCIStatusesCmd(a.ciStatuses, a.repo, batch))
+	}
+	return tea.Batch(cmds...)
+}
+
+func (a *App) handleCIStatusesLoaded(msg views.CIStatusesLoadedMsg) tea.Cmd {
+	if msg.Repo != a.repo {
+		return nil
+	}
+	if msg.Err != nil {
+		if errors.Is(msg.Err, domain.ErrRateLimited) {
+			return a.rateLimited(msg.Err)
+		}
+		// Hydration is best effort; rows keep the status they were listed with.
+		logging.Log.Debug("CI hydration failed", "err", msg.Err)
+		return nil
+	}
+	a.prList.SetCIStatuses(msg.Statuses)
+	return nil
+}
+
+// errorToast reports a failed host call. Rate limits pause auto-refresh
+// instead of surfacing as a plain error, an operation the backend for the
+// repo does not support is a warning, and classified failures show how to
+// recover, staying up long enough to act on.
+func (a *App) errorToast(prefix string, err error) tea.Cmd {
+	if errors.Is(err, domain.ErrRateLimited) {
+		return a.rateLimited(err)
+	}
+	if errors.Is(err, errors.ErrUnsupported) {
+		return a.toasts.Add(fmt.Sprintf("%s: %s", prefix, errorText(err)), domain.ToastWarning, 3*time.Second)
+	}
+	logging.Log.Warn(prefix, "err", err)
+	duration := 5 * time.Second
+	if remedy(err) != "" {
+		duration = 10 * time.Second
+	}
+	return a.toasts.Add(
+		fmt.Sprintf("%s: %s", prefix, errorText(err)),
+		domain.ToastError, duration,
+	)
+}
+
+// remedy returns the recovery hint attached to a classified host error.
+func remedy(err error) string {
+	var he *domain.HostError
+	if errors.As(err, &he) {
+		return he.Remedy
+	}
+	return ""
+}
+
+// errorText describes err for the user: that the backend does not support
+// the operation, the failure kind and its remedy when the host error was
+// classified, or the full error otherwise.
+func errorText(err error) string {
+	if errors.Is(err, errors.ErrUnsupported) {
+		return "not supported by this backend"
+	}
+	var he *domain.HostError
+	if errors.As(err, &he) && he.Remedy != "" {
+		return he.Kind.Error() + " — " + he.Remedy
+	}
+	return err.Error()
+}
+
+// rateLimited holds auto-refresh until the limit behind err resets and
+// refreshes the budget shown in the status bar.
+func (a *App) rateLimited(err error) tea.Cmd {
+	now := time.Now()
+	var rle *domain.RateLimitError
+	if !errors.As(err, &rle) {
+		rle = &domain.RateLimitError{}
+	}
+	if until := rle.ResumeAt(now); until.After(a.rateLimitedUntil) {
+		a.rateLimitedUntil = until
+	}
+	a.status.SetRateLimit(a.rateLimit, a.rateLimitedUntil)
+	return tea.Batch(
+		a.toasts.Add(
+			fmt.Sprintf("Rate limited; auto-refresh paused until %s", a.rateLimitedUntil.Format("15:04")),
+			domain.ToastWarning, 5*time.Second,
+		),
+		a.fetchRateLimit(),
+	)
+}
+
+// fetchRateLimit queries the API budget when the backend can report it.
+func (a *App) fetchRateLimit() tea.Cmd {
+	if a.rateLimits == nil || a.repo.Owner == "" {
+		return nil
+	}
+	return fetchRateLimitCmd(a.rateLimits, a.repo)
+}
+
+func (a *App) handleRateLimitLoaded(msg rateLimitLoadedMsg) {
+	if msg.Err != nil {
+		// The budget is informational; requests report limits themselves.
+		return
+	}
+	a.rateLimit = msg.Limit
+	if msg.Limit.Remaining == 0 && msg.Limit.Reset.After(a.rateLimitedUntil) {
+		a.rateLimitedUntil = msg.Limit.Reset
+	}
+	a.status.SetRateLimit(a.rateLimit, a.rateLimitedUntil)
+}
+
+func (a *App) handleInboxPRsLoaded(msg views.InboxPRsLoadedMsg) tea.Cmd {
+	cmd := a.inbox.Update(msg)
+	if msg.Err != nil {
+		return tea.Batch(cmd, a.errorToast("Error loading inbox", msg.Err))
+	}
+	return cmd
 }
 
 func (a *App) handlePRCountLoaded(msg views.PRCountLoadedMsg) tea.Model {
@@ -872,10 +1214,18 @@ func (a *App) handleDiffLoaded(msg views.DiffLoadedMsg) tea.Cmd {
 	if msg.Err == nil && msg.Diff != nil {
 		a.currentReviewDiff = msg.Diff
 	}
+	if errors.Is(msg.Err, domain.ErrRateLimited) {
+		cmds = append(cmds, a.rateLimited(msg.Err))
+	}
+	if a.diffScope != "" {
+		// The diff view shows commits or an interdiff; the PR diff is kept
+		// for later.
+		return tea.Batch(cmds...)
+	}
 	cmd := a.diffView.Update(msg)
 	if msg.Err == nil && msg.Diff != nil && a.currentReviewContext != nil {
-		if next := a.nextReviewTargetPath(""); next != "" {
-			a.diffView.JumpToFile(next)
+		if next, hunk := a.nextReviewTarget("", -1); next != "" {
+			a.diffView.JumpToHunk(next, hunk)
 		}
 	}
 	if cmd != nil {
@@ -968,28 +1318,27 @@ func (a *App) handleOpenPR(msg views.OpenPRMsg) (tea.Model, tea.Cmd) {
 	a.prDetail.SetReviewContext(nil)
 	a.diffView.SetReviewContext(nil)
 	spinCmd := a.prDetail.StartLoading(msg.Number)
+	hookCmd := a.actionHook(plugin.HookPROpened, msg.Number, plugin.ActionEvent{})
 
 	if a.getPRDetail != nil && a.repo.Owner != "" {
-		return a, tea.Batch(spinCmd, loadPRDetailCmd(a.getPRDetail, a.repo, msg.Number))
+		return a, tea.Batch(spinCmd, hookCmd, loadPRDetailCmd(a.getPRDetail, a.repo, msg.Number))
 	}
-	return a, spinCmd
+	return a, tea.Batch(spinCmd, hookCmd)
 }
 
 func (a *App) handlePRDetailLoaded(msg views.PRDetailLoadedMsg) (tea.Model, tea.Cmd) {
 	if msg.Err != nil {
 		a.prDetail.StopLoading()
-		cmd := a.toasts.Add(
-			fmt.Sprintf("Error loading PR detail: %v", msg.Err),
-			domain.ToastError, 5*time.Second,
-		)
+		cmd := a.errorToast("Error loading PR detail", msg.Err)
 		return a, cmd
 	}
 	a.prDetail.SetDetail(msg.Detail)
+	loadCommits := a.prDetail.LoadCommits()
 	if a.getReviewContext != nil && a.repo.Owner != "" {
 		state := a.repoState.ReviewState(msg.Detail.Number)
-		return a, loadReviewContextCmd(a.getReviewContext, a.repo, msg.Detail.Number, msg.Detail, state)
+		return a, tea.Batch(loadCommits, loadReviewContextCmd(a.getReviewContext, a.repo, msg.Detail.Number, msg.Detail, state))
 	}
-	return a, nil
+	return a, loadCommits
 }
 
 func (a *App) handleReviewContextLoaded(msg views.ReviewContextLoadedMsg) tea.Model {
@@ -998,12 +1347,16 @@ func (a *App) handleReviewContextLoaded(msg views.ReviewContextLoadedMsg) tea.Mo
 	}
 	a.currentReviewContext = msg.Context
 	a.currentReviewDiff = msg.Diff
+	if state, changed := reviewprogress.MigrateViewed(msg.Context, a.repoState.ReviewState(msg.Number)); changed {
+		a.repoState.SetReviewState(msg.Number, state)
+		a.saveRepoState()
+	}
 	a.prDetail.SetReviewContext(msg.Context)
 	a.diffView.SetReviewContext(msg.Context)
-	if a.view == core.ViewDiff && msg.Diff != nil {
+	if a.view == core.ViewDiff && a.diffScope == "" && msg.Diff != nil {
 		a.diffView.SetDiff(msg.Diff)
-		if next := a.nextReviewTargetPath(a.diffView.CurrentFilePath()); next != "" && a.diffView.CurrentFilePath() == "" {
-			a.diffView.JumpToFile(next)
+		if next, hunk := a.nextReviewTarget(a.diffView.CurrentFilePath(), -1); next != "" && a.diffView.CurrentFilePath() == "" {
+			a.diffView.JumpToHunk(next, hunk)
 		}
 	}
 	return a
@@ -1105,15 +1458,21 @@ type externalDiffDoneMsg struct {
 
 func (a *App) handleOpenDiff(msg views.OpenDiffMsg) (tea.Model, tea.Cmd) {
 	a.view = core.ViewDiff
+	a.diffScope = ""
+	a.interdiff = false
+	a.diffView.SetReadOnly(false)
+	a.diffView.SetScope("")
 	a.diffView.SetPRNumber(msg.Number)
+	a.diffView.SetPRAuthor(a.prDetailAuthor())
 	a.diffView.SetHeadBranch(a.prDetail.GetBranch().Head)
 	// Pass inline comments from the loaded PR detail to the diff view.
 	a.diffView.SetComments(a.prDetail.GetInlineComments())
+	a.diffView.SetPendingComments(a.repoState.ReviewState(msg.Number).PendingComments)
 	a.diffView.SetReviewContext(a.currentReviewContext)
 	if a.currentReviewPR == msg.Number && a.currentReviewDiff != nil {
 		a.diffView.SetDiff(a.currentReviewDiff)
-		if next := a.nextReviewTargetPath(""); next != "" {
-			a.diffView.JumpToFile(next)
+		if next, hunk := a.nextReviewTarget("", -1); next != "" {
+			a.diffView.JumpToHunk(next, hunk)
 		}
 		return a, nil
 	}
@@ -1133,13 +1492,13 @@ func (a *App) handleAddInlineComment(msg views.AddInlineCommentMsg) (tea.Model,
 
 func (a *App) handleInlineCommentAdded(msg views.InlineCommentAddedMsg) (tea.Model, tea.Cmd) {
 	if msg.Err != nil {
-		cmd := a.toasts.Add(
-			fmt.Sprintf("Comment failed: %v", msg.Err),
-			domain.ToastError, 5*time.Second,
-		)
+		cmd := a.errorToast("Comment failed", msg.Err)
 		return a, cmd
 	}
-	cmd := a.toasts.Add("Comment added", domain.ToastSuccess, 3*time.Second)
+	cmd := tea.Batch(
+		a.toasts.Add("Comment added", domain.ToastSuccess, 3*time.Second),
+		a.actionHook(plugin.HookCommentAdded, msg.Number, plugin.ActionEvent{Comment: &msg.Input}),
+	)
 	// Refresh PR detail to show the new comment.
 	if a.getPRDetail != nil && a.prDetail.GetPRNumber() > 0 {
 		return a, tea.Batch(cmd, loadPRDetailCmd(a.getPRDetail, a.repo, a.prDetail.GetPRNumber()))
@@ -1147,23 +1506,59 @@ func (a *App) handleInlineCommentAdded(msg views.InlineCommentAddedMsg) (tea.Mod
 	return a, cmd
 }
 
+// handlePendingCommentsChanged persists a PR's pending review comments, so
+// they survive quitting before the review is submitted.
+func (a *App) handlePendingCommentsChanged(msg views.PendingCommentsChangedMsg) tea.Cmd {
+	state := a.repoState.ReviewState(msg.Number)
+	state.PendingComments = msg.Comments
+	a.repoState.SetReviewState(msg.Number, state)
+	a.saveRepoState()
+	switch len(msg.Comments) {
+	case 0:
+		return a.toasts.Add("No pending comments", domain.ToastInfo, 3*time.Second)
+	case 1:
+		return a.toasts.Add("1 comment pending — submit a review to post it", domain.ToastInfo, 3*time.Second)
+	default:
+		return a.toasts.Add(fmt.Sprintf("%d comments pending — submit a review to post them", len(msg.Comments)), domain.ToastInfo, 3*time.Second)
+	}
+}
+
 func (a *App) handleSubmitReview(msg views.SubmitReviewMsg) (tea.Model, tea.Cmd) {
+	msg.Review.Comments = a.repoState.ReviewState(msg.Number).PendingComments
 	if a.reviewPR != nil && a.repo.Owner != "" {
 		return a, submitReviewCmd(a.reviewPR, a.repo, msg.Number, msg.Review)
 	}
 	return a, nil
 }
 
+// clearSubmittedComments drops comments a review posted from the PR's
+// pending list. Comments added while the review was in flight stay.
+func (a *App) clearSubmittedComments(number int, submitted []domain.InlineCommentInput) {
+	if len(submitted) == 0 {
+		return
+	}
+	state := a.repoState.ReviewState(number)
+	state.PendingComments = slices.DeleteFunc(state.PendingComments, func(c domain.InlineCommentInput) bool {
+		return slices.Contains(submitted, c)
+	})
+	a.repoState.SetReviewState(number, state)
+	a.saveRepoState()
+	if a.diffView.GetPRNumber() == number {
+		a.diffView.SetPendingComments(state.PendingComments)
+	}
+}
+
 func (a *App) handleReviewSubmitted(msg views.ReviewSubmittedMsg) (tea.Model, tea.Cmd) {
 	if msg.Err != nil {
-		cmd := a.toasts.Add(
-			fmt.Sprintf("Review failed: %v", msg.Err),
-			domain.ToastError, 5*time.Second,
-		)
+		cmd := a.errorToast("Review failed", msg.Err)
 		return a, cmd
 	}
 	a.markCurrentPRReviewed()
-	cmd := a.toasts.Add("Review submitted", domain.ToastSuccess, 3*time.Second)
+	a.clearSubmittedComments(msg.Number, msg.Review.Comments)
+	cmd := tea.Batch(
+		a.toasts.Add("Review submitted", domain.ToastSuccess, 3*time.Second),
+		a.actionHook(plugin.HookReviewSubmitted, msg.Number, plugin.ActionEvent{Review: &msg.Review}),
+	)
 	a.view = core.ViewPRDetail
 	if a.getPRDetail != nil && a.prDetail.GetPRNumber() > 0 {
 		return a, tea.Batch(cmd, loadPRDetailCmd(a.getPRDetail, a.repo, a.prDetail.GetPRNumber()))
@@ -1245,7 +1640,7 @@ func (a *App) handleCheckoutStrategyChosen(msg views.CheckoutStrategyChosenMsg)
 
 	case "browser":
 		// Open in browser.
-		url := fmt.Sprintf("https://github.com/%s/pull/%d", msg.Repo.String(), msg.PRNumber)
+		url := fmt.Sprintf("https://%s/%s/pull/%d", msg.Repo.HostName(), msg.Repo.FullName(), msg.PRNumber)
 		a.view = a.prevView
 		if err := openBrowser(url); err != nil {
 			cmd := a.toasts.Add(
@@ -1271,19 +1666,62 @@ func (a *App) handleCloneDone(msg views.CloneDoneMsg) (tea.Model, tea.Cmd) {
 		smartCheckoutCmd(a.smartCheckout, a.repo, a.checkoutDialog.GetPRNumber(), msg.Path, false))
 }
 
-func (a *App) handleSmartCheckoutDone(msg views.SmartCheckoutDoneMsg) tea.Model {
+func (a *App) handleSmartCheckoutDone(msg views.SmartCheckoutDoneMsg) tea.Cmd {
 	if msg.Err != nil {
 		a.checkoutDialog.ShowError(msg.Err)
-		return a
+		return nil
 	}
 	a.prList.SetCurrentBranch(msg.Branch)
 	cwdCheckout := msg.Path == a.cwdPath
 	a.checkoutDialog.ShowSuccess(msg.Branch, msg.Path, cwdCheckout)
-	return a
+	return a.actionHook(plugin.HookCheckoutDone, msg.Number, plugin.ActionEvent{Branch: msg.Branch, Path: msg.Path})
+}
+
+// handleMergePR opens the merge dialog and loads the PR's merge status.
+func (a *App) handleMergePR(msg views.MergePRMsg) tea.Cmd {
+	if a.mergePR == nil {
+		return a.unsupported("Merging")
+	}
+	a.prevView = a.view
+	a.view = core.ViewMerge
+	return tea.Batch(a.mergeDialog.Show(msg.PR), loadMergeStatusCmd(a.mergePR, a.repo, msg.PR))
+}
+
+func (a *App) handleSubmitMerge(msg views.SubmitMergeMsg) tea.Cmd {
+	spinnerCmd := a.mergeDialog.ShowMerging()
+	return tea.Batch(spinnerCmd, mergePRCmd(a.mergePR, a.repo, msg.Number, msg.Opts))
+}
+
+// handleMergeDone reports a merge, closing the dialog on success and
+// reloading the PR list and the open detail to show the new state.
+func (a *App) handleMergeDone(msg views.MergeDoneMsg) tea.Cmd {
+	if msg.Err != nil {
+		if a.view == core.ViewMerge {
+			a.mergeDialog.ShowError(msg.Err)
+			return nil
+		}
+		return a.errorToast("Merge failed", msg.Err)
+	}
+	if a.view == core.ViewMerge {
+		a.mergeDialog.Close()
+		a.view = a.prevView
+	}
+	done := fmt.Sprintf("Merged PR #%d", msg.Number)
+	if msg.Auto {
+		done = fmt.Sprintf("Auto-merge enabled for PR #%d", msg.Number)
+	}
+	cmds := []tea.Cmd{a.toasts.Add(done, domain.ToastSuccess, 3*time.Second)}
+	if a.listPRs != nil {
+		cmds = append(cmds, loadPRsCmd(a.listPRs, a.hooks, a.repo, a.filterOpts))
+	}
+	if a.getPRDetail != nil && a.prDetail.GetPRNumber() == msg.Number {
+		cmds = append(cmds, loadPRDetailCmd(a.getPRDetail, a.repo, msg.Number))
+	}
+	return tea.Batch(cmds...)
 }
 
 func reposMatchRef(a, b domain.RepoRef) bool {
-	return strings.EqualFold(a.Owner, b.Owner) && strings.EqualFold(a.Name, b.Name)
+	return a.Equal(b)
 }
 
 // findRepoDir returns a local directory for the currently browsed repo.
@@ -1333,6 +1771,15 @@ func (a *App) handleConfirmResult(msg views.ConfirmResultMsg) (tea.Model, tea.Cm
 		)
 		return a, tea.Batch(spinnerCmd, checkoutPRCmd(a.checkoutPR, a.repo, checkoutMsg.Number))
 	}
+	if del, ok := msg.Action.(views.DeleteCommentMsg); ok && a.manageComments != nil && a.repo.Owner != "" {
+		spinnerCmd := a.confirmDialog.ShowLoading("Delete Comment", "Deleting comment...")
+		uc, repo := a.manageComments, a.repo
+		return a, tea.Batch(spinnerCmd, commentActionCmd(del.Number, "Comment deleted", "Delete failed",
+			func(ctx context.Context) error { return uc.Delete(ctx, repo, del.Ref) }))
+	}
+	if cmd, ok := a.runLifecycleAction(msg); ok {
+		return a, cmd
+	}
 	a.view = a.prevView
 	return a, nil
 }
@@ -1341,26 +1788,24 @@ func (a *App) handleCheckoutDone(msg views.CheckoutDoneMsg) (tea.Model, tea.Cmd)
 	if msg.Err != nil {
 		// Show error in the dialog if it's still open, otherwise toast.
 		if a.view == core.ViewConfirm {
-			a.confirmDialog.ShowResult("Checkout Failed", msg.Err.Error(), false)
+			a.confirmDialog.ShowResult("Checkout Failed", errorText(msg.Err), false)
 			return a, nil
 		}
-		cmd := a.toasts.Add(
-			fmt.Sprintf("Checkout failed: %v", msg.Err),
-			domain.ToastError, 5*time.Second,
-		)
+		cmd := a.errorToast("Checkout failed", msg.Err)
 		return a, cmd
 	}
 	a.prList.SetCurrentBranch(msg.Branch)
+	hookCmd := a.actionHook(plugin.HookCheckoutDone, msg.Number, plugin.ActionEvent{Branch: msg.Branch, Path: a.cwdPath})
 	// Show success in the dialog if it's still open, otherwise toast.
 	if a.view == core.ViewConfirm {
 		a.confirmDialog.ShowResult("Checkout Complete", fmt.Sprintf("Checked out branch: %s", msg.Branch), true)
-		return a, nil
+		return a, hookCmd
 	}
 	cmd := a.toasts.Add(
 		fmt.Sprintf("Checked out branch: %s", msg.Branch),
 		domain.ToastSuccess, 3*time.Second,
 	)
-	return a, cmd
+	return a, tea.Batch(cmd, hookCmd)
 }
 
 func (a *App) handleSwitchRepo(msg views.SwitchRepoMsg) (tea.Model, tea.Cmd) {
@@ -1378,7 +1823,7 @@ func (a *App) handleSwitchRepo(msg views.SwitchRepoMsg) (tea.Model, tea.Cmd) {
 			state = domain.PRStateOpen
 		}
 		return a, tea.Batch(
-			loadPRsCmd(a.listPRs, a.repo, a.filterOpts),
+			loadPRsCmd(a.listPRs, a.hooks, a.repo, a.filterOpts),
 			loadPRCountCmd(a.reader, a.repo, state),
 		)
 	}
@@ -1458,6 +1903,9 @@ func (a *App) handleOpenInboxPR(msg views.OpenInboxPRMsg) (tea.Model, tea.Cmd) {
 
 func (a *App) handleRepoValidated(msg views.RepoValidatedMsg) (tea.Model, tea.Cmd) {
 	if msg.Err != nil {
+		if !errors.Is(msg.Err, domain.ErrNotFound) && remedy(msg.Err) != "" {
+			return a, a.errorToast("Could not open "+msg.Repo.String(), msg.Err)
+		}
 		cmd := a.toasts.Add(
 			fmt.Sprintf("Repository not found: %s", msg.Repo.String()),
 			domain.ToastError, 5*time.Second,
@@ -1518,13 +1966,13 @@ func (a *App) handleResolveThread(msg views.ResolveThreadMsg) (tea.Model, tea.Cm
 
 func (a *App) handleResolveThreadDone(msg resolveThreadDoneMsg) (tea.Model, tea.Cmd) {
 	if msg.Err != nil {
-		cmd := a.toasts.Add(
-			fmt.Sprintf("Resolve failed: %v", msg.Err),
-			domain.ToastError, 5*time.Second,
-		)
+		cmd := a.errorToast("Resolve failed", msg.Err)
 		return a, cmd
 	}
-	cmd := a.toasts.Add("Thread resolved", domain.ToastSuccess, 3*time.Second)
+	cmd := tea.Batch(
+		a.toasts.Add("Thread resolved", domain.ToastSuccess, 3*time.Second),
+		a.actionHook(plugin.HookThreadResolved, a.prDetail.GetPRNumber(), plugin.ActionEvent{ThreadID: msg.ThreadID}),
+	)
 	// Refresh PR detail to show updated resolved status
 	if a.getPRDetail != nil && a.prDetail.GetPRNumber() > 0 {
 		return a, tea.Batch(cmd, loadPRDetailCmd(a.getPRDetail, a.repo, a.prDetail.GetPRNumber()))
@@ -1532,6 +1980,117 @@ func (a *App) handleResolveThreadDone(msg resolveThreadDoneMsg) (tea.Model, tea.
 	return a, cmd
 }
 
+// commentActionDoneMsg is sent when a comment action from the PR detail
+// completes. Done is the success toast and Failed prefixes the error. Hook,
+// when set, is emitted with Event once the action succeeded.
+type commentActionDoneMsg struct {
+	Number int
+	Done   string
+	Failed string
+	Err    error
+	Hook   plugin.HookPoint
+	Event  plugin.ActionEvent
+}
+
+// unsupported reports, with a toast, that the backend cannot do what. With
+// several backends, a capability one of them has can still be missing from
+// the one a repo routes to, which the action's errors.ErrUnsupported tells.
+func (a *App) unsupported(what string) tea.Cmd {
+	return a.toasts.Add(what+" is not supported by this backend", domain.ToastWarning, 3*time.Second)
+}
+
+// commentsUnavailable reports, with a toast, when the reviewer cannot
+// manage comments.
+func (a *App) commentsUnavailable() (tea.Cmd, bool) {
+	if a.manageComments != nil && a.repo.Owner != "" {
+		return nil, false
+	}
+	return a.toasts.Add("This backend cannot manage comments", domain.ToastInfo, 3*time.Second), true
+}
+
+func (a *App) handlePostComment(msg views.PostCommentMsg) tea.Cmd {
+	if cmd, unavailable := a.commentsUnavailable(); unavailable {
+		return cmd
+	}
+	uc, repo := a.manageComments, a.repo
+	return func() tea.Msg {
+		err := uc.Post(context.Background(), repo, msg.Number, msg.Body)
+		return commentActionDoneMsg{
+			Number: msg.Number, Done: "Comment posted", Failed: "Comment failed", Err: err,
+			Hook: plugin.HookCommentAdded, Event: plugin.ActionEvent{Comment: &domain.InlineCommentInput{Body: msg.Body}},
+		}
+	}
+}
+
+func (a *App) handleEditComment(msg views.EditCommentMsg) tea.Cmd {
+	if cmd, unavailable := a.commentsUnavailable(); unavailable {
+		return cmd
+	}
+	uc, repo := a.manageComments, a.repo
+	return commentActionCmd(msg.Number, "Comment updated", "Edit failed",
+		func(ctx context.Context) error { return uc.Edit(ctx, repo, msg.Ref, msg.Body) })
+}
+
+// handleDeleteCommentConfirm asks before deleting; handleConfirmResult
+// runs the deletion.
+func (a *App) handleDeleteCommentConfirm(msg views.DeleteCommentMsg) tea.Cmd {
+	if cmd, unavailable := a.commentsUnavailable(); unavailable {
+		return cmd
+	}
+	a.prevView = a.view
+	a.view = core.ViewConfirm
+	a.confirmDialog.Show("Delete Comment", fmt.Sprintf("Delete this comment by @%s? This cannot be undone.", msg.Author), msg)
+	return nil
+}
+
+func (a *App) handleToggleReaction(msg views.ToggleReactionMsg) tea.Cmd {
+	if cmd, unavailable := a.commentsUnavailable(); unavailable {
+		return cmd
+	}
+	uc, repo := a.manageComments, a.repo
+	return func() tea.Msg {
+		added, err := uc.ToggleReaction(context.Background(), repo, msg.Kind, msg.Comment, msg.Content)
+		done := "Removed " + msg.Content.Emoji()
+		if added {
+			done = "Reacted " + msg.Content.Emoji()
+		}
+		return commentActionDoneMsg{Number: msg.Number, Done: done, Failed: "Reaction failed", Err: err}
+	}
+}
+
+func (a *App) handleUnresolveThread(msg views.UnresolveThreadMsg) tea.Cmd {
+	if cmd, unavailable := a.commentsUnavailable(); unavailable {
+		return cmd
+	}
+	uc, repo := a.manageComments, a.repo
+	return commentActionCmd(a.prDetail.GetPRNumber(), "Thread unresolved", "Unresolve failed",
+		func(ctx context.Context) error { return uc.Unresolve(ctx, repo, msg.ThreadID) })
+}
+
+// handleCommentActionDone reports a comment action, in the confirm dialog
+// if it is still open, emits its hook and reloads the PR detail to show the
+// change.
+func (a *App) handleCommentActionDone(msg commentActionDoneMsg) tea.Cmd {
+	var cmd tea.Cmd
+	switch {
+	case a.view == core.ViewConfirm && msg.Err != nil:
+		a.confirmDialog.ShowResult(msg.Failed, errorText(msg.Err), false)
+	case a.view == core.ViewConfirm:
+		a.confirmDialog.ShowResult(msg.Done, fmt.Sprintf("PR #%d will refresh.", msg.Number), true)
+	case msg.Err != nil:
+		return a.errorToast(msg.Failed, msg.Err)
+	default:
+		cmd = a.toasts.Add(msg.Done, domain.ToastSuccess, 3*time.Second)
+	}
+	if msg.Err == nil && msg.Hook != "" {
+		cmd = tea.Batch(cmd, a.actionHook(msg.Hook, msg.Number, msg.Event))
+	}
+	if msg.Err == nil && a.getPRDetail != nil && msg.Number > 0 && a.prDetail.GetPRNumber() == msg.Number {
+		return tea.Batch(cmd, loadPRDetailCmd(a.getPRDetail, a.repo, msg.Number))
+	}
+	return cmd
+}
+
 func (a *App) handleCycleReviewScope() tea.Model {
 	state := a.repoState.ReviewState(a.currentReviewPR)
 	scope := reviewprogress.Scope(state.ActiveScope)
@@ -1547,15 +2106,15 @@ func (a *App) handleCycleReviewScope() tea.Model {
 }
 
 func (a *App) handleJumpNextReviewTarget(msg views.JumpNextReviewTargetMsg) tea.Model {
-	path := a.nextReviewTargetPath(msg.CurrentPath)
-	if path == "" {
-		return a
-	}
 	switch a.view {
 	case core.ViewDiff:
-		a.diffView.JumpToFile(path)
+		if path, hunk := a.nextReviewTarget(msg.CurrentPath, msg.Hunk); path != "" {
+			a.diffView.JumpToHunk(path, hunk)
+		}
 	default:
-		a.prDetail.JumpToFile(path)
+		if path := a.currentReviewContext.NextActionableAfter(msg.CurrentPath); path != "" {
+			a.prDetail.JumpToFile(path)
+		}
 	}
 	return a
 }
@@ -1569,23 +2128,53 @@ func (a *App) handleToggleViewedFile(msg views.ToggleViewedFileMsg) tea.Model {
 		return a
 	}
 
+	a.setViewedHunks(file, func(h reviewprogress.Hunk, _ int) bool { return !file.Viewed })
+	return a
+}
+
+func (a *App) handleToggleViewedHunk(msg views.ToggleViewedHunkMsg) tea.Model {
+	if msg.Path == "" || a.currentReviewContext == nil {
+		return a
+	}
+	file, ok := a.currentReviewContext.FindFile(msg.Path)
+	if !ok || msg.Hunk < 0 || msg.Hunk >= len(file.Hunks) {
+		return a
+	}
+	a.setViewedHunks(file, func(h reviewprogress.Hunk, i int) bool {
+		if i == msg.Hunk {
+			return !h.Viewed
+		}
+		return h.Viewed
+	})
+	return a
+}
+
+// setViewedHunks stores which hunks of file are viewed. Digests of hunks
+// the file no longer has are dropped.
+func (a *App) setViewedHunks(file reviewprogress.File, viewed func(h reviewprogress.Hunk, i int) bool) {
+	var digests []string
+	for i, h := range file.Hunks {
+		if viewed(h, i) {
+			digests = append(digests, h.Digest)
+		}
+	}
+
 	state := a.repoState.ReviewState(a.currentReviewPR)
 	if state.ViewedFiles == nil {
 		state.ViewedFiles = make(map[string]cache.FileReviewState)
 	}
-	if snap, ok := state.ViewedFiles[msg.Path]; ok && snap.PatchDigest == file.PatchDigest {
-		delete(state.ViewedFiles, msg.Path)
+	if len(digests) == 0 {
+		delete(state.ViewedFiles, file.Path)
 	} else {
-		state.ViewedFiles[msg.Path] = cache.FileReviewState{
+		state.ViewedFiles[file.Path] = cache.FileReviewState{
 			ViewedAt:      time.Now(),
 			ViewedHeadSHA: a.currentReviewContext.HeadSHA,
-			PatchDigest:   file.PatchDigest,
+			ViewedHunks:   digests,
 		}
 	}
 	a.repoState.SetReviewState(a.currentReviewPR, state)
 	a.saveRepoState()
 	a.rebuildReviewContext()
-	return a
 }
 
 func (a *App) rebuildReviewContext() {
@@ -1597,17 +2186,16 @@ func (a *App) rebuildReviewContext() {
 		return
 	}
 	state := a.repoState.ReviewState(detail.Number)
-	digests := a.currentReviewContext.CurrentDigests
-	a.currentReviewContext = reviewprogress.Build(detail, digests, state, a.currentReviewContext.DegradedDigestSource)
+	prev := a.currentReviewContext
+	a.currentReviewContext = reviewprogress.Build(detail, prev.CurrentDigests, prev.CurrentHunkDigests, state, prev.DegradedDigestSource)
 	a.prDetail.SetReviewContext(a.currentReviewContext)
 	a.diffView.SetReviewContext(a.currentReviewContext)
 }
 
-func (a *App) nextReviewTargetPath(current string) string {
-	if a.currentReviewContext == nil {
-		return ""
-	}
-	return a.currentReviewContext.NextActionableAfter(current)
+// nextReviewTarget returns the next unviewed hunk to review after the
+// given hunk of the file at current.
+func (a *App) nextReviewTarget(current string, hunk int) (path string, next int) {
+	return a.currentReviewContext.NextActionableHunk(current, hunk)
 }
 
 func (a *App) finalizeCurrentPRVisit() {
@@ -1643,17 +2231,18 @@ func (a *App) markCurrentPRReviewed() {
 		state.ViewedFiles = make(map[string]cache.FileReviewState)
 	}
 	markVisibleOnly := a.currentReviewContext.Scope != reviewprogress.ScopeAll
-	for path, digest := range files {
-		if markVisibleOnly {
-			file, ok := a.currentReviewContext.FindFile(path)
-			if !ok || !file.Actionable {
-				continue
-			}
+	for _, file := range a.currentReviewContext.Files {
+		if markVisibleOnly && !file.Actionable {
+			continue
+		}
+		digests := make([]string, 0, len(file.Hunks))
+		for _, h := range file.Hunks {
+			digests = append(digests, h.Digest)
 		}
-		state.ViewedFiles[path] = cache.FileReviewState{
+		state.ViewedFiles[file.Path] = cache.FileReviewState{
 			ViewedAt:      now,
 			ViewedHeadSHA: headSHA,
-			PatchDigest:   digest,
+			ViewedHunks:   digests,
 		}
 	}
 	if state.ActiveScope == "" {
@@ -1709,6 +2298,12 @@ func (a *App) updateActiveView(msg tea.Msg) tea.Cmd {
 		return a.confirmDialog.Update(msg)
 	case core.ViewSmartCheckout:
 		return a.checkoutDialog.Update(msg)
+	case core.ViewMerge:
+		return a.mergeDialog.Update(msg)
+	case core.ViewMetadata:
+		return a.metadataPicker.Update(msg)
+	case core.ViewCreatePR:
+		return a.createPRDialog.Update(msg)
 	}
 	return nil
 }
@@ -1730,6 +2325,9 @@ func (a *App) rebuildStyles() {
 	a.filterPanel.SetStyles(s)
 	a.confirmDialog.SetStyles(s)
 	a.checkoutDialog.SetStyles(s)
+	a.mergeDialog.SetStyles(s)
+	a.metadataPicker.SetStyles(s)
+	a.createPRDialog.SetStyles(s)
 
 	// Update styles on components (preserves state).
 	a.banner.SetStyles(s)
@@ -1770,10 +2368,9 @@ func (a *App) saveRepoState() {
 func (a *App) initRepoSwitcherFavorites() {
 	var entries []views.RepoEntry
 	for _, fav := range a.cfg.Repos.Favorites {
-		parts := strings.SplitN(fav, "/", 2)
-		if len(parts) == 2 {
+		if repo, err := domain.ParseRepoRef(fav); err == nil {
 			entries = append(entries, views.RepoEntry{
-				Repo:      domain.RepoRef{Owner: parts[0], Name: parts[1]},
+				Repo:      repo,
 				Favorite:  true,
 				Section:   views.SectionFavorite,
 				OpenCount: -1,
@@ -1787,6 +2384,7 @@ func (a *App) View() string {
 	if !a.ready {
 		return ""
 	}
+	a.emitBeforeRender()
 
 	// Banner supersedes everything when visible.
 	if a.view == core.ViewBanner && a.banner.Visible() {
@@ -1809,8 +2407,20 @@ func (a *App) View() string {
 	switch {
 	case a.view == core.ViewSmartCheckout:
 		a.status.SetHints([]string{a.checkoutDialog.StatusHint()})
+	case a.view == core.ViewMerge:
+		a.status.SetHints([]string{a.mergeDialog.StatusHint()})
+	case a.view == core.ViewMetadata:
+		a.status.SetHints([]string{a.metadataPicker.StatusHint()})
+	case a.view == core.ViewCreatePR:
+		a.status.SetHints([]string{a.createPRDialog.StatusHint()})
 	case a.view == core.ViewConfirm:
 		a.status.SetHints([]string{a.confirmDialog.ConfirmStateHint()})
+	case a.view == core.ViewPRDetail && a.prDetail.IsInputActive():
+		a.status.SetHints([]string{a.prDetail.StatusHint()})
+	case a.view == core.ViewDiff && a.interdiff:
+		a.status.SetHints([]string{"j/k scroll  Tab pane  {/} file  [/] hunk  t split  / search  I full diff  Esc back"})
+	case a.view == core.ViewDiff && a.diffScope != "":
+		a.status.SetHints([]string{"j/k scroll  Tab pane  {/} file  [/] hunk  t split  / search  Esc back to commits"})
 	case a.view == core.ViewPRList && a.prList.IsSelectionMode():
 		n := a.prList.SelectionCount()
 		a.status.SetHints([]string{fmt.Sprintf("%d selected  Space toggle  a all  y copy  o open  v exit  Esc cancel", n)})
@@ -1868,6 +2478,17 @@ func (a *App) renderContent(height int) string {
 	case core.ViewSmartCheckout:
 		return a.checkoutDialog.View()
 
+	case core.ViewMerge:
+		return a.mergeDialog.View()
+
+	case core.ViewMetadata:
+		return a.metadataPicker.View()
+	case core.ViewCreatePR:
+		return a.createPRDialog.View()
+
+	case core.ViewPlugin:
+		return a.renderPluginView(height)
+
 	default:
 		return ""
 	}
@@ -1897,6 +2518,14 @@ func (a *App) viewName() string {
 		return "Confirm"
 	case core.ViewSmartCheckout:
 		return "Smart Checkout"
+	case core.ViewMerge:
+		return "Merge"
+	case core.ViewMetadata:
+		return "Edit " + a.metadataPicker.Kind().String()
+	case core.ViewCreatePR:
+		return "New PR"
+	case core.ViewPlugin:
+		return a.pluginViews[a.pluginView].Title
 	default:
 		return ""
 	}
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -394,7 +395,7 @@ func TestAppToggleViewedFile(t *testing.T) {
 		CurrentDigests: map[string]string{
 			"plugin.go": "digest-1",
 		},
-		Files: []reviewprogress.File{{Path: "plugin.go", PatchDigest: "digest-1"}},
+		Files: []reviewprogress.File{{Path: "plugin.go", PatchDigest: "digest-1", Hunks: []reviewprogress.Hunk{{Digest: "digest-1"}}}},
 	}
 	app.prDetail.SetDetail(&domain.PRDetail{
 		PR:    domain.PR{Number: 42},
@@ -405,10 +406,45 @@ func TestAppToggleViewedFile(t *testing.T) {
 	a := updated.(*App)
 
 	state := a.repoState.ReviewState(42)
-	assert.Equal(t, "digest-1", state.ViewedFiles["plugin.go"].PatchDigest)
+	assert.Equal(t, []string{"digest-1"}, state.ViewedFiles["plugin.go"].ViewedHunks)
 	assert.WithinDuration(t, time.Now(), state.ViewedFiles["plugin.go"].ViewedAt, time.Second)
 }
 
+func TestAppToggleViewedHunk(t *testing.T) {
+	app := newTestApp()
+	app.currentReviewPR = 42
+	app.currentReviewContext = &reviewprogress.Context{
+		Scope:              reviewprogress.ScopeAll,
+		HeadSHA:            "head-1",
+		CurrentDigests:     map[string]string{"plugin.go": "digest-1"},
+		CurrentHunkDigests: map[string][]string{"plugin.go": {"hunk-1", "hunk-2"}},
+		Files: []reviewprogress.File{{
+			Path:  "plugin.go",
+			Hunks: []reviewprogress.Hunk{{Digest: "hunk-1"}, {Digest: "hunk-2"}},
+		}},
+	}
+	app.prDetail.SetDetail(&domain.PRDetail{
+		PR:    domain.PR{Number: 42},
+		Files: []domain.FileChange{{Path: "plugin.go", Additions: 4, Status: "modified"}},
+	})
+
+	app.Update(views.ToggleViewedHunkMsg{Path: "plugin.go", Hunk: 1})
+
+	assert.Equal(t, []string{"hunk-2"}, app.repoState.ReviewState(42).ViewedFiles["plugin.go"].ViewedHunks)
+	assert.Equal(t, 1, app.currentReviewContext.ViewedHunks)
+	assert.Equal(t, 0, app.currentReviewContext.ViewedFiles)
+	path, hunk := app.nextReviewTarget("plugin.go", -1)
+	assert.Equal(t, "plugin.go", path)
+	assert.Equal(t, 0, hunk, "the unviewed hunk is next")
+
+	app.Update(views.ToggleViewedHunkMsg{Path: "plugin.go", Hunk: 0})
+	assert.Equal(t, 1, app.currentReviewContext.ViewedFiles)
+
+	app.Update(views.ToggleViewedFileMsg{Path: "plugin.go"})
+	_, ok := app.repoState.ReviewState(42).ViewedFiles["plugin.go"]
+	assert.False(t, ok, "unviewing the file clears its hunks")
+}
+
 func TestAppIgnoresStaleDiffLoaded(t *testing.T) {
 	app := newTestApp()
 	app.currentReviewPR = 42
@@ -466,3 +502,143 @@ func TestAppReviewSubmittedMarksOnlyVisibleScopeFilesViewed(t *testing.T) {
 	assert.Contains(t, state.ViewedFiles, "plugin.go")
 	assert.NotContains(t, state.ViewedFiles, "registry.go")
 }
+
+// budgetReader is a PR reader that reports a fixed API budget.
+type budgetReader struct {
+	domain.PRReader
+	limit domain.RateLimit
+}
+
+func (r budgetReader) RateLimit(context.Context, domain.RepoRef) (domain.RateLimit, error) {
+	return r.limit, nil
+}
+
+func TestAppRateLimitPausesAutoRefresh(t *testing.T) {
+	app := newTestApp()
+	app.ready = true
+	app.view = core.ViewPRList
+	app.banner.Hide()
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	err := fmt.Errorf("listing: %w", &domain.RateLimitError{RetryAfter: 5 * time.Minute})
+	updated, cmd := app.Update(views.PRsLoadedMsg{Err: err})
+	a := updated.(*App)
+
+	assert.NotNil(t, cmd, "should return warning toast cmd")
+	assert.WithinDuration(t, time.Now().Add(5*time.Minute), a.rateLimitedUntil, 5*time.Second)
+	assert.Contains(t, a.status.View(), "rate limited · resumes")
+
+	// Auto-refresh stays held through the countdown.
+	a.refreshCountdown = 1
+	a.handleRefreshTick()
+	assert.Equal(t, a.refreshInterval, a.refreshCountdown)
+	assert.True(t, time.Now().Before(a.rateLimitedUntil))
+}
+
+func TestAppShowsRateLimitBudget(t *testing.T) {
+	reader := budgetReader{limit: domain.RateLimit{Resource: "core", Limit: 5000, Remaining: 4812}}
+	app := New(config.Default(), WithVersion("test"), WithReader(reader), WithRepo(domain.RepoRef{Owner: "o", Name: "r"}))
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	cmd := app.fetchRateLimit()
+	require.NotNil(t, cmd)
+	app.Update(cmd())
+
+	assert.Contains(t, app.status.View(), "API 4812/5000")
+	assert.True(t, app.rateLimitedUntil.IsZero())
+}
+
+func TestAppExhaustedBudgetPausesUntilReset(t *testing.T) {
+	app := newTestApp()
+	reset := time.Now().Add(20 * time.Minute)
+
+	app.Update(rateLimitLoadedMsg{Limit: domain.RateLimit{Limit: 5000, Remaining: 0, Reset: reset}})
+
+	assert.Equal(t, reset, app.rateLimitedUntil)
+}
+
+func TestAppInboxRateLimitKeepsPartialResults(t *testing.T) {
+	app := newTestApp()
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app.view = core.ViewInbox
+
+	msg := views.InboxPRsLoadedMsg{
+		PRs: []views.InboxPR{{PR: domain.PR{Number: 1, Title: "partial result"}, Repo: domain.RepoRef{Owner: "o", Name: "r"}}},
+		Err: &domain.RateLimitError{Secondary: true, RetryAfter: time.Minute},
+	}
+	_, cmd := app.Update(msg)
+
+	assert.NotNil(t, cmd)
+	assert.False(t, app.rateLimitedUntil.IsZero())
+	assert.Contains(t, app.inbox.View(), "partial result")
+}
+
+func TestAppErrorToastShowsRemedy(t *testing.T) {
+	app := newTestApp()
+	app.Update(tea.WindowSizeMsg{Width: 200, Height: 40})
+
+	err := fmt.Errorf("listing: %w", &domain.HostError{
+		Kind:    domain.ErrInsufficientScope,
+		Message: "Your token has not been granted the required scopes",
+		Remedy:  "run gh auth refresh -s read:org",
+	})
+	app.Update(views.PRsLoadedMsg{Err: err})
+
+	toast := app.toasts.View()
+	assert.Contains(t, toast, "token is missing required scopes — run gh auth refresh -s read:org")
+	assert.NotContains(t, toast, "Your token has not been granted")
+}
+
+func TestAppErrorToastFallsBackToError(t *testing.T) {
+	app := newTestApp()
+	app.Update(tea.WindowSizeMsg{Width: 200, Height: 40})
+
+	app.Update(views.ReviewSubmittedMsg{Err: fmt.Errorf("gh: unexpected failure")})
+
+	assert.Contains(t, app.toasts.View(), "Review failed: gh: unexpected failure")
+}
+
+// ciReader is a PR reader that reports CI statuses in batches.
+type ciReader struct {
+	domain.PRReader
+	statuses map[int]domain.CIStatus
+}
+
+func (r ciReader) GetCIStatuses(_ context.Context, _ domain.RepoRef, numbers []int) (map[int]domain.CIStatus, error) {
+	out := make(map[int]domain.CIStatus, len(numbers))
+	for _, n := range numbers {
+		out[n] = r.statuses[n]
+	}
+	return out, nil
+}
+
+func TestAppHydratesCIForListedPRs(t *testing.T) {
+	repo := domain.RepoRef{Owner: "o", Name: "r"}
+	reader := ciReader{statuses: map[int]domain.CIStatus{1: domain.CIPass, 2: domain.CIFail}}
+	app := New(config.Default(), WithVersion("test"), WithReader(reader), WithRepo(repo))
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	prs := []domain.PR{
+		{Number: 1, Title: "unknown", State: domain.PRStateOpen},
+		{Number: 2, Title: "pending", State: domain.PRStateOpen, CI: domain.CIPending},
+		{Number: 3, Title: "done", State: domain.PRStateOpen, CI: domain.CIPass},
+	}
+	app.prList.SetPRs(prs)
+	cmd := app.hydrateCI()
+	require.NotNil(t, cmd)
+
+	msg := cmd()
+	require.IsType(t, views.CIStatusesLoadedMsg{}, msg)
+	assert.Len(t, msg.(views.CIStatusesLoadedMsg).Statuses, 2, "settled rows are not re-queried")
+	app.Update(msg)
+
+	got := map[int]domain.CIStatus{}
+	for _, pr := range app.prList.FilteredPRs() {
+		got[pr.Number] = pr.CI
+	}
+	assert.Equal(t, map[int]domain.CIStatus{1: domain.CIPass, 2: domain.CIFail, 3: domain.CIPass}, got)
+
+	// Results for a repo the user has switched away from are dropped.
+	app.Update(views.CIStatusesLoadedMsg{Repo: domain.RepoRef{Owner: "x", Name: "y"}, Statuses: map[int]domain.CIStatus{3: domain.CIFail}})
+	assert.Equal(t, domain.CIPass, app.prList.FilteredPRs()[2].CI)
+}
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"context"
+	"fmt"
 	"os/exec"
 	"strings"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/indrasvat/vivecaka/internal/cache"
 	"github.com/indrasvat/vivecaka/internal/domain"
 	"github.com/indrasvat/vivecaka/internal/logging"
+	"github.com/indrasvat/vivecaka/internal/plugin"
 	"github.com/indrasvat/vivecaka/internal/tui/views"
 	"github.com/indrasvat/vivecaka/internal/usecase"
 )
@@ -30,29 +32,64 @@ func detectRepoCmd() tea.Cmd {
 	}
 }
 
-// detectUserCmd detects the current GitHub user via gh CLI.
-func detectUserCmd() tea.Cmd {
+// detectUserCmd detects the current GitHub user on host via gh CLI.
+func detectUserCmd(host string) tea.Cmd {
 	return func() tea.Msg {
-		username, err := ghcli.DetectUser(context.Background())
+		username, err := ghcli.DetectUser(context.Background(), host)
 		return views.UserDetectedMsg{Username: username, Err: err}
 	}
 }
 
 // loadPRsCmd fetches PRs for the given repo.
-func loadPRsCmd(uc *usecase.ListPRs, repo domain.RepoRef, opts domain.ListOpts) tea.Cmd {
+func loadPRsCmd(uc *usecase.ListPRs, hooks *plugin.HookManager, repo domain.RepoRef, opts domain.ListOpts) tea.Cmd {
 	return func() tea.Msg {
-		prs, err := uc.Execute(context.Background(), repo, opts)
-		return views.PRsLoadedMsg{PRs: prs, Err: err}
+		page, err := fetchPRPage(uc, hooks, repo, opts)
+		return views.PRsLoadedMsg{PRs: page.PRs, NextCursor: page.NextCursor, Err: err}
 	}
 }
 
-// loadMorePRsCmd fetches additional PRs for pagination.
-func loadMorePRsCmd(uc *usecase.ListPRs, repo domain.RepoRef, opts domain.ListOpts, page int) tea.Cmd {
+// loadMorePRsCmd fetches the next page of PRs, resuming at opts.Cursor when
+// the reader paginates with cursors.
+func loadMorePRsCmd(uc *usecase.ListPRs, hooks *plugin.HookManager, repo domain.RepoRef, opts domain.ListOpts, page int) tea.Cmd {
 	return func() tea.Msg {
-		prs, err := uc.Execute(context.Background(), repo, opts)
-		// Determine if there are more pages: if we got fewer than PerPage, no more
-		hasMore := len(prs) >= opts.PerPage
-		return views.MorePRsLoadedMsg{PRs: prs, Page: page, HasMore: hasMore, Err: err}
+		next, err := fetchPRPage(uc, hooks, repo, opts)
+		return views.MorePRsLoadedMsg{
+			PRs:        next.PRs,
+			Page:       page,
+			HasMore:    next.HasMore,
+			NextCursor: next.NextCursor,
+			Err:        err,
+		}
+	}
+}
+
+// fetchPRPage fetches one page of PRs between the before_fetch and
+// after_fetch plugin hooks. A before_fetch error cancels the fetch.
+func fetchPRPage(uc *usecase.ListPRs, hooks *plugin.HookManager, repo domain.RepoRef, opts domain.ListOpts) (domain.PRPage, error) {
+	ctx := context.Background()
+	event := plugin.FetchEvent{Repo: repo, Opts: opts}
+	if err := hooks.Emit(ctx, plugin.HookBeforeFetch, event); err != nil {
+		return domain.PRPage{}, fmt.Errorf("before_fetch hook: %w", err)
+	}
+	page, err := uc.ExecutePage(ctx, repo, opts)
+	event.PRs, event.Err = page.PRs, err
+	if hookErr := hooks.Emit(ctx, plugin.HookAfterFetch, event); hookErr != nil {
+		logging.Log.Warn("after_fetch hook failed", "err", hookErr)
+	}
+	return page, err
+}
+
+// ciBatchSize caps how many PRs one CI status query covers.
+const ciBatchSize = 25
+
+// loadCIStatusesCmd fetches the CI status of a batch of listed PRs.
+func loadCIStatusesCmd(reader domain.CIStatusReader, repo domain.RepoRef, numbers []int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), ghTimeout)
+		defer cancel()
+
+		statuses, err := reader.GetCIStatuses(ctx, repo, numbers)
+		return views.CIStatusesLoadedMsg{Repo: repo, Statuses: statuses, Err: err}
 	}
 }
 
@@ -124,7 +161,7 @@ func loadDiffCmd(reader domain.PRReader, repo domain.RepoRef, number int) tea.Cm
 func submitReviewCmd(uc *usecase.ReviewPR, repo domain.RepoRef, number int, review domain.Review) tea.Cmd {
 	return func() tea.Msg {
 		err := uc.Execute(context.Background(), repo, number, review)
-		return views.ReviewSubmittedMsg{Err: err}
+		return views.ReviewSubmittedMsg{Number: number, Review: review, Err: err}
 	}
 }
 
@@ -132,7 +169,23 @@ func submitReviewCmd(uc *usecase.ReviewPR, repo domain.RepoRef, number int, revi
 func checkoutPRCmd(uc *usecase.CheckoutPR, repo domain.RepoRef, number int) tea.Cmd {
 	return func() tea.Msg {
 		branch, err := uc.Execute(context.Background(), repo, number)
-		return views.CheckoutDoneMsg{Branch: branch, Err: err}
+		return views.CheckoutDoneMsg{Number: number, Branch: branch, Err: err}
+	}
+}
+
+// loadMergeStatusCmd fetches what the merge dialog shows about a PR.
+func loadMergeStatusCmd(uc *usecase.MergePR, repo domain.RepoRef, pr domain.PR) tea.Cmd {
+	return func() tea.Msg {
+		status, err := uc.Status(context.Background(), repo, pr)
+		return views.MergeStatusLoadedMsg{Number: pr.Number, Status: status, Err: err}
+	}
+}
+
+// mergePRCmd merges a PR, or enables auto-merge for it.
+func mergePRCmd(uc *usecase.MergePR, repo domain.RepoRef, number int, opts domain.MergeOpts) tea.Cmd {
+	return func() tea.Msg {
+		err := uc.Execute(context.Background(), repo, number, opts)
+		return views.MergeDoneMsg{Number: number, Auto: opts.Auto, Err: err}
 	}
 }
 
@@ -144,12 +197,19 @@ func resolveThreadCmd(uc *usecase.ResolveThread, repo domain.RepoRef, threadID s
 	}
 }
 
+// commentActionCmd runs a comment action on a PR and reports the outcome
+// with the success text done or the error prefix failed.
+func commentActionCmd(number int, done, failed string, action func(context.Context) error) tea.Cmd {
+	return func() tea.Msg {
+		err := action(context.Background())
+		return commentActionDoneMsg{Number: number, Done: done, Failed: failed, Err: err}
+	}
+}
+
 // detectBranchCmd detects the current git branch.
 func detectBranchCmd() tea.Cmd {
 	return func() tea.Msg {
-		cmd := exec.CommandContext(context.Background(), "git", "rev-parse", "--abbrev-ref", "HEAD")
-		out, err := cmd.Output()
-		branch := strings.TrimSpace(string(out))
+		branch, err := currentBranch(context.Background(), "")
 		if err != nil || branch == "" {
 			return views.BranchDetectedMsg{Err: err}
 		}
@@ -157,6 +217,15 @@ func detectBranchCmd() tea.Cmd {
 	}
 }
 
+// currentBranch returns the branch checked out in dir, the working
+// directory when dir is empty. A detached HEAD gives "HEAD".
+func currentBranch(ctx context.Context, dir string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--abbrev-ref", "HEAD")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	return strings.TrimSpace(string(out)), err
+}
+
 // discoverReposCmd fetches the user's repos via gh repo list.
 func discoverReposCmd() tea.Cmd {
 	return func() tea.Msg {
@@ -168,16 +237,31 @@ func discoverReposCmd() tea.Cmd {
 // loadInboxCmd fetches PRs from multiple repos for the inbox.
 func loadInboxCmd(uc *usecase.GetInboxPRs, repos []domain.RepoRef) tea.Cmd {
 	return func() tea.Msg {
+		// A rate-limited fan-out still carries the PRs fetched before it stopped.
 		prs, err := uc.Execute(context.Background(), repos)
-		if err != nil {
-			return views.InboxPRsLoadedMsg{PRs: nil}
-		}
 		// Convert usecase.InboxPR to views.InboxPR.
 		vPRs := make([]views.InboxPR, len(prs))
 		for i, p := range prs {
 			vPRs[i] = views.InboxPR{PR: p.PR, Repo: p.Repo}
 		}
-		return views.InboxPRsLoadedMsg{PRs: vPRs}
+		return views.InboxPRsLoadedMsg{PRs: vPRs, Err: err}
+	}
+}
+
+// rateLimitLoadedMsg carries the API budget left on the current repo's host.
+type rateLimitLoadedMsg struct {
+	Limit domain.RateLimit
+	Err   error
+}
+
+// fetchRateLimitCmd queries the remaining API budget for repo's host.
+func fetchRateLimitCmd(rl domain.RateLimitReporter, repo domain.RepoRef) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), ghTimeout)
+		defer cancel()
+
+		limit, err := rl.RateLimit(ctx, repo)
+		return rateLimitLoadedMsg{Limit: limit, Err: err}
 	}
 }
 
@@ -210,7 +294,7 @@ func saveCacheCmd(repo domain.RepoRef, prs []domain.PR) tea.Cmd {
 func addInlineCommentCmd(uc *usecase.AddComment, repo domain.RepoRef, number int, input domain.InlineCommentInput) tea.Cmd {
 	return func() tea.Msg {
 		err := uc.Execute(context.Background(), repo, number, input)
-		return views.InlineCommentAddedMsg{Err: err}
+		return views.InlineCommentAddedMsg{Number: number, Input: input, Err: err}
 	}
 }
 
@@ -232,7 +316,7 @@ func smartCheckoutCmd(uc *usecase.SmartCheckout, repo domain.RepoRef, number int
 		ctx, cancel := context.WithTimeout(context.Background(), ghTimeout)
 		defer cancel()
 		branch, err := uc.ExecuteCheckout(ctx, repo, number, workDir)
-		return views.SmartCheckoutDoneMsg{Branch: branch, Path: workDir, Err: err}
+		return views.SmartCheckoutDoneMsg{Number: number, Branch: branch, Path: workDir, Err: err}
 	}
 }
 
@@ -242,7 +326,7 @@ func worktreeCmd(uc *usecase.SmartCheckout, repo domain.RepoRef, number int, bra
 		ctx, cancel := context.WithTimeout(context.Background(), ghTimeout)
 		defer cancel()
 		wtPath, err := uc.ExecuteWorktree(ctx, repo, number, branch, basePath)
-		return views.SmartCheckoutDoneMsg{Branch: branch, Path: wtPath, Err: err}
+		return views.SmartCheckoutDoneMsg{Number: number, Branch: branch, Path: wtPath, Err: err}
 	}
 }
 
@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/tui/core"
+	"github.com/indrasvat/vivecaka/internal/tui/views"
+)
+
+// handleLoadCommits loads the commits of the PR shown in the detail view.
+func (a *App) handleLoadCommits(msg views.LoadCommitsMsg) tea.Cmd {
+	if a.prCommits == nil || a.repo.Owner == "" {
+		return func() tea.Msg { return views.CommitsLoadedMsg{Number: msg.Number, Err: errors.ErrUnsupported} }
+	}
+	uc, repo := a.prCommits, a.repo
+	return func() tea.Msg {
+		commits, err := uc.List(context.Background(), repo, msg.Number)
+		return views.CommitsLoadedMsg{Number: msg.Number, Commits: commits, Err: err}
+	}
+}
+
+// handleOpenCommitDiff opens the diff of one commit, or of a range of
+// commits.
+func (a *App) handleOpenCommitDiff(msg views.OpenCommitDiffMsg) tea.Cmd {
+	scope := views.CommitScope(msg.Commits)
+	uc, repo := a.prCommits, a.repo
+	return a.openScopedDiff(msg.Number, scope, func() (*domain.Diff, error) {
+		return uc.Diff(context.Background(), repo, msg.Commits)
+	})
+}
+
+// openScopedDiff shows the diff load returns in place of the PR diff,
+// read-only: comments and review progress belong to the whole PR diff.
+// scope describes it in the diff view's header.
+func (a *App) openScopedDiff(number int, scope string, load func() (*domain.Diff, error)) tea.Cmd {
+	a.diffScope = scope
+	a.interdiff = false
+	a.view = core.ViewDiff
+	a.diffView.SetPRNumber(number)
+	a.diffView.SetReadOnly(true)
+	a.diffView.SetScope(scope)
+	a.diffView.SetComments(nil)
+	a.diffView.SetPendingComments(nil)
+	a.diffView.SetReviewContext(nil)
+	spinnerCmd := a.diffView.StartLoading()
+	return tea.Batch(spinnerCmd, func() tea.Msg {
+		diff, err := load()
+		return views.ScopedDiffLoadedMsg{Number: number, Scope: scope, Diff: diff, Err: err}
+	})
+}
+
+// handleScopedDiffLoaded shows a commit diff or an interdiff if the diff
+// view still waits for it.
+func (a *App) handleScopedDiffLoaded(msg views.ScopedDiffLoadedMsg) tea.Cmd {
+	if a.view != core.ViewDiff || msg.Scope != a.diffScope {
+		return nil
+	}
+	cmds := []tea.Cmd{a.diffView.Update(views.DiffLoadedMsg{Number: msg.Number, Diff: msg.Diff, Err: msg.Err})}
+	if errors.Is(msg.Err, domain.ErrRateLimited) {
+		cmds = append(cmds, a.rateLimited(msg.Err))
+	}
+	if a.interdiff && msg.Diff != nil && len(msg.Diff.Files) == 0 {
+		cmds = append(cmds, a.toasts.Add("The PR's changes are the same; only its base moved", domain.ToastInfo, 5*time.Second))
+	}
+	return tea.Batch(cmds...)
+}
@@ -1,10 +1,13 @@
 package components
 
 import (
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/indrasvat/vivecaka/internal/domain"
 	"github.com/indrasvat/vivecaka/internal/tui/core"
 )
 
@@ -15,6 +18,10 @@ type StatusBar struct {
 	msg    string
 	msgErr bool
 	width  int
+
+	// API budget, shown on the right when the backend reports one.
+	rateLimit    domain.RateLimit
+	limitedUntil time.Time
 }
 
 // SetStyles updates the styles without losing state.
@@ -37,6 +44,13 @@ func (s *StatusBar) SetMessage(msg string, isError bool) {
 // ClearMessage clears the transient message.
 func (s *StatusBar) ClearMessage() { s.msg = "" }
 
+// SetRateLimit updates the API budget and the time requests resume after
+// hitting a rate limit (zero when not limited).
+func (s *StatusBar) SetRateLimit(rl domain.RateLimit, limitedUntil time.Time) {
+	s.rateLimit = rl
+	s.limitedUntil = limitedUntil
+}
+
 // SetWidth updates the status bar width.
 func (s *StatusBar) SetWidth(w int) { s.width = w }
 
@@ -60,5 +74,33 @@ func (sb *StatusBar) View() string {
 		left = strings.Join(parts, "  ")
 	}
 
-	return sb.styles.StatusBar.Width(sb.width).Render(left)
+	right := sb.rateLimitView()
+	if right == "" {
+		return sb.styles.StatusBar.Width(sb.width).Render(left)
+	}
+	// Drop the budget rather than wrap when the hints fill the bar.
+	inner := sb.width - sb.styles.StatusBar.GetHorizontalFrameSize()
+	gap := inner - lipgloss.Width(left) - lipgloss.Width(right)
+	if gap < 1 {
+		return sb.styles.StatusBar.Width(sb.width).Render(left)
+	}
+	return sb.styles.StatusBar.Width(sb.width).Render(left + strings.Repeat(" ", gap) + right)
+}
+
+// rateLimitView renders the API budget, or when requests are rate limited,
+// the time they resume.
+func (sb *StatusBar) rateLimitView() string {
+	t := sb.styles.Theme
+	if time.Now().Before(sb.limitedUntil) {
+		return lipgloss.NewStyle().Foreground(t.Error).
+			Render("rate limited · resumes " + sb.limitedUntil.Format("15:04"))
+	}
+	if sb.rateLimit.Limit <= 0 {
+		return ""
+	}
+	style := lipgloss.NewStyle().Foreground(t.Muted)
+	if sb.rateLimit.Low() {
+		style = lipgloss.NewStyle().Foreground(t.Warning)
+	}
+	return style.Render(fmt.Sprintf("API %d/%d", sb.rateLimit.Remaining, sb.rateLimit.Limit))
 }
@@ -32,6 +32,15 @@ type KeyMap struct {
 	Yank     key.Binding
 	Open     key.Binding
 	Checkout key.Binding
+	Merge    key.Binding
+
+	// PR detail
+	EditLabels    key.Binding
+	EditAssignees key.Binding
+	EditReviewers key.Binding
+	ClosePR       key.Binding
+	ToggleDraft   key.Binding
+	UpdateBranch  key.Binding
 }
 
 // DefaultKeyMap returns the default keybindings.
@@ -133,16 +142,48 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("c"),
 			key.WithHelp("c", "checkout"),
 		),
+		Merge: key.NewBinding(
+			key.WithKeys("M"),
+			key.WithHelp("M", "merge"),
+		),
+
+		// PR detail
+		EditLabels: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "edit labels"),
+		),
+		EditAssignees: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "edit assignees"),
+		),
+		EditReviewers: key.NewBinding(
+			key.WithKeys("Q"),
+			key.WithHelp("Q", "request reviewers"),
+		),
+		ClosePR: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "close / reopen"),
+		),
+		ToggleDraft: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "toggle draft"),
+		),
+		UpdateBranch: key.NewBinding(
+			key.WithKeys("U"),
+			key.WithHelp("U", "update branch"),
+		),
 	}
 }
 
 // ApplyOverrides applies keybinding overrides from a string map.
 // Keys are binding names (e.g., "quit", "search"), values are key strings (e.g., "ctrl+q").
+// The help shows the new key.
 func (k *KeyMap) ApplyOverrides(overrides map[string]string) {
 	for name, keyStr := range overrides {
 		binding := k.bindingByName(name)
 		if binding != nil {
 			binding.SetKeys(keyStr)
+			binding.SetHelp(keyStr, binding.Help().Desc)
 		}
 	}
 }
@@ -195,6 +236,20 @@ func (k *KeyMap) bindingByName(name string) *key.Binding {
 		return &k.Open
 	case "checkout":
 		return &k.Checkout
+	case "merge":
+		return &k.Merge
+	case "edit_labels":
+		return &k.EditLabels
+	case "edit_assignees":
+		return &k.EditAssignees
+	case "edit_reviewers":
+		return &k.EditReviewers
+	case "close_pr":
+		return &k.ClosePR
+	case "toggle_draft":
+		return &k.ToggleDraft
+	case "update_branch":
+		return &k.UpdateBranch
 	default:
 		return nil
 	}
@@ -210,7 +265,8 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.PageUp, k.PageDown, k.HalfPageUp, k.HalfPageDown, k.Top, k.Bottom},
 		{k.Enter, k.Back, k.Tab, k.ShiftTab, k.Search, k.Filter, k.Sort},
-		{k.Yank, k.Open, k.Checkout, k.Refresh, k.ThemeCycle, k.RepoSwitch},
+		{k.Yank, k.Open, k.Checkout, k.Merge, k.Refresh, k.ThemeCycle, k.RepoSwitch},
+		{k.EditLabels, k.EditAssignees, k.EditReviewers, k.ClosePR, k.ToggleDraft, k.UpdateBranch},
 		{k.Help, k.Quit},
 	}
 }
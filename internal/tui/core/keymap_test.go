@@ -27,6 +27,13 @@ func TestDefaultKeyMapCreation(t *testing.T) {
 		{"Yank", km.Yank},
 		{"Open", km.Open},
 		{"Checkout", km.Checkout},
+		{"Merge", km.Merge},
+		{"EditLabels", km.EditLabels},
+		{"EditAssignees", km.EditAssignees},
+		{"EditReviewers", km.EditReviewers},
+		{"ClosePR", km.ClosePR},
+		{"ToggleDraft", km.ToggleDraft},
+		{"UpdateBranch", km.UpdateBranch},
 		{"Refresh", km.Refresh},
 		{"RepoSwitch", km.RepoSwitch},
 		{"ThemeCycle", km.ThemeCycle},
@@ -61,6 +68,7 @@ func TestApplyOverrides(t *testing.T) {
 	// Verify search was overridden.
 	searchKeys := km.Search.Keys()
 	assert.Contains(t, searchKeys, "ctrl+f")
+	assert.Equal(t, "ctrl+f", km.Search.Help().Key, "help shows the new key")
 }
 
 func TestApplyOverridesUnknown(t *testing.T) {
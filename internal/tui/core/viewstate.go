@@ -16,4 +16,36 @@ const (
 	ViewFilter
 	ViewConfirm
 	ViewSmartCheckout
+	ViewMerge
+	ViewMetadata
+	ViewCreatePR
+	ViewPlugin // a view mounted by a plugin
 )
+
+// viewNames are the names plugins use to refer to views.
+var viewNames = map[ViewState]string{
+	ViewBanner:        "banner",
+	ViewLoading:       "loading",
+	ViewPRList:        "pr_list",
+	ViewPRDetail:      "pr_detail",
+	ViewDiff:          "diff",
+	ViewReview:        "review",
+	ViewHelp:          "help",
+	ViewRepoSwitch:    "repo_switch",
+	ViewInbox:         "inbox",
+	ViewFilter:        "filter",
+	ViewConfirm:       "confirm",
+	ViewSmartCheckout: "smart_checkout",
+	ViewMerge:         "merge",
+	ViewMetadata:      "metadata",
+	ViewCreatePR:      "create_pr",
+	ViewPlugin:        "plugin",
+}
+
+// String returns the view's name, as used by plugin key bindings and hooks.
+func (v ViewState) String() string {
+	if name, ok := viewNames[v]; ok {
+		return name
+	}
+	return "unknown"
+}
@@ -0,0 +1,106 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/tui/core"
+	"github.com/indrasvat/vivecaka/internal/tui/views"
+)
+
+// handleNewPR opens the create PR dialog for the branch checked out in the
+// local clone of the repo. The metadata options, when the writer has them,
+// fill the reviewer and label pickers.
+func (a *App) handleNewPR() tea.Cmd {
+	if a.createPR == nil || a.repo.Owner == "" {
+		return a.unsupported("Creating PRs")
+	}
+	dir := a.findRepoDir()
+	if dir == "" {
+		return a.toasts.Add(fmt.Sprintf("Run vivecaka from a clone of %s to open a PR", a.repo.FullName()), domain.ToastWarning, 5*time.Second)
+	}
+	a.prevView = a.view
+	a.view = core.ViewCreatePR
+	cmds := []tea.Cmd{a.createPRDialog.Show()}
+
+	uc, repo := a.createPR, a.repo
+	cmds = append(cmds, func() tea.Msg {
+		ctx := context.Background()
+		head, err := currentBranch(ctx, dir)
+		if err != nil {
+			return views.NewPRBasesLoadedMsg{Err: fmt.Errorf("reading the current branch: %w", err)}
+		}
+		bases, err := uc.Bases(ctx, repo, head)
+		return views.NewPRBasesLoadedMsg{Head: head, Bases: bases, Err: err}
+	})
+	if a.metadataOptions != nil && a.metadataOptionsRepo.Equal(a.repo) {
+		a.createPRDialog.SetOptions(a.metadataOptions)
+	} else if a.editMetadata != nil {
+		cmds = append(cmds, loadMetadataOptionsCmd(a.editMetadata, a.repo))
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleNewPRBasesLoaded fills the create PR dialog with the branches to
+// open the PR against. The dialog closes when the repo's backend cannot
+// open PRs.
+func (a *App) handleNewPRBasesLoaded(msg views.NewPRBasesLoadedMsg) tea.Cmd {
+	if errors.Is(msg.Err, errors.ErrUnsupported) && a.view == core.ViewCreatePR {
+		a.createPRDialog.Close()
+		a.view = a.prevView
+		return a.unsupported("Creating PRs")
+	}
+	return a.createPRDialog.SetBases(msg)
+}
+
+// handleCompareBranch compares the branch with the chosen base and reads
+// the repo's PR template for the body.
+func (a *App) handleCompareBranch(msg views.CompareBranchMsg) tea.Cmd {
+	uc, dir := a.createPR, a.findRepoDir()
+	return func() tea.Msg {
+		c, err := uc.Compare(context.Background(), dir, msg.Base, msg.Head)
+		return views.BranchComparedMsg{Base: msg.Base, Comparison: c, Template: uc.Template(dir), Err: err}
+	}
+}
+
+// handleSubmitNewPR pushes the branch if needed and creates the PR.
+func (a *App) handleSubmitNewPR(msg views.SubmitNewPRMsg) tea.Cmd {
+	spinnerCmd := a.createPRDialog.ShowCreating()
+	uc, repo, dir := a.createPR, a.repo, a.findRepoDir()
+	return tea.Batch(spinnerCmd, func() tea.Msg {
+		number, err := uc.Execute(context.Background(), repo, dir, msg.PR, msg.Push)
+		return views.PRCreatedMsg{Number: number, Err: err}
+	})
+}
+
+// handlePRCreated lands in the detail view of the new PR and reloads the
+// list. A PR opened with a failed follow-up step, such as requesting
+// reviewers, is still opened, with the error in a toast.
+func (a *App) handlePRCreated(msg views.PRCreatedMsg) tea.Cmd {
+	if msg.Number == 0 {
+		if a.view == core.ViewCreatePR {
+			a.createPRDialog.ShowError(msg.Err)
+			return nil
+		}
+		return a.errorToast("Creating the PR failed", msg.Err)
+	}
+	if a.view == core.ViewCreatePR {
+		a.createPRDialog.Close()
+		a.view = a.prevView
+	}
+	toast := a.toasts.Add(fmt.Sprintf("Created PR #%d", msg.Number), domain.ToastSuccess, 3*time.Second)
+	if msg.Err != nil {
+		toast = a.errorToast(fmt.Sprintf("Created PR #%d with errors", msg.Number), msg.Err)
+	}
+	cmds := []tea.Cmd{toast}
+	if a.listPRs != nil {
+		cmds = append(cmds, loadPRsCmd(a.listPRs, a.hooks, a.repo, a.filterOpts))
+	}
+	_, openCmd := a.handleOpenPR(views.OpenPRMsg{Number: msg.Number})
+	return tea.Batch(append(cmds, openCmd)...)
+}
@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/indrasvat/vivecaka/internal/tui/views"
+)
+
+// editorScissors separates the text being written from the context below
+// it, which is dropped when the file is read back, as git commit -v does.
+const editorScissors = "# ------------------------ >8 ------------------------"
+
+const editorHelp = `# Do not modify or remove the line above.
+# Write markdown above it; everything below is ignored.
+# Save and quit to preview before posting.`
+
+// editorCommand returns the user's editor: $VISUAL, then $EDITOR, then vi.
+// It may carry arguments, e.g. "code --wait".
+func editorCommand() string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if e := strings.TrimSpace(os.Getenv(env)); e != "" {
+			return e
+		}
+	}
+	return "vi"
+}
+
+// editorFileContent lays out the file opened in the editor: the body, then
+// the scissors line and the context.
+func editorFileContent(body, context string) string {
+	var b strings.Builder
+	b.WriteString(body)
+	if !strings.HasSuffix(body, "\n") {
+		b.WriteString("\n")
+	}
+	b.WriteString("\n" + editorScissors + "\n" + editorHelp + "\n")
+	if context != "" {
+		b.WriteString("\n" + context + "\n")
+	}
+	return b.String()
+}
+
+// editorFileBody returns the text written above the scissors line.
+func editorFileBody(content string) string {
+	if i := strings.Index(content, editorScissors); i >= 0 {
+		content = content[:i]
+	}
+	return strings.TrimRight(content, " \t\n")
+}
+
+// openEditorCmd opens the body of msg in the user's editor, suspending the
+// TUI, and reports what was written. The editor runs through sh so that
+// $EDITOR may hold arguments, as git does.
+func openEditorCmd(msg views.OpenEditorMsg) tea.Cmd {
+	f, err := os.CreateTemp("", "vivecaka-*.md")
+	if err != nil {
+		return func() tea.Msg { return views.EditorDoneMsg{Err: err} }
+	}
+	path := f.Name()
+	_, err = f.WriteString(editorFileContent(msg.Body, msg.Context))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return func() tea.Msg { return views.EditorDoneMsg{Err: err} }
+	}
+
+	c := exec.Command("sh", "-c", editorCommand()+` "$1"`, "_", path) //nolint:noctx // tea.ExecProcess requires raw *exec.Cmd, no context available
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		defer func() { _ = os.Remove(path) }()
+		if err != nil {
+			return views.EditorDoneMsg{Err: err}
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return views.EditorDoneMsg{Err: err}
+		}
+		return views.EditorDoneMsg{Body: editorFileBody(string(content))}
+	})
+}
@@ -0,0 +1,30 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+	assert.Equal(t, "vi", editorCommand())
+
+	t.Setenv("EDITOR", "nano")
+	assert.Equal(t, "nano", editorCommand())
+
+	t.Setenv("VISUAL", "code --wait")
+	assert.Equal(t, "code --wait", editorCommand(), "$VISUAL wins over $EDITOR")
+}
+
+func TestEditorFileRoundTrip(t *testing.T) {
+	content := editorFileContent("# Summary\n\nLooks good.", "@alice wrote:\n> nit")
+	assert.Contains(t, content, editorScissors)
+	assert.Contains(t, content, "> nit")
+
+	// Markdown headings survive; everything from the scissors on is dropped.
+	assert.Equal(t, "# Summary\n\nLooks good.", editorFileBody(content))
+	assert.Equal(t, "", editorFileBody(editorFileContent("", "")))
+	assert.Equal(t, "no scissors", editorFileBody("no scissors\n\n"))
+}
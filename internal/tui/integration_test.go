@@ -2,7 +2,9 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os/exec"
 	"testing"
 	"time"
 
@@ -10,8 +12,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/indrasvat/vivecaka/internal/cache"
 	"github.com/indrasvat/vivecaka/internal/config"
 	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/plugin"
+	"github.com/indrasvat/vivecaka/internal/reviewprogress"
 	"github.com/indrasvat/vivecaka/internal/tui/components"
 	"github.com/indrasvat/vivecaka/internal/tui/core"
 	"github.com/indrasvat/vivecaka/internal/tui/views"
@@ -220,6 +225,30 @@ func TestIntegrationOpenDiffFromDetail(t *testing.T) {
 	assert.Contains(t, view, "auth/middleware.go", "diff should show file path")
 }
 
+func TestIntegrationDiffEditorKeepsGlobalKeys(t *testing.T) {
+	app := readyApp()
+	app.banner.Hide()
+	app.view = core.ViewPRDetail
+	app.Update(views.OpenDiffMsg{Number: 1})
+	app.Update(views.DiffLoadedMsg{Diff: &domain.Diff{Files: []domain.FileDiff{{
+		Path: "main.go",
+		Hunks: []domain.Hunk{{
+			Header: "@@ -0,0 +1,1 @@",
+			Lines:  []domain.DiffLine{{Type: domain.DiffAdd, Content: "package main", NewNum: 1}},
+		}},
+	}}}})
+	app.diffView.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	require.True(t, app.diffView.IsInputActive())
+
+	// q and Esc belong to the editor, not to quit and back.
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	assert.Nil(t, cmd)
+	app.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	assert.Equal(t, core.ViewDiff, app.view)
+	assert.False(t, app.diffView.IsInputActive())
+}
+
 func TestIntegrationReviewFlow(t *testing.T) {
 	app := readyApp()
 	app.banner.Hide()
@@ -239,6 +268,704 @@ func TestIntegrationReviewFlow(t *testing.T) {
 	assert.NotNil(t, cmd, "should return toast cmd")
 }
 
+func TestIntegrationReviewBodyFromEditor(t *testing.T) {
+	app := readyApp()
+	app.banner.Hide()
+	app.view = core.ViewPRDetail
+	app.prDetail.SetDetail(sampleDetail())
+	app.Update(views.StartReviewMsg{Number: 1})
+
+	// The editor's text reaches the open review form as a preview.
+	app.Update(views.EditorDoneMsg{Body: "Ship it."})
+	assert.Contains(t, app.View(), "Preview")
+
+	// A failed editor only reports the error.
+	_, cmd := app.Update(views.EditorDoneMsg{Err: fmt.Errorf("exit status 1")})
+	assert.NotNil(t, cmd, "should return toast cmd")
+	assert.Equal(t, core.ViewReview, app.view)
+}
+
+func TestIntegrationReviewTemplate(t *testing.T) {
+	cfg := config.Default()
+	cfg.General.RefreshInterval = 0
+	cfg.ReviewTemplates = []config.TemplateConfig{{Repos: "github.com/test", Body: "Reviewed #{{number}} by @{{author}}"}}
+	cfg.Snippets = []config.SnippetConfig{{Name: "lgtm", Body: "LGTM"}}
+	app := New(cfg, WithVersion("test-integration"), WithRepo(domain.RepoRef{Owner: "test", Name: "repo"}))
+	app.Init()
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app.banner.Hide()
+	app.view = core.ViewPRDetail
+	app.prDetail.SetDetail(sampleDetail())
+
+	app.Update(views.StartReviewMsg{Number: 1})
+	assert.Equal(t, core.ViewReview, app.view)
+	assert.Contains(t, app.reviewForm.View(), "Reviewed #1 by @alice")
+	assert.Contains(t, app.reviewForm.View(), "Ctrl+T inserts a snippet")
+}
+
+// recordingReviewer records submitted reviews.
+type recordingReviewer struct {
+	reviews []domain.Review
+}
+
+func (r *recordingReviewer) SubmitReview(_ context.Context, _ domain.RepoRef, _ int, review domain.Review) error {
+	r.reviews = append(r.reviews, review)
+	return nil
+}
+
+func (r *recordingReviewer) AddComment(context.Context, domain.RepoRef, int, domain.InlineCommentInput) error {
+	return nil
+}
+
+func (r *recordingReviewer) ResolveThread(context.Context, domain.RepoRef, string) error {
+	return nil
+}
+
+func TestIntegrationPendingCommentsSubmittedWithReview(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	repo := domain.RepoRef{Owner: "test", Name: "repo"}
+	reviewer := &recordingReviewer{}
+	cfg := config.Default()
+	cfg.General.RefreshInterval = 0
+	app := New(cfg, WithVersion("test-integration"), WithReviewer(reviewer), WithRepo(repo))
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	// Pending comments are saved with the repo state.
+	pending := []domain.InlineCommentInput{{Path: "main.go", Line: 3, Side: "RIGHT", Body: "nit"}}
+	app.Update(views.PendingCommentsChangedMsg{Number: 1, Comments: pending})
+	saved, err := cache.LoadRepoState(repo)
+	require.NoError(t, err)
+	assert.Equal(t, pending, saved.ReviewState(1).PendingComments)
+
+	app.Update(views.StartReviewMsg{Number: 1})
+	assert.Contains(t, app.View(), "1 pending comment")
+
+	// Submitting the review sends them along.
+	_, cmd := app.Update(views.SubmitReviewMsg{Number: 1, Review: domain.Review{Action: domain.ReviewActionComment}})
+	require.NotNil(t, cmd)
+	submitted, ok := cmd().(views.ReviewSubmittedMsg)
+	require.True(t, ok)
+	require.NoError(t, submitted.Err)
+	require.Len(t, reviewer.reviews, 1)
+	assert.Equal(t, pending, reviewer.reviews[0].Comments)
+
+	// Once posted, they are no longer pending.
+	app.Update(submitted)
+	assert.Empty(t, app.repoState.ReviewState(1).PendingComments)
+	saved, err = cache.LoadRepoState(repo)
+	require.NoError(t, err)
+	assert.Empty(t, saved.ReviewState(1).PendingComments)
+}
+
+// commentingReviewer is a recordingReviewer that can also manage comments.
+type commentingReviewer struct {
+	recordingReviewer
+	calls []string
+}
+
+func (r *commentingReviewer) AddPRComment(_ context.Context, _ domain.RepoRef, number int, body string) error {
+	r.calls = append(r.calls, fmt.Sprintf("post #%d %s", number, body))
+	return nil
+}
+
+func (r *commentingReviewer) EditComment(_ context.Context, _ domain.RepoRef, ref domain.CommentRef, body string) error {
+	r.calls = append(r.calls, fmt.Sprintf("edit %s %s", ref.NodeID, body))
+	return nil
+}
+
+func (r *commentingReviewer) DeleteComment(_ context.Context, _ domain.RepoRef, ref domain.CommentRef) error {
+	r.calls = append(r.calls, "delete "+ref.NodeID)
+	return nil
+}
+
+func (r *commentingReviewer) UnresolveThread(_ context.Context, _ domain.RepoRef, threadID string) error {
+	r.calls = append(r.calls, "unresolve "+threadID)
+	return nil
+}
+
+func (r *commentingReviewer) AddReaction(_ context.Context, _ domain.RepoRef, ref domain.CommentRef, content domain.ReactionContent) error {
+	r.calls = append(r.calls, fmt.Sprintf("react %s %s", ref.NodeID, content))
+	return nil
+}
+
+func (r *commentingReviewer) RemoveReaction(_ context.Context, _ domain.RepoRef, ref domain.CommentRef, content domain.ReactionContent) error {
+	r.calls = append(r.calls, fmt.Sprintf("unreact %s %s", ref.NodeID, content))
+	return nil
+}
+
+func TestIntegrationCommentActions(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	repo := domain.RepoRef{Owner: "test", Name: "repo"}
+	reviewer := &commentingReviewer{}
+	cfg := config.Default()
+	cfg.General.RefreshInterval = 0
+	app := New(cfg, WithVersion("test-integration"), WithReviewer(reviewer), WithRepo(repo))
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app.banner.Hide()
+	require.NotNil(t, app.manageComments)
+
+	// While composing, global keys such as q type into the composer.
+	app.view = core.ViewPRDetail
+	app.prDetail.SetDetail(&domain.PRDetail{PR: domain.PR{Number: 7}})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	assert.Nil(t, cmd, "q must not quit while composing")
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	require.NotNil(t, cmd)
+	post, ok := cmd().(views.PostCommentMsg)
+	require.True(t, ok)
+
+	_, cmd = app.Update(post)
+	require.NotNil(t, cmd)
+	done, ok := cmd().(commentActionDoneMsg)
+	require.True(t, ok)
+	require.NoError(t, done.Err)
+	assert.Equal(t, "Comment posted", done.Done)
+
+	_, cmd = app.Update(views.ToggleReactionMsg{
+		Number: 7, Kind: domain.DiscussionComment, Content: domain.ReactionEyes,
+		Comment: domain.Comment{NodeID: "IC_1"},
+	})
+	require.NotNil(t, cmd)
+	cmd()
+
+	// Deleting asks first, then runs in the confirm dialog.
+	del := views.DeleteCommentMsg{Number: 7, Ref: domain.CommentRef{NodeID: "IC_1", Kind: domain.DiscussionComment}, Author: "me"}
+	app.Update(del)
+	assert.Equal(t, core.ViewConfirm, app.view)
+	assert.Contains(t, app.View(), "Delete this comment by @me?")
+	_, cmd = app.Update(views.ConfirmResultMsg{Confirmed: true, Action: del})
+	require.NotNil(t, cmd)
+	for _, c := range cmd().(tea.BatchMsg) {
+		if done, ok := c().(commentActionDoneMsg); ok {
+			app.Update(done)
+		}
+	}
+	assert.Equal(t, core.ViewConfirm, app.view)
+	assert.Contains(t, app.View(), "Comment deleted")
+
+	assert.Equal(t, []string{"post #7 q", "react IC_1 EYES", "delete IC_1"}, reviewer.calls)
+}
+
+// mergingWriter records merges and reports a fixed merge status.
+type mergingWriter struct {
+	status *domain.MergeStatus
+	merged []domain.MergeOpts
+}
+
+func (w *mergingWriter) Checkout(context.Context, domain.RepoRef, int) (string, error) {
+	return "", nil
+}
+
+func (w *mergingWriter) Merge(_ context.Context, _ domain.RepoRef, _ int, opts domain.MergeOpts) error {
+	w.merged = append(w.merged, opts)
+	return nil
+}
+
+func (w *mergingWriter) GetMergeStatus(context.Context, domain.RepoRef, int) (*domain.MergeStatus, error) {
+	return w.status, nil
+}
+
+func TestIntegrationMergeDialog(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	writer := &mergingWriter{status: &domain.MergeStatus{
+		State:     domain.PRStateOpen,
+		Mergeable: domain.MergeableClean,
+		Methods:   []string{domain.MergeMethodSquash},
+	}}
+	cfg := config.Default()
+	cfg.General.RefreshInterval = 0
+	app := New(cfg, WithVersion("test-integration"), WithWriter(writer), WithRepo(domain.RepoRef{Owner: "test", Name: "repo"}))
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app.banner.Hide()
+	app.view = core.ViewPRList
+	app.Update(views.PRsLoadedMsg{PRs: samplePRs()})
+
+	// M on the list opens the dialog for the selected PR.
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'M'}})
+	require.NotNil(t, cmd)
+	msg, ok := cmd().(views.MergePRMsg)
+	require.True(t, ok)
+	assert.Equal(t, 1, msg.PR.Number)
+
+	_, cmd = app.Update(msg)
+	assert.Equal(t, core.ViewMerge, app.view)
+	assert.Contains(t, app.View(), "Merge PR #1")
+	for _, c := range cmd().(tea.BatchMsg) {
+		if loaded, ok := c().(views.MergeStatusLoadedMsg); ok {
+			app.Update(loaded)
+		}
+	}
+	assert.Contains(t, app.View(), "Squash and merge")
+
+	_, cmd = app.Update(views.SubmitMergeMsg{Number: 1, Opts: domain.MergeOpts{Method: domain.MergeMethodSquash, DeleteBranch: true}})
+	require.NotNil(t, cmd)
+	for _, c := range cmd().(tea.BatchMsg) {
+		if done, ok := c().(views.MergeDoneMsg); ok {
+			app.Update(done)
+		}
+	}
+	assert.Equal(t, []domain.MergeOpts{{Method: domain.MergeMethodSquash, DeleteBranch: true}}, writer.merged)
+	assert.Equal(t, core.ViewPRList, app.view, "a successful merge closes the dialog")
+}
+
+func TestIntegrationMergeUnsupported(t *testing.T) {
+	app := readyApp()
+	app.banner.Hide()
+	app.view = core.ViewPRList
+	app.Update(views.MergePRMsg{PR: domain.PR{Number: 1}})
+	assert.Equal(t, core.ViewPRList, app.view, "no writer, no dialog")
+}
+
+// editingWriter is a mergingWriter that can also edit PR metadata.
+type editingWriter struct {
+	mergingWriter
+	edits []domain.MetadataEdit
+	fail  error
+}
+
+func (w *editingWriter) GetMetadataOptions(context.Context, domain.RepoRef) (*domain.MetadataOptions, error) {
+	return &domain.MetadataOptions{Labels: []domain.Label{{Name: "enhancement"}, {Name: "security"}}}, nil
+}
+
+func (w *editingWriter) EditMetadata(_ context.Context, _ domain.RepoRef, _ int, edit domain.MetadataEdit) error {
+	w.edits = append(w.edits, edit)
+	return w.fail
+}
+
+func TestIntegrationEditLabels(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	writer := &editingWriter{}
+	cfg := config.Default()
+	cfg.General.RefreshInterval = 0
+	app := New(cfg, WithVersion("test-integration"), WithWriter(writer), WithRepo(domain.RepoRef{Owner: "test", Name: "repo"}))
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app.banner.Hide()
+	app.view = core.ViewPRList
+	app.Update(views.PRsLoadedMsg{PRs: samplePRs()})
+	app.Update(views.OpenPRMsg{Number: 1})
+	app.Update(views.PRDetailLoadedMsg{Detail: sampleDetail()})
+
+	// L opens the label picker once the repo's labels load.
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'L'}})
+	require.NotNil(t, cmd)
+	_, cmd = app.Update(cmd())
+	assert.Equal(t, core.ViewMetadata, app.view)
+	for _, c := range cmd().(tea.BatchMsg) {
+		if loaded, ok := c().(metadataOptionsLoadedMsg); ok {
+			app.Update(loaded)
+		}
+	}
+	assert.Contains(t, app.View(), "Labels for PR #1")
+
+	// Add security: shown at once, then sent.
+	app.Update(tea.KeyMsg{Type: tea.KeyDown})
+	app.Update(tea.KeyMsg{Type: tea.KeySpace})
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	_, cmd = app.Update(cmd())
+	assert.Equal(t, core.ViewPRDetail, app.view)
+	assert.Equal(t, []string{"enhancement", "security"}, app.prDetail.GetDetail().Labels)
+	pr, _ := app.prList.PR(1)
+	assert.Equal(t, []string{"enhancement", "security"}, pr.Labels)
+	app.Update(cmd())
+	assert.Equal(t, []domain.MetadataEdit{{AddLabels: []string{"security"}}}, writer.edits)
+
+	// A rejected edit is rolled back; the options are cached.
+	writer.fail = errors.New("forbidden")
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'L'}})
+	_, cmd = app.Update(cmd())
+	assert.Contains(t, app.View(), "Labels for PR #1", "cached options need no fetch")
+	app.Update(tea.KeyMsg{Type: tea.KeySpace})
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	_, cmd = app.Update(cmd())
+	pr, _ = app.prList.PR(1)
+	assert.Equal(t, []string{"security"}, pr.Labels)
+	app.Update(cmd())
+	pr, _ = app.prList.PR(1)
+	assert.Equal(t, []string{"enhancement", "security"}, pr.Labels)
+}
+
+func TestIntegrationEditMetadataUnsupported(t *testing.T) {
+	app := New(config.Default(), WithWriter(&mergingWriter{}))
+	assert.Nil(t, app.editMetadata)
+}
+
+func TestIntegrationEditMetadataRoutedToBackendWithout(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	router := plugin.NewRouter([]plugin.Backend{
+		{Name: "gh", Writer: &editingWriter{}},
+		{Name: "lab", Writer: &mergingWriter{}},
+	}, []plugin.Route{{Pattern: "gitlab.example.com", Backend: "lab"}})
+	cfg := config.Default()
+	cfg.General.RefreshInterval = 0
+	app := New(cfg, WithVersion("test-integration"), WithWriter(router),
+		WithRepo(domain.RepoRef{Host: "gitlab.example.com", Owner: "test", Name: "repo"}))
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app.banner.Hide()
+	require.NotNil(t, app.editMetadata, "another backend can edit metadata")
+	app.view = core.ViewPRList
+	app.Update(views.OpenPRMsg{Number: 1})
+	app.Update(views.PRDetailLoadedMsg{Detail: sampleDetail()})
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'L'}})
+	require.NotNil(t, cmd)
+	_, cmd = app.Update(cmd())
+	assert.Equal(t, core.ViewMetadata, app.view)
+	runBatch[metadataOptionsLoadedMsg](app, cmd)
+	assert.Equal(t, core.ViewPRDetail, app.view, "the picker closes")
+	assert.Contains(t, app.toasts.View(), "Editing labels, assignees and reviewers is not supported by this backend")
+
+	_, cmd = app.Update(views.ApplyMetadataMsg{Number: 1, Kind: views.MetadataLabels, Edit: domain.MetadataEdit{AddLabels: []string{"bug"}}})
+	runBatch[metadataEditedMsg](app, cmd)
+	assert.Contains(t, app.toasts.View(), "not supported by this backend")
+	assert.NotContains(t, app.toasts.View(), "unsupported operation")
+}
+
+// lifecycleWriter is a mergingWriter that can also change PR state.
+type lifecycleWriter struct {
+	mergingWriter
+	calls []string
+}
+
+func (w *lifecycleWriter) ClosePR(_ context.Context, _ domain.RepoRef, number int, comment string) error {
+	w.calls = append(w.calls, fmt.Sprintf("close #%d %s", number, comment))
+	return nil
+}
+
+func (w *lifecycleWriter) ReopenPR(_ context.Context, _ domain.RepoRef, number int, comment string) error {
+	w.calls = append(w.calls, fmt.Sprintf("reopen #%d %s", number, comment))
+	return nil
+}
+
+func (w *lifecycleWriter) SetDraft(_ context.Context, _ domain.RepoRef, number int, draft bool) error {
+	w.calls = append(w.calls, fmt.Sprintf("draft #%d %v", number, draft))
+	return nil
+}
+
+func (w *lifecycleWriter) UpdateBranch(_ context.Context, _ domain.RepoRef, number int, method string) error {
+	w.calls = append(w.calls, fmt.Sprintf("update #%d %s", number, method))
+	return nil
+}
+
+// runBatch runs cmd and feeds app the messages of type T it produces.
+func runBatch[T tea.Msg](app *App, cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	msgs := []tea.Msg{cmd()}
+	if batch, ok := msgs[0].(tea.BatchMsg); ok {
+		msgs = msgs[:0]
+		for _, c := range batch {
+			if c != nil {
+				msgs = append(msgs, c())
+			}
+		}
+	}
+	for _, msg := range msgs {
+		if m, ok := msg.(T); ok {
+			app.Update(m)
+		}
+	}
+}
+
+func TestIntegrationPRLifecycle(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	writer := &lifecycleWriter{mergingWriter: mergingWriter{status: &domain.MergeStatus{
+		State:     domain.PRStateOpen,
+		Mergeable: domain.MergeableClean,
+		Behind:    true,
+	}}}
+	cfg := config.Default()
+	cfg.General.RefreshInterval = 0
+	app := New(cfg, WithVersion("test-integration"), WithWriter(writer), WithRepo(domain.RepoRef{Owner: "test", Name: "repo"}))
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app.banner.Hide()
+	app.view = core.ViewPRList
+	app.Update(views.PRsLoadedMsg{PRs: samplePRs()})
+	app.Update(views.OpenPRMsg{Number: 1})
+	app.Update(views.PRDetailLoadedMsg{Detail: sampleDetail()})
+
+	// C asks to close, with a comment.
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'C'}})
+	require.NotNil(t, cmd)
+	app.Update(cmd())
+	assert.Equal(t, core.ViewConfirm, app.view)
+	assert.Contains(t, app.View(), "Close PR #1")
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("dup of #2")})
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	_, cmd = app.Update(cmd())
+	runBatch[prStateChangedMsg](app, cmd)
+	assert.Contains(t, app.View(), "Closed PR #1")
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app.Update(cmd())
+	assert.Equal(t, core.ViewPRDetail, app.view)
+
+	// U checks the branch, then r rebases it.
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'U'}})
+	_, cmd = app.Update(cmd())
+	runBatch[updateBranchStatusMsg](app, cmd)
+	assert.Contains(t, app.View(), "is behind main")
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	_, cmd = app.Update(cmd())
+	runBatch[prStateChangedMsg](app, cmd)
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app.Update(cmd())
+
+	// W on a merged PR only explains why not.
+	app.Update(views.ToggleDraftMsg{PR: domain.PR{Number: 1, State: domain.PRStateMerged}})
+	assert.Equal(t, core.ViewPRDetail, app.view)
+
+	assert.Equal(t, []string{"close #1 dup of #2", "update #1 rebase"}, writer.calls)
+}
+
+func TestIntegrationPRLifecycleUnsupported(t *testing.T) {
+	app := New(config.Default(), WithWriter(&mergingWriter{}), WithRepo(domain.RepoRef{Owner: "test", Name: "repo"}))
+	assert.Nil(t, app.prLifecycle)
+	app.view = core.ViewPRDetail
+	app.Update(views.ClosePRMsg{PR: domain.PR{Number: 1, State: domain.PRStateOpen}})
+	assert.Equal(t, core.ViewPRDetail, app.view, "nothing to confirm without lifecycle support")
+}
+
+// creatingWriter is a mergingWriter that can also create PRs.
+type creatingWriter struct {
+	mergingWriter
+	comparison *domain.BranchComparison
+	calls      []string
+	created    []domain.NewPR
+}
+
+func (w *creatingWriter) ListBranches(context.Context, domain.RepoRef) (*domain.RepoBranches, error) {
+	return &domain.RepoBranches{Default: "main", Names: []string{"main", "feat/oauth"}}, nil
+}
+
+func (w *creatingWriter) CompareBranch(_ context.Context, _ string, base, head string) (*domain.BranchComparison, error) {
+	w.calls = append(w.calls, fmt.Sprintf("compare %s...%s", base, head))
+	return w.comparison, nil
+}
+
+func (w *creatingWriter) PushBranch(_ context.Context, _ string, branch string) error {
+	w.calls = append(w.calls, "push "+branch)
+	return nil
+}
+
+func (w *creatingWriter) CreatePR(_ context.Context, _ domain.RepoRef, pr domain.NewPR) (int, error) {
+	w.created = append(w.created, pr)
+	return 9, nil
+}
+
+// gitClone creates a git repo with branch checked out.
+func gitClone(t *testing.T, branch string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+		{"checkout", "-q", "-b", branch},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	return dir
+}
+
+func TestIntegrationCreatePR(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	repo := domain.RepoRef{Owner: "test", Name: "repo"}
+	writer := &creatingWriter{comparison: &domain.BranchComparison{
+		Base:    "main",
+		Head:    "feat/oauth",
+		Commits: []domain.Commit{{SHA: "abc1234def", Title: "Add OAuth login", Body: "Closes #3"}},
+		Diff:    domain.Diff{Files: []domain.FileDiff{{Path: "auth.go"}}},
+	}}
+	cfg := config.Default()
+	cfg.General.RefreshInterval = 0
+	app := New(cfg, WithVersion("test-integration"), WithWriter(writer), WithRepo(repo))
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app.banner.Hide()
+	app.cwdRepo, app.cwdPath = repo, gitClone(t, "feat/oauth")
+	app.view = core.ViewPRList
+	app.Update(views.PRsLoadedMsg{PRs: samplePRs()})
+
+	// N finds the branch and offers the bases.
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'N'}})
+	require.NotNil(t, cmd)
+	_, cmd = app.Update(cmd())
+	assert.Equal(t, core.ViewCreatePR, app.view)
+	runBatch[views.NewPRBasesLoadedMsg](app, cmd)
+	assert.Contains(t, app.View(), "main (default)")
+
+	// Choosing main compares the branch and prefills the form.
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	_, cmd = app.Update(cmd()) // next field
+	_, cmd = app.Update(cmd()) // next group, completing the form
+	for _, c := range cmd().(tea.BatchMsg) {
+		if compare, ok := c().(views.CompareBranchMsg); ok {
+			_, cmd = app.Update(compare)
+		}
+	}
+	app.Update(cmd())
+	assert.Contains(t, app.View(), "Add OAuth login")
+
+	// Esc leaves the form for the summary; Enter pushes and creates.
+	app.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	assert.Contains(t, app.View(), "will be pushed")
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	_, cmd = app.Update(cmd())
+	runBatch[views.PRCreatedMsg](app, cmd)
+
+	assert.Equal(t, core.ViewPRDetail, app.view, "lands in the new PR")
+	assert.Equal(t, 9, app.prDetail.GetPRNumber())
+	assert.Equal(t, []string{"compare main...feat/oauth", "push feat/oauth"}, writer.calls)
+	require.Len(t, writer.created, 1)
+	assert.Equal(t, domain.NewPR{Base: "main", Head: "feat/oauth", Title: "Add OAuth login", Body: "Closes #3"}, writer.created[0])
+}
+
+func TestIntegrationCreatePRUnsupported(t *testing.T) {
+	app := New(config.Default(), WithWriter(&mergingWriter{}), WithRepo(domain.RepoRef{Owner: "test", Name: "repo"}))
+	assert.Nil(t, app.createPR)
+	app.view = core.ViewPRList
+	app.Update(views.NewPRMsg{})
+	assert.Equal(t, core.ViewPRList, app.view)
+}
+
+// commitReader is a PR reader that lists commits and diffs ranges of them.
+type commitReader struct {
+	domain.PRReader
+	compared [2]string
+	diff     *domain.Diff // returned by CompareCommits, if set
+}
+
+func (r *commitReader) GetCommits(context.Context, domain.RepoRef, int) ([]domain.Commit, error) {
+	return []domain.Commit{
+		{SHA: "1111111a", Parent: "0000000", Title: "Add middleware", Author: "alice"},
+		{SHA: "2222222b", Parent: "1111111a", Title: "Wire middleware", Author: "alice"},
+	}, nil
+}
+
+func (r *commitReader) CompareCommits(_ context.Context, _ domain.RepoRef, base, head string) (*domain.Diff, error) {
+	r.compared = [2]string{base, head}
+	if r.diff != nil {
+		return r.diff, nil
+	}
+	return &domain.Diff{Files: []domain.FileDiff{{Path: "cmd/server/main.go"}}}, nil
+}
+
+func TestIntegrationCommitDiff(t *testing.T) {
+	reader := &commitReader{}
+	cfg := config.Default()
+	cfg.General.RefreshInterval = 0
+	app := New(cfg, WithVersion("test-integration"), WithReader(reader), WithRepo(domain.RepoRef{Owner: "test", Name: "repo"}))
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app.banner.Hide()
+	app.view = core.ViewPRDetail
+	app.prDetail.SetDetail(sampleDetail())
+
+	// 5 shows the Commits tab, which loads the commits.
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'5'}})
+	var load tea.Msg
+	for _, c := range cmd().(tea.BatchMsg) {
+		if msg, ok := c().(views.LoadCommitsMsg); ok {
+			load = msg
+		}
+	}
+	require.NotNil(t, load)
+	_, cmd = app.Update(load)
+	app.Update(cmd())
+	assert.Contains(t, app.View(), "Wire middleware")
+
+	// Enter on the second commit opens its diff, read-only.
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	_, cmd = app.Update(cmd())
+	runBatch[views.ScopedDiffLoadedMsg](app, cmd)
+	assert.Equal(t, core.ViewDiff, app.view)
+	assert.Equal(t, [2]string{"1111111a", "2222222b"}, reader.compared)
+	view := app.View()
+	assert.Contains(t, view, "commit 2222222 · Wire middleware")
+	assert.Contains(t, view, "cmd/server/main.go")
+
+	// The PR diff arriving late does not replace the commit diff.
+	app.currentReviewPR = 1
+	app.Update(views.DiffLoadedMsg{Number: 1, Diff: &domain.Diff{Files: []domain.FileDiff{{Path: "auth/middleware.go"}}}})
+	assert.Equal(t, "cmd/server/main.go", app.diffView.CurrentFilePath())
+
+	// Back in the detail view, d opens the whole PR diff for review again.
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	app.Update(views.OpenDiffMsg{Number: 1})
+	assert.Empty(t, app.diffScope)
+	assert.Equal(t, "auth/middleware.go", app.diffView.CurrentFilePath())
+}
+
+func TestIntegrationInterdiff(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	hunk := func(line string) []domain.Hunk {
+		return []domain.Hunk{{Header: "@@ -1,1 +1,2 @@", Lines: []domain.DiffLine{
+			{Type: domain.DiffContext, Content: "package auth", OldNum: 1, NewNum: 1},
+			{Type: domain.DiffAdd, Content: line, NewNum: 2},
+		}}}
+	}
+	reader := &commitReader{}
+	reader.diff = &domain.Diff{Files: []domain.FileDiff{{Path: "auth/middleware.go", Hunks: hunk("func Auth() {}")}}}
+	cfg := config.Default()
+	cfg.General.RefreshInterval = 0
+	app := New(cfg, WithVersion("test-integration"), WithReader(reader), WithRepo(domain.RepoRef{Owner: "test", Name: "repo"}))
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app.banner.Hide()
+	app.view = core.ViewPRDetail
+	detail := sampleDetail()
+	detail.Branch.HeadSHA = "2222222b"
+	app.prDetail.SetDetail(detail)
+	app.currentReviewPR = 1
+	app.currentReviewDiff = &domain.Diff{Files: []domain.FileDiff{{Path: "auth/middleware.go", Hunks: hunk("func Auth(next http.Handler) {}")}}}
+	app.currentReviewContext = &reviewprogress.Context{Scope: reviewprogress.ScopeSinceReview, HeadSHA: "2222222b", LastReviewHeadSHA: "1111111a"}
+	app.Update(views.OpenDiffMsg{Number: 1})
+
+	// I compares the patch at the reviewed head with the current one.
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'I'}})
+	_, cmd = app.Update(cmd())
+	runBatch[views.ScopedDiffLoadedMsg](app, cmd)
+	assert.Equal(t, [2]string{"main", "1111111a"}, reader.compared)
+	view := app.View()
+	assert.Contains(t, view, "interdiff since your last review · 1111111 → 2222222")
+	assert.Contains(t, view, "I full diff")
+	assert.Equal(t, "auth/middleware.go", app.diffView.CurrentFilePath())
+
+	// I again returns to the full diff.
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'I'}})
+	app.Update(cmd())
+	assert.False(t, app.interdiff)
+	assert.Empty(t, app.diffScope)
+	assert.NotContains(t, app.View(), "interdiff since")
+
+	// Nothing to compare at the reviewed head.
+	app.currentReviewContext.LastReviewHeadSHA = "2222222b"
+	_, cmd = app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'I'}})
+	app.Update(cmd())
+	assert.False(t, app.interdiff)
+	assert.Contains(t, app.toasts.View(), "No pushes since your last review")
+}
+
+func TestIntegrationCommitsUnsupported(t *testing.T) {
+	app := readyApp()
+	assert.Nil(t, app.prCommits)
+	app.view = core.ViewPRDetail
+	app.prDetail.SetDetail(sampleDetail())
+	_, cmd := app.Update(views.LoadCommitsMsg{Number: 1})
+	assert.ErrorIs(t, cmd().(views.CommitsLoadedMsg).Err, errors.ErrUnsupported)
+}
+
+func TestIntegrationCommentActionsUnsupported(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	app := New(config.Default(), WithReviewer(&recordingReviewer{}), WithRepo(domain.RepoRef{Owner: "test", Name: "repo"}))
+	assert.Nil(t, app.manageComments)
+
+	app.Update(views.DeleteCommentMsg{Number: 7, Ref: domain.CommentRef{NodeID: "IC_1"}})
+	assert.NotEqual(t, core.ViewConfirm, app.view, "nothing to confirm without a comment manager")
+}
+
 func TestIntegrationReviewError(t *testing.T) {
 	app := readyApp()
 	app.banner.Hide()
@@ -678,7 +1405,7 @@ func (m *mockRepoManager) CloneRepo(_ context.Context, _ domain.RepoRef, _ strin
 	return m.cloneErr
 }
 
-func (m *mockRepoManager) CreateWorktree(_ context.Context, _ string, _ int, _, _ string) error {
+func (m *mockRepoManager) CreateWorktree(_ context.Context, _ domain.RepoRef, _ string, _ int, _, _ string) error {
 	return m.worktreeErr
 }
 
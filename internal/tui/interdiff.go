@@ -0,0 +1,45 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/tui/views"
+)
+
+// handleToggleInterdiff switches the diff view between the PR diff and the
+// interdiff since the baseline of the active review scope: how the PR's
+// patch changed since the head last visited or reviewed, however many
+// force-pushes ago.
+func (a *App) handleToggleInterdiff(msg views.ToggleInterdiffMsg) tea.Cmd {
+	if a.interdiff {
+		_, cmd := a.handleOpenDiff(views.OpenDiffMsg{Number: msg.Number})
+		return cmd
+	}
+	if a.getInterdiff == nil || a.repo.Owner == "" {
+		return a.unsupported("Showing interdiffs")
+	}
+	detail := a.prDetail.GetDetail()
+	if detail == nil || detail.Number != msg.Number || a.currentReviewPR != msg.Number {
+		return nil
+	}
+	since, baseline := a.currentReviewContext.Baseline()
+	switch baseline {
+	case "":
+		return a.toasts.Add("No earlier visit or review of this PR to compare with", domain.ToastInfo, 3*time.Second)
+	case detail.Branch.HeadSHA:
+		return a.toasts.Add(fmt.Sprintf("No pushes since your last %s", since), domain.ToastInfo, 3*time.Second)
+	}
+
+	scope := fmt.Sprintf("interdiff since your last %s · %.7s → %.7s", since, baseline, detail.Branch.HeadSHA)
+	uc, repo, current := a.getInterdiff, a.repo, a.currentReviewDiff
+	cmd := a.openScopedDiff(msg.Number, scope, func() (*domain.Diff, error) {
+		return uc.Execute(context.Background(), repo, detail, baseline, current)
+	})
+	a.interdiff = true
+	return cmd
+}
@@ -0,0 +1,229 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/tui/core"
+	"github.com/indrasvat/vivecaka/internal/tui/views"
+)
+
+// The confirm dialog actions of the PR lifecycle keys; handleConfirmResult
+// runs them through runLifecycleAction.
+type (
+	closePRAction struct {
+		number int
+		reopen bool
+	}
+	setDraftAction struct {
+		number int
+		draft  bool
+	}
+	updateBranchAction struct {
+		number int
+		method string
+	}
+)
+
+// updateBranchStatusMsg carries the merge status checked before offering
+// to update a PR's branch.
+type updateBranchStatusMsg struct {
+	PR     domain.PR
+	Status *domain.MergeStatus
+	Err    error
+}
+
+// prStateChangedMsg is sent when a lifecycle action completes. Done is the
+// success title and Failed the error title.
+type prStateChangedMsg struct {
+	Number int
+	Done   string
+	Failed string
+	Err    error
+}
+
+// lifecycleUnavailable reports, with a toast, when the writer cannot
+// change PR state, or when pr is in a state the action does not apply to.
+func (a *App) lifecycleUnavailable(pr domain.PR, needOpen bool) (tea.Cmd, bool) {
+	switch {
+	case a.prLifecycle == nil || a.repo.Owner == "":
+		return a.unsupported("Changing PR state"), true
+	case pr.State == domain.PRStateMerged:
+		return a.toasts.Add(fmt.Sprintf("PR #%d is already merged", pr.Number), domain.ToastInfo, 3*time.Second), true
+	case needOpen && pr.State != domain.PRStateOpen:
+		return a.toasts.Add(fmt.Sprintf("PR #%d is closed; reopen it first", pr.Number), domain.ToastInfo, 3*time.Second), true
+	}
+	return nil, false
+}
+
+// handleClosePR asks to close an open PR or reopen a closed one, with an
+// optional comment.
+func (a *App) handleClosePR(msg views.ClosePRMsg) tea.Cmd {
+	pr := msg.PR
+	if cmd, unavailable := a.lifecycleUnavailable(pr, false); unavailable {
+		return cmd
+	}
+	a.prevView = a.view
+	a.view = core.ViewConfirm
+	if pr.State == domain.PRStateClosed {
+		return a.confirmDialog.ShowInput("Reopen PR",
+			fmt.Sprintf("Reopen PR #%d %q?", pr.Number, pr.Title),
+			"Comment (optional)", closePRAction{number: pr.Number, reopen: true})
+	}
+	return a.confirmDialog.ShowInput("Close PR",
+		fmt.Sprintf("Close PR #%d %q without merging?", pr.Number, pr.Title),
+		"Comment (optional)", closePRAction{number: pr.Number})
+}
+
+// handleToggleDraft asks to convert an open PR to a draft, or to mark a
+// draft ready for review.
+func (a *App) handleToggleDraft(msg views.ToggleDraftMsg) tea.Cmd {
+	pr := msg.PR
+	if cmd, unavailable := a.lifecycleUnavailable(pr, true); unavailable {
+		return cmd
+	}
+	a.prevView = a.view
+	a.view = core.ViewConfirm
+	if pr.Draft {
+		a.confirmDialog.Show("Ready for Review",
+			fmt.Sprintf("Mark PR #%d ready for review? Requested reviewers will be notified.", pr.Number),
+			setDraftAction{number: pr.Number})
+		return nil
+	}
+	a.confirmDialog.Show("Convert to Draft",
+		fmt.Sprintf("Convert PR #%d to a draft? It cannot be merged until it is marked ready again.", pr.Number),
+		setDraftAction{number: pr.Number, draft: true})
+	return nil
+}
+
+// handleUpdateBranch checks whether an open PR is behind its base before
+// asking how to update it.
+func (a *App) handleUpdateBranch(msg views.UpdateBranchMsg) tea.Cmd {
+	pr := msg.PR
+	if cmd, unavailable := a.lifecycleUnavailable(pr, true); unavailable {
+		return cmd
+	}
+	a.prevView = a.view
+	a.view = core.ViewConfirm
+	spinnerCmd := a.confirmDialog.ShowLoading("Update Branch",
+		fmt.Sprintf("Comparing %s with %s...", pr.Branch.Head, pr.Branch.Base))
+	uc, repo := a.mergePR, a.repo
+	return tea.Batch(spinnerCmd, func() tea.Msg {
+		status, err := uc.Status(context.Background(), repo, pr)
+		return updateBranchStatusMsg{PR: pr, Status: status, Err: err}
+	})
+}
+
+// handleUpdateBranchStatus offers to merge the base into the head, or
+// rebase the head onto it. A branch with conflicts cannot be updated on
+// the host, so that ends here.
+func (a *App) handleUpdateBranchStatus(msg updateBranchStatusMsg) {
+	if a.view != core.ViewConfirm {
+		return
+	}
+	pr := msg.PR
+	if msg.Err != nil {
+		a.confirmDialog.ShowResult("Update Branch Failed", errorText(msg.Err), false)
+		return
+	}
+	head, base := pr.Branch.Head, pr.Branch.Base
+	if msg.Status.Mergeable == domain.MergeableConflicting {
+		a.confirmDialog.ShowResult("Cannot Update Branch",
+			fmt.Sprintf("%s conflicts with %s. Resolve the conflicts locally and push.", head, base), false)
+		return
+	}
+	prompt := fmt.Sprintf("%s is behind %s. Merge %s into it, or rebase it onto %s?", head, base, base, base)
+	if !msg.Status.Behind {
+		prompt = fmt.Sprintf("%s is not reported as behind %s. Update it anyway?", head, base)
+	}
+	a.confirmDialog.Show("Update Branch", prompt, updateBranchAction{number: pr.Number, method: domain.MergeMethodMerge})
+	a.confirmDialog.SetConfirmLabel("Merge")
+	a.confirmDialog.SetAlternative('r', "Rebase", updateBranchAction{number: pr.Number, method: domain.MergeMethodRebase})
+}
+
+// runLifecycleAction runs a confirmed lifecycle action with the dialog's
+// loading spinner. ok is false for other confirm actions.
+func (a *App) runLifecycleAction(msg views.ConfirmResultMsg) (cmd tea.Cmd, ok bool) {
+	if a.prLifecycle == nil {
+		return nil, false
+	}
+	uc, repo := a.prLifecycle, a.repo
+	var (
+		number         int
+		title, loading string
+		done, failed   string
+		action         func(context.Context) error
+	)
+	switch act := msg.Action.(type) {
+	case closePRAction:
+		number = act.number
+		comment := msg.Input
+		if act.reopen {
+			title, loading, done, failed = "Reopen PR", "Reopening", "Reopened", "Reopen Failed"
+			action = func(ctx context.Context) error { return uc.Reopen(ctx, repo, act.number, comment) }
+		} else {
+			title, loading, done, failed = "Close PR", "Closing", "Closed", "Close Failed"
+			action = func(ctx context.Context) error { return uc.Close(ctx, repo, act.number, comment) }
+		}
+		loading = fmt.Sprintf("%s PR #%d...", loading, number)
+		done = fmt.Sprintf("%s PR #%d", done, number)
+	case setDraftAction:
+		number = act.number
+		if act.draft {
+			title, failed = "Convert to Draft", "Convert Failed"
+			loading = fmt.Sprintf("Converting PR #%d to a draft...", number)
+			done = fmt.Sprintf("PR #%d is now a draft", number)
+		} else {
+			title, failed = "Ready for Review", "Ready for Review Failed"
+			loading = fmt.Sprintf("Marking PR #%d ready for review...", number)
+			done = fmt.Sprintf("PR #%d is ready for review", number)
+		}
+		action = func(ctx context.Context) error { return uc.SetDraft(ctx, repo, act.number, act.draft) }
+	case updateBranchAction:
+		number = act.number
+		title, failed = "Update Branch", "Update Branch Failed"
+		loading = fmt.Sprintf("Merging the base branch into PR #%d...", number)
+		if act.method == domain.MergeMethodRebase {
+			loading = fmt.Sprintf("Rebasing PR #%d onto the base branch...", number)
+		}
+		done = fmt.Sprintf("Updated the branch of PR #%d", number)
+		action = func(ctx context.Context) error { return uc.UpdateBranch(ctx, repo, act.number, act.method) }
+	default:
+		return nil, false
+	}
+
+	spinnerCmd := a.confirmDialog.ShowLoading(title, loading)
+	return tea.Batch(spinnerCmd, func() tea.Msg {
+		err := action(context.Background())
+		return prStateChangedMsg{Number: number, Done: done, Failed: failed, Err: err}
+	}), true
+}
+
+// handlePRStateChanged reports a lifecycle action, in the confirm dialog
+// if it is still open, and reloads the PR list and the open detail to show
+// the new state.
+func (a *App) handlePRStateChanged(msg prStateChangedMsg) tea.Cmd {
+	var cmds []tea.Cmd
+	switch {
+	case a.view == core.ViewConfirm && msg.Err != nil:
+		a.confirmDialog.ShowResult(msg.Failed, errorText(msg.Err), false)
+		return nil
+	case a.view == core.ViewConfirm:
+		a.confirmDialog.ShowResult(msg.Done, fmt.Sprintf("PR #%d will refresh.", msg.Number), true)
+	case msg.Err != nil:
+		return a.errorToast(msg.Failed, msg.Err)
+	default:
+		cmds = append(cmds, a.toasts.Add(msg.Done, domain.ToastSuccess, 3*time.Second))
+	}
+	if a.listPRs != nil {
+		cmds = append(cmds, loadPRsCmd(a.listPRs, a.hooks, a.repo, a.filterOpts))
+	}
+	if a.getPRDetail != nil && a.prDetail.GetPRNumber() == msg.Number {
+		cmds = append(cmds, loadPRDetailCmd(a.getPRDetail, a.repo, msg.Number))
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,114 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/tui/core"
+	"github.com/indrasvat/vivecaka/internal/tui/views"
+	"github.com/indrasvat/vivecaka/internal/usecase"
+)
+
+// metadataOptionsLoadedMsg carries the labels, users and teams of a repo.
+type metadataOptionsLoadedMsg struct {
+	Repo    domain.RepoRef
+	Options *domain.MetadataOptions
+	Err     error
+}
+
+// metadataEditedMsg is sent when a metadata edit completes. PrevLabels are
+// the list row's labels before the edit was shown, restored on failure.
+type metadataEditedMsg struct {
+	Number     int
+	Kind       views.MetadataKind
+	PrevLabels []string
+	HadRow     bool
+	Err        error
+}
+
+// loadMetadataOptionsCmd fetches what the metadata picker offers.
+func loadMetadataOptionsCmd(uc *usecase.EditMetadata, repo domain.RepoRef) tea.Cmd {
+	return func() tea.Msg {
+		opts, err := uc.Options(context.Background(), repo)
+		return metadataOptionsLoadedMsg{Repo: repo, Options: opts, Err: err}
+	}
+}
+
+// handleEditMetadata opens the metadata picker on the PR in the detail
+// view. The repo's options are fetched once and reused until the repo
+// changes.
+func (a *App) handleEditMetadata(msg views.EditMetadataMsg) tea.Cmd {
+	if a.editMetadata == nil {
+		return a.unsupported("Editing labels, assignees and reviewers")
+	}
+	d := a.prDetail.GetDetail()
+	if d == nil {
+		return nil
+	}
+	a.prevView = a.view
+	a.view = core.ViewMetadata
+	cmd := a.metadataPicker.Show(msg.Kind, *d)
+	if a.metadataOptions != nil && a.metadataOptionsRepo.Equal(a.repo) {
+		a.metadataPicker.SetOptions(a.metadataOptions, nil)
+		return cmd
+	}
+	return tea.Batch(cmd, loadMetadataOptionsCmd(a.editMetadata, a.repo))
+}
+
+// handleMetadataOptionsLoaded fills the metadata picker and the create PR
+// dialog. The picker closes when the repo's backend cannot edit metadata.
+func (a *App) handleMetadataOptionsLoaded(msg metadataOptionsLoadedMsg) tea.Cmd {
+	if msg.Err == nil {
+		a.metadataOptions, a.metadataOptionsRepo = msg.Options, msg.Repo
+	}
+	if msg.Repo.Equal(a.repo) && errors.Is(msg.Err, errors.ErrUnsupported) && a.view == core.ViewMetadata {
+		a.metadataPicker.Close()
+		a.view = a.prevView
+		return a.unsupported("Editing labels, assignees and reviewers")
+	}
+	if msg.Repo.Equal(a.repo) {
+		a.metadataPicker.SetOptions(msg.Options, msg.Err)
+		if msg.Err == nil {
+			a.createPRDialog.SetOptions(msg.Options)
+		}
+	}
+	return nil
+}
+
+// handleApplyMetadata shows the edit on the detail view and the list row
+// right away, then sends it.
+func (a *App) handleApplyMetadata(msg views.ApplyMetadataMsg) tea.Cmd {
+	a.view = a.prevView
+	a.prDetail.ApplyMetadataEdit(msg.Number, msg.Edit)
+	done := metadataEditedMsg{Number: msg.Number, Kind: msg.Kind}
+	if pr, ok := a.prList.PR(msg.Number); ok {
+		done.PrevLabels, done.HadRow = a.prList.SetPRLabels(msg.Number, msg.Edit.ApplyLabels(pr.Labels))
+	}
+	uc, repo := a.editMetadata, a.repo
+	return func() tea.Msg {
+		done.Err = uc.Execute(context.Background(), repo, msg.Number, msg.Edit)
+		return done
+	}
+}
+
+// handleMetadataEdited reports an edit. A failed edit is rolled back: the
+// list row gets its labels back and the detail is reloaded from the host.
+func (a *App) handleMetadataEdited(msg metadataEditedMsg) tea.Cmd {
+	if msg.Err == nil {
+		return a.toasts.Add(fmt.Sprintf("%s updated on PR #%d", msg.Kind, msg.Number), domain.ToastSuccess, 3*time.Second)
+	}
+	if msg.HadRow {
+		a.prList.SetPRLabels(msg.Number, msg.PrevLabels)
+	}
+	cmd := a.errorToast("Updating "+strings.ToLower(msg.Kind.String())+" failed", msg.Err)
+	if a.getPRDetail != nil && a.prDetail.GetPRNumber() == msg.Number {
+		return tea.Batch(cmd, loadPRDetailCmd(a.getPRDetail, a.repo, msg.Number))
+	}
+	return cmd
+}
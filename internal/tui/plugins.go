@@ -0,0 +1,214 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/logging"
+	"github.com/indrasvat/vivecaka/internal/plugin"
+	"github.com/indrasvat/vivecaka/internal/tui/core"
+)
+
+// WithPlugins mounts a plugin registry: its plugins are initialised with the
+// app, their views can be opened with plugin.OpenViewMsg, their key bindings
+// are dispatched per view, and lifecycle hooks are emitted on its HookManager.
+func WithPlugins(r *plugin.Registry) Option {
+	return func(a *App) {
+		a.plugins = r
+		a.hooks = r.Hooks()
+		a.pluginViews = r.GetViews()
+		a.pluginKeys = r.GetKeyBindings()
+	}
+}
+
+// SetSender sets how plugin messages reach the running program, normally
+// (*tea.Program).Send. Messages sent before it is set are dropped.
+func (a *App) SetSender(send func(tea.Msg)) {
+	a.sender = send
+}
+
+// pluginContext is the plugin.AppContext handed to plugins' Init.
+type pluginContext struct{ app *App }
+
+func (c pluginContext) ConfigValue(key string) any  { return c.app.cfg.Value(key) }
+func (c pluginContext) ThemeName() string           { return c.app.theme.Name }
+func (c pluginContext) CurrentRepo() domain.RepoRef { return c.app.repo }
+func (c pluginContext) SendMessage(msg tea.Msg)     { c.app.sendPluginMsg(msg) }
+func (a *App) sendPluginMsg(msg tea.Msg) {
+	if a.sender == nil {
+		logging.Log.Debug("dropping plugin message: no program", "msg", fmt.Sprintf("%T", msg))
+		return
+	}
+	// Send blocks until the event loop reads it, so never call it inline:
+	// plugins may send from Init, before the loop starts.
+	go a.sender(msg)
+}
+
+// initPlugins initialises every registered plugin and plugin view model.
+func (a *App) initPlugins() tea.Cmd {
+	if a.plugins == nil {
+		return nil
+	}
+	var cmds []tea.Cmd
+	for _, p := range a.plugins.Plugins() {
+		cmds = append(cmds, p.Init(pluginContext{app: a}))
+	}
+	for _, v := range a.pluginViews {
+		if v.Model != nil {
+			cmds = append(cmds, v.Model.Init())
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// hookViewName names the current view for plugin key bindings and hooks. A
+// plugin view is named by its registration.
+func (a *App) hookViewName() string {
+	if a.view == core.ViewPlugin && a.pluginView < len(a.pluginViews) {
+		return a.pluginViews[a.pluginView].Name
+	}
+	return a.view.String()
+}
+
+// pluginKeyCmd runs the first plugin key binding that matches msg in the
+// current view. ok reports whether a binding matched.
+func (a *App) pluginKeyCmd(msg tea.KeyMsg) (cmd tea.Cmd, ok bool) {
+	view := a.hookViewName()
+	for _, k := range a.pluginKeys {
+		if k.Action == nil || (k.View != "" && k.View != view) {
+			continue
+		}
+		if key.Matches(msg, k.Key) {
+			return k.Action(), true
+		}
+	}
+	return nil, false
+}
+
+// openPluginView shows the plugin view registered under name.
+func (a *App) openPluginView(name string) tea.Cmd {
+	for i, v := range a.pluginViews {
+		if v.Name != name || v.Model == nil {
+			continue
+		}
+		if a.view != core.ViewPlugin {
+			a.prevView = a.view
+		}
+		a.pluginView = i
+		a.view = core.ViewPlugin
+		return nil
+	}
+	return a.toasts.Add(fmt.Sprintf("No plugin view %q", name), domain.ToastWarning, 3*time.Second)
+}
+
+// handlePluginViewKey routes a key to the shown plugin view. Plugin views
+// own the keyboard like text inputs do: only Ctrl+C quits, Esc closes the
+// view, and plugin key bindings scoped to it run first.
+func (a *App) handlePluginViewKey(msg tea.KeyMsg) tea.Cmd {
+	if msg.Type == tea.KeyCtrlC {
+		return tea.Quit
+	}
+	if cmd, ok := a.pluginKeyCmd(msg); ok {
+		return cmd
+	}
+	if msg.Type == tea.KeyEsc {
+		a.view = a.prevView
+		return nil
+	}
+	v := &a.pluginViews[a.pluginView]
+	var cmd tea.Cmd
+	v.Model, cmd = v.Model.Update(msg)
+	return cmd
+}
+
+// updatePluginViews passes msg to every plugin view model, shown or not.
+func (a *App) updatePluginViews(msg tea.Msg) tea.Cmd {
+	var cmds []tea.Cmd
+	for i := range a.pluginViews {
+		v := &a.pluginViews[i]
+		if v.Model == nil {
+			continue
+		}
+		var cmd tea.Cmd
+		v.Model, cmd = v.Model.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+	return tea.Batch(cmds...)
+}
+
+// renderPluginView renders the shown plugin view. Overlays float in a
+// titled box over the content area; tabs and panes fill it.
+func (a *App) renderPluginView(height int) string {
+	v := a.pluginViews[a.pluginView]
+	if v.Position != "overlay" {
+		return v.Model.View()
+	}
+	title := lipgloss.NewStyle().Bold(true).Foreground(a.theme.Primary).Render(v.Title)
+	framed := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(a.theme.Border).
+		Padding(0, 1).
+		Render(lipgloss.JoinVertical(lipgloss.Left, title, "", v.Model.View()))
+	return lipgloss.Place(a.width, height, lipgloss.Center, lipgloss.Center, framed)
+}
+
+// lifecycleHooks emits on_view_change and on_pr_select for what the last
+// message changed. Handlers run off the UI goroutine.
+func (a *App) lifecycleHooks(fromView string) tea.Cmd {
+	if a.plugins == nil {
+		return nil
+	}
+	var cmds []tea.Cmd
+	if to := a.hookViewName(); to != fromView {
+		cmds = append(cmds, a.emitHook(plugin.HookOnViewChange, plugin.ViewChangeEvent{From: fromView, To: to}))
+	}
+	if a.view == core.ViewPRList {
+		if pr := a.prList.SelectedPR(); pr != nil && pr.Number != a.lastSelected {
+			a.lastSelected = pr.Number
+			cmds = append(cmds, a.emitHook(plugin.HookOnPRSelect, plugin.PRSelectEvent{Repo: a.repo, PR: *pr}))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// actionHook emits an action hook about PR number once the action succeeded.
+// event carries the action's details; the repo and what is known about the
+// PR are filled in.
+func (a *App) actionHook(point plugin.HookPoint, number int, event plugin.ActionEvent) tea.Cmd {
+	if a.plugins == nil {
+		return nil
+	}
+	event.Event = point
+	event.Repo = a.repo
+	event.PR = domain.PR{Number: number}
+	if d := a.prDetail.GetDetail(); d != nil && d.Number == number {
+		event.PR = d.PR
+	} else if pr, ok := a.prList.PR(number); ok {
+		event.PR = pr
+	}
+	return a.emitHook(point, event)
+}
+
+// emitHook emits a hook in a command, logging handler errors.
+func (a *App) emitHook(point plugin.HookPoint, data any) tea.Cmd {
+	return func() tea.Msg {
+		if err := a.hooks.Emit(context.Background(), point, data); err != nil {
+			logging.Log.Warn("plugin hook failed", "hook", point, "err", err)
+		}
+		return nil
+	}
+}
+
+// emitBeforeRender emits before_render inline, so handlers must not block.
+func (a *App) emitBeforeRender() {
+	event := plugin.RenderEvent{View: a.hookViewName(), Width: a.width, Height: a.height}
+	if err := a.hooks.Emit(context.Background(), plugin.HookBeforeRender, event); err != nil {
+		logging.Log.Warn("plugin hook failed", "hook", plugin.HookBeforeRender, "err", err)
+	}
+}
@@ -0,0 +1,230 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/indrasvat/vivecaka/internal/config"
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/plugin"
+	"github.com/indrasvat/vivecaka/internal/tui/core"
+	"github.com/indrasvat/vivecaka/internal/tui/views"
+	"github.com/indrasvat/vivecaka/internal/usecase"
+)
+
+// echoModel is a plugin view that shows the last key it received.
+type echoModel struct{ last string }
+
+func (m echoModel) Init() tea.Cmd { return nil }
+
+func (m echoModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if k, ok := msg.(tea.KeyMsg); ok {
+		m.last = k.String()
+	}
+	return m, nil
+}
+
+func (m echoModel) View() string { return "echo:" + m.last }
+
+// testPlugin provides an overlay view opened by X on the PR list and records
+// the hooks it sees.
+type testPlugin struct {
+	mu     sync.Mutex
+	inited bool
+	events map[plugin.HookPoint][]any
+}
+
+func (p *testPlugin) Info() plugin.PluginInfo { return plugin.PluginInfo{Name: "test"} }
+
+func (p *testPlugin) Init(plugin.AppContext) tea.Cmd {
+	p.inited = true
+	return nil
+}
+
+func (p *testPlugin) Views() []plugin.ViewRegistration {
+	return []plugin.ViewRegistration{{Name: "echo", Title: "Echo", Position: "overlay", Model: echoModel{}}}
+}
+
+func (p *testPlugin) KeyBindings() []plugin.KeyRegistration {
+	return []plugin.KeyRegistration{{
+		Key:    key.NewBinding(key.WithKeys("X")),
+		View:   "pr_list",
+		Action: func() tea.Cmd { return func() tea.Msg { return plugin.OpenViewMsg{Name: "echo"} } },
+	}}
+}
+
+func (p *testPlugin) Hooks() []plugin.HookRegistration {
+	var regs []plugin.HookRegistration
+	for _, point := range []plugin.HookPoint{plugin.HookOnViewChange, plugin.HookOnPRSelect, plugin.HookBeforeRender} {
+		regs = append(regs, plugin.HookRegistration{Point: point, Handler: func(_ context.Context, data any) error {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			p.events[point] = append(p.events[point], data)
+			return nil
+		}})
+	}
+	return regs
+}
+
+func pluginApp(t *testing.T) (*App, *testPlugin) {
+	t.Helper()
+	p := &testPlugin{events: make(map[plugin.HookPoint][]any)}
+	registry := plugin.NewRegistry()
+	require.NoError(t, registry.Register(p))
+
+	cfg := config.Default()
+	cfg.General.RefreshInterval = 0
+	app := New(cfg, WithVersion("test"), WithPlugins(registry))
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app.view = core.ViewPRList
+	app.banner.Hide()
+	return app, p
+}
+
+// runCmd executes cmd, expanding batches, and feeds the messages it produces
+// back into app.
+func runCmd(app *App, cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	switch msg := cmd().(type) {
+	case nil:
+	case tea.BatchMsg:
+		for _, c := range msg {
+			runCmd(app, c)
+		}
+	default:
+		_, next := app.Update(msg)
+		runCmd(app, next)
+	}
+}
+
+func TestPluginInitAndKeyOpensOverlay(t *testing.T) {
+	app, p := pluginApp(t)
+	app.initPlugins()
+	assert.True(t, p.inited)
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'X'}})
+	runCmd(app, cmd)
+	require.Equal(t, core.ViewPlugin, app.view)
+	assert.Contains(t, app.View(), "Echo")
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	assert.Equal(t, core.ViewPlugin, app.view, "plugin views own the keyboard")
+	assert.Contains(t, app.View(), "echo:q")
+
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, core.ViewPRList, app.view)
+}
+
+func TestPluginKeyBindingIsScopedToView(t *testing.T) {
+	app, _ := pluginApp(t)
+	app.view = core.ViewInbox
+
+	_, ok := app.pluginKeyCmd(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'X'}})
+	assert.False(t, ok)
+}
+
+func TestPluginLifecycleHooks(t *testing.T) {
+	app, p := pluginApp(t)
+	app.prList.SetPRs([]domain.PR{{Number: 7, State: domain.PRStateOpen}})
+
+	_, cmd := app.Update(views.OpenFilterMsg{})
+	runCmd(app, cmd)
+	_, cmd = app.Update(views.CloseFilterMsg{})
+	runCmd(app, cmd)
+	app.View()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	assert.Equal(t, []any{
+		plugin.ViewChangeEvent{From: "pr_list", To: "filter"},
+		plugin.ViewChangeEvent{From: "filter", To: "pr_list"},
+	}, p.events[plugin.HookOnViewChange])
+	require.Len(t, p.events[plugin.HookOnPRSelect], 1)
+	assert.Equal(t, 7, p.events[plugin.HookOnPRSelect][0].(plugin.PRSelectEvent).PR.Number)
+	assert.Contains(t, p.events[plugin.HookBeforeRender], plugin.RenderEvent{View: "pr_list", Width: 120, Height: 40})
+}
+
+// countingReader counts list calls.
+type countingReader struct {
+	domain.PRReader
+	calls int
+}
+
+func (r *countingReader) ListPRs(context.Context, domain.RepoRef, domain.ListOpts) ([]domain.PR, error) {
+	r.calls++
+	return []domain.PR{{Number: 1}}, nil
+}
+
+func TestFetchHooksWrapListFetch(t *testing.T) {
+	reader := &countingReader{}
+	uc := usecase.NewListPRs(reader)
+	repo := domain.RepoRef{Owner: "o", Name: "r"}
+
+	hooks := plugin.NewHookManager()
+	var after plugin.FetchEvent
+	hooks.On(plugin.HookAfterFetch, func(_ context.Context, data any) error {
+		after = data.(plugin.FetchEvent)
+		return nil
+	})
+	msg := loadPRsCmd(uc, hooks, repo, domain.ListOpts{})().(views.PRsLoadedMsg)
+	require.NoError(t, msg.Err)
+	assert.Equal(t, repo, after.Repo)
+	assert.Len(t, after.PRs, 1)
+
+	hooks.On(plugin.HookBeforeFetch, func(context.Context, any) error { return errors.New("vetoed") })
+	msg = loadPRsCmd(uc, hooks, repo, domain.ListOpts{})().(views.PRsLoadedMsg)
+	require.ErrorContains(t, msg.Err, "vetoed")
+	assert.Equal(t, 1, reader.calls, "a before_fetch error cancels the fetch")
+}
+
+func TestPostCommentEmitsCommentAdded(t *testing.T) {
+	app, _ := pluginApp(t)
+	app.repo = domain.RepoRef{Owner: "o", Name: "r"}
+	reviewer := &commentingReviewer{}
+	app.manageComments = usecase.NewManageComments(reviewer)
+	var got []plugin.ActionEvent
+	app.hooks.On(plugin.HookCommentAdded, func(_ context.Context, data any) error {
+		got = append(got, data.(plugin.ActionEvent))
+		return nil
+	})
+
+	runCmd(app, app.handlePostComment(views.PostCommentMsg{Number: 7, Body: "LGTM"}))
+	require.Len(t, got, 1)
+	assert.Equal(t, 7, got[0].PR.Number)
+	assert.Equal(t, "LGTM", got[0].Comment.Body)
+	assert.Empty(t, got[0].Comment.Path)
+}
+
+func TestActionHookCarriesPRAndAction(t *testing.T) {
+	app, _ := pluginApp(t)
+	app.repo = domain.RepoRef{Owner: "o", Name: "r"}
+	app.prList.SetPRs([]domain.PR{{Number: 7, Title: "Fix", State: domain.PRStateOpen}})
+	var got []plugin.ActionEvent
+	app.hooks.On(plugin.HookReviewSubmitted, func(_ context.Context, data any) error {
+		got = append(got, data.(plugin.ActionEvent))
+		return nil
+	})
+
+	review := domain.Review{Action: domain.ReviewActionApprove}
+	app.actionHook(plugin.HookReviewSubmitted, 7, plugin.ActionEvent{Review: &review})()
+	require.Len(t, got, 1)
+	assert.Equal(t, plugin.HookReviewSubmitted, got[0].Event)
+	assert.Equal(t, app.repo, got[0].Repo)
+	assert.Equal(t, "Fix", got[0].PR.Title)
+	assert.Equal(t, &review, got[0].Review)
+
+	app.actionHook(plugin.HookReviewSubmitted, 9, plugin.ActionEvent{})()
+	require.Len(t, got, 2)
+	assert.Equal(t, domain.PR{Number: 9}, got[1].PR, "unknown PRs carry only their number")
+	assert.Nil(t, New(config.Default()).actionHook(plugin.HookPROpened, 9, plugin.ActionEvent{}),
+		"without plugins nothing is emitted")
+}
@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"github.com/indrasvat/vivecaka/internal/config"
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// snippetsFor returns the snippets offered in repo, in config order: the
+// global ones and those whose repos pattern matches it.
+func snippetsFor(cfg *config.Config, repo domain.RepoRef) []domain.Snippet {
+	var out []domain.Snippet
+	for _, s := range cfg.Snippets {
+		if s.Repos == "" || repo.Matches(s.Repos) {
+			out = append(out, domain.Snippet{Name: s.Name, Body: s.Body})
+		}
+	}
+	return out
+}
+
+// reviewTemplateFor returns the review body template for repo: the first
+// template whose repos pattern matches it, or else the first global one.
+func reviewTemplateFor(cfg *config.Config, repo domain.RepoRef) string {
+	global, found := "", false
+	for _, t := range cfg.ReviewTemplates {
+		switch {
+		case t.Repos == "" && !found:
+			global, found = t.Body, true
+		case t.Repos != "" && repo.Matches(t.Repos):
+			return t.Body
+		}
+	}
+	return global
+}
+
+// applySnippets offers the current repo's snippets in every editor.
+func (a *App) applySnippets() {
+	snippets := snippetsFor(a.cfg, a.repo)
+	a.diffView.SetSnippets(snippets)
+	a.prDetail.SetSnippets(snippets)
+	a.reviewForm.SetSnippets(snippets)
+}
+
+// prDetailAuthor returns the author of the loaded PR, or "" before it loads.
+func (a *App) prDetailAuthor() string {
+	if d := a.prDetail.GetDetail(); d != nil {
+		return d.Author
+	}
+	return ""
+}
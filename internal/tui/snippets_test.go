@@ -0,0 +1,37 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/indrasvat/vivecaka/internal/config"
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+func TestSnippetsFor(t *testing.T) {
+	cfg := config.Default()
+	cfg.Snippets = []config.SnippetConfig{
+		{Name: "nit", Body: "nit"},
+		{Name: "acme", Body: "see CONTRIBUTING", Repos: "github.com/acme/*"},
+		{Name: "ghe", Body: "internal", Repos: "ghe.example.com"},
+	}
+
+	got := snippetsFor(cfg, domain.RepoRef{Owner: "acme", Name: "api"})
+	assert.Equal(t, []domain.Snippet{{Name: "nit", Body: "nit"}, {Name: "acme", Body: "see CONTRIBUTING"}}, got)
+	assert.Equal(t, []domain.Snippet{{Name: "nit", Body: "nit"}}, snippetsFor(cfg, domain.RepoRef{Owner: "x", Name: "y"}))
+	assert.Empty(t, snippetsFor(config.Default(), domain.RepoRef{Owner: "x", Name: "y"}))
+}
+
+func TestReviewTemplateFor(t *testing.T) {
+	cfg := config.Default()
+	assert.Empty(t, reviewTemplateFor(cfg, domain.RepoRef{Owner: "acme", Name: "api"}))
+
+	cfg.ReviewTemplates = []config.TemplateConfig{
+		{Body: "global"},
+		{Body: "acme", Repos: "github.com/acme"},
+		{Body: "second global"},
+	}
+	assert.Equal(t, "acme", reviewTemplateFor(cfg, domain.RepoRef{Owner: "acme", Name: "api"}), "a matching repo template wins")
+	assert.Equal(t, "global", reviewTemplateFor(cfg, domain.RepoRef{Owner: "other", Name: "api"}))
+}
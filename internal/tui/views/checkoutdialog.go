@@ -85,6 +85,7 @@ type CloneDoneMsg struct {
 
 // SmartCheckoutDoneMsg is sent when a smart checkout (at a non-CWD path) finishes.
 type SmartCheckoutDoneMsg struct {
+	Number int
 	Branch string
 	Path   string
 	Err    error
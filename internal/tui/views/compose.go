@@ -0,0 +1,48 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// OpenEditorMsg asks the app to open Body in the user's $VISUAL or $EDITOR.
+// Context is written below the text for reference and dropped when the
+// file is read back.
+type OpenEditorMsg struct {
+	Body    string
+	Context string
+}
+
+// EditorDoneMsg carries the text written in the external editor back to
+// the view that opened it.
+type EditorDoneMsg struct {
+	Body string
+	Err  error
+}
+
+// openEditor asks the app to edit body in the external editor.
+func openEditor(body, context string) tea.Cmd {
+	return func() tea.Msg { return OpenEditorMsg{Body: body, Context: context} }
+}
+
+// renderPreview renders body as markdown for a composer preview.
+func renderPreview(body string, width int) []string {
+	if strings.TrimSpace(body) == "" {
+		return []string{"(empty)"}
+	}
+	return strings.Split(renderMarkdown(body, width), "\n")
+}
+
+// quoteComments quotes a conversation for the external editor, one block
+// per comment, as context for a reply.
+func quoteComments(comments []domain.Comment) string {
+	blocks := make([]string, 0, len(comments))
+	for _, c := range comments {
+		blocks = append(blocks, fmt.Sprintf("@%s wrote:\n%s", c.Author, strings.TrimRight(quoteReply(c), "\n")))
+	}
+	return strings.Join(blocks, "\n\n")
+}
@@ -2,8 +2,10 @@ package views
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
@@ -33,6 +35,15 @@ type ConfirmModel struct {
 	height       int
 	styles       core.Styles
 
+	// An alternative action, confirmed with altKey instead of Enter/y.
+	altKey   rune
+	altLabel string
+	onAlt    tea.Msg
+	// withInput adds a single-line text input to the prompt, e.g. for an
+	// optional comment. Its value is sent as ConfirmResultMsg.Input.
+	withInput bool
+	input     textinput.Model
+
 	// Loading state
 	spinnerFrame int
 
@@ -46,10 +57,14 @@ func (m *ConfirmModel) SetStyles(s core.Styles) { m.styles = s }
 
 // NewConfirmModel creates a new confirmation dialog.
 func NewConfirmModel(styles core.Styles) ConfirmModel {
+	ti := textinput.New()
+	ti.Prompt = "❯ "
+	ti.CharLimit = 1024
 	return ConfirmModel{
 		styles:       styles,
 		confirmLabel: "Yes",
 		cancelLabel:  "No",
+		input:        ti,
 	}
 }
 
@@ -57,6 +72,7 @@ func NewConfirmModel(styles core.Styles) ConfirmModel {
 type ConfirmResultMsg struct {
 	Confirmed bool
 	Action    tea.Msg
+	Input     string // the text input's value, for prompts shown with ShowInput
 }
 
 // CloseConfirmMsg is emitted when the dialog should close.
@@ -73,6 +89,30 @@ func (m *ConfirmModel) Show(title, message string, onConfirm tea.Msg) {
 	m.onConfirm = onConfirm
 }
 
+// ShowInput is Show with a text input under the message, for an optional
+// comment. Typing goes to the input, so only Enter and Esc answer.
+func (m *ConfirmModel) ShowInput(title, message, placeholder string, onConfirm tea.Msg) tea.Cmd {
+	m.Show(title, message, onConfirm)
+	m.withInput = true
+	m.input.Reset()
+	m.input.Placeholder = placeholder
+	return m.input.Focus()
+}
+
+// SetConfirmLabel names the Enter/y action of the current prompt instead
+// of "Yes".
+func (m *ConfirmModel) SetConfirmLabel(label string) {
+	m.confirmLabel = label
+}
+
+// SetAlternative offers a second action on the current prompt: pressing
+// key confirms with action instead of the Show action.
+func (m *ConfirmModel) SetAlternative(key rune, label string, action tea.Msg) {
+	m.altKey = key
+	m.altLabel = label
+	m.onAlt = action
+}
+
 // ShowLoading transitions the dialog to a loading spinner state.
 func (m *ConfirmModel) ShowLoading(title, message string) tea.Cmd {
 	m.state = confirmLoading
@@ -148,18 +188,27 @@ func (m *ConfirmModel) handleKey(msg tea.KeyMsg) tea.Cmd {
 func (m *ConfirmModel) handlePromptKey(msg tea.KeyMsg) tea.Cmd {
 	switch msg.Type {
 	case tea.KeyEnter:
-		action := m.onConfirm
-		return func() tea.Msg { return ConfirmResultMsg{Confirmed: true, Action: action} }
+		action, input := m.onConfirm, m.inputValue()
+		return func() tea.Msg { return ConfirmResultMsg{Confirmed: true, Action: action, Input: input} }
 	case tea.KeyEscape:
 		m.reset()
 		return func() tea.Msg { return CloseConfirmMsg{} }
-	case tea.KeyRunes:
+	}
+	if m.withInput {
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return cmd
+	}
+	if msg.Type == tea.KeyRunes {
 		if len(msg.Runes) == 1 {
-			switch msg.Runes[0] {
-			case 'y', 'Y':
+			switch r := msg.Runes[0]; {
+			case m.onAlt != nil && r == m.altKey:
+				action := m.onAlt
+				return func() tea.Msg { return ConfirmResultMsg{Confirmed: true, Action: action} }
+			case r == 'y' || r == 'Y':
 				action := m.onConfirm
 				return func() tea.Msg { return ConfirmResultMsg{Confirmed: true, Action: action} }
-			case 'n', 'N':
+			case r == 'n' || r == 'N':
 				m.reset()
 				return func() tea.Msg { return CloseConfirmMsg{} }
 			}
@@ -168,12 +217,23 @@ func (m *ConfirmModel) handlePromptKey(msg tea.KeyMsg) tea.Cmd {
 	return nil
 }
 
+func (m *ConfirmModel) inputValue() string {
+	if !m.withInput {
+		return ""
+	}
+	return m.input.Value()
+}
+
 func (m *ConfirmModel) reset() {
 	m.state = confirmPrompt
 	m.title = ""
 	m.message = ""
 	m.resultMessage = ""
 	m.onConfirm = nil
+	m.confirmLabel = "Yes"
+	m.altKey, m.altLabel, m.onAlt = 0, "", nil
+	m.withInput = false
+	m.input.Blur()
 }
 
 // View renders the dialog based on current state.
@@ -201,25 +261,28 @@ func (m *ConfirmModel) viewPrompt() string {
 		Foreground(t.Fg).
 		Width(boxWidth - 6)
 
-	confirmKey := lipgloss.NewStyle().
-		Foreground(t.Success).
-		Bold(true).
-		Render("Enter/y")
-	cancelKey := lipgloss.NewStyle().
-		Foreground(t.Error).
-		Bold(true).
-		Render("Esc/n")
+	confirmKeys, cancelKeys := "Enter/y", "Esc/n"
+	if m.withInput {
+		confirmKeys, cancelKeys = "Enter", "Esc"
+	}
+	keyStyle := lipgloss.NewStyle().Bold(true)
+	confirmKey := keyStyle.Foreground(t.Success).Render(confirmKeys)
+	cancelKey := keyStyle.Foreground(t.Error).Render(cancelKeys)
 
 	hints := fmt.Sprintf("%s %s   %s %s",
 		confirmKey, m.confirmLabel, cancelKey, m.cancelLabel)
+	if m.onAlt != nil {
+		altKey := keyStyle.Foreground(t.Warning).Render(string(m.altKey))
+		hints = fmt.Sprintf("%s %s   %s %s   %s %s",
+			confirmKey, m.confirmLabel, altKey, m.altLabel, cancelKey, m.cancelLabel)
+	}
 
-	inner := lipgloss.JoinVertical(lipgloss.Left,
-		titleStyle.Render(m.title),
-		"",
-		msgStyle.Render(m.message),
-		"",
-		hints,
-	)
+	lines := []string{titleStyle.Render(m.title), "", msgStyle.Render(m.message), ""}
+	if m.withInput {
+		m.input.Width = boxWidth - 10
+		lines = append(lines, m.input.View(), "")
+	}
+	inner := lipgloss.JoinVertical(lipgloss.Left, append(lines, hints)...)
 
 	return m.renderBox(inner, boxWidth, string(t.Primary))
 }
@@ -306,10 +369,16 @@ func (m *ConfirmModel) renderBox(inner string, boxWidth int, borderColor string)
 func (m *ConfirmModel) ConfirmStateHint() string {
 	switch m.state {
 	case confirmLoading:
-		return "Checking out..."
+		return m.message
 	case confirmResult:
 		return "Press any key to continue"
 	default:
+		if m.withInput {
+			return "Enter confirm  Esc cancel"
+		}
+		if m.onAlt != nil {
+			return fmt.Sprintf("Enter/y %s  %c %s  Esc/n cancel", strings.ToLower(m.confirmLabel), m.altKey, strings.ToLower(m.altLabel))
+		}
 		return "Enter/y confirm  Esc/n cancel"
 	}
 }
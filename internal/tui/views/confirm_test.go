@@ -189,9 +189,56 @@ func TestConfirmModel_StateHints(t *testing.T) {
 	m.Show("Test", "msg", nil)
 	assert.Contains(t, m.ConfirmStateHint(), "confirm")
 
-	m.ShowLoading("Test", "loading...")
-	assert.Contains(t, m.ConfirmStateHint(), "Checking")
+	m.ShowLoading("Test", "Checking out \"main\"...")
+	assert.Contains(t, m.ConfirmStateHint(), "Checking", "the loading hint is the loading message")
 
 	m.ShowResult("Done", "ok", true)
 	assert.Contains(t, m.ConfirmStateHint(), "any key")
 }
+
+func TestConfirmModel_Input(t *testing.T) {
+	m := NewConfirmModel(testStyles())
+	m.SetSize(80, 24)
+	m.ShowInput("Close PR", "Close PR #7?", "Comment (optional)", CheckoutPRMsg{Number: 7})
+	assert.Contains(t, m.View(), "Comment (optional)")
+	assert.Contains(t, m.ConfirmStateHint(), "Esc cancel")
+
+	// y and n are typed into the input rather than answering.
+	for _, r := range "not needed" {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	assert.True(t, m.Active())
+	assert.Contains(t, m.View(), "not needed")
+
+	result, ok := m.Update(tea.KeyMsg{Type: tea.KeyEnter})().(ConfirmResultMsg)
+	require.True(t, ok)
+	assert.True(t, result.Confirmed)
+	assert.Equal(t, "not needed", result.Input)
+
+	// Closing forgets the input for the next prompt.
+	m.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	m.Show("Next", "msg", nil)
+	result, ok = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})().(ConfirmResultMsg)
+	require.True(t, ok)
+	assert.Empty(t, result.Input)
+}
+
+func TestConfirmModel_Alternative(t *testing.T) {
+	m := NewConfirmModel(testStyles())
+	m.SetSize(80, 24)
+	m.Show("Update Branch", "Update?", CheckoutPRMsg{Number: 1})
+	m.SetConfirmLabel("Merge")
+	m.SetAlternative('r', "Rebase", CheckoutPRMsg{Number: 2})
+	view := m.View()
+	assert.Contains(t, view, "Merge")
+	assert.Contains(t, view, "Rebase")
+	assert.Contains(t, m.ConfirmStateHint(), "r rebase")
+
+	result, ok := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})().(ConfirmResultMsg)
+	require.True(t, ok)
+	assert.Equal(t, CheckoutPRMsg{Number: 2}, result.Action)
+
+	result, ok = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})().(ConfirmResultMsg)
+	require.True(t, ok)
+	assert.Equal(t, CheckoutPRMsg{Number: 1}, result.Action)
+}
@@ -0,0 +1,641 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/tui/core"
+)
+
+// createPRState tracks which phase the create PR dialog is in.
+type createPRState int
+
+const (
+	createInactive  createPRState = iota
+	createLoading                 // detecting the branch and listing bases
+	createBase                    // choosing the base branch
+	createComparing               // comparing the branch with the base
+	createForm                    // editing title, body, draft, reviewers and labels
+	createSummary                 // reviewing what will be opened
+	createPreview                 // browsing the diff against the base
+	createCreating                // push and create running
+	createError                   // loading, comparing or creating failed
+)
+
+// CreatePRModel opens a pull request from the current branch: it asks for
+// the base, prefills the title and body from the commits and the repo's PR
+// template, collects draft, reviewers and labels, previews the diff
+// against the base, and creates the PR.
+type CreatePRModel struct {
+	state  createPRState
+	styles core.Styles
+	keys   core.KeyMap
+	width  int
+	height int
+
+	head         string
+	bases        *domain.RepoBranches
+	options      *domain.MetadataOptions
+	comparison   *domain.BranchComparison
+	err          error
+	spinnerFrame int
+
+	form      *huh.Form
+	base      string
+	title     string
+	body      string
+	draft     bool
+	reviewers []string
+	labels    []string
+	// The title and body last suggested; a new comparison replaces the
+	// fields only while they still hold them.
+	suggestedTitle string
+	suggestedBody  string
+
+	diff DiffViewModel
+}
+
+// Create PR dialog messages.
+
+// NewPRMsg asks to open the create PR dialog for the current branch.
+type NewPRMsg struct{}
+
+// NewPRBasesLoadedMsg carries the current branch and the bases a PR from
+// it can target.
+type NewPRBasesLoadedMsg struct {
+	Head  string
+	Bases *domain.RepoBranches
+	Err   error
+}
+
+// CompareBranchMsg asks to compare the head branch with a base.
+type CompareBranchMsg struct {
+	Base string
+	Head string
+}
+
+// BranchComparedMsg carries a branch comparison and the repo's PR
+// template.
+type BranchComparedMsg struct {
+	Base       string
+	Comparison *domain.BranchComparison
+	Template   string
+	Err        error
+}
+
+// SubmitNewPRMsg is sent when the user confirms creating the PR. Push is
+// set when the head branch has to be pushed first.
+type SubmitNewPRMsg struct {
+	PR   domain.NewPR
+	Push bool
+}
+
+// PRCreatedMsg is sent after creating a PR. Number is set whenever the PR
+// was opened, even if a follow-up step failed.
+type PRCreatedMsg struct {
+	Number int
+	Err    error
+}
+
+// CreatePRCloseMsg is sent when the create PR dialog should close.
+type CreatePRCloseMsg struct{}
+
+// createPRSpinnerTick drives the loading spinner.
+type createPRSpinnerTick struct{}
+
+// NewCreatePRModel creates a new create PR dialog.
+func NewCreatePRModel(styles core.Styles, keys core.KeyMap) CreatePRModel {
+	return CreatePRModel{styles: styles, keys: keys, diff: newPreviewDiff(styles, keys)}
+}
+
+func newPreviewDiff(styles core.Styles, keys core.KeyMap) DiffViewModel {
+	d := NewDiffViewModel(styles, keys)
+	d.SetReadOnly(true)
+	return d
+}
+
+// SetStyles updates styles without losing state.
+func (m *CreatePRModel) SetStyles(s core.Styles) {
+	m.styles = s
+	m.diff.SetStyles(s)
+	if m.form != nil {
+		m.form.WithTheme(formTheme(s))
+	}
+}
+
+// SetSize updates dimensions.
+func (m *CreatePRModel) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+	m.diff.SetSize(w, h)
+	if m.form != nil {
+		m.form.WithWidth(m.boxWidth() - 6)
+	}
+}
+
+// Active returns whether the dialog is currently visible.
+func (m *CreatePRModel) Active() bool { return m.state != createInactive }
+
+// Show opens the dialog while the current branch and the bases load.
+func (m *CreatePRModel) Show() tea.Cmd {
+	m.reset()
+	m.state = createLoading
+	return m.spinnerTick()
+}
+
+// SetOptions sets the reviewers and labels the form offers. Options that
+// arrive after the form was built are offered the next time it is edited.
+func (m *CreatePRModel) SetOptions(opts *domain.MetadataOptions) {
+	if m.Active() {
+		m.options = opts
+	}
+}
+
+// SetBases shows the base branch picker, or the error when the branch or
+// the bases could not be loaded.
+func (m *CreatePRModel) SetBases(msg NewPRBasesLoadedMsg) tea.Cmd {
+	if m.state != createLoading {
+		return nil
+	}
+	switch {
+	case msg.Err != nil:
+		m.ShowError(msg.Err)
+		return nil
+	case msg.Head == "" || msg.Head == "HEAD":
+		m.ShowError(fmt.Errorf("no branch is checked out; switch to the branch to open a PR from"))
+		return nil
+	case len(msg.Bases.Names) == 0:
+		m.ShowError(fmt.Errorf("the repo has no branch other than %s to open a PR against", msg.Head))
+		return nil
+	}
+	m.head, m.bases = msg.Head, msg.Bases
+	m.base = msg.Bases.Names[0]
+	return m.pickBase()
+}
+
+// SetComparison shows what the PR would contain and moves on to the form,
+// prefilling the title and body the first time.
+func (m *CreatePRModel) SetComparison(msg BranchComparedMsg) tea.Cmd {
+	if m.state != createComparing || msg.Base != m.base {
+		return nil
+	}
+	if msg.Err != nil {
+		m.ShowError(msg.Err)
+		return nil
+	}
+	m.comparison = msg.Comparison
+	if title := msg.Comparison.SuggestedTitle(); m.title == m.suggestedTitle {
+		m.title, m.suggestedTitle = title, title
+	}
+	if body := msg.Comparison.SuggestedBody(msg.Template); m.body == m.suggestedBody {
+		m.body, m.suggestedBody = body, body
+	}
+	m.diff = newPreviewDiff(m.styles, m.keys)
+	m.diff.SetSize(m.width, m.height)
+	m.diff.SetDiff(&msg.Comparison.Diff)
+	if len(msg.Comparison.Commits) == 0 {
+		m.state = createSummary
+		return nil
+	}
+	return m.editForm()
+}
+
+// ShowCreating shows the PR being created.
+func (m *CreatePRModel) ShowCreating() tea.Cmd {
+	m.state = createCreating
+	m.spinnerFrame = 0
+	return m.spinnerTick()
+}
+
+// ShowError shows a failed load, comparison or create. Dismissing it goes
+// back to the summary when there is one, so nothing typed is lost.
+func (m *CreatePRModel) ShowError(err error) {
+	m.state = createError
+	m.err = err
+}
+
+// Close hides the dialog.
+func (m *CreatePRModel) Close() { m.reset() }
+
+func (m *CreatePRModel) reset() {
+	*m = CreatePRModel{
+		styles: m.styles, keys: m.keys, width: m.width, height: m.height,
+		diff: newPreviewDiff(m.styles, m.keys),
+	}
+	m.diff.SetSize(m.width, m.height)
+}
+
+// pickBase shows the base branch picker.
+func (m *CreatePRModel) pickBase() tea.Cmd {
+	options := make([]huh.Option[string], 0, len(m.bases.Names))
+	for _, name := range m.bases.Names {
+		label := name
+		if name == m.bases.Default {
+			label += " (default)"
+		}
+		options = append(options, huh.NewOption(label, name))
+	}
+	m.state = createBase
+	m.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Base branch").
+				Description("Merge " + m.head + " into").
+				Options(options...).
+				Height(min(len(options)+3, 14)).
+				Value(&m.base),
+		),
+	).WithShowHelp(false)
+	m.form.WithTheme(formTheme(m.styles))
+	m.form.WithWidth(m.boxWidth() - 6)
+	return m.form.Init()
+}
+
+// editForm shows the title, body, draft, reviewers and labels form.
+func (m *CreatePRModel) editForm() tea.Cmd {
+	fields := []huh.Field{
+		huh.NewInput().
+			Title("Title").
+			CharLimit(256).
+			Value(&m.title),
+		huh.NewText().
+			Title("Body").
+			Lines(8).
+			ExternalEditor(false).
+			Value(&m.body),
+		huh.NewConfirm().
+			Title("Open as draft?").
+			Affirmative("Draft").
+			Negative("Ready for review").
+			Value(&m.draft),
+	}
+	groups := []*huh.Group{huh.NewGroup(fields...)}
+	var metaFields []huh.Field
+	if m.options != nil {
+		reviewers := make([]string, 0, len(m.options.Users)+len(m.options.Teams))
+		reviewers = append(reviewers, m.options.Users...)
+		reviewers = append(reviewers, m.options.Teams...)
+		if len(reviewers) > 0 {
+			metaFields = append(metaFields, huh.NewMultiSelect[string]().
+				Title("Reviewers").
+				Options(huh.NewOptions(reviewers...)...).
+				Filterable(true).
+				Height(8).
+				Value(&m.reviewers))
+		}
+		if len(m.options.Labels) > 0 {
+			labels := make([]string, len(m.options.Labels))
+			for i, l := range m.options.Labels {
+				labels[i] = l.Name
+			}
+			metaFields = append(metaFields, huh.NewMultiSelect[string]().
+				Title("Labels").
+				Options(huh.NewOptions(labels...)...).
+				Filterable(true).
+				Height(8).
+				Value(&m.labels))
+		}
+	}
+	if len(metaFields) > 0 {
+		groups = append(groups, huh.NewGroup(metaFields...))
+	}
+
+	m.state = createForm
+	m.form = huh.NewForm(groups...).WithShowHelp(false)
+	m.form.WithTheme(formTheme(m.styles))
+	m.form.WithWidth(m.boxWidth() - 6)
+	return m.form.Init()
+}
+
+// compare asks for the comparison with the chosen base.
+func (m *CreatePRModel) compare() tea.Cmd {
+	m.state = createComparing
+	m.spinnerFrame = 0
+	base, head := m.base, m.head
+	return tea.Batch(m.spinnerTick(), func() tea.Msg { return CompareBranchMsg{Base: base, Head: head} })
+}
+
+// NewPR returns the PR the dialog would create.
+func (m *CreatePRModel) NewPR() domain.NewPR {
+	return domain.NewPR{
+		Base:      m.base,
+		Head:      m.head,
+		Title:     strings.TrimSpace(m.title),
+		Body:      m.body,
+		Draft:     m.draft,
+		Reviewers: m.reviewers,
+		Labels:    m.labels,
+	}
+}
+
+// canCreate reports whether the summary may be confirmed.
+func (m *CreatePRModel) canCreate() bool {
+	return m.comparison != nil && len(m.comparison.Commits) > 0 && strings.TrimSpace(m.title) != ""
+}
+
+// Update handles messages for the create PR dialog.
+func (m *CreatePRModel) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case createPRSpinnerTick:
+		switch m.state {
+		case createLoading, createComparing, createCreating:
+			m.spinnerFrame++
+			return m.spinnerTick()
+		}
+		return nil
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+
+	switch m.state {
+	case createBase, createForm:
+		return m.updateForm(msg)
+	case createPreview:
+		return m.diff.Update(msg)
+	}
+	return nil
+}
+
+func (m *CreatePRModel) handleKey(msg tea.KeyMsg) tea.Cmd {
+	switch m.state {
+	case createLoading, createComparing:
+		if msg.Type == tea.KeyEscape {
+			return m.close()
+		}
+		return nil
+	case createCreating:
+		return nil
+	case createError:
+		// Any key dismisses.
+		if m.comparison != nil {
+			m.state = createSummary
+			return nil
+		}
+		return m.close()
+	case createBase:
+		if msg.Type == tea.KeyEscape {
+			if m.comparison != nil {
+				m.base = m.comparison.Base
+				m.state = createSummary
+				return nil
+			}
+			return m.close()
+		}
+		return m.updateForm(msg)
+	case createForm:
+		if msg.Type == tea.KeyEscape {
+			m.state = createSummary
+			return nil
+		}
+		return m.updateForm(msg)
+	case createPreview:
+		if !m.diff.IsInputActive() && (msg.Type == tea.KeyEscape || msg.String() == "q") {
+			m.state = createSummary
+			return nil
+		}
+		return m.diff.Update(msg)
+	case createSummary:
+		return m.handleSummaryKey(msg)
+	}
+	return nil
+}
+
+func (m *CreatePRModel) handleSummaryKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEscape:
+		return m.close()
+	case tea.KeyEnter:
+		return m.submit()
+	case tea.KeyRunes:
+		if len(msg.Runes) != 1 {
+			return nil
+		}
+		switch msg.Runes[0] {
+		case 'y':
+			return m.submit()
+		case 'd':
+			if m.comparison != nil && len(m.comparison.Diff.Files) > 0 {
+				m.state = createPreview
+			}
+		case 'e':
+			if m.comparison != nil && len(m.comparison.Commits) > 0 {
+				return m.editForm()
+			}
+		case 'b':
+			return m.pickBase()
+		}
+	}
+	return nil
+}
+
+func (m *CreatePRModel) submit() tea.Cmd {
+	if !m.canCreate() {
+		return nil
+	}
+	pr, push := m.NewPR(), !m.comparison.Pushed
+	return func() tea.Msg { return SubmitNewPRMsg{PR: pr, Push: push} }
+}
+
+func (m *CreatePRModel) updateForm(msg tea.Msg) tea.Cmd {
+	if m.form == nil {
+		return nil
+	}
+	model, cmd := m.form.Update(msg)
+	if f, ok := model.(*huh.Form); ok {
+		m.form = f
+	}
+	switch m.form.State {
+	case huh.StateCompleted:
+		if m.state == createBase {
+			if m.comparison != nil && m.comparison.Base == m.base {
+				m.state = createSummary
+				return nil
+			}
+			return m.compare()
+		}
+		m.state = createSummary
+		return nil
+	case huh.StateAborted:
+		return m.close()
+	}
+	return cmd
+}
+
+func (m *CreatePRModel) close() tea.Cmd {
+	m.reset()
+	return func() tea.Msg { return CreatePRCloseMsg{} }
+}
+
+func (m *CreatePRModel) spinnerTick() tea.Cmd {
+	return tea.Tick(80*time.Millisecond, func(time.Time) tea.Msg {
+		return createPRSpinnerTick{}
+	})
+}
+
+// StatusHint returns status bar text for the current dialog state.
+func (m *CreatePRModel) StatusHint() string {
+	switch m.state {
+	case createLoading, createComparing:
+		return "Loading...   Esc cancel"
+	case createCreating:
+		return "Creating PR..."
+	case createError:
+		return "Press any key to continue"
+	case createBase:
+		return "↑/↓ choose   / filter   Enter select   Esc cancel"
+	case createForm:
+		return "Tab/Enter next field   Esc done"
+	case createPreview:
+		return "j/k scroll   {/} file   Tab tree   / search   t split   Esc back"
+	default:
+		return "Enter create   d diff   e edit   b base   Esc cancel"
+	}
+}
+
+// View renders the dialog.
+func (m *CreatePRModel) View() string {
+	t := m.styles.Theme
+	switch m.state {
+	case createLoading, createComparing, createCreating:
+		text := "Finding the branches to open a PR against..."
+		switch m.state {
+		case createComparing:
+			text = "Comparing " + m.head + " with " + m.base + "..."
+		case createCreating:
+			text = "Creating the PR..."
+			if m.comparison != nil && !m.comparison.Pushed {
+				text = "Pushing " + m.head + " and creating the PR..."
+			}
+		}
+		frame := spinnerFrames[m.spinnerFrame%len(spinnerFrames)]
+		spinner := lipgloss.NewStyle().Foreground(t.Primary).Bold(true).Render(frame)
+		return m.renderBox(lipgloss.JoinVertical(lipgloss.Left,
+			m.header(), "", spinner+" "+lipgloss.NewStyle().Foreground(t.Fg).Render(text),
+		), t.Primary)
+	case createError:
+		icon := lipgloss.NewStyle().Foreground(t.Error).Bold(true).Render("✗ Cannot create the PR")
+		errMsg := "unknown error"
+		if m.err != nil {
+			errMsg = m.err.Error()
+		}
+		errText := lipgloss.NewStyle().Foreground(t.Fg).Width(m.boxWidth() - 6).Render(errMsg)
+		return m.renderBox(lipgloss.JoinVertical(lipgloss.Left,
+			m.header(), "", icon, errText, "", m.hint("Press any key to continue"),
+		), t.Error)
+	case createBase, createForm:
+		return m.renderBox(lipgloss.JoinVertical(lipgloss.Left, m.header(), "", m.form.View()), t.Primary)
+	case createPreview:
+		return m.diff.View()
+	case createSummary:
+		return m.renderBox(lipgloss.JoinVertical(lipgloss.Left, m.summaryLines()...), t.Primary)
+	}
+	return ""
+}
+
+// header renders the dialog title and the branches.
+func (m *CreatePRModel) header() string {
+	t := m.styles.Theme
+	title := lipgloss.NewStyle().Foreground(t.Primary).Bold(true).Render("New Pull Request")
+	if m.head == "" {
+		return title
+	}
+	branches := lipgloss.NewStyle().Foreground(t.Info).Render(m.head)
+	if m.state != createBase && m.base != "" {
+		branches += lipgloss.NewStyle().Foreground(t.Muted).Render(" → ") +
+			lipgloss.NewStyle().Foreground(t.Info).Render(m.base)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, title, branches)
+}
+
+// maxSummaryCommits caps the commits listed in the summary.
+const maxSummaryCommits = 8
+
+// summaryLines describes the PR about to be created: its commits, the
+// size of the diff, and the chosen title and options.
+func (m *CreatePRModel) summaryLines() []string {
+	t := m.styles.Theme
+	c := m.comparison
+	width := m.boxWidth() - 6
+	label := lipgloss.NewStyle().Foreground(t.Subtext).Width(11)
+	muted := lipgloss.NewStyle().Foreground(t.Muted)
+	fg := lipgloss.NewStyle().Foreground(t.Fg)
+
+	lines := []string{m.header(), ""}
+	if len(c.Commits) == 0 {
+		warn := lipgloss.NewStyle().Foreground(t.Warning).Width(width).
+			Render(fmt.Sprintf("● %s has no commits that %s does not already have. Commit some changes, or pick another base.", c.Head, c.Base))
+		return append(lines, warn, "", m.hint("b base   Esc cancel"))
+	}
+
+	title := strings.TrimSpace(m.title)
+	if title == "" {
+		title = lipgloss.NewStyle().Foreground(t.Error).Render("(no title; press e to add one)")
+	}
+	lines = append(lines, label.Render("Title")+fg.Width(width-11).Render(title))
+
+	var options []string
+	if m.draft {
+		options = append(options, "draft")
+	}
+	if len(m.reviewers) > 0 {
+		options = append(options, "reviewers: "+strings.Join(m.reviewers, ", "))
+	}
+	if len(m.labels) > 0 {
+		options = append(options, "labels: "+strings.Join(m.labels, ", "))
+	}
+	if len(options) > 0 {
+		lines = append(lines, label.Render("Options")+fg.Width(width-11).Render(strings.Join(options, " · ")))
+	}
+
+	adds, dels := c.Diff.Stats()
+	files := fmt.Sprintf("%d files", len(c.Diff.Files))
+	if len(c.Diff.Files) == 1 {
+		files = "1 file"
+	}
+	changes := files + "  " +
+		lipgloss.NewStyle().Foreground(t.Success).Render(fmt.Sprintf("+%d", adds)) + " " +
+		lipgloss.NewStyle().Foreground(t.Error).Render(fmt.Sprintf("-%d", dels))
+	lines = append(lines, label.Render("Changes")+changes)
+
+	lines = append(lines, label.Render("Commits")+fmt.Sprintf("%d", len(c.Commits)))
+	for i, commit := range c.Commits {
+		if i == maxSummaryCommits {
+			lines = append(lines, label.Render("")+muted.Render(fmt.Sprintf("… and %d more", len(c.Commits)-i)))
+			break
+		}
+		sha := lipgloss.NewStyle().Foreground(t.Warning).Render(commit.ShortSHA())
+		lines = append(lines, label.Render("")+sha+" "+fg.Render(truncateLine(commit.Title, width-11-8)))
+	}
+
+	if !c.Pushed {
+		push := lipgloss.NewStyle().Foreground(t.Warning).Width(width).
+			Render("● " + c.Head + " will be pushed to origin first.")
+		lines = append(lines, "", push)
+	}
+	return append(lines, "", m.hint("Enter create   d diff   e edit   b base   Esc cancel"))
+}
+
+func (m *CreatePRModel) hint(text string) string {
+	return lipgloss.NewStyle().Foreground(m.styles.Theme.Muted).Italic(true).Render(text)
+}
+
+func (m *CreatePRModel) boxWidth() int {
+	return max(40, min(80, m.width-4))
+}
+
+func (m *CreatePRModel) renderBox(inner string, border lipgloss.TerminalColor) string {
+	box := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(border).
+		Padding(1, 2).
+		Width(m.boxWidth()).
+		Render(inner)
+	centered := lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+	return ensureExactHeight(centered, m.height, m.width)
+}
@@ -0,0 +1,243 @@
+package views
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+func testCreatePR() CreatePRModel {
+	m := NewCreatePRModel(testStyles(), testKeys())
+	m.SetSize(100, 40)
+	return m
+}
+
+func testComparison() *domain.BranchComparison {
+	return &domain.BranchComparison{
+		Base: "main",
+		Head: "feat/oauth-login",
+		Commits: []domain.Commit{
+			{SHA: "1111111aaaa", Title: "Add OAuth client"},
+			{SHA: "2222222bbbb", Title: "Wire login button"},
+		},
+		Diff: *testDiff(),
+	}
+}
+
+// comparedCreatePR returns a dialog that compared feat/oauth-login with
+// main and is showing the form.
+func comparedCreatePR(t *testing.T) CreatePRModel {
+	t.Helper()
+	m := testCreatePR()
+	m.Show()
+	m.SetBases(NewPRBasesLoadedMsg{Head: "feat/oauth-login", Bases: &domain.RepoBranches{Default: "main", Names: []string{"main", "release"}}})
+	require.Equal(t, createBase, m.state)
+	m.compare()
+	m.SetComparison(BranchComparedMsg{Base: "main", Comparison: testComparison(), Template: "## Testing\n"})
+	require.Equal(t, createForm, m.state)
+	return m
+}
+
+func runeKey(r rune) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}} }
+
+func TestCreatePRInactiveByDefault(t *testing.T) {
+	m := testCreatePR()
+	assert.False(t, m.Active())
+	assert.Empty(t, m.View())
+}
+
+func TestCreatePRBases(t *testing.T) {
+	m := testCreatePR()
+	assert.NotNil(t, m.Show(), "loading starts the spinner")
+	assert.Contains(t, m.View(), "Finding the branches")
+
+	m.SetBases(NewPRBasesLoadedMsg{Head: "feat/x", Bases: &domain.RepoBranches{Default: "main", Names: []string{"main", "develop"}}})
+	require.Equal(t, createBase, m.state)
+	assert.Equal(t, "main", m.base, "defaults to the first base")
+	view := m.View()
+	assert.Contains(t, view, "main (default)")
+	assert.Contains(t, view, "develop")
+}
+
+func TestCreatePRChooseBase(t *testing.T) {
+	m := testCreatePR()
+	m.Show()
+	m.SetBases(NewPRBasesLoadedMsg{Head: "feat/x", Bases: &domain.RepoBranches{Names: []string{"main", "develop"}}})
+
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	for range 2 { // next field, then next group
+		require.NotNil(t, cmd)
+		cmd = m.Update(cmd())
+	}
+	require.Equal(t, createComparing, m.state)
+	assert.Contains(t, m.View(), "Comparing feat/x with develop")
+
+	var compare tea.Msg
+	for _, c := range cmd().(tea.BatchMsg) {
+		if msg, ok := c().(CompareBranchMsg); ok {
+			compare = msg
+		}
+	}
+	assert.Equal(t, CompareBranchMsg{Base: "develop", Head: "feat/x"}, compare)
+}
+
+func TestCreatePRBasesErrors(t *testing.T) {
+	for name, msg := range map[string]NewPRBasesLoadedMsg{
+		"error":    {Err: errors.New("boom")},
+		"detached": {Head: "HEAD", Bases: &domain.RepoBranches{Names: []string{"main"}}},
+		"no bases": {Head: "main", Bases: &domain.RepoBranches{Default: "main"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			m := testCreatePR()
+			m.Show()
+			m.SetBases(msg)
+			assert.Equal(t, createError, m.state)
+
+			cmd := m.Update(runeKey('x'))
+			require.NotNil(t, cmd)
+			assert.IsType(t, CreatePRCloseMsg{}, cmd())
+		})
+	}
+}
+
+func TestCreatePRPrefill(t *testing.T) {
+	m := comparedCreatePR(t)
+	assert.Equal(t, "Oauth login", m.title, "several commits are titled after the branch")
+	assert.Equal(t, "- Add OAuth client\n- Wire login button\n\n## Testing", m.body)
+
+	// A comparison with another base keeps what the user typed.
+	m.title = "Add OAuth login"
+	m.base = "release"
+	m.compare()
+	m.SetComparison(BranchComparedMsg{Base: "release", Comparison: testComparison()})
+	assert.Equal(t, "Add OAuth login", m.title)
+	assert.Equal(t, "- Add OAuth client\n- Wire login button", m.body, "an untouched body follows the suggestion")
+}
+
+func TestCreatePRIgnoresStaleComparison(t *testing.T) {
+	m := testCreatePR()
+	m.Show()
+	m.SetBases(NewPRBasesLoadedMsg{Head: "feat/x", Bases: &domain.RepoBranches{Names: []string{"main"}}})
+	m.compare()
+	m.SetComparison(BranchComparedMsg{Base: "develop", Comparison: testComparison()})
+	assert.Equal(t, createComparing, m.state)
+}
+
+func TestCreatePRSummary(t *testing.T) {
+	m := comparedCreatePR(t)
+	m.draft = true
+	m.reviewers = []string{"alice"}
+	m.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	require.Equal(t, createSummary, m.state)
+
+	view := m.View()
+	assert.Contains(t, view, "feat/oauth-login")
+	assert.Contains(t, view, "2 files")
+	assert.Contains(t, view, "+3")
+	assert.Contains(t, view, "1111111")
+	assert.Contains(t, view, "Wire login button")
+	assert.Contains(t, view, "reviewers: alice")
+	assert.Contains(t, view, "will be pushed", "the branch is not on origin yet")
+
+	cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	submit, ok := cmd().(SubmitNewPRMsg)
+	require.True(t, ok)
+	assert.True(t, submit.Push)
+	assert.Equal(t, domain.NewPR{
+		Base:      "main",
+		Head:      "feat/oauth-login",
+		Title:     m.title,
+		Body:      m.body,
+		Draft:     true,
+		Reviewers: []string{"alice"},
+	}, submit.PR)
+}
+
+func TestCreatePRSummaryNeedsTitle(t *testing.T) {
+	m := comparedCreatePR(t)
+	m.title = "  "
+	m.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	assert.Contains(t, m.View(), "no title")
+	assert.Nil(t, m.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+}
+
+func TestCreatePRNoCommits(t *testing.T) {
+	m := testCreatePR()
+	m.Show()
+	m.SetBases(NewPRBasesLoadedMsg{Head: "feat/x", Bases: &domain.RepoBranches{Names: []string{"main"}}})
+	m.compare()
+	m.SetComparison(BranchComparedMsg{Base: "main", Comparison: &domain.BranchComparison{Base: "main", Head: "feat/x"}})
+
+	require.Equal(t, createSummary, m.state, "there is nothing to edit")
+	assert.Contains(t, m.View(), "has no commits")
+	assert.Nil(t, m.Update(tea.KeyMsg{Type: tea.KeyEnter}))
+	m.Update(runeKey('e'))
+	assert.Equal(t, createSummary, m.state)
+}
+
+func TestCreatePRPreview(t *testing.T) {
+	m := comparedCreatePR(t)
+	m.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	m.Update(runeKey('d'))
+	require.Equal(t, createPreview, m.state)
+	assert.Contains(t, m.View(), "registry.go")
+
+	// The preview is read-only, and Esc goes back to the summary.
+	assert.Nil(t, m.Update(runeKey('V')))
+	m.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	assert.Equal(t, createSummary, m.state)
+}
+
+func TestCreatePRChangeBase(t *testing.T) {
+	m := comparedCreatePR(t)
+	m.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	m.Update(runeKey('b'))
+	require.Equal(t, createBase, m.state)
+
+	// Esc keeps the compared base.
+	m.base = "release"
+	m.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	assert.Equal(t, createSummary, m.state)
+	assert.Equal(t, "main", m.base)
+}
+
+func TestCreatePRErrorKeepsForm(t *testing.T) {
+	m := comparedCreatePR(t)
+	m.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	assert.NotNil(t, m.ShowCreating())
+	assert.Contains(t, m.View(), "Pushing feat/oauth-login")
+
+	m.ShowError(errors.New("title is taken"))
+	assert.Contains(t, m.View(), "title is taken")
+	m.Update(runeKey('x'))
+	assert.Equal(t, createSummary, m.state, "dismissing the error keeps the PR being written")
+	assert.NotEmpty(t, m.title)
+}
+
+func TestCreatePRFormOffersMetadata(t *testing.T) {
+	m := testCreatePR()
+	m.Show()
+	m.SetOptions(&domain.MetadataOptions{
+		Users:  []string{"alice"},
+		Teams:  []string{"acme/core"},
+		Labels: []domain.Label{{Name: "bug"}},
+	})
+	m.SetBases(NewPRBasesLoadedMsg{Head: "feat/x", Bases: &domain.RepoBranches{Names: []string{"main"}}})
+	m.compare()
+	m.SetComparison(BranchComparedMsg{Base: "main", Comparison: testComparison()})
+	require.Equal(t, createForm, m.state)
+
+	// Reviewers and labels are on the form's second page.
+	m.form.NextGroup()
+	view := m.View()
+	assert.Contains(t, view, "Reviewers")
+	assert.Contains(t, view, "acme/core")
+	assert.Contains(t, view, "Labels")
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -114,9 +115,18 @@ type AddInlineCommentMsg struct {
 	Input  domain.InlineCommentInput
 }
 
+// PendingCommentsChangedMsg is sent when the user adds, edits or deletes a
+// pending review comment. Comments is the PR's full pending list.
+type PendingCommentsChangedMsg struct {
+	Number   int
+	Comments []domain.InlineCommentInput
+}
+
 // InlineCommentAddedMsg is sent after a comment is successfully added.
 type InlineCommentAddedMsg struct {
-	Err error
+	Number int
+	Input  domain.InlineCommentInput
+	Err    error
 }
 
 // DiffViewModel implements the diff viewer.
@@ -149,6 +159,10 @@ type DiffViewModel struct {
 	// Side-by-side mode.
 	splitMode bool // true for side-by-side, false for unified
 
+	// Line range selection: from selectAnchor to scrollY.
+	selecting    bool
+	selectAnchor int
+
 	// Inline comments.
 	comments      []domain.CommentThread            // all comments for this PR
 	commentMap    map[string][]domain.CommentThread // path:line → threads
@@ -158,7 +172,20 @@ type DiffViewModel struct {
 	editPath      string                            // file path being commented
 	editSide      string                            // "LEFT" or "RIGHT"
 	editReplyTo   string                            // thread ID if replying
+	editStartLine int                               // first line of a range comment, or 0
+	editStartSide string                            // side of editStartLine
+	editPending   int                               // index of the pending comment being edited, or -1
+	editPreview   bool                              // true when the editor shows the rendered markdown
+	picker        *snippetPicker                    // open snippet picker, if any
+	snippets      []domain.Snippet                  // canned responses for the picker
+	prAuthor      string                            // PR author, for snippet placeholders
+	pending       []domain.InlineCommentInput       // comments held for the next review
 	reviewContext *reviewprogress.Context
+
+	// readOnly turns off commenting, thread actions, review progress and
+	// the external diff tool, for diffs that are not of an open PR.
+	readOnly bool
+	scope    string // what a read-only diff shows, in place of review progress
 }
 
 // SetStyles updates the styles without losing state.
@@ -172,6 +199,7 @@ func NewDiffViewModel(styles core.Styles, keys core.KeyMap) DiffViewModel {
 		loading:      true,
 		currentMatch: -1,
 		highlighter:  newSyntaxHighlighter(),
+		editPending:  -1,
 	}
 }
 
@@ -184,6 +212,23 @@ func (m *DiffViewModel) SetSize(w, h int) {
 // SetPRNumber sets the PR number for external tool launches.
 func (m *DiffViewModel) SetPRNumber(n int) { m.prNumber = n }
 
+// GetPRNumber returns the PR number the diff belongs to.
+func (m *DiffViewModel) GetPRNumber() int { return m.prNumber }
+
+// SetSnippets sets the canned responses offered by the snippet picker.
+func (m *DiffViewModel) SetSnippets(snippets []domain.Snippet) { m.snippets = snippets }
+
+// SetPRAuthor sets the PR author used for snippet placeholders.
+func (m *DiffViewModel) SetPRAuthor(author string) { m.prAuthor = author }
+
+// SetReadOnly turns the review keys off, leaving navigation, search and
+// the layout toggles.
+func (m *DiffViewModel) SetReadOnly(readOnly bool) { m.readOnly = readOnly }
+
+// SetScope sets the line a read-only diff shows above the content in place
+// of review progress, such as the commits it covers.
+func (m *DiffViewModel) SetScope(scope string) { m.scope = scope }
+
 // SetHeadBranch sets the head branch name for checkout from error state.
 func (m *DiffViewModel) SetHeadBranch(b string) { m.headBranch = b }
 
@@ -191,8 +236,7 @@ func (m *DiffViewModel) SetHeadBranch(b string) { m.headBranch = b }
 func (m *DiffViewModel) SetDiff(d *domain.Diff) {
 	m.diff = d
 	m.loading = false
-	m.fileIdx = 0
-	m.scrollY = 0
+	m.showFile(0)
 	// Pre-compute file change counts so renderFileTree doesn't recount every frame.
 	if d != nil {
 		m.fileChangeCounts = make([][2]int, len(d.Files))
@@ -218,6 +262,29 @@ func (m *DiffViewModel) SetComments(threads []domain.CommentThread) {
 	}
 }
 
+// SetPendingComments sets the comments held for the PR's next review.
+func (m *DiffViewModel) SetPendingComments(comments []domain.InlineCommentInput) {
+	m.pending = slices.Clone(comments)
+}
+
+// PendingComments returns the comments held for the PR's next review.
+func (m *DiffViewModel) PendingComments() []domain.InlineCommentInput {
+	return slices.Clone(m.pending)
+}
+
+// pendingAt returns the index of the pending comment on a file line, or -1.
+func (m *DiffViewModel) pendingAt(path string, line int, side string) int {
+	return slices.IndexFunc(m.pending, func(c domain.InlineCommentInput) bool {
+		return c.Path == path && c.Line == line && c.Side == side
+	})
+}
+
+// pendingChanged reports the pending list to the app, which persists it.
+func (m *DiffViewModel) pendingChanged() tea.Cmd {
+	n, comments := m.prNumber, slices.Clone(m.pending)
+	return func() tea.Msg { return PendingCommentsChangedMsg{Number: n, Comments: comments} }
+}
+
 // SetReviewContext updates the incremental review context shown in diff view.
 func (m *DiffViewModel) SetReviewContext(ctx *reviewprogress.Context) {
 	m.reviewContext = ctx
@@ -238,13 +305,34 @@ func (m *DiffViewModel) JumpToFile(path string) {
 	}
 	for i, file := range m.diff.Files {
 		if file.Path == path {
-			m.fileIdx = i
-			m.scrollY = 0
+			m.showFile(i)
 			return
 		}
 	}
 }
 
+// JumpToHunk selects a file by path and scrolls to the header of its
+// hunk at index hunk.
+func (m *DiffViewModel) JumpToHunk(path string, hunk int) {
+	m.JumpToFile(path)
+	if m.CurrentFilePath() != path {
+		return
+	}
+	if hunks := m.hunkLineIndexes(m.fileIdx); hunk >= 0 && hunk < len(hunks) {
+		m.scrollY = hunks[hunk]
+	}
+}
+
+// currentHunk returns the index of the hunk at the current scroll
+// position, or -1 without a diff.
+func (m *DiffViewModel) currentHunk() int {
+	if m.CurrentFilePath() == "" {
+		return -1
+	}
+	_, hunk, _ := m.diffLineAt(m.scrollY)
+	return hunk
+}
+
 func (m *DiffViewModel) spinnerTick() tea.Cmd {
 	return tea.Tick(80*time.Millisecond, func(_ time.Time) tea.Msg {
 		return diffSpinnerTickMsg{}
@@ -256,8 +344,7 @@ func (m *DiffViewModel) StartLoading() tea.Cmd {
 	m.loading = true
 	m.diff = nil
 	m.loadErr = nil
-	m.fileIdx = 0
-	m.scrollY = 0
+	m.showFile(0)
 	m.spinnerFrame = 0
 	return m.spinnerTick()
 }
@@ -277,6 +364,16 @@ type DiffLoadedMsg struct {
 	Err    error
 }
 
+// ScopedDiffLoadedMsg carries a diff shown read-only in place of the PR
+// diff, such as a commit diff or an interdiff. Scope tells it apart from
+// diffs opened later.
+type ScopedDiffLoadedMsg struct {
+	Number int
+	Scope  string
+	Diff   *domain.Diff
+	Err    error
+}
+
 // diffSpinnerTickMsg drives the diff loading spinner animation.
 type diffSpinnerTickMsg struct{}
 
@@ -299,6 +396,12 @@ func (m *DiffViewModel) Update(msg tea.Msg) tea.Cmd {
 		}
 		m.loadErr = nil
 		m.SetDiff(msg.Diff)
+	case EditorDoneMsg:
+		// Preview what was written in the external editor before posting.
+		if m.editing && msg.Err == nil {
+			m.editBuffer = msg.Body
+			m.editPreview = true
+		}
 	}
 	return nil
 }
@@ -309,9 +412,16 @@ func (m *DiffViewModel) IsTreeFocus() bool { return m.treeFocus }
 // IsSplitMode returns whether the diff is in side-by-side mode.
 func (m *DiffViewModel) IsSplitMode() bool { return m.splitMode }
 
+// IsInputActive returns whether the view is taking keys for the comment
+// editor, search or a line selection, so global shortcuts must not fire.
+func (m *DiffViewModel) IsInputActive() bool { return m.editing || m.searching || m.selecting }
+
 func (m *DiffViewModel) handleKey(msg tea.KeyMsg) tea.Cmd {
 	// Error state: only allow e (external diff) and c (checkout).
 	if !m.loading && m.diff == nil {
+		if m.readOnly {
+			return nil
+		}
 		if msg.Type == tea.KeyRunes && len(msg.Runes) == 1 {
 			switch msg.Runes[0] {
 			case 'e':
@@ -375,32 +485,73 @@ func (m *DiffViewModel) handleTreeKey(msg tea.KeyMsg) tea.Cmd {
 }
 
 func (m *DiffViewModel) handleEditKey(msg tea.KeyMsg) tea.Cmd {
+	if m.picker != nil {
+		if s, done := m.picker.handleKey(msg); done {
+			m.picker = nil
+			if s != nil {
+				vars := domain.SnippetVars{Author: m.prAuthor, Number: m.prNumber, File: m.editPath, Line: m.editLine}
+				m.editBuffer = insertSnippet(m.editBuffer, *s, vars)
+			}
+		}
+		return nil
+	}
+
+	// The preview only posts, or goes back to editing.
+	if m.editPreview && msg.Type != tea.KeyCtrlS && msg.Type != tea.KeyCtrlE {
+		if msg.Type == tea.KeyEscape || msg.Type == tea.KeyCtrlP {
+			m.editPreview = false
+		}
+		return nil
+	}
+
 	switch msg.Type {
 	case tea.KeyEscape:
-		m.editing = false
-		m.editBuffer = ""
-		m.editReplyTo = ""
-	case tea.KeyCtrlS:
-		// Submit the comment.
-		if strings.TrimSpace(m.editBuffer) == "" {
-			m.editing = false
-			m.editBuffer = ""
-			return nil
+		m.closeEditor()
+	case tea.KeyCtrlE:
+		return openEditor(m.editBuffer, m.editorContext())
+	case tea.KeyCtrlP:
+		m.editPreview = true
+	case tea.KeyCtrlT:
+		if len(m.snippets) > 0 {
+			m.picker = newSnippetPicker(m.snippets)
 		}
+	case tea.KeyCtrlS:
 		input := domain.InlineCommentInput{
 			Path:      m.editPath,
 			Line:      m.editLine,
 			Side:      m.editSide,
+			StartLine: m.editStartLine,
+			StartSide: m.editStartSide,
 			Body:      m.editBuffer,
 			InReplyTo: m.editReplyTo,
 		}
-		n := m.prNumber
-		m.editing = false
-		m.editBuffer = ""
-		m.editReplyTo = ""
-		return func() tea.Msg {
-			return AddInlineCommentMsg{Number: n, Input: input}
+		idx := m.editPending
+		m.closeEditor()
+		blank := strings.TrimSpace(input.Body) == ""
+
+		// Replies answer an existing conversation and post right away.
+		if input.InReplyTo != "" {
+			if blank {
+				return nil
+			}
+			n := m.prNumber
+			return func() tea.Msg {
+				return AddInlineCommentMsg{Number: n, Input: input}
+			}
+		}
+
+		// New comments wait for the review; clearing one deletes it.
+		switch {
+		case idx >= 0 && blank:
+			m.pending = slices.Delete(m.pending, idx, idx+1)
+		case idx >= 0:
+			m.pending[idx].Body = input.Body
+		case blank:
+			return nil
+		default:
+			m.pending = append(m.pending, input)
 		}
+		return m.pendingChanged()
 	case tea.KeyEnter:
 		m.editBuffer += "\n"
 	case tea.KeyBackspace:
@@ -413,33 +564,166 @@ func (m *DiffViewModel) handleEditKey(msg tea.KeyMsg) tea.Cmd {
 	return nil
 }
 
+// closeEditor closes the comment editor and clears its state.
+func (m *DiffViewModel) closeEditor() {
+	m.editing = false
+	m.editPreview = false
+	m.picker = nil
+	m.editBuffer = ""
+	m.editReplyTo = ""
+	m.editPending = -1
+	m.editStartLine = 0
+	m.editStartSide = ""
+}
+
+// diffLineAt returns the diff line at a scroll position in the current
+// file, and the index of its hunk. ok is false on hunk headers and past the
+// end.
+func (m *DiffViewModel) diffLineAt(pos int) (dl domain.DiffLine, hunk int, ok bool) {
+	if m.diff == nil || m.fileIdx >= len(m.diff.Files) || pos < 0 {
+		return domain.DiffLine{}, 0, false
+	}
+	if m.splitMode {
+		rows := m.buildSplitRows(m.diff.Files[m.fileIdx])
+		if pos >= len(rows) {
+			return domain.DiffLine{}, 0, false
+		}
+		return rows[pos].line, rows[pos].hunk, rows[pos].isLine
+	}
+	for i, h := range m.diff.Files[m.fileIdx].Hunks {
+		pos-- // hunk header
+		if pos < 0 {
+			return domain.DiffLine{}, i, false
+		}
+		if pos < len(h.Lines) {
+			return h.Lines[pos], i, true
+		}
+		pos -= len(h.Lines)
+	}
+	return domain.DiffLine{}, 0, false
+}
+
+// lineAnchor returns the line number and side a comment on dl refers to.
+func lineAnchor(dl domain.DiffLine) (line int, side string) {
+	if dl.Type == domain.DiffDelete {
+		return dl.OldNum, "LEFT"
+	}
+	return dl.NewNum, "RIGHT"
+}
+
 // currentDiffLine returns the diff line info at the current scroll position.
 func (m *DiffViewModel) currentDiffLine() (path string, line int, side string) {
 	if m.diff == nil || m.fileIdx >= len(m.diff.Files) {
 		return "", 0, ""
 	}
-	file := m.diff.Files[m.fileIdx]
-	pos := m.scrollY
-	for _, hunk := range file.Hunks {
-		pos-- // hunk header
-		if pos < 0 {
-			return file.Path, 0, ""
+	path = m.diff.Files[m.fileIdx].Path
+	dl, _, ok := m.diffLineAt(m.scrollY)
+	if !ok {
+		return path, 0, ""
+	}
+	line, side = lineAnchor(dl)
+	return path, line, side
+}
+
+// selectionBounds returns the first and last scroll positions of the line
+// selection, or the current line when nothing is selected.
+func (m *DiffViewModel) selectionBounds() (lo, hi int) {
+	if !m.selecting {
+		return m.scrollY, m.scrollY
+	}
+	return min(m.selectAnchor, m.scrollY), max(m.selectAnchor, m.scrollY)
+}
+
+// selectedLines returns the selected diff lines. ok is false when the
+// selection leaves its hunk, since a comment range must stay inside one.
+func (m *DiffViewModel) selectedLines() (lines []domain.DiffLine, ok bool) {
+	lo, hi := m.selectionBounds()
+	firstHunk := -1
+	for pos := lo; pos <= hi; pos++ {
+		dl, hunk, isLine := m.diffLineAt(pos)
+		if !isLine || (firstHunk >= 0 && hunk != firstHunk) {
+			return nil, false
 		}
-		for _, dl := range hunk.Lines {
-			if pos == 0 {
-				switch dl.Type {
-				case domain.DiffAdd:
-					return file.Path, dl.NewNum, "RIGHT"
-				case domain.DiffDelete:
-					return file.Path, dl.OldNum, "LEFT"
-				default:
-					return file.Path, dl.NewNum, "RIGHT"
-				}
+		firstHunk = hunk
+		lines = append(lines, dl)
+	}
+	return lines, len(lines) > 0
+}
+
+// openRangeEditor opens the comment editor on lines, prefilled with body.
+// A single line without a prefilled body reuses its pending comment, if
+// any.
+func (m *DiffViewModel) openRangeEditor(lines []domain.DiffLine, body string) {
+	path := m.CurrentFilePath()
+	line, side := lineAnchor(lines[len(lines)-1])
+	m.closeEditor()
+	m.selecting = false
+	m.editing = true
+	m.editPath = path
+	m.editLine = line
+	m.editSide = side
+	m.editBuffer = body
+	if len(lines) > 1 {
+		m.editStartLine, m.editStartSide = lineAnchor(lines[0])
+		return
+	}
+	if idx := m.pendingAt(path, line, side); idx >= 0 && body == "" {
+		m.editPending = idx
+		m.editBuffer = m.pending[idx].Body
+	}
+}
+
+// suggestion returns a suggested-change block holding the new-side content
+// of lines, and those lines. A suggestion replaces new-side lines, so
+// deleted lines are left out and a selection ending on one has none.
+func suggestion(lines []domain.DiffLine) (body string, kept []domain.DiffLine) {
+	if lines[len(lines)-1].Type == domain.DiffDelete {
+		return "", nil
+	}
+	var content []string
+	for _, dl := range lines {
+		if dl.Type == domain.DiffDelete {
+			continue
+		}
+		kept = append(kept, dl)
+		content = append(content, dl.Content)
+	}
+	return "```suggestion\n" + strings.Join(content, "\n") + "\n```", kept
+}
+
+// editorContext returns what the comment being edited refers to, for the
+// external editor: the thread for a reply, otherwise the diff hunk up to
+// the commented line, as GitHub shows it above review comments.
+func (m *DiffViewModel) editorContext() string {
+	if m.editReplyTo != "" {
+		for _, t := range m.comments {
+			if t.ReplyToID == m.editReplyTo {
+				return quoteComments(t.Comments)
 			}
-			pos--
 		}
+		return ""
 	}
-	return file.Path, 0, ""
+	if m.diff == nil || m.fileIdx >= len(m.diff.Files) {
+		return ""
+	}
+	f := m.diff.Files[m.fileIdx]
+	for _, h := range f.Hunks {
+		lines := []string{f.Path, h.Header}
+		for _, dl := range h.Lines {
+			prefix := " "
+			switch dl.Type {
+			case domain.DiffAdd:
+				prefix = "+"
+			case domain.DiffDelete:
+				prefix = "-"
+			}
+			lines = append(lines, prefix+dl.Content)
+			if line, side := lineAnchor(dl); line == m.editLine && side == m.editSide {
+				return strings.Join(lines, "\n")
+			}
+		}
+	}
+	return ""
 }
 
 // threadAtCurrentLine returns the first comment thread at the current scroll position.
@@ -455,10 +739,17 @@ func (m *DiffViewModel) threadAtCurrentLine() *domain.CommentThread {
 	return nil
 }
 
+// reviewKeys are the content keys a read-only diff ignores.
+const reviewKeys = "cSvDrxXeiuV"
+
 func (m *DiffViewModel) handleContentKey(msg tea.KeyMsg) tea.Cmd {
 	if m.pendingKey != 0 && msg.Type != tea.KeyRunes {
 		m.pendingKey = 0
 	}
+	if m.selecting && msg.Type == tea.KeyEscape {
+		m.selecting = false
+		return nil
+	}
 
 	if msg.Type == tea.KeyRunes && len(msg.Runes) == 1 {
 		r := msg.Runes[0]
@@ -477,6 +768,10 @@ func (m *DiffViewModel) handleContentKey(msg tea.KeyMsg) tea.Cmd {
 			}
 		}
 
+		if m.readOnly && strings.ContainsRune(reviewKeys, r) {
+			return nil
+		}
+
 		switch r {
 		case 'n':
 			m.nextMatch()
@@ -506,27 +801,44 @@ func (m *DiffViewModel) handleContentKey(msg tea.KeyMsg) tea.Cmd {
 			m.pendingKey = 'z'
 			return nil
 		case 't':
+			// Positions count rows, which differ between the modes.
 			m.splitMode = !m.splitMode
 			m.scrollY = 0
+			m.selecting = false
 			return nil
 		case 'c':
-			// Open comment editor at current line.
+			// Open comment editor on the selected lines, or on the pending
+			// comment at the current line if one exists.
+			if lines, ok := m.selectedLines(); ok {
+				m.openRangeEditor(lines, "")
+			}
+			return nil
+		case 'S':
+			// Suggest a change to the selected lines.
+			if lines, ok := m.selectedLines(); ok {
+				if body, kept := suggestion(lines); len(kept) > 0 {
+					m.openRangeEditor(kept, body)
+				}
+			}
+			return nil
+		case 'v':
+			m.selecting = !m.selecting
+			m.selectAnchor = m.scrollY
+			return nil
+		case 'D':
+			// Discard the pending comment at current line.
 			path, line, side := m.currentDiffLine()
-			if path != "" && line > 0 {
-				m.editing = true
-				m.editBuffer = ""
-				m.editPath = path
-				m.editLine = line
-				m.editSide = side
-				m.editReplyTo = ""
+			if idx := m.pendingAt(path, line, side); idx >= 0 {
+				m.pending = slices.Delete(m.pending, idx, idx+1)
+				return m.pendingChanged()
 			}
 			return nil
 		case 'r':
 			// Reply to thread at current line.
 			thread := m.threadAtCurrentLine()
 			if thread != nil && thread.ReplyToID != "" {
+				m.closeEditor()
 				m.editing = true
-				m.editBuffer = ""
 				m.editPath = thread.Path
 				m.editLine = thread.Line
 				m.editSide = "RIGHT"
@@ -541,16 +853,30 @@ func (m *DiffViewModel) handleContentKey(msg tea.KeyMsg) tea.Cmd {
 				return func() tea.Msg { return ResolveThreadMsg{ThreadID: threadID} }
 			}
 			return nil
+		case 'X':
+			// Unresolve thread at current line.
+			thread := m.threadAtCurrentLine()
+			if thread != nil && thread.Resolved && thread.ThreadID != "" {
+				threadID := thread.ThreadID
+				return func() tea.Msg { return UnresolveThreadMsg{ThreadID: threadID} }
+			}
+			return nil
 		case 'e':
 			n, e := m.prNumber, m.loadErr
 			return func() tea.Msg { return OpenExternalDiffMsg{Number: n, LoadErr: e} }
 		case 'i':
 			return func() tea.Msg { return CycleReviewScopeMsg{} }
+		case 'I':
+			if n := m.prNumber; n > 0 {
+				return func() tea.Msg { return ToggleInterdiffMsg{Number: n} }
+			}
 		case 'u':
-			return func() tea.Msg { return JumpNextReviewTargetMsg{CurrentPath: m.CurrentFilePath()} }
+			path, hunk := m.CurrentFilePath(), m.currentHunk()
+			return func() tea.Msg { return JumpNextReviewTargetMsg{CurrentPath: path, Hunk: hunk} }
 		case 'V':
 			if path := m.CurrentFilePath(); path != "" {
-				return func() tea.Msg { return ToggleViewedFileMsg{Path: path} }
+				hunk := m.currentHunk()
+				return func() tea.Msg { return ToggleViewedHunkMsg{Path: path, Hunk: hunk} }
 			}
 		}
 	}
@@ -799,7 +1125,7 @@ func (m *DiffViewModel) renderUnifiedContent() string {
 
 	file := m.diff.Files[m.fileIdx]
 	modeLabel := lipgloss.NewStyle().Foreground(t.Muted).Render(" Unified")
-	fileHeader := lipgloss.NewStyle().Foreground(t.Primary).Bold(true).Render(file.Path) + modeLabel
+	fileHeader := lipgloss.NewStyle().Foreground(t.Primary).Bold(true).Render(file.Path) + modeLabel + m.pendingLabel()
 	reviewHeader := m.renderReviewHeader(contentWidth)
 
 	if m.isCollapsed(m.fileIdx) {
@@ -824,6 +1150,7 @@ func (m *DiffViewModel) renderUnifiedContent() string {
 	var visible []string
 	lineIdx := 0
 	visibleCount := 0
+	selLo, selHi := m.selectionBounds()
 
 	if largeFile && m.scrollY == 0 {
 		warnStyle := lipgloss.NewStyle().Foreground(t.Warning).Bold(true)
@@ -831,7 +1158,7 @@ func (m *DiffViewModel) renderUnifiedContent() string {
 		visibleCount++
 	}
 
-	for _, hunk := range file.Hunks {
+	for hunkIdx, hunk := range file.Hunks {
 		if visibleCount >= contentHeight {
 			break
 		}
@@ -842,10 +1169,10 @@ func (m *DiffViewModel) renderUnifiedContent() string {
 			header := hunk.Header
 			if matches := lineMatches[lineIdx]; len(matches) > 0 {
 				header = applyHighlights(header, matches, hunkStyle, matchStyle)
-				visible = append(visible, header)
 			} else {
-				visible = append(visible, hunkStyle.Render(header))
+				header = hunkStyle.Render(header)
 			}
+			visible = append(visible, m.hunkMarker(file.Path, hunkIdx)+header)
 			visibleCount++
 		}
 		lineIdx++
@@ -863,32 +1190,38 @@ func (m *DiffViewModel) renderUnifiedContent() string {
 				} else {
 					highlightedContent = m.highlighter.highlight(dl.Content, file.Path)
 				}
+				selected := m.selecting && lineIdx >= selLo && lineIdx <= selHi
 				switch dl.Type {
 				case domain.DiffAdd:
-					lineNum := fmt.Sprintf("%4s %4d ", "", dl.NewNum)
+					lineNum := selectionMark(fmt.Sprintf("%4s %4d ", "", dl.NewNum), selected)
 					visible = append(visible, renderDiffLineWithSyntax(lineNum, "+", dl.Content, highlightedContent, m.styles.DiffAdd, matchStyle, matches))
 				case domain.DiffDelete:
-					lineNum := fmt.Sprintf("%4d %4s ", dl.OldNum, "")
+					lineNum := selectionMark(fmt.Sprintf("%4d %4s ", dl.OldNum, ""), selected)
 					visible = append(visible, renderDiffLineWithSyntax(lineNum, "-", dl.Content, highlightedContent, m.styles.DiffDelete, matchStyle, matches))
 				default:
-					lineNum := fmt.Sprintf("%4d %4d ", dl.OldNum, dl.NewNum)
+					lineNum := selectionMark(fmt.Sprintf("%4d %4d ", dl.OldNum, dl.NewNum), selected)
 					visible = append(visible, renderDiffLineWithSyntax(lineNum, " ", dl.Content, highlightedContent, lipgloss.NewStyle().Foreground(t.Fg), matchStyle, matches))
 				}
 				visibleCount++
 
 				// Render inline comments anchored to this line.
-				commentLine := dl.NewNum
+				commentLine, commentSide := dl.NewNum, "RIGHT"
 				if dl.Type == domain.DiffDelete {
-					commentLine = dl.OldNum
+					commentLine, commentSide = dl.OldNum, "LEFT"
 				}
+				var commentLines []string
 				for _, thread := range m.commentsForLine(file.Path, commentLine) {
-					for _, cl := range m.renderCommentThread(thread) {
-						if visibleCount >= contentHeight {
-							break
-						}
-						visible = append(visible, cl)
-						visibleCount++
+					commentLines = append(commentLines, m.renderCommentThread(thread)...)
+				}
+				if idx := m.pendingAt(file.Path, commentLine, commentSide); idx >= 0 {
+					commentLines = append(commentLines, m.renderPendingComment(m.pending[idx])...)
+				}
+				for _, cl := range commentLines {
+					if visibleCount >= contentHeight {
+						break
 					}
+					visible = append(visible, cl)
+					visibleCount++
 				}
 			}
 			lineIdx++
@@ -899,6 +1232,9 @@ func (m *DiffViewModel) renderUnifiedContent() string {
 	if m.searching {
 		content += "\n" + lipgloss.NewStyle().Foreground(t.Info).Render(m.searchBarText())
 	}
+	if m.selecting {
+		content += "\n" + lipgloss.NewStyle().Foreground(t.Info).Render(m.selectionBarText())
+	}
 
 	// Show comment editor if active.
 	if m.editing {
@@ -909,6 +1245,35 @@ func (m *DiffViewModel) renderUnifiedContent() string {
 		Render(lipgloss.JoinVertical(lipgloss.Left, fileHeader, reviewHeader, content))
 }
 
+// selectionMark marks a selected line in the first column of its line
+// numbers.
+func selectionMark(lineNum string, selected bool) string {
+	if !selected {
+		return lineNum
+	}
+	return "▌" + lineNum[1:]
+}
+
+// selectionBarText describes the line selection and what can be done with
+// it.
+func (m *DiffViewModel) selectionBarText() string {
+	lines, ok := m.selectedLines()
+	if !ok {
+		return "-- VISUAL -- selection must stay within one hunk  Esc cancel"
+	}
+	first, _ := lineAnchor(lines[0])
+	last, _ := lineAnchor(lines[len(lines)-1])
+	return fmt.Sprintf("-- VISUAL -- %s  c comment  S suggest change  Esc cancel", lineSpan(first, last))
+}
+
+// lineSpan describes a comment's lines: "line 7" or "lines 3–7".
+func lineSpan(start, line int) string {
+	if start <= 0 || start == line {
+		return fmt.Sprintf("line %d", line)
+	}
+	return fmt.Sprintf("lines %d–%d", start, line)
+}
+
 // renderCommentThread renders an inline comment thread as indented lines.
 func (m *DiffViewModel) renderCommentThread(thread domain.CommentThread) []string {
 	t := m.styles.Theme
@@ -921,6 +1286,9 @@ func (m *DiffViewModel) renderCommentThread(thread domain.CommentThread) []strin
 
 	prefix := "    │ "
 	topBorder := borderStyle.Render("    ┌─── ")
+	if thread.StartLine > 0 {
+		topBorder += bodyStyle.Render(lineSpan(thread.StartLine, thread.Line) + " ")
+	}
 	if thread.Resolved {
 		topBorder += resolvedStyle.Render("✓ resolved")
 	}
@@ -937,18 +1305,65 @@ func (m *DiffViewModel) renderCommentThread(thread domain.CommentThread) []strin
 	return lines
 }
 
+// renderPendingComment renders a comment held for the next review.
+func (m *DiffViewModel) renderPendingComment(c domain.InlineCommentInput) []string {
+	t := m.styles.Theme
+	borderStyle := lipgloss.NewStyle().Foreground(t.Border)
+	pendingStyle := lipgloss.NewStyle().Foreground(t.Warning).Bold(true)
+	bodyStyle := lipgloss.NewStyle().Foreground(t.Muted)
+
+	prefix := "    │ "
+	span := ""
+	if c.IsRange() {
+		span = " · " + lineSpan(c.StartLine, c.Line)
+	}
+	lines := []string{borderStyle.Render("    ┌─── ") + pendingStyle.Render("● pending") + bodyStyle.Render(span+"  c edit  D discard")}
+	for _, bodyLine := range strings.Split(c.Body, "\n") {
+		lines = append(lines, borderStyle.Render(prefix)+bodyStyle.Render(bodyLine))
+	}
+	return append(lines, borderStyle.Render("    └───"))
+}
+
 // renderCommentEditor renders the inline comment editor.
 func (m *DiffViewModel) renderCommentEditor() string {
 	t := m.styles.Theme
 	border := lipgloss.NewStyle().Foreground(t.Primary)
 	hint := lipgloss.NewStyle().Foreground(t.Muted)
 
+	title, keys := "Comment on ", "Ctrl+S add to review"
+	switch {
+	case m.editReplyTo != "":
+		keys = "Ctrl+S submit"
+	case m.editPending >= 0:
+		title, keys = "Edit pending comment on ", "Ctrl+S save (empty deletes)"
+	}
+	if m.editPreview {
+		keys += "  Ctrl+E $EDITOR  Esc back to editing"
+	} else {
+		keys += "  Ctrl+E $EDITOR  Ctrl+P preview  Esc cancel"
+		if len(m.snippets) > 0 {
+			keys += "  Ctrl+T snippets"
+		}
+	}
+
 	var lines []string
-	lines = append(lines, border.Render("    ╔══ Comment on "+m.editPath+fmt.Sprintf(":%d", m.editLine)))
+	where := fmt.Sprintf(":%d", m.editLine)
+	if m.editStartLine > 0 {
+		where = fmt.Sprintf(":%d–%d", m.editStartLine, m.editLine)
+	}
+	lines = append(lines, border.Render("    ╔══ "+title+m.editPath+where))
 	if m.editReplyTo != "" {
 		lines = append(lines, border.Render("    ║ ")+hint.Render("(reply to thread)"))
 	}
 
+	if m.editPreview {
+		for _, pl := range renderPreview(m.editBuffer, m.width-8) {
+			lines = append(lines, border.Render("    ║ ")+pl)
+		}
+		lines = append(lines, border.Render("    ╚══ ")+hint.Render(keys))
+		return strings.Join(lines, "\n")
+	}
+
 	// Show buffer content.
 	bufLines := strings.Split(m.editBuffer, "\n")
 	for _, bl := range bufLines {
@@ -958,10 +1373,26 @@ func (m *DiffViewModel) renderCommentEditor() string {
 		lines = append(lines, border.Render("    ║ ")+"▎")
 	}
 
-	lines = append(lines, border.Render("    ╚══ ")+hint.Render("Ctrl+S submit  Esc cancel"))
+	lines = append(lines, border.Render("    ╚══ ")+hint.Render(keys))
+	if m.picker != nil {
+		lines = append(lines, m.picker.view(m.styles, "    ", m.width))
+	}
 	return strings.Join(lines, "\n")
 }
 
+// pendingLabel summarizes the pending review comments for the file header.
+func (m *DiffViewModel) pendingLabel() string {
+	if len(m.pending) == 0 {
+		return ""
+	}
+	noun := "comments"
+	if len(m.pending) == 1 {
+		noun = "comment"
+	}
+	return lipgloss.NewStyle().Foreground(m.styles.Theme.Warning).
+		Render(fmt.Sprintf("  ● %d pending %s", len(m.pending), noun))
+}
+
 // splitRow holds one row of the side-by-side view.
 type splitRow struct {
 	leftNum   string
@@ -970,6 +1401,10 @@ type splitRow struct {
 	rightNum  string
 	rightText string
 	rightType domain.DiffLineType
+
+	hunk   int
+	line   domain.DiffLine // what a comment on the row refers to: its new side, if any
+	isLine bool            // false for hunk headers
 }
 
 // renderSplitContent renders side-by-side diff columns.
@@ -982,7 +1417,7 @@ func (m *DiffViewModel) renderSplitContent() string {
 
 	file := m.diff.Files[m.fileIdx]
 	modeLabel := lipgloss.NewStyle().Foreground(t.Muted).Render(" Split")
-	fileHeader := lipgloss.NewStyle().Foreground(t.Primary).Bold(true).Render(file.Path) + modeLabel
+	fileHeader := lipgloss.NewStyle().Foreground(t.Primary).Bold(true).Render(file.Path) + modeLabel + m.pendingLabel()
 	reviewHeader := m.renderReviewHeader(contentWidth)
 
 	rows := m.buildSplitRows(file)
@@ -998,12 +1433,14 @@ func (m *DiffViewModel) renderSplitContent() string {
 	divider := lipgloss.NewStyle().Foreground(t.Border).Render(" │ ")
 	lineNumWidth := 5
 
-	for _, row := range rows[m.scrollY:end] {
+	selLo, selHi := m.selectionBounds()
+	for i, row := range rows[m.scrollY:end] {
 		leftStyle := m.splitLineStyle(row.leftType)
 		rightStyle := m.splitLineStyle(row.rightType)
 
-		leftLine := m.renderSplitHalf(row.leftNum, row.leftText, leftStyle, lineNumWidth, colWidth)
-		rightLine := m.renderSplitHalf(row.rightNum, row.rightText, rightStyle, lineNumWidth, colWidth)
+		selected := m.selecting && row.isLine && m.scrollY+i >= selLo && m.scrollY+i <= selHi
+		leftLine := m.renderSplitHalf(row.leftNum, row.leftText, leftStyle, lineNumWidth, colWidth, selected)
+		rightLine := m.renderSplitHalf(row.rightNum, row.rightText, rightStyle, lineNumWidth, colWidth, false)
 		visible = append(visible, leftLine+divider+rightLine)
 	}
 
@@ -1011,6 +1448,9 @@ func (m *DiffViewModel) renderSplitContent() string {
 	if m.searching {
 		content += "\n" + lipgloss.NewStyle().Foreground(t.Info).Render(m.searchBarText())
 	}
+	if m.selecting {
+		content += "\n" + lipgloss.NewStyle().Foreground(t.Info).Render(m.selectionBarText())
+	}
 
 	return lipgloss.NewStyle().Width(contentWidth).
 		Render(lipgloss.JoinVertical(lipgloss.Left, fileHeader, reviewHeader, content))
@@ -1018,21 +1458,28 @@ func (m *DiffViewModel) renderSplitContent() string {
 
 func (m *DiffViewModel) renderReviewHeader(width int) string {
 	t := m.styles.Theme
+	if m.readOnly {
+		return truncateANSIWidth(lipgloss.NewStyle().Foreground(t.Muted).Render(m.scope), width)
+	}
 	if m.reviewContext == nil {
 		return lipgloss.NewStyle().Foreground(t.Muted).Render("Review context loading...")
 	}
 
 	currentPath := m.CurrentFilePath()
 	file, ok := m.reviewContext.FindFile(currentPath)
-	fileState := "unviewed"
+	fileState, hunkState := "unviewed", ""
 	if ok {
 		fileState = reviewFileStateText(file)
+		if hunk := m.currentHunk(); hunk >= 0 && hunk < len(file.Hunks) && len(file.Hunks) > 1 {
+			hunkState = fmt.Sprintf("hunk %d/%d: %s   ", hunk+1, len(file.Hunks), reviewHunkStateText(file.Hunks[hunk]))
+		}
 	}
 
-	line := fmt.Sprintf("scope: %s   progress: %d/%d viewed   file: %s   V viewed   u next",
+	line := fmt.Sprintf("scope: %s   progress: %d/%d hunks viewed   %sfile: %s   V viewed   u next",
 		m.reviewContext.Scope.Label(),
-		m.reviewContext.ViewedFiles,
-		m.reviewContext.TotalFiles,
+		m.reviewContext.ViewedHunks,
+		m.reviewContext.TotalHunks,
+		hunkState,
 		fileState,
 	)
 	return truncateANSIWidth(lipgloss.NewStyle().Foreground(t.Muted).Render(line), width)
@@ -1049,6 +1496,19 @@ func (m *DiffViewModel) reviewMarkerForPath(path string) string {
 	return reviewFileMarker(file)
 }
 
+// hunkMarker returns the viewed marker shown before a hunk header, or ""
+// outside incremental review.
+func (m *DiffViewModel) hunkMarker(path string, hunk int) string {
+	if m.reviewContext == nil || m.readOnly {
+		return ""
+	}
+	file, ok := m.reviewContext.FindFile(path)
+	if !ok || hunk >= len(file.Hunks) {
+		return ""
+	}
+	return reviewHunkMarker(file.Hunks[hunk]) + " "
+}
+
 func (m *DiffViewModel) splitLineStyle(lineType domain.DiffLineType) lipgloss.Style {
 	switch lineType {
 	case domain.DiffAdd:
@@ -1060,8 +1520,8 @@ func (m *DiffViewModel) splitLineStyle(lineType domain.DiffLineType) lipgloss.St
 	}
 }
 
-func (m *DiffViewModel) renderSplitHalf(num, text string, style lipgloss.Style, numW, colW int) string {
-	numStr := fmt.Sprintf("%*s ", numW, num)
+func (m *DiffViewModel) renderSplitHalf(num, text string, style lipgloss.Style, numW, colW int, selected bool) string {
+	numStr := selectionMark(fmt.Sprintf("%*s ", numW, num), selected)
 	maxText := colW - numW - 2
 	if maxText < 0 {
 		maxText = 0
@@ -1075,11 +1535,12 @@ func (m *DiffViewModel) renderSplitHalf(num, text string, style lipgloss.Style,
 func (m *DiffViewModel) buildSplitRows(file domain.FileDiff) []splitRow {
 	var rows []splitRow
 
-	for _, hunk := range file.Hunks {
+	for hunkIdx, hunk := range file.Hunks {
 		// Hunk header spans both sides.
 		rows = append(rows, splitRow{
-			leftText: hunk.Header, leftType: domain.DiffContext,
+			leftText: m.hunkMarker(file.Path, hunkIdx) + hunk.Header, leftType: domain.DiffContext,
 			rightText: hunk.Header, rightType: domain.DiffContext,
+			hunk: hunkIdx,
 		})
 
 		// Pair up deletions and additions within the hunk.
@@ -1087,16 +1548,18 @@ func (m *DiffViewModel) buildSplitRows(file domain.FileDiff) []splitRow {
 		flushPairs := func() {
 			maxLen := max(len(delBuf), len(addBuf))
 			for i := range maxLen {
-				var row splitRow
+				row := splitRow{hunk: hunkIdx, isLine: true}
 				if i < len(delBuf) {
 					row.leftNum = fmt.Sprintf("%d", delBuf[i].OldNum)
 					row.leftText = delBuf[i].Content
 					row.leftType = domain.DiffDelete
+					row.line = delBuf[i]
 				}
 				if i < len(addBuf) {
 					row.rightNum = fmt.Sprintf("%d", addBuf[i].NewNum)
 					row.rightText = addBuf[i].Content
 					row.rightType = domain.DiffAdd
+					row.line = addBuf[i]
 				}
 				rows = append(rows, row)
 			}
@@ -1116,6 +1579,7 @@ func (m *DiffViewModel) buildSplitRows(file domain.FileDiff) []splitRow {
 				rows = append(rows, splitRow{
 					leftNum: fmt.Sprintf("%d", dl.OldNum), leftText: dl.Content, leftType: domain.DiffContext,
 					rightNum: fmt.Sprintf("%d", dl.NewNum), rightText: dl.Content, rightType: domain.DiffContext,
+					hunk: hunkIdx, line: dl, isLine: true,
 				})
 			}
 		}
@@ -1219,8 +1683,7 @@ func (m *DiffViewModel) jumpToMatch(idx int) {
 	match := m.searchMatches[idx]
 	m.currentMatch = idx
 	if match.fileIdx != m.fileIdx {
-		m.fileIdx = match.fileIdx
-		m.scrollY = 0
+		m.showFile(match.fileIdx)
 	}
 
 	lineCount := m.fileLineCount(m.fileIdx)
@@ -1287,20 +1750,26 @@ func (m *DiffViewModel) searchBarText() string {
 	return fmt.Sprintf("/ %s [%d/%d]▎", m.searchQuery, current, count)
 }
 
+// showFile switches to file idx at its top. A line selection cannot span
+// files, so it ends.
+func (m *DiffViewModel) showFile(idx int) {
+	m.fileIdx = idx
+	m.scrollY = 0
+	m.selecting = false
+}
+
 func (m *DiffViewModel) nextFile() {
 	if m.diff == nil || m.fileIdx >= len(m.diff.Files)-1 {
 		return
 	}
-	m.fileIdx++
-	m.scrollY = 0
+	m.showFile(m.fileIdx + 1)
 }
 
 func (m *DiffViewModel) prevFile() {
 	if m.diff == nil || m.fileIdx <= 0 {
 		return
 	}
-	m.fileIdx--
-	m.scrollY = 0
+	m.showFile(m.fileIdx - 1)
 }
 
 func (m *DiffViewModel) scrollToTop() {
@@ -2,6 +2,7 @@ package views
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -304,9 +305,68 @@ func TestDiffToggleViewedKey(t *testing.T) {
 
 	cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'V'}})
 	require.NotNil(t, cmd)
-	msg, ok := cmd().(ToggleViewedFileMsg)
+	msg, ok := cmd().(ToggleViewedHunkMsg)
 	require.True(t, ok)
-	assert.Equal(t, "internal/plugin/registry.go", msg.Path)
+	assert.Equal(t, ToggleViewedHunkMsg{Path: "internal/plugin/registry.go", Hunk: 0}, msg)
+}
+
+func TestDiffHunkViewedState(t *testing.T) {
+	m := NewDiffViewModel(testStyles(), testKeys())
+	m.SetSize(120, 24)
+	m.SetDiff(testDiffWithHunks())
+	m.SetReviewContext(&reviewprogress.Context{
+		Scope:       reviewprogress.ScopeUnviewed,
+		ViewedHunks: 1,
+		TotalHunks:  2,
+		Files: []reviewprogress.File{{
+			Path:        "internal/plugin/registry.go",
+			Hunks:       []reviewprogress.Hunk{{Digest: "h1", Viewed: true}, {Digest: "h2"}},
+			ViewedHunks: 1,
+			Actionable:  true,
+		}},
+	})
+
+	view := m.View()
+	assert.Contains(t, view, "1/2 hunks viewed")
+	assert.Contains(t, view, "✓ @@ -1,1 +1,1 @@")
+	assert.Contains(t, view, "● @@ -10,1 +10,1 @@")
+
+	m.JumpToHunk("internal/plugin/registry.go", 1)
+	assert.Equal(t, 2, m.scrollY)
+	assert.Contains(t, m.View(), "hunk 2/2: unviewed")
+
+	cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'V'}})
+	require.NotNil(t, cmd)
+	assert.Equal(t, ToggleViewedHunkMsg{Path: "internal/plugin/registry.go", Hunk: 1}, cmd())
+
+	cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	require.NotNil(t, cmd)
+	assert.Equal(t, JumpNextReviewTargetMsg{CurrentPath: "internal/plugin/registry.go", Hunk: 1}, cmd())
+}
+
+func TestDiffInterdiffKey(t *testing.T) {
+	m := NewDiffViewModel(testStyles(), testKeys())
+	m.SetSize(120, 24)
+	m.SetDiff(testDiff())
+	assert.Nil(t, m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'I'}}), "no PR, no interdiff")
+
+	m.SetPRNumber(42)
+	cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'I'}})
+	require.NotNil(t, cmd)
+	assert.Equal(t, ToggleInterdiffMsg{Number: 42}, cmd())
+}
+
+func TestDiffReadOnlyShowsScope(t *testing.T) {
+	m := NewDiffViewModel(testStyles(), testKeys())
+	m.SetSize(120, 24)
+	m.SetReadOnly(true)
+	m.SetScope("commit abc1234 · Fix lint")
+	m.SetDiff(testDiff())
+
+	view := m.View()
+	assert.Contains(t, view, "commit abc1234 · Fix lint")
+	assert.NotContains(t, view, "Review context loading")
+	assert.Nil(t, m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'V'}}))
 }
 
 func TestDiffJumpNextActionableKey(t *testing.T) {
@@ -863,13 +923,62 @@ func TestDiffCommentEditorSubmit(t *testing.T) {
 	cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
 	assert.False(t, m.editing, "expected editing to end after Ctrl+S")
 
-	// Should produce an AddInlineCommentMsg command.
+	// New comments are held for the review, not posted.
 	require.NotNil(t, cmd, "expected a command after Ctrl+S")
 	msg := cmd()
-	addMsg, ok := msg.(AddInlineCommentMsg)
-	require.True(t, ok, "expected AddInlineCommentMsg, got %T", msg)
-	assert.Equal(t, 42, addMsg.Number)
-	assert.Equal(t, "test", addMsg.Input.Body)
+	changed, ok := msg.(PendingCommentsChangedMsg)
+	require.True(t, ok, "expected PendingCommentsChangedMsg, got %T", msg)
+	assert.Equal(t, 42, changed.Number)
+	require.Len(t, changed.Comments, 1)
+	assert.Equal(t, "test", changed.Comments[0].Body)
+	assert.Equal(t, changed.Comments, m.PendingComments())
+	assert.Contains(t, m.View(), "● pending")
+	assert.Contains(t, m.View(), "1 pending comment")
+}
+
+func TestDiffPendingCommentEditAndDiscard(t *testing.T) {
+	m := NewDiffViewModel(testStyles(), testKeys())
+	m.SetSize(120, 40)
+	m.SetDiff(testDiff())
+	m.SetPRNumber(42)
+	m.scrollY = 1
+	path, line, side := m.currentDiffLine()
+	m.SetPendingComments([]domain.InlineCommentInput{{Path: path, Line: line, Side: side, Body: "old"}})
+
+	// c on a pending comment edits it.
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	assert.Equal(t, "old", m.editBuffer)
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'!'}})
+	cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	require.NotNil(t, cmd)
+	changed, ok := cmd().(PendingCommentsChangedMsg)
+	require.True(t, ok)
+	require.Len(t, changed.Comments, 1)
+	assert.Equal(t, "old!", changed.Comments[0].Body)
+
+	// D discards it.
+	cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}})
+	require.NotNil(t, cmd)
+	changed, ok = cmd().(PendingCommentsChangedMsg)
+	require.True(t, ok)
+	assert.Empty(t, changed.Comments)
+	assert.Nil(t, m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}}), "nothing left to discard")
+}
+
+func TestDiffPendingCommentClearedDeletes(t *testing.T) {
+	m := NewDiffViewModel(testStyles(), testKeys())
+	m.SetSize(120, 40)
+	m.SetDiff(testDiff())
+	m.scrollY = 1
+	path, line, side := m.currentDiffLine()
+	m.SetPendingComments([]domain.InlineCommentInput{{Path: path, Line: line, Side: side, Body: "ab"}})
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	require.NotNil(t, cmd)
+	assert.Empty(t, m.PendingComments())
 }
 
 func TestDiffCommentEditorEmptySubmit(t *testing.T) {
@@ -914,6 +1023,15 @@ func TestDiffCommentReply(t *testing.T) {
 
 	assert.True(t, m.editing, "expected editing mode for reply")
 	assert.Equal(t, "comment-1", m.editReplyTo)
+
+	// Replies post right away instead of waiting for the review.
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+', '1'}})
+	cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	require.NotNil(t, cmd)
+	addMsg, ok := cmd().(AddInlineCommentMsg)
+	require.True(t, ok)
+	assert.Equal(t, "comment-1", addMsg.Input.InReplyTo)
+	assert.Empty(t, m.PendingComments())
 }
 
 func TestDiffCommentResolve(t *testing.T) {
@@ -947,6 +1065,30 @@ func TestDiffCommentResolve(t *testing.T) {
 	assert.Equal(t, "PRRT_thread_1", resolveMsg.ThreadID)
 }
 
+func TestDiffCommentUnresolve(t *testing.T) {
+	m := NewDiffViewModel(testStyles(), testKeys())
+	m.SetSize(120, 40)
+	m.SetDiff(testDiff())
+	thread := domain.CommentThread{
+		ID: "t1", ThreadID: "PRRT_thread_1",
+		Path: "internal/plugin/registry.go", Line: 11,
+		Comments: []domain.Comment{{Author: "alice", Body: "looks good"}},
+	}
+	m.SetComments([]domain.CommentThread{thread})
+	m.scrollY = 2
+
+	X := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'X'}}
+	assert.Nil(t, m.Update(X), "an open thread cannot be unresolved")
+
+	thread.Resolved = true
+	m.SetComments([]domain.CommentThread{thread})
+	cmd := m.Update(X)
+	require.NotNil(t, cmd)
+	unresolve, ok := cmd().(UnresolveThreadMsg)
+	require.True(t, ok)
+	assert.Equal(t, "PRRT_thread_1", unresolve.ThreadID)
+}
+
 func TestDiffCommentEditorBackspace(t *testing.T) {
 	m := NewDiffViewModel(testStyles(), testKeys())
 	m.SetSize(120, 40)
@@ -1215,3 +1357,219 @@ func TestDiffSpinnerViewRendering(t *testing.T) {
 	assert.NotEmpty(t, view, "spinner view should not be empty")
 	assert.Contains(t, view, "Loading diff", "should show loading text with spinner")
 }
+
+func TestDiffRangeComment(t *testing.T) {
+	m := NewDiffViewModel(testStyles(), testKeys())
+	m.SetSize(120, 40)
+	m.SetDiff(testDiff())
+	m.SetPRNumber(42)
+
+	// Select from the context line at 10 to the added line at 12.
+	m.scrollY = 1
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	assert.True(t, m.IsInputActive(), "a selection takes keys")
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	assert.Contains(t, m.View(), "lines 10–12")
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	require.True(t, m.editing)
+	assert.False(t, m.selecting, "commenting ends the selection")
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("why?")})
+	cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	require.NotNil(t, cmd)
+	changed, ok := cmd().(PendingCommentsChangedMsg)
+	require.True(t, ok)
+	require.Len(t, changed.Comments, 1)
+	c := changed.Comments[0]
+	assert.Equal(t, 10, c.StartLine)
+	assert.Equal(t, "RIGHT", c.StartSide)
+	assert.Equal(t, 12, c.Line)
+	assert.Equal(t, "RIGHT", c.Side)
+	assert.True(t, c.IsRange())
+}
+
+func TestDiffSuggestChange(t *testing.T) {
+	m := NewDiffViewModel(testStyles(), testKeys())
+	m.SetSize(120, 40)
+	m.SetDiff(testDiff())
+
+	// The selection spans a deleted line, which a suggestion leaves out.
+	m.scrollY = 1
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	m.scrollY = 4
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}})
+	require.True(t, m.editing)
+	assert.Equal(t, "```suggestion\nimport (\n\t\"sync\"\n\t\"fmt\"\n```", m.editBuffer)
+	assert.Equal(t, 10, m.editStartLine)
+	assert.Equal(t, 12, m.editLine)
+
+	// A deleted line cannot be suggested on.
+	m.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	m.scrollY = 2
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}})
+	assert.False(t, m.editing)
+}
+
+func TestDiffSelectionStaysInHunk(t *testing.T) {
+	m := NewDiffViewModel(testStyles(), testKeys())
+	m.SetSize(120, 40)
+	m.SetDiff(testDiffWithHunks())
+
+	m.scrollY = 1
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	m.scrollY = 3
+	assert.Contains(t, m.View(), "within one hunk")
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	assert.False(t, m.editing, "a range across hunks cannot be commented on")
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	assert.False(t, m.IsInputActive())
+}
+
+func TestDiffRangeSelectionInSplitMode(t *testing.T) {
+	m := NewDiffViewModel(testStyles(), testKeys())
+	m.SetSize(120, 40)
+	m.SetDiff(testDiff())
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	require.True(t, m.IsSplitMode())
+	marks := strings.Count(m.View(), "▌")
+
+	// Rows 1–3 show line 10, line 11 paired with old 11, and line 12.
+	// Selecting upwards keeps them all on screen.
+	m.scrollY = 3
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	m.scrollY = 1
+	view := m.View()
+	assert.Contains(t, view, "lines 10–12")
+	assert.Equal(t, marks+3, strings.Count(view, "▌"), "selected rows are marked")
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	require.True(t, m.editing)
+	assert.Equal(t, 10, m.editStartLine)
+	assert.Equal(t, 12, m.editLine)
+}
+
+func TestDiffSelectionEndsOnFileOrModeChange(t *testing.T) {
+	m := NewDiffViewModel(testStyles(), testKeys())
+	m.SetSize(120, 40)
+	m.SetDiff(testDiff())
+
+	m.scrollY = 1
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	m.nextFile()
+	assert.False(t, m.selecting, "a selection cannot span files")
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	assert.False(t, m.selecting, "rows differ between unified and split mode")
+}
+
+func TestDiffRangeThreadRendersSpan(t *testing.T) {
+	m := NewDiffViewModel(testStyles(), testKeys())
+	m.SetSize(120, 40)
+	m.SetDiff(testDiff())
+	m.SetComments([]domain.CommentThread{{
+		ID: "t1", Path: "internal/plugin/registry.go", StartLine: 10, Line: 12,
+		Comments: []domain.Comment{{Author: "alice", Body: "extract this"}},
+	}})
+
+	assert.Contains(t, m.View(), "lines 10–12")
+}
+
+func TestDiffCommentInExternalEditor(t *testing.T) {
+	m := NewDiffViewModel(testStyles(), testKeys())
+	m.SetSize(120, 40)
+	m.SetDiff(testDiff())
+	m.SetPRNumber(42)
+
+	// Comment on the second added line (header=0, context=1, delete=2, add=3, add=4).
+	m.scrollY = 4
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("draft")})
+
+	// Ctrl+E hands the draft and the hunk up to the line to $EDITOR.
+	cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlE})
+	require.NotNil(t, cmd)
+	open, ok := cmd().(OpenEditorMsg)
+	require.True(t, ok)
+	assert.Equal(t, "draft", open.Body)
+	assert.Equal(t, "internal/plugin/registry.go\n@@ -10,5 +10,8 @@\n import (\n-\t\"sync\"\n+\t\"sync\"\n+\t\"fmt\"", open.Context)
+
+	// The text comes back as a preview that only posts or goes back.
+	m.Update(EditorDoneMsg{Body: "Use **errors.Join** here."})
+	assert.True(t, m.editPreview)
+	assert.Contains(t, m.View(), "Esc back to editing")
+	assert.Nil(t, m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}}))
+	assert.Equal(t, "Use **errors.Join** here.", m.editBuffer)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	assert.True(t, m.editing, "Esc in the preview goes back to editing")
+	assert.False(t, m.editPreview)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	cmd = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	require.NotNil(t, cmd)
+	changed, ok := cmd().(PendingCommentsChangedMsg)
+	require.True(t, ok)
+	require.Len(t, changed.Comments, 1)
+	assert.Equal(t, "Use **errors.Join** here.", changed.Comments[0].Body)
+	assert.Equal(t, 12, changed.Comments[0].Line)
+}
+
+func TestDiffReplyInExternalEditorQuotesThread(t *testing.T) {
+	m := NewDiffViewModel(testStyles(), testKeys())
+	m.SetSize(120, 40)
+	m.SetDiff(testDiff())
+	m.SetComments([]domain.CommentThread{{
+		ID: "t1", ReplyToID: "comment-1", Path: "internal/plugin/registry.go", Line: 11,
+		Comments: []domain.Comment{{Author: "alice", Body: "looks good"}},
+	}})
+	m.scrollY = 2
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+
+	cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlE})
+	require.NotNil(t, cmd)
+	open, ok := cmd().(OpenEditorMsg)
+	require.True(t, ok)
+	assert.Equal(t, "@alice wrote:\n> looks good", open.Context)
+
+	// An editor that failed leaves the draft alone.
+	m.Update(EditorDoneMsg{Err: assert.AnError})
+	assert.True(t, m.editing)
+	assert.False(t, m.editPreview)
+}
+
+func TestDiffCommentInsertsSnippet(t *testing.T) {
+	m := NewDiffViewModel(testStyles(), testKeys())
+	m.SetSize(120, 40)
+	m.SetDiff(testDiff())
+	m.SetPRNumber(42)
+	m.SetPRAuthor("octocat")
+	m.SetSnippets([]domain.Snippet{
+		{Name: "nit", Body: "nit: not blocking"},
+		{Name: "where", Body: "@{{author}}, see {{file}}:{{line}} in #{{number}}"},
+	})
+
+	m.scrollY = 4
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("hi")})
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	require.NotNil(t, m.picker)
+	assert.Contains(t, m.View(), "Snippets:")
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("whr")})
+	require.Len(t, m.picker.matches, 1)
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	assert.Nil(t, m.picker)
+	assert.True(t, m.editing, "picking a snippet keeps the comment open")
+	assert.Equal(t, "hi @octocat, see internal/plugin/registry.go:12 in #42", m.editBuffer)
+
+	// Esc closes the picker without touching the draft.
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	m.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	assert.Nil(t, m.picker)
+	assert.True(t, m.editing)
+}
@@ -16,14 +16,16 @@ type HelpModel struct {
 	width   int
 	height  int
 	styles  core.Styles
+	keys    core.KeyMap
 }
 
 // SetStyles updates the styles without losing state.
 func (m *HelpModel) SetStyles(s core.Styles) { m.styles = s }
 
-// NewHelpModel creates a new help overlay.
-func NewHelpModel(styles core.Styles) HelpModel {
-	return HelpModel{styles: styles}
+// NewHelpModel creates a new help overlay. Rebindable actions are listed
+// under the keys they are bound to.
+func NewHelpModel(styles core.Styles, keys core.KeyMap) HelpModel {
+	return HelpModel{styles: styles, keys: keys}
 }
 
 // SetSize updates the overlay dimensions.
@@ -178,7 +180,9 @@ func (m *HelpModel) bindings() (left, right []helpSection) {
 				title: "Actions",
 				bindings: []helpBinding{
 					{"Enter", "Open PR detail"},
-					{"c", "Checkout branch"},
+					{m.keys.Checkout.Help().Key, "Checkout branch"},
+					{m.keys.Merge.Help().Key, "Merge PR"},
+					{"N", "New PR from branch"},
 					{"o", "Open in browser"},
 					{"y", "Copy PR URL"},
 					{"I", "Toggle inbox"},
@@ -195,6 +199,15 @@ func (m *HelpModel) bindings() (left, right []helpSection) {
 					{"j/k", "Scroll up/down"},
 					{"Tab", "Next pane"},
 					{"Shift+Tab", "Previous pane"},
+					{"1-5", "Jump to tab"},
+				},
+			},
+			{
+				title: "Commits",
+				bindings: []helpBinding{
+					{"Enter", "Diff commit or range"},
+					{"v", "Mark / clear range"},
+					{"o", "Open commit in browser"},
 				},
 			},
 		}
@@ -207,12 +220,34 @@ func (m *HelpModel) bindings() (left, right []helpSection) {
 					{"V", "Toggle viewed file"},
 					{"d", "Open diff"},
 					{"Enter", "Open diff (Files)"},
-					{"c", "Checkout branch"},
+					{m.keys.Checkout.Help().Key, "Checkout branch"},
+					{m.keys.Merge.Help().Key, "Merge PR"},
+					{m.keys.EditLabels.Help().Key, "Edit labels"},
+					{m.keys.EditAssignees.Help().Key, "Edit assignees"},
+					{m.keys.EditReviewers.Help().Key, "Request reviewers"},
+					{m.keys.ClosePR.Help().Key, "Close / reopen PR"},
+					{m.keys.ToggleDraft.Help().Key, "Toggle draft / ready"},
+					{m.keys.UpdateBranch.Help().Key, "Update branch from base"},
 					{"o", "Open in browser"},
 					{"r", "Submit review"},
 					{"Esc", "Back to list"},
 				},
 			},
+			{
+				title: "Comments",
+				bindings: []helpBinding{
+					{"n", "New comment"},
+					{"r", "Reply (quotes comments)"},
+					{"e", "Edit your comment"},
+					{"D", "Delete your comment"},
+					{"+", "Toggle reaction"},
+					{"x/X", "Resolve / unresolve"},
+					{"Ctrl+S", "Post from composer"},
+					{"Ctrl+E", "Compose in $EDITOR"},
+					{"Ctrl+P", "Preview markdown"},
+					{"Ctrl+T", "Insert snippet"},
+				},
+			},
 			global,
 		}
 
@@ -235,14 +270,21 @@ func (m *HelpModel) bindings() (left, right []helpSection) {
 				title: "Actions",
 				bindings: []helpBinding{
 					{"i", "Cycle review scope"},
-					{"u", "Jump next review target"},
-					{"V", "Toggle viewed file"},
+					{"u", "Jump next unviewed hunk"},
+					{"V", "Toggle viewed hunk"},
+					{"I", "Interdiff since visit/review"},
 					{"Enter", "Select file (in tree)"},
 					{"t", "Toggle unified/split"},
 					{"/", "Search in diff"},
-					{"c", "Add inline comment"},
+					{"c", "Add/edit pending comment"},
+					{"D", "Discard pending comment"},
+					{"v", "Select line range"},
+					{"S", "Suggest change"},
+					{"Ctrl+E", "Comment in $EDITOR"},
+					{"Ctrl+T", "Insert snippet"},
 					{"r", "Reply to thread"},
 					{"x", "Resolve thread"},
+					{"X", "Unresolve thread"},
 					{"e", "External diff tool"},
 					{"za", "Toggle collapse"},
 					{"Esc", "Back to detail"},
@@ -258,6 +300,8 @@ func (m *HelpModel) bindings() (left, right []helpSection) {
 				bindings: []helpBinding{
 					{"j/k", "Move between fields"},
 					{"Enter", "Cycle action / edit / submit"},
+					{"Ctrl+E", "Write body in $EDITOR"},
+					{"Ctrl+T", "Insert snippet"},
 					{"Esc", "Stop editing body"},
 				},
 			},
@@ -11,26 +11,26 @@ import (
 )
 
 func TestNewHelpModel(t *testing.T) {
-	m := NewHelpModel(testStyles())
+	m := NewHelpModel(testStyles(), testKeys())
 	// Default context is ViewBanner (iota 0), but that's fine - context gets set when help opens
 	assert.Equal(t, core.ViewBanner, m.context, "default context should be ViewBanner")
 }
 
 func TestHelpSetSize(t *testing.T) {
-	m := NewHelpModel(testStyles())
+	m := NewHelpModel(testStyles(), testKeys())
 	m.SetSize(120, 40)
 	assert.Equal(t, 120, m.width)
 	assert.Equal(t, 40, m.height)
 }
 
 func TestHelpSetContext(t *testing.T) {
-	m := NewHelpModel(testStyles())
+	m := NewHelpModel(testStyles(), testKeys())
 	m.SetContext(core.ViewPRList)
 	assert.Equal(t, core.ViewPRList, m.context)
 }
 
 func TestHelpEscClose(t *testing.T) {
-	m := NewHelpModel(testStyles())
+	m := NewHelpModel(testStyles(), testKeys())
 	m.SetSize(120, 40)
 	m.SetContext(core.ViewPRList)
 
@@ -42,7 +42,7 @@ func TestHelpEscClose(t *testing.T) {
 }
 
 func TestHelpQuestionMarkClose(t *testing.T) {
-	m := NewHelpModel(testStyles())
+	m := NewHelpModel(testStyles(), testKeys())
 	m.SetSize(120, 40)
 
 	cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'?'}})
@@ -53,7 +53,7 @@ func TestHelpQuestionMarkClose(t *testing.T) {
 }
 
 func TestHelpOtherKeysIgnored(t *testing.T) {
-	m := NewHelpModel(testStyles())
+	m := NewHelpModel(testStyles(), testKeys())
 	m.SetSize(120, 40)
 
 	cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
@@ -61,13 +61,13 @@ func TestHelpOtherKeysIgnored(t *testing.T) {
 }
 
 func TestHelpNonKeyMsg(t *testing.T) {
-	m := NewHelpModel(testStyles())
+	m := NewHelpModel(testStyles(), testKeys())
 	cmd := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
 	assert.Nil(t, cmd, "non-key messages should return nil cmd")
 }
 
 func TestHelpViewPRList(t *testing.T) {
-	m := NewHelpModel(testStyles())
+	m := NewHelpModel(testStyles(), testKeys())
 	m.SetSize(120, 40)
 	m.SetContext(core.ViewPRList)
 
@@ -76,7 +76,7 @@ func TestHelpViewPRList(t *testing.T) {
 }
 
 func TestHelpViewPRDetail(t *testing.T) {
-	m := NewHelpModel(testStyles())
+	m := NewHelpModel(testStyles(), testKeys())
 	m.SetSize(120, 40)
 	m.SetContext(core.ViewPRDetail)
 
@@ -85,7 +85,7 @@ func TestHelpViewPRDetail(t *testing.T) {
 }
 
 func TestHelpViewDiff(t *testing.T) {
-	m := NewHelpModel(testStyles())
+	m := NewHelpModel(testStyles(), testKeys())
 	m.SetSize(120, 40)
 	m.SetContext(core.ViewDiff)
 
@@ -94,7 +94,7 @@ func TestHelpViewDiff(t *testing.T) {
 }
 
 func TestHelpViewReview(t *testing.T) {
-	m := NewHelpModel(testStyles())
+	m := NewHelpModel(testStyles(), testKeys())
 	m.SetSize(120, 40)
 	m.SetContext(core.ViewReview)
 
@@ -103,7 +103,7 @@ func TestHelpViewReview(t *testing.T) {
 }
 
 func TestHelpViewInbox(t *testing.T) {
-	m := NewHelpModel(testStyles())
+	m := NewHelpModel(testStyles(), testKeys())
 	m.SetSize(120, 40)
 	m.SetContext(core.ViewInbox)
 
@@ -112,7 +112,7 @@ func TestHelpViewInbox(t *testing.T) {
 }
 
 func TestHelpViewDefault(t *testing.T) {
-	m := NewHelpModel(testStyles())
+	m := NewHelpModel(testStyles(), testKeys())
 	m.SetSize(120, 40)
 	m.SetContext(core.ViewLoading)
 
@@ -121,7 +121,7 @@ func TestHelpViewDefault(t *testing.T) {
 }
 
 func TestHelpViewSmall(t *testing.T) {
-	m := NewHelpModel(testStyles())
+	m := NewHelpModel(testStyles(), testKeys())
 	m.SetSize(40, 15) // Small terminal.
 	m.SetContext(core.ViewPRList)
 
@@ -164,3 +164,16 @@ func TestStatusHintsFilterActions(t *testing.T) {
 	assert.Contains(t, hints, "a accept")
 	assert.Contains(t, hints, "c cancel")
 }
+
+func TestHelpShowsReboundKeys(t *testing.T) {
+	keys := testKeys()
+	keys.ApplyOverrides(map[string]string{"edit_labels": "ctrl+l", "merge": "ctrl+g", "update_branch": "ctrl+b"})
+	m := NewHelpModel(testStyles(), keys)
+	m.SetSize(120, 50)
+	m.SetContext(core.ViewPRDetail)
+
+	view := m.View()
+	assert.Contains(t, view, "ctrl+l")
+	assert.Contains(t, view, "ctrl+g")
+	assert.Contains(t, view, "ctrl+b")
+}
@@ -78,8 +78,11 @@ func (m *InboxModel) SetPRs(prs []InboxPR) {
 
 // Message types.
 type (
-	InboxPRsLoadedMsg struct{ PRs []InboxPR }
-	OpenInboxPRMsg    struct {
+	InboxPRsLoadedMsg struct {
+		PRs []InboxPR
+		Err error
+	}
+	OpenInboxPRMsg struct {
 		Repo   domain.RepoRef
 		Number int
 	}
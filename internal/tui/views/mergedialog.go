@@ -0,0 +1,457 @@
+package views
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/tui/core"
+)
+
+// mergeDialogState tracks which phase the merge dialog is in.
+type mergeDialogState int
+
+const (
+	mergeInactive mergeDialogState = iota
+	mergeLoading                   // fetching the merge status
+	mergeBlocked                   // merging is refused; shows why
+	mergeOptions                   // choosing method, commit message and branch deletion
+	mergeMerging                   // merge request running
+	mergeError                     // loading the status or merging failed
+)
+
+// mergeMethodLabels names the merge methods the way GitHub's merge button
+// does.
+var mergeMethodLabels = map[string]string{
+	domain.MergeMethodMerge:  "Create a merge commit",
+	domain.MergeMethodSquash: "Squash and merge",
+	domain.MergeMethodRebase: "Rebase and merge",
+}
+
+// MergeDialogModel shows whether a PR can be merged and collects the merge
+// options: method, commit title and message, and branch deletion. When the
+// PR only waits for required checks or a review, it offers auto-merge.
+type MergeDialogModel struct {
+	state  mergeDialogState
+	styles core.Styles
+	width  int
+	height int
+
+	pr           domain.PR
+	status       *domain.MergeStatus
+	err          error
+	spinnerFrame int
+
+	form         *huh.Form
+	method       string
+	title        string
+	body         string
+	deleteBranch bool
+	confirm      bool
+}
+
+// Merge dialog messages.
+
+// MergePRMsg asks to open the merge dialog for a PR.
+type MergePRMsg struct {
+	PR domain.PR
+}
+
+// MergeStatusLoadedMsg carries the merge status fetched for the dialog.
+type MergeStatusLoadedMsg struct {
+	Number int
+	Status *domain.MergeStatus
+	Err    error
+}
+
+// SubmitMergeMsg is sent when the user confirms the merge.
+type SubmitMergeMsg struct {
+	Number int
+	Opts   domain.MergeOpts
+}
+
+// MergeDoneMsg is sent after a merge, or enabling auto-merge, completes.
+type MergeDoneMsg struct {
+	Number int
+	Auto   bool
+	Err    error
+}
+
+// MergeDialogCloseMsg is sent when the merge dialog should close.
+type MergeDialogCloseMsg struct{}
+
+// mergeDialogSpinnerTick drives the loading spinner.
+type mergeDialogSpinnerTick struct{}
+
+// NewMergeDialogModel creates a new merge dialog.
+func NewMergeDialogModel(styles core.Styles) MergeDialogModel {
+	return MergeDialogModel{styles: styles}
+}
+
+// SetStyles updates styles without losing state.
+func (m *MergeDialogModel) SetStyles(s core.Styles) { m.styles = s }
+
+// SetSize updates dimensions.
+func (m *MergeDialogModel) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+	if m.form != nil {
+		m.form.WithWidth(m.boxWidth() - 6)
+	}
+}
+
+// Active returns whether the dialog is currently visible.
+func (m *MergeDialogModel) Active() bool { return m.state != mergeInactive }
+
+// GetPRNumber returns the PR number the dialog is handling.
+func (m *MergeDialogModel) GetPRNumber() int { return m.pr.Number }
+
+// Show opens the dialog for pr while its merge status loads.
+func (m *MergeDialogModel) Show(pr domain.PR) tea.Cmd {
+	m.reset()
+	m.state = mergeLoading
+	m.pr = pr
+	return m.spinnerTick()
+}
+
+// SetStatus shows the loaded merge status: the refusal reason when the PR
+// cannot be merged, or the merge options.
+func (m *MergeDialogModel) SetStatus(msg MergeStatusLoadedMsg) tea.Cmd {
+	if m.state != mergeLoading || msg.Number != m.pr.Number {
+		return nil
+	}
+	if msg.Err != nil {
+		m.ShowError(msg.Err)
+		return nil
+	}
+	m.status = msg.Status
+	if m.status.Blocker() != "" && !m.status.CanAutoMerge() || len(m.status.Methods) == 0 {
+		m.state = mergeBlocked
+		return nil
+	}
+	m.state = mergeOptions
+	m.method = m.status.Methods[0]
+	m.initForm()
+	return m.form.Init()
+}
+
+// ShowMerging shows the merge in progress.
+func (m *MergeDialogModel) ShowMerging() tea.Cmd {
+	m.state = mergeMerging
+	m.spinnerFrame = 0
+	return m.spinnerTick()
+}
+
+// ShowError shows a failed status load or merge.
+func (m *MergeDialogModel) ShowError(err error) {
+	m.state = mergeError
+	m.err = err
+}
+
+// Close hides the dialog.
+func (m *MergeDialogModel) Close() { m.reset() }
+
+func (m *MergeDialogModel) reset() {
+	*m = MergeDialogModel{styles: m.styles, width: m.width, height: m.height}
+}
+
+// auto reports whether confirming enables auto-merge instead of merging.
+func (m *MergeDialogModel) auto() bool {
+	return m.status != nil && m.status.Blocker() != ""
+}
+
+// initForm builds the merge options form.
+func (m *MergeDialogModel) initForm() {
+	options := make([]huh.Option[string], 0, len(m.status.Methods))
+	for _, method := range m.status.Methods {
+		options = append(options, huh.NewOption(mergeMethodLabels[method], method))
+	}
+	deleteDesc := "Delete " + m.pr.Branch.Head + " once merged"
+	if m.status.DeleteBranchOnMerge {
+		deleteDesc = "The repo deletes head branches after merging anyway"
+	}
+	confirmTitle, affirmative := "Merge PR #"+itoa(m.pr.Number)+"?", "Merge"
+	if m.auto() {
+		confirmTitle, affirmative = "Enable auto-merge?", "Enable auto-merge"
+	}
+
+	m.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Merge method").
+				Options(options...).
+				Value(&m.method),
+			huh.NewInput().
+				Title("Commit title").
+				DescriptionFunc(m.commitDescription, &m.method).
+				PlaceholderFunc(m.defaultTitle, &m.method).
+				CharLimit(256).
+				Value(&m.title),
+			huh.NewText().
+				Title("Commit message").
+				DescriptionFunc(m.commitDescription, &m.method).
+				Lines(3).
+				ExternalEditor(false).
+				Value(&m.body),
+			huh.NewConfirm().
+				Title("Delete branch?").
+				Description(deleteDesc).
+				Value(&m.deleteBranch),
+			huh.NewConfirm().
+				Title(confirmTitle).
+				Affirmative(affirmative).
+				Negative("Cancel").
+				Value(&m.confirm),
+		),
+	).WithShowHelp(false)
+	m.form.WithTheme(formTheme(m.styles))
+	m.form.WithWidth(m.boxWidth() - 6)
+}
+
+// defaultTitle is the commit title the host uses when none is given.
+func (m *MergeDialogModel) defaultTitle() string {
+	switch m.method {
+	case domain.MergeMethodSquash:
+		return fmt.Sprintf("%s (#%d)", m.pr.Title, m.pr.Number)
+	case domain.MergeMethodRebase:
+		return ""
+	default:
+		return fmt.Sprintf("Merge pull request #%d from %s", m.pr.Number, m.pr.Branch.Head)
+	}
+}
+
+func (m *MergeDialogModel) commitDescription() string {
+	if m.method == domain.MergeMethodRebase {
+		return "Not used when rebasing"
+	}
+	return "Leave empty for the default"
+}
+
+// Update handles messages for the merge dialog.
+func (m *MergeDialogModel) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case mergeDialogSpinnerTick:
+		if m.state == mergeLoading || m.state == mergeMerging {
+			m.spinnerFrame++
+			return m.spinnerTick()
+		}
+		return nil
+	case tea.KeyMsg:
+		switch m.state {
+		case mergeLoading, mergeMerging:
+			if msg.Type == tea.KeyEscape && m.state == mergeLoading {
+				return m.close()
+			}
+			return nil
+		case mergeBlocked, mergeError:
+			// Any key dismisses.
+			return m.close()
+		case mergeOptions:
+			if msg.Type == tea.KeyEscape {
+				return m.close()
+			}
+		}
+	}
+
+	if m.state != mergeOptions || m.form == nil {
+		return nil
+	}
+	model, cmd := m.form.Update(msg)
+	if f, ok := model.(*huh.Form); ok {
+		m.form = f
+	}
+	switch m.form.State {
+	case huh.StateCompleted:
+		if !m.confirm {
+			return m.close()
+		}
+		number, opts := m.pr.Number, m.opts()
+		return func() tea.Msg { return SubmitMergeMsg{Number: number, Opts: opts} }
+	case huh.StateAborted:
+		return m.close()
+	}
+	return cmd
+}
+
+// opts returns the chosen merge options.
+func (m *MergeDialogModel) opts() domain.MergeOpts {
+	opts := domain.MergeOpts{
+		Method:       m.method,
+		DeleteBranch: m.deleteBranch,
+		Auto:         m.auto(),
+	}
+	if m.method != domain.MergeMethodRebase {
+		opts.CommitTitle, opts.CommitMessage = m.title, m.body
+	}
+	return opts
+}
+
+func (m *MergeDialogModel) close() tea.Cmd {
+	m.reset()
+	return func() tea.Msg { return MergeDialogCloseMsg{} }
+}
+
+func (m *MergeDialogModel) spinnerTick() tea.Cmd {
+	return tea.Tick(80*time.Millisecond, func(time.Time) tea.Msg {
+		return mergeDialogSpinnerTick{}
+	})
+}
+
+// StatusHint returns status bar text for the current dialog state.
+func (m *MergeDialogModel) StatusHint() string {
+	switch m.state {
+	case mergeLoading:
+		return "Checking mergeability...   Esc cancel"
+	case mergeMerging:
+		return "Merging..."
+	case mergeBlocked, mergeError:
+		return "Press any key to continue"
+	default:
+		return "Tab/Enter next field   ←/→ choose   Esc cancel"
+	}
+}
+
+// View renders the dialog.
+func (m *MergeDialogModel) View() string {
+	t := m.styles.Theme
+	switch m.state {
+	case mergeLoading, mergeMerging:
+		text := "Checking whether PR #" + itoa(m.pr.Number) + " can be merged..."
+		if m.state == mergeMerging {
+			text = "Merging PR #" + itoa(m.pr.Number) + "..."
+		}
+		frame := spinnerFrames[m.spinnerFrame%len(spinnerFrames)]
+		spinner := lipgloss.NewStyle().Foreground(t.Primary).Bold(true).Render(frame)
+		return m.renderBox(lipgloss.JoinVertical(lipgloss.Left,
+			m.header(), "", spinner+" "+lipgloss.NewStyle().Foreground(t.Fg).Render(text),
+		), t.Primary)
+	case mergeBlocked:
+		reason := m.status.Blocker()
+		if reason == "" {
+			reason = "the repo allows no merge method"
+		}
+		icon := lipgloss.NewStyle().Foreground(t.Error).Bold(true).Render("✗ Cannot merge: ")
+		lines := []string{m.header(), ""}
+		lines = append(lines, m.statusLines()...)
+		lines = append(lines, "",
+			lipgloss.NewStyle().Width(m.boxWidth()-6).Render(icon+reason),
+			"", m.hint("Press any key to continue"))
+		return m.renderBox(lipgloss.JoinVertical(lipgloss.Left, lines...), t.Error)
+	case mergeError:
+		icon := lipgloss.NewStyle().Foreground(t.Error).Bold(true).Render("✗ Merge failed")
+		errMsg := "unknown error"
+		if m.err != nil {
+			errMsg = m.err.Error()
+		}
+		errText := lipgloss.NewStyle().Foreground(t.Fg).Width(m.boxWidth() - 6).Render(errMsg)
+		return m.renderBox(lipgloss.JoinVertical(lipgloss.Left,
+			m.header(), "", icon, errText, "", m.hint("Press any key to continue"),
+		), t.Error)
+	case mergeOptions:
+		lines := []string{m.header(), ""}
+		lines = append(lines, m.statusLines()...)
+		if m.auto() {
+			wait := lipgloss.NewStyle().Foreground(t.Warning).Width(m.boxWidth() - 6).
+				Render("● Waiting: " + m.status.Blocker() + ". Auto-merge merges the PR once that clears.")
+			lines = append(lines, "", wait)
+		}
+		lines = append(lines, "", m.form.View())
+		return m.renderBox(lipgloss.JoinVertical(lipgloss.Left, lines...), t.Primary)
+	}
+	return ""
+}
+
+// header renders the dialog title and the PR being merged.
+func (m *MergeDialogModel) header() string {
+	t := m.styles.Theme
+	title := lipgloss.NewStyle().Foreground(t.Primary).Bold(true).
+		Render(fmt.Sprintf("Merge PR #%d", m.pr.Number))
+	sub := lipgloss.NewStyle().Foreground(t.Fg).Width(m.boxWidth() - 6).Render(m.pr.Title)
+	branches := lipgloss.NewStyle().Foreground(t.Info).Render(m.pr.Branch.Head) +
+		lipgloss.NewStyle().Foreground(t.Muted).Render(" → ") +
+		lipgloss.NewStyle().Foreground(t.Info).Render(m.pr.Branch.Base)
+	return lipgloss.JoinVertical(lipgloss.Left, title, sub, branches)
+}
+
+// statusLines summarizes mergeability, the review decision and the
+// required checks.
+func (m *MergeDialogModel) statusLines() []string {
+	t := m.styles.Theme
+	s := m.status
+	label := lipgloss.NewStyle().Foreground(t.Subtext).Width(11)
+	good := lipgloss.NewStyle().Foreground(t.Success)
+	bad := lipgloss.NewStyle().Foreground(t.Error)
+	warn := lipgloss.NewStyle().Foreground(t.Warning)
+	muted := lipgloss.NewStyle().Foreground(t.Muted)
+
+	var conflicts string
+	switch s.Mergeable {
+	case domain.MergeableClean:
+		conflicts = good.Render("✓ no conflicts")
+	case domain.MergeableConflicting:
+		conflicts = bad.Render("✗ conflicts with " + m.pr.Branch.Base)
+	default:
+		conflicts = muted.Render("? not known yet")
+	}
+	if s.Behind {
+		conflicts += warn.Render(" · behind " + m.pr.Branch.Base)
+	}
+
+	var review string
+	switch s.Review {
+	case domain.ReviewApproved:
+		review = good.Render("✓ approved")
+	case domain.ReviewChangesRequested:
+		review = bad.Render("✗ changes requested")
+	case domain.ReviewPending:
+		review = warn.Render("● review required")
+	default:
+		review = muted.Render("— no review decision")
+	}
+
+	lines := []string{
+		label.Render("Mergeable") + conflicts,
+		label.Render("Review") + review,
+	}
+	required := s.RequiredChecks()
+	if len(required) == 0 {
+		return append(lines, label.Render("Checks")+muted.Render("no required checks"))
+	}
+	lines = append(lines, label.Render("Checks")+fmt.Sprintf("%d required", len(required)))
+	for _, c := range required {
+		style := muted
+		switch c.Status {
+		case domain.CIPass:
+			style = good
+		case domain.CIFail:
+			style = bad
+		case domain.CIPending:
+			style = warn
+		}
+		lines = append(lines, label.Render("")+style.Render(detailCIIcon(c.Status)+" "+c.Name))
+	}
+	return lines
+}
+
+func (m *MergeDialogModel) hint(text string) string {
+	return lipgloss.NewStyle().Foreground(m.styles.Theme.Muted).Italic(true).Render(text)
+}
+
+func (m *MergeDialogModel) boxWidth() int {
+	return max(40, min(72, m.width-4))
+}
+
+func (m *MergeDialogModel) renderBox(inner string, border lipgloss.TerminalColor) string {
+	box := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(border).
+		Padding(1, 2).
+		Width(m.boxWidth()).
+		Render(inner)
+	centered := lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+	return ensureExactHeight(centered, m.height, m.width)
+}
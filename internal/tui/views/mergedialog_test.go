@@ -0,0 +1,145 @@
+package views
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/tui/core"
+)
+
+func testMergeDialog() MergeDialogModel {
+	m := NewMergeDialogModel(core.NewStyles(core.ThemeByName("catppuccin-mocha")))
+	m.SetSize(100, 40)
+	return m
+}
+
+func testMergePR() domain.PR {
+	return domain.PR{
+		Number: 42,
+		Title:  "Add OAuth login",
+		Branch: domain.BranchInfo{Head: "feat/oauth", Base: "main"},
+	}
+}
+
+func cleanMergeStatus() *domain.MergeStatus {
+	return &domain.MergeStatus{
+		State:     domain.PRStateOpen,
+		Mergeable: domain.MergeableClean,
+		Review:    domain.ReviewApproved,
+		Checks:    []domain.Check{{Name: "build", Status: domain.CIPass, Required: true}},
+		Methods:   []string{domain.MergeMethodSquash, domain.MergeMethodMerge},
+	}
+}
+
+func TestMergeDialogInactiveByDefault(t *testing.T) {
+	m := testMergeDialog()
+	assert.False(t, m.Active())
+	assert.Empty(t, m.View())
+}
+
+func TestMergeDialogLoading(t *testing.T) {
+	m := testMergeDialog()
+	cmd := m.Show(testMergePR())
+	assert.NotNil(t, cmd, "loading starts the spinner")
+	assert.True(t, m.Active())
+	assert.Contains(t, m.View(), "Checking whether PR #42 can be merged")
+
+	// A status for another PR is ignored.
+	m.SetStatus(MergeStatusLoadedMsg{Number: 7, Status: cleanMergeStatus()})
+	assert.Equal(t, mergeLoading, m.state)
+}
+
+func TestMergeDialogBlocked(t *testing.T) {
+	m := testMergeDialog()
+	m.Show(testMergePR())
+	status := cleanMergeStatus()
+	status.Mergeable = domain.MergeableConflicting
+	m.SetStatus(MergeStatusLoadedMsg{Number: 42, Status: status})
+
+	require.Equal(t, mergeBlocked, m.state)
+	view := m.View()
+	assert.Contains(t, view, "Cannot merge")
+	assert.Contains(t, view, "conflicts that must be resolved")
+	assert.Contains(t, view, "build")
+
+	cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	require.NotNil(t, cmd)
+	assert.IsType(t, MergeDialogCloseMsg{}, cmd())
+	assert.False(t, m.Active())
+}
+
+func TestMergeDialogPendingChecksWithoutAutoMerge(t *testing.T) {
+	m := testMergeDialog()
+	m.Show(testMergePR())
+	status := cleanMergeStatus()
+	status.Blocked = true
+	status.Checks[0].Status = domain.CIPending
+	m.SetStatus(MergeStatusLoadedMsg{Number: 42, Status: status})
+
+	assert.Equal(t, mergeBlocked, m.state, "the repo does not allow auto-merge")
+	assert.Contains(t, m.View(), "required checks are still running")
+}
+
+func TestMergeDialogOptions(t *testing.T) {
+	m := testMergeDialog()
+	m.Show(testMergePR())
+	m.SetStatus(MergeStatusLoadedMsg{Number: 42, Status: cleanMergeStatus()})
+
+	require.Equal(t, mergeOptions, m.state)
+	view := m.View()
+	assert.Contains(t, view, "no conflicts")
+	assert.Contains(t, view, "approved")
+	assert.Contains(t, view, "Squash and merge")
+	assert.Equal(t, domain.MergeMethodSquash, m.method, "defaults to the first allowed method")
+	assert.Equal(t, "Add OAuth login (#42)", m.defaultTitle())
+
+	m.title, m.body, m.deleteBranch = "Custom title", "Body", true
+	assert.Equal(t, domain.MergeOpts{
+		Method:        domain.MergeMethodSquash,
+		DeleteBranch:  true,
+		CommitTitle:   "Custom title",
+		CommitMessage: "Body",
+	}, m.opts())
+
+	m.method = domain.MergeMethodRebase
+	opts := m.opts()
+	assert.Empty(t, opts.CommitTitle, "rebasing makes no merge commit")
+	assert.Empty(t, opts.CommitMessage)
+
+	cmd := m.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	require.NotNil(t, cmd)
+	assert.IsType(t, MergeDialogCloseMsg{}, cmd())
+}
+
+func TestMergeDialogAutoMerge(t *testing.T) {
+	m := testMergeDialog()
+	m.Show(testMergePR())
+	status := cleanMergeStatus()
+	status.Blocked = true
+	status.AutoMergeAllowed = true
+	status.Checks[0].Status = domain.CIPending
+	m.SetStatus(MergeStatusLoadedMsg{Number: 42, Status: status})
+
+	require.Equal(t, mergeOptions, m.state)
+	view := m.View()
+	assert.Contains(t, view, "Waiting")
+	assert.Contains(t, view, "Enable auto-merge")
+	assert.True(t, m.opts().Auto)
+}
+
+func TestMergeDialogError(t *testing.T) {
+	m := testMergeDialog()
+	m.Show(testMergePR())
+	m.SetStatus(MergeStatusLoadedMsg{Number: 42, Err: errors.New("boom")})
+
+	require.Equal(t, mergeError, m.state)
+	assert.Contains(t, m.View(), "boom")
+
+	m.Close()
+	assert.False(t, m.Active())
+}
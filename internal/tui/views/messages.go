@@ -35,26 +35,38 @@ type CloseFilterMsg struct{}
 
 // CheckoutDoneMsg is sent after a PR checkout completes.
 type CheckoutDoneMsg struct {
+	Number int
 	Branch string
 	Err    error
 }
 
 // ReviewSubmittedMsg is sent after a review submission completes.
 type ReviewSubmittedMsg struct {
-	Err error
+	Number int
+	Review domain.Review
+	Err    error
 }
 
 // LoadMorePRsMsg is sent when the user scrolls near the bottom and more PRs should be loaded.
 type LoadMorePRsMsg struct {
-	Page int
+	Page   int
+	Cursor string // where the previous page ended; empty for page-numbered readers
 }
 
 // MorePRsLoadedMsg is sent when additional PRs have been loaded (pagination).
 type MorePRsLoadedMsg struct {
-	PRs     []domain.PR
-	Page    int
-	HasMore bool
-	Err     error
+	PRs        []domain.PR
+	Page       int
+	HasMore    bool
+	NextCursor string
+	Err        error
+}
+
+// CIStatusesLoadedMsg carries one batch of CI statuses for listed PRs.
+type CIStatusesLoadedMsg struct {
+	Repo     domain.RepoRef
+	Statuses map[int]domain.CIStatus
+	Err      error
 }
 
 // PRCountLoadedMsg is sent when the total PR count is fetched.
@@ -103,12 +115,44 @@ type ReviewContextLoadedMsg struct {
 // CycleReviewScopeMsg asks the app to advance the active incremental review scope.
 type CycleReviewScopeMsg struct{}
 
-// JumpNextReviewTargetMsg asks the app to focus the next actionable file.
+// JumpNextReviewTargetMsg asks the app to focus the next actionable file,
+// or in the diff view the next unviewed hunk after Hunk.
 type JumpNextReviewTargetMsg struct {
 	CurrentPath string
+	Hunk        int
 }
 
 // ToggleViewedFileMsg asks the app to toggle viewed state for a file at the current revision.
 type ToggleViewedFileMsg struct {
 	Path string
 }
+
+// ToggleViewedHunkMsg asks the app to toggle viewed state for one hunk of a
+// file at the current revision.
+type ToggleViewedHunkMsg struct {
+	Path string
+	Hunk int
+}
+
+// ToggleInterdiffMsg asks the app to switch the diff view between the PR
+// diff and what the patch changed since the last visit or review.
+type ToggleInterdiffMsg struct {
+	Number int
+}
+
+// ClosePRMsg asks the app to close the PR, or to reopen it if it is closed.
+type ClosePRMsg struct {
+	PR domain.PR
+}
+
+// ToggleDraftMsg asks the app to convert the PR to a draft, or to mark a
+// draft ready for review.
+type ToggleDraftMsg struct {
+	PR domain.PR
+}
+
+// UpdateBranchMsg asks the app to bring the PR's head branch up to date
+// with its base.
+type UpdateBranchMsg struct {
+	PR domain.PR
+}
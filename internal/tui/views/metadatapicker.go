@@ -0,0 +1,416 @@
+package views
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/tui/core"
+)
+
+// MetadataKind is which of a PR's metadata the picker edits.
+type MetadataKind int
+
+const (
+	MetadataLabels MetadataKind = iota
+	MetadataAssignees
+	MetadataReviewers
+)
+
+// String names the kind for titles and toasts.
+func (k MetadataKind) String() string {
+	switch k {
+	case MetadataAssignees:
+		return "Assignees"
+	case MetadataReviewers:
+		return "Reviewers"
+	default:
+		return "Labels"
+	}
+}
+
+// maxMetadataRows is how many options the picker lists at once.
+const maxMetadataRows = 10
+
+// metadataPickerState tracks which phase the picker is in.
+type metadataPickerState int
+
+const (
+	metadataInactive metadataPickerState = iota
+	metadataLoading                      // fetching the repo's labels, users and teams
+	metadataReady
+	metadataError
+)
+
+// metadataItem is one option in the picker.
+type metadataItem struct {
+	value    string
+	color    string // label color, hex without '#'
+	note     string // label description, or the verdict of a submitted review
+	initial  bool   // set on the PR when the picker opened
+	selected bool
+}
+
+// MetadataPickerModel is a filterable multi-select over a repo's labels,
+// assignable users or reviewers, opened on a PR. Applying it sends the
+// difference from the PR's current values as a domain.MetadataEdit.
+type MetadataPickerModel struct {
+	state  metadataPickerState
+	styles core.Styles
+	width  int
+	height int
+
+	kind         MetadataKind
+	detail       domain.PRDetail
+	items        []metadataItem
+	matches      []int // indexes into items
+	query        string
+	cursor       int
+	err          error
+	spinnerFrame int
+}
+
+// Metadata picker messages.
+
+// EditMetadataMsg asks to open the picker on the current PR.
+type EditMetadataMsg struct {
+	Kind MetadataKind
+}
+
+// ApplyMetadataMsg is sent when the user applies the picker.
+type ApplyMetadataMsg struct {
+	Number int
+	Kind   MetadataKind
+	Edit   domain.MetadataEdit
+}
+
+// MetadataPickerCloseMsg is sent when the picker closes without changes.
+type MetadataPickerCloseMsg struct{}
+
+// metadataPickerSpinnerTick drives the loading spinner.
+type metadataPickerSpinnerTick struct{}
+
+// NewMetadataPickerModel creates a new metadata picker.
+func NewMetadataPickerModel(styles core.Styles) MetadataPickerModel {
+	return MetadataPickerModel{styles: styles}
+}
+
+// SetStyles updates styles without losing state.
+func (m *MetadataPickerModel) SetStyles(s core.Styles) { m.styles = s }
+
+// SetSize updates dimensions.
+func (m *MetadataPickerModel) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+}
+
+// Active returns whether the picker is currently visible.
+func (m *MetadataPickerModel) Active() bool { return m.state != metadataInactive }
+
+// Kind returns which metadata the picker edits.
+func (m *MetadataPickerModel) Kind() MetadataKind { return m.kind }
+
+// Show opens the picker on d while the options load.
+func (m *MetadataPickerModel) Show(kind MetadataKind, d domain.PRDetail) tea.Cmd {
+	m.reset()
+	m.state = metadataLoading
+	m.kind = kind
+	m.detail = d
+	return m.spinnerTick()
+}
+
+// SetOptions lists the options, marking those set on the PR. Values set on
+// the PR but missing from the options are listed too, so they can be
+// removed.
+func (m *MetadataPickerModel) SetOptions(opts *domain.MetadataOptions, err error) {
+	if m.state != metadataLoading {
+		return
+	}
+	if err != nil {
+		m.state = metadataError
+		m.err = err
+		return
+	}
+	m.items = nil
+	d := m.detail
+	switch m.kind {
+	case MetadataLabels:
+		for _, l := range opts.Labels {
+			m.items = append(m.items, metadataItem{value: l.Name, color: l.Color, note: l.Description})
+		}
+		m.markInitial(d.Labels)
+	case MetadataAssignees:
+		for _, u := range opts.Users {
+			m.items = append(m.items, metadataItem{value: u})
+		}
+		m.markInitial(d.Assignees)
+	case MetadataReviewers:
+		for _, r := range slices.Concat(opts.Teams, opts.Users) {
+			if r != d.Author { // authors cannot review their own PR
+				m.items = append(m.items, metadataItem{value: r})
+			}
+		}
+		m.markInitial(d.RequestedReviewers())
+		for _, r := range d.Reviewers {
+			if r.State == domain.ReviewPending {
+				continue
+			}
+			if i := m.index(r.Login); i >= 0 {
+				m.items[i].note = reviewVerdict(r.State)
+			}
+		}
+	}
+	m.state = metadataReady
+	m.filter()
+}
+
+// markInitial selects the items for values, adding any that are missing.
+func (m *MetadataPickerModel) markInitial(values []string) {
+	for _, v := range values {
+		i := m.index(v)
+		if i < 0 {
+			m.items = append(m.items, metadataItem{value: v})
+			i = len(m.items) - 1
+		}
+		m.items[i].initial, m.items[i].selected = true, true
+	}
+}
+
+func (m *MetadataPickerModel) index(value string) int {
+	return slices.IndexFunc(m.items, func(it metadataItem) bool { return it.value == value })
+}
+
+// reviewVerdict describes a submitted review next to its reviewer.
+func reviewVerdict(s domain.ReviewState) string {
+	switch s {
+	case domain.ReviewApproved:
+		return "approved; select to re-request"
+	case domain.ReviewChangesRequested:
+		return "requested changes; select to re-request"
+	default:
+		return "reviewed; select to re-request"
+	}
+}
+
+// Close hides the picker.
+func (m *MetadataPickerModel) Close() { m.reset() }
+
+func (m *MetadataPickerModel) reset() {
+	*m = MetadataPickerModel{styles: m.styles, width: m.width, height: m.height}
+}
+
+// filter lists the items whose value fuzzy-matches the query.
+func (m *MetadataPickerModel) filter() {
+	q := strings.ToLower(m.query)
+	m.matches = m.matches[:0]
+	for i, it := range m.items {
+		if fuzzyMatch(strings.ToLower(it.value), q) {
+			m.matches = append(m.matches, i)
+		}
+	}
+	m.cursor = min(m.cursor, max(0, len(m.matches)-1))
+}
+
+// Edit returns the changes the current selection makes to the PR.
+func (m *MetadataPickerModel) Edit() domain.MetadataEdit {
+	var add, remove []string
+	for _, it := range m.items {
+		switch {
+		case it.selected && !it.initial:
+			add = append(add, it.value)
+		case !it.selected && it.initial:
+			remove = append(remove, it.value)
+		}
+	}
+	switch m.kind {
+	case MetadataAssignees:
+		return domain.MetadataEdit{AddAssignees: add, RemoveAssignees: remove}
+	case MetadataReviewers:
+		return domain.MetadataEdit{AddReviewers: add, RemoveReviewers: remove}
+	default:
+		return domain.MetadataEdit{AddLabels: add, RemoveLabels: remove}
+	}
+}
+
+// Update handles messages for the picker.
+func (m *MetadataPickerModel) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case metadataPickerSpinnerTick:
+		if m.state == metadataLoading {
+			m.spinnerFrame++
+			return m.spinnerTick()
+		}
+	case tea.KeyMsg:
+		switch m.state {
+		case metadataLoading:
+			if msg.Type == tea.KeyEscape {
+				return m.close()
+			}
+		case metadataError:
+			return m.close()
+		case metadataReady:
+			return m.handleKey(msg)
+		}
+	}
+	return nil
+}
+
+func (m *MetadataPickerModel) handleKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEscape:
+		return m.close()
+	case tea.KeyEnter:
+		edit := m.Edit()
+		if edit.Empty() {
+			return m.close()
+		}
+		number, kind := m.detail.Number, m.kind
+		m.reset()
+		return func() tea.Msg { return ApplyMetadataMsg{Number: number, Kind: kind, Edit: edit} }
+	case tea.KeySpace, tea.KeyTab:
+		if len(m.matches) > 0 {
+			it := &m.items[m.matches[m.cursor]]
+			it.selected = !it.selected
+		}
+	case tea.KeyUp, tea.KeyCtrlP:
+		m.cursor = max(0, m.cursor-1)
+	case tea.KeyDown, tea.KeyCtrlN:
+		m.cursor = min(max(0, len(m.matches)-1), m.cursor+1)
+	case tea.KeyBackspace:
+		if r := []rune(m.query); len(r) > 0 {
+			m.query = string(r[:len(r)-1])
+			m.filter()
+		}
+	case tea.KeyRunes:
+		m.query += string(msg.Runes)
+		m.filter()
+	}
+	return nil
+}
+
+func (m *MetadataPickerModel) close() tea.Cmd {
+	m.reset()
+	return func() tea.Msg { return MetadataPickerCloseMsg{} }
+}
+
+func (m *MetadataPickerModel) spinnerTick() tea.Cmd {
+	return tea.Tick(80*time.Millisecond, func(time.Time) tea.Msg {
+		return metadataPickerSpinnerTick{}
+	})
+}
+
+// StatusHint returns status bar text for the current picker state.
+func (m *MetadataPickerModel) StatusHint() string {
+	switch m.state {
+	case metadataLoading:
+		return "Loading...   Esc cancel"
+	case metadataError:
+		return "Press any key to continue"
+	default:
+		return "type to filter  ↑/↓ move  Space toggle  Enter apply  Esc cancel"
+	}
+}
+
+// View renders the picker.
+func (m *MetadataPickerModel) View() string {
+	t := m.styles.Theme
+	muted := lipgloss.NewStyle().Foreground(t.Muted)
+	title := lipgloss.NewStyle().Foreground(t.Primary).Bold(true).
+		Render(fmt.Sprintf("%s for PR #%d", m.kind, m.detail.Number))
+
+	switch m.state {
+	case metadataLoading:
+		frame := spinnerFrames[m.spinnerFrame%len(spinnerFrames)]
+		spinner := lipgloss.NewStyle().Foreground(t.Primary).Bold(true).Render(frame)
+		return m.renderBox(lipgloss.JoinVertical(lipgloss.Left,
+			title, "", spinner+" "+lipgloss.NewStyle().Foreground(t.Fg).Render("Loading "+strings.ToLower(m.kind.String())+"..."),
+		), t.Primary)
+	case metadataError:
+		errMsg := "unknown error"
+		if m.err != nil {
+			errMsg = m.err.Error()
+		}
+		icon := lipgloss.NewStyle().Foreground(t.Error).Bold(true).Render("✗ Could not load " + strings.ToLower(m.kind.String()))
+		return m.renderBox(lipgloss.JoinVertical(lipgloss.Left,
+			title, "", icon,
+			lipgloss.NewStyle().Foreground(t.Fg).Width(m.boxWidth()-6).Render(errMsg),
+			"", muted.Italic(true).Render("Press any key to continue"),
+		), t.Error)
+	case metadataReady:
+		lines := []string{title, "", muted.Render("Filter: ") + m.query + "▎", ""}
+		lines = append(lines, m.renderItems()...)
+		edit := m.Edit()
+		adds := len(edit.AddLabels) + len(edit.AddAssignees) + len(edit.AddReviewers)
+		removes := len(edit.RemoveLabels) + len(edit.RemoveAssignees) + len(edit.RemoveReviewers)
+		summary := muted.Render("no changes")
+		if adds+removes > 0 {
+			summary = lipgloss.NewStyle().Foreground(t.Success).Render(fmt.Sprintf("+%d", adds)) + " " +
+				lipgloss.NewStyle().Foreground(t.Error).Render(fmt.Sprintf("−%d", removes))
+		}
+		lines = append(lines, "", summary, muted.Italic(true).Render("Space toggle  Enter apply  Esc cancel"))
+		return m.renderBox(lipgloss.JoinVertical(lipgloss.Left, lines...), t.Primary)
+	}
+	return ""
+}
+
+// renderItems renders the visible window of matching items.
+func (m *MetadataPickerModel) renderItems() []string {
+	t := m.styles.Theme
+	muted := lipgloss.NewStyle().Foreground(t.Muted)
+	if len(m.matches) == 0 {
+		if len(m.items) == 0 {
+			return []string{muted.Render("nothing to choose from")}
+		}
+		return []string{muted.Render("no matches")}
+	}
+	var lines []string
+	start := max(0, m.cursor-maxMetadataRows+1)
+	for i := start; i < len(m.matches) && i < start+maxMetadataRows; i++ {
+		it := m.items[m.matches[i]]
+		marker, check := "  ", "[ ] "
+		if it.selected {
+			check = lipgloss.NewStyle().Foreground(t.Success).Render("[x] ")
+		}
+		name := it.value
+		if i == m.cursor {
+			marker = lipgloss.NewStyle().Foreground(t.Primary).Render("> ")
+			name = lipgloss.NewStyle().Foreground(t.Primary).Bold(true).Render(name)
+		}
+		if it.color != "" {
+			name = lipgloss.NewStyle().Foreground(lipgloss.Color("#"+it.color)).Render("● ") + name
+		}
+		line := marker + check + name
+		if it.note != "" {
+			note := []rune(it.note)
+			if room := m.boxWidth() - 6 - lipgloss.Width(line) - 2; room > 3 && len(note) > room {
+				note = append(note[:room-1], '…')
+			}
+			line += "  " + muted.Render(string(note))
+		}
+		lines = append(lines, line)
+	}
+	if len(m.matches) > maxMetadataRows {
+		lines = append(lines, muted.Render(fmt.Sprintf("  %d/%d", m.cursor+1, len(m.matches))))
+	}
+	return lines
+}
+
+func (m *MetadataPickerModel) boxWidth() int {
+	return max(40, min(64, m.width-4))
+}
+
+func (m *MetadataPickerModel) renderBox(inner string, border lipgloss.TerminalColor) string {
+	box := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(border).
+		Padding(1, 2).
+		Width(m.boxWidth()).
+		Render(inner)
+	centered := lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+	return ensureExactHeight(centered, m.height, m.width)
+}
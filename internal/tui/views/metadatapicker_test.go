@@ -0,0 +1,114 @@
+package views
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/tui/core"
+)
+
+func testMetadataPicker() MetadataPickerModel {
+	m := NewMetadataPickerModel(core.NewStyles(core.ThemeByName("catppuccin-mocha")))
+	m.SetSize(100, 40)
+	return m
+}
+
+func testMetadataDetail() domain.PRDetail {
+	return domain.PRDetail{
+		PR:        domain.PR{Number: 7, Author: "alice", Labels: []string{"bug", "legacy"}},
+		Assignees: []string{"bob"},
+		Reviewers: []domain.ReviewerInfo{
+			{Login: "carol", State: domain.ReviewPending},
+			{Login: "dave", State: domain.ReviewApproved},
+		},
+	}
+}
+
+func testMetadataOptions() *domain.MetadataOptions {
+	return &domain.MetadataOptions{
+		Labels: []domain.Label{{Name: "bug", Color: "d73a4a"}, {Name: "docs", Description: "Documentation"}},
+		Users:  []string{"alice", "bob", "carol", "dave"},
+		Teams:  []string{"acme/core"},
+	}
+}
+
+func pickerKey(m *MetadataPickerModel, keys ...tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+	for _, k := range keys {
+		cmd = m.Update(k)
+	}
+	return cmd
+}
+
+func TestMetadataPickerLabels(t *testing.T) {
+	m := testMetadataPicker()
+	m.Show(MetadataLabels, testMetadataDetail())
+	assert.Contains(t, m.View(), "Loading labels")
+
+	m.SetOptions(testMetadataOptions(), nil)
+	view := m.View()
+	assert.Contains(t, view, "Labels for PR #7")
+	assert.Contains(t, view, "Documentation")
+	assert.Contains(t, view, "legacy", "labels missing from the repo are still listed")
+	assert.Contains(t, view, "no changes")
+
+	// Filter to docs and select it; unselect legacy.
+	pickerKey(&m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("doc")}, tea.KeyMsg{Type: tea.KeySpace})
+	pickerKey(&m, tea.KeyMsg{Type: tea.KeyBackspace}, tea.KeyMsg{Type: tea.KeyBackspace}, tea.KeyMsg{Type: tea.KeyBackspace})
+	pickerKey(&m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("leg")}, tea.KeyMsg{Type: tea.KeySpace})
+	assert.Equal(t, domain.MetadataEdit{AddLabels: []string{"docs"}, RemoveLabels: []string{"legacy"}}, m.Edit())
+
+	cmd := pickerKey(&m, tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	msg, ok := cmd().(ApplyMetadataMsg)
+	require.True(t, ok)
+	assert.Equal(t, 7, msg.Number)
+	assert.Equal(t, MetadataLabels, msg.Kind)
+	assert.False(t, m.Active())
+}
+
+func TestMetadataPickerReviewers(t *testing.T) {
+	m := testMetadataPicker()
+	m.Show(MetadataReviewers, testMetadataDetail())
+	m.SetOptions(testMetadataOptions(), nil)
+
+	var values []string
+	for _, it := range m.items {
+		values = append(values, it.value)
+	}
+	assert.Equal(t, []string{"acme/core", "bob", "carol", "dave"}, values, "the author is not offered")
+	assert.True(t, m.items[2].selected, "pending requests start selected")
+	assert.False(t, m.items[3].selected, "submitted reviews are not requests")
+	assert.Contains(t, m.View(), "approved; select to re-request")
+
+	// Request the team and drop carol.
+	pickerKey(&m, tea.KeyMsg{Type: tea.KeySpace}, tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeySpace})
+	assert.Equal(t, domain.MetadataEdit{AddReviewers: []string{"acme/core"}, RemoveReviewers: []string{"carol"}}, m.Edit())
+}
+
+func TestMetadataPickerNoChangeCloses(t *testing.T) {
+	m := testMetadataPicker()
+	m.Show(MetadataAssignees, testMetadataDetail())
+	m.SetOptions(testMetadataOptions(), nil)
+	assert.True(t, m.items[1].selected)
+
+	cmd := pickerKey(&m, tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.IsType(t, MetadataPickerCloseMsg{}, cmd())
+}
+
+func TestMetadataPickerError(t *testing.T) {
+	m := testMetadataPicker()
+	m.Show(MetadataAssignees, testMetadataDetail())
+	m.SetOptions(nil, errors.New("boom"))
+	assert.Contains(t, m.View(), "boom")
+
+	cmd := pickerKey(&m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	require.NotNil(t, cmd)
+	assert.IsType(t, MetadataPickerCloseMsg{}, cmd())
+}
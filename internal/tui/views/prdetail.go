@@ -37,6 +37,14 @@ type PRDetailModel struct {
 	commentCursor    int
 	pendingCollapseZ bool
 	reviewContext    *reviewprogress.Context
+
+	// Comment actions: the open composer, if any, and whether the next
+	// key picks a reaction.
+	compose         *composer
+	pickingReaction bool
+	snippets        []domain.Snippet // canned responses for the composer's picker
+
+	commits commitList
 }
 
 // DetailTab represents the active tab in detail view.
@@ -47,9 +55,10 @@ const (
 	TabChecks
 	TabFiles
 	TabComments
+	TabCommits
 )
 
-const numTabs = 4
+const numTabs = 5
 const collapseDiscussionThreshold = 20
 
 type markdownCache struct {
@@ -74,6 +83,7 @@ func NewPRDetailModel(styles core.Styles, keys core.KeyMap) PRDetailModel {
 		loading: true,
 		spinner: newDetailSpinner(styles),
 		tab:     TabDescription,
+		commits: commitList{anchor: -1},
 	}
 }
 
@@ -99,6 +109,8 @@ func (m *PRDetailModel) SetDetail(d *domain.PRDetail) {
 	}
 	m.commentCursor = 0
 	m.reviewContext = nil
+	m.pickingReaction = false
+	m.resetCommits(d)
 }
 
 // SetReviewContext updates the incremental review context shown in detail view.
@@ -117,6 +129,14 @@ func (m *PRDetailModel) GetPRNumber() int {
 	return m.pendingNum
 }
 
+// ApplyMetadataEdit shows an edit to the PR's labels, assignees or
+// reviewers before the host confirms it.
+func (m *PRDetailModel) ApplyMetadataEdit(number int, edit domain.MetadataEdit) {
+	if m.detail != nil && m.detail.Number == number {
+		edit.Apply(m.detail)
+	}
+}
+
 // GetDetail returns the currently loaded PR detail.
 func (m *PRDetailModel) GetDetail() *domain.PRDetail {
 	return m.detail
@@ -188,10 +208,6 @@ type (
 	OpenDiffMsg    struct{ Number int }
 	StartReviewMsg struct{ Number int }
 
-	ReplyToThreadMsg struct {
-		ThreadID string
-		Body     string
-	}
 	ResolveThreadMsg struct {
 		ThreadID string
 	}
@@ -204,12 +220,15 @@ type (
 func (m *PRDetailModel) Update(msg tea.Msg) tea.Cmd {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		return m.handleKey(msg)
+		return tea.Batch(m.handleKey(msg), m.LoadCommits())
 	case PRDetailLoadedMsg:
 		m.SetDetail(msg.Detail)
 		return nil
+	case EditorDoneMsg:
+		m.composeFromEditor(msg)
+		return nil
 	case spinner.TickMsg:
-		if !m.loading {
+		if !m.loading && !m.commits.loading {
 			return nil
 		}
 		var cmd tea.Cmd
@@ -220,6 +239,13 @@ func (m *PRDetailModel) Update(msg tea.Msg) tea.Cmd {
 }
 
 func (m *PRDetailModel) handleKey(msg tea.KeyMsg) tea.Cmd {
+	if m.compose != nil {
+		return m.handleComposeKey(msg)
+	}
+	if m.pickingReaction {
+		return m.handleReactionKey(msg)
+	}
+
 	// Handle za sequence for collapse
 	if m.pendingCollapseZ && msg.Type == tea.KeyRunes && len(msg.Runes) == 1 {
 		m.pendingCollapseZ = false
@@ -229,6 +255,12 @@ func (m *PRDetailModel) handleKey(msg tea.KeyMsg) tea.Cmd {
 		}
 	}
 
+	if m.tab == TabCommits {
+		if cmd, handled := m.handleCommitKey(msg); handled {
+			return cmd
+		}
+	}
+
 	if cmd, handled := m.handleNavigationKey(msg); handled {
 		return cmd
 	}
@@ -287,11 +319,46 @@ func (m *PRDetailModel) handleNavigationKey(msg tea.KeyMsg) (tea.Cmd, bool) {
 			}, true
 		}
 		return nil, true
+	case key.Matches(msg, m.keys.Merge):
+		if m.detail != nil {
+			pr := m.detail.PR
+			return func() tea.Msg { return MergePRMsg{PR: pr} }, true
+		}
+		return nil, true
+	case key.Matches(msg, m.keys.EditLabels):
+		return m.editMetadata(MetadataLabels), true
+	case key.Matches(msg, m.keys.EditAssignees):
+		return m.editMetadata(MetadataAssignees), true
+	case key.Matches(msg, m.keys.EditReviewers):
+		return m.editMetadata(MetadataReviewers), true
+	case key.Matches(msg, m.keys.ClosePR):
+		return m.lifecycle(func(pr domain.PR) tea.Msg { return ClosePRMsg{PR: pr} }), true
+	case key.Matches(msg, m.keys.ToggleDraft):
+		return m.lifecycle(func(pr domain.PR) tea.Msg { return ToggleDraftMsg{PR: pr} }), true
+	case key.Matches(msg, m.keys.UpdateBranch):
+		return m.lifecycle(func(pr domain.PR) tea.Msg { return UpdateBranchMsg{PR: pr} }), true
 	default:
 		return nil, false
 	}
 }
 
+// editMetadata opens the metadata picker for kind on the PR shown.
+func (m *PRDetailModel) editMetadata(kind MetadataKind) tea.Cmd {
+	if m.detail == nil {
+		return nil
+	}
+	return func() tea.Msg { return EditMetadataMsg{Kind: kind} }
+}
+
+// lifecycle sends the state change msg builds for the PR shown.
+func (m *PRDetailModel) lifecycle(msg func(domain.PR) tea.Msg) tea.Cmd {
+	if m.detail == nil {
+		return nil
+	}
+	pr := m.detail.PR
+	return func() tea.Msg { return msg(pr) }
+}
+
 func (m *PRDetailModel) handleRuneKey(r rune) (tea.Cmd, bool) {
 	switch r {
 	case '1':
@@ -310,15 +377,17 @@ func (m *PRDetailModel) handleRuneKey(r rune) (tea.Cmd, bool) {
 		m.tab = TabComments
 		m.scrollY = 0
 		return nil, true
+	case '5':
+		m.tab = TabCommits
+		m.scrollY = 0
+		return nil, true
 	case 'r':
 		if m.detail == nil {
 			return nil, true
 		}
 		if m.tab == TabComments {
-			if item, ok := m.currentDiscussionItem(); ok && item.Kind == domain.DiscussionInlineThread && item.ReplyToID != "" {
-				return func() tea.Msg {
-					return ReplyToThreadMsg{ThreadID: item.ReplyToID, Body: ""}
-				}, true
+			if cmd, handled := m.handleCommentActionKey(r); handled {
+				return cmd, true
 			}
 		}
 		return func() tea.Msg { return StartReviewMsg{Number: m.detail.Number} }, true
@@ -369,6 +438,10 @@ func (m *PRDetailModel) handleRuneKey(r rune) (tea.Cmd, bool) {
 		}
 		return nil, true
 	default:
+		// New comments can be started from any tab.
+		if m.tab == TabComments || r == 'n' {
+			return m.handleCommentActionKey(r)
+		}
 		return nil, false
 	}
 }
@@ -397,6 +470,11 @@ func (m *PRDetailModel) openURL() string {
 			return item.URL
 		}
 	}
+	if m.tab == TabCommits {
+		if url := m.selectedCommitURL(); url != "" {
+			return url
+		}
+	}
 	return m.detail.URL
 }
 
@@ -461,10 +539,18 @@ func (m *PRDetailModel) View() string {
 	tabBar := m.renderTabBar()
 	contextBar := m.renderReviewContextBar()
 	chromeHeight := lipgloss.Height(prHeader) + lipgloss.Height(tabBar) + lipgloss.Height(contextBar)
+	input := m.renderCommentInput()
+	if input != "" {
+		chromeHeight += lipgloss.Height(input)
+	}
 	contentHeight := max(1, m.height-chromeHeight)
 	content := m.renderTabContent(contentHeight)
 
-	view := lipgloss.JoinVertical(lipgloss.Left, prHeader, tabBar, contextBar, content)
+	parts := []string{prHeader, tabBar, contextBar, content}
+	if input != "" {
+		parts = append(parts, input)
+	}
+	view := lipgloss.JoinVertical(lipgloss.Left, parts...)
 	return ensureExactHeight(view, m.height, m.width)
 }
 
@@ -529,6 +615,7 @@ func (m *PRDetailModel) renderTabBar() string {
 		{"Checks", len(d.Checks), TabChecks},
 		{"Files", len(d.Files), TabFiles},
 		{"Comments", len(d.Discussion), TabComments},
+		{"Commits", len(m.commits.commits), TabCommits},
 	}
 
 	var tabStrings []string
@@ -573,6 +660,8 @@ func (m *PRDetailModel) renderTabContent(height int) string {
 		content = m.renderChecksTab()
 	case TabComments:
 		return m.renderCommentsTab(height)
+	case TabCommits:
+		return m.renderCommitsTab(height)
 	}
 
 	// Apply scrolling
@@ -629,7 +718,7 @@ func (m *PRDetailModel) renderReviewContextBar() string {
 
 	leftParts := []string{
 		lipgloss.NewStyle().Foreground(t.Warning).Bold(true).Render("Review"),
-		lipgloss.NewStyle().Foreground(t.Fg).Render(fmt.Sprintf("%d/%d viewed", m.reviewContext.ViewedFiles, m.reviewContext.TotalFiles)),
+		lipgloss.NewStyle().Foreground(t.Fg).Render(fmt.Sprintf("%d/%d hunks viewed", m.reviewContext.ViewedHunks, m.reviewContext.TotalHunks)),
 	}
 	if m.reviewContext.HasReviewBaseline {
 		leftParts = append(leftParts, lipgloss.NewStyle().Foreground(t.Warning).Render(fmt.Sprintf("Δ %d since review", m.reviewContext.SinceReviewFiles)))
@@ -654,9 +743,9 @@ func (m *PRDetailModel) renderReviewContextBar() string {
 		return borderStyle.Render(left + padding + right)
 	}
 
-	compact := fmt.Sprintf("  Review %d/%d viewed · Δ%d · %s [i]    u next  V viewed",
-		m.reviewContext.ViewedFiles,
-		m.reviewContext.TotalFiles,
+	compact := fmt.Sprintf("  Review %d/%d hunks viewed · Δ%d · %s [i]    u next  V viewed",
+		m.reviewContext.ViewedHunks,
+		m.reviewContext.TotalHunks,
 		m.reviewContext.SinceReviewFiles,
 		m.reviewContext.Scope.Label(),
 	)
@@ -694,6 +783,14 @@ func (m *PRDetailModel) renderDescriptionTab() string {
 		))
 	}
 
+	// Assignees
+	if len(d.Assignees) > 0 {
+		lines = append(lines, fmt.Sprintf("%s  %s",
+			labelStyle.Render("Assignees:"),
+			valueStyle.Render(strings.Join(d.Assignees, ", ")),
+		))
+	}
+
 	// Reviewers
 	if len(d.Reviewers) > 0 {
 		var revs []string
@@ -1038,6 +1135,9 @@ func (m *PRDetailModel) renderDiscussionBlock(item domain.DiscussionItem, idx, c
 			for _, l := range strings.Split(rendered, "\n") {
 				lines = append(lines, "      "+l)
 			}
+			if len(c.Reactions) > 0 {
+				lines = append(lines, "      "+m.renderReactions(c))
+			}
 		}
 	}
 	lines = append(lines, "")
@@ -0,0 +1,358 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// Comment actions sent from the PR detail Comments tab.
+type (
+	// PostCommentMsg posts a top-level comment on the PR conversation.
+	PostCommentMsg struct {
+		Number int
+		Body   string
+	}
+	// EditCommentMsg replaces the body of one of the viewer's comments.
+	EditCommentMsg struct {
+		Number int
+		Ref    domain.CommentRef
+		Body   string
+	}
+	// DeleteCommentMsg asks to delete one of the viewer's comments. The app
+	// confirms before deleting.
+	DeleteCommentMsg struct {
+		Number int
+		Ref    domain.CommentRef
+		Author string
+	}
+	// ToggleReactionMsg adds the viewer's reaction to a comment, or removes
+	// it if the viewer already reacted with Content.
+	ToggleReactionMsg struct {
+		Number  int
+		Kind    domain.DiscussionKind
+		Comment domain.Comment
+		Content domain.ReactionContent
+	}
+)
+
+// composeMode is what the PR detail comment composer will do on submit.
+type composeMode int
+
+const (
+	composeNew         composeMode = iota // top-level PR comment
+	composeQuoteReply                     // top-level comment quoting a comment or review
+	composeThreadReply                    // reply in an inline thread
+	composeEdit                           // edit an existing comment
+)
+
+// composer is the PR detail comment editor.
+type composer struct {
+	mode    composeMode
+	item    domain.DiscussionItem // item replied to or edited
+	ref     domain.CommentRef     // comment being edited
+	buffer  string
+	preview bool           // show the rendered markdown instead of the buffer
+	picker  *snippetPicker // open snippet picker, if any
+}
+
+// SetSnippets sets the canned responses offered in the comment composer.
+func (m *PRDetailModel) SetSnippets(snippets []domain.Snippet) { m.snippets = snippets }
+
+// IsInputActive returns whether the view is taking keys for the comment
+// composer or the reaction picker, so global shortcuts must not fire.
+func (m *PRDetailModel) IsInputActive() bool {
+	return m.compose != nil || m.pickingReaction
+}
+
+// StatusHint returns the key hints for the composer or the reaction
+// picker, or "" when neither is open.
+func (m *PRDetailModel) StatusHint() string {
+	switch {
+	case m.compose != nil:
+		submit := "Ctrl+S post"
+		if m.compose.mode == composeEdit {
+			submit = "Ctrl+S save"
+		}
+		if m.compose.preview {
+			return submit + "  Ctrl+E $EDITOR  Esc back to editing"
+		}
+		hint := submit + "  Enter newline  Ctrl+E $EDITOR  Ctrl+P preview  Esc cancel"
+		if len(m.snippets) > 0 {
+			hint += "  Ctrl+T snippets"
+		}
+		return hint
+	case m.pickingReaction:
+		return "1-8 toggle reaction  Esc cancel"
+	}
+	return ""
+}
+
+// openComposer opens the comment composer prefilled with body.
+func (m *PRDetailModel) openComposer(mode composeMode, item domain.DiscussionItem, ref domain.CommentRef, body string) {
+	m.compose = &composer{mode: mode, item: item, ref: ref, buffer: body}
+}
+
+// handleComposeKey edits the composer buffer and submits it on Ctrl+S.
+func (m *PRDetailModel) handleComposeKey(msg tea.KeyMsg) tea.Cmd {
+	c := m.compose
+	if c.picker != nil {
+		if s, done := c.picker.handleKey(msg); done {
+			c.picker = nil
+			if s != nil {
+				c.buffer = insertSnippet(c.buffer, *s, m.snippetVars(c))
+			}
+		}
+		return nil
+	}
+
+	// The preview only posts, or goes back to editing.
+	if c.preview && msg.Type != tea.KeyCtrlS && msg.Type != tea.KeyCtrlE {
+		if msg.Type == tea.KeyEscape || msg.Type == tea.KeyCtrlP {
+			c.preview = false
+		}
+		return nil
+	}
+
+	switch msg.Type {
+	case tea.KeyEscape:
+		m.compose = nil
+	case tea.KeyCtrlE:
+		return openEditor(c.buffer, composerContext(c))
+	case tea.KeyCtrlP:
+		c.preview = true
+	case tea.KeyCtrlT:
+		if len(m.snippets) > 0 {
+			c.picker = newSnippetPicker(m.snippets)
+		}
+	case tea.KeyCtrlS:
+		m.compose = nil
+		return m.submitComposer(c)
+	case tea.KeyEnter:
+		c.buffer += "\n"
+	case tea.KeyBackspace:
+		if r := []rune(c.buffer); len(r) > 0 {
+			c.buffer = string(r[:len(r)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		c.buffer += string(msg.Runes)
+	}
+	return nil
+}
+
+// snippetVars returns the placeholder values for a snippet inserted in c:
+// the PR, and the file and line of the thread a reply answers.
+func (m *PRDetailModel) snippetVars(c *composer) domain.SnippetVars {
+	vars := domain.SnippetVars{Author: m.detail.Author, Number: m.detail.Number}
+	if c.mode == composeThreadReply {
+		vars.File, vars.Line = c.item.Path, c.item.Line
+	}
+	return vars
+}
+
+// composeFromEditor shows what was written in the external editor in the
+// composer's preview, ready to post.
+func (m *PRDetailModel) composeFromEditor(msg EditorDoneMsg) {
+	if m.compose == nil || msg.Err != nil {
+		return
+	}
+	m.compose.buffer = msg.Body
+	m.compose.preview = true
+}
+
+// composerContext returns the thread a reply answers, for the external
+// editor. Quote replies already carry their quote in the body.
+func composerContext(c *composer) string {
+	if c.mode != composeThreadReply {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d\n\n%s", c.item.Path, c.item.Line, quoteComments(c.item.Comments))
+}
+
+// submitComposer turns the composed text into the message for its mode.
+// Blank text is dropped.
+func (m *PRDetailModel) submitComposer(c *composer) tea.Cmd {
+	if m.detail == nil || strings.TrimSpace(c.buffer) == "" {
+		return nil
+	}
+	number, body := m.detail.Number, c.buffer
+	switch c.mode {
+	case composeThreadReply:
+		input := domain.InlineCommentInput{
+			Path:      c.item.Path,
+			Line:      c.item.Line,
+			Side:      "RIGHT",
+			Body:      body,
+			InReplyTo: c.item.ReplyToID,
+		}
+		return func() tea.Msg { return AddInlineCommentMsg{Number: number, Input: input} }
+	case composeEdit:
+		ref := c.ref
+		return func() tea.Msg { return EditCommentMsg{Number: number, Ref: ref, Body: body} }
+	default:
+		return func() tea.Msg { return PostCommentMsg{Number: number, Body: body} }
+	}
+}
+
+// handleReactionKey toggles the numbered reaction on the selected item's
+// first comment.
+func (m *PRDetailModel) handleReactionKey(msg tea.KeyMsg) tea.Cmd {
+	m.pickingReaction = false
+	if msg.Type != tea.KeyRunes || len(msg.Runes) != 1 {
+		return nil
+	}
+	i := int(msg.Runes[0] - '1')
+	if i < 0 || i >= len(domain.ReactionContents) {
+		return nil
+	}
+	item, ok := m.currentDiscussionItem()
+	if !ok || len(item.Comments) == 0 || item.Comments[0].NodeID == "" {
+		return nil
+	}
+	react := ToggleReactionMsg{
+		Number:  m.detail.Number,
+		Kind:    item.Kind,
+		Comment: item.Comments[0],
+		Content: domain.ReactionContents[i],
+	}
+	return func() tea.Msg { return react }
+}
+
+// handleCommentActionKey handles the Comments tab keys that act on the
+// selected discussion item. handled is false for other keys.
+func (m *PRDetailModel) handleCommentActionKey(r rune) (cmd tea.Cmd, handled bool) {
+	if m.detail == nil {
+		return nil, false
+	}
+	item, ok := m.currentDiscussionItem()
+	switch r {
+	case 'n':
+		m.openComposer(composeNew, domain.DiscussionItem{}, domain.CommentRef{}, "")
+		return nil, true
+	case 'r':
+		switch {
+		case !ok:
+			return nil, false
+		case item.Kind == domain.DiscussionInlineThread && item.ReplyToID != "":
+			m.openComposer(composeThreadReply, item, domain.CommentRef{}, "")
+		case item.Kind != domain.DiscussionInlineThread && len(item.Comments) > 0:
+			m.openComposer(composeQuoteReply, item, domain.CommentRef{}, quoteReply(item.Comments[len(item.Comments)-1]))
+		default:
+			return nil, false
+		}
+		return nil, true
+	case 'e':
+		if c, found := lastComment(item, func(c domain.Comment) bool { return c.CanEdit }); ok && found {
+			m.openComposer(composeEdit, item, domain.CommentRef{NodeID: c.NodeID, Kind: item.Kind}, c.Body)
+		}
+		return nil, true
+	case 'D':
+		if c, found := lastComment(item, func(c domain.Comment) bool { return c.CanDelete }); ok && found {
+			del := DeleteCommentMsg{Number: m.detail.Number, Ref: domain.CommentRef{NodeID: c.NodeID, Kind: item.Kind}, Author: c.Author}
+			return func() tea.Msg { return del }, true
+		}
+		return nil, true
+	case '+':
+		if ok && len(item.Comments) > 0 && item.Comments[0].NodeID != "" {
+			m.pickingReaction = true
+		}
+		return nil, true
+	}
+	return nil, false
+}
+
+// lastComment returns the latest comment in item that keep accepts: in a
+// thread, the viewer's own most recent reply.
+func lastComment(item domain.DiscussionItem, keep func(domain.Comment) bool) (domain.Comment, bool) {
+	for i := len(item.Comments) - 1; i >= 0; i-- {
+		if c := item.Comments[i]; c.NodeID != "" && keep(c) {
+			return c, true
+		}
+	}
+	return domain.Comment{}, false
+}
+
+// quoteReply starts a reply that quotes c, like GitHub's "Quote reply".
+func quoteReply(c domain.Comment) string {
+	lines := strings.Split(strings.TrimRight(c.Body, "\n"), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight("> "+l, " ")
+	}
+	return strings.Join(lines, "\n") + "\n\n"
+}
+
+// renderReactions renders a comment's reactions on one line, highlighting
+// the viewer's own.
+func (m *PRDetailModel) renderReactions(c domain.Comment) string {
+	t := m.styles.Theme
+	parts := make([]string, 0, len(c.Reactions))
+	for _, r := range c.Reactions {
+		style := lipgloss.NewStyle().Foreground(t.Muted)
+		if r.ViewerReacted {
+			style = lipgloss.NewStyle().Foreground(t.Primary).Bold(true)
+		}
+		parts = append(parts, style.Render(fmt.Sprintf("%s %d", r.Content.Emoji(), r.Count)))
+	}
+	return strings.Join(parts, "  ")
+}
+
+// renderCommentInput renders the composer or the reaction picker, or ""
+// when neither is open.
+func (m *PRDetailModel) renderCommentInput() string {
+	t := m.styles.Theme
+	border := lipgloss.NewStyle().Foreground(t.Primary)
+	hint := lipgloss.NewStyle().Foreground(t.Muted)
+
+	if m.pickingReaction {
+		parts := make([]string, 0, len(domain.ReactionContents))
+		for i, r := range domain.ReactionContents {
+			parts = append(parts, fmt.Sprintf("%d %s", i+1, r.Emoji()))
+		}
+		return border.Render("React: ") + strings.Join(parts, "  ") + hint.Render("  Esc cancel")
+	}
+	if m.compose == nil {
+		return ""
+	}
+
+	c := m.compose
+	var title string
+	switch c.mode {
+	case composeNew:
+		title = fmt.Sprintf("New comment on #%d", m.detail.Number)
+	case composeQuoteReply:
+		title = "Reply to " + discussionAuthor(c.item)
+	case composeThreadReply:
+		title = fmt.Sprintf("Reply to thread on %s:%d", c.item.Path, c.item.Line)
+	case composeEdit:
+		title = "Edit comment"
+	}
+
+	lines := []string{border.Render("╔══ " + title)}
+	if c.preview {
+		for _, l := range renderPreview(c.buffer, m.width-4) {
+			lines = append(lines, border.Render("║ ")+l)
+		}
+	} else {
+		for _, l := range strings.Split(c.buffer, "\n") {
+			lines = append(lines, border.Render("║ ")+l)
+		}
+		lines[len(lines)-1] += "▎"
+	}
+	lines = append(lines, border.Render("╚══ ")+hint.Render(m.StatusHint()))
+	if c.picker != nil {
+		lines = append(lines, c.picker.view(m.styles, "", m.width))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// discussionAuthor names the author of an item's first comment.
+func discussionAuthor(item domain.DiscussionItem) string {
+	if len(item.Comments) == 0 || item.Comments[0].Author == "" {
+		return "comment"
+	}
+	return "@" + item.Comments[0].Author
+}
@@ -0,0 +1,203 @@
+package views
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// Commit review messages sent from and to the PR detail Commits tab.
+type (
+	// LoadCommitsMsg asks for the PR's commits.
+	LoadCommitsMsg struct{ Number int }
+	// CommitsLoadedMsg carries the PR's commits, oldest first.
+	CommitsLoadedMsg struct {
+		Number  int
+		Commits []domain.Commit
+		Err     error
+	}
+	// OpenCommitDiffMsg opens the combined diff of a run of consecutive
+	// commits, oldest first.
+	OpenCommitDiffMsg struct {
+		Number  int
+		Commits []domain.Commit
+	}
+)
+
+// commitList is the state of the Commits tab. The list is loaded the first
+// time the tab is shown for a PR head.
+type commitList struct {
+	number  int    // PR the list is loaded, or loading, for
+	head    string // head SHA the list was loaded at
+	loading bool
+	commits []domain.Commit
+	err     error
+	cursor  int
+	anchor  int // other end of the selected range, or -1
+}
+
+// LoadCommits returns the command that loads the PR's commits when the
+// Commits tab is shown and they are not loaded yet.
+func (m *PRDetailModel) LoadCommits() tea.Cmd {
+	d := m.detail
+	if d == nil || m.tab != TabCommits || m.commits.number == d.Number {
+		return nil
+	}
+	m.commits = commitList{number: d.Number, head: d.Branch.HeadSHA, loading: true, anchor: -1}
+	number := d.Number
+	return tea.Batch(m.spinner.Tick, func() tea.Msg { return LoadCommitsMsg{Number: number} })
+}
+
+// SetCommits shows the loaded commits of the PR.
+func (m *PRDetailModel) SetCommits(msg CommitsLoadedMsg) {
+	if msg.Number != m.commits.number {
+		return
+	}
+	m.commits.loading = false
+	m.commits.commits = msg.Commits
+	m.commits.err = msg.Err
+	m.commits.cursor = 0
+	m.commits.anchor = -1
+}
+
+// resetCommits drops the loaded commits when d is another PR or the head
+// has moved.
+func (m *PRDetailModel) resetCommits(d *domain.PRDetail) {
+	if m.commits.number != d.Number || m.commits.head != d.Branch.HeadSHA {
+		m.commits = commitList{anchor: -1}
+	}
+}
+
+// selectedCommits returns the commits in the selected range, or the one
+// under the cursor, oldest first.
+func (m *PRDetailModel) selectedCommits() []domain.Commit {
+	c := &m.commits
+	if len(c.commits) == 0 {
+		return nil
+	}
+	c.cursor = min(max(c.cursor, 0), len(c.commits)-1)
+	from, to := c.cursor, c.cursor
+	if c.anchor >= 0 && c.anchor < len(c.commits) {
+		from, to = min(c.anchor, c.cursor), max(c.anchor, c.cursor)
+	}
+	return c.commits[from : to+1]
+}
+
+// handleCommitKey handles the Commits tab keys: moving the cursor,
+// marking a range with v and opening its diff with Enter.
+func (m *PRDetailModel) handleCommitKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	c := &m.commits
+	switch {
+	case msg.String() == "j" || msg.Type == tea.KeyDown:
+		c.cursor = min(c.cursor+1, max(0, len(c.commits)-1))
+	case msg.String() == "k" || msg.Type == tea.KeyUp:
+		c.cursor = max(c.cursor-1, 0)
+	case msg.String() == "g":
+		c.cursor = 0
+	case msg.String() == "G":
+		c.cursor = max(0, len(c.commits)-1)
+	case msg.String() == "v":
+		if c.anchor >= 0 {
+			c.anchor = -1
+		} else if len(c.commits) > 0 {
+			c.anchor = c.cursor
+		}
+	case msg.Type == tea.KeyEnter:
+		commits := m.selectedCommits()
+		if len(commits) == 0 || m.detail == nil {
+			return nil, true
+		}
+		number := m.detail.Number
+		return func() tea.Msg { return OpenCommitDiffMsg{Number: number, Commits: commits} }, true
+	default:
+		return nil, false
+	}
+	return nil, true
+}
+
+// selectedCommitURL returns the web page of the commit under the cursor.
+func (m *PRDetailModel) selectedCommitURL() string {
+	if m.detail == nil || m.detail.URL == "" || len(m.commits.commits) == 0 {
+		return ""
+	}
+	c := m.commits.commits[min(max(m.commits.cursor, 0), len(m.commits.commits)-1)]
+	return m.detail.URL + "/commits/" + c.SHA
+}
+
+// CommitScope describes a run of commits, oldest first, for the diff
+// view's header.
+func CommitScope(commits []domain.Commit) string {
+	switch len(commits) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf("commit %s · %s", commits[0].ShortSHA(), commits[0].Title)
+	default:
+		return fmt.Sprintf("commits %s..%s (%d)", commits[0].ShortSHA(), commits[len(commits)-1].ShortSHA(), len(commits))
+	}
+}
+
+// renderCommitsTab renders the Commits tab content.
+func (m *PRDetailModel) renderCommitsTab(height int) string {
+	t := m.styles.Theme
+	c := &m.commits
+	muted := lipgloss.NewStyle().Foreground(t.Muted).Italic(true)
+
+	switch {
+	case c.loading:
+		return ensureExactHeight(muted.Render(m.spinner.View()+" Loading commits..."), height, m.width)
+	case errors.Is(c.err, errors.ErrUnsupported):
+		return ensureExactHeight(muted.Render("Commits are not supported by this backend."), height, m.width)
+	case c.err != nil:
+		return ensureExactHeight(lipgloss.NewStyle().Foreground(t.Error).Render("Error loading commits: "+c.err.Error()), height, m.width)
+	case len(c.commits) == 0:
+		return ensureExactHeight(muted.Render("No commits."), height, m.width)
+	}
+
+	selected := m.selectedCommits()
+	first, last := c.cursor, c.cursor
+	if c.anchor >= 0 {
+		first, last = min(c.anchor, c.cursor), max(c.anchor, c.cursor)
+	}
+
+	hint := "Enter diff  v mark range  o open"
+	if c.anchor >= 0 {
+		hint = fmt.Sprintf("%d commits selected  Enter diff range  v clear", len(selected))
+	}
+	lines := []string{lipgloss.NewStyle().Bold(true).Foreground(t.Fg).Render(fmt.Sprintf("%d commits", len(c.commits))) +
+		"  " + lipgloss.NewStyle().Foreground(t.Muted).Render(hint), ""}
+
+	visibleHeight := max(1, height-len(lines))
+	start := 0
+	if c.cursor >= visibleHeight {
+		start = c.cursor - visibleHeight + 1
+	}
+	end := min(len(c.commits), start+visibleHeight)
+
+	shaStyle := lipgloss.NewStyle().Foreground(t.Warning)
+	metaStyle := lipgloss.NewStyle().Foreground(t.Muted)
+	rangeStyle := lipgloss.NewStyle().Foreground(t.Info)
+	for i := start; i < end; i++ {
+		commit := c.commits[i]
+		cursor := "  "
+		if i == c.cursor {
+			cursor = lipgloss.NewStyle().Foreground(t.Primary).Bold(true).Render("▸ ")
+		}
+		mark := " "
+		if c.anchor >= 0 && i >= first && i <= last {
+			mark = rangeStyle.Render("┃")
+		}
+		meta := metaStyle.Render(fmt.Sprintf("%s  %s", commit.Author, formatRelativeTime(commit.Date)))
+		line := fmt.Sprintf("%s%s %s %s ", cursor, mark, detailCIIcon(commit.CI), shaStyle.Render(commit.ShortSHA()))
+		title := truncateLine(commit.Title, max(10, m.width-lipgloss.Width(line)-lipgloss.Width(meta)-2))
+		gap := max(1, m.width-lipgloss.Width(line)-lipgloss.Width(title)-lipgloss.Width(meta))
+		lines = append(lines, line+title+strings.Repeat(" ", gap)+meta)
+	}
+
+	return ensureExactHeight(strings.Join(lines, "\n"), height, m.width)
+}
@@ -1,6 +1,7 @@
 package views
 
 import (
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -215,7 +216,7 @@ func TestDetailTabNavigation(t *testing.T) {
 	m.SetSize(120, 40)
 	m.SetDetail(testDetail())
 
-	// Tab forward through all tabs: Description → Checks → Files → Comments → Description
+	// Tab forward through all tabs: Description → Checks → Files → Comments → Commits → Description
 	tab := tea.KeyMsg{Type: tea.KeyTab}
 	m.Update(tab)
 	assert.Equal(t, TabChecks, m.tab)
@@ -226,6 +227,9 @@ func TestDetailTabNavigation(t *testing.T) {
 	m.Update(tab)
 	assert.Equal(t, TabComments, m.tab)
 
+	m.Update(tab)
+	assert.Equal(t, TabCommits, m.tab)
+
 	m.Update(tab)
 	assert.Equal(t, TabDescription, m.tab)
 }
@@ -238,7 +242,7 @@ func TestDetailShiftTabNavigation(t *testing.T) {
 	// Shift-tab wraps backward.
 	shiftTab := tea.KeyMsg{Type: tea.KeyShiftTab}
 	m.Update(shiftTab)
-	assert.Equal(t, TabComments, m.tab)
+	assert.Equal(t, TabCommits, m.tab)
 }
 
 func TestDetailScrolling(t *testing.T) {
@@ -648,6 +652,17 @@ func TestCommentPaneUnresolve(t *testing.T) {
 	assert.Equal(t, "thread-2", unresolve.ThreadID)
 }
 
+// typeText sends each rune of text as a key.
+func typeText(m *PRDetailModel, text string) {
+	for _, r := range text {
+		if r == '\n' {
+			m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			continue
+		}
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
 func TestCommentPaneReplyKey(t *testing.T) {
 	m := NewPRDetailModel(testStyles(), testKeys())
 	m.SetSize(120, 40)
@@ -657,15 +672,128 @@ func TestCommentPaneReplyKey(t *testing.T) {
 	m.tab = TabComments
 	m.commentCursor = 2
 
-	// 'r' in comments pane should produce ReplyToThreadMsg
+	// 'r' in comments pane opens the composer on the thread.
 	r := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}}
-	cmd := m.Update(r)
-	require.NotNil(t, cmd, "'r' key should produce a command in comments pane")
+	assert.Nil(t, m.Update(r))
+	require.True(t, m.IsInputActive())
+	assert.Contains(t, m.View(), "Reply to thread on plugin.go:42")
+
+	typeText(&m, "done")
+	cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	require.NotNil(t, cmd, "Ctrl+S should post the reply")
+	assert.False(t, m.IsInputActive())
 
 	msg := cmd()
-	reply, ok := msg.(ReplyToThreadMsg)
-	require.True(t, ok, "expected ReplyToThreadMsg, got %T", msg)
-	assert.Equal(t, "comment-1", reply.ThreadID)
+	reply, ok := msg.(AddInlineCommentMsg)
+	require.True(t, ok, "expected AddInlineCommentMsg, got %T", msg)
+	assert.Equal(t, 42, reply.Number)
+	assert.Equal(t, "comment-1", reply.Input.InReplyTo)
+	assert.Equal(t, "plugin.go", reply.Input.Path)
+	assert.Equal(t, "done", reply.Input.Body)
+}
+
+func TestCommentPaneQuoteReply(t *testing.T) {
+	m := NewPRDetailModel(testStyles(), testKeys())
+	m.SetSize(120, 40)
+	m.SetDetail(testDetail())
+	m.tab = TabComments
+	m.commentCursor = 1
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	require.True(t, m.IsInputActive())
+	typeText(&m, "Verified.")
+	cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	require.NotNil(t, cmd)
+
+	post, ok := cmd().(PostCommentMsg)
+	require.True(t, ok)
+	assert.Equal(t, "> Please verify the migration path.\n\nVerified.", post.Body)
+}
+
+func TestCommentPaneNewCommentFromAnyTab(t *testing.T) {
+	m := NewPRDetailModel(testStyles(), testKeys())
+	m.SetSize(120, 40)
+	m.SetDetail(testDetail())
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	require.True(t, m.IsInputActive())
+	assert.Contains(t, m.View(), "New comment on #42")
+
+	// Keys go to the composer, not to tab switching.
+	typeText(&m, "q 2")
+	assert.Equal(t, TabDescription, m.tab)
+
+	// Esc discards; blank text posts nothing.
+	m.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	assert.False(t, m.IsInputActive())
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	assert.Nil(t, m.Update(tea.KeyMsg{Type: tea.KeyCtrlS}))
+}
+
+func TestCommentPaneEditAndDeleteOwnComment(t *testing.T) {
+	m := NewPRDetailModel(testStyles(), testKeys())
+	m.SetSize(120, 40)
+	detail := testDetail()
+	thread := &detail.Discussion[2]
+	thread.Comments[1].NodeID = "PRRC_2"
+	thread.Comments[1].CanEdit = true
+	thread.Comments[1].CanDelete = true
+	m.SetDetail(detail)
+	m.tab = TabComments
+
+	// Someone else's comment: nothing to edit or delete.
+	m.commentCursor = 1
+	assert.Nil(t, m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}}))
+	assert.False(t, m.IsInputActive())
+	assert.Nil(t, m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}}))
+
+	m.commentCursor = 2
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	require.True(t, m.IsInputActive())
+	typeText(&m, " Done.")
+	cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	require.NotNil(t, cmd)
+	edit, ok := cmd().(EditCommentMsg)
+	require.True(t, ok)
+	assert.Equal(t, domain.CommentRef{NodeID: "PRRC_2", Kind: domain.DiscussionInlineThread}, edit.Ref)
+	assert.Equal(t, "Good catch, fixing. Done.", edit.Body)
+
+	cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}})
+	require.NotNil(t, cmd)
+	del, ok := cmd().(DeleteCommentMsg)
+	require.True(t, ok)
+	assert.Equal(t, "PRRC_2", del.Ref.NodeID)
+	assert.Equal(t, "indrasvat", del.Author)
+}
+
+func TestCommentPaneReactions(t *testing.T) {
+	m := NewPRDetailModel(testStyles(), testKeys())
+	m.SetSize(120, 40)
+	detail := testDetail()
+	detail.Discussion[1].Comments[0].NodeID = "IC_1"
+	detail.Discussion[1].Comments[0].Reactions = []domain.Reaction{{Content: domain.ReactionRocket, Count: 3, ViewerReacted: true}}
+	m.SetDetail(detail)
+	m.tab = TabComments
+	m.commentCursor = 1
+	assert.Contains(t, m.View(), "🚀 3")
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+	require.True(t, m.IsInputActive())
+	assert.Contains(t, m.View(), "1 👍")
+	cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'7'}})
+	require.NotNil(t, cmd)
+	assert.False(t, m.IsInputActive())
+	react, ok := cmd().(ToggleReactionMsg)
+	require.True(t, ok)
+	assert.Equal(t, domain.ReactionRocket, react.Content)
+	assert.Equal(t, domain.DiscussionComment, react.Kind)
+	assert.Equal(t, "IC_1", react.Comment.NodeID)
+
+	// Any other key closes the picker without reacting.
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+	assert.Nil(t, m.Update(tea.KeyMsg{Type: tea.KeyEscape}))
+	assert.False(t, m.IsInputActive())
+	assert.Equal(t, TabComments, m.tab)
 }
 
 func TestCommentPaneViewRendering(t *testing.T) {
@@ -746,3 +874,158 @@ func TestGetPRNumber(t *testing.T) {
 	m.pendingNum = 123
 	assert.Equal(t, 123, m.GetPRNumber())
 }
+
+func TestCommentPaneReplyInExternalEditor(t *testing.T) {
+	m := NewPRDetailModel(testStyles(), testKeys())
+	m.SetSize(120, 40)
+	m.SetDetail(testDetail())
+	m.tab = TabComments
+	m.commentCursor = 2
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlE})
+	require.NotNil(t, cmd)
+	open, ok := cmd().(OpenEditorMsg)
+	require.True(t, ok)
+	assert.Equal(t, "plugin.go:42\n\n@alice wrote:\n> Needs error handling here.\n\n@indrasvat wrote:\n> Good catch, fixing.", open.Context)
+
+	m.Update(EditorDoneMsg{Body: "Fixed in the next push."})
+	assert.Contains(t, m.StatusHint(), "Esc back to editing")
+	assert.Contains(t, m.View(), "Fixed in the next")
+
+	cmd = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	require.NotNil(t, cmd)
+	reply, ok := cmd().(AddInlineCommentMsg)
+	require.True(t, ok)
+	assert.Equal(t, "Fixed in the next push.", reply.Input.Body)
+	assert.Equal(t, "comment-1", reply.Input.InReplyTo)
+}
+
+func TestCommentComposerInsertsSnippet(t *testing.T) {
+	m := NewPRDetailModel(testStyles(), testKeys())
+	m.SetSize(120, 40)
+	m.SetDetail(testDetail())
+	m.SetSnippets([]domain.Snippet{{Name: "thanks", Body: "Thanks @{{author}}!"}})
+	m.tab = TabComments
+	m.commentCursor = 2
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	assert.Contains(t, m.StatusHint(), "Ctrl+T")
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	assert.Contains(t, m.View(), "thanks")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	require.NotNil(t, m.compose)
+	assert.Nil(t, m.compose.picker)
+	assert.Equal(t, "Thanks @indrasvat!", m.compose.buffer)
+}
+
+func testCommits() []domain.Commit {
+	return []domain.Commit{
+		{SHA: "aaaaaaa1", Parent: "base", Title: "Add registry", Author: "indrasvat", CI: domain.CIPass},
+		{SHA: "bbbbbbb2", Parent: "aaaaaaa1", Title: "Load plugins", Author: "indrasvat", CI: domain.CIFail},
+		{SHA: "ccccccc3", Parent: "bbbbbbb2", Title: "Fix lint", Author: "alice"},
+	}
+}
+
+func TestCommitsTabLoadsOnce(t *testing.T) {
+	m := NewPRDetailModel(testStyles(), testKeys())
+	m.SetSize(120, 40)
+	m.SetDetail(testDetail())
+
+	cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'5'}})
+	require.NotNil(t, cmd)
+	var load tea.Msg
+	for _, c := range cmd().(tea.BatchMsg) {
+		if msg, ok := c().(LoadCommitsMsg); ok {
+			load = msg
+		}
+	}
+	assert.Equal(t, LoadCommitsMsg{Number: 42}, load)
+	assert.Contains(t, m.View(), "Loading commits")
+
+	m.SetCommits(CommitsLoadedMsg{Number: 42, Commits: testCommits()})
+	view := m.View()
+	assert.Contains(t, view, "Commits (3)")
+	assert.Contains(t, view, "bbbbbbb")
+	assert.Contains(t, view, "Load plugins")
+
+	assert.Nil(t, m.Update(tea.KeyMsg{Type: tea.KeyTab}))
+	assert.Nil(t, m.Update(tea.KeyMsg{Type: tea.KeyShiftTab}), "commits are loaded once per head")
+
+	d := testDetail()
+	d.Branch.HeadSHA = "ddddddd4"
+	m.SetDetail(d)
+	assert.NotNil(t, m.LoadCommits(), "a new head reloads the commits")
+}
+
+func TestCommitsTabOpensCommitAndRangeDiffs(t *testing.T) {
+	m := NewPRDetailModel(testStyles(), testKeys())
+	m.SetSize(120, 40)
+	m.SetDetail(testDetail())
+	m.tab = TabCommits
+	m.LoadCommits()
+	m.SetCommits(CommitsLoadedMsg{Number: 42, Commits: testCommits()})
+
+	key := func(r rune) tea.Cmd { return m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}) }
+	key('j')
+	cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	open := cmd().(OpenCommitDiffMsg)
+	assert.Equal(t, 42, open.Number)
+	assert.Equal(t, testCommits()[1:2], open.Commits)
+	assert.Equal(t, "commit bbbbbbb · Load plugins", CommitScope(open.Commits))
+
+	key('v')
+	key('k')
+	assert.Contains(t, m.View(), "2 commits selected")
+	open = m.Update(tea.KeyMsg{Type: tea.KeyEnter})().(OpenCommitDiffMsg)
+	assert.Equal(t, testCommits()[:2], open.Commits)
+	assert.Equal(t, "commits aaaaaaa..bbbbbbb (2)", CommitScope(open.Commits))
+
+	key('v')
+	assert.Len(t, m.selectedCommits(), 1, "v again clears the range")
+
+	browser := key('o')().(OpenBrowserMsg)
+	assert.Equal(t, "https://example.com/pr/42/commits/aaaaaaa1", browser.URL)
+}
+
+func TestCommitsTabUnsupported(t *testing.T) {
+	m := NewPRDetailModel(testStyles(), testKeys())
+	m.SetSize(120, 40)
+	m.SetDetail(testDetail())
+	m.tab = TabCommits
+	m.LoadCommits()
+	m.SetCommits(CommitsLoadedMsg{Number: 42, Err: errors.ErrUnsupported})
+	assert.Contains(t, m.View(), "not supported")
+}
+
+func TestPRDetailMetadataKeysFollowKeyMap(t *testing.T) {
+	keys := testKeys()
+	keys.ApplyOverrides(map[string]string{"edit_labels": "ctrl+l"})
+	m := NewPRDetailModel(testStyles(), keys)
+	m.SetDetail(testDetail())
+
+	assert.Nil(t, m.Update(runeKey('L')), "the default key is rebound")
+	cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlL})
+	require.NotNil(t, cmd)
+	assert.Equal(t, EditMetadataMsg{Kind: MetadataLabels}, cmd())
+	cmd = m.Update(runeKey('Q'))
+	require.NotNil(t, cmd)
+	assert.Equal(t, EditMetadataMsg{Kind: MetadataReviewers}, cmd())
+}
+
+func TestPRDetailLifecycleKeysFollowKeyMap(t *testing.T) {
+	keys := testKeys()
+	keys.ApplyOverrides(map[string]string{"close_pr": "ctrl+x"})
+	m := NewPRDetailModel(testStyles(), keys)
+	m.SetDetail(testDetail())
+
+	assert.Nil(t, m.Update(runeKey('C')), "the default key is rebound")
+	cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlX})
+	require.NotNil(t, cmd)
+	assert.Equal(t, ClosePRMsg{PR: testDetail().PR}, cmd())
+	cmd = m.Update(runeKey('W'))
+	require.NotNil(t, cmd)
+	assert.Equal(t, ToggleDraftMsg{PR: testDetail().PR}, cmd())
+}
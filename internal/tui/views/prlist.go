@@ -37,10 +37,14 @@ type PRListModel struct {
 	panelLabel    string
 
 	// Pagination state
-	page        int  // current page (1-based)
-	perPage     int  // items per page
-	hasMore     bool // are there more PRs to load?
-	loadingMore bool // currently loading more PRs?
+	page        int    // current page (1-based)
+	perPage     int    // items per page
+	hasMore     bool   // are there more PRs to load?
+	loadingMore bool   // currently loading more PRs?
+	nextCursor  string // where the last loaded page ended
+
+	// CI hydration: PR numbers whose CI was requested since the last load
+	ciRequested map[int]bool
 
 	// Spinner animation
 	spinnerFrame int
@@ -105,9 +109,20 @@ func (m *PRListModel) SetPRs(prs []domain.PR) {
 	// If we got fewer than perPage, there are no more pages
 	m.hasMore = len(prs) >= m.perPage
 	m.loadingMore = false
+	m.nextCursor = ""
+	m.ciRequested = nil
 	m.applyFilter()
 }
 
+// SetNextCursor records where the last loaded page ended. A cursor means the
+// reader knows more PRs follow, even if drafts left the page short.
+func (m *PRListModel) SetNextCursor(cursor string) {
+	m.nextCursor = cursor
+	if cursor != "" {
+		m.hasMore = true
+	}
+}
+
 // AppendPRs adds more PRs to the existing list (pagination).
 func (m *PRListModel) AppendPRs(prs []domain.PR, hasMore bool) {
 	m.prs = append(m.prs, prs...)
@@ -116,6 +131,73 @@ func (m *PRListModel) AppendPRs(prs []domain.PR, hasMore bool) {
 	m.applyFilter()
 }
 
+// TakeCIHydration returns the loaded PRs whose CI status is unknown or still
+// pending and has not been requested since the list was loaded, and marks
+// them requested. Rows hidden by filters or scrolled out of view are
+// included, so CI sort and filters see every loaded PR.
+func (m *PRListModel) TakeCIHydration() []int {
+	if m.ciRequested == nil {
+		m.ciRequested = make(map[int]bool)
+	}
+	var numbers []int
+	for _, pr := range m.prs {
+		if m.ciRequested[pr.Number] {
+			continue
+		}
+		if pr.CI != "" && pr.CI != domain.CIPending {
+			continue
+		}
+		m.ciRequested[pr.Number] = true
+		numbers = append(numbers, pr.Number)
+	}
+	return numbers
+}
+
+// SetCIStatuses applies hydrated CI statuses, re-filtering and re-sorting the
+// list while keeping the selected PR under the cursor.
+func (m *PRListModel) SetCIStatuses(statuses map[int]domain.CIStatus) {
+	changed := false
+	for i := range m.prs {
+		if ci, ok := statuses[m.prs[i].Number]; ok && m.prs[i].CI != ci {
+			m.prs[i].CI = ci
+			changed = true
+		}
+	}
+	if changed {
+		m.refilterKeepingSelection()
+	}
+}
+
+// SetPRLabels replaces a loaded PR's labels, returning the previous ones.
+func (m *PRListModel) SetPRLabels(number int, labels []string) (prev []string, ok bool) {
+	for i := range m.prs {
+		if m.prs[i].Number == number {
+			prev = m.prs[i].Labels
+			m.prs[i].Labels = labels
+			m.refilterKeepingSelection()
+			return prev, true
+		}
+	}
+	return nil, false
+}
+
+// refilterKeepingSelection re-filters and re-sorts the list, keeping the
+// selected PR under the cursor.
+func (m *PRListModel) refilterKeepingSelection() {
+	selected := m.SelectedPR()
+	m.applyFilter()
+	if selected == nil {
+		return
+	}
+	for i, pr := range m.filtered {
+		if pr.Number == selected.Number {
+			m.cursor = i
+			m.ensureVisible()
+			return
+		}
+	}
+}
+
 // IsLoadingMore returns true if more PRs are being loaded.
 func (m *PRListModel) IsLoadingMore() bool {
 	return m.loadingMore
@@ -156,6 +238,7 @@ func (m *PRListModel) SetFilter(opts domain.ListOpts) {
 	m.page = 1
 	m.hasMore = true
 	m.loadingMore = false
+	m.nextCursor = ""
 	m.applyFilter()
 }
 
@@ -173,6 +256,16 @@ func (m *PRListModel) SelectedPR() *domain.PR {
 	return &pr
 }
 
+// PR returns the loaded PR with the given number, filtered out or not.
+func (m *PRListModel) PR(number int) (domain.PR, bool) {
+	for _, pr := range m.prs {
+		if pr.Number == number {
+			return pr, true
+		}
+	}
+	return domain.PR{}, false
+}
+
 // IsLoading returns true if the PR list is still loading.
 func (m *PRListModel) IsLoading() bool {
 	return m.loading
@@ -196,8 +289,9 @@ func (m *PRListModel) TotalPRs() int {
 // PRListMsg types for communication with parent.
 type (
 	PRsLoadedMsg struct {
-		PRs []domain.PR
-		Err error
+		PRs        []domain.PR
+		NextCursor string
+		Err        error
 	}
 	OpenPRMsg     struct{ Number int }
 	CheckoutPRMsg struct {
@@ -229,6 +323,7 @@ func (m *PRListModel) Update(msg tea.Msg) tea.Cmd {
 		return m.handleKey(msg)
 	case PRsLoadedMsg:
 		m.SetPRs(msg.PRs)
+		m.SetNextCursor(msg.NextCursor)
 	case SpinnerTickMsg:
 		if m.loadingMore {
 			m.spinnerFrame++
@@ -257,6 +352,10 @@ func (m *PRListModel) handleKey(msg tea.KeyMsg) tea.Cmd {
 			if !m.selectionMode {
 				return m.toggleQuickFilter(quickFilterNeedsReview)
 			}
+		case 'N':
+			if !m.selectionMode {
+				return func() tea.Msg { return NewPRMsg{} }
+			}
 		case 'v':
 			m.selectionMode = !m.selectionMode
 			if !m.selectionMode {
@@ -356,6 +455,11 @@ func (m *PRListModel) handleKey(msg tea.KeyMsg) tea.Cmd {
 		if pr := m.SelectedPR(); pr != nil {
 			return func() tea.Msg { return CheckoutPRMsg{Number: pr.Number, Branch: pr.Branch.Head} }
 		}
+	case key.Matches(msg, m.keys.Merge):
+		if pr := m.SelectedPR(); pr != nil {
+			target := *pr
+			return func() tea.Msg { return MergePRMsg{PR: target} }
+		}
 	case key.Matches(msg, m.keys.Yank):
 		if m.selectionMode && len(m.selected) > 0 {
 			urls := m.selectedURLs()
@@ -497,8 +601,9 @@ func (m *PRListModel) checkLoadMore() tea.Cmd {
 	distanceFromBottom := len(m.filtered) - 1 - m.cursor
 	if distanceFromBottom <= 5 {
 		nextPage := m.page + 1
+		cursor := m.nextCursor
 		return func() tea.Msg {
-			return LoadMorePRsMsg{Page: nextPage}
+			return LoadMorePRsMsg{Page: nextPage, Cursor: cursor}
 		}
 	}
 	return nil
@@ -101,6 +101,52 @@ func TestSetPRs(t *testing.T) {
 	assert.Len(t, m.filtered, 5)
 }
 
+func TestLoadMoreCarriesCursor(t *testing.T) {
+	m := NewPRListModel(testStyles(), testKeys())
+	m.SetSize(120, 30)
+	m.Update(PRsLoadedMsg{PRs: testPRs(), NextCursor: "c1"})
+	assert.True(t, m.HasMore(), "a cursor means more PRs even on a short page")
+
+	cmd := m.checkLoadMore()
+	require.NotNil(t, cmd)
+	assert.Equal(t, LoadMorePRsMsg{Page: 2, Cursor: "c1"}, cmd())
+
+	m.SetFilter(domain.ListOpts{State: domain.PRStateClosed})
+	assert.Empty(t, m.nextCursor, "filters restart pagination")
+}
+
+func TestTakeCIHydrationAllLoadedRowsOnce(t *testing.T) {
+	m := NewPRListModel(testStyles(), testKeys())
+	m.SetSize(120, 5) // two visible rows
+	m.SetPRs(testPRsForSort())
+
+	assert.ElementsMatch(t, []int{1, 2, 3, 4, 5}, m.TakeCIHydration(), "rows out of view are included")
+	assert.Empty(t, m.TakeCIHydration(), "rows are requested once per load")
+
+	m.AppendPRs([]domain.PR{{Number: 6, State: domain.PRStateOpen}, {Number: 7, State: domain.PRStateOpen, CI: domain.CIPending}}, false)
+	assert.Equal(t, []int{6, 7}, m.TakeCIHydration(), "appended pages are requested")
+
+	m.SetPRs([]domain.PR{{Number: 9, State: domain.PRStateOpen, CI: domain.CIPass}})
+	assert.Empty(t, m.TakeCIHydration(), "rows with a settled CI status are skipped")
+}
+
+func TestSetCIStatusesRefiltersAndKeepsSelection(t *testing.T) {
+	m := NewPRListModel(testStyles(), testKeys())
+	m.SetSize(120, 30)
+	m.SetFilter(domain.ListOpts{State: domain.PRStateOpen, CI: domain.CIFail})
+	m.SetPRs(testPRsForSort())
+	assert.Empty(t, m.FilteredPRs(), "unknown CI does not match a CI filter")
+
+	m.SetCIStatuses(map[int]domain.CIStatus{1: domain.CIFail, 2: domain.CIFail, 5: domain.CIFail})
+	require.Len(t, m.FilteredPRs(), 3)
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	require.Equal(t, 2, m.SelectedPR().Number)
+
+	m.SetCIStatuses(map[int]domain.CIStatus{5: domain.CIPass})
+	require.Len(t, m.FilteredPRs(), 2)
+	assert.Equal(t, 2, m.SelectedPR().Number, "selection follows the PR, not the row")
+}
+
 func TestSelectedPR(t *testing.T) {
 	m := NewPRListModel(testStyles(), testKeys())
 	m.SetPRs(testPRs())
@@ -283,9 +283,7 @@ func (m *RepoSwitcherModel) rebuildVisible() {
 
 	// Ghost add entry: when query looks like owner/repo and no exact match.
 	if strings.Contains(m.query, "/") && len(m.query) >= 3 {
-		parts := strings.SplitN(m.query, "/", 2)
-		if len(parts) == 2 && parts[0] != "" && parts[1] != "" {
-			ghostRepo := domain.RepoRef{Owner: parts[0], Name: parts[1]}
+		if ghostRepo, err := domain.ParseRepoRef(m.query); err == nil {
 			// Check if it already exists.
 			found := false
 			for _, v := range m.visible {
@@ -1,6 +1,8 @@
 package views
 
 import (
+	"strings"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
@@ -19,6 +21,12 @@ type ReviewModel struct {
 	form     *huh.Form
 	action   domain.ReviewAction
 	body     string
+	pending  int  // pending inline comments submitted with the review
+	preview  bool // show the body from $EDITOR rendered, ready to submit
+
+	author   string           // PR author, for snippet placeholders
+	snippets []domain.Snippet // canned responses for the picker
+	picker   *snippetPicker   // open snippet picker, if any
 }
 
 // SetStyles updates the styles without losing state.
@@ -48,33 +56,61 @@ func (m *ReviewModel) SetPRNumber(n int) {
 	m.prNumber = n
 	m.action = domain.ReviewActionComment
 	m.body = ""
+	m.pending = 0
+	m.preview = false
+	m.picker = nil
 	m.initForm()
 }
 
-// initForm creates the huh form with Select and Text fields.
-func (m *ReviewModel) initForm() {
-	// Action options
-	actionOptions := []huh.Option[domain.ReviewAction]{
-		huh.NewOption("Comment", domain.ReviewActionComment),
-		huh.NewOption("Approve ✓", domain.ReviewActionApprove),
-		huh.NewOption("Request Changes !", domain.ReviewActionRequestChanges),
-	}
+// SetPRAuthor sets the PR author used for snippet placeholders.
+func (m *ReviewModel) SetPRAuthor(author string) { m.author = author }
+
+// SetSnippets sets the canned responses offered by the snippet picker.
+func (m *ReviewModel) SetSnippets(snippets []domain.Snippet) { m.snippets = snippets }
+
+// SetTemplate prefills the review body with a template, its placeholders
+// filled in for the PR under review.
+func (m *ReviewModel) SetTemplate(body string) {
+	m.body = m.snippetVars().Expand(body)
+	m.initForm()
+}
+
+// snippetVars returns the placeholder values for the PR under review.
+func (m *ReviewModel) snippetVars() domain.SnippetVars {
+	return domain.SnippetVars{Author: m.author, Number: m.prNumber}
+}
+
+// SetPendingCount sets how many pending inline comments the review will
+// include.
+func (m *ReviewModel) SetPendingCount(n int) { m.pending = n }
 
+// reviewActions lists the review actions with their labels, in the order
+// the form offers them.
+var reviewActions = []huh.Option[domain.ReviewAction]{
+	huh.NewOption("Comment", domain.ReviewActionComment),
+	huh.NewOption("Approve ✓", domain.ReviewActionApprove),
+	huh.NewOption("Request Changes !", domain.ReviewActionRequestChanges),
+}
+
+// initForm creates the huh form with Select and Text fields, keeping the
+// chosen action and body.
+func (m *ReviewModel) initForm() {
 	m.form = huh.NewForm(
 		huh.NewGroup(
 			huh.NewSelect[domain.ReviewAction]().
 				Title("Review Action").
 				Description("Select the type of review to submit").
-				Options(actionOptions...).
+				Options(reviewActions...).
 				Value(&m.action).
 				Key("action"),
 			huh.NewText().
 				Title("Review Body").
-				Description("Enter your review comments (optional)").
+				Description(m.bodyDescription()).
 				Placeholder("Add your review comments here...").
 				Value(&m.body).
 				Lines(8).
 				CharLimit(4000).
+				ExternalEditor(false). // Ctrl+E goes through the app's editor and preview
 				Key("body"),
 			huh.NewConfirm().
 				Title("Submit Review?").
@@ -86,7 +122,7 @@ func (m *ReviewModel) initForm() {
 	)
 
 	// Apply custom styling
-	m.form.WithTheme(m.createTheme())
+	m.form.WithTheme(formTheme(m.styles))
 	if m.width > 4 {
 		m.form.WithWidth(m.width - 4) // Account for padding
 	}
@@ -95,9 +131,18 @@ func (m *ReviewModel) initForm() {
 	}
 }
 
-// createTheme returns a huh theme based on the app's styles.
-func (m *ReviewModel) createTheme() *huh.Theme {
-	t := m.styles.Theme
+// bodyDescription describes the body field and its shortcuts.
+func (m *ReviewModel) bodyDescription() string {
+	desc := "Enter your review comments (optional) · Ctrl+E opens $EDITOR"
+	if len(m.snippets) > 0 {
+		desc += " · Ctrl+T inserts a snippet"
+	}
+	return desc
+}
+
+// formTheme returns a huh theme based on the app's styles.
+func formTheme(styles core.Styles) *huh.Theme {
+	t := styles.Theme
 	theme := huh.ThemeDracula()
 
 	// Customize to match vivecaka theme
@@ -140,9 +185,30 @@ func (m *ReviewModel) Update(msg tea.Msg) tea.Cmd {
 	// Check for Escape key to cancel (before nil form check so Escape
 	// always works, even if the form hasn't initialized yet).
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
-		if keyMsg.Type == tea.KeyEscape {
+		switch {
+		case m.picker != nil:
+			return m.handlePickerKey(keyMsg)
+		case m.preview:
+			return m.handlePreviewKey(keyMsg)
+		}
+		switch keyMsg.Type {
+		case tea.KeyEscape:
 			return func() tea.Msg { return CloseReviewMsg{} }
+		case tea.KeyCtrlE:
+			return openEditor(m.body, "")
+		case tea.KeyCtrlT:
+			if len(m.snippets) > 0 {
+				m.picker = newSnippetPicker(m.snippets)
+			}
+			return nil
+		}
+	}
+	if done, ok := msg.(EditorDoneMsg); ok {
+		if done.Err == nil {
+			m.body = done.Body
+			m.preview = true
 		}
+		return nil
 	}
 
 	if m.form == nil {
@@ -177,6 +243,44 @@ func (m *ReviewModel) Update(msg tea.Msg) tea.Cmd {
 	return cmd
 }
 
+// handlePreviewKey submits the previewed review, reopens the editor, or
+// goes back to the form with the body filled in.
+func (m *ReviewModel) handlePreviewKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyCtrlS:
+		review := domain.Review{Action: m.action, Body: m.body}
+		n := m.prNumber
+		return func() tea.Msg { return SubmitReviewMsg{Number: n, Review: review} }
+	case tea.KeyCtrlE:
+		return openEditor(m.body, "")
+	case tea.KeyEscape:
+		m.preview = false
+		return m.editBody()
+	}
+	return nil
+}
+
+// handlePickerKey drives the snippet picker and inserts the chosen snippet
+// into the body.
+func (m *ReviewModel) handlePickerKey(msg tea.KeyMsg) tea.Cmd {
+	s, done := m.picker.handleKey(msg)
+	if !done {
+		return nil
+	}
+	m.picker = nil
+	if s == nil {
+		return nil
+	}
+	m.body = insertSnippet(m.body, *s, m.snippetVars())
+	return m.editBody()
+}
+
+// editBody rebuilds the form around the current body and focuses it.
+func (m *ReviewModel) editBody() tea.Cmd {
+	m.initForm()
+	return tea.Batch(m.form.Init(), m.form.NextField())
+}
+
 // View renders the review form.
 func (m *ReviewModel) View() string {
 	t := m.styles.Theme
@@ -185,10 +289,23 @@ func (m *ReviewModel) View() string {
 		Bold(true).
 		MarginBottom(1)
 
+	title := "Submit Review for PR #" + itoa(m.prNumber)
+	switch {
+	case m.pending == 1:
+		title += " · 1 pending comment"
+	case m.pending > 1:
+		title += " · " + itoa(m.pending) + " pending comments"
+	}
+
 	var content string
-	if m.form != nil {
+	switch {
+	case m.preview:
+		content = m.renderPreview()
+	case m.form != nil && m.picker != nil:
+		content = m.form.View() + "\n" + m.picker.view(m.styles, "", m.width-2)
+	case m.form != nil:
 		content = m.form.View()
-	} else {
+	default:
 		content = "Loading form..."
 	}
 
@@ -198,11 +315,27 @@ func (m *ReviewModel) View() string {
 		Padding(1).
 		Render(lipgloss.JoinVertical(
 			lipgloss.Left,
-			titleStyle.Render("Submit Review for PR #"+itoa(m.prNumber)),
+			titleStyle.Render(title),
 			content,
 		))
 }
 
+// renderPreview renders the chosen action and the markdown body.
+func (m *ReviewModel) renderPreview() string {
+	t := m.styles.Theme
+	label := m.action.String()
+	for _, o := range reviewActions {
+		if o.Value == m.action {
+			label = o.Key
+		}
+	}
+	hint := lipgloss.NewStyle().Foreground(t.Muted)
+	lines := []string{lipgloss.NewStyle().Foreground(t.Primary).Bold(true).Render("Preview · " + label), ""}
+	lines = append(lines, renderPreview(m.body, m.width-4)...)
+	lines = append(lines, "", hint.Render("Ctrl+S submit  Ctrl+E $EDITOR  Esc back to form"))
+	return strings.Join(lines, "\n")
+}
+
 // itoa converts int to string (simple helper).
 func itoa(n int) string {
 	if n == 0 {
@@ -77,6 +77,17 @@ func TestReviewView(t *testing.T) {
 	assert.NotEmpty(t, view, "view should not be empty")
 }
 
+func TestReviewViewShowsPendingCount(t *testing.T) {
+	m := NewReviewModel(testStyles(), testKeys())
+	m.SetSize(100, 24)
+	m.SetPRNumber(42)
+	m.SetPendingCount(3)
+	assert.Contains(t, m.View(), "3 pending comments")
+
+	m.SetPRNumber(43)
+	assert.NotContains(t, m.View(), "pending")
+}
+
 func TestReviewViewBeforeInit(t *testing.T) {
 	m := NewReviewModel(testStyles(), testKeys())
 	m.SetSize(80, 24)
@@ -121,3 +132,57 @@ func TestItoa(t *testing.T) {
 		assert.Equal(t, tt.want, got)
 	}
 }
+
+func TestReviewBodyInExternalEditor(t *testing.T) {
+	m := NewReviewModel(testStyles(), testKeys())
+	m.SetSize(80, 24)
+	m.SetPRNumber(42)
+	m.action = domain.ReviewActionApprove
+
+	cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlE})
+	require.NotNil(t, cmd)
+	_, ok := cmd().(OpenEditorMsg)
+	require.True(t, ok, "Ctrl+E opens $EDITOR")
+
+	m.Update(EditorDoneMsg{Body: "Ship it."})
+	view := m.View()
+	assert.Contains(t, view, "Preview · Approve ✓")
+	assert.Contains(t, view, "Ship")
+
+	// Esc goes back to the form with the body kept.
+	cmd = m.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	if cmd != nil {
+		_, closed := cmd().(CloseReviewMsg)
+		assert.False(t, closed, "Esc in the preview should not close the review")
+	}
+	assert.Equal(t, "Ship it.", m.body)
+
+	m.Update(EditorDoneMsg{Body: "Ship it!"})
+	cmd = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	require.NotNil(t, cmd)
+	submit, ok := cmd().(SubmitReviewMsg)
+	require.True(t, ok)
+	assert.Equal(t, 42, submit.Number)
+	assert.Equal(t, domain.Review{Action: domain.ReviewActionApprove, Body: "Ship it!"}, submit.Review)
+}
+
+func TestReviewTemplateAndSnippets(t *testing.T) {
+	m := NewReviewModel(testStyles(), testKeys())
+	m.SetSize(80, 24)
+	m.SetPRNumber(42)
+	m.SetPRAuthor("octocat")
+	m.SetSnippets([]domain.Snippet{{Name: "lgtm", Body: "LGTM, thanks @{{author}}"}})
+	m.SetTemplate("## Review of #{{number}}\n")
+	assert.Equal(t, "## Review of #42\n", m.body)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	require.NotNil(t, m.picker)
+	assert.Contains(t, m.View(), "lgtm")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, m.picker)
+	assert.Equal(t, "## Review of #42\nLGTM, thanks @octocat", m.body)
+
+	// A new review starts from an empty body again.
+	m.SetPRNumber(43)
+	assert.Empty(t, m.body)
+}
@@ -1,6 +1,7 @@
 package views
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -20,6 +21,20 @@ func reviewFileMarker(file reviewprogress.File) string {
 	}
 }
 
+func reviewHunkMarker(hunk reviewprogress.Hunk) string {
+	if hunk.Viewed {
+		return "✓"
+	}
+	return "●"
+}
+
+func reviewHunkStateText(hunk reviewprogress.Hunk) string {
+	if hunk.Viewed {
+		return "viewed"
+	}
+	return "unviewed"
+}
+
 func reviewFileStateText(file reviewprogress.File) string {
 	parts := make([]string, 0, 2)
 	switch {
@@ -29,8 +44,11 @@ func reviewFileStateText(file reviewprogress.File) string {
 		parts = append(parts, "changed since visit")
 	}
 
-	if file.Viewed {
+	switch {
+	case file.Viewed:
 		parts = append(parts, "viewed")
+	case file.ViewedHunks > 0:
+		parts = append(parts, fmt.Sprintf("%d/%d hunks viewed", file.ViewedHunks, len(file.Hunks)))
 	}
 
 	if len(parts) == 0 {
@@ -48,8 +66,11 @@ func reviewFileMeta(file reviewprogress.File, theme core.Theme) string {
 		parts = append(parts, lipgloss.NewStyle().Foreground(theme.Info).Render("changed since visit"))
 	}
 
-	if file.Viewed {
+	switch {
+	case file.Viewed:
 		parts = append(parts, lipgloss.NewStyle().Foreground(theme.Success).Render("viewed"))
+	case file.ViewedHunks > 0:
+		parts = append(parts, lipgloss.NewStyle().Foreground(theme.Success).Render(fmt.Sprintf("%d/%d hunks viewed", file.ViewedHunks, len(file.Hunks))))
 	}
 
 	if len(parts) == 0 {
@@ -0,0 +1,109 @@
+package views
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/tui/core"
+)
+
+// maxPickerRows is how many snippets the picker lists at once.
+const maxPickerRows = 6
+
+// snippetPicker fuzzy-filters the configured snippets by name for
+// insertion into a comment or review body.
+type snippetPicker struct {
+	snippets []domain.Snippet
+	query    string
+	matches  []domain.Snippet
+	cursor   int
+}
+
+// newSnippetPicker opens a picker over snippets.
+func newSnippetPicker(snippets []domain.Snippet) *snippetPicker {
+	p := &snippetPicker{snippets: snippets}
+	p.filter()
+	return p
+}
+
+// filter lists the snippets whose name fuzzy-matches the query.
+func (p *snippetPicker) filter() {
+	q := strings.ToLower(p.query)
+	p.matches = p.matches[:0]
+	for _, s := range p.snippets {
+		if fuzzyMatch(strings.ToLower(s.Name), q) {
+			p.matches = append(p.matches, s)
+		}
+	}
+	p.cursor = min(p.cursor, max(0, len(p.matches)-1))
+}
+
+// handleKey filters, moves and picks. done is true once the picker should
+// close; chosen is the picked snippet, or nil when it was dismissed.
+func (p *snippetPicker) handleKey(msg tea.KeyMsg) (chosen *domain.Snippet, done bool) {
+	switch msg.Type {
+	case tea.KeyEscape, tea.KeyCtrlT:
+		return nil, true
+	case tea.KeyEnter:
+		if len(p.matches) == 0 {
+			return nil, true
+		}
+		s := p.matches[p.cursor]
+		return &s, true
+	case tea.KeyUp, tea.KeyCtrlP:
+		p.cursor = max(0, p.cursor-1)
+	case tea.KeyDown, tea.KeyCtrlN:
+		p.cursor = min(max(0, len(p.matches)-1), p.cursor+1)
+	case tea.KeyBackspace:
+		if r := []rune(p.query); len(r) > 0 {
+			p.query = string(r[:len(r)-1])
+			p.filter()
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		p.query += string(msg.Runes)
+		p.filter()
+	}
+	return nil, false
+}
+
+// view renders the query and the matching snippets with a one-line
+// preview of each, each line prefixed with indent.
+func (p *snippetPicker) view(styles core.Styles, indent string, width int) string {
+	t := styles.Theme
+	border := lipgloss.NewStyle().Foreground(t.Secondary)
+	muted := lipgloss.NewStyle().Foreground(t.Muted)
+	selected := lipgloss.NewStyle().Foreground(t.Primary).Bold(true)
+
+	lines := []string{border.Render(indent+"┌── Snippets: ") + p.query + "▎"}
+	if len(p.matches) == 0 {
+		lines = append(lines, border.Render(indent+"│ ")+muted.Render("no matching snippets"))
+	}
+	start := max(0, p.cursor-maxPickerRows+1)
+	for i := start; i < len(p.matches) && i < start+maxPickerRows; i++ {
+		s := p.matches[i]
+		marker, name := "  ", s.Name
+		if i == p.cursor {
+			marker, name = "> ", selected.Render(s.Name)
+		}
+		preview := []rune(strings.Join(strings.Fields(s.Body), " "))
+		if room := width - len(indent) - len(s.Name) - 8; room > 3 && len(preview) > room {
+			preview = append(preview[:room-1], '…')
+		}
+		lines = append(lines, border.Render(indent+"│ ")+marker+name+"  "+muted.Render(string(preview)))
+	}
+	lines = append(lines, border.Render(indent+"└── ")+muted.Render("type to filter  ↑/↓ move  Enter insert  Esc close"))
+	return strings.Join(lines, "\n")
+}
+
+// insertSnippet appends an expanded snippet to a body, after a space
+// unless the body is empty or already ends with whitespace.
+func insertSnippet(body string, s domain.Snippet, vars domain.SnippetVars) string {
+	text := vars.Expand(s.Body)
+	if body != "" && !strings.HasSuffix(body, "\n") && !strings.HasSuffix(body, " ") {
+		body += " "
+	}
+	return body + text
+}
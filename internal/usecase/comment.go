@@ -2,6 +2,9 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/indrasvat/vivecaka/internal/domain"
 )
@@ -18,16 +21,37 @@ func NewAddComment(reviewer domain.PRReviewer) *AddComment {
 
 // Execute validates and adds an inline comment.
 func (uc *AddComment) Execute(ctx context.Context, repo domain.RepoRef, number int, input domain.InlineCommentInput) error {
+	if err := validateComment(input); err != nil {
+		return err
+	}
+	return uc.reviewer.AddComment(ctx, repo, number, input)
+}
+
+// validateComment checks the fields every inline comment needs, and that a
+// range runs forward. A reply is placed by its thread, so it needs only a
+// body; threads on outdated lines have none.
+func validateComment(input domain.InlineCommentInput) error {
+	if input.InReplyTo != "" {
+		if input.Body == "" {
+			return &domain.ValidationError{Field: "body", Message: "body is required"}
+		}
+		return nil
+	}
 	if input.Path == "" {
 		return &domain.ValidationError{Field: "path", Message: "path is required"}
 	}
 	if input.Line <= 0 {
 		return &domain.ValidationError{Field: "line", Message: "line must be positive"}
 	}
+	// Lines on different sides number different files, so only a range on
+	// one side has an order to check.
+	if input.StartLine > 0 && input.StartSide == input.Side && input.StartLine > input.Line {
+		return &domain.ValidationError{Field: "start_line", Message: "range must start before it ends"}
+	}
 	if input.Body == "" {
 		return &domain.ValidationError{Field: "body", Message: "body is required"}
 	}
-	return uc.reviewer.AddComment(ctx, repo, number, input)
+	return nil
 }
 
 // ResolveThread resolves a review comment thread.
@@ -47,3 +71,74 @@ func (uc *ResolveThread) Execute(ctx context.Context, repo domain.RepoRef, threa
 	}
 	return uc.reviewer.ResolveThread(ctx, repo, threadID)
 }
+
+// ManageComments posts, edits and deletes PR conversation comments,
+// unresolves threads and toggles reactions.
+type ManageComments struct {
+	comments domain.CommentManager
+}
+
+// NewManageComments creates a new ManageComments use case.
+func NewManageComments(comments domain.CommentManager) *ManageComments {
+	return &ManageComments{comments: comments}
+}
+
+// Post adds a top-level comment to a PR's conversation.
+func (uc *ManageComments) Post(ctx context.Context, repo domain.RepoRef, number int, body string) error {
+	if strings.TrimSpace(body) == "" {
+		return &domain.ValidationError{Field: "body", Message: "body is required"}
+	}
+	return uc.comments.AddPRComment(ctx, repo, number, body)
+}
+
+// Edit replaces the body of a comment.
+func (uc *ManageComments) Edit(ctx context.Context, repo domain.RepoRef, ref domain.CommentRef, body string) error {
+	if err := validateRef(ref); err != nil {
+		return err
+	}
+	if strings.TrimSpace(body) == "" {
+		return &domain.ValidationError{Field: "body", Message: "body is required; delete the comment instead"}
+	}
+	return uc.comments.EditComment(ctx, repo, ref, body)
+}
+
+// Delete deletes a comment.
+func (uc *ManageComments) Delete(ctx context.Context, repo domain.RepoRef, ref domain.CommentRef) error {
+	if err := validateRef(ref); err != nil {
+		return err
+	}
+	return uc.comments.DeleteComment(ctx, repo, ref)
+}
+
+// Unresolve reopens a resolved review thread.
+func (uc *ManageComments) Unresolve(ctx context.Context, repo domain.RepoRef, threadID string) error {
+	if threadID == "" {
+		return &domain.ValidationError{Field: "thread_id", Message: "thread ID is required"}
+	}
+	return uc.comments.UnresolveThread(ctx, repo, threadID)
+}
+
+// ToggleReaction adds the viewer's reaction to comment c, or removes it if
+// the viewer already reacted with content. It reports whether the reaction
+// was added.
+func (uc *ManageComments) ToggleReaction(ctx context.Context, repo domain.RepoRef, kind domain.DiscussionKind, c domain.Comment, content domain.ReactionContent) (added bool, err error) {
+	ref := domain.CommentRef{NodeID: c.NodeID, Kind: kind}
+	if err := validateRef(ref); err != nil {
+		return false, err
+	}
+	if !slices.Contains(domain.ReactionContents, content) {
+		return false, &domain.ValidationError{Field: "content", Message: fmt.Sprintf("unknown reaction %q", content)}
+	}
+	if r, ok := c.Reaction(content); ok && r.ViewerReacted {
+		return false, uc.comments.RemoveReaction(ctx, repo, ref, content)
+	}
+	return true, uc.comments.AddReaction(ctx, repo, ref, content)
+}
+
+// validateRef checks that ref names a comment.
+func validateRef(ref domain.CommentRef) error {
+	if ref.NodeID == "" {
+		return &domain.ValidationError{Field: "node_id", Message: "comment ID is required"}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// prTemplateDirs are where GitHub looks for a repo's PR template, relative
+// to the repo root, in the order it looks.
+var prTemplateDirs = []string{".github", ".", "docs"}
+
+// CreatePR opens pull requests from a branch of a local clone.
+type CreatePR struct {
+	creator domain.PRCreator
+}
+
+// NewCreatePR creates a new CreatePR use case.
+func NewCreatePR(creator domain.PRCreator) *CreatePR {
+	return &CreatePR{creator: creator}
+}
+
+// Bases lists the branches a PR from head can target, the default branch
+// first. head itself is left out.
+func (uc *CreatePR) Bases(ctx context.Context, repo domain.RepoRef, head string) (*domain.RepoBranches, error) {
+	branches, err := uc.creator.ListBranches(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	names := slices.DeleteFunc(slices.Clone(branches.Names), func(n string) bool {
+		return n == head || n == branches.Default
+	})
+	if branches.Default != "" && branches.Default != head {
+		names = append([]string{branches.Default}, names...)
+	}
+	return &domain.RepoBranches{Default: branches.Default, Names: names}, nil
+}
+
+// Compare describes what a PR from head into base would contain.
+func (uc *CreatePR) Compare(ctx context.Context, dir, base, head string) (*domain.BranchComparison, error) {
+	return uc.creator.CompareBranch(ctx, dir, base, head)
+}
+
+// Template returns the PR template of the repo cloned at dir, or "" if it
+// has none. File names are matched case-insensitively, like GitHub does.
+func (uc *CreatePR) Template(dir string) string {
+	for _, sub := range prTemplateDirs {
+		entries, err := os.ReadDir(filepath.Join(dir, sub))
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if e.Type().IsRegular() && strings.EqualFold(e.Name(), "pull_request_template.md") {
+				if data, err := os.ReadFile(filepath.Join(dir, sub, e.Name())); err == nil {
+					return string(data)
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// Execute validates pr, pushes its head branch from the clone at dir when
+// push is set, and opens the PR. It returns the new PR's number, which is
+// set even on error when the backend opened the PR but failed to add its
+// labels or request its reviewers.
+func (uc *CreatePR) Execute(ctx context.Context, repo domain.RepoRef, dir string, pr domain.NewPR, push bool) (int, error) {
+	pr.Title = strings.TrimSpace(pr.Title)
+	switch {
+	case pr.Title == "":
+		return 0, &domain.ValidationError{Field: "title", Message: "title must not be empty"}
+	case pr.Base == "" || pr.Head == "":
+		return 0, &domain.ValidationError{Field: "branch", Message: "base and head branches must be set"}
+	case pr.Base == pr.Head:
+		return 0, &domain.ValidationError{Field: "branch", Message: "base and head must be different branches"}
+	}
+	if push {
+		if err := uc.creator.PushBranch(ctx, dir, pr.Head); err != nil {
+			return 0, err
+		}
+	}
+	return uc.creator.CreatePR(ctx, repo, pr)
+}
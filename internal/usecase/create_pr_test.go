@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// prCreator records pushes and created PRs.
+type prCreator struct {
+	branches *domain.RepoBranches
+	pushed   []string
+	created  []domain.NewPR
+}
+
+func (c *prCreator) ListBranches(context.Context, domain.RepoRef) (*domain.RepoBranches, error) {
+	return c.branches, nil
+}
+
+func (c *prCreator) CompareBranch(_ context.Context, _, base, head string) (*domain.BranchComparison, error) {
+	return &domain.BranchComparison{Base: base, Head: head}, nil
+}
+
+func (c *prCreator) PushBranch(_ context.Context, _, branch string) error {
+	c.pushed = append(c.pushed, branch)
+	return nil
+}
+
+func (c *prCreator) CreatePR(_ context.Context, _ domain.RepoRef, pr domain.NewPR) (int, error) {
+	c.created = append(c.created, pr)
+	return 12, nil
+}
+
+func TestCreatePRBases(t *testing.T) {
+	uc := NewCreatePR(&prCreator{branches: &domain.RepoBranches{Default: "main", Names: []string{"feat/x", "release", "main"}}})
+	bases, err := uc.Bases(context.Background(), testRepo, "feat/x")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"main", "release"}, bases.Names, "default first, head left out")
+}
+
+func TestCreatePRExecute(t *testing.T) {
+	creator := &prCreator{}
+	uc := NewCreatePR(creator)
+	ctx := context.Background()
+
+	number, err := uc.Execute(ctx, testRepo, "/src", domain.NewPR{Base: "main", Head: "feat/x", Title: " Add x "}, true)
+	require.NoError(t, err)
+	assert.Equal(t, 12, number)
+	assert.Equal(t, []string{"feat/x"}, creator.pushed)
+	assert.Equal(t, "Add x", creator.created[0].Title)
+
+	for _, pr := range []domain.NewPR{
+		{Base: "main", Head: "feat/x"},
+		{Base: "main", Head: "main", Title: "x"},
+		{Head: "feat/x", Title: "x"},
+	} {
+		_, err := uc.Execute(ctx, testRepo, "/src", pr, false)
+		var ve *domain.ValidationError
+		require.ErrorAs(t, err, &ve, "%+v", pr)
+	}
+	assert.Len(t, creator.created, 1)
+}
+
+func TestCreatePRTemplate(t *testing.T) {
+	uc := NewCreatePR(&prCreator{})
+	dir := t.TempDir()
+	assert.Empty(t, uc.Template(dir))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "PULL_REQUEST_TEMPLATE.md"), []byte("root"), 0o644))
+	assert.Equal(t, "root", uc.Template(dir))
+
+	require.NoError(t, os.Mkdir(filepath.Join(dir, ".github"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".github", "pull_request_template.md"), []byte("github"), 0o644))
+	assert.Equal(t, "github", uc.Template(dir), ".github wins")
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// EditMetadata edits a PR's labels, assignees and requested reviewers.
+type EditMetadata struct {
+	editor domain.MetadataEditor
+}
+
+// NewEditMetadata creates a new EditMetadata use case.
+func NewEditMetadata(editor domain.MetadataEditor) *EditMetadata {
+	return &EditMetadata{editor: editor}
+}
+
+// Options lists the labels, users and teams a PR in repo can be given.
+func (uc *EditMetadata) Options(ctx context.Context, repo domain.RepoRef) (*domain.MetadataOptions, error) {
+	return uc.editor.GetMetadataOptions(ctx, repo)
+}
+
+// Execute validates and applies an edit. An empty edit is a no-op.
+func (uc *EditMetadata) Execute(ctx context.Context, repo domain.RepoRef, number int, edit domain.MetadataEdit) error {
+	if edit.Empty() {
+		return nil
+	}
+	for _, values := range [][]string{
+		edit.AddLabels, edit.RemoveLabels, edit.AddAssignees,
+		edit.RemoveAssignees, edit.AddReviewers, edit.RemoveReviewers,
+	} {
+		if slices.Contains(values, "") {
+			return &domain.ValidationError{Field: "metadata", Message: "label, assignee and reviewer names must not be empty"}
+		}
+	}
+	return uc.editor.EditMetadata(ctx, repo, number, edit)
+}
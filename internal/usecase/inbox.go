@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"sync"
 
 	"golang.org/x/sync/errgroup"
@@ -27,6 +28,8 @@ func NewGetInboxPRs(reader domain.PRReader) *GetInboxPRs {
 
 // Execute fetches open PRs from all given repos concurrently.
 // Partial failures are tolerated: results from successful repos are returned.
+// A rate limit stops the fan-out, since every further request would be refused
+// too; the results gathered so far are returned along with the error.
 func (uc *GetInboxPRs) Execute(ctx context.Context, repos []domain.RepoRef) ([]InboxPR, error) {
 	if len(repos) == 0 {
 		return nil, nil
@@ -44,6 +47,9 @@ func (uc *GetInboxPRs) Execute(ctx context.Context, repos []domain.RepoRef) ([]I
 				PerPage: 30,
 			}
 			prs, err := uc.reader.ListPRs(ctx, repo, opts)
+			if errors.Is(err, domain.ErrRateLimited) {
+				return err
+			}
 			if err != nil {
 				return nil //nolint:nilerr // tolerate individual repo failures
 			}
@@ -56,8 +62,8 @@ func (uc *GetInboxPRs) Execute(ctx context.Context, repos []domain.RepoRef) ([]I
 		})
 	}
 
-	// errgroup never returns error since we swallow per-repo failures.
-	_ = g.Wait()
+	// Only a rate limit surfaces here; other per-repo failures are swallowed.
+	err := g.Wait()
 
-	return result, nil
+	return result, err
 }
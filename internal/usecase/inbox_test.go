@@ -16,11 +16,15 @@ import (
 type mockInboxReader struct {
 	prsByRepo map[string][]domain.PR
 	failRepos map[string]bool
+	failErr   error
 }
 
 func (m *mockInboxReader) ListPRs(_ context.Context, repo domain.RepoRef, _ domain.ListOpts) ([]domain.PR, error) {
 	key := repo.String()
 	if m.failRepos[key] {
+		if m.failErr != nil {
+			return nil, m.failErr
+		}
 		return nil, errors.New("API error")
 	}
 	return m.prsByRepo[key], nil
@@ -105,6 +109,17 @@ func TestGetInboxPRs_PartialFailure(t *testing.T) {
 	assert.Equal(t, "org/alpha", prs[0].Repo.String())
 }
 
+func TestGetInboxPRs_RateLimitIsReported(t *testing.T) {
+	reader := &mockInboxReader{
+		failRepos: map[string]bool{"org/alpha": true},
+		failErr:   &domain.RateLimitError{Secondary: true},
+	}
+
+	uc := NewGetInboxPRs(reader)
+	_, err := uc.Execute(context.Background(), []domain.RepoRef{{Owner: "org", Name: "alpha"}})
+	require.ErrorIs(t, err, domain.ErrRateLimited)
+}
+
 func TestGetInboxPRs_EmptyRepos(t *testing.T) {
 	uc := NewGetInboxPRs(&mockInboxReader{})
 	prs, err := uc.Execute(context.Background(), nil)
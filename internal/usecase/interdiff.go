@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+	"github.com/indrasvat/vivecaka/internal/reviewprogress"
+)
+
+// GetInterdiff shows what changed in a PR's patch since an earlier head,
+// such as the one last reviewed before a force-push.
+type GetInterdiff struct {
+	reader domain.CommitReader
+}
+
+// NewGetInterdiff creates a new GetInterdiff use case.
+func NewGetInterdiff(reader domain.CommitReader) *GetInterdiff {
+	return &GetInterdiff{reader: reader}
+}
+
+// Execute compares the PR's patch at the baseline head with its patch at
+// the current head. Both are taken against the base branch from where each
+// head forked, so commits the base gained in between are not shown. current
+// is the PR's diff at its head, when already loaded.
+func (uc *GetInterdiff) Execute(
+	ctx context.Context,
+	repo domain.RepoRef,
+	detail *domain.PRDetail,
+	baseline string,
+	current *domain.Diff,
+) (*domain.Diff, error) {
+	if baseline == "" {
+		return nil, &domain.ValidationError{Field: "baseline", Message: "no earlier head to compare with"}
+	}
+	base := detail.Branch.Base
+	if base == "" {
+		return nil, &domain.ValidationError{Field: "base", Message: "the PR's base branch is unknown"}
+	}
+	before, err := uc.reader.CompareCommits(ctx, repo, base, baseline)
+	if err != nil {
+		return nil, fmt.Errorf("loading the patch at %.7s: %w", baseline, err)
+	}
+	if current == nil {
+		if detail.Branch.HeadSHA == "" {
+			return nil, &domain.ValidationError{Field: "head", Message: "the PR's head commit is unknown"}
+		}
+		if current, err = uc.reader.CompareCommits(ctx, repo, base, detail.Branch.HeadSHA); err != nil {
+			return nil, fmt.Errorf("loading the patch at %.7s: %w", detail.Branch.HeadSHA, err)
+		}
+	}
+	return reviewprogress.Interdiff(before, current), nil
+}
@@ -20,3 +20,10 @@ func NewListPRs(reader domain.PRReader) *ListPRs {
 func (uc *ListPRs) Execute(ctx context.Context, repo domain.RepoRef, opts domain.ListOpts) ([]domain.PR, error) {
 	return uc.reader.ListPRs(ctx, repo, opts)
 }
+
+// ExecutePage lists one page of PRs. Readers that paginate with cursors fetch
+// only that page; others are asked for opts.Page, and a full page is taken
+// to mean more may follow.
+func (uc *ListPRs) ExecutePage(ctx context.Context, repo domain.RepoRef, opts domain.ListOpts) (domain.PRPage, error) {
+	return domain.ListPage(ctx, uc.reader, repo, opts)
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// allMergeMethods is what a writer that cannot report the repo's merge
+// settings is assumed to allow.
+var allMergeMethods = []string{domain.MergeMethodMerge, domain.MergeMethodSquash, domain.MergeMethodRebase}
+
+// MergePR merges pull requests and reports whether they can be merged.
+type MergePR struct {
+	writer domain.PRWriter
+	status domain.MergeStatusReader // nil when the writer cannot report merge status
+}
+
+// NewMergePR creates a new MergePR use case.
+func NewMergePR(writer domain.PRWriter) *MergePR {
+	uc := &MergePR{writer: writer}
+	uc.status, _ = writer.(domain.MergeStatusReader)
+	return uc
+}
+
+// Status reports whether pr can be merged. For writers that cannot report
+// it, including routers whose backend for repo cannot, the status is derived
+// from pr alone: mergeability and required checks are unknown and the host
+// decides when the merge is attempted.
+func (uc *MergePR) Status(ctx context.Context, repo domain.RepoRef, pr domain.PR) (*domain.MergeStatus, error) {
+	if uc.status != nil {
+		status, err := uc.status.GetMergeStatus(ctx, repo, pr.Number)
+		if !errors.Is(err, errors.ErrUnsupported) {
+			return status, err
+		}
+	}
+	return &domain.MergeStatus{
+		State:     pr.State,
+		Draft:     pr.Draft,
+		Mergeable: domain.MergeableUnknown,
+		Review:    pr.Review.State,
+		Methods:   allMergeMethods,
+	}, nil
+}
+
+// Execute validates and merges the PR, or enables auto-merge when
+// opts.Auto is set.
+func (uc *MergePR) Execute(ctx context.Context, repo domain.RepoRef, number int, opts domain.MergeOpts) error {
+	switch opts.Method {
+	case domain.MergeMethodMerge, domain.MergeMethodSquash, domain.MergeMethodRebase:
+	default:
+		return &domain.ValidationError{
+			Field:   "method",
+			Message: fmt.Sprintf("invalid merge method: %q", opts.Method),
+		}
+	}
+	return uc.writer.Merge(ctx, repo, number, opts)
+}
@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// PRCommits lists a PR's commits and diffs single commits or ranges of
+// them.
+type PRCommits struct {
+	reader domain.CommitReader
+}
+
+// NewPRCommits creates a new PRCommits use case.
+func NewPRCommits(reader domain.CommitReader) *PRCommits {
+	return &PRCommits{reader: reader}
+}
+
+// List returns the PR's commits, oldest first.
+func (uc *PRCommits) List(ctx context.Context, repo domain.RepoRef, number int) ([]domain.Commit, error) {
+	return uc.reader.GetCommits(ctx, repo, number)
+}
+
+// Diff returns the combined changes of a run of consecutive commits,
+// oldest first: from the parent of the first to the last.
+func (uc *PRCommits) Diff(ctx context.Context, repo domain.RepoRef, commits []domain.Commit) (*domain.Diff, error) {
+	if len(commits) == 0 {
+		return nil, &domain.ValidationError{Field: "commits", Message: "no commits selected"}
+	}
+	first, last := commits[0], commits[len(commits)-1]
+	if first.Parent == "" {
+		return nil, &domain.ValidationError{Field: "commits", Message: "commit " + first.ShortSHA() + " has no parent to compare with"}
+	}
+	return uc.reader.CompareCommits(ctx, repo, first.Parent, last.SHA)
+}
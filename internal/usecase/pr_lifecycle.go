@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/indrasvat/vivecaka/internal/domain"
+)
+
+// PRLifecycle closes, reopens, drafts and updates the branch of PRs.
+type PRLifecycle struct {
+	manager domain.PRLifecycleManager
+}
+
+// NewPRLifecycle creates a new PRLifecycle use case.
+func NewPRLifecycle(manager domain.PRLifecycleManager) *PRLifecycle {
+	return &PRLifecycle{manager: manager}
+}
+
+// Close closes the PR. A blank comment is not posted.
+func (uc *PRLifecycle) Close(ctx context.Context, repo domain.RepoRef, number int, comment string) error {
+	return uc.manager.ClosePR(ctx, repo, number, strings.TrimSpace(comment))
+}
+
+// Reopen reopens the PR. A blank comment is not posted.
+func (uc *PRLifecycle) Reopen(ctx context.Context, repo domain.RepoRef, number int, comment string) error {
+	return uc.manager.ReopenPR(ctx, repo, number, strings.TrimSpace(comment))
+}
+
+// SetDraft converts the PR to a draft, or marks it ready for review.
+func (uc *PRLifecycle) SetDraft(ctx context.Context, repo domain.RepoRef, number int, draft bool) error {
+	return uc.manager.SetDraft(ctx, repo, number, draft)
+}
+
+// UpdateBranch validates method and brings the PR's head up to date with
+// its base by merging or rebasing.
+func (uc *PRLifecycle) UpdateBranch(ctx context.Context, repo domain.RepoRef, number int, method string) error {
+	switch method {
+	case domain.MergeMethodMerge, domain.MergeMethodRebase:
+	default:
+		return &domain.ValidationError{
+			Field:   "method",
+			Message: fmt.Sprintf("invalid update method: %q", method),
+		}
+	}
+	return uc.manager.UpdateBranch(ctx, repo, number, method)
+}
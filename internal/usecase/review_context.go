@@ -38,6 +38,6 @@ func (uc *GetReviewContext) Execute(
 		}
 	}
 
-	context := reviewprogress.Build(detail, digests, state, degraded || err != nil)
+	context := reviewprogress.Build(detail, digests, reviewprogress.HunkDigestsFromDiff(diff), state, degraded || err != nil)
 	return context, diff, nil
 }
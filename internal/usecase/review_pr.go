@@ -36,5 +36,14 @@ func (uc *ReviewPR) Execute(ctx context.Context, repo domain.RepoRef, number int
 		}
 	}
 
+	for _, c := range review.Comments {
+		if c.InReplyTo != "" {
+			return &domain.ValidationError{Field: "comments", Message: "replies cannot be part of a review"}
+		}
+		if err := validateComment(c); err != nil {
+			return err
+		}
+	}
+
 	return uc.reviewer.SubmitReview(ctx, repo, number, review)
 }
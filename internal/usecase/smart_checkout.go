@@ -103,7 +103,7 @@ func (uc *SmartCheckout) ExecuteWorktree(ctx context.Context, repo domain.RepoRe
 	wtDir := fmt.Sprintf("pr-%d-%s", number, safeBranch)
 	wtPath := filepath.Join(basePath, ".worktrees", wtDir)
 
-	if err := uc.repoMgr.CreateWorktree(ctx, basePath, number, branch, wtPath); err != nil {
+	if err := uc.repoMgr.CreateWorktree(ctx, repo, basePath, number, branch, wtPath); err != nil {
 		return "", fmt.Errorf("worktree: %w", err)
 	}
 	return wtPath, nil
@@ -117,7 +117,7 @@ func (uc *SmartCheckout) WorktreePath(number int, branch, basePath string) strin
 }
 
 func reposMatch(a, b domain.RepoRef) bool {
-	return strings.EqualFold(a.Owner, b.Owner) && strings.EqualFold(a.Name, b.Name)
+	return a.Equal(b)
 }
 
 func sanitizeBranchName(branch string) string {
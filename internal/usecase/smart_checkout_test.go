@@ -58,7 +58,7 @@ func (m *mockRepoManager) CloneRepo(_ context.Context, repo domain.RepoRef, targ
 	return m.cloneRepoErr
 }
 
-func (m *mockRepoManager) CreateWorktree(_ context.Context, repoPath string, number int, branch, worktreePath string) error {
+func (m *mockRepoManager) CreateWorktree(_ context.Context, _ domain.RepoRef, repoPath string, number int, branch, worktreePath string) error {
 	m.createWorktreeCalls = append(m.createWorktreeCalls, createWorktreeCall{
 		RepoPath: repoPath, Number: number, Branch: branch, WorktreePath: worktreePath,
 	})
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -68,15 +69,14 @@ func (m *mockReviewer) ResolveThread(_ context.Context, _ domain.RepoRef, _ stri
 type mockWriter struct {
 	branch string
 	err    error
+	merged []domain.MergeOpts
 }
 
 func (m *mockWriter) Checkout(_ context.Context, _ domain.RepoRef, _ int) (string, error) {
 	return m.branch, m.err
 }
-func (m *mockWriter) Merge(_ context.Context, _ domain.RepoRef, _ int, _ domain.MergeOpts) error {
-	return m.err
-}
-func (m *mockWriter) UpdateLabels(_ context.Context, _ domain.RepoRef, _ int, _ []string) error {
+func (m *mockWriter) Merge(_ context.Context, _ domain.RepoRef, _ int, opts domain.MergeOpts) error {
+	m.merged = append(m.merged, opts)
 	return m.err
 }
 
@@ -105,6 +105,44 @@ func TestListPRsExecuteError(t *testing.T) {
 	require.Error(t, err)
 }
 
+// pagerReader is a reader that also paginates with cursors.
+type pagerReader struct {
+	mockReader
+	opts domain.ListOpts
+	page domain.PRPage
+}
+
+func (m *pagerReader) ListPRPage(_ context.Context, _ domain.RepoRef, opts domain.ListOpts) (domain.PRPage, error) {
+	m.opts = opts
+	return m.page, m.err
+}
+
+func TestListPRsExecutePageUsesCursor(t *testing.T) {
+	reader := &pagerReader{page: domain.PRPage{PRs: []domain.PR{{Number: 3}}, NextCursor: "c2", HasMore: true}}
+	uc := NewListPRs(reader)
+
+	got, err := uc.ExecutePage(context.Background(), testRepo, domain.ListOpts{Page: 2, PerPage: 1, Cursor: "c1"})
+	require.NoError(t, err)
+	assert.Equal(t, "c1", reader.opts.Cursor)
+	assert.Equal(t, "c2", got.NextCursor)
+	assert.True(t, got.HasMore)
+}
+
+func TestListPRsExecutePageFallsBackToPageNumbers(t *testing.T) {
+	reader := &mockReader{prs: []domain.PR{{Number: 1}, {Number: 2}}}
+	uc := NewListPRs(reader)
+
+	got, err := uc.ExecutePage(context.Background(), testRepo, domain.ListOpts{Page: 2, PerPage: 2})
+	require.NoError(t, err)
+	assert.Len(t, got.PRs, 2)
+	assert.True(t, got.HasMore, "a full page may have more after it")
+	assert.Empty(t, got.NextCursor)
+
+	got, err = uc.ExecutePage(context.Background(), testRepo, domain.ListOpts{Page: 2, PerPage: 5})
+	require.NoError(t, err)
+	assert.False(t, got.HasMore)
+}
+
 // --- GetPRDetail tests ---
 
 func TestGetPRDetailExecute(t *testing.T) {
@@ -241,6 +279,32 @@ func TestReviewPRRequestChangesRequiresBody(t *testing.T) {
 	require.Error(t, err, "Execute() should require body for request_changes")
 }
 
+func TestReviewPRValidatesComments(t *testing.T) {
+	reviewer := &mockReviewer{}
+	uc := NewReviewPR(reviewer)
+
+	err := uc.Execute(context.Background(), testRepo, 42, domain.Review{
+		Action:   domain.ReviewActionComment,
+		Comments: []domain.InlineCommentInput{{Path: "main.go", Line: 3, Body: "nit"}},
+	})
+	require.NoError(t, err)
+
+	var ve *domain.ValidationError
+	err = uc.Execute(context.Background(), testRepo, 42, domain.Review{
+		Action:   domain.ReviewActionComment,
+		Comments: []domain.InlineCommentInput{{Path: "main.go", Body: "nit"}},
+	})
+	require.ErrorAs(t, err, &ve)
+	assert.Equal(t, "line", ve.Field)
+
+	err = uc.Execute(context.Background(), testRepo, 42, domain.Review{
+		Action:   domain.ReviewActionComment,
+		Comments: []domain.InlineCommentInput{{Path: "main.go", Line: 3, Body: "+1", InReplyTo: "9"}},
+	})
+	require.ErrorAs(t, err, &ve)
+	assert.Equal(t, "comments", ve.Field)
+}
+
 // --- CheckoutPR tests ---
 
 func TestCheckoutPRExecute(t *testing.T) {
@@ -260,6 +324,137 @@ func TestCheckoutPRError(t *testing.T) {
 	require.Error(t, err)
 }
 
+// --- MergePR tests ---
+
+// statusWriter is a writer that reports merge status.
+type statusWriter struct {
+	mockWriter
+	status *domain.MergeStatus
+}
+
+func (m *statusWriter) GetMergeStatus(_ context.Context, _ domain.RepoRef, _ int) (*domain.MergeStatus, error) {
+	return m.status, m.err
+}
+
+func TestMergePRExecute(t *testing.T) {
+	writer := &mockWriter{}
+	uc := NewMergePR(writer)
+
+	opts := domain.MergeOpts{Method: domain.MergeMethodSquash, CommitTitle: "Add x (#42)"}
+	require.NoError(t, uc.Execute(context.Background(), testRepo, 42, opts))
+	assert.Equal(t, []domain.MergeOpts{opts}, writer.merged)
+
+	err := uc.Execute(context.Background(), testRepo, 42, domain.MergeOpts{Method: "octopus"})
+	var ve *domain.ValidationError
+	require.ErrorAs(t, err, &ve)
+	assert.Equal(t, "method", ve.Field)
+	assert.Len(t, writer.merged, 1)
+}
+
+func TestMergePRStatus(t *testing.T) {
+	pr := domain.PR{Number: 42, State: domain.PRStateOpen, Draft: true, Review: domain.ReviewStatus{State: domain.ReviewApproved}}
+
+	// Without a status reader the status comes from the PR itself.
+	status, err := NewMergePR(&mockWriter{}).Status(context.Background(), testRepo, pr)
+	require.NoError(t, err)
+	assert.Equal(t, domain.MergeableUnknown, status.Mergeable)
+	assert.True(t, status.Draft)
+	assert.Equal(t, domain.ReviewApproved, status.Review)
+	assert.Len(t, status.Methods, 3)
+
+	reported := &domain.MergeStatus{Mergeable: domain.MergeableConflicting}
+	status, err = NewMergePR(&statusWriter{status: reported}).Status(context.Background(), testRepo, pr)
+	require.NoError(t, err)
+	assert.Same(t, reported, status)
+
+	// A router whose backend for the repo cannot report it falls back too.
+	unsupported := &statusWriter{mockWriter: mockWriter{err: fmt.Errorf("GetMergeStatus: %w", errors.ErrUnsupported)}}
+	status, err = NewMergePR(unsupported).Status(context.Background(), testRepo, pr)
+	require.NoError(t, err)
+	assert.Equal(t, domain.MergeableUnknown, status.Mergeable)
+}
+
+// metadataEditor records metadata edits.
+type metadataEditor struct {
+	edits []domain.MetadataEdit
+}
+
+func (m *metadataEditor) GetMetadataOptions(context.Context, domain.RepoRef) (*domain.MetadataOptions, error) {
+	return &domain.MetadataOptions{Users: []string{"alice"}}, nil
+}
+
+func (m *metadataEditor) EditMetadata(_ context.Context, _ domain.RepoRef, _ int, edit domain.MetadataEdit) error {
+	m.edits = append(m.edits, edit)
+	return nil
+}
+
+func TestEditMetadataExecute(t *testing.T) {
+	editor := &metadataEditor{}
+	uc := NewEditMetadata(editor)
+
+	require.NoError(t, uc.Execute(context.Background(), testRepo, 42, domain.MetadataEdit{}))
+	assert.Empty(t, editor.edits, "empty edits are not sent")
+
+	edit := domain.MetadataEdit{AddLabels: []string{"bug"}, RemoveReviewers: []string{"org/core"}}
+	require.NoError(t, uc.Execute(context.Background(), testRepo, 42, edit))
+	assert.Equal(t, []domain.MetadataEdit{edit}, editor.edits)
+
+	err := uc.Execute(context.Background(), testRepo, 42, domain.MetadataEdit{AddAssignees: []string{""}})
+	var ve *domain.ValidationError
+	require.ErrorAs(t, err, &ve)
+	assert.Len(t, editor.edits, 1)
+
+	opts, err := uc.Options(context.Background(), testRepo)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice"}, opts.Users)
+}
+
+// lifecycleManager records PR state changes.
+type lifecycleManager struct {
+	calls []string
+}
+
+func (m *lifecycleManager) ClosePR(_ context.Context, _ domain.RepoRef, number int, comment string) error {
+	m.calls = append(m.calls, fmt.Sprintf("close %d %q", number, comment))
+	return nil
+}
+
+func (m *lifecycleManager) ReopenPR(_ context.Context, _ domain.RepoRef, number int, comment string) error {
+	m.calls = append(m.calls, fmt.Sprintf("reopen %d %q", number, comment))
+	return nil
+}
+
+func (m *lifecycleManager) SetDraft(_ context.Context, _ domain.RepoRef, number int, draft bool) error {
+	m.calls = append(m.calls, fmt.Sprintf("draft %d %v", number, draft))
+	return nil
+}
+
+func (m *lifecycleManager) UpdateBranch(_ context.Context, _ domain.RepoRef, number int, method string) error {
+	m.calls = append(m.calls, fmt.Sprintf("update %d %s", number, method))
+	return nil
+}
+
+func TestPRLifecycle(t *testing.T) {
+	manager := &lifecycleManager{}
+	uc := NewPRLifecycle(manager)
+	ctx := context.Background()
+
+	require.NoError(t, uc.Close(ctx, testRepo, 42, "  superseded  "))
+	require.NoError(t, uc.Reopen(ctx, testRepo, 42, "\n"))
+	require.NoError(t, uc.SetDraft(ctx, testRepo, 42, true))
+	require.NoError(t, uc.UpdateBranch(ctx, testRepo, 42, domain.MergeMethodRebase))
+
+	err := uc.UpdateBranch(ctx, testRepo, 42, domain.MergeMethodSquash)
+	var ve *domain.ValidationError
+	require.ErrorAs(t, err, &ve)
+	assert.Equal(t, []string{
+		`close 42 "superseded"`,
+		`reopen 42 ""`,
+		"draft 42 true",
+		"update 42 rebase",
+	}, manager.calls)
+}
+
 // --- AddComment tests ---
 
 func TestAddCommentExecute(t *testing.T) {
@@ -307,6 +502,30 @@ func TestAddCommentInvalidLine(t *testing.T) {
 	require.Error(t, err, "Execute() should require positive line number")
 }
 
+func TestAddCommentRangeOrder(t *testing.T) {
+	uc := NewAddComment(&mockReviewer{})
+	ctx := context.Background()
+
+	err := uc.Execute(ctx, testRepo, 42, domain.InlineCommentInput{Path: "main.go", StartLine: 9, StartSide: "RIGHT", Line: 3, Side: "RIGHT", Body: "x"})
+	var ve *domain.ValidationError
+	require.ErrorAs(t, err, &ve)
+	assert.Equal(t, "start_line", ve.Field)
+
+	require.NoError(t, uc.Execute(ctx, testRepo, 42, domain.InlineCommentInput{Path: "main.go", StartLine: 3, StartSide: "RIGHT", Line: 9, Side: "RIGHT", Body: "x"}))
+	require.NoError(t, uc.Execute(ctx, testRepo, 42, domain.InlineCommentInput{Path: "main.go", StartLine: 9, StartSide: "LEFT", Line: 3, Side: "RIGHT", Body: "x"}),
+		"sides number lines independently")
+}
+
+func TestAddCommentReplyNeedsOnlyBody(t *testing.T) {
+	uc := NewAddComment(&mockReviewer{})
+
+	require.NoError(t, uc.Execute(context.Background(), testRepo, 42, domain.InlineCommentInput{
+		InReplyTo: "1001",
+		Body:      "outdated, but still relevant",
+	}))
+	require.Error(t, uc.Execute(context.Background(), testRepo, 42, domain.InlineCommentInput{InReplyTo: "1001"}))
+}
+
 // --- ResolveThread tests ---
 
 func TestResolveThreadExecute(t *testing.T) {
@@ -324,8 +543,151 @@ func TestResolveThreadEmptyID(t *testing.T) {
 	require.Error(t, err, "Execute() should require thread ID")
 }
 
+// --- ManageComments tests ---
+
+type mockCommentManager struct {
+	calls []string
+	err   error
+}
+
+func (m *mockCommentManager) record(call string) error {
+	m.calls = append(m.calls, call)
+	return m.err
+}
+func (m *mockCommentManager) AddPRComment(_ context.Context, _ domain.RepoRef, number int, body string) error {
+	return m.record(fmt.Sprintf("add #%d %s", number, body))
+}
+func (m *mockCommentManager) EditComment(_ context.Context, _ domain.RepoRef, ref domain.CommentRef, body string) error {
+	return m.record(fmt.Sprintf("edit %s %s %s", ref.Kind, ref.NodeID, body))
+}
+func (m *mockCommentManager) DeleteComment(_ context.Context, _ domain.RepoRef, ref domain.CommentRef) error {
+	return m.record(fmt.Sprintf("delete %s %s", ref.Kind, ref.NodeID))
+}
+func (m *mockCommentManager) UnresolveThread(_ context.Context, _ domain.RepoRef, threadID string) error {
+	return m.record("unresolve " + threadID)
+}
+func (m *mockCommentManager) AddReaction(_ context.Context, _ domain.RepoRef, ref domain.CommentRef, content domain.ReactionContent) error {
+	return m.record(fmt.Sprintf("react %s %s", ref.NodeID, content))
+}
+func (m *mockCommentManager) RemoveReaction(_ context.Context, _ domain.RepoRef, ref domain.CommentRef, content domain.ReactionContent) error {
+	return m.record(fmt.Sprintf("unreact %s %s", ref.NodeID, content))
+}
+
+func TestManageCommentsValidates(t *testing.T) {
+	cm := &mockCommentManager{}
+	uc := NewManageComments(cm)
+	ctx := context.Background()
+	ref := domain.CommentRef{NodeID: "IC_1", Kind: domain.DiscussionComment}
+
+	require.Error(t, uc.Post(ctx, testRepo, 42, "  \n"))
+	require.Error(t, uc.Edit(ctx, testRepo, ref, ""), "an emptied comment is deleted, not edited")
+	require.Error(t, uc.Edit(ctx, testRepo, domain.CommentRef{}, "body"))
+	require.Error(t, uc.Delete(ctx, testRepo, domain.CommentRef{}))
+	require.Error(t, uc.Unresolve(ctx, testRepo, ""))
+	_, err := uc.ToggleReaction(ctx, testRepo, domain.DiscussionComment, domain.Comment{NodeID: "IC_1"}, "SHRUG")
+	require.Error(t, err)
+	assert.Empty(t, cm.calls)
+
+	require.NoError(t, uc.Post(ctx, testRepo, 42, "ship it"))
+	require.NoError(t, uc.Edit(ctx, testRepo, ref, "ship it!"))
+	require.NoError(t, uc.Delete(ctx, testRepo, ref))
+	require.NoError(t, uc.Unresolve(ctx, testRepo, "PRRT_1"))
+	assert.Equal(t, []string{
+		"add #42 ship it",
+		"edit comment IC_1 ship it!",
+		"delete comment IC_1",
+		"unresolve PRRT_1",
+	}, cm.calls)
+}
+
+func TestManageCommentsToggleReaction(t *testing.T) {
+	cm := &mockCommentManager{}
+	uc := NewManageComments(cm)
+	c := domain.Comment{NodeID: "PRRC_1", Reactions: []domain.Reaction{
+		{Content: domain.ReactionHeart, Count: 2, ViewerReacted: true},
+		{Content: domain.ReactionThumbsUp, Count: 1},
+	}}
+
+	added, err := uc.ToggleReaction(context.Background(), testRepo, domain.DiscussionInlineThread, c, domain.ReactionHeart)
+	require.NoError(t, err)
+	assert.False(t, added)
+	added, err = uc.ToggleReaction(context.Background(), testRepo, domain.DiscussionInlineThread, c, domain.ReactionThumbsUp)
+	require.NoError(t, err)
+	assert.True(t, added)
+	assert.Equal(t, []string{"unreact PRRC_1 HEART", "react PRRC_1 THUMBS_UP"}, cm.calls)
+}
+
 // Verify the use case doesn't import any TUI-specific packages.
 // This is a compile-time guarantee: if someone adds a bubbletea import to
 // the usecase package, these tests will fail to compile without that dep.
 // The absence of tea imports is the verification.
 var _ = time.Now // Use time to avoid unused import lint on test helper time.
+
+type commitReader struct {
+	commits       []domain.Commit
+	compared      [2]string
+	compareResult *domain.Diff
+}
+
+func (m *commitReader) GetCommits(context.Context, domain.RepoRef, int) ([]domain.Commit, error) {
+	return m.commits, nil
+}
+
+func (m *commitReader) CompareCommits(_ context.Context, _ domain.RepoRef, base, head string) (*domain.Diff, error) {
+	m.compared = [2]string{base, head}
+	return m.compareResult, nil
+}
+
+func TestPRCommitsDiff(t *testing.T) {
+	repo := domain.RepoRef{Owner: "o", Name: "r"}
+	cr := &commitReader{compareResult: &domain.Diff{}}
+	uc := NewPRCommits(cr)
+	commits := []domain.Commit{
+		{SHA: "bbb", Parent: "aaa"},
+		{SHA: "ccc", Parent: "bbb"},
+		{SHA: "ddd", Parent: "ccc"},
+	}
+
+	_, err := uc.Diff(context.Background(), repo, commits[1:2])
+	require.NoError(t, err)
+	assert.Equal(t, [2]string{"bbb", "ccc"}, cr.compared, "one commit diffs against its parent")
+
+	_, err = uc.Diff(context.Background(), repo, commits)
+	require.NoError(t, err)
+	assert.Equal(t, [2]string{"aaa", "ddd"}, cr.compared, "a range spans the first parent to the last commit")
+
+	_, err = uc.Diff(context.Background(), repo, nil)
+	var ve *domain.ValidationError
+	require.ErrorAs(t, err, &ve)
+
+	_, err = uc.Diff(context.Background(), repo, []domain.Commit{{SHA: "root"}})
+	require.ErrorAs(t, err, &ve)
+}
+
+func TestGetInterdiffComparesPatchesAgainstBase(t *testing.T) {
+	repo := domain.RepoRef{Owner: "o", Name: "r"}
+	cr := &commitReader{compareResult: &domain.Diff{Files: []domain.FileDiff{{
+		Path:  "a.go",
+		Hunks: []domain.Hunk{{Lines: []domain.DiffLine{{Type: domain.DiffAdd, Content: "old", NewNum: 1}}}},
+	}}}}
+	detail := &domain.PRDetail{PR: domain.PR{Branch: domain.BranchInfo{Base: "main", HeadSHA: "new-head"}}}
+	current := &domain.Diff{Files: []domain.FileDiff{{
+		Path:  "a.go",
+		Hunks: []domain.Hunk{{Lines: []domain.DiffLine{{Type: domain.DiffAdd, Content: "new", NewNum: 1}}}},
+	}}}
+
+	diff, err := NewGetInterdiff(cr).Execute(context.Background(), repo, detail, "old-head", current)
+	require.NoError(t, err)
+	assert.Equal(t, [2]string{"main", "old-head"}, cr.compared)
+	require.Len(t, diff.Files, 1)
+	assert.Equal(t, "+old", diff.Files[0].Hunks[0].Lines[0].Content)
+	assert.Equal(t, "+new", diff.Files[0].Hunks[0].Lines[1].Content)
+
+	_, err = NewGetInterdiff(cr).Execute(context.Background(), repo, detail, "old-head", nil)
+	require.NoError(t, err)
+	assert.Equal(t, [2]string{"main", "new-head"}, cr.compared, "the current patch is fetched when not loaded")
+
+	_, err = NewGetInterdiff(cr).Execute(context.Background(), repo, detail, "", current)
+	var ve *domain.ValidationError
+	require.ErrorAs(t, err, &ve)
+}